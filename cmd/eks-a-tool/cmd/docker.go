@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var dockerCmd = &cobra.Command{
+	Use:   "docker",
+	Short: "Docker commands",
+	Long:  "Use eks-a-tool docker to run docker provider utilities",
+}
+
+func init() {
+	rootCmd.AddCommand(dockerCmd)
+}
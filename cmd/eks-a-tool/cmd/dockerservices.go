@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/internal/pkg/docker/localservices"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+)
+
+const (
+	servicesNameFlag = "name"
+	servicesDirFlag  = "dir"
+)
+
+var dockerServicesCmd = &cobra.Command{
+	Use:   "services",
+	Short: "Local registry mirror and proxy commands",
+	Long: `Use eks-a-tool docker services to run an authenticated registry mirror and a proxy for Docker clusters,
+to test cluster configs with registryMirrorConfiguration and proxyConfiguration locally.`,
+}
+
+func init() {
+	dockerCmd.AddCommand(dockerServicesCmd)
+}
+
+func servicesFlags(cmd *cobra.Command) {
+	cmd.Flags().String(servicesNameFlag, "eksa-local", "Name of the services, used as prefix for their containers")
+	cmd.Flags().String(servicesDirFlag, "", "Directory for the registry mirror configuration (default: the services name)")
+}
+
+func newServices(config localservices.Config) (*localservices.Services, error) {
+	dir := viper.GetString(servicesDirFlag)
+	if dir == "" {
+		dir = config.Name
+	}
+	writer, err := filewriter.NewWriter(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return localservices.New(executables.BuildDockerExecutable(), writer, config), nil
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/internal/pkg/docker/localservices"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	servicesNetworkFlag          = "network"
+	servicesRegistryFlag         = "registry-mirror"
+	servicesProxyFlag            = "proxy"
+	servicesRegistryImageFlag    = "registry-image"
+	servicesRegistryPortFlag     = "registry-port"
+	servicesRegistryUpstreamFlag = "registry-upstream"
+	servicesRegistryUsernameFlag = "registry-username"
+	servicesRegistryPasswordFlag = "registry-password"
+	servicesProxyImageFlag       = "proxy-image"
+	servicesOutputFlag           = "output"
+)
+
+var dockerServicesCreateCmd = &cobra.Command{
+	Use:    "create",
+	PreRun: prerunCmdBindFlags,
+	Short:  "Create a local registry mirror and proxy",
+	Long: `This command runs a registry mirror with TLS and basic auth and a squid proxy in the host network, listening
+on the gateway of the docker network of the cluster nodes, and writes the registryMirrorConfiguration and
+proxyConfiguration to add to the Cluster spec to use them.
+By default the registry mirror is a pull through cache of public.ecr.aws, so clusters can be created without
+importing the EKS Anywhere images first.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config := localservices.Config{
+			Name:             viper.GetString(servicesNameFlag),
+			Network:          viper.GetString(servicesNetworkFlag),
+			Registry:         viper.GetBool(servicesRegistryFlag),
+			Proxy:            viper.GetBool(servicesProxyFlag),
+			RegistryImage:    viper.GetString(servicesRegistryImageFlag),
+			RegistryPort:     viper.GetInt(servicesRegistryPortFlag),
+			RegistryUpstream: viper.GetString(servicesRegistryUpstreamFlag),
+			RegistryUsername: viper.GetString(servicesRegistryUsernameFlag),
+			RegistryPassword: viper.GetString(servicesRegistryPasswordFlag),
+			ProxyImage:       viper.GetString(servicesProxyImageFlag),
+		}
+		if config.Registry && config.RegistryPassword == "" {
+			return fmt.Errorf("--%s is required for the registry mirror", servicesRegistryPasswordFlag)
+		}
+
+		s, err := newServices(config)
+		if err != nil {
+			return err
+		}
+
+		endpoints, err := s.Create(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("creating local services %s: %v", config.Name, err)
+		}
+
+		content, err := yaml.Marshal(endpoints)
+		if err != nil {
+			return fmt.Errorf("marshalling local services configuration: %v", err)
+		}
+		output := viper.GetString(servicesOutputFlag)
+		if err := os.WriteFile(output, content, 0o644); err != nil {
+			return fmt.Errorf("writing local services configuration: %v", err)
+		}
+
+		logger.Info("Local services created", "name", config.Name, "clusterConfig", output)
+		if config.Registry {
+			logger.Info(fmt.Sprintf("Set %s and %s to the registry mirror credentials to create clusters using it", constants.RegistryUsername, constants.RegistryPassword))
+		}
+		return nil
+	},
+}
+
+func init() {
+	dockerServicesCmd.AddCommand(dockerServicesCreateCmd)
+
+	servicesFlags(dockerServicesCreateCmd)
+	dockerServicesCreateCmd.Flags().String(servicesNetworkFlag, localservices.DefaultNetwork, "Docker network of the cluster nodes, created if it doesn't exist")
+	dockerServicesCreateCmd.Flags().Bool(servicesRegistryFlag, true, "Run a registry mirror")
+	dockerServicesCreateCmd.Flags().Bool(servicesProxyFlag, true, "Run a proxy")
+	dockerServicesCreateCmd.Flags().String(servicesRegistryImageFlag, localservices.DefaultRegistryImage, "Image of the registry mirror")
+	dockerServicesCreateCmd.Flags().Int(servicesRegistryPortFlag, 5000, "Port of the registry mirror")
+	dockerServicesCreateCmd.Flags().String(servicesRegistryUpstreamFlag, "https://"+constants.DefaultCoreEKSARegistry, "Registry the mirror pulls through, empty to only serve imported images")
+	dockerServicesCreateCmd.Flags().String(servicesRegistryUsernameFlag, "admin", "Username of the registry mirror")
+	dockerServicesCreateCmd.Flags().String(servicesRegistryPasswordFlag, "", "Password of the registry mirror, required with --registry-mirror")
+	dockerServicesCreateCmd.Flags().String(servicesProxyImageFlag, localservices.DefaultProxyImage, "Image of the squid proxy")
+	dockerServicesCreateCmd.Flags().String(servicesOutputFlag, "local-services.yaml", "Path of the Cluster spec fields written for the services")
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/internal/pkg/docker/localservices"
+)
+
+var dockerServicesDeleteCmd = &cobra.Command{
+	Use:    "delete",
+	PreRun: prerunCmdBindFlags,
+	Short:  "Delete a local registry mirror and proxy",
+	Long:   "This command deletes the containers and the registry mirror configuration of local services",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := viper.GetString(servicesNameFlag)
+		s, err := newServices(localservices.Config{Name: name})
+		if err != nil {
+			return err
+		}
+
+		if err := s.Delete(cmd.Context()); err != nil {
+			return fmt.Errorf("deleting local services %s: %v", name, err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	dockerServicesCmd.AddCommand(dockerServicesDeleteCmd)
+
+	servicesFlags(dockerServicesDeleteCmd)
+}
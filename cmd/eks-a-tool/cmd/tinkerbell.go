@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var tinkerbellCmd = &cobra.Command{
+	Use:   "tinkerbell",
+	Short: "Tinkerbell commands",
+	Long:  "Use eks-a-tool tinkerbell to run tinkerbell utilities",
+}
+
+func init() {
+	rootCmd.AddCommand(tinkerbellCmd)
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/internal/pkg/tinkerbell/lab"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+)
+
+const (
+	labNameFlag = "name"
+	labDirFlag  = "dir"
+)
+
+var tinkerbellLabCmd = &cobra.Command{
+	Use:   "lab",
+	Short: "Tinkerbell virtual lab commands",
+	Long: `Use eks-a-tool tinkerbell lab to run Tinkerbell hardware as local libvirt VMs with virtual Redfish BMCs.
+It requires a Linux host with KVM, libvirt and docker.`,
+}
+
+func init() {
+	tinkerbellCmd.AddCommand(tinkerbellLabCmd)
+}
+
+func labFlags(cmd *cobra.Command) {
+	cmd.Flags().String(labNameFlag, "eksa-lab", "Name of the lab, used as prefix for its network, VMs and BMC containers")
+	cmd.Flags().String(labDirFlag, "", "Directory for the BMC configuration of the lab (default: the lab name)")
+}
+
+func newLab(config lab.Config) (*lab.Lab, error) {
+	dir := viper.GetString(labDirFlag)
+	if dir == "" {
+		dir = config.Name
+	}
+	writer, err := filewriter.NewWriter(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return lab.New(
+		executables.BuildVirshExecutable(lab.LibvirtURI),
+		executables.BuildDockerExecutable(),
+		writer,
+		config,
+	), nil
+}
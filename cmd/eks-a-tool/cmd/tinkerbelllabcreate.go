@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/internal/pkg/api"
+	"github.com/aws/eks-anywhere/internal/pkg/tinkerbell/lab"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	labCIDRFlag             = "cidr"
+	labControlPlanesFlag    = "control-plane-count"
+	labWorkersFlag          = "worker-count"
+	labCPUsFlag             = "cpus"
+	labMemoryFlag           = "memory"
+	labDiskSizeFlag         = "disk-size"
+	labStoragePoolFlag      = "storage-pool"
+	labEmulatorImageFlag    = "emulator-image"
+	labEmulatorBasePortFlag = "emulator-base-port"
+	labBMCUsernameFlag      = "bmc-username"
+	labBMCPasswordFlag      = "bmc-password"
+	labHardwareCSVFlag      = "hardware-csv"
+)
+
+var tinkerbellLabCreateCmd = &cobra.Command{
+	Use:    "create",
+	PreRun: prerunCmdBindFlags,
+	Short:  "Create a Tinkerbell virtual lab",
+	Long: `This command creates a libvirt network without DHCP, powered off VMs that boot over PXE and a sushy-tools
+Redfish emulator per VM, and writes the hardware CSV to create a Tinkerbell cluster with them.
+The first address of the CIDR is used by the host, the addresses up to the tenth are left for the
+control plane endpoint and the Tinkerbell IP.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config := lab.Config{
+			Name:              viper.GetString(labNameFlag),
+			CIDR:              viper.GetString(labCIDRFlag),
+			ControlPlaneCount: viper.GetInt(labControlPlanesFlag),
+			WorkerCount:       viper.GetInt(labWorkersFlag),
+			CPUs:              viper.GetInt(labCPUsFlag),
+			MemoryMiB:         viper.GetInt(labMemoryFlag),
+			DiskSize:          viper.GetString(labDiskSizeFlag),
+			StoragePool:       viper.GetString(labStoragePoolFlag),
+			EmulatorImage:     viper.GetString(labEmulatorImageFlag),
+			EmulatorBasePort:  viper.GetInt(labEmulatorBasePortFlag),
+			BMCUsername:       viper.GetString(labBMCUsernameFlag),
+			BMCPassword:       viper.GetString(labBMCPasswordFlag),
+		}
+
+		l, err := newLab(config)
+		if err != nil {
+			return err
+		}
+
+		machines, err := l.Create(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("creating lab %s: %v", config.Name, err)
+		}
+
+		csv := viper.GetString(labHardwareCSVFlag)
+		if err := api.WriteHardwareSliceToCSV(machines, csv); err != nil {
+			return err
+		}
+
+		logger.Info("Lab created", "name", config.Name, "hardware", csv)
+		return nil
+	},
+}
+
+func init() {
+	tinkerbellLabCmd.AddCommand(tinkerbellLabCreateCmd)
+
+	labFlags(tinkerbellLabCreateCmd)
+	tinkerbellLabCreateCmd.Flags().String(labCIDRFlag, "10.80.0.0/24", "IPv4 CIDR of the lab network")
+	tinkerbellLabCreateCmd.Flags().Int(labControlPlanesFlag, 1, "Number of control plane VMs")
+	tinkerbellLabCreateCmd.Flags().Int(labWorkersFlag, 1, "Number of worker VMs")
+	tinkerbellLabCreateCmd.Flags().Int(labCPUsFlag, 2, "Number of vCPUs of each VM")
+	tinkerbellLabCreateCmd.Flags().Int(labMemoryFlag, 8192, "Memory of each VM in MiB")
+	tinkerbellLabCreateCmd.Flags().String(labDiskSizeFlag, "40G", "Disk size of each VM")
+	tinkerbellLabCreateCmd.Flags().String(labStoragePoolFlag, "default", "libvirt storage pool of the VM disks")
+	tinkerbellLabCreateCmd.Flags().String(labEmulatorImageFlag, lab.DefaultEmulatorImage, "Image of the Redfish emulators, the default is built with a pinned sushy-tools version")
+	tinkerbellLabCreateCmd.Flags().Int(labEmulatorBasePortFlag, 8001, "Port of the Redfish emulator of the first VM, the next VMs use the following ports")
+	tinkerbellLabCreateCmd.Flags().String(labBMCUsernameFlag, "admin", "Username of the virtual BMCs")
+	tinkerbellLabCreateCmd.Flags().String(labBMCPasswordFlag, "", "Password of the virtual BMCs (REQUIRED)")
+	tinkerbellLabCreateCmd.Flags().String(labHardwareCSVFlag, "hardware.csv", "Path of the hardware CSV written for the lab VMs")
+
+	if err := tinkerbellLabCreateCmd.MarkFlagRequired(labBMCPasswordFlag); err != nil {
+		log.Fatalf("Marking flag '%s' as required", labBMCPasswordFlag)
+	}
+}
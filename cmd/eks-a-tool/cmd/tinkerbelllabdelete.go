@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/internal/pkg/tinkerbell/lab"
+)
+
+var tinkerbellLabDeleteCmd = &cobra.Command{
+	Use:    "delete",
+	PreRun: prerunCmdBindFlags,
+	Short:  "Delete a Tinkerbell virtual lab",
+	Long:   "This command deletes the BMC containers, the VMs with their disks and the network of a lab",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := viper.GetString(labNameFlag)
+		l, err := newLab(lab.Config{Name: name})
+		if err != nil {
+			return err
+		}
+
+		if err := l.Delete(cmd.Context()); err != nil {
+			return fmt.Errorf("deleting lab %s: %v", name, err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	tinkerbellLabCmd.AddCommand(tinkerbellLabDeleteCmd)
+
+	labFlags(tinkerbellLabDeleteCmd)
+}
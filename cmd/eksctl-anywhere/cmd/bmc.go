@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+var bmcCmd = &cobra.Command{
+	Use:   "bmc",
+	Short: "Manage bare metal hardware through their BMC",
+	Long:  "Use eksctl anywhere bmc to execute power and boot device operations on Tinkerbell hardware",
+}
+
+func init() {
+	rootCmd.AddCommand(bmcCmd)
+}
+
+// bmcOptions holds the flags shared by the bmc subcommands to select hardware and decide how to
+// reach their BMCs.
+type bmcOptions struct {
+	hostnames       []string
+	selector        string
+	kubeconfig      string
+	hardwareCSVPath string
+}
+
+func applyBMCFlags(flagSet *pflag.FlagSet, opts *bmcOptions) {
+	flagSet.StringSliceVar(&opts.hostnames, "hostname", nil, "Hostnames of the hardware to operate on")
+	flagSet.StringVarP(&opts.selector, "selector", "l", "", "Label selector of the hardware to operate on (e.g. type=cp)")
+	flagSet.StringVar(&opts.kubeconfig, "kubeconfig", "", "Management cluster kubeconfig file. When set, operations are executed through Rufio")
+	applyTinkerbellHardwareFlag(flagSet, &opts.hardwareCSVPath)
+}
+
+// buildClientAndTargets resolves the hardware selected by opts. When a hardware CSV is provided,
+// the BMCs are reached directly with the credentials from the CSV. Otherwise the management
+// cluster's Rufio Machines are used.
+func (opts *bmcOptions) buildClientAndTargets(ctx context.Context) (bmc.Client, []bmc.Target, error) {
+	selector := bmc.Selector{Hostnames: opts.hostnames}
+	if opts.selector != "" {
+		s, err := labels.Parse(opts.selector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing selector: %v", err)
+		}
+		selector.Labels = s
+	}
+
+	if selector.IsEmpty() {
+		return nil, nil, fmt.Errorf("one of --hostname or --selector is required")
+	}
+
+	if opts.hardwareCSVPath != "" {
+		if opts.kubeconfig != "" {
+			return nil, nil, fmt.Errorf("--%s and --kubeconfig are mutually exclusive", TinkerbellHardwareCSVFlagName)
+		}
+
+		reader, err := hardware.NewNormalizedCSVReaderFromFile(opts.hardwareCSVPath, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading hardware csv: %v", err)
+		}
+		targets, err := bmc.TargetsFromMachines(reader, selector)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return bmc.NewDirectClient(logr.Discard()), targets, nil
+	}
+
+	kubeconfigPath, err := kubeconfig.ResolveAndValidateFilename(opts.kubeconfig, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := kubernetes.NewRuntimeClientFromFileName(kubeconfigPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("building management cluster client: %v", err)
+	}
+
+	targets, err := bmc.TargetsFromCluster(ctx, client, selector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return bmc.NewRufioClient(client), targets, nil
+}
+
+// printBMCResults prints results as a table and returns an error if any of them failed.
+func printBMCResults(results []bmc.Result, withPowerState bool) error {
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	if withPowerState {
+		fmt.Fprintln(w, "HOSTNAME\tPOWER\tERROR")
+	} else {
+		fmt.Fprintln(w, "HOSTNAME\tSTATUS\tERROR")
+	}
+
+	failed := 0
+	for _, r := range results {
+		status := "Succeeded"
+		if withPowerState {
+			status = r.PowerState
+		}
+		errMsg := ""
+		if r.Err != nil {
+			failed++
+			errMsg = r.Err.Error()
+			if !withPowerState {
+				status = "Failed"
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.Hostname, status, errMsg)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	fmt.Fprint(os.Stdout, buffer.String())
+
+	if failed > 0 {
+		return fmt.Errorf("bmc operation failed for %d of %d hardware", failed, len(results))
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
+)
+
+var bmcBootDeviceOpts = &bmcOptions{}
+
+var bmcBootDeviceCmd = &cobra.Command{
+	Use:          "boot-device pxe|disk",
+	Short:        "Set the hardware next boot device",
+	Long:         "Configure hardware to boot from the network (pxe) or the local disk on their next boot through their BMC",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	ValidArgs:    []string{string(bmc.BootDevicePXE), string(bmc.BootDeviceDisk)},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		device, err := bmc.ParseBootDevice(args[0])
+		if err != nil {
+			return err
+		}
+
+		client, targets, err := bmcBootDeviceOpts.buildClientAndTargets(ctx)
+		if err != nil {
+			return fmt.Errorf("selecting hardware: %v", err)
+		}
+
+		return printBMCResults(bmc.Run(ctx, client, targets, bmc.SetBootDeviceOperation(device)), false)
+	},
+}
+
+func init() {
+	bmcCmd.AddCommand(bmcBootDeviceCmd)
+	applyBMCFlags(bmcBootDeviceCmd.Flags(), bmcBootDeviceOpts)
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
+)
+
+const bmcPowerStatus = "status"
+
+var bmcPowerOpts = &bmcOptions{}
+
+var bmcPowerCmd = &cobra.Command{
+	Use:          "power on|off|cycle|status",
+	Short:        "Manage hardware power",
+	Long:         "Power on, power off, power cycle or retrieve the power status of hardware through their BMC",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	ValidArgs:    []string{string(bmc.PowerOn), string(bmc.PowerOff), string(bmc.PowerCycle), bmcPowerStatus},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		op := bmc.PowerStateOperation()
+		withPowerState := true
+		if args[0] != bmcPowerStatus {
+			action, err := bmc.ParsePowerAction(args[0])
+			if err != nil {
+				return err
+			}
+			op = bmc.SetPowerStateOperation(action)
+			withPowerState = false
+		}
+
+		client, targets, err := bmcPowerOpts.buildClientAndTargets(ctx)
+		if err != nil {
+			return fmt.Errorf("selecting hardware: %v", err)
+		}
+
+		return printBMCResults(bmc.Run(ctx, client, targets, op), withPowerState)
+	},
+}
+
+func init() {
+	bmcCmd.AddCommand(bmcPowerCmd)
+	applyBMCFlags(bmcPowerCmd.Flags(), bmcPowerOpts)
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	infracleanup "github.com/aws/eks-anywhere/pkg/cleanup"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+type cleanupClusterOptions struct {
+	fileName string
+	dryRun   bool
+	force    bool
+}
+
+var cleanupClusterOpts = &cleanupClusterOptions{}
+
+var cleanupClusterCmd = &cobra.Command{
+	Use:   "cluster <cluster-name> -f <cluster-config-file> [flags]",
+	Short: "Delete the infrastructure left behind by a cluster",
+	Long: `Find the infrastructure of a cluster that was not deleted, for example after a failed create, and delete it after confirmation.
+The VMs named after the control plane, etcd and worker node group machines of the cluster (on Nutanix, only the ones in its
+CAPX category), the vSphere DRS rules and groups and the CloudStack affinity groups created for it and the bootstrap and
+Docker containers labeled with the cluster name are included. The command refuses to run while the control plane endpoint
+or the API server of the cluster kubeconfig answers. Templates, with their vSphere tags and content library items, are
+shared by clusters and not included, use eksctl anywhere prune templates to delete the vSphere templates no cluster uses.`,
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clusterName, err := validations.ValidateClusterNameArg(args)
+		if err != nil {
+			return err
+		}
+		return cleanupClusterOpts.cleanupCluster(cmd.Context(), clusterName, cmd.InOrStdin(), cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	cleanupCmd.AddCommand(cleanupClusterCmd)
+
+	cleanupClusterCmd.Flags().StringVarP(&cleanupClusterOpts.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	cleanupClusterCmd.Flags().BoolVar(&cleanupClusterOpts.dryRun, "dry-run", false, "Print the infrastructure that would be deleted without deleting it")
+	cleanupClusterCmd.Flags().BoolVar(&cleanupClusterOpts.force, "force", false, "Delete the infrastructure without asking for confirmation")
+
+	if err := cleanupClusterCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (opts *cleanupClusterOptions) cleanupCluster(ctx context.Context, clusterName string, in io.Reader, out io.Writer) error {
+	clusterSpec, err := readAndValidateClusterSpec(opts.fileName, version.Get())
+	if err != nil {
+		return err
+	}
+
+	if clusterSpec.Cluster.Name != clusterName {
+		return fmt.Errorf("cluster name %s does not match the name %s in the cluster config file", clusterName, clusterSpec.Cluster.Name)
+	}
+
+	if err := infracleanup.ValidateClusterUnreachable(&networkutils.DefaultNetClient{}, clusterSpec.Cluster, kubeconfig.FromClusterName(clusterName)); err != nil {
+		return err
+	}
+
+	factory := dependencies.NewFactory().WithDocker()
+	kind := clusterSpec.Cluster.Spec.DatacenterRef.Kind
+	switch kind {
+	case v1alpha1.VSphereDatacenterKind:
+		if err := vsphere.SetupEnvVars(clusterSpec.VSphereDatacenter); err != nil {
+			return err
+		}
+		factory.WithVSphereProviderClient()
+	case v1alpha1.CloudStackDatacenterKind:
+		factory.WithExecutableBuilder()
+	case v1alpha1.NutanixDatacenterKind:
+		factory.WithNutanixClientCache()
+	}
+
+	deps, err := factory.Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	// Snow and Tinkerbell machines are not named after the cluster, only their bootstrap containers are found.
+	discoverers := []infracleanup.Discoverer{infracleanup.NewContainerDiscoverer(deps.DockerClient)}
+	switch kind {
+	case v1alpha1.VSphereDatacenterKind:
+		discoverers = append(discoverers, vsphere.NewCleanupDiscoverer(deps.VSphereProviderClient, clusterSpec))
+	case v1alpha1.CloudStackDatacenterKind:
+		execConfig, err := decoder.ParseCloudStackCredsFromEnv()
+		if err != nil {
+			return fmt.Errorf("parsing CloudStack credentials: %v", err)
+		}
+		cmk, err := deps.ExecutableBuilder.BuildCmkExecutable(deps.Writer, execConfig)
+		if err != nil {
+			return err
+		}
+		defer close(ctx, cmk)
+		discoverers = append(discoverers, cloudstack.NewCleanupDiscoverer(cmk, clusterSpec))
+	case v1alpha1.NutanixDatacenterKind:
+		discoverers = append(discoverers, nutanix.NewCleanupDiscoverer(deps.NutanixClientCache, clusterSpec))
+	}
+
+	cleaner := infracleanup.NewCleaner(discoverers...)
+	plan, err := cleaner.Plan(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+
+	if plan.Empty() {
+		fmt.Fprintf(out, "No infrastructure found for cluster %s\n", clusterName)
+		return nil
+	}
+
+	if err := plan.Write(out); err != nil {
+		return err
+	}
+
+	if opts.dryRun {
+		return nil
+	}
+
+	if !opts.force && !confirmDelete(in, out, "resources") {
+		fmt.Fprintln(out, "Cleanup cancelled")
+		return nil
+	}
+
+	return cleaner.Execute(ctx, plan)
+}
+
+// confirmDelete asks on out to delete the listed things and reads the answer from in.
+func confirmDelete(in io.Reader, out io.Writer, things string) bool {
+	fmt.Fprintf(out, "Delete these %s? [y/N]: ", things)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
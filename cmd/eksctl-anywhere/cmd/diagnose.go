@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var diagnoseCmd = &cobra.Command{
+	Use:   "diagnose",
+	Short: "Diagnose cluster components",
+	Long:  "Use eksctl anywhere diagnose to check the health of the components backing a cluster",
+}
+
+func init() {
+	rootCmd.AddCommand(diagnoseCmd)
+}
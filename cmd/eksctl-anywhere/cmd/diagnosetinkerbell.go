@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/stack"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/workflow"
+)
+
+type diagnoseTinkerbellOptions struct {
+	kubeconfig   string
+	tinkerbellIP string
+}
+
+var diagnoseTinkerbellOpts = &diagnoseTinkerbellOptions{}
+
+var diagnoseTinkerbellCmd = &cobra.Command{
+	Use:          "tinkerbell",
+	Short:        "Check the health of the Tinkerbell stack",
+	Long:         "Check the Tinkerbell stack pods and service, probe the Tinkerbell IP for the DHCP, HTTP and gRPC ports of the stack and list the workflows that haven't completed",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return diagnoseTinkerbellOpts.diagnose(cmd.Context())
+	},
+}
+
+func init() {
+	diagnoseCmd.AddCommand(diagnoseTinkerbellCmd)
+	diagnoseTinkerbellCmd.Flags().StringVar(&diagnoseTinkerbellOpts.kubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	diagnoseTinkerbellCmd.Flags().StringVar(&diagnoseTinkerbellOpts.tinkerbellIP, "tinkerbell-ip", "", "Tinkerbell IP to probe. Defaults to the TinkerbellIP of the TinkerbellDatacenterConfigs in the cluster")
+}
+
+func (opts *diagnoseTinkerbellOptions) diagnose(ctx context.Context) error {
+	kubeconfigPath, err := kubeconfig.ResolveAndValidateFilename(opts.kubeconfig, "")
+	if err != nil {
+		return err
+	}
+
+	kubeClient, err := kubernetes.NewRuntimeClientFromFileName(kubeconfigPath)
+	if err != nil {
+		return fmt.Errorf("building management cluster client: %v", err)
+	}
+
+	tinkerbellIP := opts.tinkerbellIP
+	if tinkerbellIP == "" {
+		if tinkerbellIP, err = tinkerbellIPFromCluster(ctx, kubeClient); err != nil {
+			return err
+		}
+	}
+
+	report, err := stack.NewHealthChecker(kubeClient, constants.EksaSystemNamespace).Check(ctx, tinkerbellIP)
+	if err != nil {
+		return err
+	}
+
+	pending, err := workflow.Pending(ctx, kubeClient, constants.EksaSystemNamespace, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := printTinkerbellDiagnosis(report, pending); err != nil {
+		return err
+	}
+
+	if failed := report.Failed(); len(failed) > 0 {
+		return fmt.Errorf("tinkerbell stack is unhealthy: %d of %d checks failed", len(failed), len(report.Checks))
+	}
+
+	return nil
+}
+
+// tinkerbellIPFromCluster returns the TinkerbellIP shared by the TinkerbellDatacenterConfigs in the cluster.
+func tinkerbellIPFromCluster(ctx context.Context, c client.Client) (string, error) {
+	datacenters := &v1alpha1.TinkerbellDatacenterConfigList{}
+	if err := c.List(ctx, datacenters); err != nil {
+		return "", fmt.Errorf("listing tinkerbell datacenter configs: %v", err)
+	}
+
+	ip := ""
+	for _, dc := range datacenters.Items {
+		switch {
+		case dc.Spec.TinkerbellIP == "", dc.Spec.TinkerbellIP == ip:
+		case ip == "":
+			ip = dc.Spec.TinkerbellIP
+		default:
+			return "", fmt.Errorf("tinkerbell datacenter configs use different tinkerbell ips, use --tinkerbell-ip to select one")
+		}
+	}
+
+	if ip == "" {
+		return "", fmt.Errorf("no tinkerbell ip found in the cluster tinkerbell datacenter configs, use --tinkerbell-ip to provide one")
+	}
+
+	return ip, nil
+}
+
+func printTinkerbellDiagnosis(report stack.HealthReport, pending []workflow.MachineStatus) error {
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "COMPONENT\tCHECK\tSTATUS\tDETAILS")
+	for _, c := range report.Checks {
+		status := "Healthy"
+		switch {
+		case c.Unverified:
+			status = "Unverified"
+		case !c.Healthy:
+			status = "Unhealthy"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Component, c.Name, status, c.Details)
+	}
+
+	fmt.Fprintln(w)
+	if len(pending) == 0 {
+		fmt.Fprintln(w, "No pending workflows")
+	} else {
+		fmt.Fprintln(w, "HARDWARE\tWORKFLOW\tSTATE\tACTION\tELAPSED\tMESSAGE")
+		for _, p := range pending {
+			state := string(p.State)
+			if state == "" {
+				state = "STATE_PENDING"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", p.Hardware, p.Machine, state, p.CurrentAction, p.Elapsed.Round(time.Second), p.Message)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	fmt.Fprint(os.Stdout, buffer.String())
+
+	return nil
+}
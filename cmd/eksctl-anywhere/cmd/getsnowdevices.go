@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+)
+
+type getSnowDevicesOptions struct {
+	output string
+}
+
+var gsdo = &getSnowDevicesOptions{}
+
+var getSnowDevicesCmd = &cobra.Command{
+	Use:     "snow-devices [flags]",
+	Aliases: []string{"snow-device"},
+	Short:   "Get the inventory and health of the Snow devices",
+	Long: `Report, for every device in the aws credentials file set in EKSA_AWS_CREDENTIALS_FILE, its unlock status,
+software version, available capacity per instance type, images and the cluster machines running on it.
+The command fails if a device is unreachable, locked or runs an unsupported software version.`,
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return gsdo.getSnowDevices(cmd.Context())
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getSnowDevicesCmd)
+
+	getSnowDevicesCmd.Flags().StringVarP(&gsdo.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+}
+
+func (opts *getSnowDevicesOptions) getSnowDevices(ctx context.Context) error {
+	if opts.output != outputText && opts.output != outputJson {
+		return fmt.Errorf("invalid output format [%s]", opts.output)
+	}
+
+	deps, err := dependencies.NewFactory().WithAwsSnow().Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	inventory, err := snow.GetDeviceInventory(ctx, deps.SnowAwsClientRegistry)
+	if err != nil {
+		return err
+	}
+
+	if opts.output == outputJson {
+		b, err := json.MarshalIndent(inventory, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed serializing the snow device inventory to json: %v", err)
+		}
+		fmt.Println(string(b))
+	} else if err := inventory.Write(os.Stdout); err != nil {
+		return err
+	}
+
+	if !inventory.Healthy() {
+		return fmt.Errorf("one or more snow devices are not healthy")
+	}
+
+	return nil
+}
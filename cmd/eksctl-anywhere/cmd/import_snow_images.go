@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+type importSnowImagesOptions struct {
+	inputDir           string
+	bucket             string
+	bundlesOverride    string
+	kubernetesVersions []string
+	osFamilies         []string
+	output             string
+}
+
+var importSnowImagesOpts = &importSnowImagesOptions{}
+
+var importSnowImagesCmd = &cobra.Command{
+	Use:   "snow-images --input-dir <dir> --bucket <bucket> [flags]",
+	Short: "Import the Snow node images for a Bundles release",
+	Long: `Import the raw node images of the EKS Anywhere Bundles release from a local directory onto every device in the aws credentials file
+set in EKSA_AWS_CREDENTIALS_FILE. Each image is uploaded to a bucket of the device s3 adapter, imported as a snapshot and registered as an AMI,
+then deleted from the bucket. The Bottlerocket images are the ones in the Bundles manifest, Ubuntu images must be named ubuntu-<eks-d channel>.raw,
+like ubuntu-1-29.raw. The AMI ID of the image on each device is printed. AMIs are registered with the names CAPAS looks up, so SnowMachineConfigs
+with no amiID use the image imported on each device, and get the AMI ID set when the image has the same ID on all their devices.
+Images that already exist on a device are skipped.`,
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return importSnowImagesOpts.importSnowImages(cmd.Context())
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importSnowImagesCmd)
+
+	importSnowImagesCmd.Flags().StringVar(&importSnowImagesOpts.inputDir, "input-dir", "", "Directory containing the raw images to import")
+	importSnowImagesCmd.Flags().StringVar(&importSnowImagesOpts.bucket, "bucket", "", "Bucket of the devices s3 adapter to upload the images to")
+	importSnowImagesCmd.Flags().StringVar(&importSnowImagesOpts.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	importSnowImagesCmd.Flags().StringSliceVar(&importSnowImagesOpts.kubernetesVersions, "kubernetes-versions", nil, "Kubernetes versions to import images for. Defaults to the versions with an image in the input directory")
+	importSnowImagesCmd.Flags().StringSliceVar(&importSnowImagesOpts.osFamilies, "os-families", []string{string(v1alpha1.Bottlerocket)}, "OS families to import images for")
+	importSnowImagesCmd.Flags().StringVarP(&importSnowImagesOpts.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+
+	for _, flag := range []string{"input-dir", "bucket"} {
+		if err := importSnowImagesCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+func (opts *importSnowImagesOptions) importSnowImages(ctx context.Context) error {
+	if opts.output != outputText && opts.output != outputJson {
+		return fmt.Errorf("invalid output format [%s]", opts.output)
+	}
+
+	bundles, err := getBundles(version.Get(), opts.bundlesOverride)
+	if err != nil {
+		return err
+	}
+
+	requested := map[string]bool{}
+	for _, v := range opts.kubernetesVersions {
+		requested[v] = true
+	}
+
+	deps, err := dependencies.NewFactory().WithAwsSnow().Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	importer := snow.NewImageImporter(deps.SnowAwsClientRegistry, opts.bucket)
+	imported := snow.ImportedImages{}
+	for _, f := range opts.osFamilies {
+		osFamily := v1alpha1.OSFamily(strings.ToLower(f))
+		for _, vb := range bundles.Spec.VersionsBundles {
+			if len(requested) > 0 && !requested[vb.KubeVersion] {
+				continue
+			}
+
+			file, err := snow.ImageFile(osFamily, vb)
+			if err != nil {
+				return err
+			}
+			file = filepath.Join(opts.inputDir, file)
+			if _, err := os.Stat(file); err != nil {
+				if len(requested) == 0 && os.IsNotExist(err) {
+					logger.V(4).Info("Skipping kubernetes version with no image in the input directory", "version", vb.KubeVersion, "file", file)
+					continue
+				}
+				return fmt.Errorf("reading image for kubernetes version %s: %v", vb.KubeVersion, err)
+			}
+
+			name, err := snow.ImageName(osFamily, vb)
+			if err != nil {
+				return err
+			}
+
+			images, err := importer.Import(ctx, name, file)
+			if err != nil {
+				return err
+			}
+			imported = append(imported, images...)
+		}
+	}
+
+	if len(imported) == 0 {
+		return fmt.Errorf("no images found in %s for the requested kubernetes versions and os families", opts.inputDir)
+	}
+
+	if opts.output == outputJson {
+		b, err := json.MarshalIndent(imported, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed serializing the imported images to json: %v", err)
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	return imported.Write(os.Stdout)
+}
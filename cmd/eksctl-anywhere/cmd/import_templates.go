@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+type importTemplatesOptions struct {
+	fileName           string
+	bundlesOverride    string
+	kubernetesVersions []string
+	osFamilies         []string
+	inputDir           string
+}
+
+var importTemplatesOpts = &importTemplatesOptions{}
+
+var importTemplatesCmd = &cobra.Command{
+	Use:   "templates -f <cluster-config-file> [flags]",
+	Short: "Import the vSphere templates for a Bundles release",
+	Long: `Import the OVAs of the EKS Anywhere Bundles release into the vSphere content library of the cluster and deploy them as tagged templates.
+Templates that already exist are skipped. Use --input-dir to import OVAs downloaded beforehand in air-gapped environments.`,
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return importTemplatesOpts.importTemplates(cmd.Context())
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importTemplatesCmd)
+
+	importTemplatesCmd.Flags().StringVarP(&importTemplatesOpts.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	importTemplatesCmd.Flags().StringVar(&importTemplatesOpts.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	importTemplatesCmd.Flags().StringSliceVar(&importTemplatesOpts.kubernetesVersions, "kubernetes-versions", nil, "Kubernetes versions to import templates for. Defaults to the versions used by the cluster")
+	importTemplatesCmd.Flags().StringSliceVar(&importTemplatesOpts.osFamilies, "os-families", []string{string(v1alpha1.Bottlerocket)}, "OS families to import templates for")
+	importTemplatesCmd.Flags().StringVar(&importTemplatesOpts.inputDir, "input-dir", "", "Directory containing the OVAs to import instead of downloading them")
+
+	if err := importTemplatesCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (opts *importTemplatesOptions) importTemplates(ctx context.Context) error {
+	clusterSpec, err := readVSphereClusterSpec(opts.fileName, opts.bundlesOverride)
+	if err != nil {
+		return err
+	}
+
+	importOpts := vsphere.ImportTemplatesOptions{
+		KubernetesVersions: clusterSpec.Cluster.KubernetesVersions(),
+		OVADir:             opts.inputDir,
+	}
+	if len(opts.kubernetesVersions) > 0 {
+		importOpts.KubernetesVersions = make([]v1alpha1.KubernetesVersion, 0, len(opts.kubernetesVersions))
+		for _, v := range opts.kubernetesVersions {
+			importOpts.KubernetesVersions = append(importOpts.KubernetesVersions, v1alpha1.KubernetesVersion(v))
+		}
+	}
+	for _, f := range opts.osFamilies {
+		importOpts.OSFamilies = append(importOpts.OSFamilies, v1alpha1.OSFamily(strings.ToLower(f)))
+	}
+
+	factory := dependencies.NewFactory()
+	if opts.inputDir != "" {
+		// With the govc fallback, the OVAs are uploaded from inside the tools container.
+		inputDir, err := filepath.Abs(opts.inputDir)
+		if err != nil {
+			return err
+		}
+		factory.WithExecutableMountDirs(inputDir)
+	}
+
+	deps, err := factory.WithVSphereProviderClient().Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	manager := vsphere.NewTemplateManager(deps.VSphereProviderClient, vsphere.TemplatePlacementFromSpec(clusterSpec))
+	templates, err := manager.ImportTemplates(ctx, clusterSpec.Bundles, importOpts)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range templates {
+		fmt.Println(t)
+	}
+
+	return nil
+}
+
+// readVSphereClusterSpec reads a vSphere cluster config and sets up the govc environment for its vCenter.
+func readVSphereClusterSpec(fileName, bundlesOverride string) (*cluster.Spec, error) {
+	var specOpts []cluster.FileSpecBuilderOpt
+	if bundlesOverride != "" {
+		specOpts = append(specOpts, cluster.WithOverrideBundlesManifest(bundlesOverride))
+	}
+	clusterSpec, err := readAndValidateClusterSpec(fileName, version.Get(), specOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if clusterSpec.VSphereDatacenter == nil {
+		return nil, fmt.Errorf("cluster config %s is not for the vSphere provider", fileName)
+	}
+
+	if err := vsphere.SetupEnvVars(clusterSpec.VSphereDatacenter); err != nil {
+		return nil, err
+	}
+
+	return clusterSpec, nil
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var nutanixPlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Plan Nutanix resources",
+	Long:  "Use eksctl anywhere nutanix plan to check Nutanix resources before creating or upgrading a cluster",
+}
+
+func init() {
+	nutanixCmd.AddCommand(nutanixPlanCmd)
+}
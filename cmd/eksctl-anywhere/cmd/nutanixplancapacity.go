@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+type nutanixPlanCapacityOptions struct {
+	fileName string
+	output   string
+}
+
+var nutanixPlanCapacityOpts = &nutanixPlanCapacityOptions{}
+
+var nutanixPlanCapacityCmd = &cobra.Command{
+	Use:   "capacity -f <cluster-config-file> [flags]",
+	Short: "Report the Nutanix capacity requested by a cluster",
+	Long: `Report, per Prism Element cluster, the vCPU, memory, storage and GPUs requested by the machines of a cluster,
+including the extra machines rolled out during an upgrade, and compare them to the capacity available.
+Worker node groups with autoscaling are counted with their maxCount. The resources used by the existing machines
+of the cluster are counted as available, so the report applies to both creating and upgrading the cluster.
+The command fails if the memory, storage or GPUs requested are not available.`,
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return nutanixPlanCapacityOpts.planCapacity(cmd.Context())
+	},
+}
+
+func init() {
+	nutanixPlanCmd.AddCommand(nutanixPlanCapacityCmd)
+
+	nutanixPlanCapacityCmd.Flags().StringVarP(&nutanixPlanCapacityOpts.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	nutanixPlanCapacityCmd.Flags().StringVarP(&nutanixPlanCapacityOpts.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+
+	if err := nutanixPlanCapacityCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (opts *nutanixPlanCapacityOptions) planCapacity(ctx context.Context) error {
+	if opts.output != outputText && opts.output != outputJson {
+		return fmt.Errorf("invalid output format [%s]", opts.output)
+	}
+
+	clusterSpec, err := readAndValidateClusterSpec(opts.fileName, version.Get())
+	if err != nil {
+		return err
+	}
+
+	if kind := clusterSpec.Cluster.Spec.DatacenterRef.Kind; kind != v1alpha1.NutanixDatacenterKind {
+		return fmt.Errorf("planning capacity is only supported for %s, not %s", v1alpha1.NutanixDatacenterKind, kind)
+	}
+
+	deps, err := dependencies.NewFactory().WithNutanixValidator().Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	report, err := deps.NutanixValidator.PlanCapacity(ctx, clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	if opts.output == outputJson {
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed serializing the capacity report to json: %v", err)
+		}
+		fmt.Println(string(b))
+	} else if err := report.Write(os.Stdout); err != nil {
+		return err
+	}
+
+	if !report.Fits() {
+		return fmt.Errorf("not enough capacity in Nutanix for the cluster machines")
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Prune resources",
+	Long:  "Use eksctl anywhere prune to remove resources no cluster uses anymore",
+}
+
+func init() {
+	rootCmd.AddCommand(pruneCmd)
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+)
+
+type pruneTemplatesOptions struct {
+	fileName        string
+	bundlesOverride string
+	kubeconfigs     []string
+	dryRun          bool
+	force           bool
+}
+
+var pruneTemplatesOpts = &pruneTemplatesOptions{}
+
+var pruneTemplatesCmd = &cobra.Command{
+	Use:   "templates -f <cluster-config-file> [flags]",
+	Short: "Delete the vSphere templates no cluster uses",
+	Long: `Delete the templates in the EKS Anywhere templates folder of the datacenter that are not referenced by any VSphereMachineConfig,
+VSphereMachineTemplate or VSphereMachine in the management clusters or by the cluster config file, after confirmation.
+When several management clusters share the templates folder, pass the kubeconfig of each of them with --kubeconfig.`,
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return pruneTemplatesOpts.pruneTemplates(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	pruneCmd.AddCommand(pruneTemplatesCmd)
+
+	pruneTemplatesCmd.Flags().StringVarP(&pruneTemplatesOpts.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	pruneTemplatesCmd.Flags().StringVar(&pruneTemplatesOpts.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	pruneTemplatesCmd.Flags().StringSliceVar(&pruneTemplatesOpts.kubeconfigs, "kubeconfig", nil, "Kubeconfig files of the management clusters using the templates folder")
+	pruneTemplatesCmd.Flags().BoolVar(&pruneTemplatesOpts.dryRun, "dry-run", false, "Print the templates that would be deleted without deleting them")
+	pruneTemplatesCmd.Flags().BoolVar(&pruneTemplatesOpts.force, "force", false, "Delete the templates without asking for confirmation")
+
+	if err := pruneTemplatesCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (opts *pruneTemplatesOptions) pruneTemplates(ctx context.Context, in io.Reader, out io.Writer) error {
+	clusterSpec, err := readVSphereClusterSpec(opts.fileName, opts.bundlesOverride)
+	if err != nil {
+		return err
+	}
+
+	kubeconfigs := opts.kubeconfigs
+	if len(kubeconfigs) == 0 {
+		kubeconfigs = []string{""}
+	}
+
+	var inUse []string
+	for _, k := range kubeconfigs {
+		kubeconfigPath, err := kubeconfig.ResolveAndValidateFilename(k, "")
+		if err != nil {
+			return err
+		}
+
+		kubeClient, err := kubernetes.NewRuntimeClientFromFileName(kubeconfigPath)
+		if err != nil {
+			return fmt.Errorf("building management cluster client: %v", err)
+		}
+
+		templates, err := vsphere.TemplatesInUse(ctx, kubeClient)
+		if err != nil {
+			return fmt.Errorf("reading templates in use in management cluster %s: %v", kubeconfigPath, err)
+		}
+		inUse = append(inUse, templates...)
+	}
+	for _, mc := range clusterSpec.VSphereMachineConfigs {
+		if mc.Spec.Template != "" {
+			inUse = append(inUse, mc.Spec.Template)
+		}
+	}
+
+	deps, err := dependencies.NewFactory().WithVSphereProviderClient().Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	manager := vsphere.NewTemplateManager(deps.VSphereProviderClient, vsphere.TemplatePlacementFromSpec(clusterSpec))
+	unused, err := manager.UnusedTemplates(ctx, inUse)
+	if err != nil {
+		return err
+	}
+
+	if len(unused) == 0 {
+		fmt.Fprintln(out, "No unused templates found")
+		return nil
+	}
+
+	fmt.Fprintln(out, "Unused templates:")
+	for _, t := range unused {
+		fmt.Fprintf(out, "  %s\n", t)
+	}
+
+	if opts.dryRun {
+		return nil
+	}
+
+	if !opts.force && !confirmDelete(in, out, "templates") {
+		fmt.Fprintln(out, "Prune cancelled")
+		return nil
+	}
+
+	return manager.DeleteTemplates(ctx, unused)
+}
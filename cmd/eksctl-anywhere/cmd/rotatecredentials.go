@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/credentials"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+type rotateCredentialsOptions struct {
+	fileName         string
+	kubeconfig       string
+	reconnectTimeout time.Duration
+}
+
+var rotateCredentialsOpts = &rotateCredentialsOptions{}
+
+var rotateCredentialsCmd = &cobra.Command{
+	Use:   "credentials -f <cluster-config-file> [flags]",
+	Short: "Rotate the infrastructure credentials of a management cluster and its workload clusters",
+	Long: `Rotate the vSphere, Nutanix or CloudStack credentials of a management cluster and of all the workload clusters it manages.
+The new credentials are read from the same environment variables used to create the cluster. They are validated against the
+infrastructure before updating the secrets of the clusters. Once updated, the credentials stored in the secrets of every cluster
+are checked against the infrastructure API and the command waits for the infrastructure of every cluster to be ready.`,
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return rotateCredentialsOpts.rotateCredentials(cmd.Context())
+	},
+}
+
+func init() {
+	rotateCmd.AddCommand(rotateCredentialsCmd)
+
+	rotateCredentialsCmd.Flags().StringVarP(&rotateCredentialsOpts.fileName, "filename", "f", "", "Filename that contains EKS-A management cluster configuration")
+	rotateCredentialsCmd.Flags().StringVar(&rotateCredentialsOpts.kubeconfig, "kubeconfig", "", "kubeconfig file pointing to the management cluster")
+	rotateCredentialsCmd.Flags().DurationVar(&rotateCredentialsOpts.reconnectTimeout, "reconnect-timeout", 10*time.Minute, "Time to wait for the infrastructure of each cluster to be ready with the new credentials")
+
+	if err := rotateCredentialsCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (opts *rotateCredentialsOptions) rotateCredentials(ctx context.Context) error {
+	clusterSpec, err := readAndValidateClusterSpec(opts.fileName, version.Get())
+	if err != nil {
+		return err
+	}
+
+	kubeconfig := opts.kubeconfig
+	if kubeconfig == "" {
+		kubeconfig, err = getManagementClusterKubeconfig(clusterSpec.Cluster.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	factory := dependencies.NewFactory().WithUnAuthKubeClient()
+	kind := clusterSpec.Cluster.Spec.DatacenterRef.Kind
+	switch kind {
+	case v1alpha1.VSphereDatacenterKind:
+		factory.WithVSphereValidator()
+	case v1alpha1.NutanixDatacenterKind:
+		factory.WithNutanixValidator()
+	case v1alpha1.CloudStackDatacenterKind:
+		factory.WithCloudStackValidatorRegistry(false)
+	default:
+		return fmt.Errorf("rotating credentials is not supported for %s", kind)
+	}
+
+	deps, err := factory.Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	var provider credentials.ProviderRotator
+	switch kind {
+	case v1alpha1.VSphereDatacenterKind:
+		provider = vsphere.NewCredentialsRotator(deps.VSphereValidator)
+	case v1alpha1.NutanixDatacenterKind:
+		provider = nutanix.NewCredentialsRotator(deps.NutanixClientCache, deps.NutanixValidator)
+	case v1alpha1.CloudStackDatacenterKind:
+		provider = cloudstack.NewCredentialsRotator(deps.CloudStackValidatorRegistry)
+	}
+
+	rotator := credentials.NewRotator(
+		provider,
+		deps.UnAuthKubeClient.KubeconfigClient(kubeconfig),
+		credentials.WithReconnectTimeout(opts.reconnectTimeout),
+	)
+
+	return rotator.Rotate(ctx, clusterSpec)
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/spf13/cobra"
 
@@ -14,6 +15,7 @@ type vSphereSetupUserOptions struct {
 	fileName string
 	force    bool
 	password string
+	plan     bool
 }
 
 var setupUserOptions = &vSphereSetupUserOptions{}
@@ -21,7 +23,7 @@ var setupUserOptions = &vSphereSetupUserOptions{}
 var setupUserCmd = &cobra.Command{
 	Use:          "user -f <config-file> [flags]",
 	Short:        "Setup vSphere user",
-	Long:         "Use eksctl anywhere vsphere setup user to configure EKS Anywhere vSphere user",
+	Long:         "Use eksctl anywhere vsphere setup user to configure EKS Anywhere vSphere user. Running it again against an existing setup adds the missing role privileges and permissions",
 	PreRunE:      bindFlagsToViper,
 	SilenceUsage: false,
 	RunE:         setupUserOptions.setupUser,
@@ -33,6 +35,7 @@ func init() {
 	setupUserCmd.Flags().StringVarP(&setupUserOptions.fileName, "filename", "f", "", "Filename containing vsphere setup configuration")
 	setupUserCmd.Flags().StringVarP(&setupUserOptions.password, "password", "p", "", "Password for creating new user")
 	setupUserCmd.Flags().BoolVarP(&setupUserOptions.force, "force", "", false, "Force flag. When set, setup user will proceed even if the group and role objects already exist. Mutually exclusive with --password flag, as it expects the user to already exist. default: false")
+	setupUserCmd.Flags().BoolVar(&setupUserOptions.plan, "plan", false, "Print the changes required for the existing user, group, roles and permissions to match the configuration without applying them")
 
 	if err := setupUserCmd.MarkFlagRequired("filename"); err != nil {
 		log.Fatalf("error marking flag as required: %v", err)
@@ -61,16 +64,32 @@ func (setupUserOptions *vSphereSetupUserOptions) setupUser(cmd *cobra.Command, _
 	}
 	defer close(ctx, deps)
 
-	// when using the force flag we assume the user already exists
-	if !setupUserOptions.force {
-		err = deps.Govc.CreateUser(ctx, cfg.Spec.Username, setupUserOptions.password)
+	if setupUserOptions.plan {
+		plan, err := setupuser.BuildPlan(ctx, cfg, deps.Govc)
 		if err != nil {
 			return err
 		}
-		err = setupuser.ValidateVSphereObjects(ctx, cfg, deps.Govc)
+		return plan.Write(os.Stdout, cfg)
+	}
+
+	// when using the force flag we assume the user already exists
+	if !setupUserOptions.force {
+		userExists, err := deps.Govc.UserExists(ctx, cfg.Spec.Username)
 		if err != nil {
 			return err
 		}
+
+		// an existing user is converged with the configuration, only a new one requires the group and roles not to exist
+		if !userExists {
+			err = deps.Govc.CreateUser(ctx, cfg.Spec.Username, setupUserOptions.password)
+			if err != nil {
+				return err
+			}
+			err = setupuser.ValidateVSphereObjects(ctx, cfg, deps.Govc)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	err = setupuser.Run(ctx, cfg, deps.Govc)
@@ -129,6 +129,7 @@ func newVsphereClusterReconcilerTest(t *testing.T, objs ...runtime.Object) *vsph
 		cniReconciler,
 		nil,
 		ipValidator,
+		vsphere.NewAntiAffinity(govcClient),
 	)
 	registry := clusters.NewProviderClusterReconcilerRegistryBuilder().
 		Add(anywherev1.VSphereDatacenterKind, reconciler).
@@ -170,6 +171,7 @@ func newVsphereClusterReconcilerWithFailureDomainsTest(t *testing.T, cl client.C
 		cniReconciler,
 		nil,
 		ipValidator,
+		vsphere.NewAntiAffinity(govcClient),
 	)
 	registry := clusters.NewProviderClusterReconcilerRegistryBuilder().
 		Add(anywherev1.VSphereDatacenterKind, reconciler).
@@ -1750,6 +1752,77 @@ func TestClusterReconcilerPackagesDeletion(s *testing.T) {
 	})
 }
 
+type deletingProviderReconciler struct {
+	dummyProviderReconciler
+	deleted []string
+	err     error
+}
+
+func (r *deletingProviderReconciler) ReconcileDelete(_ context.Context, _ logr.Logger, cluster *anywherev1.Cluster) error {
+	r.deleted = append(r.deleted, cluster.Name)
+	return r.err
+}
+
+func TestClusterReconcilerProviderDeletion(s *testing.T) {
+	newTestCluster := func() *anywherev1.Cluster {
+		deleteTime := metav1.NewTime(time.Now().Add(-1 * time.Second))
+		return &anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "my-workload-cluster",
+				Namespace:         "my-namespace",
+				DeletionTimestamp: &deleteTime,
+				Finalizers:        []string{controllers.ClusterFinalizerName},
+			},
+			Spec: anywherev1.ClusterSpec{
+				KubernetesVersion: "v1.25",
+				ClusterNetwork: anywherev1.ClusterNetwork{
+					CNIConfig: &anywherev1.CNIConfig{
+						Cilium: &anywherev1.CiliumConfig{},
+					},
+				},
+				ManagementCluster: anywherev1.ManagementCluster{
+					Name: "my-management-cluster",
+				},
+				Packages: &anywherev1.PackageConfiguration{Disable: true},
+			},
+		}
+	}
+
+	s.Run("deletes provider resources once the CAPI cluster is gone", func(t *testing.T) {
+		g := NewWithT(t)
+		ctx := context.Background()
+		cluster := newTestCluster()
+		fakeClient := fake.NewClientBuilder().WithRuntimeObjects(cluster).
+			WithStatusSubresource(cluster).
+			Build()
+		providerReconciler := &deletingProviderReconciler{}
+		mockPkgs := mocks.NewMockPackagesClient(gomock.NewController(t))
+		mockPkgs.EXPECT().ReconcileDelete(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+		r := controllers.NewClusterReconciler(fakeClient, newRegistryMock(providerReconciler), nil, nil, mockPkgs, nil, nil)
+		_, err := r.Reconcile(ctx, clusterRequest(cluster))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(providerReconciler.deleted).To(ConsistOf("my-workload-cluster"))
+	})
+
+	s.Run("keeps the finalizer when deleting provider resources fails", func(t *testing.T) {
+		g := NewWithT(t)
+		ctx := context.Background()
+		cluster := newTestCluster()
+		fakeClient := fake.NewClientBuilder().WithRuntimeObjects(cluster).
+			WithStatusSubresource(cluster).
+			Build()
+		providerReconciler := &deletingProviderReconciler{err: errors.New("affinity group in use")}
+
+		r := controllers.NewClusterReconciler(fakeClient, newRegistryMock(providerReconciler), nil, nil, nil, nil, nil)
+		_, err := r.Reconcile(ctx, clusterRequest(cluster))
+		g.Expect(err).To(MatchError(ContainSubstring("deleting provider resources for cluster \"my-workload-cluster\": affinity group in use")))
+
+		g.Expect(fakeClient.Get(ctx, clusterRequest(cluster).NamespacedName, cluster)).To(Succeed())
+		g.Expect(cluster.Finalizers).To(ContainElement(controllers.ClusterFinalizerName))
+	})
+}
+
 func TestClusterReconcilerPackagesInstall(s *testing.T) {
 	version := test.DevEksaVersion()
 	newTestCluster := func() *anywherev1.Cluster {
@@ -25,7 +25,6 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
-	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -75,7 +74,7 @@ func NewControlPlaneUpgradeReconciler(client client.Client, remoteClientRegistry
 //+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=controlplaneupgrades/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=controlplaneupgrades/finalizers,verbs=update
 //+kubebuilder:rbac:groups=bootstrap.cluster.x-k8s.io,resources=kubeadmconfigs,verbs=get;list;watch;update;patch
-//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=tinkerbellmachines;vspheremachines,verbs=get;list;update;patch
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=dockermachines;tinkerbellmachines;vspheremachines,verbs=get;list;update;patch
 //+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 
 // Reconcile reconciles a ControlPlaneUpgrade object.
@@ -437,6 +436,10 @@ func createKubeVipCMIfNotExist(ctx context.Context, remoteClient client.Client,
 			if err != nil {
 				return err
 			}
+			// Control planes load balanced outside the nodes, like docker ones, don't run kube-vip.
+			if kubeVipCM == nil {
+				return nil
+			}
 			if err := remoteClient.Create(ctx, kubeVipCM); err != nil {
 				return fmt.Errorf("failed to create %s config map: %v", constants.KubeVipConfigMapName, err)
 			}
@@ -447,6 +450,8 @@ func createKubeVipCMIfNotExist(ctx context.Context, remoteClient client.Client,
 	return nil
 }
 
+// kubeVipConfigMap returns the config map holding the kube-vip manifest of the new control plane spec, or nil
+// when the control plane doesn't run kube-vip.
 func kubeVipConfigMap(cpUpgrade *anywherev1.ControlPlaneUpgrade) (*corev1.ConfigMap, error) {
 	kcpSpec, err := decodeAndUnmarshalKcpSpecData(cpUpgrade.Spec.ControlPlaneSpecData)
 	if err != nil {
@@ -461,7 +466,7 @@ func kubeVipConfigMap(cpUpgrade *anywherev1.ControlPlaneUpgrade) (*corev1.Config
 	}
 
 	if kubeVipConfig == "" {
-		return nil, errors.New("fetching kube-vip manifest from KubeadmConfigSpec")
+		return nil, nil
 	}
 
 	return &corev1.ConfigMap{
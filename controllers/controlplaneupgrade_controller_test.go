@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -87,6 +88,45 @@ func TestCPUpgradeReconcile(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 }
 
+func TestCPUpgradeReconcileWithoutKubeVip(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	clientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
+	testObjs := getObjectsForCPUpgradeTest()
+	for i := range testObjs.nodeUpgrades {
+		testObjs.nodeUpgrades[i].Name = fmt.Sprintf("%s-node-upgrader", testObjs.machines[i].Name)
+		testObjs.nodeUpgrades[i].Status = anywherev1.NodeUpgradeStatus{
+			Completed: true,
+		}
+	}
+	// Docker control planes are load balanced outside the nodes and don't run kube-vip.
+	kcpSpec := generateKcpSpec()
+	kcpSpec.KubeadmConfigSpec.Files = nil
+	kcpSpecData, err := json.Marshal(kcpSpec)
+	g.Expect(err).ToNot(HaveOccurred())
+	testObjs.cpUpgrade.Spec.ControlPlaneSpecData = base64.StdEncoding.EncodeToString(kcpSpecData)
+
+	objs := []runtime.Object{
+		testObjs.cluster, testObjs.cpUpgrade, testObjs.machines[0], testObjs.machines[1], testObjs.nodes[0], testObjs.nodes[1],
+		testObjs.nodeUpgrades[0], testObjs.nodeUpgrades[1], testObjs.kubeadmConfigs[0], testObjs.kubeadmConfigs[1], testObjs.infraMachines[0], testObjs.infraMachines[1],
+	}
+	client := fake.NewClientBuilder().WithRuntimeObjects(objs...).WithObjects(tinkerbellMachineCRD()).
+		WithStatusSubresource(testObjs.cpUpgrade).
+		Build()
+	kcp := testObjs.cpUpgrade.Spec.ControlPlane
+	clientRegistry.EXPECT().GetClient(ctx, types.NamespacedName{Name: kcp.Name, Namespace: kcp.Namespace}).Return(client, nil)
+
+	r := controllers.NewControlPlaneUpgradeReconciler(client, clientRegistry)
+	req := cpUpgradeRequest(testObjs.cpUpgrade)
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	cm := &corev1.ConfigMap{}
+	err = client.Get(ctx, types.NamespacedName{Name: constants.KubeVipConfigMapName, Namespace: constants.EksaSystemNamespace}, cm)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
 func TestCPUpgradeReconcileEarly(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -21,13 +21,12 @@ import (
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
-	"github.com/aws/eks-anywhere/pkg/executables"
-	"github.com/aws/eks-anywhere/pkg/executables/cmk"
 	"github.com/aws/eks-anywhere/pkg/helm"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 	ciliumreconciler "github.com/aws/eks-anywhere/pkg/networking/cilium/reconciler"
 	cnireconciler "github.com/aws/eks-anywhere/pkg/networking/reconciler"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/apiclient"
 	cloudstackreconciler "github.com/aws/eks-anywhere/pkg/providers/cloudstack/reconciler"
 	dockerreconciler "github.com/aws/eks-anywhere/pkg/providers/docker/reconciler"
 	nutanixreconciler "github.com/aws/eks-anywhere/pkg/providers/nutanix/reconciler"
@@ -78,6 +77,11 @@ type Reconcilers struct {
 	ControlPlaneUpgradeReconciler      *ControlPlaneUpgradeReconciler
 	MachineDeploymentUpgradeReconciler *MachineDeploymentUpgradeReconciler
 	NodeUpgradeReconciler              *NodeUpgradeReconciler
+	VSphereIPAddressClaimReconciler    *VSphereIPAddressClaimReconciler
+	NutanixIPPoolReconciler            *NutanixIPPoolReconciler
+	NutanixIPAddressClaimReconciler    *NutanixIPAddressClaimReconciler
+	NutanixDataDiskReconciler          *NutanixDataDiskReconciler
+	SnowIPPoolReconciler               *SnowIPPoolReconciler
 }
 
 type buildStep func(ctx context.Context) error
@@ -180,6 +184,92 @@ func (f *Factory) WithVSphereDatacenterReconciler() *Factory {
 	return f
 }
 
+// WithVSphereIPAddressClaimReconciler adds the VSphereIPAddressClaimReconciler to the controller factory.
+func (f *Factory) WithVSphereIPAddressClaimReconciler() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.reconcilers.VSphereIPAddressClaimReconciler != nil {
+			return nil
+		}
+
+		f.reconcilers.VSphereIPAddressClaimReconciler = NewVSphereIPAddressClaimReconciler(
+			f.manager.GetClient(),
+			f.manager.GetAPIReader(),
+		)
+
+		return nil
+	})
+	return f
+}
+
+// WithNutanixIPPoolReconciler adds the NutanixIPPoolReconciler to the controller factory.
+func (f *Factory) WithNutanixIPPoolReconciler() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.reconcilers.NutanixIPPoolReconciler != nil {
+			return nil
+		}
+
+		f.reconcilers.NutanixIPPoolReconciler = NewNutanixIPPoolReconciler(
+			f.manager.GetClient(),
+			f.manager.GetAPIReader(),
+		)
+
+		return nil
+	})
+	return f
+}
+
+// WithNutanixIPAddressClaimReconciler adds the NutanixIPAddressClaimReconciler to the controller factory.
+func (f *Factory) WithNutanixIPAddressClaimReconciler() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.reconcilers.NutanixIPAddressClaimReconciler != nil {
+			return nil
+		}
+
+		f.reconcilers.NutanixIPAddressClaimReconciler = NewNutanixIPAddressClaimReconciler(
+			f.manager.GetClient(),
+			f.manager.GetAPIReader(),
+		)
+
+		return nil
+	})
+	return f
+}
+
+// WithNutanixDataDiskReconciler adds the NutanixDataDiskReconciler to the controller factory.
+func (f *Factory) WithNutanixDataDiskReconciler() *Factory {
+	f.dependencyFactory.WithNutanixClientCache()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.reconcilers.NutanixDataDiskReconciler != nil {
+			return nil
+		}
+
+		f.reconcilers.NutanixDataDiskReconciler = NewNutanixDataDiskReconciler(
+			f.manager.GetClient(),
+			f.deps.NutanixClientCache,
+		)
+
+		return nil
+	})
+	return f
+}
+
+// WithSnowIPPoolReconciler adds the SnowIPPoolReconciler to the controller factory.
+func (f *Factory) WithSnowIPPoolReconciler() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.reconcilers.SnowIPPoolReconciler != nil {
+			return nil
+		}
+
+		f.reconcilers.SnowIPPoolReconciler = NewSnowIPPoolReconciler(
+			f.manager.GetClient(),
+		)
+
+		return nil
+	})
+	return f
+}
+
 func (f *Factory) WithSnowMachineConfigReconciler() *Factory {
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.reconcilers.SnowMachineConfigReconciler != nil {
@@ -383,7 +473,7 @@ func (f *Factory) withDockerClusterReconciler() *Factory {
 }
 
 func (f *Factory) withVSphereClusterReconciler() *Factory {
-	f.dependencyFactory.WithVSphereDefaulter().WithVSphereValidator()
+	f.dependencyFactory.WithVSphereDefaulter().WithVSphereValidator().WithVSphereAntiAffinity()
 	f.withTracker().withCNIReconciler(f.getProviderNamespace(constants.VSphereProviderName)).withIPValidator()
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.vsphereClusterReconciler != nil {
@@ -397,6 +487,7 @@ func (f *Factory) withVSphereClusterReconciler() *Factory {
 			f.cniReconciler,
 			f.tracker,
 			f.ipValidator,
+			f.deps.VSphereAntiAffinity,
 		)
 		f.registryBuilder.Add(anywherev1.VSphereDatacenterKind, f.vsphereClusterReconciler)
 
@@ -464,6 +555,7 @@ func (f *Factory) withCloudStackClusterReconciler() *Factory {
 			f.cniReconciler,
 			f.tracker,
 			f.cloudStackValidatorRegistry,
+			cloudstack.NewAffinityGroupManagerFactory(apiclient.NewBuilder(), f.deps.Writer),
 		)
 		f.registryBuilder.Add(anywherev1.CloudStackDatacenterKind, f.cloudstackClusterReconciler)
 
@@ -481,8 +573,8 @@ func (f *Factory) withCloudStackValidatorRegistry() *Factory {
 			return nil
 		}
 
-		cmkBuilder := cmk.NewCmkBuilder(executables.NewLocalExecutablesBuilder())
-		f.cloudStackValidatorRegistry = cloudstack.NewValidatorFactory(cmkBuilder, f.deps.Writer, false)
+		clientBuilder := apiclient.NewBuilder()
+		f.cloudStackValidatorRegistry = cloudstack.NewValidatorFactory(clientBuilder, f.deps.Writer, false)
 
 		return nil
 	})
@@ -370,3 +370,106 @@ func TestFactoryWithMachineDeploymentUpgradeReconciler(t *testing.T) {
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(reconcilers.MachineDeploymentUpgradeReconciler).NotTo(BeNil())
 }
+
+func TestFactoryWithVSphereIPAddressClaimReconciler(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().GetClient().AnyTimes()
+	manager.EXPECT().GetAPIReader().AnyTimes()
+
+	f := controllers.NewFactory(logger, manager).
+		WithVSphereIPAddressClaimReconciler()
+
+	// testing idempotence
+	f.WithVSphereIPAddressClaimReconciler()
+
+	reconcilers, err := f.Build(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconcilers.VSphereIPAddressClaimReconciler).NotTo(BeNil())
+}
+
+func TestFactoryWithNutanixIPPoolReconciler(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().GetClient().AnyTimes()
+	manager.EXPECT().GetAPIReader().AnyTimes()
+
+	f := controllers.NewFactory(logger, manager).
+		WithNutanixIPPoolReconciler()
+
+	// testing idempotence
+	f.WithNutanixIPPoolReconciler()
+
+	reconcilers, err := f.Build(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconcilers.NutanixIPPoolReconciler).NotTo(BeNil())
+}
+
+func TestFactoryWithNutanixIPAddressClaimReconciler(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().GetClient().AnyTimes()
+	manager.EXPECT().GetAPIReader().AnyTimes()
+
+	f := controllers.NewFactory(logger, manager).
+		WithNutanixIPAddressClaimReconciler()
+
+	// testing idempotence
+	f.WithNutanixIPAddressClaimReconciler()
+
+	reconcilers, err := f.Build(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconcilers.NutanixIPAddressClaimReconciler).NotTo(BeNil())
+}
+
+func TestFactoryWithNutanixDataDiskReconciler(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().GetClient().AnyTimes()
+
+	f := controllers.NewFactory(logger, manager).
+		WithNutanixDataDiskReconciler()
+
+	// testing idempotence
+	f.WithNutanixDataDiskReconciler()
+
+	reconcilers, err := f.Build(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconcilers.NutanixDataDiskReconciler).NotTo(BeNil())
+}
+
+func TestFactoryWithSnowIPPoolReconciler(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().GetClient().AnyTimes()
+
+	f := controllers.NewFactory(logger, manager).
+		WithSnowIPPoolReconciler()
+
+	// testing idempotence
+	f.WithSnowIPPoolReconciler()
+
+	reconcilers, err := f.Build(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconcilers.SnowIPPoolReconciler).NotTo(BeNil())
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controllers/nutanix_datadisk_controller.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	nutanix "github.com/aws/eks-anywhere/pkg/providers/nutanix"
+	gomock "github.com/golang/mock/gomock"
+	credentials "github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+)
+
+// MockNutanixClientGetter is a mock of NutanixClientGetter interface.
+type MockNutanixClientGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockNutanixClientGetterMockRecorder
+}
+
+// MockNutanixClientGetterMockRecorder is the mock recorder for MockNutanixClientGetter.
+type MockNutanixClientGetterMockRecorder struct {
+	mock *MockNutanixClientGetter
+}
+
+// NewMockNutanixClientGetter creates a new mock instance.
+func NewMockNutanixClientGetter(ctrl *gomock.Controller) *MockNutanixClientGetter {
+	mock := &MockNutanixClientGetter{ctrl: ctrl}
+	mock.recorder = &MockNutanixClientGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNutanixClientGetter) EXPECT() *MockNutanixClientGetterMockRecorder {
+	return m.recorder
+}
+
+// GetNutanixClient mocks base method.
+func (m *MockNutanixClientGetter) GetNutanixClient(datacenterConfig *v1alpha1.NutanixDatacenterConfig, creds credentials.BasicAuthCredential) (nutanix.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNutanixClient", datacenterConfig, creds)
+	ret0, _ := ret[0].(nutanix.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNutanixClient indicates an expected call of GetNutanixClient.
+func (mr *MockNutanixClientGetterMockRecorder) GetNutanixClient(datacenterConfig, creds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNutanixClient", reflect.TypeOf((*MockNutanixClientGetter)(nil).GetNutanixClient), datacenterConfig, creds)
+}
@@ -187,6 +187,14 @@ func (r *NodeUpgradeReconciler) reconcile(ctx context.Context, log logr.Logger,
 		} else {
 			upgraderPod = upgrader.UpgradeSecondaryControlPlanePod(node.Name, upgraderImage, nodeUpgrade.Spec.KubernetesVersion)
 		}
+		kubeVip, err := kubeVipConfigMapExists(ctx, remoteClient)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !kubeVip {
+			log.Info("kube-vip config map not found, upgrading the control plane node without kube-vip", "Node", node.Name)
+			upgrader.RemoveKubeVipManifest(upgraderPod)
+		}
 	} else {
 		upgraderPod = upgrader.UpgradeWorkerPod(node.Name, upgraderImage)
 	}
@@ -396,6 +404,19 @@ func patchNodeUpgrade(ctx context.Context, patchHelper *v1beta1patch.Helper, nod
 	return patchHelper.Patch(ctx, &nodeUpgrade, options...)
 }
 
+// kubeVipConfigMapExists checks whether the ControlPlaneUpgrade created the kube-vip config map in the
+// remote cluster. It isn't created for the control planes that don't run kube-vip.
+func kubeVipConfigMapExists(ctx context.Context, remoteClient client.Client) (bool, error) {
+	cm := &corev1.ConfigMap{}
+	if err := remoteClient.Get(ctx, GetNamespacedNameType(constants.KubeVipConfigMapName, constants.EksaSystemNamespace), cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("getting %s config map: %v", constants.KubeVipConfigMapName, err)
+	}
+	return true, nil
+}
+
 func upgraderPodExists(ctx context.Context, remoteClient client.Client, nodeName string) bool {
 	_, err := getUpgraderPod(ctx, remoteClient, nodeName)
 	return err == nil
@@ -27,6 +27,35 @@ func TestNodeUpgradeReconcilerReconcileFirstControlPlane(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	clientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
 
+	cluster, machine, node, nodeUpgrade, configMap := getObjectsForNodeUpgradeTest()
+	nodeUpgrade.Spec.FirstNodeToBeUpgraded = true
+	nodeUpgrade.Spec.EtcdVersion = ptr.String("v3.5.9-eks-1-28-9")
+	node.Labels = map[string]string{
+		"node-role.kubernetes.io/control-plane": "true",
+	}
+	client := fake.NewClientBuilder().WithRuntimeObjects(cluster, machine, node, nodeUpgrade, configMap, generateKubeVipConfigMap()).
+		WithStatusSubresource(nodeUpgrade).
+		Build()
+
+	clientRegistry.EXPECT().GetClient(ctx, types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}).Return(client, nil)
+
+	r := controllers.NewNodeUpgradeReconciler(client, clientRegistry)
+	req := nodeUpgradeRequest(nodeUpgrade)
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	pod := &corev1.Pod{}
+	err = client.Get(ctx, types.NamespacedName{Name: upgrader.PodName(node.Name), Namespace: "eksa-system"}, pod)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pod.Spec.Volumes).To(ContainElement(HaveField("Name", "kube-vip")))
+}
+
+func TestNodeUpgradeReconcilerReconcileFirstControlPlaneWithoutKubeVip(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	clientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
+
 	cluster, machine, node, nodeUpgrade, configMap := getObjectsForNodeUpgradeTest()
 	nodeUpgrade.Spec.FirstNodeToBeUpgraded = true
 	nodeUpgrade.Spec.EtcdVersion = ptr.String("v3.5.9-eks-1-28-9")
@@ -47,6 +76,8 @@ func TestNodeUpgradeReconcilerReconcileFirstControlPlane(t *testing.T) {
 	pod := &corev1.Pod{}
 	err = client.Get(ctx, types.NamespacedName{Name: upgrader.PodName(node.Name), Namespace: "eksa-system"}, pod)
 	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pod.Spec.Volumes).NotTo(ContainElement(HaveField("Name", "kube-vip")))
+	g.Expect(pod.Spec.InitContainers[0].VolumeMounts).NotTo(ContainElement(HaveField("Name", "kube-vip")))
 }
 
 func TestNodeUpgradeReconcilerReconcileNextControlPlane(t *testing.T) {
@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	nutanixv1 "github.com/nutanix-cloud-native/cluster-api-provider-nutanix/api/v1beta1"
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+	nutanixreconciler "github.com/aws/eks-anywhere/pkg/providers/nutanix/reconciler"
+)
+
+// NutanixClientGetter returns the Prism Central client of a NutanixDatacenterConfig.
+type NutanixClientGetter interface {
+	GetNutanixClient(datacenterConfig *anywherev1.NutanixDatacenterConfig, creds credentials.BasicAuthCredential) (nutanix.Client, error)
+}
+
+// NutanixDataDiskReconciler attaches the data disks of the NutanixMachines of machine configs with data disks
+// to their VM. CAPX creates the VMs with their system disk only, the disks are listed in an annotation the
+// NutanixMachines get from their template.
+type NutanixDataDiskReconciler struct {
+	client  client.Client
+	clients NutanixClientGetter
+	log     logr.Logger
+}
+
+// NewNutanixDataDiskReconciler returns a new instance of NutanixDataDiskReconciler.
+func NewNutanixDataDiskReconciler(client client.Client, clients NutanixClientGetter) *NutanixDataDiskReconciler {
+	return &NutanixDataDiskReconciler{
+		client:  client,
+		clients: clients,
+		log:     ctrl.Log.WithName("NutanixDataDiskController"),
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NutanixDataDiskReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("nutanixdatadisk").
+		For(&nutanixv1.NutanixMachine{}, builder.WithPredicates(predicate.NewPredicateFuncs(hasNutanixDataDisks))).
+		Complete(r)
+}
+
+func hasNutanixDataDisks(o client.Object) bool {
+	_, ok := o.GetAnnotations()[nutanix.DataDisksAnnotation]
+	return ok
+}
+
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=nutanixmachines,verbs=get;list;watch;update;patch
+
+// Reconcile attaches the data disks of a NutanixMachine to its VM once CAPX has created it, and records
+// it in an annotation so they are only attached once. The mount script of the node waits for the disks.
+func (r *NutanixDataDiskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := r.log.WithValues("NutanixMachine", req.NamespacedName)
+
+	nutanixMachine := &nutanixv1.NutanixMachine{}
+	if err := r.client.Get(ctx, req.NamespacedName, nutanixMachine); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if _, attached := nutanixMachine.Annotations[nutanix.DataDisksAttachedAnnotation]; attached || !hasNutanixDataDisks(nutanixMachine) || !nutanixMachine.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	if nutanixMachine.Status.VmUUID == "" {
+		log.Info("Waiting for VM to be created")
+		return ctrl.Result{}, nil
+	}
+
+	disks, err := nutanix.DataDisksFromAnnotation(nutanixMachine.Annotations[nutanix.DataDisksAnnotation])
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	clusterName, ok := nutanixMachine.Labels[clusterv1beta2.ClusterNameLabel]
+	if !ok {
+		return ctrl.Result{}, fmt.Errorf("nutanixmachine has no %s label", clusterv1beta2.ClusterNameLabel)
+	}
+
+	datacenter, err := getNutanixDatacenter(ctx, r.client, client.ObjectKey{Name: clusterName, Namespace: nutanixMachine.Namespace})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if datacenter.Spec.CredentialRef == nil {
+		return ctrl.Result{}, fmt.Errorf("NutanixDatacenterConfig %s has no credentialRef", datacenter.Name)
+	}
+
+	creds, err := nutanixreconciler.GetNutanixCredsFromSecret(ctx, r.client, datacenter.Spec.CredentialRef.Name, constants.EksaSystemNamespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	nutanixClient, err := r.clients.GetNutanixClient(datacenter, creds)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := nutanix.AttachDataDisks(ctx, nutanixClient, nutanixMachine.Status.VmUUID, disks); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	patchHelper, err := patch.NewHelper(nutanixMachine, r.client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, nutanixMachine); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, fmt.Errorf("patching nutanixmachine: %v", err)})
+		}
+	}()
+
+	nutanixMachine.Annotations[nutanix.DataDisksAttachedAnnotation] = "true"
+	log.Info("Data disks attached to VM", "vm", nutanixMachine.Status.VmUUID, "disks", len(disks))
+
+	return ctrl.Result{}, nil
+}
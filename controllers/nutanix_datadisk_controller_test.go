@@ -0,0 +1,149 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	nutanixv1 "github.com/nutanix-cloud-native/cluster-api-provider-nutanix/api/v1beta1"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/controllers"
+	"github.com/aws/eks-anywhere/controllers/mocks"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+	nutanixmocks "github.com/aws/eks-anywhere/pkg/providers/nutanix/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const nutanixDataDiskVMUUID = "vm-uuid"
+
+func TestNutanixDataDiskReconcilerAttachesDisks(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	machine := nutanixDataDiskMachine("worker-1", nutanixDataDiskVMUUID)
+	c := newNutanixIPPoolClient(g, append(nutanixDataDiskClusterObjects(), machine)...)
+	ctrl := gomock.NewController(t)
+	clients := mocks.NewMockNutanixClientGetter(ctrl)
+	nutanixClient := nutanixmocks.NewMockClient(ctrl)
+
+	clients.EXPECT().GetNutanixClient(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(datacenter *anywherev1.NutanixDatacenterConfig, creds interface{}) (nutanix.Client, error) {
+			g.Expect(datacenter.Name).To(Equal("test"))
+			return nutanixClient, nil
+		})
+	nutanixClient.EXPECT().GetVM(ctx, nutanixDataDiskVMUUID).Return(&v3.VMIntentResponse{
+		Metadata: &v3.Metadata{Kind: ptr.String("vm")},
+		Spec: &v3.VM{
+			ClusterReference: &v3.Reference{UUID: ptr.String("cluster-uuid")},
+			Resources:        &v3.VMResources{},
+		},
+	}, nil)
+	nutanixClient.EXPECT().UpdateVM(ctx, nutanixDataDiskVMUUID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, body *v3.VMIntentInput) (*v3.VMIntentResponse, error) {
+			g.Expect(body.Spec.Resources.DiskList).To(HaveLen(1))
+			g.Expect(*body.Spec.Resources.DiskList[0].DiskSizeMib).To(Equal(int64(102400)))
+			return &v3.VMIntentResponse{}, nil
+		})
+
+	r := controllers.NewNutanixDataDiskReconciler(c, clients)
+	_, err := r.Reconcile(ctx, nutanixIPPoolRequest(machine))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(machine), machine)).To(Succeed())
+	g.Expect(machine.Annotations).To(HaveKeyWithValue(nutanix.DataDisksAttachedAnnotation, "true"))
+}
+
+func TestNutanixDataDiskReconcilerWaitsForVM(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	machine := nutanixDataDiskMachine("worker-1", "")
+	c := newNutanixIPPoolClient(g, append(nutanixDataDiskClusterObjects(), machine)...)
+	clients := mocks.NewMockNutanixClientGetter(gomock.NewController(t))
+
+	r := controllers.NewNutanixDataDiskReconciler(c, clients)
+	_, err := r.Reconcile(ctx, nutanixIPPoolRequest(machine))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(machine), machine)).To(Succeed())
+	g.Expect(machine.Annotations).NotTo(HaveKey(nutanix.DataDisksAttachedAnnotation))
+}
+
+func TestNutanixDataDiskReconcilerAlreadyAttached(t *testing.T) {
+	g := NewWithT(t)
+	machine := nutanixDataDiskMachine("worker-1", nutanixDataDiskVMUUID)
+	machine.Annotations[nutanix.DataDisksAttachedAnnotation] = "true"
+	c := newNutanixIPPoolClient(g, append(nutanixDataDiskClusterObjects(), machine)...)
+	clients := mocks.NewMockNutanixClientGetter(gomock.NewController(t))
+
+	r := controllers.NewNutanixDataDiskReconciler(c, clients)
+	_, err := r.Reconcile(context.Background(), nutanixIPPoolRequest(machine))
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestNutanixDataDiskReconcilerAttachError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	machine := nutanixDataDiskMachine("worker-1", nutanixDataDiskVMUUID)
+	c := newNutanixIPPoolClient(g, append(nutanixDataDiskClusterObjects(), machine)...)
+	ctrl := gomock.NewController(t)
+	clients := mocks.NewMockNutanixClientGetter(ctrl)
+	nutanixClient := nutanixmocks.NewMockClient(ctrl)
+
+	clients.EXPECT().GetNutanixClient(gomock.Any(), gomock.Any()).Return(nutanixClient, nil)
+	nutanixClient.EXPECT().GetVM(ctx, nutanixDataDiskVMUUID).Return(nil, errors.New("vm not found"))
+
+	r := controllers.NewNutanixDataDiskReconciler(c, clients)
+	_, err := r.Reconcile(ctx, nutanixIPPoolRequest(machine))
+	g.Expect(err).To(MatchError(ContainSubstring("vm not found")))
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(machine), machine)).To(Succeed())
+	g.Expect(machine.Annotations).NotTo(HaveKey(nutanix.DataDisksAttachedAnnotation))
+}
+
+func TestNutanixDataDiskReconcilerNotFound(t *testing.T) {
+	g := NewWithT(t)
+	c := newNutanixIPPoolClient(g)
+	clients := mocks.NewMockNutanixClientGetter(gomock.NewController(t))
+
+	r := controllers.NewNutanixDataDiskReconciler(c, clients)
+	_, err := r.Reconcile(context.Background(), nutanixIPPoolRequest(nutanixDataDiskMachine("worker-1", "")))
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func nutanixDataDiskMachine(name, vmUUID string) *nutanixv1.NutanixMachine {
+	return &nutanixv1.NutanixMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1beta2.ClusterNameLabel: "test"},
+			Annotations: map[string]string{
+				nutanix.DataDisksAnnotation: `[{"size":"100Gi","adapterType":"SCSI","deviceIndex":1}]`,
+			},
+		},
+		Status: nutanixv1.NutanixMachineStatus{VmUUID: vmUUID},
+	}
+}
+
+func nutanixDataDiskClusterObjects() []client.Object {
+	objs := nutanixIPPoolClusterObjects()
+	for _, obj := range objs {
+		if datacenter, ok := obj.(*anywherev1.NutanixDatacenterConfig); ok {
+			datacenter.Spec.CredentialRef = &anywherev1.Ref{Kind: constants.SecretKind, Name: "nutanix-credentials"}
+		}
+	}
+
+	return append(objs, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "nutanix-credentials", Namespace: constants.EksaSystemNamespace},
+		Data: map[string][]byte{
+			"credentials": []byte(`[{"type":"basic_auth","data":{"prismCentral":{"username":"admin","password":"password"}}}]`),
+		},
+	})
+}
@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1beta1 "sigs.k8s.io/cluster-api/api/ipam/v1beta1"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+)
+
+// NutanixIPAddressClaimReconciler allocates addresses from the in-cluster ipPools of NutanixDatacenterConfigs
+// to the IPAddressClaims the NutanixIPPoolReconciler creates for the machines using them.
+// The claims reference the NutanixDatacenterConfig as pool and the name of its ipPool in an annotation.
+type NutanixIPAddressClaimReconciler struct {
+	client client.Client
+	// uncachedClient reads the addresses in use directly from the API server, so an address
+	// created for a claim is never handed out again because the cache hasn't observed it yet.
+	uncachedClient client.Reader
+	log            logr.Logger
+}
+
+// NewNutanixIPAddressClaimReconciler returns a new instance of NutanixIPAddressClaimReconciler.
+func NewNutanixIPAddressClaimReconciler(client client.Client, uncachedClient client.Reader) *NutanixIPAddressClaimReconciler {
+	return &NutanixIPAddressClaimReconciler{
+		client:         client,
+		uncachedClient: uncachedClient,
+		log:            ctrl.Log.WithName("NutanixIPAddressClaimController"),
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NutanixIPAddressClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("nutanixipaddressclaim").
+		For(&ipamv1beta1.IPAddressClaim{}, builder.WithPredicates(predicate.NewPredicateFuncs(isNutanixIPPoolClaim))).
+		Owns(&ipamv1beta1.IPAddress{}).
+		Complete(r)
+}
+
+func isNutanixIPPoolClaim(o client.Object) bool {
+	claim, ok := o.(*ipamv1beta1.IPAddressClaim)
+	if !ok {
+		return false
+	}
+	return isNutanixIPPoolRef(claim.Spec.PoolRef.APIGroup, claim.Spec.PoolRef.Kind)
+}
+
+func isNutanixIPPoolRef(apiGroup *string, kind string) bool {
+	return apiGroup != nil && *apiGroup == anywherev1.GroupVersion.Group && kind == anywherev1.NutanixDatacenterKind
+}
+
+//+kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddresses,verbs=get;list;watch;create;delete
+
+// Reconcile assigns the first free address of the claimed ipPool to an IPAddressClaim.
+// The IPAddress is owned by the claim, so it's garbage collected and its address released when
+// the claim is deleted together with the machine.
+func (r *NutanixIPAddressClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := r.log.WithValues("IPAddressClaim", req.NamespacedName)
+
+	claim := &ipamv1beta1.IPAddressClaim{}
+	if err := r.client.Get(ctx, req.NamespacedName, claim); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !isNutanixIPPoolClaim(claim) || !claim.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(claim, r.client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, claim); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, fmt.Errorf("patching ipaddressclaim: %v", err)})
+		}
+	}()
+
+	address, err := r.reconcileAddress(ctx, log, claim)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	claim.Status.AddressRef.Name = address.Name
+	markIPAddressClaimReady(claim)
+
+	return ctrl.Result{}, nil
+}
+
+func (r *NutanixIPAddressClaimReconciler) reconcileAddress(ctx context.Context, log logr.Logger, claim *ipamv1beta1.IPAddressClaim) (*ipamv1beta1.IPAddress, error) {
+	// The address is named after the claim, if it exists the claim has already been fulfilled.
+	address := &ipamv1beta1.IPAddress{}
+	err := r.uncachedClient.Get(ctx, client.ObjectKeyFromObject(claim), address)
+	if err == nil {
+		return address, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("getting ipaddress: %v", err)
+	}
+
+	clusterName := claim.Spec.ClusterName
+	datacenter, pool, err := getNutanixIPPool(ctx, r.client, client.ObjectKey{Name: clusterName, Namespace: claim.Namespace}, claim.Annotations[nutanix.IPPoolAnnotation])
+	if err != nil {
+		return nil, err
+	}
+	if datacenter.Name != claim.Spec.PoolRef.Name {
+		return nil, fmt.Errorf("cluster %s uses NutanixDatacenterConfig %s, not %s", clusterName, datacenter.Name, claim.Spec.PoolRef.Name)
+	}
+
+	addresses := &ipamv1beta1.IPAddressList{}
+	if err := r.uncachedClient.List(ctx, addresses, client.InNamespace(claim.Namespace), client.MatchingLabels{nutanix.IPPoolAnnotation: pool.Name}); err != nil {
+		return nil, fmt.Errorf("listing ipaddresses: %v", err)
+	}
+	inUse := map[string]bool{}
+	for _, a := range addresses.Items {
+		if isNutanixIPPoolRef(a.Spec.PoolRef.APIGroup, a.Spec.PoolRef.Kind) && a.Spec.PoolRef.Name == datacenter.Name {
+			inUse[a.Spec.Address] = true
+		}
+	}
+
+	ip, ok := pool.FirstAvailable(inUse)
+	if !ok {
+		return nil, fmt.Errorf("ipPool %s has no free address", pool.Name)
+	}
+
+	address = &ipamv1beta1.IPAddress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claim.Name,
+			Namespace: claim.Namespace,
+			Labels: map[string]string{
+				nutanix.IPPoolAnnotation:        pool.Name,
+				clusterv1beta2.ClusterNameLabel: clusterName,
+			},
+		},
+		Spec: ipamv1beta1.IPAddressSpec{
+			ClaimRef: corev1.LocalObjectReference{Name: claim.Name},
+			PoolRef:  claim.Spec.PoolRef,
+			Address:  ip,
+			Prefix:   pool.Prefix,
+			Gateway:  pool.Gateway,
+		},
+	}
+	if err := controllerutil.SetControllerReference(claim, address, r.client.Scheme()); err != nil {
+		return nil, err
+	}
+
+	log.Info("Allocating address from Nutanix ipPool", "pool", pool.Name, "address", ip)
+	if err := r.client.Create(ctx, address); err != nil {
+		return nil, fmt.Errorf("creating ipaddress: %v", err)
+	}
+
+	return address, nil
+}
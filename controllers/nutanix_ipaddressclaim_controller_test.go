@@ -0,0 +1,125 @@
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1beta1 "sigs.k8s.io/cluster-api/api/ipam/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/controllers"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+)
+
+func TestNutanixIPAddressClaimReconcilerAllocate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	claim := nutanixIPPoolClaim("worker-1")
+	c := newNutanixIPPoolClient(g, append(nutanixIPPoolClusterObjects(), claim,
+		nutanixIPPoolAddress("worker-0", "10.0.0.10"),
+	)...)
+
+	r := controllers.NewNutanixIPAddressClaimReconciler(c, c)
+	_, err := r.Reconcile(ctx, nutanixIPPoolRequest(claim))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	address := &ipamv1beta1.IPAddress{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(claim), address)).To(Succeed())
+	g.Expect(address.Spec.Address).To(Equal("10.0.0.20"))
+	g.Expect(address.Spec.Prefix).To(Equal(24))
+	g.Expect(address.Spec.Gateway).To(Equal("10.0.0.1"))
+	g.Expect(address.Spec.ClaimRef.Name).To(Equal(claim.Name))
+	g.Expect(address.Labels).To(HaveKeyWithValue(nutanix.IPPoolAnnotation, "workers"))
+	g.Expect(address.Labels).To(HaveKeyWithValue(clusterv1beta2.ClusterNameLabel, "test"))
+	g.Expect(address.OwnerReferences).To(HaveLen(1))
+	g.Expect(address.OwnerReferences[0].Name).To(Equal(claim.Name))
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(claim), claim)).To(Succeed())
+	g.Expect(claim.Status.AddressRef.Name).To(Equal(claim.Name))
+	g.Expect(claim.Status.Conditions).To(HaveLen(1))
+	g.Expect(claim.Status.Conditions[0].Type).To(Equal(clusterv1beta1.ReadyCondition))
+	g.Expect(claim.Status.Conditions[0].Status).To(Equal(corev1.ConditionTrue))
+}
+
+func TestNutanixIPAddressClaimReconcilerReadsAddressesInUseUncached(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	claim := nutanixIPPoolClaim("worker-1")
+	c := newNutanixIPPoolClient(g, append(nutanixIPPoolClusterObjects(), claim)...)
+	// The cache hasn't observed the address allocated to worker-0 yet.
+	uncached := newNutanixIPPoolClient(g, nutanixIPPoolAddress("worker-0", "10.0.0.10"))
+
+	r := controllers.NewNutanixIPAddressClaimReconciler(c, uncached)
+	_, err := r.Reconcile(ctx, nutanixIPPoolRequest(claim))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	address := &ipamv1beta1.IPAddress{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(claim), address)).To(Succeed())
+	g.Expect(address.Spec.Address).To(Equal("10.0.0.20"))
+}
+
+func TestNutanixIPAddressClaimReconcilerExistingAddress(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	claim := nutanixIPPoolClaim("worker-1")
+	c := newNutanixIPPoolClient(g, append(nutanixIPPoolClusterObjects(), claim,
+		nutanixIPPoolAddress("worker-1", "10.0.0.10"),
+	)...)
+
+	r := controllers.NewNutanixIPAddressClaimReconciler(c, c)
+	_, err := r.Reconcile(ctx, nutanixIPPoolRequest(claim))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	addresses := &ipamv1beta1.IPAddressList{}
+	g.Expect(c.List(ctx, addresses)).To(Succeed())
+	g.Expect(addresses.Items).To(HaveLen(1))
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(claim), claim)).To(Succeed())
+	g.Expect(claim.Status.AddressRef.Name).To(Equal(claim.Name))
+}
+
+func TestNutanixIPAddressClaimReconcilerPoolExhausted(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	claim := nutanixIPPoolClaim("worker-2")
+	c := newNutanixIPPoolClient(g, append(nutanixIPPoolClusterObjects(), claim,
+		nutanixIPPoolAddress("worker-0", "10.0.0.10"),
+		nutanixIPPoolAddress("worker-1", "10.0.0.20"),
+	)...)
+
+	r := controllers.NewNutanixIPAddressClaimReconciler(c, c)
+	_, err := r.Reconcile(ctx, nutanixIPPoolRequest(claim))
+	g.Expect(err).To(MatchError(ContainSubstring("ipPool workers has no free address")))
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(claim), claim)).To(Succeed())
+	g.Expect(claim.Status.AddressRef.Name).To(BeEmpty())
+}
+
+func TestNutanixIPAddressClaimReconcilerOtherPool(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	claim := nutanixIPPoolClaim("worker-1")
+	claim.Spec.PoolRef.Kind = "InClusterIPPool"
+	c := newNutanixIPPoolClient(g, append(nutanixIPPoolClusterObjects(), claim)...)
+
+	r := controllers.NewNutanixIPAddressClaimReconciler(c, c)
+	_, err := r.Reconcile(ctx, nutanixIPPoolRequest(claim))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	addresses := &ipamv1beta1.IPAddressList{}
+	g.Expect(c.List(ctx, addresses)).To(Succeed())
+	g.Expect(addresses.Items).To(BeEmpty())
+}
+
+func TestNutanixIPAddressClaimReconcilerNotFound(t *testing.T) {
+	g := NewWithT(t)
+	c := newNutanixIPPoolClient(g)
+
+	r := controllers.NewNutanixIPAddressClaimReconciler(c, c)
+	_, err := r.Reconcile(context.Background(), nutanixIPPoolRequest(nutanixIPPoolClaim("worker-1")))
+	g.Expect(err).NotTo(HaveOccurred())
+}
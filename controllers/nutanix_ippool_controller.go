@@ -0,0 +1,268 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	nutanixv1 "github.com/nutanix-cloud-native/cluster-api-provider-nutanix/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1beta1 "sigs.k8s.io/cluster-api/api/ipam/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+// NutanixIPPoolReconciler gives the NutanixMachines of the control plane, etcd and worker machines using
+// an in-cluster ipPool their static address. The machines are created paused from their templates, so CAPX
+// only creates the VM once the address has been claimed from the pool and written to its bootstrap data.
+type NutanixIPPoolReconciler struct {
+	client client.Client
+	// uncachedClient reads the IPAddress of a claim directly from the API server, since it's
+	// created by the NutanixIPAddressClaimReconciler right before the claim status is updated.
+	uncachedClient client.Reader
+	log            logr.Logger
+}
+
+// NewNutanixIPPoolReconciler returns a new instance of NutanixIPPoolReconciler.
+func NewNutanixIPPoolReconciler(client client.Client, uncachedClient client.Reader) *NutanixIPPoolReconciler {
+	return &NutanixIPPoolReconciler{
+		client:         client,
+		uncachedClient: uncachedClient,
+		log:            ctrl.Log.WithName("NutanixIPPoolController"),
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NutanixIPPoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&nutanixv1.NutanixMachine{}, builder.WithPredicates(predicate.NewPredicateFuncs(usesNutanixIPPool))).
+		Owns(&ipamv1beta1.IPAddressClaim{}).
+		Watches(
+			&clusterv1beta2.Machine{},
+			handler.EnqueueRequestsFromMapFunc(machineToNutanixMachine),
+		).
+		Complete(r)
+}
+
+func usesNutanixIPPool(o client.Object) bool {
+	_, ok := o.GetAnnotations()[nutanix.IPPoolAnnotation]
+	return ok
+}
+
+// machineToNutanixMachine enqueues the NutanixMachine of a Machine, so the bootstrap data secret
+// is updated as soon as the Machine references it.
+func machineToNutanixMachine(_ context.Context, o client.Object) []reconcile.Request {
+	machine, ok := o.(*clusterv1beta2.Machine)
+	if !ok || machine.Spec.InfrastructureRef.Kind != "NutanixMachine" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: machine.Spec.InfrastructureRef.Name, Namespace: machine.Namespace}}}
+}
+
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=nutanixmachines,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch
+//+kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims,verbs=get;list;watch;create;update;patch
+
+// Reconcile claims an address from the ipPool of a paused NutanixMachine, adds it to the bootstrap data
+// of its Machine and unpauses it. The IPAddressClaim is owned by the NutanixMachine, so the address is
+// released when the machine is deleted.
+func (r *NutanixIPPoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := r.log.WithValues("NutanixMachine", req.NamespacedName)
+
+	nutanixMachine := &nutanixv1.NutanixMachine{}
+	if err := r.client.Get(ctx, req.NamespacedName, nutanixMachine); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// the address has already been added to the bootstrap data once the machine is unpaused
+	if _, paused := nutanixMachine.Annotations[clusterv1beta2.PausedAnnotation]; !paused || !usesNutanixIPPool(nutanixMachine) || !nutanixMachine.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	claim, err := r.reconcileClaim(ctx, log, nutanixMachine)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if claim.Status.AddressRef.Name == "" {
+		log.Info("Waiting for address to be allocated", "IPAddressClaim", claim.Name)
+		return ctrl.Result{}, nil
+	}
+
+	address := &ipamv1beta1.IPAddress{}
+	if err := r.uncachedClient.Get(ctx, client.ObjectKey{Name: claim.Status.AddressRef.Name, Namespace: claim.Namespace}, address); err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting ipaddress: %v", err)
+	}
+
+	machine, err := util.GetOwnerMachine(ctx, r.client, nutanixMachine.ObjectMeta)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if machine == nil || machine.Spec.Bootstrap.DataSecretName == nil {
+		log.Info("Waiting for machine bootstrap data")
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.addAddressToBootstrapData(ctx, machine, address, claim); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	patchHelper, err := patch.NewHelper(nutanixMachine, r.client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, nutanixMachine); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, fmt.Errorf("patching nutanixmachine: %v", err)})
+		}
+	}()
+
+	delete(nutanixMachine.Annotations, clusterv1beta2.PausedAnnotation)
+	log.Info("Static address added to machine bootstrap data", "address", address.Spec.Address)
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileClaim returns the IPAddressClaim of a NutanixMachine, creating it if it doesn't exist yet.
+// The claim is named after the machine and references the NutanixDatacenterConfig defining the ipPool.
+func (r *NutanixIPPoolReconciler) reconcileClaim(ctx context.Context, log logr.Logger, nutanixMachine *nutanixv1.NutanixMachine) (*ipamv1beta1.IPAddressClaim, error) {
+	claim := &ipamv1beta1.IPAddressClaim{}
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(nutanixMachine), claim)
+	if err == nil {
+		return claim, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("getting ipaddressclaim: %v", err)
+	}
+
+	clusterName, ok := nutanixMachine.Labels[clusterv1beta2.ClusterNameLabel]
+	if !ok {
+		return nil, fmt.Errorf("nutanixmachine has no %s label", clusterv1beta2.ClusterNameLabel)
+	}
+
+	poolName := nutanixMachine.Annotations[nutanix.IPPoolAnnotation]
+	datacenter, pool, err := getNutanixIPPool(ctx, r.client, client.ObjectKey{Name: clusterName, Namespace: nutanixMachine.Namespace}, poolName)
+	if err != nil {
+		return nil, err
+	}
+
+	claim = &ipamv1beta1.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nutanixMachine.Name,
+			Namespace: nutanixMachine.Namespace,
+			Labels: map[string]string{
+				clusterv1beta2.ClusterNameLabel: clusterName,
+			},
+			Annotations: map[string]string{
+				nutanix.IPPoolAnnotation: pool.Name,
+			},
+		},
+		Spec: ipamv1beta1.IPAddressClaimSpec{
+			ClusterName: clusterName,
+			PoolRef: corev1.TypedLocalObjectReference{
+				APIGroup: ptr.String(anywherev1.GroupVersion.Group),
+				Kind:     anywherev1.NutanixDatacenterKind,
+				Name:     datacenter.Name,
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(nutanixMachine, claim, r.client.Scheme()); err != nil {
+		return nil, err
+	}
+
+	log.Info("Claiming address from Nutanix ipPool", "pool", pool.Name)
+	if err := r.client.Create(ctx, claim); err != nil {
+		return nil, fmt.Errorf("creating ipaddressclaim: %v", err)
+	}
+
+	return claim, nil
+}
+
+// addAddressToBootstrapData adds the address to the cloud-config the machine is bootstrapped with.
+func (r *NutanixIPPoolReconciler) addAddressToBootstrapData(ctx context.Context, machine *clusterv1beta2.Machine, address *ipamv1beta1.IPAddress, claim *ipamv1beta1.IPAddressClaim) error {
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{Name: *machine.Spec.Bootstrap.DataSecretName, Namespace: machine.Namespace}
+	if err := r.client.Get(ctx, secretKey, secret); err != nil {
+		return fmt.Errorf("getting bootstrap data secret %s: %v", secretKey.Name, err)
+	}
+
+	_, pool, err := getNutanixIPPool(ctx, r.client, client.ObjectKey{Name: claim.Spec.ClusterName, Namespace: claim.Namespace}, claim.Annotations[nutanix.IPPoolAnnotation])
+	if err != nil {
+		return err
+	}
+
+	data, err := nutanix.AddStaticIPToBootstrapData(secret.Data["value"], nutanix.StaticIP{
+		Address:     address.Spec.Address,
+		Prefix:      address.Spec.Prefix,
+		Gateway:     address.Spec.Gateway,
+		Nameservers: pool.Nameservers,
+	})
+	if err != nil {
+		return fmt.Errorf("adding static address to bootstrap data secret %s: %v", secretKey.Name, err)
+	}
+
+	secret.Data["value"] = data
+	if err := r.client.Update(ctx, secret); err != nil {
+		return fmt.Errorf("updating bootstrap data secret %s: %v", secretKey.Name, err)
+	}
+
+	return nil
+}
+
+// getNutanixIPPool returns an ipPool of the EKS-A cluster a CAPI cluster belongs to. The pool is defined
+// in the NutanixDatacenterConfig of the EKS-A cluster.
+func getNutanixIPPool(ctx context.Context, c client.Client, capiClusterKey client.ObjectKey, poolName string) (*anywherev1.NutanixDatacenterConfig, *anywherev1.NutanixIPPool, error) {
+	datacenter, err := getNutanixDatacenter(ctx, c, capiClusterKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pool := datacenter.IPPool(poolName)
+	if pool == nil {
+		return nil, nil, fmt.Errorf("ipPool %s not found in NutanixDatacenterConfig %s", poolName, datacenter.Name)
+	}
+
+	return datacenter, pool, nil
+}
+
+// getNutanixDatacenter returns the NutanixDatacenterConfig of the EKS-A cluster a CAPI cluster belongs to,
+// which is recorded in the labels of the CAPI cluster.
+func getNutanixDatacenter(ctx context.Context, c client.Client, capiClusterKey client.ObjectKey) (*anywherev1.NutanixDatacenterConfig, error) {
+	capiCluster := &clusterv1beta2.Cluster{}
+	if err := c.Get(ctx, capiClusterKey, capiCluster); err != nil {
+		return nil, fmt.Errorf("getting cluster %s: %v", capiClusterKey.Name, err)
+	}
+
+	eksaCluster := &anywherev1.Cluster{}
+	eksaClusterKey := client.ObjectKey{
+		Name:      capiCluster.Labels[clusterapi.EKSAClusterLabelName],
+		Namespace: capiCluster.Labels[clusterapi.EKSAClusterLabelNamespace],
+	}
+	if err := c.Get(ctx, eksaClusterKey, eksaCluster); err != nil {
+		return nil, fmt.Errorf("getting EKS-A cluster %s: %v", eksaClusterKey.Name, err)
+	}
+
+	datacenter := &anywherev1.NutanixDatacenterConfig{}
+	datacenterKey := client.ObjectKey{Name: eksaCluster.Spec.DatacenterRef.Name, Namespace: eksaCluster.Namespace}
+	if err := c.Get(ctx, datacenterKey, datacenter); err != nil {
+		return nil, fmt.Errorf("getting NutanixDatacenterConfig %s: %v", datacenterKey.Name, err)
+	}
+
+	return datacenter, nil
+}
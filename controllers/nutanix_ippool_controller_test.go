@@ -0,0 +1,319 @@
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	nutanixv1 "github.com/nutanix-cloud-native/cluster-api-provider-nutanix/api/v1beta1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1beta1 "sigs.k8s.io/cluster-api/api/ipam/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const nutanixIPPoolBootstrapData = `## template: jinja
+#cloud-config
+
+runcmd:
+- kubeadm join --config /run/kubeadm/kubeadm-join-config.yaml
+`
+
+func TestNutanixIPPoolReconcilerCreatesClaim(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	nutanixMachine := nutanixIPPoolMachine("worker-1")
+	c := newNutanixIPPoolClient(g, append(nutanixIPPoolClusterObjects(), nutanixMachine)...)
+
+	r := controllers.NewNutanixIPPoolReconciler(c, c)
+	_, err := r.Reconcile(ctx, nutanixIPPoolRequest(nutanixMachine))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	claim := &ipamv1beta1.IPAddressClaim{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(nutanixMachine), claim)).To(Succeed())
+	g.Expect(claim.Spec.ClusterName).To(Equal("test"))
+	g.Expect(claim.Spec.PoolRef).To(Equal(corev1.TypedLocalObjectReference{
+		APIGroup: ptr.String(anywherev1.GroupVersion.Group),
+		Kind:     anywherev1.NutanixDatacenterKind,
+		Name:     "test",
+	}))
+	g.Expect(claim.Annotations).To(HaveKeyWithValue(nutanix.IPPoolAnnotation, "workers"))
+	g.Expect(claim.Labels).To(HaveKeyWithValue(clusterv1beta2.ClusterNameLabel, "test"))
+	g.Expect(claim.OwnerReferences).To(HaveLen(1))
+	g.Expect(claim.OwnerReferences[0].Name).To(Equal(nutanixMachine.Name))
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(nutanixMachine), nutanixMachine)).To(Succeed())
+	g.Expect(nutanixMachine.Annotations).To(HaveKey(clusterv1beta2.PausedAnnotation))
+}
+
+func TestNutanixIPPoolReconcilerAddsAddressToBootstrapData(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	nutanixMachine := nutanixIPPoolMachine("worker-1")
+	secret := nutanixIPPoolBootstrapSecret("worker-1")
+	c := newNutanixIPPoolClient(g, append(nutanixIPPoolClusterObjects(),
+		nutanixMachine,
+		nutanixIPPoolOwnerMachine("worker-1", ptr.String(secret.Name)),
+		secret,
+		nutanixIPPoolFulfilledClaim("worker-1"),
+		nutanixIPPoolAddress("worker-1", "10.0.0.20"),
+	)...)
+
+	r := controllers.NewNutanixIPPoolReconciler(c, c)
+	_, err := r.Reconcile(ctx, nutanixIPPoolRequest(nutanixMachine))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+	want, err := nutanix.AddStaticIPToBootstrapData([]byte(nutanixIPPoolBootstrapData), nutanix.StaticIP{
+		Address:     "10.0.0.20",
+		Prefix:      24,
+		Gateway:     "10.0.0.1",
+		Nameservers: []string{"10.0.0.2", "10.0.0.3"},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(secret.Data["value"])).To(Equal(string(want)))
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(nutanixMachine), nutanixMachine)).To(Succeed())
+	g.Expect(nutanixMachine.Annotations).NotTo(HaveKey(clusterv1beta2.PausedAnnotation))
+}
+
+func TestNutanixIPPoolReconcilerWaitsForAddress(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	nutanixMachine := nutanixIPPoolMachine("worker-1")
+	claim := nutanixIPPoolFulfilledClaim("worker-1")
+	claim.Status.AddressRef.Name = ""
+	c := newNutanixIPPoolClient(g, append(nutanixIPPoolClusterObjects(), nutanixMachine, claim)...)
+
+	r := controllers.NewNutanixIPPoolReconciler(c, c)
+	_, err := r.Reconcile(ctx, nutanixIPPoolRequest(nutanixMachine))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(nutanixMachine), nutanixMachine)).To(Succeed())
+	g.Expect(nutanixMachine.Annotations).To(HaveKey(clusterv1beta2.PausedAnnotation))
+}
+
+func TestNutanixIPPoolReconcilerWaitsForBootstrapData(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	nutanixMachine := nutanixIPPoolMachine("worker-1")
+	c := newNutanixIPPoolClient(g, append(nutanixIPPoolClusterObjects(),
+		nutanixMachine,
+		nutanixIPPoolOwnerMachine("worker-1", nil),
+		nutanixIPPoolFulfilledClaim("worker-1"),
+		nutanixIPPoolAddress("worker-1", "10.0.0.20"),
+	)...)
+
+	r := controllers.NewNutanixIPPoolReconciler(c, c)
+	_, err := r.Reconcile(ctx, nutanixIPPoolRequest(nutanixMachine))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(nutanixMachine), nutanixMachine)).To(Succeed())
+	g.Expect(nutanixMachine.Annotations).To(HaveKey(clusterv1beta2.PausedAnnotation))
+}
+
+func TestNutanixIPPoolReconcilerUnpaused(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	nutanixMachine := nutanixIPPoolMachine("worker-1")
+	delete(nutanixMachine.Annotations, clusterv1beta2.PausedAnnotation)
+	c := newNutanixIPPoolClient(g, nutanixMachine)
+
+	r := controllers.NewNutanixIPPoolReconciler(c, c)
+	_, err := r.Reconcile(ctx, nutanixIPPoolRequest(nutanixMachine))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	claims := &ipamv1beta1.IPAddressClaimList{}
+	g.Expect(c.List(ctx, claims)).To(Succeed())
+	g.Expect(claims.Items).To(BeEmpty())
+}
+
+func TestNutanixIPPoolReconcilerPoolNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	nutanixMachine := nutanixIPPoolMachine("worker-1")
+	nutanixMachine.Annotations[nutanix.IPPoolAnnotation] = "other"
+	c := newNutanixIPPoolClient(g, append(nutanixIPPoolClusterObjects(), nutanixMachine)...)
+
+	r := controllers.NewNutanixIPPoolReconciler(c, c)
+	_, err := r.Reconcile(ctx, nutanixIPPoolRequest(nutanixMachine))
+	g.Expect(err).To(MatchError("ipPool other not found in NutanixDatacenterConfig test"))
+}
+
+func TestNutanixIPPoolReconcilerNotFound(t *testing.T) {
+	g := NewWithT(t)
+	c := newNutanixIPPoolClient(g)
+
+	r := controllers.NewNutanixIPPoolReconciler(c, c)
+	_, err := r.Reconcile(context.Background(), nutanixIPPoolRequest(nutanixIPPoolMachine("worker-1")))
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func newNutanixIPPoolClient(g *WithT, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(anywherev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1beta2.AddToScheme(scheme)).To(Succeed())
+	g.Expect(nutanixv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(ipamv1beta1.AddToScheme(scheme)).To(Succeed())
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&ipamv1beta1.IPAddressClaim{}).
+		Build()
+}
+
+func nutanixIPPoolRequest(obj client.Object) reconcile.Request {
+	return reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+		},
+	}
+}
+
+func nutanixIPPoolMachine(name string) *nutanixv1.NutanixMachine {
+	return &nutanixv1.NutanixMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1beta2.ClusterNameLabel: "test"},
+			Annotations: map[string]string{
+				clusterv1beta2.PausedAnnotation: "true",
+				nutanix.IPPoolAnnotation:        "workers",
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: clusterv1beta2.GroupVersion.String(),
+				Kind:       "Machine",
+				Name:       name,
+			}},
+		},
+	}
+}
+
+func nutanixIPPoolOwnerMachine(name string, dataSecretName *string) *clusterv1beta2.Machine {
+	return &clusterv1beta2.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: clusterv1beta2.MachineSpec{
+			ClusterName: "test",
+			Bootstrap: clusterv1beta2.Bootstrap{
+				DataSecretName: dataSecretName,
+			},
+			InfrastructureRef: clusterv1beta2.ContractVersionedObjectReference{
+				APIGroup: nutanixv1.GroupVersion.Group,
+				Kind:     "NutanixMachine",
+				Name:     name,
+			},
+		},
+	}
+}
+
+func nutanixIPPoolBootstrapSecret(name string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-bootstrap",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Data: map[string][]byte{
+			"value": []byte(nutanixIPPoolBootstrapData),
+		},
+	}
+}
+
+func nutanixIPPoolClaim(name string) *ipamv1beta1.IPAddressClaim {
+	return &ipamv1beta1.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   constants.EksaSystemNamespace,
+			Labels:      map[string]string{clusterv1beta2.ClusterNameLabel: "test"},
+			Annotations: map[string]string{nutanix.IPPoolAnnotation: "workers"},
+		},
+		Spec: ipamv1beta1.IPAddressClaimSpec{
+			ClusterName: "test",
+			PoolRef: corev1.TypedLocalObjectReference{
+				APIGroup: ptr.String(anywherev1.GroupVersion.Group),
+				Kind:     anywherev1.NutanixDatacenterKind,
+				Name:     "test",
+			},
+		},
+	}
+}
+
+func nutanixIPPoolFulfilledClaim(name string) *ipamv1beta1.IPAddressClaim {
+	claim := nutanixIPPoolClaim(name)
+	claim.Status.AddressRef.Name = name
+	return claim
+}
+
+func nutanixIPPoolAddress(name, address string) *ipamv1beta1.IPAddress {
+	return &ipamv1beta1.IPAddress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{nutanix.IPPoolAnnotation: "workers"},
+		},
+		Spec: ipamv1beta1.IPAddressSpec{
+			ClaimRef: corev1.LocalObjectReference{Name: name},
+			PoolRef: corev1.TypedLocalObjectReference{
+				APIGroup: ptr.String(anywherev1.GroupVersion.Group),
+				Kind:     anywherev1.NutanixDatacenterKind,
+				Name:     "test",
+			},
+			Address: address,
+			Prefix:  24,
+			Gateway: "10.0.0.1",
+		},
+	}
+}
+
+func nutanixIPPoolClusterObjects() []client.Object {
+	return []client.Object{
+		&clusterv1beta2.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: constants.EksaSystemNamespace,
+				Labels: map[string]string{
+					clusterapi.EKSAClusterLabelName:      "test",
+					clusterapi.EKSAClusterLabelNamespace: "default",
+				},
+			},
+		},
+		&anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: anywherev1.ClusterSpec{
+				DatacenterRef: anywherev1.Ref{Kind: anywherev1.NutanixDatacenterKind, Name: "test"},
+			},
+		},
+		&anywherev1.NutanixDatacenterConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: anywherev1.NutanixDatacenterConfigSpec{
+				IPPools: []anywherev1.NutanixIPPool{{
+					Name: "workers",
+					Ranges: []anywherev1.NutanixIPRange{
+						{Start: "10.0.0.10", End: "10.0.0.10"},
+						{Start: "10.0.0.20", End: "10.0.0.20"},
+					},
+					Prefix:      24,
+					Gateway:     "10.0.0.1",
+					Nameservers: []string{"10.0.0.2", "10.0.0.3"},
+				}},
+			},
+		},
+	}
+}
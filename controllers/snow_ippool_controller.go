@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+)
+
+// SnowIPPoolReconciler tracks the addresses of a SnowIPPool assigned to machines in its status.
+// CAPAS allocates the addresses from the AWSSnowIPPool built from the SnowIPPool, so the allocations
+// are read from the AWSSnowMachines with a DNI using that pool.
+type SnowIPPoolReconciler struct {
+	client client.Client
+	log    logr.Logger
+}
+
+// NewSnowIPPoolReconciler returns a new instance of SnowIPPoolReconciler.
+func NewSnowIPPoolReconciler(client client.Client) *SnowIPPoolReconciler {
+	return &SnowIPPoolReconciler{
+		client: client,
+		log:    ctrl.Log.WithName("SnowIPPoolController"),
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SnowIPPoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&anywherev1.SnowIPPool{}).
+		Watches(
+			&snowv1.AWSSnowMachine{},
+			handler.EnqueueRequestsFromMapFunc(r.poolsForMachine),
+		).
+		Complete(r)
+}
+
+//+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=snowippools,verbs=get;list;watch
+//+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=snowippools/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awssnowmachines,verbs=get;list;watch
+
+// Reconcile records the addresses of the pool assigned to the existing AWSSnowMachines in the SnowIPPool status.
+// The addresses of deleted machines are no longer recorded, which makes them available again.
+func (r *SnowIPPoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := r.log.WithValues("SnowIPPool", req.NamespacedName)
+
+	pool := &anywherev1.SnowIPPool{}
+	if err := r.client.Get(ctx, req.NamespacedName, pool); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !pool.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(pool, r.client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, pool); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, fmt.Errorf("patching snowippool: %v", err)})
+		}
+	}()
+
+	machines := &snowv1.AWSSnowMachineList{}
+	if err := r.client.List(ctx, machines, client.InNamespace(constants.EksaSystemNamespace)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing awssnowmachines: %v", err)
+	}
+
+	allocations := []anywherev1.SnowIPAllocation{}
+	for _, m := range machines.Items {
+		if !usesSnowIPPool(&m, pool.Name) {
+			continue
+		}
+		for _, address := range m.Status.Addresses {
+			if pool.Contains(address.Address) {
+				allocations = append(allocations, anywherev1.SnowIPAllocation{
+					IP:      address.Address,
+					Machine: m.Name,
+					Cluster: m.Labels[clusterv1beta2.ClusterNameLabel],
+				})
+			}
+		}
+	}
+	sort.Slice(allocations, func(i, j int) bool {
+		return bytes.Compare(net.ParseIP(allocations[i].IP), net.ParseIP(allocations[j].IP)) < 0
+	})
+
+	pool.Status.Allocations = allocations
+	pool.Status.Total = pool.Size()
+	pool.Status.Used = len(allocations)
+	pool.Status.Free = pool.Status.Total - pool.Status.Used
+	log.Info("Updated SnowIPPool allocations", "used", pool.Status.Used, "free", pool.Status.Free)
+
+	return ctrl.Result{}, nil
+}
+
+// poolsForMachine returns the SnowIPPools used by the DNIs of an AWSSnowMachine. The AWSSnowIPPools
+// are created in the eksa-system namespace with the name of the SnowIPPool they are built from.
+func (r *SnowIPPoolReconciler) poolsForMachine(ctx context.Context, o client.Object) []reconcile.Request {
+	machine, ok := o.(*snowv1.AWSSnowMachine)
+	if !ok {
+		return nil
+	}
+
+	pools := &anywherev1.SnowIPPoolList{}
+	if err := r.client.List(ctx, pools); err != nil {
+		r.log.Error(err, "Listing SnowIPPools")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, p := range pools.Items {
+		if usesSnowIPPool(machine, p.Name) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&p)})
+		}
+	}
+	return requests
+}
+
+func usesSnowIPPool(machine *snowv1.AWSSnowMachine, poolName string) bool {
+	for _, dni := range machine.Spec.Network.DirectNetworkInterfaces {
+		if dni.IPPool != nil && dni.IPPool.Kind == snow.SnowIPPoolKind && dni.IPPool.Name == poolName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+)
+
+func TestSnowIPPoolReconcilerAllocations(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	pool := snowIPPool()
+	c := newSnowIPPoolClient(g, pool,
+		snowIPPoolMachine("worker-1", "test", "ip-pool", "1.2.3.6", "10.0.0.1"),
+		snowIPPoolMachine("cp-1", "other", "ip-pool", "1.2.3.4"),
+		snowIPPoolMachine("worker-2", "test", "other-pool", "1.2.3.5"),
+	)
+
+	r := controllers.NewSnowIPPoolReconciler(c)
+	_, err := r.Reconcile(ctx, snowIPPoolRequest(pool))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pool), pool)).To(Succeed())
+	g.Expect(pool.Status).To(Equal(anywherev1.SnowIPPoolStatus{
+		Allocations: []anywherev1.SnowIPAllocation{
+			{IP: "1.2.3.4", Machine: "cp-1", Cluster: "other"},
+			{IP: "1.2.3.6", Machine: "worker-1", Cluster: "test"},
+		},
+		Total: 5,
+		Used:  2,
+		Free:  3,
+	}))
+}
+
+func TestSnowIPPoolReconcilerAllocationsSortedByAddress(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	pool := snowIPPool()
+	pool.Spec.Pools[0].IPEnd = "1.2.3.20"
+	c := newSnowIPPoolClient(g, pool,
+		snowIPPoolMachine("worker-1", "test", "ip-pool", "1.2.3.10"),
+		snowIPPoolMachine("worker-2", "test", "ip-pool", "1.2.3.9"),
+	)
+
+	r := controllers.NewSnowIPPoolReconciler(c)
+	_, err := r.Reconcile(ctx, snowIPPoolRequest(pool))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pool), pool)).To(Succeed())
+	g.Expect(pool.Status.Allocations).To(Equal([]anywherev1.SnowIPAllocation{
+		{IP: "1.2.3.9", Machine: "worker-2", Cluster: "test"},
+		{IP: "1.2.3.10", Machine: "worker-1", Cluster: "test"},
+	}))
+}
+
+func TestSnowIPPoolReconcilerReclaimDeletedMachines(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	pool := snowIPPool()
+	pool.Status = anywherev1.SnowIPPoolStatus{
+		Allocations: []anywherev1.SnowIPAllocation{{IP: "1.2.3.4", Machine: "deleted", Cluster: "test"}},
+		Total:       5,
+		Used:        1,
+		Free:        4,
+	}
+	c := newSnowIPPoolClient(g, pool)
+
+	r := controllers.NewSnowIPPoolReconciler(c)
+	_, err := r.Reconcile(ctx, snowIPPoolRequest(pool))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pool), pool)).To(Succeed())
+	g.Expect(pool.Status.Allocations).To(BeEmpty())
+	g.Expect(pool.Status.Used).To(Equal(0))
+	g.Expect(pool.Status.Free).To(Equal(5))
+}
+
+func TestSnowIPPoolReconcilerNotFound(t *testing.T) {
+	g := NewWithT(t)
+	c := newSnowIPPoolClient(g)
+
+	r := controllers.NewSnowIPPoolReconciler(c)
+	_, err := r.Reconcile(context.Background(), snowIPPoolRequest(snowIPPool()))
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func newSnowIPPoolClient(g *WithT, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	g.Expect(anywherev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(snowv1.AddToScheme(scheme)).To(Succeed())
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&anywherev1.SnowIPPool{}).
+		Build()
+}
+
+func snowIPPoolRequest(pool *anywherev1.SnowIPPool) reconcile.Request {
+	return reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      pool.Name,
+			Namespace: pool.Namespace,
+		},
+	}
+}
+
+func snowIPPool() *anywherev1.SnowIPPool {
+	return &anywherev1.SnowIPPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ip-pool",
+			Namespace: "default",
+		},
+		Spec: anywherev1.SnowIPPoolSpec{
+			Pools: []anywherev1.IPPool{
+				{IPStart: "1.2.3.4", IPEnd: "1.2.3.8", Subnet: "1.2.3.0/24", Gateway: "1.2.3.1"},
+			},
+		},
+	}
+}
+
+func snowIPPoolMachine(name, cluster, pool string, addresses ...string) *snowv1.AWSSnowMachine {
+	machineAddresses := make([]clusterv1.MachineAddress, 0, len(addresses))
+	for _, a := range addresses {
+		machineAddresses = append(machineAddresses, clusterv1.MachineAddress{Type: clusterv1.MachineExternalIP, Address: a})
+	}
+
+	return &snowv1.AWSSnowMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1beta2.ClusterNameLabel: cluster},
+		},
+		Spec: snowv1.AWSSnowMachineSpec{
+			Network: snowv1.AWSSnowNetwork{
+				DirectNetworkInterfaces: []snowv1.AWSSnowDirectNetworkInterface{
+					{
+						Index:  1,
+						IPPool: &corev1.ObjectReference{Kind: snow.SnowIPPoolKind, Name: pool},
+					},
+				},
+			},
+		},
+		Status: snowv1.AWSSnowMachineStatus{
+			Addresses: machineAddresses,
+		},
+	}
+}
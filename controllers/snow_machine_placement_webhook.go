@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+)
+
+// SnowMachinePlacementDefaulter places the AWSSnowMachines created from machine templates with a spread or pack
+// placement on a single device. CAPAS places a machine on any of the devices of its template, so the device is
+// chosen when CAPI creates the machine from the template, before CAPAS provisions it.
+type SnowMachinePlacementDefaulter struct {
+	client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &SnowMachinePlacementDefaulter{}
+
+// NewSnowMachinePlacementDefaulter returns a new instance of SnowMachinePlacementDefaulter. The client should
+// read from the API server, not from a cache, so the machines created just before are counted.
+func NewSnowMachinePlacementDefaulter(client client.Reader) *SnowMachinePlacementDefaulter {
+	return &SnowMachinePlacementDefaulter{
+		client: client,
+	}
+}
+
+// SetupWebhookWithManager sets up the webhook with the Manager.
+func (d *SnowMachinePlacementDefaulter) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&snowv1.AWSSnowMachine{}).
+		WithDefaulter(d).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-awssnowmachine,mutating=true,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=awssnowmachines,verbs=create,versions=v1beta1,name=placement.awssnowmachine.anywhere.amazonaws.com,admissionReviewVersions={v1,v1beta1}
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awssnowmachinetemplates,verbs=get;list;watch
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awssnowmachines,verbs=get;list;watch
+
+// Default sets the devices of a new AWSSnowMachine to the device chosen by the placement policy of its template.
+// Machines already placed on a single device, like the ones moved between clusters, are left untouched.
+func (d *SnowMachinePlacementDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	machine, ok := obj.(*snowv1.AWSSnowMachine)
+	if !ok {
+		return fmt.Errorf("expected an AWSSnowMachine but got %T", obj)
+	}
+
+	templateName, ok := machine.Annotations[clusterv1beta2.TemplateClonedFromNameAnnotation]
+	if !ok || len(machine.Spec.Devices) <= 1 {
+		return nil
+	}
+
+	template := &snowv1.AWSSnowMachineTemplate{}
+	if err := d.client.Get(ctx, client.ObjectKey{Name: templateName, Namespace: machine.Namespace}, template); err != nil {
+		return fmt.Errorf("getting AWSSnowMachineTemplate %s to place AWSSnowMachine: %v", templateName, err)
+	}
+
+	if template.Annotations[snow.PlacementPolicyAnnotation] == "" {
+		return nil
+	}
+
+	machines := &snowv1.AWSSnowMachineList{}
+	if err := d.client.List(ctx, machines, client.InNamespace(machine.Namespace)); err != nil {
+		return fmt.Errorf("listing AWSSnowMachines to place AWSSnowMachine: %v", err)
+	}
+
+	siblings := make([]snowv1.AWSSnowMachine, 0, len(machines.Items))
+	for _, m := range machines.Items {
+		if m.Annotations[clusterv1beta2.TemplateClonedFromNameAnnotation] == templateName && m.Name != machine.Name {
+			siblings = append(siblings, m)
+		}
+	}
+
+	device, err := snow.MachineDevice(template, siblings)
+	if err != nil {
+		return err
+	}
+
+	machine.Spec.Devices = []string{device}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/controllers"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+)
+
+var placementDevices = []string{"1.2.3.4", "1.2.3.5", "1.2.3.6"}
+
+func TestSnowMachinePlacementDefaulterSpread(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSnowPlacementClient(g,
+		snowPlacementTemplate("test-cp-2", map[string]string{snow.PlacementPolicyAnnotation: "spread"}),
+		snowPlacementMachine("cp-1", "test-cp-2", "1.2.3.4"),
+		// Machines of previous templates being replaced aren't counted.
+		snowPlacementMachine("cp-old-1", "test-cp-1", "1.2.3.5"),
+		snowPlacementMachine("cp-old-2", "test-cp-1", "1.2.3.5"),
+	)
+	machine := snowPlacementMachine("cp-2", "test-cp-2", placementDevices...)
+
+	d := controllers.NewSnowMachinePlacementDefaulter(c)
+	g.Expect(d.Default(ctx, machine)).To(Succeed())
+	g.Expect(machine.Spec.Devices).To(Equal([]string{"1.2.3.5"}))
+}
+
+func TestSnowMachinePlacementDefaulterPack(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSnowPlacementClient(g,
+		snowPlacementTemplate("test-md-0-1", map[string]string{
+			snow.PlacementPolicyAnnotation:      "pack",
+			snow.MaxMachinesPerDeviceAnnotation: "2",
+		}),
+		snowPlacementMachine("md-0-1", "test-md-0-1", "1.2.3.4"),
+		snowPlacementMachine("md-0-2", "test-md-0-1", "1.2.3.4"),
+	)
+	machine := snowPlacementMachine("md-0-3", "test-md-0-1", placementDevices...)
+
+	d := controllers.NewSnowMachinePlacementDefaulter(c)
+	g.Expect(d.Default(ctx, machine)).To(Succeed())
+	g.Expect(machine.Spec.Devices).To(Equal([]string{"1.2.3.5"}))
+}
+
+func TestSnowMachinePlacementDefaulterPackDevicesFull(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSnowPlacementClient(g,
+		snowPlacementTemplate("test-md-0-1", map[string]string{
+			snow.PlacementPolicyAnnotation:      "pack",
+			snow.MaxMachinesPerDeviceAnnotation: "1",
+		}),
+		snowPlacementMachine("md-0-1", "test-md-0-1", "1.2.3.4"),
+		snowPlacementMachine("md-0-2", "test-md-0-1", "1.2.3.5"),
+		snowPlacementMachine("md-0-3", "test-md-0-1", "1.2.3.6"),
+	)
+	machine := snowPlacementMachine("md-0-4", "test-md-0-1", placementDevices...)
+
+	d := controllers.NewSnowMachinePlacementDefaulter(c)
+	g.Expect(d.Default(ctx, machine)).To(MatchError("all the devices of AWSSnowMachineTemplate test-md-0-1 already have 1 machines"))
+}
+
+func TestSnowMachinePlacementDefaulterNoPlacement(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSnowPlacementClient(g, snowPlacementTemplate("test-md-0-1", nil))
+	machine := snowPlacementMachine("md-0-1", "test-md-0-1", placementDevices...)
+
+	d := controllers.NewSnowMachinePlacementDefaulter(c)
+	g.Expect(d.Default(ctx, machine)).To(Succeed())
+	g.Expect(machine.Spec.Devices).To(Equal(placementDevices))
+}
+
+func TestSnowMachinePlacementDefaulterAlreadyPlaced(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	// Moved machines are created without their template.
+	c := newSnowPlacementClient(g)
+	machine := snowPlacementMachine("md-0-1", "test-md-0-1", "1.2.3.6")
+
+	d := controllers.NewSnowMachinePlacementDefaulter(c)
+	g.Expect(d.Default(ctx, machine)).To(Succeed())
+	g.Expect(machine.Spec.Devices).To(Equal([]string{"1.2.3.6"}))
+}
+
+func TestSnowMachinePlacementDefaulterMissingTemplate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSnowPlacementClient(g)
+	machine := snowPlacementMachine("md-0-1", "test-md-0-1", placementDevices...)
+
+	d := controllers.NewSnowMachinePlacementDefaulter(c)
+	g.Expect(d.Default(ctx, machine)).To(MatchError(ContainSubstring("getting AWSSnowMachineTemplate test-md-0-1 to place AWSSnowMachine")))
+}
+
+func newSnowPlacementClient(g *WithT, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	g.Expect(snowv1.AddToScheme(scheme)).To(Succeed())
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		Build()
+}
+
+func snowPlacementTemplate(name string, annotations map[string]string) *snowv1.AWSSnowMachineTemplate {
+	return &snowv1.AWSSnowMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   constants.EksaSystemNamespace,
+			Annotations: annotations,
+		},
+		Spec: snowv1.AWSSnowMachineTemplateSpec{
+			Template: snowv1.AWSSnowMachineTemplateResource{
+				Spec: snowv1.AWSSnowMachineSpec{
+					Devices: placementDevices,
+				},
+			},
+		},
+	}
+}
+
+func snowPlacementMachine(name, template string, devices ...string) *snowv1.AWSSnowMachine {
+	return &snowv1.AWSSnowMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Annotations: map[string]string{
+				clusterv1beta2.TemplateClonedFromNameAnnotation: template,
+			},
+		},
+		Spec: snowv1.AWSSnowMachineSpec{
+			Devices: devices,
+		},
+	}
+}
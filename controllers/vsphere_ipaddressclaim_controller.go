@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1beta1 "sigs.k8s.io/cluster-api/api/ipam/v1beta1"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+// VSphereIPAddressClaimReconciler allocates addresses from VSphereIPPools to the IPAddressClaims
+// CAPV creates for the machine NICs configured with addressesFromPools.
+type VSphereIPAddressClaimReconciler struct {
+	client client.Client
+	// uncachedClient reads the addresses in use directly from the API server, so an address
+	// created for a claim is never handed out again because the cache hasn't observed it yet.
+	uncachedClient client.Reader
+	log            logr.Logger
+}
+
+// NewVSphereIPAddressClaimReconciler returns a new instance of VSphereIPAddressClaimReconciler.
+func NewVSphereIPAddressClaimReconciler(client client.Client, uncachedClient client.Reader) *VSphereIPAddressClaimReconciler {
+	return &VSphereIPAddressClaimReconciler{
+		client:         client,
+		uncachedClient: uncachedClient,
+		log:            ctrl.Log.WithName("VSphereIPAddressClaimController"),
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VSphereIPAddressClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ipamv1beta1.IPAddressClaim{}, builder.WithPredicates(predicate.NewPredicateFuncs(isVSphereIPPoolClaim))).
+		Owns(&ipamv1beta1.IPAddress{}).
+		Complete(r)
+}
+
+func isVSphereIPPoolClaim(o client.Object) bool {
+	claim, ok := o.(*ipamv1beta1.IPAddressClaim)
+	if !ok {
+		return false
+	}
+	return isVSphereIPPoolRef(claim.Spec.PoolRef.APIGroup, claim.Spec.PoolRef.Kind)
+}
+
+func isVSphereIPPoolRef(apiGroup *string, kind string) bool {
+	return apiGroup != nil && *apiGroup == anywherev1.GroupVersion.Group && kind == anywherev1.VSphereIPPoolKind
+}
+
+//+kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddresses,verbs=get;list;watch;create;delete
+
+// Reconcile assigns the first free address of the claimed VSphereIPPool to an IPAddressClaim.
+// The IPAddress is owned by the claim, so it's garbage collected and its address released when
+// CAPV deletes the claim together with the machine.
+func (r *VSphereIPAddressClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := r.log.WithValues("IPAddressClaim", req.NamespacedName)
+
+	claim := &ipamv1beta1.IPAddressClaim{}
+	if err := r.client.Get(ctx, req.NamespacedName, claim); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !isVSphereIPPoolClaim(claim) || !claim.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(claim, r.client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, claim); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, fmt.Errorf("patching ipaddressclaim: %v", err)})
+		}
+	}()
+
+	address, err := r.reconcileAddress(ctx, log, claim)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	claim.Status.AddressRef.Name = address.Name
+	markIPAddressClaimReady(claim)
+
+	return ctrl.Result{}, nil
+}
+
+// markIPAddressClaimReady sets the Ready condition CAPV aggregates to know the claims of a VM are fulfilled.
+func markIPAddressClaimReady(claim *ipamv1beta1.IPAddressClaim) {
+	for i, c := range claim.Status.Conditions {
+		if c.Type == clusterv1beta1.ReadyCondition {
+			if c.Status != corev1.ConditionTrue {
+				claim.Status.Conditions[i] = readyCondition()
+			}
+			return
+		}
+	}
+	claim.Status.Conditions = append(claim.Status.Conditions, readyCondition())
+}
+
+func readyCondition() clusterv1beta1.Condition {
+	return clusterv1beta1.Condition{
+		Type:               clusterv1beta1.ReadyCondition,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+	}
+}
+
+func (r *VSphereIPAddressClaimReconciler) reconcileAddress(ctx context.Context, log logr.Logger, claim *ipamv1beta1.IPAddressClaim) (*ipamv1beta1.IPAddress, error) {
+	// The address is named after the claim, if it exists the claim has already been fulfilled.
+	address := &ipamv1beta1.IPAddress{}
+	err := r.uncachedClient.Get(ctx, client.ObjectKeyFromObject(claim), address)
+	if err == nil {
+		return address, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("getting ipaddress: %v", err)
+	}
+
+	pool, err := r.getPool(ctx, claim)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := &ipamv1beta1.IPAddressList{}
+	if err := r.uncachedClient.List(ctx, addresses, client.InNamespace(claim.Namespace)); err != nil {
+		return nil, fmt.Errorf("listing ipaddresses: %v", err)
+	}
+	inUse := map[string]bool{}
+	for _, a := range addresses.Items {
+		if isVSphereIPPoolRef(a.Spec.PoolRef.APIGroup, a.Spec.PoolRef.Kind) && a.Spec.PoolRef.Name == pool.Name {
+			inUse[a.Spec.Address] = true
+		}
+	}
+
+	ip, ok := pool.FirstAvailable(inUse)
+	if !ok {
+		return nil, fmt.Errorf("VSphereIPPool %s has no free address", pool.Name)
+	}
+
+	address = &ipamv1beta1.IPAddress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claim.Name,
+			Namespace: claim.Namespace,
+			Labels:    map[string]string{},
+		},
+		Spec: ipamv1beta1.IPAddressSpec{
+			ClaimRef: corev1.LocalObjectReference{Name: claim.Name},
+			PoolRef:  claim.Spec.PoolRef,
+			Address:  ip,
+			Prefix:   pool.Spec.Prefix,
+			Gateway:  pool.Spec.Gateway,
+		},
+	}
+	if clusterName, ok := claim.Labels[clusterv1beta2.ClusterNameLabel]; ok {
+		address.Labels[clusterv1beta2.ClusterNameLabel] = clusterName
+	}
+	if err := controllerutil.SetControllerReference(claim, address, r.client.Scheme()); err != nil {
+		return nil, err
+	}
+
+	log.Info("Allocating address from VSphereIPPool", "pool", pool.Name, "address", ip)
+	if err := r.client.Create(ctx, address); err != nil {
+		return nil, fmt.Errorf("creating ipaddress: %v", err)
+	}
+
+	return address, nil
+}
+
+// getPool returns the claimed VSphereIPPool. The pool lives in the namespace of the EKS-A cluster,
+// which is recorded in a label of the CAPI cluster the claim belongs to.
+func (r *VSphereIPAddressClaimReconciler) getPool(ctx context.Context, claim *ipamv1beta1.IPAddressClaim) (*anywherev1.VSphereIPPool, error) {
+	clusterName := claim.Spec.ClusterName
+	if clusterName == "" {
+		clusterName = claim.Labels[clusterv1beta2.ClusterNameLabel]
+	}
+
+	namespace := claim.Namespace
+	if clusterName != "" {
+		capiCluster := &clusterv1beta2.Cluster{}
+		if err := r.client.Get(ctx, client.ObjectKey{Name: clusterName, Namespace: claim.Namespace}, capiCluster); err != nil {
+			return nil, fmt.Errorf("getting cluster %s: %v", clusterName, err)
+		}
+		if ns, ok := capiCluster.Labels[clusterapi.EKSAClusterLabelNamespace]; ok {
+			namespace = ns
+		}
+	}
+
+	pool := &anywherev1.VSphereIPPool{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: claim.Spec.PoolRef.Name, Namespace: namespace}, pool); err != nil {
+		return nil, fmt.Errorf("getting VSphereIPPool %s: %v", claim.Spec.PoolRef.Name, err)
+	}
+
+	return pool, nil
+}
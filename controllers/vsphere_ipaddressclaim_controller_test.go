@@ -0,0 +1,233 @@
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1beta1 "sigs.k8s.io/cluster-api/api/ipam/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func TestVSphereIPAddressClaimReconcilerAllocate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	claim := vsphereIPAddressClaim("claim-1")
+	c := newVSphereIPAddressClaimClient(g, vsphereIPAddressClaimCluster(), vsphereIPAddressClaimPool(), claim)
+
+	r := controllers.NewVSphereIPAddressClaimReconciler(c, c)
+	_, err := r.Reconcile(ctx, vsphereIPAddressClaimRequest(claim))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	address := &ipamv1beta1.IPAddress{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(claim), address)).To(Succeed())
+	g.Expect(address.Spec.Address).To(Equal("10.0.0.10"))
+	g.Expect(address.Spec.Prefix).To(Equal(24))
+	g.Expect(address.Spec.Gateway).To(Equal("10.0.0.1"))
+	g.Expect(address.Spec.ClaimRef.Name).To(Equal(claim.Name))
+	g.Expect(address.Labels).To(HaveKeyWithValue(clusterv1beta2.ClusterNameLabel, "test"))
+	g.Expect(address.OwnerReferences).To(HaveLen(1))
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(claim), claim)).To(Succeed())
+	g.Expect(claim.Status.AddressRef.Name).To(Equal(claim.Name))
+	g.Expect(claim.Status.Conditions).To(HaveLen(1))
+	g.Expect(claim.Status.Conditions[0].Type).To(Equal(clusterv1beta1.ReadyCondition))
+	g.Expect(claim.Status.Conditions[0].Status).To(Equal(corev1.ConditionTrue))
+}
+
+func TestVSphereIPAddressClaimReconcilerSkipsAddressesInUse(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	claim := vsphereIPAddressClaim("claim-2")
+	c := newVSphereIPAddressClaimClient(g,
+		vsphereIPAddressClaimCluster(),
+		vsphereIPAddressClaimPool(),
+		claim,
+		vsphereIPAddress("claim-0", "10.0.0.10"),
+		vsphereIPAddress("claim-1", "10.0.0.11"),
+	)
+
+	r := controllers.NewVSphereIPAddressClaimReconciler(c, c)
+	_, err := r.Reconcile(ctx, vsphereIPAddressClaimRequest(claim))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	address := &ipamv1beta1.IPAddress{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(claim), address)).To(Succeed())
+	g.Expect(address.Spec.Address).To(Equal("10.0.0.20"))
+}
+
+func TestVSphereIPAddressClaimReconcilerReadsAddressesInUseUncached(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	claim := vsphereIPAddressClaim("claim-1")
+	c := newVSphereIPAddressClaimClient(g, vsphereIPAddressClaimCluster(), vsphereIPAddressClaimPool(), claim)
+	// The cache hasn't observed the address allocated to claim-0 yet.
+	uncached := newVSphereIPAddressClaimClient(g, vsphereIPAddress("claim-0", "10.0.0.10"))
+
+	r := controllers.NewVSphereIPAddressClaimReconciler(c, uncached)
+	_, err := r.Reconcile(ctx, vsphereIPAddressClaimRequest(claim))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	address := &ipamv1beta1.IPAddress{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(claim), address)).To(Succeed())
+	g.Expect(address.Spec.Address).To(Equal("10.0.0.20"))
+}
+
+func TestVSphereIPAddressClaimReconcilerPoolExhausted(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	claim := vsphereIPAddressClaim("claim-2")
+	c := newVSphereIPAddressClaimClient(g,
+		vsphereIPAddressClaimCluster(),
+		vsphereIPAddressClaimPool(),
+		claim,
+		vsphereIPAddress("claim-0", "10.0.0.10"),
+		vsphereIPAddress("claim-1", "10.0.0.20"),
+	)
+
+	r := controllers.NewVSphereIPAddressClaimReconciler(c, c)
+	_, err := r.Reconcile(ctx, vsphereIPAddressClaimRequest(claim))
+	g.Expect(err).To(MatchError(ContainSubstring("VSphereIPPool pool has no free address")))
+}
+
+func TestVSphereIPAddressClaimReconcilerExistingAddress(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	claim := vsphereIPAddressClaim("claim-1")
+	c := newVSphereIPAddressClaimClient(g, claim, vsphereIPAddress("claim-1", "10.0.0.20"))
+
+	r := controllers.NewVSphereIPAddressClaimReconciler(c, c)
+	_, err := r.Reconcile(ctx, vsphereIPAddressClaimRequest(claim))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(claim), claim)).To(Succeed())
+	g.Expect(claim.Status.AddressRef.Name).To(Equal("claim-1"))
+}
+
+func TestVSphereIPAddressClaimReconcilerIgnoresOtherPools(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	claim := vsphereIPAddressClaim("claim-1")
+	claim.Spec.PoolRef.APIGroup = ptr.String("ipam.cluster.x-k8s.io")
+	claim.Spec.PoolRef.Kind = "InClusterIPPool"
+	c := newVSphereIPAddressClaimClient(g, claim)
+
+	r := controllers.NewVSphereIPAddressClaimReconciler(c, c)
+	_, err := r.Reconcile(ctx, vsphereIPAddressClaimRequest(claim))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	addresses := &ipamv1beta1.IPAddressList{}
+	g.Expect(c.List(ctx, addresses)).To(Succeed())
+	g.Expect(addresses.Items).To(BeEmpty())
+}
+
+func TestVSphereIPAddressClaimReconcilerNotFound(t *testing.T) {
+	g := NewWithT(t)
+	c := newVSphereIPAddressClaimClient(g)
+
+	r := controllers.NewVSphereIPAddressClaimReconciler(c, c)
+	_, err := r.Reconcile(context.Background(), vsphereIPAddressClaimRequest(vsphereIPAddressClaim("claim-1")))
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func newVSphereIPAddressClaimClient(g *WithT, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	g.Expect(anywherev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1beta2.AddToScheme(scheme)).To(Succeed())
+	g.Expect(ipamv1beta1.AddToScheme(scheme)).To(Succeed())
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&ipamv1beta1.IPAddressClaim{}).
+		Build()
+}
+
+func vsphereIPAddressClaimRequest(claim *ipamv1beta1.IPAddressClaim) reconcile.Request {
+	return reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      claim.Name,
+			Namespace: claim.Namespace,
+		},
+	}
+}
+
+func vsphereIPAddressClaim(name string) *ipamv1beta1.IPAddressClaim {
+	return &ipamv1beta1.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1beta2.ClusterNameLabel: "test"},
+		},
+		Spec: ipamv1beta1.IPAddressClaimSpec{
+			ClusterName: "test",
+			PoolRef: corev1.TypedLocalObjectReference{
+				APIGroup: ptr.String(anywherev1.GroupVersion.Group),
+				Kind:     anywherev1.VSphereIPPoolKind,
+				Name:     "pool",
+			},
+		},
+	}
+}
+
+func vsphereIPAddress(claimName, address string) *ipamv1beta1.IPAddress {
+	return &ipamv1beta1.IPAddress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claimName,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: ipamv1beta1.IPAddressSpec{
+			ClaimRef: corev1.LocalObjectReference{Name: claimName},
+			PoolRef: corev1.TypedLocalObjectReference{
+				APIGroup: ptr.String(anywherev1.GroupVersion.Group),
+				Kind:     anywherev1.VSphereIPPoolKind,
+				Name:     "pool",
+			},
+			Address: address,
+			Prefix:  24,
+			Gateway: "10.0.0.1",
+		},
+	}
+}
+
+func vsphereIPAddressClaimCluster() *clusterv1beta2.Cluster {
+	return &clusterv1beta2.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterapi.EKSAClusterLabelNamespace: "default"},
+		},
+	}
+}
+
+func vsphereIPAddressClaimPool() *anywherev1.VSphereIPPool {
+	return &anywherev1.VSphereIPPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pool",
+			Namespace: "default",
+		},
+		Spec: anywherev1.VSphereIPPoolSpec{
+			Network: "/SDDC-Datacenter/network/sddc-cgw-network-1",
+			Ranges: []anywherev1.VSphereIPRange{
+				{Start: "10.0.0.10", End: "10.0.0.10"},
+				{Start: "10.0.0.20", End: "10.0.0.20"},
+			},
+			Prefix:  24,
+			Gateway: "10.0.0.1",
+		},
+	}
+}
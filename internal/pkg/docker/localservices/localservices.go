@@ -0,0 +1,193 @@
+package localservices
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/errors"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	// DefaultNetwork is the docker network kind and the Docker provider run the cluster nodes in.
+	DefaultNetwork = "kind"
+
+	// DefaultRegistryImage is the image of the registry mirror.
+	DefaultRegistryImage = "registry:2.8.3"
+
+	// DefaultProxyImage is the image of the squid proxy. Its default configuration allows the
+	// private networks, which include the kind network.
+	DefaultProxyImage = "ubuntu/squid:5.2-22.04_beta"
+
+	// proxyPort is the port the squid proxy listens on with its default configuration.
+	proxyPort = 3128
+
+	// servicesLabel is the label added to the containers with the name of their services.
+	servicesLabel = "anywhere.eks.amazonaws.com/local-services"
+
+	registryConfigDir = "/etc/registry"
+)
+
+// DockerClient runs the containers of the local services.
+type DockerClient interface {
+	Run(ctx context.Context, image string, name string, cmd []string, flags ...string) error
+	ListContainers(ctx context.Context, filter string) ([]string, error)
+	ForceRemove(ctx context.Context, name string) error
+	CreateNetwork(ctx context.Context, name string) error
+	NetworkIPv4(ctx context.Context, name string) (subnet, gateway string, err error)
+}
+
+// Config describes the local services. Name prefixes their containers so several sets of services
+// can run on the same host with different names and registry ports.
+type Config struct {
+	Name     string
+	Network  string
+	Registry bool
+	Proxy    bool
+
+	RegistryImage    string
+	RegistryPort     int
+	RegistryUpstream string
+	RegistryUsername string
+	RegistryPassword string
+
+	ProxyImage string
+}
+
+// Endpoints are the cluster configuration using the local services. Its fields are named after
+// the Cluster spec ones so it can be added to a cluster config as is.
+type Endpoints struct {
+	RegistryMirrorConfiguration *v1alpha1.RegistryMirrorConfiguration `json:"registryMirrorConfiguration,omitempty"`
+	ProxyConfiguration          *v1alpha1.ProxyConfiguration          `json:"proxyConfiguration,omitempty"`
+}
+
+// Services runs an authenticated registry mirror and a proxy on the host, reachable by Docker
+// provider cluster nodes through the gateway of their docker network, so cluster configs using
+// registryMirrorConfiguration and proxyConfiguration can be tested locally.
+type Services struct {
+	docker DockerClient
+	writer filewriter.FileWriter
+	config Config
+}
+
+// New returns Services that write the configuration of the registry mirror with writer.
+func New(docker DockerClient, writer filewriter.FileWriter, config Config) *Services {
+	return &Services{
+		docker: docker,
+		writer: writer,
+		config: config,
+	}
+}
+
+// Create starts the services, creating the docker network of the nodes if it doesn't exist yet,
+// and returns the cluster configuration using them.
+func (s *Services) Create(ctx context.Context) (*Endpoints, error) {
+	if err := s.docker.CreateNetwork(ctx, s.config.Network); err != nil {
+		return nil, err
+	}
+	subnet, gateway, err := s.docker.NetworkIPv4(ctx, s.config.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := &Endpoints{}
+	if s.config.Registry {
+		if endpoints.RegistryMirrorConfiguration, err = s.createRegistryMirror(ctx, gateway); err != nil {
+			return nil, err
+		}
+		logger.V(2).Info("Created local registry mirror", "endpoint", gateway, "port", s.config.RegistryPort)
+	}
+
+	if s.config.Proxy {
+		if endpoints.ProxyConfiguration, err = s.createProxy(ctx, subnet, gateway); err != nil {
+			return nil, err
+		}
+		logger.V(2).Info("Created local proxy", "proxy", endpoints.ProxyConfiguration.HttpProxy)
+	}
+
+	return endpoints, nil
+}
+
+func (s *Services) createRegistryMirror(ctx context.Context, gateway string) (*v1alpha1.RegistryMirrorConfiguration, error) {
+	dir, cert, err := s.writeRegistryConfig(gateway)
+	if err != nil {
+		return nil, fmt.Errorf("writing registry mirror configuration: %v", err)
+	}
+
+	flags := append(s.hostFlags(),
+		"-v", fmt.Sprintf("%s:%s:ro", dir, registryConfigDir),
+		"-e", fmt.Sprintf("REGISTRY_HTTP_ADDR=%s:%d", gateway, s.config.RegistryPort),
+		"-e", "REGISTRY_HTTP_TLS_CERTIFICATE="+filepath.Join(registryConfigDir, registryCertFile),
+		"-e", "REGISTRY_HTTP_TLS_KEY="+filepath.Join(registryConfigDir, registryKeyFile),
+		"-e", "REGISTRY_AUTH=htpasswd",
+		"-e", "REGISTRY_AUTH_HTPASSWD_REALM=eks-anywhere",
+		"-e", "REGISTRY_AUTH_HTPASSWD_PATH="+filepath.Join(registryConfigDir, registryAuthFile),
+	)
+	if s.config.RegistryUpstream != "" {
+		// The registry becomes a read only pull through cache of the upstream registry, so the
+		// nodes can pull the EKS Anywhere images without importing them first.
+		flags = append(flags, "-e", "REGISTRY_PROXY_REMOTEURL="+s.config.RegistryUpstream)
+	}
+
+	if err := s.docker.Run(ctx, s.config.RegistryImage, s.containerName("registry-mirror"), nil, flags...); err != nil {
+		return nil, err
+	}
+
+	return &v1alpha1.RegistryMirrorConfiguration{
+		Endpoint:      gateway,
+		Port:          strconv.Itoa(s.config.RegistryPort),
+		CACertContent: string(cert),
+		Authenticate:  true,
+	}, nil
+}
+
+func (s *Services) createProxy(ctx context.Context, subnet, gateway string) (*v1alpha1.ProxyConfiguration, error) {
+	if err := s.docker.Run(ctx, s.config.ProxyImage, s.containerName("proxy"), nil, s.hostFlags()...); err != nil {
+		return nil, err
+	}
+
+	proxy := fmt.Sprintf("http://%s:%d", gateway, proxyPort)
+	return &v1alpha1.ProxyConfiguration{
+		HttpProxy:  proxy,
+		HttpsProxy: proxy,
+		// The nodes reach the load balancer and the registry mirror directly in their network and
+		// the CLI reaches the clusters through the load balancer ports published on localhost.
+		NoProxy: []string{subnet, "localhost", "127.0.0.1"},
+	}, nil
+}
+
+// hostFlags runs a container in the host network, where it can be reached by the nodes through
+// the gateway of their network and by the CLI on the host.
+func (s *Services) hostFlags() []string {
+	return []string{
+		"--network", "host",
+		"--label", fmt.Sprintf("%s=%s", servicesLabel, s.config.Name),
+	}
+}
+
+func (s *Services) containerName(service string) string {
+	return fmt.Sprintf("%s-%s", s.config.Name, service)
+}
+
+// Delete removes the containers and the registry mirror configuration of the services.
+func (s *Services) Delete(ctx context.Context) error {
+	var errs []error
+
+	containers, err := s.docker.ListContainers(ctx, fmt.Sprintf("label=%s=%s", servicesLabel, s.config.Name))
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, c := range containers {
+		if err := s.docker.ForceRemove(ctx, c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	s.writer.CleanUp()
+
+	return errors.NewAggregate(errs)
+}
@@ -0,0 +1,162 @@
+package localservices_test
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/pkg/docker/localservices"
+	"github.com/aws/eks-anywhere/internal/pkg/docker/localservices/mocks"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+)
+
+type servicesTest struct {
+	*WithT
+	ctx    context.Context
+	docker *mocks.MockDockerClient
+	writer filewriter.FileWriter
+	config localservices.Config
+}
+
+func newServicesTest(t *testing.T) *servicesTest {
+	writer, err := filewriter.NewWriter(filepath.Join(t.TempDir(), "services"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &servicesTest{
+		WithT:  NewWithT(t),
+		ctx:    context.Background(),
+		docker: mocks.NewMockDockerClient(gomock.NewController(t)),
+		writer: writer,
+		config: localservices.Config{
+			Name:             "eksa-local",
+			Network:          localservices.DefaultNetwork,
+			Registry:         true,
+			Proxy:            true,
+			RegistryImage:    localservices.DefaultRegistryImage,
+			RegistryPort:     5000,
+			RegistryUpstream: "https://public.ecr.aws",
+			RegistryUsername: "admin",
+			RegistryPassword: "password",
+			ProxyImage:       localservices.DefaultProxyImage,
+		},
+	}
+}
+
+func (tt *servicesTest) services() *localservices.Services {
+	return localservices.New(tt.docker, tt.writer, tt.config)
+}
+
+func (tt *servicesTest) expectNetwork() {
+	tt.docker.EXPECT().CreateNetwork(tt.ctx, "kind")
+	tt.docker.EXPECT().NetworkIPv4(tt.ctx, "kind").Return("172.18.0.0/16", "172.18.0.1", nil)
+}
+
+func TestServicesCreate(t *testing.T) {
+	tt := newServicesTest(t)
+	configDir, err := filepath.Abs(filepath.Join(tt.writer.Dir(), "registry-mirror"))
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.expectNetwork()
+	tt.docker.EXPECT().Run(tt.ctx, "registry:2.8.3", "eksa-local-registry-mirror", nil,
+		"--network", "host",
+		"--label", "anywhere.eks.amazonaws.com/local-services=eksa-local",
+		"-v", configDir+":/etc/registry:ro",
+		"-e", "REGISTRY_HTTP_ADDR=172.18.0.1:5000",
+		"-e", "REGISTRY_HTTP_TLS_CERTIFICATE=/etc/registry/tls.crt",
+		"-e", "REGISTRY_HTTP_TLS_KEY=/etc/registry/tls.key",
+		"-e", "REGISTRY_AUTH=htpasswd",
+		"-e", "REGISTRY_AUTH_HTPASSWD_REALM=eks-anywhere",
+		"-e", "REGISTRY_AUTH_HTPASSWD_PATH=/etc/registry/htpasswd",
+		"-e", "REGISTRY_PROXY_REMOTEURL=https://public.ecr.aws",
+	)
+	tt.docker.EXPECT().Run(tt.ctx, "ubuntu/squid:5.2-22.04_beta", "eksa-local-proxy", nil,
+		"--network", "host",
+		"--label", "anywhere.eks.amazonaws.com/local-services=eksa-local",
+	)
+
+	endpoints, err := tt.services().Create(tt.ctx)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	cert, err := os.ReadFile(filepath.Join(configDir, "tls.crt"))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(endpoints).To(Equal(&localservices.Endpoints{
+		RegistryMirrorConfiguration: &v1alpha1.RegistryMirrorConfiguration{
+			Endpoint:      "172.18.0.1",
+			Port:          "5000",
+			CACertContent: string(cert),
+			Authenticate:  true,
+		},
+		ProxyConfiguration: &v1alpha1.ProxyConfiguration{
+			HttpProxy:  "http://172.18.0.1:3128",
+			HttpsProxy: "http://172.18.0.1:3128",
+			NoProxy:    []string{"172.18.0.0/16", "localhost", "127.0.0.1"},
+		},
+	}))
+
+	block, _ := pem.Decode(cert)
+	tt.Expect(block).NotTo(BeNil())
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(parsed.IsCA).To(BeTrue())
+	tt.Expect(parsed.IPAddresses[0].String()).To(Equal("172.18.0.1"))
+
+	htpasswd, err := os.ReadFile(filepath.Join(configDir, "htpasswd"))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(string(htpasswd)).To(HavePrefix("admin:$2a$"))
+}
+
+func TestServicesCreateProxyOnly(t *testing.T) {
+	tt := newServicesTest(t)
+	tt.config.Registry = false
+
+	tt.expectNetwork()
+	tt.docker.EXPECT().Run(tt.ctx, "ubuntu/squid:5.2-22.04_beta", "eksa-local-proxy", nil,
+		"--network", "host",
+		"--label", "anywhere.eks.amazonaws.com/local-services=eksa-local",
+	)
+
+	endpoints, err := tt.services().Create(tt.ctx)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(endpoints.RegistryMirrorConfiguration).To(BeNil())
+	tt.Expect(endpoints.ProxyConfiguration.HttpProxy).To(Equal("http://172.18.0.1:3128"))
+}
+
+func TestServicesCreateNetworkError(t *testing.T) {
+	tt := newServicesTest(t)
+
+	tt.docker.EXPECT().CreateNetwork(tt.ctx, "kind")
+	tt.docker.EXPECT().NetworkIPv4(tt.ctx, "kind").Return("", "", errors.New("docker network kind doesn't have an IPv4 subnet"))
+
+	_, err := tt.services().Create(tt.ctx)
+	tt.Expect(err).To(MatchError("docker network kind doesn't have an IPv4 subnet"))
+}
+
+func TestServicesCreateRegistryError(t *testing.T) {
+	tt := newServicesTest(t)
+
+	tt.expectNetwork()
+	tt.docker.EXPECT().Run(tt.ctx, "registry:2.8.3", "eksa-local-registry-mirror", nil, gomock.Any()).Return(errors.New("port is already allocated"))
+
+	_, err := tt.services().Create(tt.ctx)
+	tt.Expect(err).To(MatchError("port is already allocated"))
+}
+
+func TestServicesDelete(t *testing.T) {
+	tt := newServicesTest(t)
+
+	tt.docker.EXPECT().ListContainers(tt.ctx, "label=anywhere.eks.amazonaws.com/local-services=eksa-local").Return([]string{"eksa-local-registry-mirror", "eksa-local-proxy"}, nil)
+	tt.docker.EXPECT().ForceRemove(tt.ctx, "eksa-local-registry-mirror").Return(errors.New("container is locked"))
+	tt.docker.EXPECT().ForceRemove(tt.ctx, "eksa-local-proxy")
+
+	tt.Expect(tt.services().Delete(tt.ctx)).To(MatchError("container is locked"))
+}
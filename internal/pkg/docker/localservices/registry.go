@@ -0,0 +1,60 @@
+package localservices
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/eks-anywhere/internal/pkg/localauth"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+)
+
+const (
+	registryCertFile = "tls.crt"
+	registryKeyFile  = "tls.key"
+	registryAuthFile = "htpasswd"
+
+	registryCertValidity = 365 * 24 * time.Hour
+)
+
+// writeRegistryConfig writes the TLS certificate and credentials of the registry mirror and returns
+// the absolute path of the directory containing them and the certificate, which is also the CA
+// the nodes trust.
+func (s *Services) writeRegistryConfig(ip string) (dir string, cert []byte, err error) {
+	w, err := s.writer.WithDir("registry-mirror")
+	if err != nil {
+		return "", nil, err
+	}
+
+	// The certificate signs itself so it can be used as the registry mirror CA in the cluster config.
+	cert, key, err := localauth.SelfSignedCA(ip, registryCertValidity)
+	if err != nil {
+		return "", nil, fmt.Errorf("generating tls certificate: %v", err)
+	}
+
+	htpasswd, err := localauth.HTPasswd(s.config.RegistryUsername, s.config.RegistryPassword)
+	if err != nil {
+		return "", nil, fmt.Errorf("writing registry credentials: %v", err)
+	}
+
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{registryCertFile, cert},
+		{registryKeyFile, key},
+		{registryAuthFile, htpasswd},
+	}
+	for _, f := range files {
+		if _, err := w.Write(f.name, f.content, filewriter.PersistentFile, filewriter.Permission0600); err != nil {
+			return "", nil, err
+		}
+	}
+
+	dir, err = filepath.Abs(w.Dir())
+	if err != nil {
+		return "", nil, err
+	}
+
+	return dir, cert, nil
+}
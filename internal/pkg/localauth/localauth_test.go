@@ -0,0 +1,56 @@
+package localauth_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/aws/eks-anywhere/internal/pkg/localauth"
+)
+
+func TestSelfSignedCA(t *testing.T) {
+	g := NewWithT(t)
+
+	certPEM, keyPEM, err := localauth.SelfSignedCA("172.18.0.10", time.Hour)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = tls.X509KeyPair(certPEM, keyPEM)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	block, _ := pem.Decode(certPEM)
+	g.Expect(block).NotTo(BeNil())
+	cert, err := x509.ParseCertificate(block.Bytes)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cert.IsCA).To(BeTrue())
+	g.Expect(cert.IPAddresses[0].String()).To(Equal("172.18.0.10"))
+	g.Expect(cert.NotAfter.Sub(cert.NotBefore)).To(Equal(time.Hour))
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+	_, err = cert.Verify(x509.VerifyOptions{Roots: roots})
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestHTPasswd(t *testing.T) {
+	g := NewWithT(t)
+
+	htpasswd, err := localauth.HTPasswd("admin", "password")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	username, hash, found := strings.Cut(strings.TrimSuffix(string(htpasswd), "\n"), ":")
+	g.Expect(found).To(BeTrue())
+	g.Expect(username).To(Equal("admin"))
+	g.Expect(bcrypt.CompareHashAndPassword([]byte(hash), []byte("password"))).To(Succeed())
+}
+
+func TestHTPasswdPasswordTooLong(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := localauth.HTPasswd("admin", strings.Repeat("a", 73))
+	g.Expect(err).To(MatchError(ContainSubstring("hashing password")))
+}
@@ -0,0 +1,116 @@
+package lab
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/eks-anywhere/internal/pkg/localauth"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+//go:embed config/emulator.conf
+var emulatorTemplate string
+
+//go:embed config/emulator.Dockerfile
+var emulatorDockerfileTemplate string
+
+const (
+	emulatorConfigFile = "emulator.conf"
+	emulatorImageDir   = "emulator-image"
+	emulatorCertFile   = "tls.crt"
+	emulatorKeyFile    = "tls.key"
+	emulatorAuthFile   = "htpasswd"
+
+	emulatorCertValidity = 365 * 24 * time.Hour
+)
+
+// emulator is the configuration of the sushy-tools Redfish emulator of a single VM. Restricting
+// each emulator to the UUID of its VM gives every VM its own BMC address and port, like physical
+// hardware.
+type emulator struct {
+	HostIP     string
+	Port       int
+	ConfigDir  string
+	LibvirtURI string
+	UUID       string
+}
+
+// writeEmulatorConfig writes the configuration, TLS certificate and credentials of the emulator
+// of m and returns the absolute path of the directory containing them.
+func (l *Lab) writeEmulatorConfig(e emulator, m *hardware.Machine) (string, error) {
+	w, err := l.writer.WithDir(m.Hostname)
+	if err != nil {
+		return "", err
+	}
+
+	config, err := templater.Execute(emulatorTemplate, e)
+	if err != nil {
+		return "", err
+	}
+
+	// Rufio and the bmc commands don't verify the certificates of BMCs.
+	cert, key, err := localauth.SelfSignedCA(e.HostIP, emulatorCertValidity)
+	if err != nil {
+		return "", fmt.Errorf("generating tls certificate: %v", err)
+	}
+
+	htpasswd, err := localauth.HTPasswd(m.BMCUsername, m.BMCPassword)
+	if err != nil {
+		return "", fmt.Errorf("writing bmc credentials: %v", err)
+	}
+
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{emulatorConfigFile, config},
+		{emulatorCertFile, cert},
+		{emulatorKeyFile, key},
+		{emulatorAuthFile, htpasswd},
+	}
+	for _, f := range files {
+		if _, err := w.Write(f.name, f.content, filewriter.PersistentFile, filewriter.Permission0600); err != nil {
+			return "", err
+		}
+	}
+
+	return filepath.Abs(w.Dir())
+}
+
+// buildEmulatorImage builds DefaultEmulatorImage when the lab uses it. Images set in the config
+// are pulled when the emulators run.
+func (l *Lab) buildEmulatorImage(ctx context.Context) error {
+	if l.config.EmulatorImage != DefaultEmulatorImage {
+		return nil
+	}
+
+	dockerfile, err := templater.Execute(emulatorDockerfileTemplate, map[string]string{
+		"BaseImage":         emulatorBaseImage,
+		"SushyToolsVersion": SushyToolsVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("building emulator Dockerfile: %v", err)
+	}
+
+	w, err := l.writer.WithDir(emulatorImageDir)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write("Dockerfile", dockerfile, filewriter.PersistentFile); err != nil {
+		return err
+	}
+
+	dir, err := filepath.Abs(w.Dir())
+	if err != nil {
+		return err
+	}
+
+	logger.V(2).Info("Building emulator image", "image", DefaultEmulatorImage)
+	return l.docker.BuildImage(ctx, DefaultEmulatorImage, dir)
+}
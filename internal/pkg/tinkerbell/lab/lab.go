@@ -0,0 +1,328 @@
+package lab
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/errors"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+//go:embed config/network.xml
+var networkTemplate string
+
+//go:embed config/domain.xml
+var domainTemplate string
+
+const (
+	// SushyToolsVersion is the sushy-tools version installed in DefaultEmulatorImage.
+	SushyToolsVersion = "1.2.0"
+
+	// DefaultEmulatorImage is the image used to emulate a Redfish BMC for each VM. There is no
+	// versioned sushy-tools image, so the lab builds it with SushyToolsVersion on emulatorBaseImage.
+	DefaultEmulatorImage = "eks-anywhere-tinkerbell-lab/sushy-tools:" + SushyToolsVersion
+
+	// LibvirtURI is the libvirt daemon the lab VMs run in. The emulators connect to it through the
+	// libvirt socket mounted in their containers.
+	LibvirtURI = "qemu:///system"
+
+	// labLabel is the label added to the emulator containers with the name of their lab.
+	labLabel = "anywhere.eks.amazonaws.com/tinkerbell-lab"
+
+	// firstMachineHostOffset is the position in the network of the first VM address. The addresses
+	// before it, except the first one used by the host, are left for the control plane endpoint and
+	// the Tinkerbell IP.
+	firstMachineHostOffset = 11
+
+	emulatorBaseImage = "debian:12.7"
+	emulatorConfigDir = "/etc/sushy"
+	libvirtSocketDir  = "/var/run/libvirt"
+)
+
+// VirshClient manages the libvirt network and VMs of a lab.
+type VirshClient interface {
+	DefineNetwork(ctx context.Context, definition []byte) error
+	StartNetwork(ctx context.Context, name string) error
+	DeleteNetwork(ctx context.Context, name string) error
+	ListNetworks(ctx context.Context) ([]string, error)
+	CreateVolume(ctx context.Context, pool, name, size string) error
+	DefineDomain(ctx context.Context, definition []byte) error
+	DomainUUID(ctx context.Context, name string) (string, error)
+	ListDomains(ctx context.Context) ([]string, error)
+	DeleteDomain(ctx context.Context, name string) error
+}
+
+// DockerClient builds the Redfish emulator image and runs the emulator containers of a lab.
+type DockerClient interface {
+	BuildImage(ctx context.Context, image, contextDir string) error
+	Run(ctx context.Context, image string, name string, cmd []string, flags ...string) error
+	ListContainers(ctx context.Context, filter string) ([]string, error)
+	ForceRemove(ctx context.Context, name string) error
+}
+
+// Config describes a lab. Name prefixes the network, VMs and emulator containers of the lab so
+// several labs can run on the same host with different names and CIDRs.
+type Config struct {
+	Name              string
+	CIDR              string
+	ControlPlaneCount int
+	WorkerCount       int
+	CPUs              int
+	MemoryMiB         int
+	DiskSize          string
+	StoragePool       string
+	EmulatorImage     string
+	EmulatorBasePort  int
+	BMCUsername       string
+	BMCPassword       string
+}
+
+// Lab runs Tinkerbell hardware as local libvirt VMs on an isolated network without DHCP, so the
+// Tinkerbell stack can provision them over PXE, each with a virtual Redfish BMC Rufio can power
+// on and off and set the boot device of.
+type Lab struct {
+	virsh  VirshClient
+	docker DockerClient
+	writer filewriter.FileWriter
+	config Config
+}
+
+// New returns a Lab that writes the configuration of its emulators with writer.
+func New(virsh VirshClient, docker DockerClient, writer filewriter.FileWriter, config Config) *Lab {
+	return &Lab{
+		virsh:  virsh,
+		docker: docker,
+		writer: writer,
+		config: config,
+	}
+}
+
+type network struct {
+	Name    string
+	HostIP  string
+	Netmask string
+	ipNet   *net.IPNet
+}
+
+func (l *Lab) network() (*network, error) {
+	ip, ipNet, err := net.ParseCIDR(l.config.CIDR)
+	if err != nil {
+		return nil, fmt.Errorf("parsing lab CIDR: %v", err)
+	}
+	if ip.To4() == nil {
+		return nil, fmt.Errorf("lab CIDR %s must be an IPv4 CIDR", l.config.CIDR)
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	machines := l.config.ControlPlaneCount + l.config.WorkerCount
+	if available := 1<<(bits-ones) - firstMachineHostOffset - 1; machines > available {
+		return nil, fmt.Errorf("lab CIDR %s only has room for %d machines", l.config.CIDR, max(available, 0))
+	}
+
+	return &network{
+		Name:    l.config.Name,
+		HostIP:  hostIP(ipNet, 1).String(),
+		Netmask: net.IP(ipNet.Mask).String(),
+		ipNet:   ipNet,
+	}, nil
+}
+
+// hostIP returns the address at offset in n.
+func hostIP(n *net.IPNet, offset int) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	copy(ip, n.IP.To4())
+	for i := len(ip) - 1; i >= 0 && offset > 0; i-- {
+		sum := int(ip[i]) + offset
+		ip[i] = byte(sum)
+		offset = sum >> 8
+	}
+	return ip
+}
+
+// Create creates the network, the VMs and the virtual BMCs of the lab and returns the hardware
+// describing them, ready to be written to a hardware CSV. The VMs are powered off, Rufio powers
+// them on through their BMC during cluster creation.
+func (l *Lab) Create(ctx context.Context) ([]*hardware.Machine, error) {
+	n, err := l.network()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.buildEmulatorImage(ctx); err != nil {
+		return nil, err
+	}
+
+	definition, err := templater.Execute(networkTemplate, n)
+	if err != nil {
+		return nil, fmt.Errorf("building lab network definition: %v", err)
+	}
+	if err := l.virsh.DefineNetwork(ctx, definition); err != nil {
+		return nil, err
+	}
+	if err := l.virsh.StartNetwork(ctx, n.Name); err != nil {
+		return nil, err
+	}
+	logger.V(2).Info("Created lab network", "network", n.Name, "hostIP", n.HostIP)
+
+	machines := make([]*hardware.Machine, 0, l.config.ControlPlaneCount+l.config.WorkerCount)
+	for i := 0; i < l.config.ControlPlaneCount+l.config.WorkerCount; i++ {
+		m := l.machine(n, i)
+		if err := l.createVM(ctx, n, m); err != nil {
+			return nil, err
+		}
+		if err := l.createBMC(ctx, n, m); err != nil {
+			return nil, err
+		}
+		logger.V(2).Info("Created lab machine", "machine", m.Hostname, "bmcPort", m.BMCPort)
+		machines = append(machines, m)
+	}
+
+	return machines, nil
+}
+
+// machine returns the hardware of the VM at index. The control plane VMs come first.
+func (l *Lab) machine(n *network, index int) *hardware.Machine {
+	role, roleIndex := "cp", index
+	if index >= l.config.ControlPlaneCount {
+		role, roleIndex = "worker", index-l.config.ControlPlaneCount
+	}
+
+	ip := hostIP(n.ipNet, firstMachineHostOffset+index)
+	return &hardware.Machine{
+		Hostname:    fmt.Sprintf("%s-%s-%d", l.config.Name, role, roleIndex),
+		IPAddress:   ip.String(),
+		Netmask:     n.Netmask,
+		Gateway:     n.HostIP,
+		Nameservers: hardware.Nameservers{n.HostIP},
+		// The locally administered QEMU prefix followed by the last bytes of the VM address keeps
+		// the MAC addresses unique across labs using different CIDRs.
+		MACAddress:   fmt.Sprintf("52:54:00:%02x:%02x:%02x", ip[1], ip[2], ip[3]),
+		Disk:         "/dev/vda",
+		Labels:       hardware.Labels{"type": role},
+		BMCIPAddress: n.HostIP,
+		BMCUsername:  l.config.BMCUsername,
+		BMCPassword:  l.config.BMCPassword,
+		BMCPort:      l.config.EmulatorBasePort + index,
+		BMCVirtual:   true,
+	}
+}
+
+type domain struct {
+	Name        string
+	CPUs        int
+	MemoryMiB   int
+	StoragePool string
+	Volume      string
+	Network     string
+	MACAddress  string
+}
+
+func (l *Lab) createVM(ctx context.Context, n *network, m *hardware.Machine) error {
+	d := domain{
+		Name:        m.Hostname,
+		CPUs:        l.config.CPUs,
+		MemoryMiB:   l.config.MemoryMiB,
+		StoragePool: l.config.StoragePool,
+		Volume:      m.Hostname + ".qcow2",
+		Network:     n.Name,
+		MACAddress:  m.MACAddress,
+	}
+	if err := l.virsh.CreateVolume(ctx, d.StoragePool, d.Volume, l.config.DiskSize); err != nil {
+		return err
+	}
+
+	definition, err := templater.Execute(domainTemplate, d)
+	if err != nil {
+		return fmt.Errorf("building definition of lab machine %s: %v", m.Hostname, err)
+	}
+
+	return l.virsh.DefineDomain(ctx, definition)
+}
+
+func (l *Lab) createBMC(ctx context.Context, n *network, m *hardware.Machine) error {
+	uuid, err := l.virsh.DomainUUID(ctx, m.Hostname)
+	if err != nil {
+		return err
+	}
+
+	dir, err := l.writeEmulatorConfig(emulator{
+		HostIP:     n.HostIP,
+		Port:       m.BMCPort,
+		ConfigDir:  emulatorConfigDir,
+		LibvirtURI: LibvirtURI,
+		UUID:       uuid,
+	}, m)
+	if err != nil {
+		return fmt.Errorf("writing bmc configuration of lab machine %s: %v", m.Hostname, err)
+	}
+
+	return l.docker.Run(ctx, l.config.EmulatorImage, bmcContainerName(m.Hostname),
+		[]string{"sushy-emulator", "--config", filepath.Join(emulatorConfigDir, emulatorConfigFile)},
+		"--network", "host",
+		"--label", fmt.Sprintf("%s=%s", labLabel, l.config.Name),
+		"-v", fmt.Sprintf("%s:%s", libvirtSocketDir, libvirtSocketDir),
+		"-v", fmt.Sprintf("%s:%s:ro", dir, emulatorConfigDir),
+	)
+}
+
+func bmcContainerName(hostname string) string {
+	return hostname + "-bmc"
+}
+
+// Delete removes the emulator containers, the VMs with their volumes and the network of the lab.
+// It continues after failures so a partially created lab can be deleted.
+func (l *Lab) Delete(ctx context.Context) error {
+	var errs []error
+
+	containers, err := l.docker.ListContainers(ctx, fmt.Sprintf("label=%s=%s", labLabel, l.config.Name))
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, c := range containers {
+		if err := l.docker.ForceRemove(ctx, c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	domains, err := l.virsh.ListDomains(ctx)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, d := range domains {
+		if !isLabMachine(l.config.Name, d) {
+			continue
+		}
+		if err := l.virsh.DeleteDomain(ctx, d); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	networks, err := l.virsh.ListNetworks(ctx)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, n := range networks {
+		if n != l.config.Name {
+			continue
+		}
+		if err := l.virsh.DeleteNetwork(ctx, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	l.writer.CleanUp()
+
+	return errors.NewAggregate(errs)
+}
+
+// isLabMachine returns whether a domain is a VM of the lab named name.
+func isLabMachine(name, domain string) bool {
+	return strings.HasPrefix(domain, name+"-cp-") || strings.HasPrefix(domain, name+"-worker-")
+}
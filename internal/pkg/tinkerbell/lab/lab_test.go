@@ -0,0 +1,231 @@
+package lab_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/pkg/tinkerbell/lab"
+	"github.com/aws/eks-anywhere/internal/pkg/tinkerbell/lab/mocks"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+type labTest struct {
+	*WithT
+	ctx    context.Context
+	virsh  *mocks.MockVirshClient
+	docker *mocks.MockDockerClient
+	writer filewriter.FileWriter
+	config lab.Config
+}
+
+func newLabTest(t *testing.T) *labTest {
+	ctrl := gomock.NewController(t)
+	writer, err := filewriter.NewWriter(filepath.Join(t.TempDir(), "lab"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &labTest{
+		WithT:  NewWithT(t),
+		ctx:    context.Background(),
+		virsh:  mocks.NewMockVirshClient(ctrl),
+		docker: mocks.NewMockDockerClient(ctrl),
+		writer: writer,
+		config: lab.Config{
+			Name:              "lab",
+			CIDR:              "10.80.0.0/24",
+			ControlPlaneCount: 1,
+			WorkerCount:       1,
+			CPUs:              2,
+			MemoryMiB:         8192,
+			DiskSize:          "40G",
+			StoragePool:       "default",
+			EmulatorImage:     lab.DefaultEmulatorImage,
+			EmulatorBasePort:  8001,
+			BMCUsername:       "admin",
+			BMCPassword:       "password",
+		},
+	}
+}
+
+func (tt *labTest) lab() *lab.Lab {
+	return lab.New(tt.virsh, tt.docker, tt.writer, tt.config)
+}
+
+// containsMatcher matches a definition containing all its substrings.
+type containsMatcher []string
+
+func containing(substrings ...string) gomock.Matcher {
+	return containsMatcher(substrings)
+}
+
+func (m containsMatcher) Matches(x interface{}) bool {
+	b, ok := x.([]byte)
+	if !ok {
+		return false
+	}
+	for _, s := range m {
+		if !strings.Contains(string(b), s) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m containsMatcher) String() string {
+	return fmt.Sprintf("contains %q", []string(m))
+}
+
+func (tt *labTest) expectMachine(name, mac, uuid string) {
+	tt.virsh.EXPECT().CreateVolume(tt.ctx, "default", name+".qcow2", "40G")
+	tt.virsh.EXPECT().DefineDomain(tt.ctx, containing("<name>"+name+"</name>", `<mac address="`+mac+`"/>`, `<source network="lab"/>`))
+	tt.virsh.EXPECT().DomainUUID(tt.ctx, name).Return(uuid, nil)
+	tt.docker.EXPECT().Run(tt.ctx, lab.DefaultEmulatorImage, name+"-bmc",
+		[]string{"sushy-emulator", "--config", "/etc/sushy/emulator.conf"},
+		"--network", "host",
+		"--label", "anywhere.eks.amazonaws.com/tinkerbell-lab=lab",
+		"-v", "/var/run/libvirt:/var/run/libvirt",
+		"-v", gomock.Any(),
+	)
+}
+
+func TestLabCreate(t *testing.T) {
+	tt := newLabTest(t)
+
+	tt.docker.EXPECT().BuildImage(tt.ctx, "eks-anywhere-tinkerbell-lab/sushy-tools:1.2.0", gomock.Any())
+	tt.virsh.EXPECT().DefineNetwork(tt.ctx, containing("<name>lab</name>", `<ip address="10.80.0.1" netmask="255.255.255.0"/>`))
+	tt.virsh.EXPECT().StartNetwork(tt.ctx, "lab")
+	tt.expectMachine("lab-cp-0", "52:54:00:50:00:0b", "uuid-cp")
+	tt.expectMachine("lab-worker-0", "52:54:00:50:00:0c", "uuid-worker")
+
+	machines, err := tt.lab().Create(tt.ctx)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(machines).To(Equal([]*hardware.Machine{
+		{
+			Hostname:     "lab-cp-0",
+			IPAddress:    "10.80.0.11",
+			Netmask:      "255.255.255.0",
+			Gateway:      "10.80.0.1",
+			Nameservers:  hardware.Nameservers{"10.80.0.1"},
+			MACAddress:   "52:54:00:50:00:0b",
+			Disk:         "/dev/vda",
+			Labels:       hardware.Labels{"type": "cp"},
+			BMCIPAddress: "10.80.0.1",
+			BMCUsername:  "admin",
+			BMCPassword:  "password",
+			BMCPort:      8001,
+			BMCVirtual:   true,
+		},
+		{
+			Hostname:     "lab-worker-0",
+			IPAddress:    "10.80.0.12",
+			Netmask:      "255.255.255.0",
+			Gateway:      "10.80.0.1",
+			Nameservers:  hardware.Nameservers{"10.80.0.1"},
+			MACAddress:   "52:54:00:50:00:0c",
+			Disk:         "/dev/vda",
+			Labels:       hardware.Labels{"type": "worker"},
+			BMCIPAddress: "10.80.0.1",
+			BMCUsername:  "admin",
+			BMCPassword:  "password",
+			BMCPort:      8002,
+			BMCVirtual:   true,
+		},
+	}))
+
+	config, err := os.ReadFile(filepath.Join(tt.writer.Dir(), "lab-worker-0", "emulator.conf"))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(string(config)).To(ContainSubstring("SUSHY_EMULATOR_LISTEN_IP = '10.80.0.1'"))
+	tt.Expect(string(config)).To(ContainSubstring("SUSHY_EMULATOR_LISTEN_PORT = 8002"))
+	tt.Expect(string(config)).To(ContainSubstring("SUSHY_EMULATOR_ALLOWED_INSTANCES = ['uuid-worker']"))
+
+	dockerfile, err := os.ReadFile(filepath.Join(tt.writer.Dir(), "emulator-image", "Dockerfile"))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(string(dockerfile)).To(HavePrefix("FROM debian:12.7\n"))
+	tt.Expect(string(dockerfile)).To(ContainSubstring("sushy-tools==1.2.0"))
+
+	htpasswd, err := os.ReadFile(filepath.Join(tt.writer.Dir(), "lab-worker-0", "htpasswd"))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(string(htpasswd)).To(HavePrefix("admin:$2a$"))
+	tt.Expect(filepath.Join(tt.writer.Dir(), "lab-worker-0", "tls.crt")).To(BeAnExistingFile())
+	tt.Expect(filepath.Join(tt.writer.Dir(), "lab-worker-0", "tls.key")).To(BeAnExistingFile())
+}
+
+func TestLabCreateCustomEmulatorImage(t *testing.T) {
+	tt := newLabTest(t)
+	tt.config.EmulatorImage = "registry.example.com/sushy-tools:1.3.0"
+	tt.config.WorkerCount = 0
+
+	tt.virsh.EXPECT().DefineNetwork(tt.ctx, gomock.Any())
+	tt.virsh.EXPECT().StartNetwork(tt.ctx, "lab")
+	tt.virsh.EXPECT().CreateVolume(tt.ctx, "default", "lab-cp-0.qcow2", "40G")
+	tt.virsh.EXPECT().DefineDomain(tt.ctx, gomock.Any())
+	tt.virsh.EXPECT().DomainUUID(tt.ctx, "lab-cp-0").Return("uuid-cp", nil)
+	tt.docker.EXPECT().Run(tt.ctx, "registry.example.com/sushy-tools:1.3.0", "lab-cp-0-bmc", gomock.Any(), gomock.Any())
+
+	_, err := tt.lab().Create(tt.ctx)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(filepath.Join(tt.writer.Dir(), "emulator-image")).NotTo(BeADirectory())
+}
+
+func TestLabCreateEmulatorImageError(t *testing.T) {
+	tt := newLabTest(t)
+
+	tt.docker.EXPECT().BuildImage(tt.ctx, lab.DefaultEmulatorImage, gomock.Any()).Return(errors.New("cannot connect to the docker daemon"))
+
+	_, err := tt.lab().Create(tt.ctx)
+	tt.Expect(err).To(MatchError("cannot connect to the docker daemon"))
+}
+
+func TestLabCreateCIDRTooSmall(t *testing.T) {
+	tt := newLabTest(t)
+	tt.config.CIDR = "10.80.0.0/28"
+	tt.config.WorkerCount = 4
+
+	_, err := tt.lab().Create(tt.ctx)
+	tt.Expect(err).To(MatchError("lab CIDR 10.80.0.0/28 only has room for 4 machines"))
+}
+
+func TestLabCreateInvalidCIDR(t *testing.T) {
+	tt := newLabTest(t)
+	tt.config.CIDR = "fd00::/64"
+
+	_, err := tt.lab().Create(tt.ctx)
+	tt.Expect(err).To(MatchError("lab CIDR fd00::/64 must be an IPv4 CIDR"))
+}
+
+func TestLabCreateVMError(t *testing.T) {
+	tt := newLabTest(t)
+
+	tt.docker.EXPECT().BuildImage(tt.ctx, lab.DefaultEmulatorImage, gomock.Any())
+	tt.virsh.EXPECT().DefineNetwork(tt.ctx, gomock.Any())
+	tt.virsh.EXPECT().StartNetwork(tt.ctx, "lab")
+	tt.virsh.EXPECT().CreateVolume(tt.ctx, "default", "lab-cp-0.qcow2", "40G").Return(errors.New("pool default not found"))
+
+	_, err := tt.lab().Create(tt.ctx)
+	tt.Expect(err).To(MatchError("pool default not found"))
+}
+
+func TestLabDelete(t *testing.T) {
+	tt := newLabTest(t)
+
+	tt.docker.EXPECT().ListContainers(tt.ctx, "label=anywhere.eks.amazonaws.com/tinkerbell-lab=lab").Return([]string{"lab-cp-0-bmc", "lab-worker-0-bmc"}, nil)
+	tt.docker.EXPECT().ForceRemove(tt.ctx, "lab-cp-0-bmc")
+	tt.docker.EXPECT().ForceRemove(tt.ctx, "lab-worker-0-bmc")
+	tt.virsh.EXPECT().ListDomains(tt.ctx).Return([]string{"lab-cp-0", "lab-worker-0", "lab2-cp-0", "devbox"}, nil)
+	tt.virsh.EXPECT().DeleteDomain(tt.ctx, "lab-cp-0").Return(errors.New("domain is locked"))
+	tt.virsh.EXPECT().DeleteDomain(tt.ctx, "lab-worker-0")
+	tt.virsh.EXPECT().ListNetworks(tt.ctx).Return([]string{"default", "lab"}, nil)
+	tt.virsh.EXPECT().DeleteNetwork(tt.ctx, "lab")
+
+	tt.Expect(tt.lab().Delete(tt.ctx)).To(MatchError("domain is locked"))
+}
@@ -0,0 +1,249 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/pkg/tinkerbell/lab/lab.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockVirshClient is a mock of VirshClient interface.
+type MockVirshClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockVirshClientMockRecorder
+}
+
+// MockVirshClientMockRecorder is the mock recorder for MockVirshClient.
+type MockVirshClientMockRecorder struct {
+	mock *MockVirshClient
+}
+
+// NewMockVirshClient creates a new mock instance.
+func NewMockVirshClient(ctrl *gomock.Controller) *MockVirshClient {
+	mock := &MockVirshClient{ctrl: ctrl}
+	mock.recorder = &MockVirshClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVirshClient) EXPECT() *MockVirshClientMockRecorder {
+	return m.recorder
+}
+
+// CreateVolume mocks base method.
+func (m *MockVirshClient) CreateVolume(ctx context.Context, pool, name, size string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVolume", ctx, pool, name, size)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVolume indicates an expected call of CreateVolume.
+func (mr *MockVirshClientMockRecorder) CreateVolume(ctx, pool, name, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVolume", reflect.TypeOf((*MockVirshClient)(nil).CreateVolume), ctx, pool, name, size)
+}
+
+// DefineDomain mocks base method.
+func (m *MockVirshClient) DefineDomain(ctx context.Context, definition []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DefineDomain", ctx, definition)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DefineDomain indicates an expected call of DefineDomain.
+func (mr *MockVirshClientMockRecorder) DefineDomain(ctx, definition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DefineDomain", reflect.TypeOf((*MockVirshClient)(nil).DefineDomain), ctx, definition)
+}
+
+// DefineNetwork mocks base method.
+func (m *MockVirshClient) DefineNetwork(ctx context.Context, definition []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DefineNetwork", ctx, definition)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DefineNetwork indicates an expected call of DefineNetwork.
+func (mr *MockVirshClientMockRecorder) DefineNetwork(ctx, definition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DefineNetwork", reflect.TypeOf((*MockVirshClient)(nil).DefineNetwork), ctx, definition)
+}
+
+// DeleteDomain mocks base method.
+func (m *MockVirshClient) DeleteDomain(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDomain", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDomain indicates an expected call of DeleteDomain.
+func (mr *MockVirshClientMockRecorder) DeleteDomain(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDomain", reflect.TypeOf((*MockVirshClient)(nil).DeleteDomain), ctx, name)
+}
+
+// DeleteNetwork mocks base method.
+func (m *MockVirshClient) DeleteNetwork(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNetwork", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNetwork indicates an expected call of DeleteNetwork.
+func (mr *MockVirshClientMockRecorder) DeleteNetwork(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNetwork", reflect.TypeOf((*MockVirshClient)(nil).DeleteNetwork), ctx, name)
+}
+
+// DomainUUID mocks base method.
+func (m *MockVirshClient) DomainUUID(ctx context.Context, name string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DomainUUID", ctx, name)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DomainUUID indicates an expected call of DomainUUID.
+func (mr *MockVirshClientMockRecorder) DomainUUID(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DomainUUID", reflect.TypeOf((*MockVirshClient)(nil).DomainUUID), ctx, name)
+}
+
+// ListDomains mocks base method.
+func (m *MockVirshClient) ListDomains(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDomains", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDomains indicates an expected call of ListDomains.
+func (mr *MockVirshClientMockRecorder) ListDomains(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDomains", reflect.TypeOf((*MockVirshClient)(nil).ListDomains), ctx)
+}
+
+// ListNetworks mocks base method.
+func (m *MockVirshClient) ListNetworks(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworks", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworks indicates an expected call of ListNetworks.
+func (mr *MockVirshClientMockRecorder) ListNetworks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworks", reflect.TypeOf((*MockVirshClient)(nil).ListNetworks), ctx)
+}
+
+// StartNetwork mocks base method.
+func (m *MockVirshClient) StartNetwork(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartNetwork", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartNetwork indicates an expected call of StartNetwork.
+func (mr *MockVirshClientMockRecorder) StartNetwork(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartNetwork", reflect.TypeOf((*MockVirshClient)(nil).StartNetwork), ctx, name)
+}
+
+// MockDockerClient is a mock of DockerClient interface.
+type MockDockerClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockDockerClientMockRecorder
+}
+
+// MockDockerClientMockRecorder is the mock recorder for MockDockerClient.
+type MockDockerClientMockRecorder struct {
+	mock *MockDockerClient
+}
+
+// NewMockDockerClient creates a new mock instance.
+func NewMockDockerClient(ctrl *gomock.Controller) *MockDockerClient {
+	mock := &MockDockerClient{ctrl: ctrl}
+	mock.recorder = &MockDockerClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDockerClient) EXPECT() *MockDockerClientMockRecorder {
+	return m.recorder
+}
+
+// BuildImage mocks base method.
+func (m *MockDockerClient) BuildImage(ctx context.Context, image, contextDir string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildImage", ctx, image, contextDir)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BuildImage indicates an expected call of BuildImage.
+func (mr *MockDockerClientMockRecorder) BuildImage(ctx, image, contextDir interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildImage", reflect.TypeOf((*MockDockerClient)(nil).BuildImage), ctx, image, contextDir)
+}
+
+// ForceRemove mocks base method.
+func (m *MockDockerClient) ForceRemove(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceRemove", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceRemove indicates an expected call of ForceRemove.
+func (mr *MockDockerClientMockRecorder) ForceRemove(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceRemove", reflect.TypeOf((*MockDockerClient)(nil).ForceRemove), ctx, name)
+}
+
+// ListContainers mocks base method.
+func (m *MockDockerClient) ListContainers(ctx context.Context, filter string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListContainers", ctx, filter)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListContainers indicates an expected call of ListContainers.
+func (mr *MockDockerClientMockRecorder) ListContainers(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContainers", reflect.TypeOf((*MockDockerClient)(nil).ListContainers), ctx, filter)
+}
+
+// Run mocks base method.
+func (m *MockDockerClient) Run(ctx context.Context, image, name string, cmd []string, flags ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, image, name, cmd}
+	for _, a := range flags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Run", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockDockerClientMockRecorder) Run(ctx, image, name, cmd interface{}, flags ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, image, name, cmd}, flags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockDockerClient)(nil).Run), varargs...)
+}
@@ -24,6 +24,7 @@ import (
 	bootstrapv1beta2 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	controlplanev1beta2 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1beta1 "sigs.k8s.io/cluster-api/api/ipam/v1beta1"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	dockerv1beta2 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta2"
 	capiflags "sigs.k8s.io/cluster-api/util/flags"
@@ -65,6 +66,7 @@ func init() {
 	utilruntime.Must(tinkv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(rufiov1alpha1.AddToScheme(scheme))
 	utilruntime.Must(nutanixv1.AddToScheme(scheme))
+	utilruntime.Must(ipamv1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -185,8 +187,13 @@ func setupReconcilers(ctx context.Context, setupLog logr.Logger, mgr ctrl.Manage
 			providers,
 		).
 		WithVSphereDatacenterReconciler().
+		WithVSphereIPAddressClaimReconciler().
 		WithSnowMachineConfigReconciler().
+		WithSnowIPPoolReconciler().
 		WithNutanixDatacenterReconciler().
+		WithNutanixIPPoolReconciler().
+		WithNutanixIPAddressClaimReconciler().
+		WithNutanixDataDiskReconciler().
 		WithCloudStackDatacenterReconciler().
 		WithKubeadmControlPlaneReconciler().
 		WithMachineDeploymentReconciler().
@@ -213,18 +220,48 @@ func setupReconcilers(ctx context.Context, setupLog logr.Logger, mgr ctrl.Manage
 		failed = true
 	}
 
+	setupLog.Info("Setting up vsphere ipaddressclaim controller")
+	if err := (reconcilers.VSphereIPAddressClaimReconciler).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "IPAddressClaim")
+		failed = true
+	}
+
 	setupLog.Info("Setting up snowmachineconfig controller")
 	if err := (reconcilers.SnowMachineConfigReconciler).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", anywherev1.SnowMachineConfigKind)
 		failed = true
 	}
 
+	setupLog.Info("Setting up snowippool controller")
+	if err := (reconcilers.SnowIPPoolReconciler).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", anywherev1.SnowIPPoolKind)
+		failed = true
+	}
+
 	setupLog.Info("Setting up nutanixdatacenter controller")
 	if err := (reconcilers.NutanixDatacenterReconciler).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", anywherev1.NutanixDatacenterKind)
 		failed = true
 	}
 
+	setupLog.Info("Setting up nutanix ippool controller")
+	if err := (reconcilers.NutanixIPPoolReconciler).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NutanixMachine")
+		failed = true
+	}
+
+	setupLog.Info("Setting up nutanix ipaddressclaim controller")
+	if err := (reconcilers.NutanixIPAddressClaimReconciler).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "IPAddressClaim")
+		failed = true
+	}
+
+	setupLog.Info("Setting up nutanix data disk controller")
+	if err := (reconcilers.NutanixDataDiskReconciler).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NutanixMachine")
+		failed = true
+	}
+
 	setupLog.Info("Setting up cloudstackdatacenter controller")
 	if err := (reconcilers.CloudStackDatacenterReconciler).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", anywherev1.CloudStackDatacenterKind)
@@ -311,6 +348,10 @@ func setupVSphereWebhooks(setupLog logr.Logger, mgr ctrl.Manager) {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.VSphereMachineConfigKind)
 		os.Exit(1)
 	}
+	if err := (&anywherev1.VSphereIPPool{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.VSphereIPPoolKind)
+		os.Exit(1)
+	}
 }
 
 func setupCloudstackWebhooks(setupLog logr.Logger, mgr ctrl.Manager) {
@@ -337,6 +378,10 @@ func setupSnowWebhooks(setupLog logr.Logger, mgr ctrl.Manager) {
 		setupLog.Error(err, "unable to create webhook", "webhook", "SnowIPPool")
 		os.Exit(1)
 	}
+	if err := controllers.NewSnowMachinePlacementDefaulter(mgr.GetAPIReader()).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "AWSSnowMachine")
+		os.Exit(1)
+	}
 }
 
 func setupTinkerbellWebhooks(setupLog logr.Logger, mgr ctrl.Manager) {
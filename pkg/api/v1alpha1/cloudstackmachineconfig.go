@@ -91,5 +91,8 @@ func validateAffinityConfig(machineConfig *CloudStackMachineConfig) error {
 			return fmt.Errorf("invalid affinity type %s for CloudStackMachineConfig %s. Please provide \"pro\", \"anti\" or \"no\"", machineConfig.Spec.Affinity, machineConfig.Name)
 		}
 	}
+	if machineConfig.Spec.ManageAffinityGroups && machineConfig.Spec.Affinity != "pro" && machineConfig.Spec.Affinity != "anti" {
+		return fmt.Errorf("manageAffinityGroups requires affinity \"pro\" or \"anti\" for CloudStackMachineConfig %s", machineConfig.Name)
+	}
 	return nil
 }
@@ -333,6 +333,28 @@ func TestCloudStackMachineConfigValidate(t *testing.T) {
 			},
 			wantErr: "affinity and affinityGroupIds cannot be set at the same time for CloudStackMachineConfig test. Please provide either one of them or none",
 		},
+		{
+			name: "manageAffinityGroups without pro or anti affinity",
+			obj: &CloudStackMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: CloudStackMachineConfigSpec{
+					Template: CloudStackResourceIdentifier{
+						Name: "template1",
+					},
+					ComputeOffering: CloudStackResourceIdentifier{
+						Name: "offering1",
+					},
+					Users: []UserConfiguration{
+						{
+							Name:              "zone1",
+							SshAuthorizedKeys: []string{"key"},
+						},
+					},
+					ManageAffinityGroups: true,
+				},
+			},
+			wantErr: `manageAffinityGroups requires affinity "pro" or "anti" for CloudStackMachineConfig test`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -436,6 +458,13 @@ func TestCloudStackMachineNotEqualAffinity(t *testing.T) {
 	g.Expect(cloudStackMachineConfigSpec1.Equal(cloudStackMachineConfigSpec2)).To(BeFalse(), "Affinity comparison in CloudStackMachineConfigSpec not detected")
 }
 
+func TestCloudStackMachineNotEqualManageAffinityGroups(t *testing.T) {
+	g := NewWithT(t)
+	cloudStackMachineConfigSpec2 := cloudStackMachineConfigSpec1.DeepCopy()
+	cloudStackMachineConfigSpec2.ManageAffinityGroups = true
+	g.Expect(cloudStackMachineConfigSpec1.Equal(cloudStackMachineConfigSpec2)).To(BeFalse(), "ManageAffinityGroups comparison in CloudStackMachineConfigSpec not detected")
+}
+
 func TestCloudStackMachineNotEqualUsersNil(t *testing.T) {
 	g := NewWithT(t)
 	cloudStackMachineConfigSpec2 := cloudStackMachineConfigSpec1.DeepCopy()
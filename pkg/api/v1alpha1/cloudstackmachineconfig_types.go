@@ -50,6 +50,11 @@ type CloudStackMachineConfigSpec struct {
 	// must be on separate physical hosts for high availability. If they are type “affinity”, all
 	// VM’s in the group must be on the same physical host for improved performance
 	AffinityGroupIds []string `json:"affinityGroupIds,omitempty"`
+	// ManageAffinityGroups can only be set with affinity `pro` or `anti`. When set, EKS Anywhere
+	// creates and owns one affinity group of the corresponding type per control plane, etcd and
+	// worker node group instead of one per machine set, keeps it across rolling upgrades and deletes
+	// it when the node group or the cluster is deleted
+	ManageAffinityGroups bool `json:"manageAffinityGroups,omitempty"`
 	// UserCustomDetails allows users to pass in non-standard key value inputs, outside those
 	// defined [here](https://github.com/shapeblue/cloudstack/blob/main/api/src/main/java/com/cloud/vm/VmDetailConstants.java)
 	UserCustomDetails map[string]string `json:"userCustomDetails,omitempty"`
@@ -278,7 +283,7 @@ func (c *CloudStackMachineConfigSpec) Equal(o *CloudStackMachineConfigSpec) bool
 		!c.DiskOffering.Equal(o.DiskOffering) {
 		return false
 	}
-	if c.Affinity != o.Affinity {
+	if c.Affinity != o.Affinity || c.ManageAffinityGroups != o.ManageAffinityGroups {
 		return false
 	}
 	if !SliceEqual(c.AffinityGroupIds, o.AffinityGroupIds) {
@@ -120,6 +120,13 @@ func validateImmutableFieldsCloudStackMachineConfig(new, old *CloudStackMachineC
 		)
 	}
 
+	if old.Spec.ManageAffinityGroups != new.Spec.ManageAffinityGroups {
+		allErrs = append(
+			allErrs,
+			field.Invalid(field.NewPath("spec", "manageAffinityGroups"), new.Spec.ManageAffinityGroups, "field is immutable"),
+		)
+	}
+
 	affinityGroupIdsMutated := false
 	if len(old.Spec.AffinityGroupIds) != len(new.Spec.AffinityGroupIds) {
 		affinityGroupIdsMutated = true
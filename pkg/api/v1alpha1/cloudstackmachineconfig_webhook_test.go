@@ -558,6 +558,18 @@ func TestCloudStackMachineValidateUpdateAffinityImmutable(t *testing.T) {
 	g.Expect(c.ValidateUpdate(ctx, &vOld, c)).Error().ToNot(Succeed())
 }
 
+func TestCloudStackMachineValidateUpdateManageAffinityGroupsImmutable(t *testing.T) {
+	ctx := context.Background()
+	vOld := cloudstackMachineConfig()
+	vOld.SetControlPlane()
+	vOld.Spec.Affinity = "anti"
+	c := vOld.DeepCopy()
+
+	c.Spec.ManageAffinityGroups = true
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(ctx, &vOld, c)).Error().ToNot(Succeed())
+}
+
 func TestCloudStackMachineValidateUpdateAffinityGroupIdsImmutable(t *testing.T) {
 	ctx := context.Background()
 	vOld := cloudstackMachineConfig()
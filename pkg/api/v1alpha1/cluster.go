@@ -1013,8 +1013,14 @@ func validateCPUpgradeRolloutStrategy(clusterConfig *Cluster) error {
 			}
 			return nil
 		}
+		if clusterConfig.Spec.DatacenterRef.Kind == DockerDatacenterKind {
+			if clusterConfig.Spec.ExternalEtcdConfiguration != nil {
+				return errors.New("stacked etcd must be configured when performing in place upgrades")
+			}
+			return nil
+		}
 		if clusterConfig.Spec.DatacenterRef.Kind != TinkerbellDatacenterKind {
-			return fmt.Errorf("ControlPlaneConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal")
+			return fmt.Errorf("ControlPlaneConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal and Docker")
 		}
 	default:
 		return fmt.Errorf("ControlPlaneConfiguration: only 'RollingUpdate' and 'InPlace' are supported for upgrade rollout strategy type")
@@ -1051,8 +1057,8 @@ func validateMDUpgradeRolloutStrategy(w *WorkerNodeGroupConfiguration, datacente
 			}
 			return errors.New("in place upgrades are not supported on vSphere")
 		}
-		if datacenterRefKind != TinkerbellDatacenterKind {
-			return fmt.Errorf("WorkerNodeGroupConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal")
+		if datacenterRefKind != TinkerbellDatacenterKind && datacenterRefKind != DockerDatacenterKind {
+			return fmt.Errorf("WorkerNodeGroupConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal and Docker")
 		}
 	default:
 		return fmt.Errorf("WorkerNodeGroupConfiguration: only 'RollingUpdate' and 'InPlace' are supported for upgrade rollout strategy type")
@@ -3698,9 +3698,40 @@ func TestValidateCPUpgradeRolloutStrategy(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "in place upgrade - docker",
+			wantErr: "",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: DockerDatacenterKind,
+					},
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						UpgradeRolloutStrategy: &ControlPlaneUpgradeRolloutStrategy{Type: "InPlace"},
+					},
+				},
+			},
+		},
+		{
+			name:    "in place upgrade - docker, external etcd",
+			wantErr: "stacked etcd must be configured when performing in place upgrades",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: DockerDatacenterKind,
+					},
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						UpgradeRolloutStrategy: &ControlPlaneUpgradeRolloutStrategy{Type: "InPlace"},
+					},
+					ExternalEtcdConfiguration: &ExternalEtcdConfiguration{
+						Count: 3,
+					},
+				},
+			},
+		},
 		{
 			name:    "in place upgrade - provider not supported",
-			wantErr: "ControlPlaneConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal",
+			wantErr: "ControlPlaneConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal and Docker",
 			cluster: &Cluster{
 				Spec: ClusterSpec{
 					ControlPlaneConfiguration: ControlPlaneConfiguration{
@@ -3886,9 +3917,23 @@ func TestValidateMDUpgradeRolloutStrategy(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "in place upgrade - docker",
+			wantErr: "",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: DockerDatacenterKind,
+					},
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{{
+						UpgradeRolloutStrategy: &WorkerNodesUpgradeRolloutStrategy{Type: "InPlace"},
+					}},
+				},
+			},
+		},
 		{
 			name:    "in place upgrade - provider not supported",
-			wantErr: "WorkerNodeGroupConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal",
+			wantErr: "WorkerNodeGroupConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal and Docker",
 			cluster: &Cluster{
 				Spec: ClusterSpec{
 					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{{
@@ -1061,6 +1061,12 @@ const (
 	MachineInvalidReason FailureReasonType = "MachineInvalid"
 )
 
+// Reasons for the terminal failures while reconciling the Cluster object specific for Snow.
+const (
+	// IPPoolInsufficientReason reports that a SnowIPPool doesn't have enough free addresses for the cluster machines.
+	IPPoolInsufficientReason FailureReasonType = "IPPoolInsufficient"
+)
+
 // ClusterCertificateInfo contains information about certificate expiration for cluster components.
 type ClusterCertificateInfo struct {
 	// Machine defines the machine name.
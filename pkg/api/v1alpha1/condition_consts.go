@@ -78,3 +78,15 @@ const (
 	// create a cluster.
 	SkipUpgradesForDefaultCNIConfiguredReason = "SkipUpgradesForDefaultCNIConfigured"
 )
+
+const (
+	// HardwareProvisionedCondition reports the provisioning workflows of the cluster's Tinkerbell machines
+	// have completed successfully.
+	HardwareProvisionedCondition ConditionType = "HardwareProvisioned"
+
+	// WorkflowsInProgressReason used when the provisioning workflows of one or more machines haven't completed yet.
+	WorkflowsInProgressReason = "WorkflowsInProgress"
+
+	// WorkflowFailedReason used when the provisioning workflow of one or more machines failed or timed out.
+	WorkflowFailedReason = "WorkflowFailed"
+)
@@ -1,6 +1,9 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"net"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/pkg/constants"
@@ -50,3 +53,143 @@ func GetNutanixDatacenterConfig(fileName string) (*NutanixDatacenterConfig, erro
 	}
 	return &clusterConfig, nil
 }
+
+// IPPool returns the pool with the given name, nil if the datacenter has no such pool.
+func (in *NutanixDatacenterConfig) IPPool(name string) *NutanixIPPool {
+	for i := range in.Spec.IPPools {
+		if in.Spec.IPPools[i].Name == name {
+			return &in.Spec.IPPools[i]
+		}
+	}
+	return nil
+}
+
+// IsPrismManaged returns true if the addresses of the pool are assigned by the IPAM of a Prism managed subnet.
+func (p *NutanixIPPool) IsPrismManaged() bool {
+	return len(p.Ranges) == 0
+}
+
+// Size returns the number of addresses in the pool ranges.
+func (p *NutanixIPPool) Size() int {
+	size := 0
+	for _, r := range p.Ranges {
+		start, end, ok := parseNutanixIPRange(r)
+		if !ok || start > end {
+			continue
+		}
+		size += int(end-start) + 1
+	}
+	return size
+}
+
+// Contains returns true if the address is in one of the pool ranges.
+func (p *NutanixIPPool) Contains(ip string) bool {
+	addr, ok := ipv4ToUint32(net.ParseIP(ip))
+	if !ok {
+		return false
+	}
+	for _, r := range p.Ranges {
+		start, end, ok := parseNutanixIPRange(r)
+		if ok && addr >= start && addr <= end {
+			return true
+		}
+	}
+	return false
+}
+
+// FirstAvailable returns the first address of the pool ranges that is not in inUse.
+// It returns false when all the addresses are in use.
+func (p *NutanixIPPool) FirstAvailable(inUse map[string]bool) (string, bool) {
+	for _, r := range p.Ranges {
+		start, end, ok := parseNutanixIPRange(r)
+		if !ok {
+			continue
+		}
+		for addr := uint64(start); addr <= uint64(end); addr++ {
+			ip := uint32ToIPv4(uint32(addr)).String()
+			if !inUse[ip] {
+				return ip, true
+			}
+		}
+	}
+	return "", false
+}
+
+func validateNutanixIPPools(in *NutanixDatacenterConfig) error {
+	validateSubnetResourceIdentifier := createValidateNutanixResourceFunc("NutanixDatacenterConfig.Spec.IPPools.Subnet", "subnet", in.Namespace+"/"+in.Name)
+	names := map[string]bool{}
+	for i := range in.Spec.IPPools {
+		pool := &in.Spec.IPPools[i]
+		if pool.Name == "" {
+			return fmt.Errorf("NutanixDatacenterConfig ipPools[%d] name can not be empty", i)
+		}
+		if names[pool.Name] {
+			return fmt.Errorf("NutanixDatacenterConfig ipPools name %s is duplicated", pool.Name)
+		}
+		names[pool.Name] = true
+
+		if err := validateSubnetResourceIdentifier(&pool.Subnet); err != nil {
+			return err
+		}
+
+		if err := validateNutanixIPPool(pool); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateNutanixIPPool(pool *NutanixIPPool) error {
+	if pool.IsPrismManaged() {
+		if pool.Prefix != 0 || pool.Gateway != "" || len(pool.Nameservers) != 0 {
+			return fmt.Errorf("NutanixDatacenterConfig ipPool %s without ranges uses the Prism subnet IPAM, prefix, gateway and nameservers can not be set", pool.Name)
+		}
+		return nil
+	}
+
+	if pool.Prefix < 1 || pool.Prefix > 32 {
+		return fmt.Errorf("NutanixDatacenterConfig ipPool %s prefix %d is invalid, it must be between 1 and 32", pool.Name, pool.Prefix)
+	}
+
+	gateway := net.ParseIP(pool.Gateway).To4()
+	if gateway == nil {
+		return fmt.Errorf("NutanixDatacenterConfig ipPool %s gateway %s is not a valid IPv4 address", pool.Name, pool.Gateway)
+	}
+	subnet := &net.IPNet{IP: gateway.Mask(net.CIDRMask(pool.Prefix, 32)), Mask: net.CIDRMask(pool.Prefix, 32)}
+
+	for index, r := range pool.Ranges {
+		start := net.ParseIP(r.Start).To4()
+		if start == nil {
+			return fmt.Errorf("NutanixDatacenterConfig ipPool %s ranges[%d].start %s is not a valid IPv4 address", pool.Name, index, r.Start)
+		}
+		end := net.ParseIP(r.End).To4()
+		if end == nil {
+			return fmt.Errorf("NutanixDatacenterConfig ipPool %s ranges[%d].end %s is not a valid IPv4 address", pool.Name, index, r.End)
+		}
+		if s, e, _ := parseNutanixIPRange(r); s > e {
+			return fmt.Errorf("NutanixDatacenterConfig ipPool %s ranges[%d].start should not be greater than end", pool.Name, index)
+		}
+		if !subnet.Contains(start) || !subnet.Contains(end) {
+			return fmt.Errorf("NutanixDatacenterConfig ipPool %s ranges[%d] should be within the subnet %s", pool.Name, index, subnet)
+		}
+	}
+
+	if pool.Contains(pool.Gateway) {
+		return fmt.Errorf("NutanixDatacenterConfig ipPool %s gateway %s can not be inside the pool ranges", pool.Name, pool.Gateway)
+	}
+
+	for index, ns := range pool.Nameservers {
+		if net.ParseIP(ns) == nil {
+			return fmt.Errorf("NutanixDatacenterConfig ipPool %s nameservers[%d] %s is not a valid IP address", pool.Name, index, ns)
+		}
+	}
+
+	return nil
+}
+
+func parseNutanixIPRange(r NutanixIPRange) (start, end uint32, ok bool) {
+	start, startOk := ipv4ToUint32(net.ParseIP(r.Start))
+	end, endOk := ipv4ToUint32(net.ParseIP(r.End))
+	return start, end, startOk && endOk
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
 
 func TestGetNutanixDatacenterConfigInvalidConfig(t *testing.T) {
@@ -218,3 +219,125 @@ func TestNutanixDatacenterConfigSetDefaults(t *testing.T) {
 	assert.Equal(t, constants.NutanixCredentialsName, dcConf.Spec.CredentialRef.Name)
 	assert.Equal(t, constants.SecretKind, dcConf.Spec.CredentialRef.Kind)
 }
+
+func nutanixIPPoolsDatacenterConfig(pools ...v1alpha1.NutanixIPPool) *v1alpha1.NutanixDatacenterConfig {
+	return &v1alpha1.NutanixDatacenterConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "eksa-unit-test", Namespace: "default"},
+		Spec: v1alpha1.NutanixDatacenterConfigSpec{
+			Endpoint: "prism.nutanix.com",
+			Port:     9440,
+			IPPools:  pools,
+		},
+	}
+}
+
+func inClusterNutanixIPPool() v1alpha1.NutanixIPPool {
+	return v1alpha1.NutanixIPPool{
+		Name:   "workers",
+		Subnet: v1alpha1.NutanixResourceIdentifier{Type: v1alpha1.NutanixIdentifierName, Name: ptr.String("unmanaged")},
+		Ranges: []v1alpha1.NutanixIPRange{
+			{Start: "10.0.0.10", End: "10.0.0.12"},
+			{Start: "10.0.0.20", End: "10.0.0.20"},
+		},
+		Prefix:      24,
+		Gateway:     "10.0.0.1",
+		Nameservers: []string{"10.0.0.2"},
+	}
+}
+
+func TestNutanixDatacenterConfigValidateIPPools(t *testing.T) {
+	prismPool := v1alpha1.NutanixIPPool{
+		Name:   "managed",
+		Subnet: v1alpha1.NutanixResourceIdentifier{Type: v1alpha1.NutanixIdentifierName, Name: ptr.String("managed")},
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(p *v1alpha1.NutanixIPPool)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			mutate: func(p *v1alpha1.NutanixIPPool) {},
+		},
+		{
+			name:    "missing subnet name",
+			mutate:  func(p *v1alpha1.NutanixIPPool) { p.Subnet.Name = nil },
+			wantErr: "NutanixDatacenterConfig.Spec.IPPools.Subnet: missing subnet name: default/eksa-unit-test",
+		},
+		{
+			name:    "invalid prefix",
+			mutate:  func(p *v1alpha1.NutanixIPPool) { p.Prefix = 33 },
+			wantErr: "NutanixDatacenterConfig ipPool workers prefix 33 is invalid, it must be between 1 and 32",
+		},
+		{
+			name:    "missing gateway",
+			mutate:  func(p *v1alpha1.NutanixIPPool) { p.Gateway = "" },
+			wantErr: "NutanixDatacenterConfig ipPool workers gateway  is not a valid IPv4 address",
+		},
+		{
+			name:    "range start after end",
+			mutate:  func(p *v1alpha1.NutanixIPPool) { p.Ranges[0].Start = "10.0.0.13" },
+			wantErr: "NutanixDatacenterConfig ipPool workers ranges[0].start should not be greater than end",
+		},
+		{
+			name:    "range outside subnet",
+			mutate:  func(p *v1alpha1.NutanixIPPool) { p.Ranges[1].End = "10.0.1.20" },
+			wantErr: "NutanixDatacenterConfig ipPool workers ranges[1] should be within the subnet 10.0.0.0/24",
+		},
+		{
+			name:    "gateway in ranges",
+			mutate:  func(p *v1alpha1.NutanixIPPool) { p.Gateway = "10.0.0.11" },
+			wantErr: "NutanixDatacenterConfig ipPool workers gateway 10.0.0.11 can not be inside the pool ranges",
+		},
+		{
+			name:    "invalid nameserver",
+			mutate:  func(p *v1alpha1.NutanixIPPool) { p.Nameservers = []string{"dns"} },
+			wantErr: "NutanixDatacenterConfig ipPool workers nameservers[0] dns is not a valid IP address",
+		},
+		{
+			name: "prism pool with gateway",
+			mutate: func(p *v1alpha1.NutanixIPPool) {
+				p.Ranges = nil
+			},
+			wantErr: "NutanixDatacenterConfig ipPool workers without ranges uses the Prism subnet IPAM, prefix, gateway and nameservers can not be set",
+		},
+		{
+			name:    "duplicated name",
+			mutate:  func(p *v1alpha1.NutanixIPPool) { p.Name = "managed" },
+			wantErr: "NutanixDatacenterConfig ipPools name managed is duplicated",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := inClusterNutanixIPPool()
+			tc.mutate(&pool)
+			err := nutanixIPPoolsDatacenterConfig(prismPool, pool).Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNutanixIPPoolAddresses(t *testing.T) {
+	pool := inClusterNutanixIPPool()
+	dc := nutanixIPPoolsDatacenterConfig(pool)
+
+	require.NotNil(t, dc.IPPool("workers"))
+	assert.Nil(t, dc.IPPool("other"))
+	assert.False(t, pool.IsPrismManaged())
+	assert.Equal(t, 4, pool.Size())
+	assert.True(t, pool.Contains("10.0.0.20"))
+	assert.False(t, pool.Contains("10.0.0.13"))
+
+	ip, ok := pool.FirstAvailable(map[string]bool{"10.0.0.10": true, "10.0.0.11": true, "10.0.0.12": true})
+	assert.True(t, ok)
+	assert.Equal(t, "10.0.0.20", ip)
+
+	_, ok = pool.FirstAvailable(map[string]bool{"10.0.0.10": true, "10.0.0.11": true, "10.0.0.12": true, "10.0.0.20": true})
+	assert.False(t, ok)
+}
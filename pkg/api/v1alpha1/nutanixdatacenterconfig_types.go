@@ -52,6 +52,52 @@ type NutanixDatacenterConfigSpec struct {
 	// List should be valid IP addresses and IP address ranges.
 	// +optional
 	CcmExcludeNodeIPs []string `json:"ccmExcludeNodeIPs,omitempty"`
+
+	// IPPools is the optional list of address pools NutanixMachineConfigs can reserve static IPs from.
+	// +optional
+	IPPools []NutanixIPPool `json:"ipPools,omitempty"`
+}
+
+// NutanixIPPool defines a pool of addresses of a subnet the machines get their address from.
+// A pool without ranges is backed by the IPAM of a Prism managed subnet: Prism assigns the
+// addresses from the subnet pools when the VMs are created and releases them when they are deleted.
+// A pool with ranges is an EKS Anywhere in-cluster pool for an unmanaged subnet: the addresses are
+// allocated by the EKS Anywhere controller and configured statically on the machines.
+type NutanixIPPool struct {
+	// Name is the unique name of the pool the NutanixMachineConfigs refer to.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Subnet is the subnet the addresses of the pool belong to.
+	// It must be the subnet of the NutanixMachineConfigs using the pool.
+	// +kubebuilder:validation:Required
+	Subnet NutanixResourceIdentifier `json:"subnet"`
+
+	// Ranges are the ranges of addresses of an in-cluster pool.
+	// Leave empty to use the IPAM of a Prism managed subnet.
+	// +optional
+	Ranges []NutanixIPRange `json:"ranges,omitempty"`
+
+	// Prefix is the prefix length of the subnet. Required for in-cluster pools.
+	// +optional
+	Prefix int `json:"prefix,omitempty"`
+
+	// Gateway is the gateway of the subnet. Required for in-cluster pools.
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
+
+	// Nameservers are the DNS servers configured on the machines of an in-cluster pool.
+	// +optional
+	Nameservers []string `json:"nameservers,omitempty"`
+}
+
+// NutanixIPRange is a range of IPv4 addresses, both ends included.
+type NutanixIPRange struct {
+	// Start is the first address of the range.
+	Start string `json:"start"`
+
+	// End is the last address of the range.
+	End string `json:"end"`
 }
 
 // NutanixDatacenterFailureDomain defines the failure domain for the Nutanix Datacenter.
@@ -191,6 +237,10 @@ func (in *NutanixDatacenterConfig) Validate() error {
 		}
 	}
 
+	if err := validateNutanixIPPools(in); err != nil {
+		return err
+	}
+
 	return nil
 }
 
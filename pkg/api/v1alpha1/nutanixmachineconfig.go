@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"path"
 
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -16,6 +17,9 @@ type NutanixGPUIdentifierType string
 // NutanixBootType is an enumeration of different boot types.
 type NutanixBootType string
 
+// NutanixDiskDeviceType is an enumeration of the adapter types a data disk can be attached with.
+type NutanixDiskDeviceType string
+
 func (c NutanixIdentifierType) String() string {
 	return string(c)
 }
@@ -44,11 +48,19 @@ const (
 	// NutanixBootTypeUEFI is a resource identifier identifying the UEFI boot type for virtual machines.
 	NutanixBootTypeUEFI NutanixBootType = "uefi"
 
+	// NutanixDiskDeviceTypeSCSI attaches a data disk to the VM SCSI adapter.
+	NutanixDiskDeviceTypeSCSI NutanixDiskDeviceType = "SCSI"
+	// NutanixDiskDeviceTypePCI attaches a data disk to the VM PCI adapter.
+	NutanixDiskDeviceTypePCI NutanixDiskDeviceType = "PCI"
+	// NutanixDiskDeviceTypeSATA attaches a data disk to the VM SATA adapter.
+	NutanixDiskDeviceTypeSATA NutanixDiskDeviceType = "SATA"
+
 	defaultNutanixOSFamily         = Ubuntu
 	defaultNutanixSystemDiskSizeGi = "40Gi"
 	defaultNutanixMemorySizeGi     = "4Gi"
 	defaultNutanixVCPUsPerSocket   = 1
 	defaultNutanixVCPUSockets      = 2
+	minNutanixDataDiskSizeGi       = "1Gi"
 
 	// DefaultNutanixMachineConfigUser is the default username we set in machine config.
 	DefaultNutanixMachineConfigUser string = "eksa"
@@ -99,6 +111,30 @@ type NutanixGPUIdentifier struct {
 	Type NutanixGPUIdentifierType `json:"type"`
 }
 
+// NutanixDataDisk holds the configuration of an additional disk attached to the VM.
+type NutanixDataDisk struct {
+	// size is the size (in Quantity format) of the disk.
+	// The minimum size is 1Gi bytes
+	// +kubebuilder:validation:Required
+	Size resource.Quantity `json:"size"`
+
+	// storageContainer is the Prism Element storage container the disk is created in.
+	// Defaults to the default storage container of the cluster when not set.
+	// +optional
+	StorageContainer *NutanixResourceIdentifier `json:"storageContainer,omitempty"`
+
+	// deviceType is the adapter type the disk is attached with. Defaults to SCSI.
+	// +optional
+	// +kubebuilder:validation:Enum:=SCSI;PCI;SATA
+	DeviceType NutanixDiskDeviceType `json:"deviceType,omitempty"`
+
+	// mountPath is an optional absolute path the disk is formatted and mounted at during
+	// node bootstrap, for example /var/lib/containerd or /var/lib/kubelet.
+	// Only supported for SCSI disks.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+}
+
 // NutanixMachineConfigGenerateOpt is a functional option that can be passed to NewNutanixMachineConfigGenerate to
 // customize the generated machine config
 //
@@ -208,6 +244,50 @@ func validateNutanixMachineConfig(c *NutanixMachineConfig) error {
 		return err
 	}
 
+	if err := validateNutanixDataDisks(c); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateNutanixDataDisks(c *NutanixMachineConfig) error {
+	mountPaths := map[string]struct{}{}
+	for i, disk := range c.Spec.DataDisks {
+		if disk.Size.Cmp(resource.MustParse(minNutanixDataDiskSizeGi)) < 0 {
+			return fmt.Errorf("NutanixMachineConfig: data disk %d size must be greater than or equal to %s", i, minNutanixDataDiskSizeGi)
+		}
+
+		switch disk.DeviceType {
+		case "", NutanixDiskDeviceTypeSCSI, NutanixDiskDeviceTypePCI, NutanixDiskDeviceTypeSATA:
+		default:
+			return fmt.Errorf("NutanixMachineConfig: data disk %d has unsupported device type (%v); Please use one of the following: %s, %s, %s", i, disk.DeviceType, NutanixDiskDeviceTypeSCSI, NutanixDiskDeviceTypePCI, NutanixDiskDeviceTypeSATA)
+		}
+
+		if disk.StorageContainer != nil {
+			if err := validateNutanixResourceReference(disk.StorageContainer, "storage container", c.Name); err != nil {
+				return err
+			}
+		}
+
+		if disk.MountPath == "" {
+			continue
+		}
+
+		if disk.DeviceType != "" && disk.DeviceType != NutanixDiskDeviceTypeSCSI {
+			return fmt.Errorf("NutanixMachineConfig: data disk %d mountPath is only supported for %s disks", i, NutanixDiskDeviceTypeSCSI)
+		}
+
+		if !path.IsAbs(disk.MountPath) || path.Clean(disk.MountPath) == "/" {
+			return fmt.Errorf("NutanixMachineConfig: data disk %d mountPath %s must be an absolute path other than /", i, disk.MountPath)
+		}
+
+		if _, ok := mountPaths[path.Clean(disk.MountPath)]; ok {
+			return fmt.Errorf("NutanixMachineConfig: data disk %d mountPath %s is used by more than one data disk", i, disk.MountPath)
+		}
+		mountPaths[path.Clean(disk.MountPath)] = struct{}{}
+	}
+
 	return nil
 }
 
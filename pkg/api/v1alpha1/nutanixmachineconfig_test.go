@@ -243,3 +243,76 @@ func TestValidateNutanixMachineConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateNutanixMachineConfigDataDisks(t *testing.T) {
+	tests := []struct {
+		name        string
+		dataDisks   []v1alpha1.NutanixDataDisk
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			dataDisks: []v1alpha1.NutanixDataDisk{
+				{Size: resource.MustParse("100Gi"), MountPath: "/var/lib/containerd"},
+				{Size: resource.MustParse("50Gi"), DeviceType: v1alpha1.NutanixDiskDeviceTypeSCSI, MountPath: "/var/lib/kubelet"},
+				{
+					Size:             resource.MustParse("10Gi"),
+					DeviceType:       v1alpha1.NutanixDiskDeviceTypePCI,
+					StorageContainer: &v1alpha1.NutanixResourceIdentifier{Type: v1alpha1.NutanixIdentifierName, Name: ptr.String("container")},
+				},
+			},
+		},
+		{
+			name:        "size too small",
+			dataDisks:   []v1alpha1.NutanixDataDisk{{Size: resource.MustParse("512Mi")}},
+			expectedErr: "NutanixMachineConfig: data disk 0 size must be greater than or equal to 1Gi",
+		},
+		{
+			name:        "invalid device type",
+			dataDisks:   []v1alpha1.NutanixDataDisk{{Size: resource.MustParse("10Gi"), DeviceType: "IDE"}},
+			expectedErr: "NutanixMachineConfig: data disk 0 has unsupported device type (IDE); Please use one of the following: SCSI, PCI, SATA",
+		},
+		{
+			name: "missing storage container name",
+			dataDisks: []v1alpha1.NutanixDataDisk{{
+				Size:             resource.MustParse("10Gi"),
+				StorageContainer: &v1alpha1.NutanixResourceIdentifier{Type: v1alpha1.NutanixIdentifierName},
+			}},
+			expectedErr: "NutanixMachineConfig: missing storage container name: eksa-unit-test",
+		},
+		{
+			name:        "mount path on a non SCSI disk",
+			dataDisks:   []v1alpha1.NutanixDataDisk{{Size: resource.MustParse("10Gi"), DeviceType: v1alpha1.NutanixDiskDeviceTypeSATA, MountPath: "/data"}},
+			expectedErr: "NutanixMachineConfig: data disk 0 mountPath is only supported for SCSI disks",
+		},
+		{
+			name:        "relative mount path",
+			dataDisks:   []v1alpha1.NutanixDataDisk{{Size: resource.MustParse("10Gi"), MountPath: "data"}},
+			expectedErr: "NutanixMachineConfig: data disk 0 mountPath data must be an absolute path other than /",
+		},
+		{
+			name: "duplicate mount path",
+			dataDisks: []v1alpha1.NutanixDataDisk{
+				{Size: resource.MustParse("10Gi"), MountPath: "/data"},
+				{Size: resource.MustParse("10Gi"), MountPath: "/data/"},
+			},
+			expectedErr: "NutanixMachineConfig: data disk 1 mountPath /data/ is used by more than one data disk",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := cluster.ParseConfigFromFile("testdata/nutanix/valid-cluster.yaml")
+			require.NoError(t, err)
+			machineConfig := config.NutanixMachineConfigs["eksa-unit-test"]
+			machineConfig.Spec.DataDisks = test.dataDisks
+
+			err = machineConfig.Validate()
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+}
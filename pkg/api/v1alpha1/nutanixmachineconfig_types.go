@@ -64,10 +64,18 @@ type NutanixMachineConfigSpec struct {
 	// +kubebuilder:validation:Optional
 	GPUs []NutanixGPUIdentifier `json:"gpus,omitempty"`
 
+	// dataDisks is a list of additional disks to be attached to the VMs.
+	// +kubebuilder:validation:Optional
+	DataDisks []NutanixDataDisk `json:"dataDisks,omitempty"`
+
 	// BootType defines the boot type of the VM. Allowed values: legacy, uefi
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Enum=legacy;uefi
 	BootType NutanixBootType `json:"bootType,omitempty"`
+
+	// IPPool is the name of the NutanixDatacenterConfig ipPool the machines reserve a static IP from.
+	// +optional
+	IPPool string `json:"ipPool,omitempty"`
 }
 
 // SetDefaults sets defaults to NutanixMachineConfig if user has not provided.
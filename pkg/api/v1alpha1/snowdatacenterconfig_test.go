@@ -105,6 +105,19 @@ func TestSnowDatacenterConfigValidate(t *testing.T) {
 			},
 			wantErr: "SnowDatacenterConfig IdentityRef kind UnknownKind is invalid",
 		},
+		{
+			name: "invalid decommissioned device",
+			obj: &SnowDatacenterConfig{
+				Spec: SnowDatacenterConfigSpec{
+					IdentityRef: Ref{
+						Name: "creds-1",
+						Kind: "Secret",
+					},
+					DecommissionedDevices: []string{"1.2.3.4", "device-1"},
+				},
+			},
+			wantErr: "SnowDatacenterConfig DecommissionedDevices device-1 is not a valid device IP",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
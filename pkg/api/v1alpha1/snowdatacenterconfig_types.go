@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"net"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -19,6 +20,11 @@ type SnowDatacenterConfigSpec struct { // Important: Run "make generate" to rege
 
 	// IdentityRef is a reference to an identity for the Snow API to be used when reconciling this cluster
 	IdentityRef Ref `json:"identityRef,omitempty"`
+
+	// DecommissionedDevices is a list of device ips being decommissioned. Machines are rolled off these devices
+	// and no new machines are placed on them, even if they are listed in a SnowMachineConfig.
+	// +optional
+	DecommissionedDevices []string `json:"decommissionedDevices,omitempty"`
 }
 
 // SnowDatacenterConfigStatus defines the observed state of SnowDatacenterConfig.
@@ -69,6 +75,12 @@ func (s *SnowDatacenterConfig) Validate() error {
 	if s.Spec.IdentityRef.Kind != SnowIdentityKind {
 		return fmt.Errorf("SnowDatacenterConfig IdentityRef kind %s is invalid, the only supported kind is %s", s.Spec.IdentityRef.Kind, SnowIdentityKind)
 	}
+
+	for _, device := range s.Spec.DecommissionedDevices {
+		if net.ParseIP(device) == nil {
+			return fmt.Errorf("SnowDatacenterConfig DecommissionedDevices %s is not a valid device IP", device)
+		}
+	}
 	return nil
 }
 
@@ -9,6 +9,9 @@ import (
 const (
 	// SnowIPPoolKind is the object kind name for SnowIPPool.
 	SnowIPPoolKind = "SnowIPPool"
+
+	// snowRolloutSurge is the number of extra machines each Snow node group creates during a rollout.
+	snowRolloutSurge = 1
 )
 
 // SnowIPPoolsSliceEqual compares and returns whether two snow IPPool objects are equal.
@@ -34,6 +37,99 @@ func SnowIPPoolsSliceEqual(a, b []IPPool) bool {
 	return true
 }
 
+// Size returns the number of addresses in the pools.
+func (s *SnowIPPool) Size() int {
+	size := 0
+	for _, p := range s.Spec.Pools {
+		start, end, ok := parseSnowIPRange(p)
+		if !ok || start > end {
+			continue
+		}
+		size += int(end-start) + 1
+	}
+	return size
+}
+
+// Contains returns true if ip is within one of the pool ranges.
+func (s *SnowIPPool) Contains(ip string) bool {
+	addr, ok := ipv4ToUint32(net.ParseIP(ip))
+	if !ok {
+		return false
+	}
+	for _, p := range s.Spec.Pools {
+		start, end, ok := parseSnowIPRange(p)
+		if ok && addr >= start && addr <= end {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateCapacity validates that the pool has enough addresses left for the machines of a cluster.
+// The addresses assigned to machines of other clusters, as recorded in the pool status, are not available,
+// while the ones assigned to this cluster are, since its machines are the ones being replaced.
+func (s *SnowIPPool) ValidateCapacity(clusterName string, required int) error {
+	available := s.Size()
+	for _, allocation := range s.Status.Allocations {
+		if allocation.Cluster != clusterName {
+			available--
+		}
+	}
+
+	if required > available {
+		return fmt.Errorf("SnowIPPool %s doesn't have enough addresses for cluster %s, %d required including rollouts and %d available", s.Name, clusterName, required, available)
+	}
+
+	return nil
+}
+
+// SnowIPPoolAddressesRequired returns the number of addresses a Snow cluster needs from each SnowIPPool
+// referenced by its machine configs, including the extra machine created by each node group during a rollout.
+// Worker node groups with autoscaling count with their maximum size.
+func SnowIPPoolAddressesRequired(cluster *Cluster, machineConfigs map[string]*SnowMachineConfig) map[string]int {
+	required := map[string]int{}
+	add := func(ref *Ref, machines int) {
+		if ref == nil {
+			return
+		}
+		machineConfig, ok := machineConfigs[ref.Name]
+		if !ok {
+			return
+		}
+		for _, dni := range machineConfig.Spec.Network.DirectNetworkInterfaces {
+			if dni.IPPoolRef != nil {
+				required[dni.IPPoolRef.Name] += machines + snowRolloutSurge
+			}
+		}
+	}
+
+	cp := cluster.Spec.ControlPlaneConfiguration
+	add(cp.MachineGroupRef, cp.Count)
+
+	if etcd := cluster.Spec.ExternalEtcdConfiguration; etcd != nil {
+		add(etcd.MachineGroupRef, etcd.Count)
+	}
+
+	for _, wc := range cluster.Spec.WorkerNodeGroupConfigurations {
+		count := 0
+		if wc.Count != nil {
+			count = *wc.Count
+		}
+		if wc.AutoScalingConfiguration != nil && wc.AutoScalingConfiguration.MaxCount > count {
+			count = wc.AutoScalingConfiguration.MaxCount
+		}
+		add(wc.MachineGroupRef, count)
+	}
+
+	return required
+}
+
+func parseSnowIPRange(pool IPPool) (start, end uint32, ok bool) {
+	start, startOk := ipv4ToUint32(net.ParseIP(pool.IPStart))
+	end, endOk := ipv4ToUint32(net.ParseIP(pool.IPEnd))
+	return start, end, startOk && endOk
+}
+
 func generateKeyForIPPool(pool IPPool) string {
 	return fmt.Sprintf("%s%s%s%s", pool.IPStart, pool.IPEnd, pool.Subnet, pool.Gateway)
 }
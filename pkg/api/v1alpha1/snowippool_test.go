@@ -263,3 +263,21 @@ func TestSnowIPPoolValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestSnowIPPoolSizeAndContains(t *testing.T) {
+	g := NewWithT(t)
+	pool := &v1alpha1.SnowIPPool{
+		Spec: v1alpha1.SnowIPPoolSpec{
+			Pools: []v1alpha1.IPPool{
+				{IPStart: "1.2.3.4", IPEnd: "1.2.3.8", Subnet: "1.2.3.0/24", Gateway: "1.2.3.1"},
+				{IPStart: "1.2.4.250", IPEnd: "1.2.5.1", Subnet: "1.2.4.0/23", Gateway: "1.2.4.1"},
+			},
+		},
+	}
+
+	g.Expect(pool.Size()).To(Equal(13))
+	g.Expect(pool.Contains("1.2.3.4")).To(BeTrue())
+	g.Expect(pool.Contains("1.2.5.0")).To(BeTrue())
+	g.Expect(pool.Contains("1.2.3.9")).To(BeFalse())
+	g.Expect(pool.Contains("invalid")).To(BeFalse())
+}
@@ -26,10 +26,42 @@ type IPPool struct {
 }
 
 // SnowIPPoolStatus defines the observed state of SnowIPPool.
-type SnowIPPoolStatus struct{}
+type SnowIPPoolStatus struct {
+	// Allocations are the addresses of the pools assigned to machines.
+	// +optional
+	Allocations []SnowIPAllocation `json:"allocations,omitempty"`
+
+	// Total is the number of addresses in the pools.
+	// +optional
+	Total int `json:"total,omitempty"`
+
+	// Used is the number of addresses assigned to machines.
+	// +optional
+	Used int `json:"used,omitempty"`
+
+	// Free is the number of addresses left to assign.
+	// +optional
+	Free int `json:"free,omitempty"`
+}
+
+// SnowIPAllocation is an address of the pools assigned to a machine.
+type SnowIPAllocation struct {
+	// IP is the assigned address.
+	IP string `json:"ip"`
+
+	// Machine is the name of the AWSSnowMachine the address is assigned to.
+	Machine string `json:"machine"`
+
+	// Cluster is the name of the cluster the machine belongs to.
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+}
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.total`
+//+kubebuilder:printcolumn:name="Used",type=integer,JSONPath=`.status.used`
+//+kubebuilder:printcolumn:name="Free",type=integer,JSONPath=`.status.free`
 
 // SnowIPPool is the Schema for the SnowIPPools API.
 type SnowIPPool struct {
@@ -8,6 +8,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -20,7 +21,7 @@ var snowippoollog = logf.Log.WithName("snowippool-resource")
 func (r *SnowIPPool) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
-		WithValidator(r).
+		WithValidator(NewSnowIPPoolValidator(mgr.GetClient())).
 		Complete()
 }
 
@@ -84,3 +85,112 @@ func validateImmutableFieldsSnowIPPool(new, old *SnowIPPool) field.ErrorList {
 	}
 	return allErrs
 }
+
+// SnowIPPoolValidator validates SnowIPPools and checks that they have enough addresses for the Snow clusters
+// whose machine configs reference them.
+type SnowIPPoolValidator struct {
+	client client.Reader
+}
+
+// NewSnowIPPoolValidator returns a new SnowIPPoolValidator reading the clusters from client.
+func NewSnowIPPoolValidator(client client.Reader) *SnowIPPoolValidator {
+	return &SnowIPPoolValidator{client: client}
+}
+
+var _ webhook.CustomValidator = &SnowIPPoolValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *SnowIPPoolValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	warnings, err := (&SnowIPPool{}).ValidateCreate(ctx, obj)
+	if err != nil {
+		return warnings, err
+	}
+
+	return warnings, v.validateCapacity(ctx, obj.(*SnowIPPool))
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *SnowIPPoolValidator) ValidateUpdate(ctx context.Context, old, obj runtime.Object) (admission.Warnings, error) {
+	warnings, err := (&SnowIPPool{}).ValidateUpdate(ctx, old, obj)
+	if err != nil {
+		return warnings, err
+	}
+
+	return warnings, v.validateCapacity(ctx, obj.(*SnowIPPool))
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *SnowIPPoolValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return (&SnowIPPool{}).ValidateDelete(ctx, obj)
+}
+
+func (v *SnowIPPoolValidator) validateCapacity(ctx context.Context, pool *SnowIPPool) error {
+	clusters, err := snowClustersIPPoolDemand(ctx, v.client, pool.Namespace, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range clusters {
+		if required, ok := c.required[pool.Name]; ok {
+			if err := pool.ValidateCapacity(c.name, required); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// snowClusterIPPoolDemand is the number of addresses a Snow cluster needs from each SnowIPPool.
+type snowClusterIPPoolDemand struct {
+	name     string
+	required map[string]int
+}
+
+// snowClustersIPPoolDemand returns the addresses the Snow clusters of a namespace need from each SnowIPPool.
+// When machineConfig is not nil, it replaces the stored machine config with the same name and only the
+// clusters using it are returned.
+func snowClustersIPPoolDemand(ctx context.Context, c client.Reader, namespace string, machineConfig *SnowMachineConfig) ([]snowClusterIPPoolDemand, error) {
+	clusters := &ClusterList{}
+	if err := c.List(ctx, clusters, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("listing clusters: %v", err)
+	}
+
+	demand := []snowClusterIPPoolDemand{}
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		if cluster.Spec.DatacenterRef.Kind != SnowDatacenterKind {
+			continue
+		}
+
+		machineConfigs := map[string]*SnowMachineConfig{}
+		usesMachineConfig := false
+		for _, ref := range cluster.MachineConfigRefs() {
+			if machineConfig != nil && ref.Name == machineConfig.Name {
+				machineConfigs[ref.Name] = machineConfig
+				usesMachineConfig = true
+				continue
+			}
+
+			m := &SnowMachineConfig{}
+			if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, m); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, fmt.Errorf("getting SnowMachineConfig %s: %v", ref.Name, err)
+			}
+			machineConfigs[ref.Name] = m
+		}
+
+		if machineConfig != nil && !usesMachineConfig {
+			continue
+		}
+
+		demand = append(demand, snowClusterIPPoolDemand{
+			name:     cluster.Name,
+			required: SnowIPPoolAddressesRequired(cluster, machineConfigs),
+		})
+	}
+
+	return demand, nil
+}
@@ -6,6 +6,8 @@ import (
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 )
@@ -191,3 +193,127 @@ func TestSnowIPPoolValidateDeleteCastFail(t *testing.T) {
 	g.Expect(err).To(HaveOccurred())
 	g.Expect(err.Error()).To(ContainSubstring("expected a SnowIPPool"))
 }
+
+func TestSnowIPPoolValidatorValidateCreateCapacity(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	pool := snowIPPoolForCapacity("192.168.1.10")
+	c := fake.NewClientBuilder().WithScheme(snowCapacityScheme(t)).WithObjects(
+		snowCapacityCluster("test", 3, 2),
+		snowCapacityMachineConfig(),
+	).Build()
+	validator := v1alpha1.NewSnowIPPoolValidator(c)
+
+	// 3 control plane and 2 worker machines, plus one surge machine per node group.
+	g.Expect(validator.ValidateCreate(ctx, pool)).Error().To(MatchError(
+		"SnowIPPool pool doesn't have enough addresses for cluster test, 7 required including rollouts and 5 available",
+	))
+
+	pool = snowIPPoolForCapacity("192.168.1.12")
+	g.Expect(validator.ValidateCreate(ctx, pool)).Error().To(Succeed())
+}
+
+func TestSnowIPPoolValidatorValidateUpdateCapacityOtherClusterAllocations(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	pool := snowIPPoolForCapacity("192.168.1.12")
+	pool.Status.Allocations = []v1alpha1.SnowIPAllocation{
+		{IP: "192.168.1.6", Machine: "other-cp", Cluster: "other"},
+		{IP: "192.168.1.7", Machine: "test-cp", Cluster: "test"},
+	}
+	c := fake.NewClientBuilder().WithScheme(snowCapacityScheme(t)).WithObjects(
+		snowCapacityCluster("test", 3, 2),
+		snowCapacityMachineConfig(),
+	).Build()
+	validator := v1alpha1.NewSnowIPPoolValidator(c)
+
+	g.Expect(validator.ValidateUpdate(ctx, pool.DeepCopy(), pool)).Error().To(MatchError(
+		"SnowIPPool pool doesn't have enough addresses for cluster test, 7 required including rollouts and 6 available",
+	))
+}
+
+func TestSnowIPPoolValidatorValidateCreateNoClusters(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	c := fake.NewClientBuilder().WithScheme(snowCapacityScheme(t)).Build()
+	validator := v1alpha1.NewSnowIPPoolValidator(c)
+
+	g.Expect(validator.ValidateCreate(ctx, snowIPPoolForCapacity("192.168.1.7"))).Error().To(Succeed())
+}
+
+func TestSnowIPPoolValidatorValidateCreateInvalidIPPool(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	pool := snowIPPoolForCapacity("192.168.1.12")
+	pool.Spec.Pools[0].IPStart = "invalid"
+	validator := v1alpha1.NewSnowIPPoolValidator(fake.NewClientBuilder().WithScheme(snowCapacityScheme(t)).Build())
+
+	g.Expect(validator.ValidateCreate(ctx, pool)).Error().To(MatchError(ContainSubstring("SnowIPPool Pools[0].IPStart is invalid")))
+}
+
+func snowCapacityScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+func snowIPPoolForCapacity(ipEnd string) *v1alpha1.SnowIPPool {
+	return &v1alpha1.SnowIPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+		Spec: v1alpha1.SnowIPPoolSpec{
+			Pools: []v1alpha1.IPPool{
+				{
+					IPStart: "192.168.1.6",
+					IPEnd:   ipEnd,
+					Gateway: "192.168.1.1",
+					Subnet:  "192.168.1.0/24",
+				},
+			},
+		},
+	}
+}
+
+func snowCapacityCluster(name string, controlPlaneCount, workerCount int) *v1alpha1.Cluster {
+	return &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1alpha1.ClusterSpec{
+			DatacenterRef: v1alpha1.Ref{Kind: v1alpha1.SnowDatacenterKind, Name: name},
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+				Count:           controlPlaneCount,
+				MachineGroupRef: &v1alpha1.Ref{Kind: v1alpha1.SnowMachineConfigKind, Name: "mc"},
+			},
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:            "md-0",
+					Count:           &workerCount,
+					MachineGroupRef: &v1alpha1.Ref{Kind: v1alpha1.SnowMachineConfigKind, Name: "mc"},
+				},
+			},
+		},
+	}
+}
+
+func snowCapacityMachineConfig() *v1alpha1.SnowMachineConfig {
+	return &v1alpha1.SnowMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "mc", Namespace: "default"},
+		Spec: v1alpha1.SnowMachineConfigSpec{
+			AMIID:                    "testAMI",
+			SshKeyName:               "testKey",
+			InstanceType:             v1alpha1.DefaultSnowInstanceType,
+			PhysicalNetworkConnector: v1alpha1.SFPPlus,
+			Devices:                  []string{"1.2.3.4"},
+			OSFamily:                 v1alpha1.Ubuntu,
+			Network: v1alpha1.SnowNetwork{
+				DirectNetworkInterfaces: []v1alpha1.SnowDirectNetworkInterface{
+					{
+						Index:     1,
+						Primary:   true,
+						IPPoolRef: &v1alpha1.Ref{Kind: v1alpha1.SnowIPPoolKind, Name: "pool"},
+					},
+				},
+			},
+		},
+	}
+}
@@ -100,6 +100,10 @@ func validateSnowMachineConfig(config *SnowMachineConfig) error {
 		return fmt.Errorf("SnowMachineConfig HostOSConfiguration is invalid: %v", err)
 	}
 
+	if err := validateSnowMachineConfigPlacement(config.Spec.Placement); err != nil {
+		return err
+	}
+
 	return validateSnowMachineConfigNonRootVolumes(config.Spec.NonRootVolumes)
 }
 
@@ -152,6 +156,27 @@ func validateSnowMachineConfigNonRootVolumes(volumes []*snowv1.Volume) error {
 	return nil
 }
 
+func validateSnowMachineConfigPlacement(placement *SnowPlacement) error {
+	if placement == nil {
+		return nil
+	}
+
+	switch placement.Policy {
+	case SnowPlacementPack:
+		if placement.MaxMachinesPerDevice <= 0 {
+			return fmt.Errorf("SnowMachineConfig Placement.MaxMachinesPerDevice must be greater than 0 for placement policy %s", SnowPlacementPack)
+		}
+	case SnowPlacementSpread, SnowPlacementPin:
+		if placement.MaxMachinesPerDevice != 0 {
+			return fmt.Errorf("SnowMachineConfig Placement.MaxMachinesPerDevice is only supported for placement policy %s", SnowPlacementPack)
+		}
+	default:
+		return fmt.Errorf("SnowMachineConfig Placement.Policy %s is not supported, please use one of the following: %s, %s, %s", placement.Policy, SnowPlacementSpread, SnowPlacementPack, SnowPlacementPin)
+	}
+
+	return nil
+}
+
 func validateSnowMachineConfigNetwork(network SnowNetwork) error {
 	if len(network.DirectNetworkInterfaces) <= 0 {
 		return errors.New("SnowMachineConfig Network.DirectNetworkInterfaces length must be no smaller than 1")
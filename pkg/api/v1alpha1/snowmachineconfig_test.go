@@ -594,6 +594,104 @@ func TestSnowMachineConfigValidate(t *testing.T) {
 			},
 			wantErr: "SnowMachineConfig HostOSConfiguration is invalid: BottlerocketConfiguration can only be used with osFamily: \"bottlerocket\"",
 		},
+		{
+			name: "valid pack placement",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					InstanceType:             DefaultSnowInstanceType,
+					PhysicalNetworkConnector: DefaultSnowPhysicalNetworkConnectorType,
+					Devices:                  []string{"1.2.3.4"},
+					OSFamily:                 Ubuntu,
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{
+								Index:   1,
+								DHCP:    true,
+								Primary: true,
+							},
+						},
+					},
+					Placement: &SnowPlacement{
+						Policy:               SnowPlacementPack,
+						MaxMachinesPerDevice: 2,
+					},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "invalid placement policy",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					InstanceType:             DefaultSnowInstanceType,
+					PhysicalNetworkConnector: DefaultSnowPhysicalNetworkConnectorType,
+					Devices:                  []string{"1.2.3.4"},
+					OSFamily:                 Ubuntu,
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{
+								Index:   1,
+								DHCP:    true,
+								Primary: true,
+							},
+						},
+					},
+					Placement: &SnowPlacement{
+						Policy: "random",
+					},
+				},
+			},
+			wantErr: "SnowMachineConfig Placement.Policy random is not supported",
+		},
+		{
+			name: "pack placement without max machines per device",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					InstanceType:             DefaultSnowInstanceType,
+					PhysicalNetworkConnector: DefaultSnowPhysicalNetworkConnectorType,
+					Devices:                  []string{"1.2.3.4"},
+					OSFamily:                 Ubuntu,
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{
+								Index:   1,
+								DHCP:    true,
+								Primary: true,
+							},
+						},
+					},
+					Placement: &SnowPlacement{
+						Policy: SnowPlacementPack,
+					},
+				},
+			},
+			wantErr: "SnowMachineConfig Placement.MaxMachinesPerDevice must be greater than 0 for placement policy pack",
+		},
+		{
+			name: "max machines per device with spread placement",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					InstanceType:             DefaultSnowInstanceType,
+					PhysicalNetworkConnector: DefaultSnowPhysicalNetworkConnectorType,
+					Devices:                  []string{"1.2.3.4"},
+					OSFamily:                 Ubuntu,
+					Network: SnowNetwork{
+						DirectNetworkInterfaces: []SnowDirectNetworkInterface{
+							{
+								Index:   1,
+								DHCP:    true,
+								Primary: true,
+							},
+						},
+					},
+					Placement: &SnowPlacement{
+						Policy:               SnowPlacementSpread,
+						MaxMachinesPerDevice: 2,
+					},
+				},
+			},
+			wantErr: "SnowMachineConfig Placement.MaxMachinesPerDevice is only supported for placement policy pack",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -53,6 +53,36 @@ type SnowMachineConfigSpec struct {
 
 	// HostOSConfiguration provides OS specific configurations for the machine
 	HostOSConfiguration *HostOSConfiguration `json:"hostOSConfiguration,omitempty"`
+
+	// Placement controls how the machines of the node groups using this machine config are placed on the devices.
+	// When omitted, machines can be placed on any of the devices.
+	// +optional
+	Placement *SnowPlacement `json:"placement,omitempty"`
+}
+
+// SnowPlacementPolicy is the policy used to place machines on the snow devices.
+type SnowPlacementPolicy string
+
+const (
+	// SnowPlacementSpread spreads the machines across all the devices.
+	SnowPlacementSpread SnowPlacementPolicy = "spread"
+	// SnowPlacementPack packs the machines on as few devices as possible, in the order they are listed.
+	SnowPlacementPack SnowPlacementPolicy = "pack"
+	// SnowPlacementPin pins all the machines to the first device listed.
+	SnowPlacementPin SnowPlacementPolicy = "pin"
+)
+
+// SnowPlacement defines the placement of machines on the snow devices.
+type SnowPlacement struct {
+	// Policy is the placement policy.
+	// Valid values: "spread", "pack" and "pin".
+	// +kubebuilder:validation:Enum:=spread;pack;pin
+	Policy SnowPlacementPolicy `json:"policy"`
+
+	// MaxMachinesPerDevice is the number of machines of a node group placed on a device before using the next one.
+	// Only used and required with the "pack" policy.
+	// +optional
+	MaxMachinesPerDevice int `json:"maxMachinesPerDevice,omitempty"`
 }
 
 // SnowNetwork specifies the network configurations for snow.
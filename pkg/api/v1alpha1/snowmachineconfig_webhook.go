@@ -17,9 +17,12 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"sort"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -32,7 +35,7 @@ func (r *SnowMachineConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		WithDefaulter(r).
-		WithValidator(r).
+		WithValidator(NewSnowMachineConfigValidator(mgr.GetClient())).
 		Complete()
 }
 
@@ -102,3 +105,72 @@ func (r *SnowMachineConfig) ValidateDelete(_ context.Context, obj runtime.Object
 	// TODO(user): fill in your validation logic upon object deletion.
 	return nil, nil
 }
+
+// SnowMachineConfigValidator validates SnowMachineConfigs and checks that the SnowIPPools they reference have
+// enough addresses for the Snow clusters using them.
+type SnowMachineConfigValidator struct {
+	client client.Reader
+}
+
+// NewSnowMachineConfigValidator returns a new SnowMachineConfigValidator reading the clusters from client.
+func NewSnowMachineConfigValidator(client client.Reader) *SnowMachineConfigValidator {
+	return &SnowMachineConfigValidator{client: client}
+}
+
+var _ webhook.CustomValidator = &SnowMachineConfigValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *SnowMachineConfigValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	warnings, err := (&SnowMachineConfig{}).ValidateCreate(ctx, obj)
+	if err != nil {
+		return warnings, err
+	}
+
+	return warnings, v.validateIPPoolCapacity(ctx, obj.(*SnowMachineConfig))
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *SnowMachineConfigValidator) ValidateUpdate(ctx context.Context, old, obj runtime.Object) (admission.Warnings, error) {
+	warnings, err := (&SnowMachineConfig{}).ValidateUpdate(ctx, old, obj)
+	if err != nil {
+		return warnings, err
+	}
+
+	return warnings, v.validateIPPoolCapacity(ctx, obj.(*SnowMachineConfig))
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *SnowMachineConfigValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return (&SnowMachineConfig{}).ValidateDelete(ctx, obj)
+}
+
+func (v *SnowMachineConfigValidator) validateIPPoolCapacity(ctx context.Context, machineConfig *SnowMachineConfig) error {
+	clusters, err := snowClustersIPPoolDemand(ctx, v.client, machineConfig.Namespace, machineConfig)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range clusters {
+		names := make([]string, 0, len(c.required))
+		for name := range c.required {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			pool := &SnowIPPool{}
+			if err := v.client.Get(ctx, client.ObjectKey{Namespace: machineConfig.Namespace, Name: name}, pool); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return fmt.Errorf("getting SnowIPPool %s: %v", name, err)
+			}
+
+			if err := pool.ValidateCapacity(c.name, c.required[name]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
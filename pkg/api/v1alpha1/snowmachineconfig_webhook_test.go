@@ -6,6 +6,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
@@ -251,3 +252,41 @@ func TestSnowMachineConfigValidateDeleteCastFail(t *testing.T) {
 	g.Expect(err).To(HaveOccurred())
 	g.Expect(err.Error()).To(ContainSubstring("expected a SnowMachineConfig"))
 }
+
+func TestSnowMachineConfigValidatorValidateUpdateIPPoolCapacity(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	old := snowCapacityMachineConfig()
+	c := fake.NewClientBuilder().WithScheme(snowCapacityScheme(t)).WithObjects(
+		snowCapacityCluster("test", 3, 2),
+		snowIPPoolForCapacity("192.168.1.12"),
+		old,
+	).Build()
+	validator := v1alpha1.NewSnowMachineConfigValidator(c)
+
+	g.Expect(validator.ValidateUpdate(ctx, old, old.DeepCopy())).Error().To(Succeed())
+
+	// A second interface on the same pool doubles the addresses the cluster needs.
+	machineConfig := old.DeepCopy()
+	machineConfig.Spec.Network.DirectNetworkInterfaces = append(machineConfig.Spec.Network.DirectNetworkInterfaces, v1alpha1.SnowDirectNetworkInterface{
+		Index:     2,
+		IPPoolRef: &v1alpha1.Ref{Kind: v1alpha1.SnowIPPoolKind, Name: "pool"},
+	})
+	g.Expect(validator.ValidateUpdate(ctx, old, machineConfig)).Error().To(MatchError(
+		"SnowIPPool pool doesn't have enough addresses for cluster test, 14 required including rollouts and 7 available",
+	))
+}
+
+func TestSnowMachineConfigValidatorValidateCreateUnusedMachineConfig(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	machineConfig := snowCapacityMachineConfig()
+	machineConfig.Name = "unused"
+	c := fake.NewClientBuilder().WithScheme(snowCapacityScheme(t)).WithObjects(
+		snowCapacityCluster("test", 3, 2),
+		snowIPPoolForCapacity("192.168.1.7"),
+	).Build()
+	validator := v1alpha1.NewSnowMachineConfigValidator(c)
+
+	g.Expect(validator.ValidateCreate(ctx, machineConfig)).Error().To(Succeed())
+}
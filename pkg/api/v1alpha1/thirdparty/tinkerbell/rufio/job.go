@@ -0,0 +1,184 @@
+package rufio
+
+/*
+Copyright 2022 Tinkerbell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PowerAction represents the power control operation on the baseboard management.
+type PowerAction string
+
+const (
+	// PowerOn powers on the machine.
+	PowerOn PowerAction = "on"
+	// PowerHardOff powers off the machine without waiting for the OS to shutdown.
+	PowerHardOff PowerAction = "off"
+	// PowerSoftOff requests a graceful shutdown of the OS.
+	PowerSoftOff PowerAction = "soft"
+	// PowerCycle powers the machine off and back on.
+	PowerCycle PowerAction = "cycle"
+	// PowerReset resets the machine.
+	PowerReset PowerAction = "reset"
+	// PowerStatus retrieves the machine power state.
+	PowerStatus PowerAction = "status"
+)
+
+// BootDevice represents boot device of the Machine.
+type BootDevice string
+
+const (
+	// PXE boots the machine from the network.
+	PXE BootDevice = "pxe"
+	// Disk boots the machine from the local disk.
+	Disk BootDevice = "disk"
+	// BIOS boots the machine into the BIOS setup.
+	BIOS BootDevice = "bios"
+	// CDROM boots the machine from the CD-ROM drive.
+	CDROM BootDevice = "cdrom"
+	// Safe boots the machine into safe mode.
+	Safe BootDevice = "safe"
+)
+
+// JobConditionType represents the condition of the BMC Job.
+type JobConditionType string
+
+const (
+	// JobCompleted represents successful completion of the BMC Job tasks.
+	JobCompleted JobConditionType = "Completed"
+	// JobFailed represents failure in BMC job execution.
+	JobFailed JobConditionType = "Failed"
+	// JobRunning represents a currently executing BMC job.
+	JobRunning JobConditionType = "Running"
+)
+
+// MachineRef is used to reference a Machine object.
+type MachineRef struct {
+	// Name of the Machine.
+	Name string `json:"name"`
+
+	// Namespace the Machine resides in.
+	Namespace string `json:"namespace"`
+}
+
+// Action represents the action to be performed.
+// A single task can only perform one type of action.
+// For example either PowerAction or OneTimeBootDeviceAction.
+// +kubebuilder:validation:MaxProperties:=1
+type Action struct {
+	// PowerAction represents a baseboard management power operation.
+	// +kubebuilder:validation:Enum=on;off;soft;status;cycle;reset
+	PowerAction *PowerAction `json:"powerAction,omitempty"`
+
+	// OneTimeBootDeviceAction represents a baseboard management one time set boot device operation.
+	OneTimeBootDeviceAction *OneTimeBootDeviceAction `json:"oneTimeBootDeviceAction,omitempty"`
+}
+
+// OneTimeBootDeviceAction represents a baseboard management one time set boot device operation.
+type OneTimeBootDeviceAction struct {
+	// Devices represents the boot devices, in order for setting one time boot.
+	// Currently only the first device in the slice is used to set one time boot.
+	Devices []BootDevice `json:"device"`
+
+	// EFIBoot instructs the machine to use EFI boot.
+	EFIBoot bool `json:"efiBoot,omitempty"`
+}
+
+// JobSpec defines the desired state of Job.
+type JobSpec struct {
+	// MachineRef represents the Machine resource to execute the job.
+	// All the tasks in the job are executed for the same Machine.
+	MachineRef MachineRef `json:"machineRef"`
+
+	// Tasks represents a list of baseboard management actions to be executed.
+	// The tasks are executed sequentially. Controller waits for one task to complete before executing the next.
+	// If a single task fails, job execution stops and sets condition Failed.
+	// Condition Completed is set only if all the tasks were successful.
+	Tasks []Action `json:"tasks"`
+}
+
+// JobStatus defines the observed state of Job.
+type JobStatus struct {
+	// Conditions represents the latest available observations of an object's current state.
+	// +optional
+	Conditions []JobCondition `json:"conditions,omitempty"`
+
+	// StartTime represents time when the Job controller started processing a job.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime represents time when the job was completed.
+	// The completion time is only set when the job finishes successfully.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// JobCondition defines an observed condition of a Job.
+type JobCondition struct {
+	// Type of the Job condition.
+	Type JobConditionType `json:"type"`
+
+	// Status is the status of the Job condition.
+	// Can be True or False.
+	Status ConditionStatus `json:"status"`
+
+	// Message represents human readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// HasCondition checks if the cType condition is present with status cStatus on a bmj.
+func (j *Job) HasCondition(cType JobConditionType, cStatus ConditionStatus) bool {
+	for _, c := range j.Status.Conditions {
+		if c.Type == cType {
+			return c.Status == cStatus
+		}
+	}
+
+	return false
+}
+
+// FormatMachineRef returns the namespaced name of the Machine referenced by the Job.
+func (j *Job) FormatMachineRef() string {
+	return j.Spec.MachineRef.Namespace + "/" + j.Spec.MachineRef.Name
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=jobs,scope=Namespaced,categories=tinkerbell,singular=job,shortName=j
+
+// Job is the Schema for the bmcjobs API.
+type Job struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   JobSpec   `json:"spec,omitempty"`
+	Status JobStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// JobList contains a list of Job.
+type JobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Job `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Job{}, &JobList{})
+}
@@ -19,6 +19,120 @@ warnings:
   dsid_missing_source: off
 `
 
+	// netplanPrimaryAddress configures the IP, nameservers and default route of the primary NIC
+	// on the netplan interface it's included in.
+	netplanPrimaryAddress = `
+            addresses:
+                - {{ $primary.DHCP.IP.Address }}/{{ netmaskToPrefixLength $primary.DHCP.IP.Netmask }}
+            nameservers:
+                addresses: [{{ range $i, $ns := $primary.DHCP.NameServers }}{{ if $i }}, {{ end }}{{ $ns }}{{ end }}]
+{{- if $primary.DHCP.IP.Gateway }}
+            routes:
+                - to: default
+                  via: {{ $primary.DHCP.IP.Gateway }}
+{{- end }}`
+
+	// netplanStaticInterfacesTemplate renders the netplan configuration of hardware with bonded NICs
+	// or additional VLANs. The first interface of the hardware is the primary NIC. Bonded NICs,
+	// including the primary NIC, have the "bond0" interface name and additional VLANs are the
+	// remaining interfaces. The bonding mode is recorded in the instance metadata tags.
+	netplanStaticInterfacesTemplate = `network:
+    version: 2
+    renderer: networkd
+{{- $primary := index .Hardware.Interfaces 0 }}
+{{- $link := "nic0" }}{{ if eq $primary.DHCP.IfaceName "bond0" }}{{ $link = "bond0" }}{{ end }}
+{{- $mode := "active-backup" }}{{ range .Hardware.Metadata.Instance.Tags }}{{ if eq . "bond-mode=802.3ad" }}{{ $mode = "802.3ad" }}{{ end }}{{ end }}
+{{- $vlans := $primary.DHCP.VLANID }}{{ range $i, $iface := .Hardware.Interfaces }}{{ if and $i (ne $iface.DHCP.IfaceName "bond0") }}{{ $vlans = "true" }}{{ end }}{{ end }}
+    ethernets:
+{{- range $i, $iface := .Hardware.Interfaces }}{{ if or (eq $i 0) (eq $iface.DHCP.IfaceName "bond0") }}
+        nic{{ $i }}:
+            match:
+                macaddress: {{ $iface.DHCP.MAC }}
+            set-name: nic{{ $i }}
+{{- if and (eq $i 0) (eq $link "nic0") (not $primary.DHCP.VLANID) }}` + netplanPrimaryAddress + `{{ end }}
+{{- end }}{{ end }}
+{{- if eq $link "bond0" }}
+    bonds:
+        bond0:
+            interfaces: [{{ range $i, $iface := .Hardware.Interfaces }}{{ if eq $iface.DHCP.IfaceName "bond0" }}{{ if $i }}, {{ end }}nic{{ $i }}{{ end }}{{ end }}]
+            parameters:
+                mode: {{ $mode }}
+                mii-monitor-interval: 100
+{{- if not $primary.DHCP.VLANID }}` + netplanPrimaryAddress + `{{ end }}
+{{- end }}
+{{- if $vlans }}
+    vlans:
+{{- if $primary.DHCP.VLANID }}
+        vlan{{ $primary.DHCP.VLANID }}:
+            id: {{ $primary.DHCP.VLANID }}
+            link: {{ $link }}` + netplanPrimaryAddress + `
+{{- end }}
+{{- range $i, $iface := .Hardware.Interfaces }}{{ if and $i (ne $iface.DHCP.IfaceName "bond0") }}
+        vlan{{ $iface.DHCP.VLANID }}:
+            id: {{ $iface.DHCP.VLANID }}
+            link: {{ $link }}
+            addresses:
+                - {{ $iface.DHCP.IP.Address }}/{{ netmaskToPrefixLength $iface.DHCP.IP.Netmask }}
+{{- end }}{{ end }}
+{{- end }}
+`
+
+	// bottlerocketStaticInterfacesTemplate renders the Bottlerocket net.toml of hardware with
+	// bonded NICs or additional VLANs. See netplanStaticInterfacesTemplate for how the interfaces
+	// of the hardware are laid out. Interfaces are identified by MAC address.
+	bottlerocketStaticInterfacesTemplate = `version = 3
+{{- $primary := index .Hardware.Interfaces 0 }}
+{{- $link := printf "%q" $primary.DHCP.MAC }}{{ if eq $primary.DHCP.IfaceName "bond0" }}{{ $link = "bond0" }}{{ end }}
+{{- $mode := "active-backup" }}{{ range .Hardware.Metadata.Instance.Tags }}{{ if eq . "bond-mode=802.3ad" }}{{ $mode = "802.3ad" }}{{ end }}{{ end }}
+{{- $ip := $link }}{{ if $primary.DHCP.VLANID }}{{ $ip = printf "vlan%s" $primary.DHCP.VLANID }}{{ end }}
+{{- if eq $link "bond0" }}
+
+[bond0]
+kind = "bond"
+mode = "{{ $mode }}"
+interfaces = [{{ range $i, $iface := .Hardware.Interfaces }}{{ if eq $iface.DHCP.IfaceName "bond0" }}{{ if $i }}, {{ end }}"{{ $iface.DHCP.MAC }}"{{ end }}{{ end }}]
+{{- if not $primary.DHCP.VLANID }}
+primary = true
+{{- end }}
+
+[bond0.monitoring]
+miimon-frequency-ms = 100
+miimon-updelay-ms = 200
+miimon-downdelay-ms = 200
+{{- else if not $primary.DHCP.VLANID }}
+
+[{{ $link }}]
+primary = true
+{{- end }}
+{{- if $primary.DHCP.VLANID }}
+
+[{{ $ip }}]
+kind = "vlan"
+device = {{ if eq $link "bond0" }}"bond0"{{ else }}{{ $link }}{{ end }}
+id = {{ $primary.DHCP.VLANID }}
+primary = true
+{{- end }}
+
+[{{ $ip }}.static4]
+addresses = ["{{ $primary.DHCP.IP.Address }}/{{ netmaskToPrefixLength $primary.DHCP.IP.Netmask }}"]
+{{- if $primary.DHCP.IP.Gateway }}
+
+[[{{ $ip }}.route]]
+to = "default"
+via = "{{ $primary.DHCP.IP.Gateway }}"
+{{- end }}
+{{- range $i, $iface := .Hardware.Interfaces }}{{ if and $i (ne $iface.DHCP.IfaceName "bond0") }}
+
+[vlan{{ $iface.DHCP.VLANID }}]
+kind = "vlan"
+device = {{ if eq $link "bond0" }}"bond0"{{ else }}{{ $link }}{{ end }}
+id = {{ $iface.DHCP.VLANID }}
+
+[vlan{{ $iface.DHCP.VLANID }}.static4]
+addresses = ["{{ $iface.DHCP.IP.Address }}/{{ netmaskToPrefixLength $iface.DHCP.IP.Netmask }}"]
+{{- end }}{{ end }}
+`
+
 	// HookOS embeds container images from the bundle.
 	// The container images are tagged as below.
 	actionImage2Disk = "127.0.0.1/embedded/image2disk"
@@ -146,8 +260,11 @@ func withNetplanAction(disk string, osFamily OSFamily) ActionOpt {
 		if osFamily == Bottlerocket {
 			// Bottlerocket needs to write onto the 12th partition as opposed to 2nd for non-Bottlerocket OS
 			netplanAction.Environment["DEST_PATH"] = "/net.toml"
-			netplanAction.Environment["STATIC_BOTTLEROCKET"] = "true"
 			netplanAction.Environment["IFNAME"] = "eno1"
+			// Hardware with bonded NICs or additional VLANs needs a net.toml that the action can't
+			// generate from the metadata service so it's rendered from the hardware object instead.
+			netplanAction.Environment["STATIC_BOTTLEROCKET"] = `{{ if gt (len .Hardware.Interfaces) 1 }}false{{ else }}true{{ end }}`
+			netplanAction.Environment["CONTENTS"] = `{{ if gt (len .Hardware.Interfaces) 1 }}` + bottlerocketStaticInterfacesTemplate + `{{ end }}`
 		} else {
 			// For other OS families (Ubuntu, etc.), use netplan configuration
 			netplanAction.Environment["DEST_PATH"] = "/etc/netplan/config.yaml"
@@ -192,8 +309,10 @@ func withNetplanAction(disk string, osFamily OSFamily) ActionOpt {
                   via: {{ (index .Hardware.Interfaces 0).DHCP.IP.Gateway }}
             {{- end }}
 `
-			// Use VLAN template if VLANID is present in the hardware object
-			netplanAction.Environment["CONTENTS"] = `{{ if (index .Hardware.Interfaces 0).DHCP.VLANID }}` + vlanTemplate + `{{ else }}` + netplanTemplate + `{{ end }}`
+			// Use the static interfaces template if the hardware has bonded NICs or additional VLANs,
+			// the VLAN template if VLANID is present in the hardware object.
+			netplanAction.Environment["CONTENTS"] = `{{ if gt (len .Hardware.Interfaces) 1 }}` + netplanStaticInterfacesTemplate +
+				`{{ else if (index .Hardware.Interfaces 0).DHCP.VLANID }}` + vlanTemplate + `{{ else }}` + netplanTemplate + `{{ end }}`
 		}
 		*a = append(*a, netplanAction)
 	}
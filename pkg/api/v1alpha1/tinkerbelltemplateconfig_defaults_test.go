@@ -1,10 +1,14 @@
 package v1alpha1
 
 import (
+	"bytes"
 	"fmt"
+	"net"
 	"testing"
+	"text/template"
 
 	"github.com/google/go-cmp/cmp"
+	tinkv1alpha1 "github.com/tinkerbell/tink/api/v1alpha1"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell"
 )
@@ -86,7 +90,8 @@ warnings:
 						"DEST_DISK":           "{{ formatPartition ( index .Hardware.Disks 0 ) 12 }}",
 						"FS_TYPE":             "ext4",
 						"DEST_PATH":           "/net.toml",
-						"STATIC_BOTTLEROCKET": "true",
+						"STATIC_BOTTLEROCKET": "{{ if gt (len .Hardware.Interfaces) 1 }}false{{ else }}true{{ end }}",
+						"CONTENTS":            "{{ if gt (len .Hardware.Interfaces) 1 }}" + bottlerocketStaticInterfacesTemplate + "{{ end }}",
 						"IFNAME":              "eno1",
 						"UID":                 "0",
 						"GID":                 "0",
@@ -160,7 +165,8 @@ warnings:
 						"DEST_DISK":           "{{ formatPartition ( index .Hardware.Disks 0 ) 12 }}",
 						"FS_TYPE":             "ext4",
 						"DEST_PATH":           "/net.toml",
-						"STATIC_BOTTLEROCKET": "true",
+						"STATIC_BOTTLEROCKET": "{{ if gt (len .Hardware.Interfaces) 1 }}false{{ else }}true{{ end }}",
+						"CONTENTS":            "{{ if gt (len .Hardware.Interfaces) 1 }}" + bottlerocketStaticInterfacesTemplate + "{{ end }}",
 						"IFNAME":              "eno1",
 						"UID":                 "0",
 						"GID":                 "0",
@@ -332,7 +338,7 @@ method=ignore
 						"GID":       "0",
 						"MODE":      "0644",
 						"UID":       "0",
-						"CONTENTS": `{{ if (index .Hardware.Interfaces 0).DHCP.VLANID }}network:
+						"CONTENTS": `{{ if gt (len .Hardware.Interfaces) 1 }}` + netplanStaticInterfacesTemplate + `{{ else if (index .Hardware.Interfaces 0).DHCP.VLANID }}network:
     version: 2
     renderer: networkd
     ethernets:
@@ -454,7 +460,7 @@ method=ignore
 						"GID":       "0",
 						"MODE":      "0644",
 						"UID":       "0",
-						"CONTENTS": `{{ if (index .Hardware.Interfaces 0).DHCP.VLANID }}network:
+						"CONTENTS": `{{ if gt (len .Hardware.Interfaces) 1 }}` + netplanStaticInterfacesTemplate + `{{ else if (index .Hardware.Interfaces 0).DHCP.VLANID }}network:
     version: 2
     renderer: networkd
     ethernets:
@@ -591,7 +597,7 @@ method=ignore
 						"GID":       "0",
 						"MODE":      "0644",
 						"UID":       "0",
-						"CONTENTS": `{{ if (index .Hardware.Interfaces 0).DHCP.VLANID }}network:
+						"CONTENTS": `{{ if gt (len .Hardware.Interfaces) 1 }}` + netplanStaticInterfacesTemplate + `{{ else if (index .Hardware.Interfaces 0).DHCP.VLANID }}network:
     version: 2
     renderer: networkd
     ethernets:
@@ -700,3 +706,250 @@ method=ignore
 		})
 	}
 }
+
+func renderNetworkAction(t *testing.T, osFamily OSFamily, key string, ifaces []tinkv1alpha1.Interface, tags ...string) string {
+	t.Helper()
+	var actions []tinkerbell.Action
+	withNetplanAction("/dev/sda2", osFamily)(&actions)
+
+	funcs := template.FuncMap{
+		"netmaskToPrefixLength": func(netmask string) int {
+			ones, _ := net.IPMask(net.ParseIP(netmask).To4()).Size()
+			return ones
+		},
+	}
+	tpl, err := template.New("").Funcs(funcs).Option("missingkey=error").Parse(actions[0].Environment[key])
+	if err != nil {
+		t.Fatalf("parsing %s: %v", key, err)
+	}
+
+	data := map[string]interface{}{
+		"Hardware": map[string]interface{}{
+			"Interfaces": ifaces,
+			"Metadata":   tinkv1alpha1.HardwareMetadata{Instance: &tinkv1alpha1.MetadataInstance{Tags: tags}},
+		},
+	}
+	var b bytes.Buffer
+	if err := tpl.Execute(&b, data); err != nil {
+		t.Fatalf("rendering %s: %v", key, err)
+	}
+	return b.String()
+}
+
+func staticInterfaces(bonded bool, vlanID string) []tinkv1alpha1.Interface {
+	primary := tinkv1alpha1.Interface{DHCP: &tinkv1alpha1.DHCP{
+		MAC:         "00:00:00:00:00:01",
+		VLANID:      vlanID,
+		NameServers: []string{"1.1.1.1", "8.8.8.8"},
+		IP:          &tinkv1alpha1.IP{Address: "10.0.0.5", Netmask: "255.255.255.0", Gateway: "10.0.0.1"},
+	}}
+	ifaces := []tinkv1alpha1.Interface{primary}
+	if bonded {
+		ifaces[0].DHCP.IfaceName = "bond0"
+		member := primary.DHCP.DeepCopy()
+		member.MAC = "00:00:00:00:00:02"
+		ifaces = append(ifaces, tinkv1alpha1.Interface{DHCP: member})
+	}
+	return append(ifaces, tinkv1alpha1.Interface{DHCP: &tinkv1alpha1.DHCP{
+		IfaceName: "vlan200",
+		VLANID:    "200",
+		IP:        &tinkv1alpha1.IP{Address: "10.20.0.5", Netmask: "255.255.0.0"},
+	}})
+}
+
+func TestNetplanActionStaticInterfaces(t *testing.T) {
+	tests := []struct {
+		name   string
+		ifaces []tinkv1alpha1.Interface
+		tags   []string
+		want   string
+	}{
+		{
+			name:   "bond",
+			ifaces: staticInterfaces(true, ""),
+			tags:   []string{"bond-mode=802.3ad"},
+			want: `network:
+    version: 2
+    renderer: networkd
+    ethernets:
+        nic0:
+            match:
+                macaddress: 00:00:00:00:00:01
+            set-name: nic0
+        nic1:
+            match:
+                macaddress: 00:00:00:00:00:02
+            set-name: nic1
+    bonds:
+        bond0:
+            interfaces: [nic0, nic1]
+            parameters:
+                mode: 802.3ad
+                mii-monitor-interval: 100
+            addresses:
+                - 10.0.0.5/24
+            nameservers:
+                addresses: [1.1.1.1, 8.8.8.8]
+            routes:
+                - to: default
+                  via: 10.0.0.1
+    vlans:
+        vlan200:
+            id: 200
+            link: bond0
+            addresses:
+                - 10.20.0.5/16
+`,
+		},
+		{
+			name:   "bond with primary vlan",
+			ifaces: staticInterfaces(true, "100"),
+			want: `network:
+    version: 2
+    renderer: networkd
+    ethernets:
+        nic0:
+            match:
+                macaddress: 00:00:00:00:00:01
+            set-name: nic0
+        nic1:
+            match:
+                macaddress: 00:00:00:00:00:02
+            set-name: nic1
+    bonds:
+        bond0:
+            interfaces: [nic0, nic1]
+            parameters:
+                mode: active-backup
+                mii-monitor-interval: 100
+    vlans:
+        vlan100:
+            id: 100
+            link: bond0
+            addresses:
+                - 10.0.0.5/24
+            nameservers:
+                addresses: [1.1.1.1, 8.8.8.8]
+            routes:
+                - to: default
+                  via: 10.0.0.1
+        vlan200:
+            id: 200
+            link: bond0
+            addresses:
+                - 10.20.0.5/16
+`,
+		},
+		{
+			name:   "additional vlans",
+			ifaces: staticInterfaces(false, ""),
+			want: `network:
+    version: 2
+    renderer: networkd
+    ethernets:
+        nic0:
+            match:
+                macaddress: 00:00:00:00:00:01
+            set-name: nic0
+            addresses:
+                - 10.0.0.5/24
+            nameservers:
+                addresses: [1.1.1.1, 8.8.8.8]
+            routes:
+                - to: default
+                  via: 10.0.0.1
+    vlans:
+        vlan200:
+            id: 200
+            link: nic0
+            addresses:
+                - 10.20.0.5/16
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderNetworkAction(t, Ubuntu, "CONTENTS", tt.ifaces, tt.tags...)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("netplan config mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNetplanActionSingleInterface(t *testing.T) {
+	got := renderNetworkAction(t, Ubuntu, "CONTENTS", staticInterfaces(false, "")[:1])
+	want := `network:
+    version: 2
+    renderer: networkd
+    ethernets:
+        id0:
+            match:
+                macaddress: 00:00:00:00:00:01
+            addresses:
+                - 10.0.0.5/24
+            nameservers:
+                addresses: [1.1.1.1, 8.8.8.8]
+            routes:
+                - to: default
+                  via: 10.0.0.1
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("netplan config mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBottlerocketNetworkActionStaticInterfaces(t *testing.T) {
+	ifaces := staticInterfaces(true, "100")
+	if got := renderNetworkAction(t, Bottlerocket, "STATIC_BOTTLEROCKET", ifaces); got != "false" {
+		t.Errorf("STATIC_BOTTLEROCKET = %q, want false", got)
+	}
+
+	got := renderNetworkAction(t, Bottlerocket, "CONTENTS", ifaces, "bond-mode=802.3ad")
+	want := `version = 3
+
+[bond0]
+kind = "bond"
+mode = "802.3ad"
+interfaces = ["00:00:00:00:00:01", "00:00:00:00:00:02"]
+
+[bond0.monitoring]
+miimon-frequency-ms = 100
+miimon-updelay-ms = 200
+miimon-downdelay-ms = 200
+
+[vlan100]
+kind = "vlan"
+device = "bond0"
+id = 100
+primary = true
+
+[vlan100.static4]
+addresses = ["10.0.0.5/24"]
+
+[[vlan100.route]]
+to = "default"
+via = "10.0.0.1"
+
+[vlan200]
+kind = "vlan"
+device = "bond0"
+id = 200
+
+[vlan200.static4]
+addresses = ["10.20.0.5/16"]
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("net.toml mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBottlerocketNetworkActionSingleInterface(t *testing.T) {
+	ifaces := staticInterfaces(false, "")[:1]
+	if got := renderNetworkAction(t, Bottlerocket, "STATIC_BOTTLEROCKET", ifaces); got != "true" {
+		t.Errorf("STATIC_BOTTLEROCKET = %q, want true", got)
+	}
+	if got := renderNetworkAction(t, Bottlerocket, "CONTENTS", ifaces); got != "" {
+		t.Errorf("CONTENTS = %q, want empty", got)
+	}
+}
@@ -0,0 +1,33 @@
+package v1alpha1
+
+// +kubebuilder:validation:Enum=soft;hard
+
+// VSphereAntiAffinityType describes how strictly DRS keeps the VMs of a machine group apart.
+type VSphereAntiAffinityType string
+
+const (
+	// SoftAntiAffinity creates a DRS rule that DRS tries to satisfy but can violate, for example
+	// when there are not enough ESXi hosts available during a rollout or a host failure.
+	SoftAntiAffinity VSphereAntiAffinityType = "soft"
+
+	// HardAntiAffinity creates a mandatory DRS rule. VMs that can't be placed on a separate
+	// ESXi host won't be powered on.
+	HardAntiAffinity VSphereAntiAffinityType = "hard"
+)
+
+// VSphereAntiAffinity configures the DRS rules that keep the VMs of a machine group on
+// different ESXi hosts of the compute cluster.
+type VSphereAntiAffinity struct {
+	// Type is either soft or hard. Defaults to soft.
+	Type VSphereAntiAffinityType `json:"type,omitempty"`
+
+	// HostGroup is the name of an existing DRS host group in the compute cluster. When set, the VMs
+	// are also added to a DRS VM group with a VM-Host rule that places them on the hosts of that group.
+	// The VM-Host rule follows the same type as the anti-affinity rule.
+	HostGroup string `json:"hostGroup,omitempty"`
+}
+
+// IsHard returns true if the DRS rules are mandatory.
+func (a *VSphereAntiAffinity) IsHard() bool {
+	return a != nil && a.Type == HardAntiAffinity
+}
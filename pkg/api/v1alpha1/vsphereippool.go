@@ -0,0 +1,127 @@
+package v1alpha1
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+)
+
+const (
+	// VSphereIPPoolKind is the object kind name for VSphereIPPool.
+	VSphereIPPoolKind = "VSphereIPPool"
+)
+
+// Size returns the number of addresses in the pool ranges.
+func (p *VSphereIPPool) Size() int {
+	size := 0
+	for _, r := range p.Spec.Ranges {
+		start, end, ok := parseVSphereIPRange(r)
+		if !ok || start > end {
+			continue
+		}
+		size += int(end-start) + 1
+	}
+	return size
+}
+
+// Contains returns true if the address is in one of the pool ranges.
+func (p *VSphereIPPool) Contains(ip string) bool {
+	addr, ok := ipv4ToUint32(net.ParseIP(ip))
+	if !ok {
+		return false
+	}
+	for _, r := range p.Spec.Ranges {
+		start, end, ok := parseVSphereIPRange(r)
+		if ok && addr >= start && addr <= end {
+			return true
+		}
+	}
+	return false
+}
+
+// FirstAvailable returns the first address of the pool ranges that is not in inUse.
+// It returns false when all the addresses are in use.
+func (p *VSphereIPPool) FirstAvailable(inUse map[string]bool) (string, bool) {
+	for _, r := range p.Spec.Ranges {
+		start, end, ok := parseVSphereIPRange(r)
+		if !ok {
+			continue
+		}
+		for addr := uint64(start); addr <= uint64(end); addr++ {
+			ip := uint32ToIPv4(uint32(addr)).String()
+			if !inUse[ip] {
+				return ip, true
+			}
+		}
+	}
+	return "", false
+}
+
+func validateVSphereIPPool(pool *VSphereIPPool) error {
+	if pool.Spec.Network == "" {
+		return fmt.Errorf("VSphereIPPool %s network can not be empty", pool.Name)
+	}
+
+	if pool.Spec.Prefix < 1 || pool.Spec.Prefix > 32 {
+		return fmt.Errorf("VSphereIPPool %s prefix %d is invalid, it must be between 1 and 32", pool.Name, pool.Spec.Prefix)
+	}
+
+	gateway := net.ParseIP(pool.Spec.Gateway).To4()
+	if gateway == nil {
+		return fmt.Errorf("VSphereIPPool %s gateway %s is not a valid IPv4 address", pool.Name, pool.Spec.Gateway)
+	}
+	subnet := &net.IPNet{IP: gateway.Mask(net.CIDRMask(pool.Spec.Prefix, 32)), Mask: net.CIDRMask(pool.Spec.Prefix, 32)}
+
+	if len(pool.Spec.Ranges) == 0 {
+		return fmt.Errorf("VSphereIPPool %s ranges can not be empty", pool.Name)
+	}
+
+	for index, r := range pool.Spec.Ranges {
+		start := net.ParseIP(r.Start).To4()
+		if start == nil {
+			return fmt.Errorf("VSphereIPPool %s ranges[%d].start %s is not a valid IPv4 address", pool.Name, index, r.Start)
+		}
+		end := net.ParseIP(r.End).To4()
+		if end == nil {
+			return fmt.Errorf("VSphereIPPool %s ranges[%d].end %s is not a valid IPv4 address", pool.Name, index, r.End)
+		}
+		if s, e, _ := parseVSphereIPRange(r); s > e {
+			return fmt.Errorf("VSphereIPPool %s ranges[%d].start should not be greater than end", pool.Name, index)
+		}
+		if !subnet.Contains(start) || !subnet.Contains(end) {
+			return fmt.Errorf("VSphereIPPool %s ranges[%d] should be within the subnet %s", pool.Name, index, subnet)
+		}
+	}
+
+	if pool.Contains(pool.Spec.Gateway) {
+		return fmt.Errorf("VSphereIPPool %s gateway %s can not be inside the pool ranges", pool.Name, pool.Spec.Gateway)
+	}
+
+	for index, ns := range pool.Spec.Nameservers {
+		if net.ParseIP(ns) == nil {
+			return fmt.Errorf("VSphereIPPool %s nameservers[%d] %s is not a valid IP address", pool.Name, index, ns)
+		}
+	}
+
+	return nil
+}
+
+func parseVSphereIPRange(r VSphereIPRange) (start, end uint32, ok bool) {
+	start, startOk := ipv4ToUint32(net.ParseIP(r.Start))
+	end, endOk := ipv4ToUint32(net.ParseIP(r.End))
+	return start, end, startOk && endOk
+}
+
+func ipv4ToUint32(ip net.IP) (uint32, bool) {
+	ip = ip.To4()
+	if ip == nil {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(ip), true
+}
+
+func uint32ToIPv4(addr uint32) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, addr)
+	return ip
+}
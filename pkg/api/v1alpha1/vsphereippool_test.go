@@ -0,0 +1,154 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func vsphereIPPool() *v1alpha1.VSphereIPPool {
+	return &v1alpha1.VSphereIPPool{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1alpha1.VSphereIPPoolKind,
+			APIVersion: v1alpha1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pool",
+		},
+		Spec: v1alpha1.VSphereIPPoolSpec{
+			Network: "/SDDC-Datacenter/network/sddc-cgw-network-1",
+			Ranges: []v1alpha1.VSphereIPRange{
+				{Start: "10.0.0.10", End: "10.0.0.12"},
+				{Start: "10.0.0.20", End: "10.0.0.20"},
+			},
+			Prefix:      24,
+			Gateway:     "10.0.0.1",
+			Nameservers: []string{"10.0.0.2"},
+		},
+	}
+}
+
+func TestVSphereIPPoolConvertConfigToConfigGenerateStruct(t *testing.T) {
+	g := NewWithT(t)
+	pool := vsphereIPPool()
+
+	want := &v1alpha1.VSphereIPPoolGenerate{
+		TypeMeta: pool.TypeMeta,
+		ObjectMeta: v1alpha1.ObjectMeta{
+			Name:      "pool",
+			Namespace: "default",
+		},
+		Spec: pool.Spec,
+	}
+
+	g.Expect(pool.ConvertConfigToConfigGenerateStruct()).To(Equal(want))
+}
+
+func TestVSphereIPPoolSize(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(vsphereIPPool().Size()).To(Equal(4))
+}
+
+func TestVSphereIPPoolContains(t *testing.T) {
+	g := NewWithT(t)
+	pool := vsphereIPPool()
+	g.Expect(pool.Contains("10.0.0.11")).To(BeTrue())
+	g.Expect(pool.Contains("10.0.0.20")).To(BeTrue())
+	g.Expect(pool.Contains("10.0.0.13")).To(BeFalse())
+	g.Expect(pool.Contains("invalid")).To(BeFalse())
+}
+
+func TestVSphereIPPoolFirstAvailable(t *testing.T) {
+	g := NewWithT(t)
+	pool := vsphereIPPool()
+
+	ip, ok := pool.FirstAvailable(map[string]bool{"10.0.0.10": true})
+	g.Expect(ok).To(BeTrue())
+	g.Expect(ip).To(Equal("10.0.0.11"))
+
+	ip, ok = pool.FirstAvailable(map[string]bool{"10.0.0.10": true, "10.0.0.11": true, "10.0.0.12": true})
+	g.Expect(ok).To(BeTrue())
+	g.Expect(ip).To(Equal("10.0.0.20"))
+
+	_, ok = pool.FirstAvailable(map[string]bool{"10.0.0.10": true, "10.0.0.11": true, "10.0.0.12": true, "10.0.0.20": true})
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestVSphereIPPoolValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*v1alpha1.VSphereIPPool)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			update: func(*v1alpha1.VSphereIPPool) {},
+		},
+		{
+			name:    "empty network",
+			update:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Network = "" },
+			wantErr: "VSphereIPPool pool network can not be empty",
+		},
+		{
+			name:    "invalid prefix",
+			update:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Prefix = 33 },
+			wantErr: "VSphereIPPool pool prefix 33 is invalid, it must be between 1 and 32",
+		},
+		{
+			name:    "invalid gateway",
+			update:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Gateway = "gateway" },
+			wantErr: "VSphereIPPool pool gateway gateway is not a valid IPv4 address",
+		},
+		{
+			name:    "empty ranges",
+			update:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Ranges = nil },
+			wantErr: "VSphereIPPool pool ranges can not be empty",
+		},
+		{
+			name:    "invalid range start",
+			update:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Ranges[1].Start = "start" },
+			wantErr: "VSphereIPPool pool ranges[1].start start is not a valid IPv4 address",
+		},
+		{
+			name:    "invalid range end",
+			update:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Ranges[0].End = "fd00::1" },
+			wantErr: "VSphereIPPool pool ranges[0].end fd00::1 is not a valid IPv4 address",
+		},
+		{
+			name:    "range start greater than end",
+			update:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Ranges[0].Start = "10.0.0.13" },
+			wantErr: "VSphereIPPool pool ranges[0].start should not be greater than end",
+		},
+		{
+			name:    "range outside of subnet",
+			update:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Ranges[1].End = "10.0.1.20" },
+			wantErr: "VSphereIPPool pool ranges[1] should be within the subnet 10.0.0.0/24",
+		},
+		{
+			name:    "gateway in range",
+			update:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Gateway = "10.0.0.11" },
+			wantErr: "VSphereIPPool pool gateway 10.0.0.11 can not be inside the pool ranges",
+		},
+		{
+			name:    "invalid nameserver",
+			update:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Nameservers = []string{"dns"} },
+			wantErr: "VSphereIPPool pool nameservers[0] dns is not a valid IP address",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			pool := vsphereIPPool()
+			tt.update(pool)
+			err := pool.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
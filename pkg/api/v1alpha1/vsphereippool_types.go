@@ -0,0 +1,103 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VSphereIPPoolSpec defines the desired state of VSphereIPPool.
+type VSphereIPPoolSpec struct {
+	// Network is the name or inventory path of the vSphere network the addresses of the pool belong to.
+	// Machine NICs attached to this network get their addresses from the pool.
+	Network string `json:"network"`
+
+	// FailureDomain is the name of the VSphereDatacenterConfig failure domain the pool is used for.
+	// When empty, the pool is used for the machines that are not placed in a failure domain.
+	// +optional
+	FailureDomain string `json:"failureDomain,omitempty"`
+
+	// Ranges are the ranges of addresses the machine NICs are assigned.
+	Ranges []VSphereIPRange `json:"ranges"`
+
+	// Prefix is the prefix length of the subnet the addresses belong to.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=32
+	Prefix int `json:"prefix"`
+
+	// Gateway is the gateway of the subnet for routing purpose.
+	Gateway string `json:"gateway"`
+
+	// Nameservers are the DNS servers configured on the machine NICs.
+	// +optional
+	Nameservers []string `json:"nameservers,omitempty"`
+}
+
+// VSphereIPRange is a range of IPv4 addresses, both ends included.
+type VSphereIPRange struct {
+	// Start is the first address of the range.
+	Start string `json:"start"`
+
+	// End is the last address of the range.
+	End string `json:"end"`
+}
+
+// VSphereIPPoolStatus defines the observed state of VSphereIPPool.
+type VSphereIPPoolStatus struct{}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// VSphereIPPool is the Schema for the VSphereIPPools API.
+type VSphereIPPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VSphereIPPoolSpec   `json:"spec,omitempty"`
+	Status VSphereIPPoolStatus `json:"status,omitempty"`
+}
+
+// Validate validates the fields in a VSphereIPPool object.
+func (p *VSphereIPPool) Validate() error {
+	return validateVSphereIPPool(p)
+}
+
+// ConvertConfigToConfigGenerateStruct converts a VSphereIPPool to VSphereIPPoolGenerate object.
+func (p *VSphereIPPool) ConvertConfigToConfigGenerateStruct() *VSphereIPPoolGenerate {
+	namespace := defaultEksaNamespace
+	if p.Namespace != "" {
+		namespace = p.Namespace
+	}
+	config := &VSphereIPPoolGenerate{
+		TypeMeta: p.TypeMeta,
+		ObjectMeta: ObjectMeta{
+			Name:        p.Name,
+			Annotations: p.Annotations,
+			Namespace:   namespace,
+		},
+		Spec: p.Spec,
+	}
+
+	return config
+}
+
+// +kubebuilder:object:generate=false
+
+// VSphereIPPoolGenerate is same as VSphereIPPool except stripped down for generation of yaml file during generate clusterconfig.
+type VSphereIPPoolGenerate struct {
+	metav1.TypeMeta `json:",inline"`
+	ObjectMeta      `json:"metadata,omitempty"`
+
+	Spec VSphereIPPoolSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VSphereIPPoolList contains a list of VSphereIPPool.
+type VSphereIPPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VSphereIPPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VSphereIPPool{}, &VSphereIPPoolList{})
+}
@@ -0,0 +1,65 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var vsphereippoollog = logf.Log.WithName("vsphereippool-resource")
+
+// SetupWebhookWithManager sets up the webhook manager for VSphereIPPool.
+func (r *VSphereIPPool) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-anywhere-eks-amazonaws-com-v1alpha1-vsphereippool,mutating=false,failurePolicy=fail,sideEffects=None,groups=anywhere.eks.amazonaws.com,resources=vsphereippools,verbs=create;update,versions=v1alpha1,name=validation.vsphereippool.anywhere.amazonaws.com,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.CustomValidator = &VSphereIPPool{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (r *VSphereIPPool) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	pool, ok := obj.(*VSphereIPPool)
+	if !ok {
+		return nil, fmt.Errorf("expected a VSphereIPPool but got a %T", obj)
+	}
+
+	vsphereippoollog.Info("validate create", "name", pool.Name)
+
+	return nil, pool.Validate()
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+// Ranges can be updated, for example to grow the pool, but addresses already assigned to machines
+// are kept until the machines are deleted.
+func (r *VSphereIPPool) ValidateUpdate(_ context.Context, _, obj runtime.Object) (admission.Warnings, error) {
+	pool, ok := obj.(*VSphereIPPool)
+	if !ok {
+		return nil, fmt.Errorf("expected a VSphereIPPool but got a %T", obj)
+	}
+
+	vsphereippoollog.Info("validate update", "name", pool.Name)
+
+	return nil, pool.Validate()
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (r *VSphereIPPool) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	pool, ok := obj.(*VSphereIPPool)
+	if !ok {
+		return nil, fmt.Errorf("expected a VSphereIPPool but got a %T", obj)
+	}
+
+	vsphereippoollog.Info("validate delete", "name", pool.Name)
+
+	return nil, nil
+}
@@ -0,0 +1,57 @@
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func TestVSphereIPPoolValidateCreate(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	new := vsphereIPPool()
+	g.Expect(new.ValidateCreate(ctx, new)).Error().To(Succeed())
+}
+
+func TestVSphereIPPoolValidateCreateInvalid(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	new := vsphereIPPool()
+	new.Spec.Gateway = ""
+	g.Expect(new.ValidateCreate(ctx, new)).Error().To(MatchError(ContainSubstring("VSphereIPPool pool gateway  is not a valid IPv4 address")))
+}
+
+func TestVSphereIPPoolValidateCreateInvalidObjectType(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	new := vsphereIPPool()
+	g.Expect(new.ValidateCreate(ctx, &v1alpha1.SnowIPPool{})).Error().To(MatchError(ContainSubstring("expected a VSphereIPPool but got a *v1alpha1.SnowIPPool")))
+}
+
+func TestVSphereIPPoolValidateUpdate(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	new := vsphereIPPool()
+	old := new.DeepCopy()
+	new.Spec.Ranges = append(new.Spec.Ranges, v1alpha1.VSphereIPRange{Start: "10.0.0.30", End: "10.0.0.40"})
+	g.Expect(new.ValidateUpdate(ctx, old, new)).Error().To(Succeed())
+}
+
+func TestVSphereIPPoolValidateUpdateInvalid(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	new := vsphereIPPool()
+	old := new.DeepCopy()
+	new.Spec.Ranges = nil
+	g.Expect(new.ValidateUpdate(ctx, old, new)).Error().To(MatchError(ContainSubstring("VSphereIPPool pool ranges can not be empty")))
+}
+
+func TestVSphereIPPoolValidateDelete(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	new := vsphereIPPool()
+	g.Expect(new.ValidateDelete(ctx, new)).Error().To(Succeed())
+}
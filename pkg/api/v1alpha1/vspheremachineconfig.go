@@ -75,6 +75,10 @@ func setVSphereMachineConfigDefaults(machineConfig *VSphereMachineConfig) {
 		logger.Info("Warning: OS family not specified in machine config specification. Defaulting to Bottlerocket.")
 		machineConfig.Spec.OSFamily = Bottlerocket
 	}
+
+	if machineConfig.Spec.AntiAffinity != nil && machineConfig.Spec.AntiAffinity.Type == "" {
+		machineConfig.Spec.AntiAffinity.Type = SoftAntiAffinity
+	}
 }
 
 func validateVSphereMachineConfig(config *VSphereMachineConfig) error {
@@ -93,6 +97,43 @@ func validateVSphereMachineConfig(config *VSphereMachineConfig) error {
 	if err := validateHostOSConfig(config.Spec.HostOSConfiguration, config.Spec.OSFamily); err != nil {
 		return fmt.Errorf("HostOSConfiguration is invalid for VSphereMachineConfig %s: %v", config.Name, err)
 	}
+	if err := validateVSphereAntiAffinity(config.Spec.AntiAffinity); err != nil {
+		return fmt.Errorf("VSphereMachineConfig %s antiAffinity is invalid: %v", config.Name, err)
+	}
+	if err := validateVSphereIPPoolRefs(config); err != nil {
+		return fmt.Errorf("VSphereMachineConfig %s ipPoolRefs is invalid: %v", config.Name, err)
+	}
+
+	return nil
+}
+
+func validateVSphereIPPoolRefs(config *VSphereMachineConfig) error {
+	if len(config.Spec.IPPoolRefs) == 0 {
+		return nil
+	}
+	// Bottlerocket doesn't apply the network configuration CAPV passes in the VM metadata.
+	if config.Spec.OSFamily == Bottlerocket {
+		return fmt.Errorf("static IP pools are not supported for osFamily %s", Bottlerocket)
+	}
+	for index, ref := range config.Spec.IPPoolRefs {
+		if ref.Kind != VSphereIPPoolKind {
+			return fmt.Errorf("ipPoolRefs[%d] kind %s is not supported, please use %s", index, ref.Kind, VSphereIPPoolKind)
+		}
+		if ref.Name == "" {
+			return fmt.Errorf("ipPoolRefs[%d] name can not be empty", index)
+		}
+	}
+
+	return nil
+}
+
+func validateVSphereAntiAffinity(antiAffinity *VSphereAntiAffinity) error {
+	if antiAffinity == nil {
+		return nil
+	}
+	if antiAffinity.Type != "" && antiAffinity.Type != SoftAntiAffinity && antiAffinity.Type != HardAntiAffinity {
+		return fmt.Errorf("type %s is not supported, please use one of the following: %s, %s", antiAffinity.Type, SoftAntiAffinity, HardAntiAffinity)
+	}
 
 	return nil
 }
@@ -186,6 +186,137 @@ func TestVSphereMachineConfigValidate(t *testing.T) {
 			},
 			wantErr: "HostOSConfiguration is invalid for VSphereMachineConfig test: NTPConfiguration.Servers can not be empty",
 		},
+		{
+			name: "invalid antiAffinity type",
+			obj: &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					Template:     "templateA",
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					Folder:       "folder/A",
+					OSFamily:     "ubuntu",
+					Users: []UserConfiguration{
+						{
+							Name: "test",
+							SshAuthorizedKeys: []string{
+								"ssh_rsa",
+							},
+						},
+					},
+					AntiAffinity: &VSphereAntiAffinity{
+						Type: "required",
+					},
+				},
+			},
+			wantErr: "VSphereMachineConfig test antiAffinity is invalid: type required is not supported, please use one of the following: soft, hard",
+		},
+		{
+			name: "valid ipPoolRefs",
+			obj: &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					Template:     "templateA",
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					Folder:       "folder/A",
+					OSFamily:     "ubuntu",
+					Users: []UserConfiguration{
+						{
+							Name: "test",
+							SshAuthorizedKeys: []string{
+								"ssh_rsa",
+							},
+						},
+					},
+					IPPoolRefs: []Ref{{Kind: VSphereIPPoolKind, Name: "pool"}},
+				},
+			},
+		},
+		{
+			name: "ipPoolRefs with bottlerocket",
+			obj: &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					Template:     "templateA",
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					Folder:       "folder/A",
+					OSFamily:     "bottlerocket",
+					Users: []UserConfiguration{
+						{
+							Name: "ec2-user",
+							SshAuthorizedKeys: []string{
+								"ssh_rsa",
+							},
+						},
+					},
+					IPPoolRefs: []Ref{{Kind: VSphereIPPoolKind, Name: "pool"}},
+				},
+			},
+			wantErr: "VSphereMachineConfig test ipPoolRefs is invalid: static IP pools are not supported for osFamily bottlerocket",
+		},
+		{
+			name: "ipPoolRefs invalid kind",
+			obj: &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					Template:     "templateA",
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					Folder:       "folder/A",
+					OSFamily:     "ubuntu",
+					Users: []UserConfiguration{
+						{
+							Name: "test",
+							SshAuthorizedKeys: []string{
+								"ssh_rsa",
+							},
+						},
+					},
+					IPPoolRefs: []Ref{{Kind: "SnowIPPool", Name: "pool"}},
+				},
+			},
+			wantErr: "VSphereMachineConfig test ipPoolRefs is invalid: ipPoolRefs[0] kind SnowIPPool is not supported, please use VSphereIPPool",
+		},
+		{
+			name: "ipPoolRefs empty name",
+			obj: &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					Template:     "templateA",
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					Folder:       "folder/A",
+					OSFamily:     "ubuntu",
+					Users: []UserConfiguration{
+						{
+							Name: "test",
+							SshAuthorizedKeys: []string{
+								"ssh_rsa",
+							},
+						},
+					},
+					IPPoolRefs: []Ref{{Kind: VSphereIPPoolKind}},
+				},
+			},
+			wantErr: "VSphereMachineConfig test ipPoolRefs is invalid: ipPoolRefs[0] name can not be empty",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -32,6 +32,15 @@ type VSphereMachineConfigSpec struct {
 	TagIDs              []string             `json:"tags,omitempty"`
 	CloneMode           CloneMode            `json:"cloneMode,omitempty"`
 	HostOSConfiguration *HostOSConfiguration `json:"hostOSConfiguration,omitempty"`
+	// AntiAffinity configures DRS rules to keep the VMs created from this machine config on
+	// different ESXi hosts.
+	// +optional
+	AntiAffinity *VSphereAntiAffinity `json:"antiAffinity,omitempty"`
+	// IPPoolRefs are the VSphereIPPools the machine NICs get static addresses from. Each NIC uses the pool
+	// of its network and, for worker node groups placed in a failure domain, of that failure domain.
+	// When empty, the NICs get their addresses from DHCP.
+	// +optional
+	IPPoolRefs []Ref `json:"ipPoolRefs,omitempty"`
 }
 
 // ResourcePaths returns a map of vSphere resource paths defined in the VSphereMachineConfig.
@@ -740,6 +740,16 @@ func TestVSphereMachineConfigSetDefaults(t *testing.T) {
 	g.Expect(sOld.Spec.OSFamily).To(Equal(v1alpha1.Bottlerocket))
 }
 
+func TestVSphereMachineConfigSetDefaultsAntiAffinity(t *testing.T) {
+	g := NewWithT(t)
+
+	config := vsphereMachineConfig()
+	config.Spec.AntiAffinity = &v1alpha1.VSphereAntiAffinity{}
+	g.Expect(config.Default(context.TODO(), &config)).To(Succeed())
+
+	g.Expect(config.Spec.AntiAffinity.Type).To(Equal(v1alpha1.SoftAntiAffinity))
+}
+
 func vsphereMachineConfig() v1alpha1.VSphereMachineConfig {
 	return v1alpha1.VSphereMachineConfig{
 		TypeMeta:   metav1.TypeMeta{},
@@ -2040,6 +2040,27 @@ func (in *NutanixCategoryIdentifier) DeepCopy() *NutanixCategoryIdentifier {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NutanixDataDisk) DeepCopyInto(out *NutanixDataDisk) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	if in.StorageContainer != nil {
+		in, out := &in.StorageContainer, &out.StorageContainer
+		*out = new(NutanixResourceIdentifier)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NutanixDataDisk.
+func (in *NutanixDataDisk) DeepCopy() *NutanixDataDisk {
+	if in == nil {
+		return nil
+	}
+	out := new(NutanixDataDisk)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NutanixDatacenterConfig) DeepCopyInto(out *NutanixDatacenterConfig) {
 	*out = *in
@@ -2119,6 +2140,13 @@ func (in *NutanixDatacenterConfigSpec) DeepCopyInto(out *NutanixDatacenterConfig
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPPools != nil {
+		in, out := &in.IPPools, &out.IPPools
+		*out = make([]NutanixIPPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NutanixDatacenterConfigSpec.
@@ -2194,6 +2222,47 @@ func (in *NutanixGPUIdentifier) DeepCopy() *NutanixGPUIdentifier {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NutanixIPPool) DeepCopyInto(out *NutanixIPPool) {
+	*out = *in
+	in.Subnet.DeepCopyInto(&out.Subnet)
+	if in.Ranges != nil {
+		in, out := &in.Ranges, &out.Ranges
+		*out = make([]NutanixIPRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NutanixIPPool.
+func (in *NutanixIPPool) DeepCopy() *NutanixIPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(NutanixIPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NutanixIPRange) DeepCopyInto(out *NutanixIPRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NutanixIPRange.
+func (in *NutanixIPRange) DeepCopy() *NutanixIPRange {
+	if in == nil {
+		return nil
+	}
+	out := new(NutanixIPRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NutanixMachineConfig) DeepCopyInto(out *NutanixMachineConfig) {
 	*out = *in
@@ -2285,6 +2354,13 @@ func (in *NutanixMachineConfigSpec) DeepCopyInto(out *NutanixMachineConfigSpec)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DataDisks != nil {
+		in, out := &in.DataDisks, &out.DataDisks
+		*out = make([]NutanixDataDisk, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NutanixMachineConfigSpec.
@@ -2713,7 +2789,7 @@ func (in *SnowDatacenterConfig) DeepCopyInto(out *SnowDatacenterConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
@@ -2771,6 +2847,11 @@ func (in *SnowDatacenterConfigList) DeepCopyObject() runtime.Object {
 func (in *SnowDatacenterConfigSpec) DeepCopyInto(out *SnowDatacenterConfigSpec) {
 	*out = *in
 	out.IdentityRef = in.IdentityRef
+	if in.DecommissionedDevices != nil {
+		in, out := &in.DecommissionedDevices, &out.DecommissionedDevices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowDatacenterConfigSpec.
@@ -2823,13 +2904,28 @@ func (in *SnowDirectNetworkInterface) DeepCopy() *SnowDirectNetworkInterface {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowIPAllocation) DeepCopyInto(out *SnowIPAllocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowIPAllocation.
+func (in *SnowIPAllocation) DeepCopy() *SnowIPAllocation {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowIPAllocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnowIPPool) DeepCopyInto(out *SnowIPPool) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowIPPool.
@@ -2905,6 +3001,11 @@ func (in *SnowIPPoolSpec) DeepCopy() *SnowIPPoolSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnowIPPoolStatus) DeepCopyInto(out *SnowIPPoolStatus) {
 	*out = *in
+	if in.Allocations != nil {
+		in, out := &in.Allocations, &out.Allocations
+		*out = make([]SnowIPAllocation, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowIPPoolStatus.
@@ -3006,6 +3107,11 @@ func (in *SnowMachineConfigSpec) DeepCopyInto(out *SnowMachineConfigSpec) {
 		*out = new(HostOSConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(SnowPlacement)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowMachineConfigSpec.
@@ -3060,6 +3166,21 @@ func (in *SnowNetwork) DeepCopy() *SnowNetwork {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowPlacement) DeepCopyInto(out *SnowPlacement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowPlacement.
+func (in *SnowPlacement) DeepCopy() *SnowPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in SymlinkMaps) DeepCopyInto(out *SymlinkMaps) {
 	{
@@ -3394,6 +3515,21 @@ func (in *UserConfiguration) DeepCopy() *UserConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereAntiAffinity) DeepCopyInto(out *VSphereAntiAffinity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereAntiAffinity.
+func (in *VSphereAntiAffinity) DeepCopy() *VSphereAntiAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereAntiAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereDatacenterConfig) DeepCopyInto(out *VSphereDatacenterConfig) {
 	*out = *in
@@ -3493,6 +3629,120 @@ func (in *VSphereDatacenterConfigStatus) DeepCopy() *VSphereDatacenterConfigStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereIPPool) DeepCopyInto(out *VSphereIPPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereIPPool.
+func (in *VSphereIPPool) DeepCopy() *VSphereIPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereIPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereIPPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereIPPoolList) DeepCopyInto(out *VSphereIPPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VSphereIPPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereIPPoolList.
+func (in *VSphereIPPoolList) DeepCopy() *VSphereIPPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereIPPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereIPPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereIPPoolSpec) DeepCopyInto(out *VSphereIPPoolSpec) {
+	*out = *in
+	if in.Ranges != nil {
+		in, out := &in.Ranges, &out.Ranges
+		*out = make([]VSphereIPRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereIPPoolSpec.
+func (in *VSphereIPPoolSpec) DeepCopy() *VSphereIPPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereIPPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereIPPoolStatus) DeepCopyInto(out *VSphereIPPoolStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereIPPoolStatus.
+func (in *VSphereIPPoolStatus) DeepCopy() *VSphereIPPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereIPPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereIPRange) DeepCopyInto(out *VSphereIPRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereIPRange.
+func (in *VSphereIPRange) DeepCopy() *VSphereIPRange {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereIPRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereMachineConfig) DeepCopyInto(out *VSphereMachineConfig) {
 	*out = *in
@@ -3577,6 +3827,16 @@ func (in *VSphereMachineConfigSpec) DeepCopyInto(out *VSphereMachineConfigSpec)
 		*out = new(HostOSConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AntiAffinity != nil {
+		in, out := &in.AntiAffinity, &out.AntiAffinity
+		*out = new(VSphereAntiAffinity)
+		**out = **in
+	}
+	if in.IPPoolRefs != nil {
+		in, out := &in.IPPoolRefs, &out.IPPoolRefs
+		*out = make([]Ref, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachineConfigSpec.
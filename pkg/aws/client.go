@@ -13,6 +13,7 @@ type Client struct {
 	ec2            EC2Client
 	imds           IMDSClient
 	snowballDevice SnowballDeviceClient
+	s3             S3Client
 }
 
 // Clients are a map between aws profile and its aws client.
@@ -78,6 +79,13 @@ func WithSnowballDevice(snowballdevice SnowballDeviceClient) ClientOpt {
 	}
 }
 
+// WithS3 returns a ClientOpt that sets the s3 client.
+func WithS3(s3 S3Client) ClientOpt {
+	return func(c *Client) {
+		c.s3 = s3
+	}
+}
+
 // NewClient builds an aws Client.
 func NewClient(opts ...ClientOpt) *Client {
 	c := &Client{}
@@ -89,10 +97,11 @@ func NewClient(opts ...ClientOpt) *Client {
 	return c
 }
 
-// NewClientFromConfig builds an aws client with ec2 and snowballdevice apis from aws config.
+// NewClientFromConfig builds an aws client with ec2, snowballdevice and s3 apis from aws config.
 func NewClientFromConfig(cfg aws.Config) *Client {
 	return NewClient(
 		WithEC2(NewEC2Client(cfg)),
 		WithSnowballDevice(NewSnowballClient(cfg)),
+		WithS3(NewS3Client(cfg)),
 	)
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/smithy-go"
 )
 
@@ -15,7 +16,11 @@ type EC2Client interface {
 	DescribeImages(ctx context.Context, params *ec2.DescribeImagesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error)
 	DescribeKeyPairs(ctx context.Context, params *ec2.DescribeKeyPairsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeKeyPairsOutput, error)
 	DescribeInstanceTypes(ctx context.Context, params *ec2.DescribeInstanceTypesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error)
+	DescribeInstances(ctx context.Context, params *ec2.DescribeInstancesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error)
 	ImportKeyPair(ctx context.Context, params *ec2.ImportKeyPairInput, optFns ...func(*ec2.Options)) (*ec2.ImportKeyPairOutput, error)
+	ImportSnapshot(ctx context.Context, params *ec2.ImportSnapshotInput, optFns ...func(*ec2.Options)) (*ec2.ImportSnapshotOutput, error)
+	DescribeImportSnapshotTasks(ctx context.Context, params *ec2.DescribeImportSnapshotTasksInput, optFns ...func(*ec2.Options)) (*ec2.DescribeImportSnapshotTasksOutput, error)
+	RegisterImage(ctx context.Context, params *ec2.RegisterImageInput, optFns ...func(*ec2.Options)) (*ec2.RegisterImageOutput, error)
 }
 
 // NewEC2Client builds a new ec2 client.
@@ -96,3 +101,145 @@ func (c *Client) EC2InstanceTypes(ctx context.Context) ([]EC2InstanceType, error
 	}
 	return instanceTypes, nil
 }
+
+// EC2Image has the information of an ec2 image.
+type EC2Image struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// EC2Images calls aws sdk ec2.DescribeImages to get the list of images available in a device.
+func (c *Client) EC2Images(ctx context.Context) ([]EC2Image, error) {
+	out, err := c.ec2.DescribeImages(ctx, &ec2.DescribeImagesInput{})
+	if err != nil {
+		return nil, fmt.Errorf("describing ec2 images in device: %v", err)
+	}
+
+	images := make([]EC2Image, 0, len(out.Images))
+	for _, image := range out.Images {
+		images = append(images, EC2Image{
+			ID:   aws.ToString(image.ImageId),
+			Name: aws.ToString(image.Name),
+		})
+	}
+	return images, nil
+}
+
+// EC2Instance has the information of an ec2 instance.
+type EC2Instance struct {
+	ID    string
+	Type  string
+	State string
+	Tags  map[string]string
+}
+
+// EC2Instances calls aws sdk ec2.DescribeInstances to get the list of instances in a device.
+func (c *Client) EC2Instances(ctx context.Context) ([]EC2Instance, error) {
+	instances := []EC2Instance{}
+	params := &ec2.DescribeInstancesInput{}
+	for {
+		out, err := c.ec2.DescribeInstances(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("describing ec2 instances in device: %v", err)
+		}
+
+		for _, reservation := range out.Reservations {
+			for _, instance := range reservation.Instances {
+				i := EC2Instance{
+					ID:   aws.ToString(instance.InstanceId),
+					Type: string(instance.InstanceType),
+					Tags: make(map[string]string, len(instance.Tags)),
+				}
+				if instance.State != nil {
+					i.State = string(instance.State.Name)
+				}
+				for _, tag := range instance.Tags {
+					i.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+				}
+				instances = append(instances, i)
+			}
+		}
+
+		if aws.ToString(out.NextToken) == "" {
+			return instances, nil
+		}
+		params = &ec2.DescribeInstancesInput{NextToken: out.NextToken}
+	}
+}
+
+const (
+	rawDiskImageFormat          = "RAW"
+	imageRootDeviceName         = "/dev/sda"
+	imageVirtualizationType     = "hvm"
+	importSnapshotTaskCompleted = "completed"
+	importSnapshotTaskDeleted   = "deleted"
+	importSnapshotTaskDeleting  = "deleting"
+)
+
+// EC2ImportSnapshot calls aws sdk ec2.ImportSnapshot to import a raw disk image stored in a bucket as a snapshot.
+// It returns the id of the import task.
+func (c *Client) EC2ImportSnapshot(ctx context.Context, bucket, key, description string) (string, error) {
+	out, err := c.ec2.ImportSnapshot(ctx, &ec2.ImportSnapshotInput{
+		Description: aws.String(description),
+		DiskContainer: &types.SnapshotDiskContainer{
+			Description: aws.String(description),
+			Format:      aws.String(rawDiskImageFormat),
+			UserBucket: &types.UserBucket{
+				S3Bucket: aws.String(bucket),
+				S3Key:    aws.String(key),
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("importing snapshot from [%s/%s]: %v", bucket, key, err)
+	}
+	return aws.ToString(out.ImportTaskId), nil
+}
+
+// EC2ImportSnapshotStatus calls aws sdk ec2.DescribeImportSnapshotTasks to check the status of a snapshot import task.
+// It returns the id of the snapshot once the import is completed, or an empty string while it's in progress.
+func (c *Client) EC2ImportSnapshotStatus(ctx context.Context, taskID string) (string, error) {
+	out, err := c.ec2.DescribeImportSnapshotTasks(ctx, &ec2.DescribeImportSnapshotTasksInput{
+		ImportTaskIds: []string{taskID},
+	})
+	if err != nil {
+		return "", fmt.Errorf("describing import snapshot task [%s]: %v", taskID, err)
+	}
+	if len(out.ImportSnapshotTasks) == 0 || out.ImportSnapshotTasks[0].SnapshotTaskDetail == nil {
+		return "", fmt.Errorf("import snapshot task [%s] not found", taskID)
+	}
+
+	detail := out.ImportSnapshotTasks[0].SnapshotTaskDetail
+	switch aws.ToString(detail.Status) {
+	case importSnapshotTaskCompleted:
+		return aws.ToString(detail.SnapshotId), nil
+	case importSnapshotTaskDeleted, importSnapshotTaskDeleting:
+		return "", fmt.Errorf("import snapshot task [%s] failed: %s", taskID, aws.ToString(detail.StatusMessage))
+	default:
+		return "", nil
+	}
+}
+
+// EC2RegisterImage calls aws sdk ec2.RegisterImage to register an x86_64 hvm image with ENA support with the
+// given name backed by a snapshot. It returns the id of the new image.
+func (c *Client) EC2RegisterImage(ctx context.Context, name, snapshotID string) (string, error) {
+	out, err := c.ec2.RegisterImage(ctx, &ec2.RegisterImageInput{
+		Name:               aws.String(name),
+		Architecture:       types.ArchitectureValuesX8664,
+		VirtualizationType: aws.String(imageVirtualizationType),
+		EnaSupport:         aws.Bool(true),
+		RootDeviceName:     aws.String(imageRootDeviceName),
+		BlockDeviceMappings: []types.BlockDeviceMapping{
+			{
+				DeviceName: aws.String(imageRootDeviceName),
+				Ebs: &types.EbsBlockDevice{
+					SnapshotId: aws.String(snapshotID),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("registering image [%s] from snapshot [%s]: %v", name, snapshotID, err)
+	}
+	return aws.ToString(out.ImageId), nil
+}
@@ -158,3 +158,197 @@ func TestEC2InstanceTypesError(t *testing.T) {
 	_, err := g.client.EC2InstanceTypes(g.ctx)
 	g.Expect(err).To(MatchError(ContainSubstring("describing ec2 instance type in device")))
 }
+
+func TestEC2Images(t *testing.T) {
+	g := newEC2Test(t)
+	out := &ec2.DescribeImagesOutput{
+		Images: []types.Image{
+			{
+				ImageId: ptr.String("ami-1"),
+				Name:    ptr.String("bottlerocket-1-28"),
+			},
+			{
+				ImageId: ptr.String("ami-2"),
+			},
+		},
+	}
+	want := []aws.EC2Image{
+		{
+			ID:   "ami-1",
+			Name: "bottlerocket-1-28",
+		},
+		{
+			ID: "ami-2",
+		},
+	}
+	g.ec2.EXPECT().DescribeImages(g.ctx, &ec2.DescribeImagesInput{}).Return(out, nil)
+	got, err := g.client.EC2Images(g.ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(got).To(Equal(want))
+}
+
+func TestEC2ImagesError(t *testing.T) {
+	g := newEC2Test(t)
+	g.ec2.EXPECT().DescribeImages(g.ctx, &ec2.DescribeImagesInput{}).Return(nil, errors.New("describe images error"))
+	_, err := g.client.EC2Images(g.ctx)
+	g.Expect(err).To(MatchError(ContainSubstring("describe images error")))
+}
+
+func TestEC2Instances(t *testing.T) {
+	g := newEC2Test(t)
+	page1 := &ec2.DescribeInstancesOutput{
+		Reservations: []types.Reservation{
+			{
+				Instances: []types.Instance{
+					{
+						InstanceId:   ptr.String("s.i-1"),
+						InstanceType: "sbe-c.large",
+						State:        &types.InstanceState{Name: types.InstanceStateNameRunning},
+						Tags: []types.Tag{
+							{Key: ptr.String("MachineName"), Value: ptr.String("cp-1")},
+						},
+					},
+				},
+			},
+		},
+		NextToken: ptr.String("token"),
+	}
+	page2 := &ec2.DescribeInstancesOutput{
+		Reservations: []types.Reservation{
+			{
+				Instances: []types.Instance{
+					{
+						InstanceId:   ptr.String("s.i-2"),
+						InstanceType: "sbe-c.xlarge",
+					},
+				},
+			},
+		},
+	}
+	want := []aws.EC2Instance{
+		{
+			ID:    "s.i-1",
+			Type:  "sbe-c.large",
+			State: "running",
+			Tags:  map[string]string{"MachineName": "cp-1"},
+		},
+		{
+			ID:   "s.i-2",
+			Type: "sbe-c.xlarge",
+			Tags: map[string]string{},
+		},
+	}
+	g.ec2.EXPECT().DescribeInstances(g.ctx, &ec2.DescribeInstancesInput{}).Return(page1, nil)
+	g.ec2.EXPECT().DescribeInstances(g.ctx, &ec2.DescribeInstancesInput{NextToken: ptr.String("token")}).Return(page2, nil)
+	got, err := g.client.EC2Instances(g.ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(got).To(Equal(want))
+}
+
+func TestEC2InstancesError(t *testing.T) {
+	g := newEC2Test(t)
+	g.ec2.EXPECT().DescribeInstances(g.ctx, &ec2.DescribeInstancesInput{}).Return(nil, errors.New("describe instances error"))
+	_, err := g.client.EC2Instances(g.ctx)
+	g.Expect(err).To(MatchError(ContainSubstring("describe instances error")))
+}
+
+func TestEC2ImportSnapshot(t *testing.T) {
+	g := newEC2Test(t)
+	params := &ec2.ImportSnapshotInput{
+		Description: ptr.String("image"),
+		DiskContainer: &types.SnapshotDiskContainer{
+			Description: ptr.String("image"),
+			Format:      ptr.String("RAW"),
+			UserBucket: &types.UserBucket{
+				S3Bucket: ptr.String("bucket"),
+				S3Key:    ptr.String("image.raw"),
+			},
+		},
+	}
+	g.ec2.EXPECT().ImportSnapshot(g.ctx, params).Return(&ec2.ImportSnapshotOutput{ImportTaskId: ptr.String("s.import-snap-1")}, nil)
+	got, err := g.client.EC2ImportSnapshot(g.ctx, "bucket", "image.raw", "image")
+	g.Expect(err).To(Succeed())
+	g.Expect(got).To(Equal("s.import-snap-1"))
+}
+
+func TestEC2ImportSnapshotError(t *testing.T) {
+	g := newEC2Test(t)
+	g.ec2.EXPECT().ImportSnapshot(g.ctx, gomock.Any()).Return(nil, errors.New("import error"))
+	_, err := g.client.EC2ImportSnapshot(g.ctx, "bucket", "image.raw", "image")
+	g.Expect(err).To(MatchError(ContainSubstring("import error")))
+}
+
+func TestEC2ImportSnapshotStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		detail  *types.SnapshotTaskDetail
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "completed",
+			detail: &types.SnapshotTaskDetail{Status: ptr.String("completed"), SnapshotId: ptr.String("s.snap-1")},
+			want:   "s.snap-1",
+		},
+		{
+			name:   "active",
+			detail: &types.SnapshotTaskDetail{Status: ptr.String("active")},
+		},
+		{
+			name:    "deleted",
+			detail:  &types.SnapshotTaskDetail{Status: ptr.String("deleted"), StatusMessage: ptr.String("invalid image")},
+			wantErr: "import snapshot task [s.import-snap-1] failed: invalid image",
+		},
+		{
+			name:    "not found",
+			wantErr: "import snapshot task [s.import-snap-1] not found",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newEC2Test(t)
+			out := &ec2.DescribeImportSnapshotTasksOutput{}
+			if tc.detail != nil {
+				out.ImportSnapshotTasks = []types.ImportSnapshotTask{{SnapshotTaskDetail: tc.detail}}
+			}
+			g.ec2.EXPECT().DescribeImportSnapshotTasks(g.ctx, &ec2.DescribeImportSnapshotTasksInput{
+				ImportTaskIds: []string{"s.import-snap-1"},
+			}).Return(out, nil)
+			got, err := g.client.EC2ImportSnapshotStatus(g.ctx, "s.import-snap-1")
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(tc.wantErr))
+				return
+			}
+			g.Expect(err).To(Succeed())
+			g.Expect(got).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestEC2RegisterImage(t *testing.T) {
+	g := newEC2Test(t)
+	params := &ec2.RegisterImageInput{
+		Name:               ptr.String("image"),
+		Architecture:       types.ArchitectureValuesX8664,
+		VirtualizationType: ptr.String("hvm"),
+		EnaSupport:         ptr.Bool(true),
+		RootDeviceName:     ptr.String("/dev/sda"),
+		BlockDeviceMappings: []types.BlockDeviceMapping{
+			{
+				DeviceName: ptr.String("/dev/sda"),
+				Ebs:        &types.EbsBlockDevice{SnapshotId: ptr.String("s.snap-1")},
+			},
+		},
+	}
+	g.ec2.EXPECT().RegisterImage(g.ctx, params).Return(&ec2.RegisterImageOutput{ImageId: ptr.String("s.ami-1")}, nil)
+	got, err := g.client.EC2RegisterImage(g.ctx, "image", "s.snap-1")
+	g.Expect(err).To(Succeed())
+	g.Expect(got).To(Equal("s.ami-1"))
+}
+
+func TestEC2RegisterImageError(t *testing.T) {
+	g := newEC2Test(t)
+	g.ec2.EXPECT().RegisterImage(g.ctx, gomock.Any()).Return(nil, errors.New("register error"))
+	_, err := g.client.EC2RegisterImage(g.ctx, "image", "s.snap-1")
+	g.Expect(err).To(MatchError(ContainSubstring("register error")))
+}
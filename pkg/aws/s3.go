@@ -0,0 +1,54 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3Client is an s3 client that wraps around the aws sdk s3 client.
+type S3Client interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error)
+	CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
+	CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+}
+
+// NewS3Client builds a new s3 client. It uses path style addressing, since the s3 adapter of snow devices
+// is reached by ip and can't serve virtual hosted buckets.
+func NewS3Client(config aws.Config) *s3.Client {
+	return s3.NewFromConfig(config, func(o *s3.Options) {
+		o.UsePathStyle = true
+	})
+}
+
+// S3UploadObject uploads an object to a bucket with the s3 upload manager, which splits large objects
+// in parts uploaded concurrently.
+func (c *Client) S3UploadObject(ctx context.Context, bucket, key string, body io.Reader) error {
+	_, err := manager.NewUploader(c.s3).Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	})
+	if err != nil {
+		return fmt.Errorf("uploading object [%s/%s]: %v", bucket, key, err)
+	}
+	return nil
+}
+
+// S3DeleteObject deletes an object from a bucket.
+func (c *Client) S3DeleteObject(ctx context.Context, bucket, key string) error {
+	if _, err := c.s3.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}); err != nil {
+		return fmt.Errorf("deleting object [%s/%s]: %v", bucket, key, err)
+	}
+	return nil
+}
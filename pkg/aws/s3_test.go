@@ -0,0 +1,123 @@
+package aws_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/aws"
+)
+
+func newS3Config(server *httptest.Server) awssdk.Config {
+	return awssdk.Config{
+		Region:      "snow",
+		HTTPClient:  server.Client(),
+		Credentials: credentials.NewStaticCredentialsProvider("access-key", "secret-key", ""),
+		EndpointResolverWithOptions: awssdk.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (awssdk.Endpoint, error) {
+			return awssdk.Endpoint{URL: server.URL, SigningRegion: "snow"}, nil
+		}),
+	}
+}
+
+// s3Server records the requests of a minimal s3 api.
+type s3Server struct {
+	sync.Mutex
+	requests []string
+	parts    map[string]int
+	auth     string
+}
+
+func (s *s3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	query := r.URL.Query()
+	s.auth = r.Header.Get("Authorization")
+
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		s.requests = append(s.requests, "CreateMultipartUpload "+r.URL.Path)
+		fmt.Fprint(w, `<InitiateMultipartUploadResult><Bucket>bucket</Bucket><Key>image.raw</Key><UploadId>upload-1</UploadId></InitiateMultipartUploadResult>`)
+	case r.Method == http.MethodPut && query.Has("partNumber"):
+		if s.parts == nil {
+			s.parts = map[string]int{}
+		}
+		s.parts[query.Get("partNumber")] = len(body)
+		w.Header().Set("ETag", `"etag-`+query.Get("partNumber")+`"`)
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		s.requests = append(s.requests, "CompleteMultipartUpload "+r.URL.Path)
+		fmt.Fprint(w, `<CompleteMultipartUploadResult><Bucket>bucket</Bucket><Key>image.raw</Key></CompleteMultipartUploadResult>`)
+	case r.Method == http.MethodPut:
+		s.requests = append(s.requests, fmt.Sprintf("PutObject %s %s", r.URL.Path, body))
+	case r.Method == http.MethodDelete:
+		s.requests = append(s.requests, "DeleteObject "+r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestS3UploadObject(t *testing.T) {
+	g := NewWithT(t)
+	s3 := &s3Server{}
+	server := httptest.NewTLSServer(s3)
+	defer server.Close()
+
+	client := aws.NewClient(aws.WithS3(aws.NewS3Client(newS3Config(server))))
+	g.Expect(client.S3UploadObject(context.Background(), "bucket", "image.raw", strings.NewReader("image"))).To(Succeed())
+	g.Expect(s3.requests).To(Equal([]string{"PutObject /bucket/image.raw image"}))
+	g.Expect(s3.auth).To(HavePrefix("AWS4-HMAC-SHA256 Credential=access-key/"))
+	g.Expect(s3.auth).To(ContainSubstring("/snow/s3/aws4_request"))
+}
+
+func TestS3UploadObjectMultipart(t *testing.T) {
+	g := NewWithT(t)
+	s3 := &s3Server{}
+	server := httptest.NewTLSServer(s3)
+	defer server.Close()
+
+	// The upload manager splits objects in parts of 5 MiB.
+	image := bytes.Repeat([]byte("i"), 6<<20)
+	client := aws.NewClient(aws.WithS3(aws.NewS3Client(newS3Config(server))))
+	g.Expect(client.S3UploadObject(context.Background(), "bucket", "image.raw", bytes.NewReader(image))).To(Succeed())
+	g.Expect(s3.requests).To(Equal([]string{
+		"CreateMultipartUpload /bucket/image.raw",
+		"CompleteMultipartUpload /bucket/image.raw",
+	}))
+	g.Expect(s3.parts).To(Equal(map[string]int{"1": 5 << 20, "2": 1 << 20}))
+}
+
+func TestS3UploadObjectError(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`<Error><Code>NoSuchBucket</Code><Message>bucket not found</Message></Error>`))
+	}))
+	defer server.Close()
+
+	client := aws.NewClient(aws.WithS3(aws.NewS3Client(newS3Config(server))))
+	err := client.S3UploadObject(context.Background(), "bucket", "image.raw", strings.NewReader("image"))
+	g.Expect(err).To(MatchError(ContainSubstring("uploading object [bucket/image.raw]")))
+	g.Expect(err).To(MatchError(ContainSubstring("NoSuchBucket")))
+}
+
+func TestS3DeleteObject(t *testing.T) {
+	g := NewWithT(t)
+	s3 := &s3Server{}
+	server := httptest.NewTLSServer(s3)
+	defer server.Close()
+
+	client := aws.NewClient(aws.WithS3(aws.NewS3Client(newS3Config(server))))
+	g.Expect(client.S3DeleteObject(context.Background(), "bucket", "image.raw")).To(Succeed())
+	g.Expect(s3.requests).To(Equal([]string{"DeleteObject /bucket/image.raw"}))
+}
@@ -13,6 +13,7 @@ import (
 const (
 	snowEC2Port        = 8243
 	snowballDevicePort = 9092
+	snowS3AdapterPort  = 8443
 )
 
 func BuildClients(ctx context.Context) (Clients, error) {
@@ -54,6 +55,11 @@ func snowEndpoints(deviceIP string) []ServiceEndpoint {
 			SigningRegion: "snow",
 			URL:           fmt.Sprintf("https://%s:%d", deviceIP, snowballDevicePort),
 		},
+		{
+			ServiceID:     "S3",
+			SigningRegion: "snow",
+			URL:           fmt.Sprintf("https://%s:%d", deviceIP, snowS3AdapterPort),
+		},
 	}
 }
 
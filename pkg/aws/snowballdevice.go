@@ -34,3 +34,32 @@ func (c *Client) SnowballDeviceSoftwareVersion(ctx context.Context) (string, err
 	}
 	return *out.InstalledVersion, nil
 }
+
+// SnowballDeviceCapacity is the usage of a resource of a snowball device, like vCPU or memory.
+type SnowballDeviceCapacity struct {
+	Name      string `json:"name"`
+	Unit      string `json:"unit,omitempty"`
+	Total     int64  `json:"total"`
+	Used      int64  `json:"used"`
+	Available int64  `json:"available"`
+}
+
+// SnowballDeviceCapacities returns the usage of the resources of a snowball device.
+func (c *Client) SnowballDeviceCapacities(ctx context.Context) ([]SnowballDeviceCapacity, error) {
+	out, err := c.snowballDevice.DescribeDevice(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("describing snowball device: %v", err)
+	}
+
+	capacities := make([]SnowballDeviceCapacity, 0, len(out.DeviceCapacities))
+	for _, capacity := range out.DeviceCapacities {
+		capacities = append(capacities, SnowballDeviceCapacity{
+			Name:      aws.ToString(capacity.Name),
+			Unit:      aws.ToString(capacity.Unit),
+			Total:     aws.ToInt64(capacity.Total),
+			Used:      aws.ToInt64(capacity.Used),
+			Available: aws.ToInt64(capacity.Available),
+		})
+	}
+	return capacities, nil
+}
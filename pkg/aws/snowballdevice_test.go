@@ -86,3 +86,39 @@ func TestSnowballDeviceSoftwareVersionDescribeDeviceSoftwareError(t *testing.T)
 	g.Expect(err).NotTo(Succeed())
 	g.Expect(got).To(Equal(""))
 }
+
+func TestSnowballDeviceCapacities(t *testing.T) {
+	g := newSnowballDeviceTest(t)
+	name, unit := "vCPU", "Number"
+	total, used, available := int64(52), int64(8), int64(44)
+	out := &snowballdevice.DescribeDeviceOutput{
+		DeviceCapacities: []types.Capacity{
+			{
+				Name:      &name,
+				Unit:      &unit,
+				Total:     &total,
+				Used:      &used,
+				Available: &available,
+			},
+		},
+	}
+	g.snowballDevice.EXPECT().DescribeDevice(g.ctx, nil).Return(out, nil)
+	got, err := g.client.SnowballDeviceCapacities(g.ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(got).To(Equal([]aws.SnowballDeviceCapacity{
+		{
+			Name:      "vCPU",
+			Unit:      "Number",
+			Total:     52,
+			Used:      8,
+			Available: 44,
+		},
+	}))
+}
+
+func TestSnowballDeviceCapacitiesDescribeDeviceError(t *testing.T) {
+	g := newSnowballDeviceTest(t)
+	g.snowballDevice.EXPECT().DescribeDevice(g.ctx, nil).Return(nil, errors.New("error"))
+	_, err := g.client.SnowballDeviceCapacities(g.ctx)
+	g.Expect(err).NotTo(Succeed())
+}
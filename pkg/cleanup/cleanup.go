@@ -0,0 +1,120 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/aws/eks-anywhere/pkg/errors"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// Resource is a piece of infrastructure created for a cluster.
+type Resource struct {
+	// Kind is the type of the resource, like VirtualMachine.
+	Kind string `json:"kind"`
+	// Name is the name the resource is displayed with.
+	Name string `json:"name"`
+	// ID identifies the resource when deleting it if its name is not enough. Optional.
+	ID string `json:"id,omitempty"`
+	// Scope is where the resource lives, like a CloudStack profile or a vSphere resource pool. Optional.
+	Scope string `json:"scope,omitempty"`
+}
+
+// Discoverer finds the infrastructure of a cluster, using the tags, categories and naming
+// conventions of an infrastructure provider, and deletes it.
+type Discoverer interface {
+	// Name returns the infrastructure the Discoverer looks in, like a provider name.
+	Name() string
+	// Discover returns the resources of a cluster, in the order they have to be deleted.
+	Discover(ctx context.Context, clusterName string) ([]Resource, error)
+	// Delete deletes a resource returned by Discover.
+	Delete(ctx context.Context, resource Resource) error
+}
+
+// PlannedResource is a resource of a Plan, with the infrastructure it was discovered in.
+type PlannedResource struct {
+	Infrastructure string `json:"infrastructure"`
+	Resource
+}
+
+// Plan is the infrastructure of a cluster a Cleaner deletes.
+type Plan struct {
+	Cluster   string            `json:"cluster"`
+	Resources []PlannedResource `json:"resources"`
+}
+
+// Empty returns true if there is nothing to delete.
+func (p *Plan) Empty() bool {
+	return len(p.Resources) == 0
+}
+
+// Write writes the plan in a human readable format.
+func (p *Plan) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(tw, "INFRASTRUCTURE\tKIND\tNAME\tSCOPE")
+	for _, r := range p.Resources {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Infrastructure, r.Kind, r.Name, r.Scope)
+	}
+	return tw.Flush()
+}
+
+// Cleaner finds and deletes the infrastructure left behind by a cluster, like the VMs of a failed create.
+type Cleaner struct {
+	discoverers []Discoverer
+}
+
+// NewCleaner builds a Cleaner looking for resources with the discoverers, in order.
+func NewCleaner(discoverers ...Discoverer) *Cleaner {
+	return &Cleaner{
+		discoverers: discoverers,
+	}
+}
+
+// Plan returns the resources of a cluster found by all the discoverers.
+func (c *Cleaner) Plan(ctx context.Context, clusterName string) (*Plan, error) {
+	plan := &Plan{Cluster: clusterName, Resources: []PlannedResource{}}
+	for _, d := range c.discoverers {
+		logger.V(4).Info("Discovering cluster infrastructure", "infrastructure", d.Name(), "cluster", clusterName)
+		resources, err := d.Discover(ctx, clusterName)
+		if err != nil {
+			return nil, fmt.Errorf("discovering %s resources of cluster %s: %v", d.Name(), clusterName, err)
+		}
+		for _, r := range resources {
+			plan.Resources = append(plan.Resources, PlannedResource{Infrastructure: d.Name(), Resource: r})
+		}
+	}
+
+	return plan, nil
+}
+
+// Execute deletes the resources of a plan in order. It carries on when a resource fails to be deleted
+// so a single failure doesn't leave the rest behind, and returns all the errors.
+func (c *Cleaner) Execute(ctx context.Context, plan *Plan) error {
+	discoverers := make(map[string]Discoverer, len(c.discoverers))
+	for _, d := range c.discoverers {
+		discoverers[d.Name()] = d
+	}
+
+	var errs []error
+	for _, r := range plan.Resources {
+		d, ok := discoverers[r.Infrastructure]
+		if !ok {
+			errs = append(errs, fmt.Errorf("no discoverer for %s %s in %s", r.Kind, r.Name, r.Infrastructure))
+			continue
+		}
+
+		if err := d.Delete(ctx, r.Resource); err != nil {
+			errs = append(errs, fmt.Errorf("deleting %s %s: %v", r.Kind, r.Name, err))
+			continue
+		}
+		logger.Info("Deleted", "infrastructure", r.Infrastructure, "kind", r.Kind, "name", r.Name)
+	}
+
+	if len(errs) > 0 {
+		return errors.NewAggregate(errs)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package cleanup_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/cleanup"
+	"github.com/aws/eks-anywhere/pkg/cleanup/mocks"
+)
+
+type cleanerTest struct {
+	*WithT
+	ctx        context.Context
+	containers *mocks.MockDiscoverer
+	provider   *mocks.MockDiscoverer
+	cleaner    *cleanup.Cleaner
+}
+
+func newCleanerTest(t *testing.T) *cleanerTest {
+	ctrl := gomock.NewController(t)
+	containers := mocks.NewMockDiscoverer(ctrl)
+	containers.EXPECT().Name().Return("docker").AnyTimes()
+	provider := mocks.NewMockDiscoverer(ctrl)
+	provider.EXPECT().Name().Return("vsphere").AnyTimes()
+
+	return &cleanerTest{
+		WithT:      NewWithT(t),
+		ctx:        context.Background(),
+		containers: containers,
+		provider:   provider,
+		cleaner:    cleanup.NewCleaner(containers, provider),
+	}
+}
+
+var (
+	bootstrapContainer = cleanup.Resource{Kind: "Container", Name: "test-eks-a-cluster-control-plane"}
+	vm                 = cleanup.Resource{Kind: "VirtualMachine", Name: "/dc/vm/test-cp-1"}
+	rule               = cleanup.Resource{Kind: "DRSRule", Name: "test-cp-anti-affinity", Scope: "*/Resources"}
+)
+
+func TestCleanerPlan(t *testing.T) {
+	tt := newCleanerTest(t)
+	tt.containers.EXPECT().Discover(tt.ctx, "test").Return([]cleanup.Resource{bootstrapContainer}, nil)
+	tt.provider.EXPECT().Discover(tt.ctx, "test").Return([]cleanup.Resource{vm, rule}, nil)
+
+	plan, err := tt.cleaner.Plan(tt.ctx, "test")
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(plan.Empty()).To(BeFalse())
+	tt.Expect(plan).To(Equal(&cleanup.Plan{
+		Cluster: "test",
+		Resources: []cleanup.PlannedResource{
+			{Infrastructure: "docker", Resource: bootstrapContainer},
+			{Infrastructure: "vsphere", Resource: vm},
+			{Infrastructure: "vsphere", Resource: rule},
+		},
+	}))
+}
+
+func TestCleanerPlanNothingFound(t *testing.T) {
+	tt := newCleanerTest(t)
+	tt.containers.EXPECT().Discover(tt.ctx, "test").Return(nil, nil)
+	tt.provider.EXPECT().Discover(tt.ctx, "test").Return(nil, nil)
+
+	plan, err := tt.cleaner.Plan(tt.ctx, "test")
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(plan.Empty()).To(BeTrue())
+}
+
+func TestCleanerPlanError(t *testing.T) {
+	tt := newCleanerTest(t)
+	tt.containers.EXPECT().Discover(tt.ctx, "test").Return(nil, nil)
+	tt.provider.EXPECT().Discover(tt.ctx, "test").Return(nil, errors.New("invalid credentials"))
+
+	_, err := tt.cleaner.Plan(tt.ctx, "test")
+	tt.Expect(err).To(MatchError("discovering vsphere resources of cluster test: invalid credentials"))
+}
+
+func TestCleanerExecute(t *testing.T) {
+	tt := newCleanerTest(t)
+	plan := &cleanup.Plan{
+		Cluster: "test",
+		Resources: []cleanup.PlannedResource{
+			{Infrastructure: "docker", Resource: bootstrapContainer},
+			{Infrastructure: "vsphere", Resource: vm},
+			{Infrastructure: "vsphere", Resource: rule},
+		},
+	}
+	gomock.InOrder(
+		tt.containers.EXPECT().Delete(tt.ctx, bootstrapContainer),
+		tt.provider.EXPECT().Delete(tt.ctx, vm),
+		tt.provider.EXPECT().Delete(tt.ctx, rule),
+	)
+
+	tt.Expect(tt.cleaner.Execute(tt.ctx, plan)).To(Succeed())
+}
+
+func TestCleanerExecuteContinuesOnError(t *testing.T) {
+	tt := newCleanerTest(t)
+	plan := &cleanup.Plan{
+		Cluster: "test",
+		Resources: []cleanup.PlannedResource{
+			{Infrastructure: "vsphere", Resource: vm},
+			{Infrastructure: "vsphere", Resource: rule},
+			{Infrastructure: "nutanix", Resource: vm},
+		},
+	}
+	tt.provider.EXPECT().Delete(tt.ctx, vm).Return(errors.New("vm is locked"))
+	tt.provider.EXPECT().Delete(tt.ctx, rule)
+
+	err := tt.cleaner.Execute(tt.ctx, plan)
+	tt.Expect(err).To(MatchError(ContainSubstring("deleting VirtualMachine /dc/vm/test-cp-1: vm is locked")))
+	tt.Expect(err).To(MatchError(ContainSubstring("no discoverer for VirtualMachine /dc/vm/test-cp-1 in nutanix")))
+}
+
+func TestPlanWrite(t *testing.T) {
+	g := NewWithT(t)
+	plan := &cleanup.Plan{
+		Cluster: "test",
+		Resources: []cleanup.PlannedResource{
+			{Infrastructure: "docker", Resource: bootstrapContainer},
+			{Infrastructure: "vsphere", Resource: rule},
+		},
+	}
+
+	out := &bytes.Buffer{}
+	g.Expect(plan.Write(out)).To(Succeed())
+	g.Expect(out.String()).To(Equal(
+		"INFRASTRUCTURE   KIND        NAME                               SCOPE\n" +
+			"docker           Container   test-eks-a-cluster-control-plane   \n" +
+			"vsphere          DRSRule     test-cp-anti-affinity              */Resources\n",
+	))
+}
@@ -0,0 +1,53 @@
+package cleanup
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+)
+
+// ValidateClusterUnreachable returns an error if the API server of a cluster answers, at its control plane
+// endpoint or at the servers of its kubeconfig file, so the infrastructure of a running cluster isn't deleted.
+// A kubeconfig file that doesn't exist is ignored, it is not written when a create fails early.
+func ValidateClusterUnreachable(client networkutils.NetClient, cluster *v1alpha1.Cluster, kubeconfigFile string) error {
+	if endpoint := cluster.Spec.ControlPlaneConfiguration.Endpoint; endpoint != nil && endpoint.Host != "" {
+		host, port, err := v1alpha1.GetControlPlaneHostPort(endpoint.Host, v1alpha1.ControlEndpointDefaultPort)
+		if err != nil {
+			return err
+		}
+		if networkutils.IsPortInUse(client, host, port) {
+			return fmt.Errorf("the control plane endpoint %s of cluster %s answers, delete the cluster instead", net.JoinHostPort(host, port), cluster.Name)
+		}
+	}
+
+	if _, err := os.Stat(kubeconfigFile); os.IsNotExist(err) {
+		return nil
+	}
+
+	config, err := clientcmd.LoadFromFile(kubeconfigFile)
+	if err != nil {
+		return fmt.Errorf("loading kubeconfig %s: %v", kubeconfigFile, err)
+	}
+
+	for _, c := range config.Clusters {
+		server, err := url.Parse(c.Server)
+		if err != nil || server.Hostname() == "" {
+			continue
+		}
+		port := server.Port()
+		if port == "" {
+			port = "443"
+		}
+		if networkutils.IsPortInUse(client, server.Hostname(), port) {
+			return fmt.Errorf("the API server %s of kubeconfig %s answers, delete the cluster instead", c.Server, kubeconfigFile)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package cleanup_test
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cleanup"
+	"github.com/aws/eks-anywhere/pkg/networkutils/mocks"
+)
+
+const unreachableTestKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://10.0.0.5:6443
+  name: test
+contexts:
+- context:
+    cluster: test
+    user: test-admin
+  name: test-admin@test
+current-context: test-admin@test
+users:
+- name: test-admin
+  user:
+    token: token
+`
+
+func unreachableTestCluster() *v1alpha1.Cluster {
+	return &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: v1alpha1.ClusterSpec{
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+				Endpoint: &v1alpha1.Endpoint{Host: "10.0.0.1"},
+			},
+		},
+	}
+}
+
+func writeUnreachableTestKubeconfig(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "test-eks-a-cluster.kubeconfig")
+	if err := os.WriteFile(path, []byte(unreachableTestKubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func dialTestConn(network, address string, timeout time.Duration) (net.Conn, error) {
+	client, server := net.Pipe()
+	server.Close()
+	return client, nil
+}
+
+func TestValidateClusterUnreachable(t *testing.T) {
+	g := NewWithT(t)
+	client := mocks.NewMockNetClient(gomock.NewController(t))
+	kubeconfig := writeUnreachableTestKubeconfig(t)
+
+	client.EXPECT().DialTimeout("tcp", "10.0.0.1:6443", gomock.Any()).Return(nil, errors.New("no route to host"))
+	client.EXPECT().DialTimeout("tcp", "10.0.0.5:6443", gomock.Any()).Return(nil, errors.New("no route to host"))
+
+	g.Expect(cleanup.ValidateClusterUnreachable(client, unreachableTestCluster(), kubeconfig)).To(Succeed())
+}
+
+func TestValidateClusterUnreachableNoKubeconfig(t *testing.T) {
+	g := NewWithT(t)
+	client := mocks.NewMockNetClient(gomock.NewController(t))
+
+	client.EXPECT().DialTimeout("tcp", "10.0.0.1:6443", gomock.Any()).Return(nil, errors.New("no route to host"))
+
+	g.Expect(cleanup.ValidateClusterUnreachable(client, unreachableTestCluster(), filepath.Join(t.TempDir(), "missing.kubeconfig"))).To(Succeed())
+}
+
+func TestValidateClusterUnreachableEndpointAnswers(t *testing.T) {
+	g := NewWithT(t)
+	client := mocks.NewMockNetClient(gomock.NewController(t))
+
+	client.EXPECT().DialTimeout("tcp", "10.0.0.1:6443", gomock.Any()).DoAndReturn(dialTestConn)
+
+	err := cleanup.ValidateClusterUnreachable(client, unreachableTestCluster(), writeUnreachableTestKubeconfig(t))
+	g.Expect(err).To(MatchError("the control plane endpoint 10.0.0.1:6443 of cluster test answers, delete the cluster instead"))
+}
+
+func TestValidateClusterUnreachableKubeconfigServerAnswers(t *testing.T) {
+	g := NewWithT(t)
+	client := mocks.NewMockNetClient(gomock.NewController(t))
+	kubeconfig := writeUnreachableTestKubeconfig(t)
+
+	client.EXPECT().DialTimeout("tcp", "10.0.0.1:6443", gomock.Any()).Return(nil, errors.New("no route to host"))
+	client.EXPECT().DialTimeout("tcp", "10.0.0.5:6443", gomock.Any()).DoAndReturn(dialTestConn)
+
+	err := cleanup.ValidateClusterUnreachable(client, unreachableTestCluster(), kubeconfig)
+	g.Expect(err).To(MatchError(ContainSubstring("the API server https://10.0.0.5:6443 of kubeconfig")))
+}
+
+func TestValidateClusterUnreachableInvalidKubeconfig(t *testing.T) {
+	g := NewWithT(t)
+	client := mocks.NewMockNetClient(gomock.NewController(t))
+	kubeconfig := filepath.Join(t.TempDir(), "test-eks-a-cluster.kubeconfig")
+	g.Expect(os.WriteFile(kubeconfig, []byte("clusters: ["), 0o600)).To(Succeed())
+
+	client.EXPECT().DialTimeout("tcp", "10.0.0.1:6443", gomock.Any()).Return(nil, errors.New("no route to host"))
+
+	g.Expect(cleanup.ValidateClusterUnreachable(client, unreachableTestCluster(), kubeconfig)).To(MatchError(ContainSubstring("loading kubeconfig")))
+}
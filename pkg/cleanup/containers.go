@@ -0,0 +1,59 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	containerKind = "Container"
+	// kindClusterLabel is the label kind and CAPD set on the containers of a cluster.
+	kindClusterLabel = "io.x-k8s.kind.cluster"
+	// bootstrapClusterSuffix is appended to the name of a cluster to name its kind bootstrap cluster.
+	bootstrapClusterSuffix = "-eks-a-cluster"
+)
+
+// DockerClient lists and removes local containers.
+type DockerClient interface {
+	ListContainers(ctx context.Context, filter string) ([]string, error)
+	ForceRemove(ctx context.Context, name string) error
+}
+
+// ContainerDiscoverer finds the local containers of a cluster: the nodes of its kind bootstrap cluster
+// and, for the Docker provider, the containers CAPD created for its machines and load balancer.
+type ContainerDiscoverer struct {
+	docker DockerClient
+}
+
+// NewContainerDiscoverer builds a ContainerDiscoverer.
+func NewContainerDiscoverer(docker DockerClient) *ContainerDiscoverer {
+	return &ContainerDiscoverer{
+		docker: docker,
+	}
+}
+
+// Name returns the infrastructure the ContainerDiscoverer looks in.
+func (d *ContainerDiscoverer) Name() string {
+	return "docker"
+}
+
+// Discover returns the containers labeled with the cluster or with its bootstrap cluster.
+func (d *ContainerDiscoverer) Discover(ctx context.Context, clusterName string) ([]Resource, error) {
+	resources := []Resource{}
+	for _, kindCluster := range []string{clusterName, clusterName + bootstrapClusterSuffix} {
+		containers, err := d.docker.ListContainers(ctx, fmt.Sprintf("label=%s=%s", kindClusterLabel, kindCluster))
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range containers {
+			resources = append(resources, Resource{Kind: containerKind, Name: c})
+		}
+	}
+
+	return resources, nil
+}
+
+// Delete force removes a container.
+func (d *ContainerDiscoverer) Delete(ctx context.Context, resource Resource) error {
+	return d.docker.ForceRemove(ctx, resource.Name)
+}
@@ -0,0 +1,55 @@
+package cleanup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/cleanup"
+	"github.com/aws/eks-anywhere/pkg/cleanup/mocks"
+)
+
+func TestContainerDiscovererDiscover(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	docker := mocks.NewMockDockerClient(gomock.NewController(t))
+	d := cleanup.NewContainerDiscoverer(docker)
+
+	docker.EXPECT().ListContainers(ctx, "label=io.x-k8s.kind.cluster=test").Return([]string{"test-lb", "test-md-0-abcd"}, nil)
+	docker.EXPECT().ListContainers(ctx, "label=io.x-k8s.kind.cluster=test-eks-a-cluster").Return([]string{"test-eks-a-cluster-control-plane"}, nil)
+
+	g.Expect(d.Name()).To(Equal("docker"))
+	resources, err := d.Discover(ctx, "test")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(resources).To(Equal([]cleanup.Resource{
+		{Kind: "Container", Name: "test-lb"},
+		{Kind: "Container", Name: "test-md-0-abcd"},
+		{Kind: "Container", Name: "test-eks-a-cluster-control-plane"},
+	}))
+}
+
+func TestContainerDiscovererDiscoverError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	docker := mocks.NewMockDockerClient(gomock.NewController(t))
+	d := cleanup.NewContainerDiscoverer(docker)
+
+	docker.EXPECT().ListContainers(ctx, "label=io.x-k8s.kind.cluster=test").Return(nil, errors.New("docker is not running"))
+
+	_, err := d.Discover(ctx, "test")
+	g.Expect(err).To(MatchError("docker is not running"))
+}
+
+func TestContainerDiscovererDelete(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	docker := mocks.NewMockDockerClient(gomock.NewController(t))
+	d := cleanup.NewContainerDiscoverer(docker)
+
+	docker.EXPECT().ForceRemove(ctx, "test-lb")
+
+	g.Expect(d.Delete(ctx, cleanup.Resource{Kind: "Container", Name: "test-lb"})).To(Succeed())
+}
@@ -0,0 +1,48 @@
+package cleanup
+
+import (
+	"regexp"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+// generatedSuffix matches the 5 random characters the Kubernetes name generator appends to generated names.
+const generatedSuffix = `-[a-z0-9]{5}`
+
+// MachineNames matches the names of the machines of a cluster, which the vSphere, CloudStack and Nutanix
+// providers give to their VMs. The control plane and etcd machines are named after their KubeadmControlPlane
+// and EtcdadmCluster, and the worker machines after the MachineSet of their MachineDeployment. A prefix match
+// on the cluster name would also match the machines of the clusters whose name starts with it.
+type MachineNames struct {
+	patterns []*regexp.Regexp
+}
+
+// NewMachineNames builds the MachineNames of the control plane, etcd and worker node groups of a cluster.
+func NewMachineNames(cluster *v1alpha1.Cluster) *MachineNames {
+	prefixes := []string{clusterapi.KubeadmControlPlaneName(cluster)}
+	if cluster.Spec.ExternalEtcdConfiguration != nil {
+		prefixes = append(prefixes, clusterapi.EtcdClusterName(cluster.Name))
+	}
+
+	patterns := make([]*regexp.Regexp, 0, len(prefixes)+len(cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, prefix := range prefixes {
+		patterns = append(patterns, regexp.MustCompile("^"+regexp.QuoteMeta(prefix)+generatedSuffix+"$"))
+	}
+	for _, wng := range cluster.Spec.WorkerNodeGroupConfigurations {
+		name := clusterapi.MachineDeploymentName(cluster, wng)
+		patterns = append(patterns, regexp.MustCompile("^"+regexp.QuoteMeta(name)+generatedSuffix+generatedSuffix+"$"))
+	}
+
+	return &MachineNames{patterns: patterns}
+}
+
+// Match returns true if name is the name of a machine of the cluster.
+func (m *MachineNames) Match(name string) bool {
+	for _, p := range m.patterns {
+		if p.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package cleanup_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cleanup"
+)
+
+func TestMachineNamesMatch(t *testing.T) {
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: v1alpha1.ClusterSpec{
+			ExternalEtcdConfiguration: &v1alpha1.ExternalEtcdConfiguration{Count: 3},
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+				{Name: "md-0"},
+				{Name: "gpu"},
+			},
+		},
+	}
+	machines := cleanup.NewMachineNames(cluster)
+
+	tests := []struct {
+		name  string
+		match bool
+	}{
+		{name: "test-x7k2p", match: true},
+		{name: "test-etcd-q9w4z", match: true},
+		{name: "test-md-0-5d8fc-abcde", match: true},
+		{name: "test-gpu-5d8fc-abcde", match: true},
+		{name: "test", match: false},
+		{name: "test-prod-x7k2p", match: false},
+		{name: "mytest-x7k2p", match: false},
+		{name: "test-md-1-5d8fc-abcde", match: false},
+		{name: "test-md-0-5d8fc-abcde-copy", match: false},
+		{name: "test-X7K2P", match: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(machines.Match(tt.name)).To(Equal(tt.match))
+		})
+	}
+}
+
+func TestMachineNamesMatchStackedEtcd(t *testing.T) {
+	g := NewWithT(t)
+	machines := cleanup.NewMachineNames(&v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test"}})
+
+	g.Expect(machines.Match("test-x7k2p")).To(BeTrue())
+	g.Expect(machines.Match("test-etcd-q9w4z")).To(BeFalse())
+}
@@ -29,6 +29,7 @@ type Config struct {
 	FluxConfig                *anywherev1.FluxConfig
 	SnowCredentialsSecret     *v1.Secret
 	SnowIPPools               map[string]*anywherev1.SnowIPPool
+	VSphereIPPools            map[string]*anywherev1.VSphereIPPool
 }
 
 func (c *Config) VsphereMachineConfig(name string) *anywherev1.VSphereMachineConfig {
@@ -48,6 +49,11 @@ func (c *Config) SnowIPPool(name string) *anywherev1.SnowIPPool {
 	return c.SnowIPPools[name]
 }
 
+// VSphereIPPool returns a VSphereIPPool based on a name.
+func (c *Config) VSphereIPPool(name string) *anywherev1.VSphereIPPool {
+	return c.VSphereIPPools[name]
+}
+
 func (c *Config) OIDCConfig(name string) *anywherev1.OIDCConfig {
 	return c.OIDCConfigs[name]
 }
@@ -123,6 +129,13 @@ func (c *Config) DeepCopy() *Config {
 		c2.SnowIPPools[k] = v.DeepCopy()
 	}
 
+	if c.VSphereIPPools != nil {
+		c2.VSphereIPPools = make(map[string]*anywherev1.VSphereIPPool, len(c.VSphereIPPools))
+	}
+	for k, v := range c.VSphereIPPools {
+		c2.VSphereIPPools[k] = v.DeepCopy()
+	}
+
 	if c.TinkerbellMachineConfigs != nil {
 		c2.TinkerbellMachineConfigs = make(map[string]*anywherev1.TinkerbellMachineConfig, len(c.TinkerbellMachineConfigs))
 	}
@@ -164,6 +177,10 @@ func (c *Config) ChildObjects() []kubernetes.Object {
 		objs = appendIfNotNil(objs, e)
 	}
 
+	for _, e := range c.VSphereIPPools {
+		objs = appendIfNotNil(objs, e)
+	}
+
 	for _, e := range c.CloudStackMachineConfigs {
 		objs = appendIfNotNil(objs, e)
 	}
@@ -17,10 +17,14 @@ func vsphereEntry() *ConfigManagerEntry {
 			anywherev1.VSphereMachineConfigKind: func() APIObject {
 				return &anywherev1.VSphereMachineConfig{}
 			},
+			anywherev1.VSphereIPPoolKind: func() APIObject {
+				return &anywherev1.VSphereIPPool{}
+			},
 		},
 		Processors: []ParsedProcessor{
 			processVSphereDatacenter,
 			machineConfigsProcessor(processVSphereMachineConfig),
+			vsphereIPPoolsProcessor,
 		},
 		Defaulters: []Defaulter{
 			func(c *Config) error {
@@ -60,6 +64,21 @@ func vsphereEntry() *ConfigManagerEntry {
 				}
 				return nil
 			},
+			func(c *Config) error {
+				for _, m := range c.VSphereMachineConfigs {
+					for _, ref := range m.Spec.IPPoolRefs {
+						if _, ok := c.VSphereIPPools[ref.Name]; !ok {
+							return fmt.Errorf("VSphereIPPool %s not found", ref.Name)
+						}
+					}
+				}
+				for _, p := range c.VSphereIPPools {
+					if err := p.Validate(); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
 			func(c *Config) error {
 				if c.VSphereDatacenter != nil {
 					if err := validateSameNamespace(c, c.VSphereDatacenter); err != nil {
@@ -76,6 +95,14 @@ func vsphereEntry() *ConfigManagerEntry {
 				}
 				return nil
 			},
+			func(c *Config) error {
+				for _, p := range c.VSphereIPPools {
+					if err := validateSameNamespace(c, p); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
 		},
 	}
 }
@@ -110,6 +137,31 @@ func processVSphereMachineConfig(c *Config, objects ObjectLookup, machineRef *an
 	c.VSphereMachineConfigs[m.GetName()] = m.(*anywherev1.VSphereMachineConfig)
 }
 
+func vsphereIPPoolsProcessor(c *Config, objects ObjectLookup) {
+	for _, m := range c.VSphereMachineConfigs {
+		for _, ref := range m.Spec.IPPoolRefs {
+			processVSphereIPPool(c, objects, ref)
+		}
+	}
+}
+
+func processVSphereIPPool(c *Config, objects ObjectLookup, ipPoolRef anywherev1.Ref) {
+	if ipPoolRef.Kind != anywherev1.VSphereIPPoolKind {
+		return
+	}
+
+	if c.VSphereIPPools == nil {
+		c.VSphereIPPools = map[string]*anywherev1.VSphereIPPool{}
+	}
+
+	p := objects.GetFromRef(c.Cluster.APIVersion, ipPoolRef)
+	if p == nil {
+		return
+	}
+
+	c.VSphereIPPools[p.GetName()] = p.(*anywherev1.VSphereIPPool)
+}
+
 func getVSphereDatacenter(ctx context.Context, client Client, c *Config) error {
 	if c.Cluster.Spec.DatacenterRef.Kind != anywherev1.VSphereDatacenterKind {
 		return nil
@@ -144,6 +196,30 @@ func getVSphereMachineConfigs(ctx context.Context, client Client, c *Config) err
 		}
 
 		c.VSphereMachineConfigs[machine.Name] = machine
+
+		if err := getVSphereIPPools(ctx, client, c, machine); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getVSphereIPPools(ctx context.Context, client Client, c *Config, machine *anywherev1.VSphereMachineConfig) error {
+	for _, ref := range machine.Spec.IPPoolRefs {
+		if _, ok := c.VSphereIPPools[ref.Name]; ok {
+			continue
+		}
+
+		pool := &anywherev1.VSphereIPPool{}
+		if err := client.Get(ctx, ref.Name, c.Cluster.Namespace, pool); err != nil {
+			return err
+		}
+
+		if c.VSphereIPPools == nil {
+			c.VSphereIPPools = map[string]*anywherev1.VSphereIPPool{}
+		}
+		c.VSphereIPPools[pool.Name] = pool
 	}
 
 	return nil
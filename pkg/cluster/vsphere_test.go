@@ -43,6 +43,41 @@ func TestValidateVsphereMachineConfigNotFoundError(t *testing.T) {
 	g.Expect(err).To(MatchError(ContainSubstring("VSphereMachineConfig dummy-machine-config not found")))
 }
 
+func TestParseConfigVSphereIPPools(t *testing.T) {
+	g := NewWithT(t)
+	got, err := cluster.ParseConfigFromFile("testdata/cluster_vsphere_ip_pools.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(got.VSphereIPPools).To(HaveLen(1))
+	pool := got.VSphereIPPool("eksa-unit-test-pool")
+	g.Expect(pool).NotTo(BeNil())
+	g.Expect(pool.Spec.Network).To(Equal("/myDatacenter/network-1"))
+	g.Expect(got.ChildObjects()).To(ContainElement(pool))
+
+	cm, _ := cluster.NewDefaultConfigManager()
+	g.Expect(cm.Validate(got)).To(Succeed())
+}
+
+func TestValidateVSphereIPPoolNotFoundError(t *testing.T) {
+	g := NewWithT(t)
+	got, _ := cluster.ParseConfigFromFile("testdata/cluster_vsphere_ip_pools.yaml")
+	got.VsphereMachineConfig("eksa-unit-test").Spec.IPPoolRefs[0].Name = "dummy-pool"
+
+	cm, _ := cluster.NewDefaultConfigManager()
+	err := cm.Validate(got)
+	g.Expect(err).To(MatchError(ContainSubstring("VSphereIPPool dummy-pool not found")))
+}
+
+func TestValidateVSphereIPPoolInvalid(t *testing.T) {
+	g := NewWithT(t)
+	got, _ := cluster.ParseConfigFromFile("testdata/cluster_vsphere_ip_pools.yaml")
+	got.VSphereIPPool("eksa-unit-test-pool").Spec.Gateway = "10.0.0.15"
+
+	cm, _ := cluster.NewDefaultConfigManager()
+	err := cm.Validate(got)
+	g.Expect(err).To(MatchError(ContainSubstring("VSphereIPPool eksa-unit-test-pool gateway 10.0.0.15 can not be inside the pool ranges")))
+}
+
 func TestDefaultConfigClientBuilderVSphereCluster(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -99,6 +134,17 @@ func TestDefaultConfigClientBuilderVSphereCluster(t *testing.T) {
 			Name:      "machine-2",
 			Namespace: "default",
 		},
+		Spec: anywherev1.VSphereMachineConfigSpec{
+			IPPoolRefs: []anywherev1.Ref{
+				{Kind: anywherev1.VSphereIPPoolKind, Name: "pool"},
+			},
+		},
+	}
+	pool := &anywherev1.VSphereIPPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pool",
+			Namespace: "default",
+		},
 	}
 
 	client.EXPECT().Get(ctx, "datacenter", "default", &anywherev1.VSphereDatacenterConfig{}).Return(nil).DoAndReturn(
@@ -122,6 +168,15 @@ func TestDefaultConfigClientBuilderVSphereCluster(t *testing.T) {
 		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
 			m := obj.(*anywherev1.VSphereMachineConfig)
 			m.ObjectMeta = machineWorker.ObjectMeta
+			m.Spec = machineWorker.Spec
+			return nil
+		},
+	)
+
+	client.EXPECT().Get(ctx, "pool", "default", &anywherev1.VSphereIPPool{}).Return(nil).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			p := obj.(*anywherev1.VSphereIPPool)
+			p.ObjectMeta = pool.ObjectMeta
 			return nil
 		},
 	)
@@ -134,4 +189,6 @@ func TestDefaultConfigClientBuilderVSphereCluster(t *testing.T) {
 	g.Expect(len(config.VSphereMachineConfigs)).To(Equal(2))
 	g.Expect(config.VSphereMachineConfigs["machine-1"]).To(Equal(machineControlPlane))
 	g.Expect(config.VSphereMachineConfigs["machine-2"]).To(Equal(machineWorker))
+	g.Expect(config.VSphereIPPools).To(HaveLen(1))
+	g.Expect(config.VSphereIPPools["pool"]).To(Equal(pool))
 }
@@ -19,7 +19,12 @@ import (
 // the condition is considered false regardless of the status value.
 // total field is to check the total number of times the given condition is met for consistency.
 func WaitForCondition(ctx context.Context, log logr.Logger, client kubernetes.Reader, cluster *anywherev1.Cluster, total int, retrier *retrier.Retrier, conditionType anywherev1.ConditionType) error {
-	return WaitFor(ctx, log, client, cluster, total, retrier, func(c *anywherev1.Cluster) error {
+	return WaitFor(ctx, log, client, cluster, total, retrier, ConditionMatcher(conditionType))
+}
+
+// ConditionMatcher returns a Matcher that succeeds when the cluster has the condition as True.
+func ConditionMatcher(conditionType anywherev1.ConditionType) Matcher {
+	return func(c *anywherev1.Cluster) error {
 		condition := v1beta1conditions.Get(c, conditionType)
 		if condition == nil {
 			return fmt.Errorf("cluster doesn't yet have condition %s", conditionType)
@@ -29,7 +34,7 @@ func WaitForCondition(ctx context.Context, log logr.Logger, client kubernetes.Re
 			return fmt.Errorf("cluster condition %s is %s: %s", conditionType, condition.Status, condition.Message)
 		}
 		return nil
-	})
+	}
 }
 
 // Matcher matches the given condition.
@@ -8,10 +8,13 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/workflow"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
@@ -23,6 +26,7 @@ const (
 	waitForFailureMessageErrorTimeout = 10 * time.Minute
 	defaultFieldManager               = "eks-a-cli"
 	defaultConditionCheckTotalCount   = 20
+	provisioningProgressLogInterval   = 30 * time.Second
 )
 
 // ApplierOpt allows to customize a Applier on construction.
@@ -155,37 +159,96 @@ func (a Applier) Run(ctx context.Context, spec *cluster.Spec, managementCluster
 	}
 
 	a.log.V(3).Info("Waiting for control plane to be ready")
-	if err := cluster.WaitForCondition(ctx, a.log, client, spec.Cluster, a.conditionCheckoutTotalCount, retry, anywherev1.ControlPlaneReadyCondition); err != nil {
+	if err := a.waitForCondition(ctx, client, spec.Cluster, retry, anywherev1.ControlPlaneReadyCondition); err != nil {
 		return errors.Wrapf(err, "waiting for cluster's control plane to be ready")
 	}
 
 	if spec.Cluster.Spec.ClusterNetwork.CNIConfig.IsManaged() {
 		a.log.V(3).Info("Waiting for default CNI to be updated")
 		retry = a.retrierForWait(waitStartTime)
-		if err := cluster.WaitForCondition(ctx, a.log, client, spec.Cluster, a.conditionCheckoutTotalCount, retry, anywherev1.DefaultCNIConfiguredCondition); err != nil {
+		if err := a.waitForCondition(ctx, client, spec.Cluster, retry, anywherev1.DefaultCNIConfiguredCondition); err != nil {
 			return errors.Wrapf(err, "waiting for cluster's CNI to be configured")
 		}
 	}
 
 	a.log.V(3).Info("Waiting for worker nodes to be ready")
 	retry = a.retrierForWait(waitStartTime)
-	if err := cluster.WaitForCondition(ctx, a.log, client, spec.Cluster, a.conditionCheckoutTotalCount, retry, anywherev1.WorkersReadyCondition); err != nil {
+	if err := a.waitForCondition(ctx, client, spec.Cluster, retry, anywherev1.WorkersReadyCondition); err != nil {
 		return errors.Wrapf(err, "waiting for cluster's workers to be ready")
 	}
 
 	a.log.V(3).Info("Waiting for cluster changes to be completed")
 	retry = a.retrierForWait(waitStartTime)
-	if err := cluster.WaitForCondition(ctx, a.log, client, spec.Cluster, a.conditionCheckoutTotalCount, retry, anywherev1.ReadyCondition); err != nil {
+	if err := a.waitForCondition(ctx, client, spec.Cluster, retry, anywherev1.ReadyCondition); err != nil {
 		return errors.Wrapf(err, "waiting for cluster to be ready")
 	}
 
 	return nil
 }
 
+// waitForCondition waits for the cluster to have conditionType as True. While waiting, it reports the
+// progress of the hardware provisioning, if the provider supports it, and aborts as soon as it fails.
+func (a Applier) waitForCondition(ctx context.Context, client kubernetes.Client, c *anywherev1.Cluster, retry *retrier.Retrier, conditionType anywherev1.ConditionType) error {
+	lastProvisioningMessage := ""
+	var lastProvisioningLog time.Time
+	conditionMatcher := cluster.ConditionMatcher(conditionType)
+	return cluster.WaitFor(ctx, a.log, client, c, a.conditionCheckoutTotalCount, retry, func(c *anywherev1.Cluster) error {
+		condition := v1beta1conditions.Get(c, anywherev1.HardwareProvisionedCondition)
+		if condition != nil && condition.Status == corev1.ConditionFalse {
+			if condition.Reason == anywherev1.WorkflowFailedReason {
+				return &hardwareProvisioningError{message: condition.Message}
+			}
+			if condition.Message != lastProvisioningMessage || time.Since(lastProvisioningLog) >= provisioningProgressLogInterval {
+				a.logProvisioningProgress(ctx, client, c, condition.Message)
+				lastProvisioningMessage = condition.Message
+				lastProvisioningLog = time.Now()
+			}
+		}
+
+		return conditionMatcher(c)
+	})
+}
+
+// logProvisioningProgress logs the progress of the hardware provisioning with the action each machine
+// is running and for how long, which the condition message leaves out. If the workflows can't be read,
+// it falls back to the condition message.
+func (a Applier) logProvisioningProgress(ctx context.Context, client kubernetes.Client, c *anywherev1.Cluster, conditionMessage string) {
+	summary, err := workflow.ForCluster(ctx, client, c.Name, time.Now())
+	if err != nil {
+		a.log.V(4).Info("Failed reading provisioning workflows", "error", err)
+		a.log.Info("Provisioning hardware", "progress", conditionMessage)
+		return
+	}
+
+	if details := summary.ProgressDetails(); details != "" {
+		a.log.Info("Provisioning hardware", "progress", fmt.Sprintf("%d/%d machines provisioned", summary.Succeeded(), len(summary.Machines)), "machines", details)
+		return
+	}
+
+	a.log.Info("Provisioning hardware", "progress", conditionMessage)
+}
+
+// hardwareProvisioningError is returned when provisioning the hardware of the cluster fails.
+// It's not transient so waits stop retrying as soon as they get it.
+type hardwareProvisioningError struct {
+	message string
+}
+
+func (e *hardwareProvisioningError) Error() string {
+	return fmt.Sprintf("hardware provisioning failed: %s", e.message)
+}
+
 func (a Applier) retrierForWait(waitStartTime time.Time) *retrier.Retrier {
+	backOffPolicy := retrier.BackOffPolicy(a.retryBackOff)
 	return retrier.New(
 		a.waitForClusterReconcile-time.Since(waitStartTime),
-		retrier.WithRetryPolicy(retrier.BackOffPolicy(a.retryBackOff)),
+		retrier.WithRetryPolicy(func(totalRetries int, err error) (retry bool, wait time.Duration) {
+			var provisioningErr *hardwareProvisioningError
+			if errors.As(err, &provisioningErr) {
+				return false, 0
+			}
+			return backOffPolicy(totalRetries, err)
+		}),
 	)
 }
 
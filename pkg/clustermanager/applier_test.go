@@ -7,18 +7,24 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	tinkv1alpha1 "github.com/tinkerbell/tink/api/v1alpha1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apitypes "k8s.io/apimachinery/pkg/types"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	tinkerbellv1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/capt/v1beta1"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermanager"
 	"github.com/aws/eks-anywhere/pkg/clustermanager/mocks"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
@@ -289,3 +295,81 @@ func TestApplierRunClusterNotReady(t *testing.T) {
 
 	tt.Expect(a.Run(tt.ctx, tt.spec, tt.mgmtCluster)).To(MatchError(ContainSubstring("waiting for cluster to be ready")))
 }
+
+func TestApplierRunHardwareProvisioningFailed(t *testing.T) {
+	tt := newApplierTest(t)
+	tt.buildClient(tt.spec.ClusterAndChildren()...)
+	tt.markConditionWithJSONPatch([]byte(`[
+		{"op":"add","path":"/status/conditions","value":[]},
+		{"op":"add","path":"/status/conditions/-","value":{"type":"HardwareProvisioned","status":"False","severity":"Error","reason":"WorkflowFailed","message":"provisioning workflow failed for cp-1: action stream-image failed after 30s"}}
+	]`))
+	a := clustermanager.NewApplier(tt.log, tt.clientFactory,
+		clustermanager.WithApplierRetryBackOff(time.Millisecond),
+		clustermanager.WithApplierNoTimeouts(),
+	)
+
+	tt.Expect(a.Run(tt.ctx, tt.spec, tt.mgmtCluster)).To(MatchError(ContainSubstring(
+		"waiting for cluster's control plane to be ready: hardware provisioning failed: provisioning workflow failed for cp-1: action stream-image failed after 30s",
+	)))
+}
+
+func TestApplierRunHardwareProvisioningInProgress(t *testing.T) {
+	tt := newApplierTest(t)
+	tt.buildClient(tt.spec.ClusterAndChildren()...)
+	tt.markConditionWithJSONPatch([]byte(`[
+		{"op":"add","path":"/status/conditions","value":[]},
+		{"op":"add","path":"/status/conditions/-","value":{"type":"HardwareProvisioned","status":"False","severity":"Info","reason":"WorkflowsInProgress","message":"0/1 machines provisioned"}}
+	]`))
+	a := clustermanager.NewApplier(tt.log, tt.clientFactory,
+		clustermanager.WithApplierWaitForClusterReconcile(0),
+		clustermanager.WithApplierWaitForFailureMessage(0),
+	)
+
+	tt.Expect(a.Run(tt.ctx, tt.spec, tt.mgmtCluster)).To(MatchError(ContainSubstring("waiting for cluster's control plane to be ready")))
+}
+
+func TestApplierRunHardwareProvisioningInProgressLogsMachineDetails(t *testing.T) {
+	tt := newApplierTest(t)
+	machine := &tinkerbellv1.TinkerbellMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cp-1",
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1beta2.ClusterNameLabel: tt.spec.Cluster.Name},
+		},
+		Spec: tinkerbellv1.TinkerbellMachineSpec{HardwareName: "hw-1"},
+	}
+	wf := &tinkv1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "cp-1", Namespace: constants.EksaSystemNamespace},
+		Status: tinkv1alpha1.WorkflowStatus{
+			State: tinkv1alpha1.WorkflowStateRunning,
+			Tasks: []tinkv1alpha1.Task{{
+				Name: "os-installation",
+				Actions: []tinkv1alpha1.Action{{
+					Name:      "stream-image",
+					Status:    tinkv1alpha1.WorkflowStateRunning,
+					StartedAt: &metav1.Time{Time: time.Now().Add(-2 * time.Minute)},
+				}},
+			}},
+		},
+	}
+	tt.buildClient(append(tt.spec.ClusterAndChildren(), machine, wf)...)
+	tt.markConditionWithJSONPatch([]byte(`[
+		{"op":"add","path":"/status/conditions","value":[]},
+		{"op":"add","path":"/status/conditions/-","value":{"type":"HardwareProvisioned","status":"False","severity":"Info","reason":"WorkflowsInProgress","message":"0/1 machines provisioned; cp-1 (hardware hw-1): running action stream-image"}}
+	]`))
+	var logs []string
+	tt.log = funcr.New(func(prefix, args string) {
+		logs = append(logs, args)
+	}, funcr.Options{})
+	a := clustermanager.NewApplier(tt.log, tt.clientFactory,
+		clustermanager.WithApplierWaitForClusterReconcile(0),
+		clustermanager.WithApplierWaitForFailureMessage(0),
+	)
+
+	tt.Expect(a.Run(tt.ctx, tt.spec, tt.mgmtCluster)).To(MatchError(ContainSubstring("waiting for cluster's control plane to be ready")))
+	tt.Expect(logs).To(ContainElement(And(
+		ContainSubstring(`"progress"="0/1 machines provisioned"`),
+		// The start time of the action is stored with a precision of seconds.
+		MatchRegexp(`"machines"="cp-1 \(hardware hw-1\): running action stream-image for 2m[0-9]s"`),
+	)))
+}
@@ -15,6 +15,13 @@ type ProviderClusterReconciler interface {
 	Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error)
 }
 
+// ProviderClusterDeleteReconciler is implemented by provider cluster reconcilers that clean up
+// provider resources outside of CAPI once the CAPI cluster has been deleted.
+type ProviderClusterDeleteReconciler interface {
+	// ReconcileDelete deletes the provider resources owned by the cluster.
+	ReconcileDelete(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error
+}
+
 // ProviderClusterReconcilerRegistry holds a collection of cluster provider reconcilers
 // and ties them to different provider Datacenter kinds.
 type ProviderClusterReconcilerRegistry struct {
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/credentials/rotate.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	kubernetes "github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	cluster "github.com/aws/eks-anywhere/pkg/cluster"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProviderRotator is a mock of ProviderRotator interface.
+type MockProviderRotator struct {
+	ctrl     *gomock.Controller
+	recorder *MockProviderRotatorMockRecorder
+}
+
+// MockProviderRotatorMockRecorder is the mock recorder for MockProviderRotator.
+type MockProviderRotatorMockRecorder struct {
+	mock *MockProviderRotator
+}
+
+// NewMockProviderRotator creates a new mock instance.
+func NewMockProviderRotator(ctrl *gomock.Controller) *MockProviderRotator {
+	mock := &MockProviderRotator{ctrl: ctrl}
+	mock.recorder = &MockProviderRotatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProviderRotator) EXPECT() *MockProviderRotatorMockRecorder {
+	return m.recorder
+}
+
+// UpdateClusterSecrets mocks base method.
+func (m *MockProviderRotator) UpdateClusterSecrets(ctx context.Context, client kubernetes.Client, spec *cluster.Spec, cluster *v1alpha1.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateClusterSecrets", ctx, client, spec, cluster)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateClusterSecrets indicates an expected call of UpdateClusterSecrets.
+func (mr *MockProviderRotatorMockRecorder) UpdateClusterSecrets(ctx, client, spec, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClusterSecrets", reflect.TypeOf((*MockProviderRotator)(nil).UpdateClusterSecrets), ctx, client, spec, cluster)
+}
+
+// UpdateSecrets mocks base method.
+func (m *MockProviderRotator) UpdateSecrets(ctx context.Context, client kubernetes.Client, spec *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecrets", ctx, client, spec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecrets indicates an expected call of UpdateSecrets.
+func (mr *MockProviderRotatorMockRecorder) UpdateSecrets(ctx, client, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecrets", reflect.TypeOf((*MockProviderRotator)(nil).UpdateSecrets), ctx, client, spec)
+}
+
+// UsesCredentials mocks base method.
+func (m *MockProviderRotator) UsesCredentials(ctx context.Context, client kubernetes.Client, spec *cluster.Spec, cluster *v1alpha1.Cluster) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsesCredentials", ctx, client, spec, cluster)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsesCredentials indicates an expected call of UsesCredentials.
+func (mr *MockProviderRotatorMockRecorder) UsesCredentials(ctx, client, spec, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsesCredentials", reflect.TypeOf((*MockProviderRotator)(nil).UsesCredentials), ctx, client, spec, cluster)
+}
+
+// ValidateCredentials mocks base method.
+func (m *MockProviderRotator) ValidateCredentials(ctx context.Context, spec *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateCredentials", ctx, spec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateCredentials indicates an expected call of ValidateCredentials.
+func (mr *MockProviderRotatorMockRecorder) ValidateCredentials(ctx, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCredentials", reflect.TypeOf((*MockProviderRotator)(nil).ValidateCredentials), ctx, spec)
+}
+
+// VerifyClusterCredentials mocks base method.
+func (m *MockProviderRotator) VerifyClusterCredentials(ctx context.Context, client kubernetes.Client, spec *cluster.Spec, cluster *v1alpha1.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyClusterCredentials", ctx, client, spec, cluster)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyClusterCredentials indicates an expected call of VerifyClusterCredentials.
+func (mr *MockProviderRotatorMockRecorder) VerifyClusterCredentials(ctx, client, spec, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyClusterCredentials", reflect.TypeOf((*MockProviderRotator)(nil).VerifyClusterCredentials), ctx, client, spec, cluster)
+}
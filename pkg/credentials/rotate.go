@@ -0,0 +1,188 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+const (
+	defaultReconnectTimeout = 10 * time.Minute
+	reconnectBackoff        = 10 * time.Second
+)
+
+// ProviderRotator updates the secrets holding the infrastructure credentials of a provider.
+// The new credentials are read from the same environment variables used to create clusters.
+type ProviderRotator interface {
+	// ValidateCredentials checks the new credentials can be used with the infrastructure of the management cluster.
+	ValidateCredentials(ctx context.Context, spec *cluster.Spec) error
+	// UpdateSecrets updates the secrets the EKS Anywhere controller reads the credentials from in the management cluster.
+	UpdateSecrets(ctx context.Context, client kubernetes.Client, spec *cluster.Spec) error
+	// UsesCredentials returns true if a cluster, the management cluster or one of its workload clusters,
+	// uses the credentials being rotated.
+	UsesCredentials(ctx context.Context, client kubernetes.Client, spec *cluster.Spec, cluster *anywherev1.Cluster) (bool, error)
+	// UpdateClusterSecrets updates the secrets of a cluster the provider controllers read the credentials from.
+	UpdateClusterSecrets(ctx context.Context, client kubernetes.Client, spec *cluster.Spec, cluster *anywherev1.Cluster) error
+	// VerifyClusterCredentials makes a call to the infrastructure API with the credentials stored in the secrets
+	// of a cluster, the same ones the provider controllers read.
+	VerifyClusterCredentials(ctx context.Context, client kubernetes.Client, spec *cluster.Spec, cluster *anywherev1.Cluster) error
+}
+
+// Rotator rotates the infrastructure credentials of a management cluster and all its workload clusters.
+type Rotator struct {
+	provider ProviderRotator
+	client   kubernetes.Client
+	retrier  *retrier.Retrier
+}
+
+// RotatorOpt allows to customize a Rotator on construction.
+type RotatorOpt func(*Rotator)
+
+// WithReconnectTimeout sets the maximum time to wait for the provider controllers to reconnect with the new credentials.
+func WithReconnectTimeout(timeout time.Duration) RotatorOpt {
+	return func(r *Rotator) {
+		r.retrier = retrier.New(timeout, retrier.WithRetryPolicy(retrier.BackOffPolicy(reconnectBackoff)))
+	}
+}
+
+// NewRotator builds a Rotator for the management cluster the client connects to.
+func NewRotator(provider ProviderRotator, client kubernetes.Client, opts ...RotatorOpt) *Rotator {
+	r := &Rotator{
+		provider: provider,
+		client:   client,
+		retrier:  retrier.New(defaultReconnectTimeout, retrier.WithRetryPolicy(retrier.BackOffPolicy(reconnectBackoff))),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Rotate validates the new credentials, updates the secrets of the management cluster and of every cluster
+// using the credentials, verifies the infrastructure API accepts the credentials stored in those secrets and
+// waits until the infrastructure of those clusters is ready.
+func (r *Rotator) Rotate(ctx context.Context, spec *cluster.Spec) error {
+	if !spec.Cluster.IsSelfManaged() {
+		return fmt.Errorf("credentials can only be rotated with the config of the management cluster, cluster %s is managed by %s", spec.Cluster.Name, spec.Cluster.ManagedBy())
+	}
+
+	if err := r.provider.ValidateCredentials(ctx, spec); err != nil {
+		return fmt.Errorf("validating new credentials: %v", err)
+	}
+	logger.MarkPass("New credentials validated")
+
+	clusters, err := r.clustersUsingCredentials(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	if err := r.provider.UpdateSecrets(ctx, r.client, spec); err != nil {
+		return fmt.Errorf("updating management cluster secrets: %v", err)
+	}
+
+	for _, c := range clusters {
+		logger.Info("Updating credentials", "cluster", c.Name)
+		if err := r.provider.UpdateClusterSecrets(ctx, r.client, spec, c); err != nil {
+			return fmt.Errorf("updating secrets of cluster %s: %v", c.Name, err)
+		}
+	}
+
+	for _, c := range clusters {
+		logger.Info("Verifying the credentials stored for the cluster", "cluster", c.Name)
+		if err := r.retrier.Retry(func() error { return r.provider.VerifyClusterCredentials(ctx, r.client, spec, c) }); err != nil {
+			return fmt.Errorf("verifying credentials of cluster %s: %v", c.Name, err)
+		}
+	}
+	logger.MarkPass("New credentials verified")
+
+	// If the previous credentials had already expired, the provider controllers couldn't reconcile the
+	// infrastructure, so wait for them to recover with the new ones.
+	for _, c := range clusters {
+		logger.Info("Waiting for the infrastructure to be ready with the new credentials", "cluster", c.Name)
+		if err := r.retrier.Retry(func() error { return r.infrastructureReady(ctx, c.Name) }); err != nil {
+			return fmt.Errorf("waiting for the infrastructure of cluster %s to be ready: %v", c.Name, err)
+		}
+	}
+	logger.MarkSuccess("Credentials rotated", "clusters", len(clusters))
+
+	return nil
+}
+
+func (r *Rotator) clustersUsingCredentials(ctx context.Context, spec *cluster.Spec) ([]*anywherev1.Cluster, error) {
+	list := &anywherev1.ClusterList{}
+	if err := r.client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("listing clusters: %v", err)
+	}
+
+	managementCluster := spec.Cluster.Name
+	datacenterKind := spec.Cluster.Spec.DatacenterRef.Kind
+
+	clusters := []*anywherev1.Cluster{}
+	for i := range list.Items {
+		c := &list.Items[i]
+		if c.Name != managementCluster && c.ManagedBy() != managementCluster {
+			continue
+		}
+		if c.Spec.DatacenterRef.Kind != datacenterKind {
+			continue
+		}
+		uses, err := r.provider.UsesCredentials(ctx, r.client, spec, c)
+		if err != nil {
+			return nil, fmt.Errorf("checking credentials of cluster %s: %v", c.Name, err)
+		}
+		if uses {
+			clusters = append(clusters, c)
+		}
+	}
+
+	return clusters, nil
+}
+
+func (r *Rotator) infrastructureReady(ctx context.Context, clusterName string) error {
+	capiCluster := &clusterv1beta2.Cluster{}
+	if err := r.client.Get(ctx, clusterName, constants.EksaSystemNamespace, capiCluster); err != nil {
+		return err
+	}
+
+	if !conditions.IsTrue(capiCluster, clusterv1beta2.ClusterInfrastructureReadyCondition) {
+		return fmt.Errorf("condition %s is not true", clusterv1beta2.ClusterInfrastructureReadyCondition)
+	}
+
+	return nil
+}
+
+// UpdateSecretData sets the keys of data in an existing secret, keeping its other keys and its metadata,
+// including the owner references and finalizers the provider controllers add to the secrets they read.
+// The last applied configuration annotation is removed since it holds the previous credentials.
+func UpdateSecretData(ctx context.Context, client kubernetes.Client, name, namespace string, data map[string][]byte) error {
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, name, namespace, secret); err != nil {
+		return fmt.Errorf("getting secret %s: %v", name, err)
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte, len(data))
+	}
+	for k, v := range data {
+		secret.Data[k] = v
+	}
+	delete(secret.Annotations, corev1.LastAppliedConfigAnnotation)
+
+	if err := client.Update(ctx, secret); err != nil {
+		return fmt.Errorf("updating secret %s: %v", name, err)
+	}
+
+	return nil
+}
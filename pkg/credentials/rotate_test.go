@@ -0,0 +1,213 @@
+package credentials_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/credentials"
+	"github.com/aws/eks-anywhere/pkg/credentials/mocks"
+)
+
+type rotatorTest struct {
+	*WithT
+	ctx      context.Context
+	provider *mocks.MockProviderRotator
+	spec     *cluster.Spec
+}
+
+func newRotatorTest(t *testing.T) *rotatorTest {
+	ctrl := gomock.NewController(t)
+	return &rotatorTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		provider: mocks.NewMockProviderRotator(ctrl),
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster = rotatorTestCluster("mgmt", "")
+		}),
+	}
+}
+
+func rotatorTestCluster(name, managedBy string) *anywherev1.Cluster {
+	c := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			DatacenterRef: anywherev1.Ref{
+				Kind: anywherev1.VSphereDatacenterKind,
+				Name: name,
+			},
+		},
+	}
+	c.Spec.ManagementCluster.Name = managedBy
+	if managedBy == "" {
+		c.Spec.ManagementCluster.Name = name
+	}
+	return c
+}
+
+func capiCluster(name string, ready bool) *clusterv1beta2.Cluster {
+	status := metav1.ConditionFalse
+	if ready {
+		status = metav1.ConditionTrue
+	}
+	return &clusterv1beta2.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Status: clusterv1beta2.ClusterStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:   clusterv1beta2.ClusterInfrastructureReadyCondition,
+					Status: status,
+				},
+			},
+		},
+	}
+}
+
+func TestRotatorRotate(t *testing.T) {
+	tt := newRotatorTest(t)
+	workload := rotatorTestCluster("workload", "mgmt")
+	otherServer := rotatorTestCluster("other-server", "mgmt")
+	otherManagement := rotatorTestCluster("other-mgmt-workload", "other-mgmt")
+	otherProvider := rotatorTestCluster("docker", "mgmt")
+	otherProvider.Spec.DatacenterRef.Kind = anywherev1.DockerDatacenterKind
+	client := test.NewFakeKubeClient(
+		tt.spec.Cluster.DeepCopy(), workload, otherServer, otherManagement, otherProvider,
+		capiCluster("mgmt", true), capiCluster("workload", true),
+	)
+
+	tt.provider.EXPECT().ValidateCredentials(tt.ctx, tt.spec)
+	tt.provider.EXPECT().UsesCredentials(tt.ctx, client, tt.spec, clusterNamed("mgmt")).Return(true, nil)
+	tt.provider.EXPECT().UsesCredentials(tt.ctx, client, tt.spec, clusterNamed("workload")).Return(true, nil)
+	tt.provider.EXPECT().UsesCredentials(tt.ctx, client, tt.spec, clusterNamed("other-server")).Return(false, nil)
+	tt.provider.EXPECT().UpdateSecrets(tt.ctx, client, tt.spec)
+	tt.provider.EXPECT().UpdateClusterSecrets(tt.ctx, client, tt.spec, clusterNamed("mgmt"))
+	tt.provider.EXPECT().UpdateClusterSecrets(tt.ctx, client, tt.spec, clusterNamed("workload"))
+	tt.provider.EXPECT().VerifyClusterCredentials(tt.ctx, client, tt.spec, clusterNamed("mgmt"))
+	tt.provider.EXPECT().VerifyClusterCredentials(tt.ctx, client, tt.spec, clusterNamed("workload"))
+
+	r := credentials.NewRotator(tt.provider, client)
+	tt.Expect(r.Rotate(tt.ctx, tt.spec)).To(Succeed())
+}
+
+func TestRotatorRotateWorkloadClusterConfig(t *testing.T) {
+	tt := newRotatorTest(t)
+	tt.spec.Cluster.Spec.ManagementCluster.Name = "other"
+
+	r := credentials.NewRotator(tt.provider, test.NewFakeKubeClient())
+	tt.Expect(r.Rotate(tt.ctx, tt.spec)).To(MatchError("credentials can only be rotated with the config of the management cluster, cluster mgmt is managed by other"))
+}
+
+func TestRotatorRotateInvalidCredentials(t *testing.T) {
+	tt := newRotatorTest(t)
+	tt.provider.EXPECT().ValidateCredentials(tt.ctx, tt.spec).Return(errors.New("unauthorized"))
+
+	r := credentials.NewRotator(tt.provider, test.NewFakeKubeClient())
+	tt.Expect(r.Rotate(tt.ctx, tt.spec)).To(MatchError("validating new credentials: unauthorized"))
+}
+
+func TestRotatorRotateUpdateClusterSecretsError(t *testing.T) {
+	tt := newRotatorTest(t)
+	client := test.NewFakeKubeClient(tt.spec.Cluster.DeepCopy())
+
+	tt.provider.EXPECT().ValidateCredentials(tt.ctx, tt.spec)
+	tt.provider.EXPECT().UsesCredentials(tt.ctx, client, tt.spec, gomock.Any()).Return(true, nil)
+	tt.provider.EXPECT().UpdateSecrets(tt.ctx, client, tt.spec)
+	tt.provider.EXPECT().UpdateClusterSecrets(tt.ctx, client, tt.spec, gomock.Any()).Return(errors.New("forbidden"))
+
+	r := credentials.NewRotator(tt.provider, client)
+	tt.Expect(r.Rotate(tt.ctx, tt.spec)).To(MatchError("updating secrets of cluster mgmt: forbidden"))
+}
+
+func TestRotatorRotateStoredCredentialsRejected(t *testing.T) {
+	tt := newRotatorTest(t)
+	client := test.NewFakeKubeClient(tt.spec.Cluster.DeepCopy(), capiCluster("mgmt", true))
+
+	tt.provider.EXPECT().ValidateCredentials(tt.ctx, tt.spec)
+	tt.provider.EXPECT().UsesCredentials(tt.ctx, client, tt.spec, gomock.Any()).Return(true, nil)
+	tt.provider.EXPECT().UpdateSecrets(tt.ctx, client, tt.spec)
+	tt.provider.EXPECT().UpdateClusterSecrets(tt.ctx, client, tt.spec, gomock.Any())
+	tt.provider.EXPECT().VerifyClusterCredentials(tt.ctx, client, tt.spec, gomock.Any()).Return(errors.New("unauthorized")).AnyTimes()
+
+	r := credentials.NewRotator(tt.provider, client, credentials.WithReconnectTimeout(time.Millisecond))
+	tt.Expect(r.Rotate(tt.ctx, tt.spec)).To(MatchError(ContainSubstring("verifying credentials of cluster mgmt: unauthorized")))
+}
+
+func TestRotatorRotateInfrastructureNotReady(t *testing.T) {
+	tt := newRotatorTest(t)
+	client := test.NewFakeKubeClient(tt.spec.Cluster.DeepCopy(), capiCluster("mgmt", false))
+
+	tt.provider.EXPECT().ValidateCredentials(tt.ctx, tt.spec)
+	tt.provider.EXPECT().UsesCredentials(tt.ctx, client, tt.spec, gomock.Any()).Return(true, nil)
+	tt.provider.EXPECT().UpdateSecrets(tt.ctx, client, tt.spec)
+	tt.provider.EXPECT().UpdateClusterSecrets(tt.ctx, client, tt.spec, gomock.Any())
+	tt.provider.EXPECT().VerifyClusterCredentials(tt.ctx, client, tt.spec, gomock.Any())
+
+	r := credentials.NewRotator(tt.provider, client, credentials.WithReconnectTimeout(time.Millisecond))
+	tt.Expect(r.Rotate(tt.ctx, tt.spec)).To(MatchError(ContainSubstring("waiting for the infrastructure of cluster mgmt to be ready")))
+}
+
+func TestUpdateSecretData(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "creds",
+			Namespace:  constants.EksaSystemNamespace,
+			Finalizers: []string{"infrastructure.cluster.x-k8s.io/secret"},
+			Annotations: map[string]string{
+				corev1.LastAppliedConfigAnnotation: `{"data":{"password":"b2xk"}}`,
+			},
+		},
+		Data: map[string][]byte{"password": []byte("old"), "extra": []byte("value")},
+	}
+	c := test.NewFakeKubeClient(secret)
+
+	g.Expect(credentials.UpdateSecretData(ctx, c, "creds", constants.EksaSystemNamespace, map[string][]byte{"password": []byte("new")})).To(Succeed())
+
+	got := &corev1.Secret{}
+	g.Expect(c.Get(ctx, "creds", constants.EksaSystemNamespace, got)).To(Succeed())
+	g.Expect(got.Data).To(Equal(map[string][]byte{"password": []byte("new"), "extra": []byte("value")}))
+	g.Expect(got.Finalizers).To(Equal(secret.Finalizers))
+	g.Expect(got.Annotations).NotTo(HaveKey(corev1.LastAppliedConfigAnnotation))
+}
+
+func TestUpdateSecretDataNotFound(t *testing.T) {
+	g := NewWithT(t)
+	c := test.NewFakeKubeClient()
+
+	err := credentials.UpdateSecretData(context.Background(), c, "creds", constants.EksaSystemNamespace, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("getting secret creds")))
+}
+
+type clusterNameMatcher string
+
+func clusterNamed(name string) gomock.Matcher {
+	return clusterNameMatcher(name)
+}
+
+func (m clusterNameMatcher) Matches(x interface{}) bool {
+	c, ok := x.(client.Object)
+	return ok && c.GetName() == string(m)
+}
+
+func (m clusterNameMatcher) String() string {
+	return "is cluster " + string(m)
+}
@@ -25,7 +25,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
 	"github.com/aws/eks-anywhere/pkg/eksd"
 	"github.com/aws/eks-anywhere/pkg/executables"
-	"github.com/aws/eks-anywhere/pkg/executables/cmk"
+	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	gitfactory "github.com/aws/eks-anywhere/pkg/git/factory"
@@ -40,6 +40,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/apiclient"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/providers/docker"
 	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
@@ -51,6 +52,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/version"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
@@ -62,6 +64,7 @@ type Dependencies struct {
 	DockerClient                *executables.Docker
 	Kubectl                     *executables.Kubectl
 	Govc                        *executables.Govc
+	VSphereProviderClient       VSphereClient
 	CloudStackValidatorRegistry cloudstack.ValidatorRegistry
 	SnowAwsClientRegistry       *snow.AwsClientRegistry
 	SnowConfigManager           *snow.ConfigManager
@@ -97,6 +100,7 @@ type Dependencies struct {
 	PackageClient               curatedpackages.PackageHandler
 	VSphereValidator            *vsphere.Validator
 	VSphereDefaulter            *vsphere.Defaulter
+	VSphereAntiAffinity         *vsphere.AntiAffinity
 	NutanixClientCache          *nutanix.ClientCache
 	NutanixDefaulter            *nutanix.Defaulter
 	NutanixValidator            *nutanix.Validator
@@ -426,7 +430,7 @@ type TinkerbellOptions struct {
 func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1.Cluster, skipIPCheck bool, hardwareCSVPath string, force bool, tinkerbellBootstrapIP string, skippedValidations map[string]bool, opts *ProviderOptions) *Factory { // nolint:gocyclo
 	switch clusterConfig.Spec.DatacenterRef.Kind {
 	case v1alpha1.VSphereDatacenterKind:
-		f.WithKubectl().WithGovc().WithWriter().WithIPValidator()
+		f.WithKubectl().WithVSphereProviderClient().WithWriter().WithIPValidator()
 	case v1alpha1.CloudStackDatacenterKind:
 		f.WithKubectl().WithCloudStackValidatorRegistry(skipIPCheck).WithWriter()
 	case v1alpha1.DockerDatacenterKind:
@@ -458,7 +462,7 @@ func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1
 			f.dependencies.Provider = vsphere.NewProvider(
 				datacenterConfig,
 				clusterConfig,
-				f.dependencies.Govc,
+				f.dependencies.VSphereProviderClient,
 				f.dependencies.Kubectl,
 				f.dependencies.Writer,
 				f.dependencies.IPValidator,
@@ -540,6 +544,9 @@ func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1
 			if opts != nil && opts.Tinkerbell != nil && opts.Tinkerbell.BMCOptions != nil {
 				provider.BMCOptions = opts.Tinkerbell.BMCOptions
 			}
+			if !skippedValidations[validations.TinkerbellBMCHealth] {
+				provider.BMCInspectorFactory = tinkerbell.NewBMCInspector
+			}
 
 			f.dependencies.Provider = provider
 
@@ -682,17 +689,52 @@ func (f *Factory) WithGovc() *Factory {
 	return f
 }
 
+// VSphereClient is the client the vSphere provider and the template and cleanup commands use to talk to vCenter.
+type VSphereClient interface {
+	vsphere.TemplateManagerGovcClient
+	vsphere.CleanupGovcClient
+}
+
+// WithVSphereProviderClient initializes the client used by the vSphere provider to talk to vCenter.
+// It's the native govmomi client unless the govc feature flag is enabled, in which case it falls
+// back to govc.
+func (f *Factory) WithVSphereProviderClient() *Factory {
+	useGovc := features.IsActive(features.VSphereGovcClientEnabled())
+	if useGovc {
+		f.WithGovc()
+	}
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.VSphereProviderClient != nil {
+			return nil
+		}
+
+		if useGovc {
+			f.dependencies.VSphereProviderClient = f.dependencies.Govc
+			return nil
+		}
+
+		client := govmomi.NewProviderClient()
+		f.dependencies.VSphereProviderClient = client
+		f.dependencies.closers = append(f.dependencies.closers, client)
+
+		return nil
+	})
+
+	return f
+}
+
 // WithCloudStackValidatorRegistry initializes the CloudStack validator for the object being constructed to make it available in the constructor.
 func (f *Factory) WithCloudStackValidatorRegistry(skipIPCheck bool) *Factory {
-	f.WithExecutableBuilder().WithWriter()
+	f.WithWriter()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.CloudStackValidatorRegistry != nil {
 			return nil
 		}
 
-		cmkBuilder := cmk.NewCmkBuilder(f.executablesConfig.builder)
-		f.dependencies.CloudStackValidatorRegistry = cloudstack.NewValidatorFactory(cmkBuilder, f.dependencies.Writer, skipIPCheck)
+		clientBuilder := apiclient.NewBuilder()
+		f.dependencies.CloudStackValidatorRegistry = cloudstack.NewValidatorFactory(clientBuilder, f.dependencies.Writer, skipIPCheck)
 
 		return nil
 	})
@@ -1580,7 +1622,7 @@ func (f *Factory) WithUnAuthKubeClient() *Factory {
 }
 
 func (f *Factory) WithVSphereValidator() *Factory {
-	f.WithGovc()
+	f.WithVSphereProviderClient()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.VSphereValidator != nil {
@@ -1588,7 +1630,7 @@ func (f *Factory) WithVSphereValidator() *Factory {
 		}
 		vcb := govmomi.NewVMOMIClientBuilder()
 		v := vsphere.NewValidator(
-			f.dependencies.Govc,
+			f.dependencies.VSphereProviderClient,
 			vcb,
 		)
 		f.dependencies.VSphereValidator = v
@@ -1600,14 +1642,31 @@ func (f *Factory) WithVSphereValidator() *Factory {
 }
 
 func (f *Factory) WithVSphereDefaulter() *Factory {
-	f.WithGovc()
+	f.WithVSphereProviderClient()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.VSphereDefaulter != nil {
 			return nil
 		}
 
-		f.dependencies.VSphereDefaulter = vsphere.NewDefaulter(f.dependencies.Govc)
+		f.dependencies.VSphereDefaulter = vsphere.NewDefaulter(f.dependencies.VSphereProviderClient)
+
+		return nil
+	})
+
+	return f
+}
+
+// WithVSphereAntiAffinity adds a new vsphere.AntiAffinity to the factory.
+func (f *Factory) WithVSphereAntiAffinity() *Factory {
+	f.WithVSphereProviderClient()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.VSphereAntiAffinity != nil {
+			return nil
+		}
+
+		f.dependencies.VSphereAntiAffinity = vsphere.NewAntiAffinity(f.dependencies.VSphereProviderClient)
 
 		return nil
 	})
@@ -16,6 +16,8 @@ import (
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/govmomi"
 	"github.com/aws/eks-anywhere/pkg/helm"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
@@ -676,6 +678,33 @@ func TestFactoryBuildWithExecutablesUsingDocker(t *testing.T) {
 	tt.Expect(deps.Helm).NotTo(BeNil())
 }
 
+func TestFactoryBuildWithVSphereProviderClientGovmomi(t *testing.T) {
+	tt := newTest(t, vsphere)
+	deps, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		WithVSphereProviderClient().
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.VSphereProviderClient).To(BeAssignableToTypeOf(&govmomi.ProviderClient{}))
+	tt.Expect(deps.Govc).To(BeNil())
+}
+
+func TestFactoryBuildWithVSphereProviderClientGovc(t *testing.T) {
+	tt := newTest(t, vsphere)
+	t.Setenv(features.VSphereGovcClientEnvVar, "true")
+	features.ClearCache()
+	t.Cleanup(features.ClearCache)
+
+	deps, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		WithVSphereProviderClient().
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.VSphereProviderClient).To(Equal(deps.Govc))
+}
+
 func TestFactoryBuildWithClusterApplierNoTimeout(t *testing.T) {
 	tt := newTest(t, vsphere)
 	deps, err := dependencies.NewFactory().
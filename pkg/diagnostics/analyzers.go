@@ -167,6 +167,8 @@ func (a *analyzerFactory) DataCenterConfigAnalyzers(datacenter v1alpha1.Ref) []*
 		return a.eksaSnowAnalyzers()
 	case v1alpha1.NutanixDatacenterKind:
 		return a.eksaNutanixAnalyzers()
+	case v1alpha1.TinkerbellDatacenterKind:
+		return a.eksaTinkerbellAnalyzers()
 	default:
 		return nil
 	}
@@ -229,6 +231,83 @@ func (a *analyzerFactory) eksaNutanixAnalyzers() []*Analyze {
 	return append(analyzers, a.validControlPlaneIPAnalyzer())
 }
 
+func (a *analyzerFactory) eksaTinkerbellAnalyzers() []*Analyze {
+	var analyzers []*Analyze
+	crds := []string{
+		fmt.Sprintf("tinkerbelldatacenterconfigs.%s", v1alpha1.GroupVersion.Group),
+		fmt.Sprintf("tinkerbellmachineconfigs.%s", v1alpha1.GroupVersion.Group),
+		"hardware.tinkerbell.org",
+		"workflows.tinkerbell.org",
+		"machines.bmc.tinkerbell.org",
+	}
+	deployments := []eksaDeployment{
+		{
+			Name:             "tinkerbell",
+			Namespace:        constants.EksaSystemNamespace,
+			ExpectedReplicas: 1,
+		},
+	}
+	analyzers = append(analyzers, a.generateCrdAnalyzers(crds)...)
+	analyzers = append(analyzers, a.generateDeploymentAnalyzers(deployments)...)
+	return append(analyzers, a.tinkerbellStackPortsAnalyzer(), a.tinkerbellWorkflowsAnalyzer())
+}
+
+// tinkerbellStackPortsAnalyzer analyzes whether the Tinkerbell stack answers on all its ports of the Tinkerbell IP.
+func (a *analyzerFactory) tinkerbellStackPortsAnalyzer() *Analyze {
+	logPath := path.Join(tinkerbellStackPortsCollectorName, tinkerbellStackPortsCollectorName+".log")
+	return &Analyze{
+		TextAnalyze: &textAnalyze{
+			analyzeMeta: analyzeMeta{
+				CheckName: fmt.Sprintf("%s: Tinkerbell stack ports unreachable. Log: %s", logAnalysisAnalyzerPrefix, logPath),
+			},
+			FileName:     logPath,
+			RegexPattern: `port \d+ closed`,
+			Outcomes: []*outcome{
+				{
+					Fail: &singleOutcome{
+						When:    "true",
+						Message: fmt.Sprintf("The Tinkerbell stack isn't answering on all its ports of the Tinkerbell IP. See %s", logPath),
+					},
+				},
+				{
+					Pass: &singleOutcome{
+						When:    "false",
+						Message: "Tinkerbell stack ports are reachable.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// tinkerbellWorkflowsAnalyzer analyzes whether any Tinkerbell workflow failed or timed out.
+func (a *analyzerFactory) tinkerbellWorkflowsAnalyzer() *Analyze {
+	logPath := path.Join(tinkerbellWorkflowsCollectorName, tinkerbellWorkflowsCollectorName+".log")
+	return &Analyze{
+		TextAnalyze: &textAnalyze{
+			analyzeMeta: analyzeMeta{
+				CheckName: fmt.Sprintf("%s: Tinkerbell workflow failed. Log: %s", logAnalysisAnalyzerPrefix, logPath),
+			},
+			FileName:     logPath,
+			RegexPattern: `"state": "STATE_(FAILED|TIMEOUT)"`,
+			Outcomes: []*outcome{
+				{
+					Fail: &singleOutcome{
+						When:    "true",
+						Message: fmt.Sprintf("One or more Tinkerbell workflows failed or timed out. See %s", logPath),
+					},
+				},
+				{
+					Pass: &singleOutcome{
+						When:    "false",
+						Message: "No failed Tinkerbell workflows.",
+					},
+				},
+			},
+		},
+	}
+}
+
 // EksaLogTextAnalyzers given a slice of Collectors will check which namespaced log collectors are present
 // and return the log analyzers associated with the namespace in the namespaceLogTextAnalyzersMap.
 func (a *analyzerFactory) EksaLogTextAnalyzers(collectors []*Collect) []*Analyze {
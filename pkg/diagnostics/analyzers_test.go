@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/eks-anywhere/internal/test"
 	eksav1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
 )
 
@@ -92,6 +93,21 @@ func TestNutanixDataCenterConfigAnalyzers(t *testing.T) {
 		"validControlPlaneIPAnalyzer() mismatch between desired regexPattern and actual")
 }
 
+func TestTinkerbellDataCenterConfigAnalyzers(t *testing.T) {
+	g := NewGomegaWithT(t)
+	datacenter := eksav1alpha1.Ref{Kind: eksav1alpha1.TinkerbellDatacenterKind}
+	analyzerFactory := diagnostics.NewAnalyzerFactory()
+	analyzers := analyzerFactory.DataCenterConfigAnalyzers(datacenter)
+	g.Expect(analyzers).To(HaveLen(8), "DataCenterConfigAnalyzers() mismatch between desired analyzers and actual")
+	g.Expect(analyzers[0].CustomResourceDefinition.CustomResourceDefinitionName).To(Equal("tinkerbelldatacenterconfigs.anywhere.eks.amazonaws.com"))
+	g.Expect(analyzers[3].CustomResourceDefinition.CustomResourceDefinitionName).To(Equal("workflows.tinkerbell.org"))
+	g.Expect(analyzers[5].DeploymentStatus.Name).To(Equal("tinkerbell"))
+	g.Expect(analyzers[5].DeploymentStatus.Namespace).To(Equal(constants.EksaSystemNamespace))
+	g.Expect(analyzers[6].TextAnalyze.FileName).To(Equal("check-tinkerbell-stack-ports/check-tinkerbell-stack-ports.log"))
+	g.Expect(analyzers[7].TextAnalyze.FileName).To(Equal("tinkerbell-workflows/tinkerbell-workflows.log"))
+	g.Expect(analyzers[7].TextAnalyze.RegexPattern).To(Equal(`"state": "STATE_(FAILED|TIMEOUT)"`))
+}
+
 func TestSnowAnalyzers(t *testing.T) {
 	g := NewGomegaWithT(t)
 	datacenter := eksav1alpha1.Ref{Kind: eksav1alpha1.SnowDatacenterKind}
@@ -3,6 +3,7 @@ package diagnostics
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
@@ -13,6 +14,16 @@ import (
 	"github.com/aws/eks-anywhere/pkg/providers"
 )
 
+const (
+	tinkerbellWorkflowsCollectorName   = "tinkerbell-workflows"
+	tinkerbellHardwareCollectorName    = "tinkerbell-hardware"
+	tinkerbellBMCMachinesCollectorName = "tinkerbell-bmc-machines"
+	tinkerbellStackPortsCollectorName  = "check-tinkerbell-stack-ports"
+)
+
+// tinkerbellStackTCPPorts are the smee http, tootles and tink-server grpc ports served on the Tinkerbell IP.
+var tinkerbellStackTCPPorts = []string{"7171", "7172", "42113"}
+
 // FileReader reads files from local disk or http urls.
 type FileReader interface {
 	ReadFile(url string) ([]byte, error)
@@ -142,7 +153,7 @@ func (c *EKSACollectorFactory) DataCenterConfigCollectors(datacenter v1alpha1.Re
 	case v1alpha1.CloudStackDatacenterKind:
 		return c.eksaCloudstackCollectors()
 	case v1alpha1.TinkerbellDatacenterKind:
-		return c.eksaTinkerbellCollectors()
+		return c.eksaTinkerbellCollectors(spec)
 	case v1alpha1.SnowDatacenterKind:
 		return c.eksaSnowCollectors()
 	case v1alpha1.NutanixDatacenterKind:
@@ -174,14 +185,69 @@ func (c *EKSACollectorFactory) eksaSnowCollectors() []*Collect {
 	}
 }
 
-func (c *EKSACollectorFactory) eksaTinkerbellCollectors() []*Collect {
-	return []*Collect{
+func (c *EKSACollectorFactory) eksaTinkerbellCollectors(spec *cluster.Spec) []*Collect {
+	collectors := []*Collect{
 		{
 			Logs: &logs{
 				Namespace: constants.CaptSystemNamespace,
 				Name:      logpath(constants.CaptSystemNamespace),
 			},
 		},
+		c.tinkerbellResourcesCollector(tinkerbellWorkflowsCollectorName, "workflows.tinkerbell.org"),
+		c.tinkerbellResourcesCollector(tinkerbellHardwareCollectorName, "hardware.tinkerbell.org"),
+		c.tinkerbellResourcesCollector(tinkerbellBMCMachinesCollectorName, "machines.bmc.tinkerbell.org"),
+	}
+	if spec.TinkerbellDatacenter != nil {
+		collectors = append(collectors, c.tinkerbellStackPortsCollector(spec.TinkerbellDatacenter.Spec.TinkerbellIP))
+	}
+	return collectors
+}
+
+// tinkerbellResourcesCollector dumps all the objects of a Tinkerbell custom resource so workflows
+// and hardware can be inspected when provisioning stalls.
+func (c *EKSACollectorFactory) tinkerbellResourcesCollector(name, resource string) *Collect {
+	return &Collect{
+		RunPod: &runPod{
+			collectorMeta: collectorMeta{
+				CollectorName: name,
+			},
+			Name:      name,
+			Namespace: constants.EksaDiagnosticsNamespace,
+			PodSpec: &v1.PodSpec{
+				Containers: []v1.Container{{
+					Name:    name,
+					Image:   c.DiagnosticCollectorImage,
+					Command: []string{"kubectl"},
+					Args:    []string{"get", resource, "--all-namespaces", "-o", "json"},
+				}},
+			},
+			Timeout: "30s",
+		},
+	}
+}
+
+// tinkerbellStackPortsCollector checks the Tinkerbell stack answers on the smee http, tootles and
+// tink-server grpc ports of the Tinkerbell IP.
+func (c *EKSACollectorFactory) tinkerbellStackPortsCollector(tinkerbellIP string) *Collect {
+	checkPorts := fmt.Sprintf("for port in %s; do if nc -z -w5 %s $port; then echo \"port $port open\"; else echo \"port $port closed\"; fi; done", strings.Join(tinkerbellStackTCPPorts, " "), tinkerbellIP)
+	return &Collect{
+		RunPod: &runPod{
+			collectorMeta: collectorMeta{
+				CollectorName: tinkerbellStackPortsCollectorName,
+			},
+			Name:      tinkerbellStackPortsCollectorName,
+			Namespace: constants.EksaDiagnosticsNamespace,
+			PodSpec: &v1.PodSpec{
+				Containers: []v1.Container{{
+					Name:    tinkerbellStackPortsCollectorName,
+					Image:   c.DiagnosticCollectorImage,
+					Command: []string{"/bin/sh", "-c"},
+					Args:    []string{checkPorts},
+				}},
+				HostNetwork: true,
+			},
+			Timeout: "30s",
+		},
 	}
 }
 
@@ -100,9 +100,29 @@ func TestTinkerbellDataCenterConfigCollectors(t *testing.T) {
 	datacenter := eksav1alpha1.Ref{Kind: eksav1alpha1.TinkerbellDatacenterKind}
 	factory := diagnostics.NewDefaultCollectorFactory(test.NewFileReader())
 	collectors := factory.DataCenterConfigCollectors(datacenter, spec)
-	g.Expect(collectors).To(HaveLen(1), "DataCenterConfigCollectors() mismatch between number of desired collectors and actual")
+	g.Expect(collectors).To(HaveLen(4), "DataCenterConfigCollectors() mismatch between number of desired collectors and actual")
 	g.Expect(collectors[0].Logs.Namespace).To(Equal(constants.CaptSystemNamespace))
 	g.Expect(collectors[0].Logs.Name).To(Equal(fmt.Sprintf("logs/%s", constants.CaptSystemNamespace)))
+	g.Expect(collectors[1].RunPod.Name).To(Equal("tinkerbell-workflows"))
+	g.Expect(collectors[1].RunPod.PodSpec.Containers[0].Args).To(Equal([]string{"get", "workflows.tinkerbell.org", "--all-namespaces", "-o", "json"}))
+	g.Expect(collectors[2].RunPod.Name).To(Equal("tinkerbell-hardware"))
+	g.Expect(collectors[3].RunPod.Name).To(Equal("tinkerbell-bmc-machines"))
+}
+
+func TestTinkerbellDataCenterConfigCollectorsStackPorts(t *testing.T) {
+	g := NewGomegaWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.TinkerbellDatacenter = &eksav1alpha1.TinkerbellDatacenterConfig{
+			Spec: eksav1alpha1.TinkerbellDatacenterConfigSpec{TinkerbellIP: "1.2.3.4"},
+		}
+	})
+	datacenter := eksav1alpha1.Ref{Kind: eksav1alpha1.TinkerbellDatacenterKind}
+	factory := diagnostics.NewDefaultCollectorFactory(test.NewFileReader())
+	collectors := factory.DataCenterConfigCollectors(datacenter, spec)
+	g.Expect(collectors).To(HaveLen(5), "DataCenterConfigCollectors() mismatch between number of desired collectors and actual")
+	g.Expect(collectors[4].RunPod.Name).To(Equal("check-tinkerbell-stack-ports"))
+	g.Expect(collectors[4].RunPod.PodSpec.HostNetwork).To(BeTrue())
+	g.Expect(collectors[4].RunPod.PodSpec.Containers[0].Args[0]).To(ContainSubstring("for port in 7171 7172 42113; do if nc -z -w5 1.2.3.4 $port"))
 }
 
 func TestSnowCollectors(t *testing.T) {
@@ -107,6 +107,13 @@ func BuildDockerExecutable() *Docker {
 	})
 }
 
+// BuildVirshExecutable builds a Virsh that runs the local virsh binary against the libvirt daemon at uri.
+func BuildVirshExecutable(uri string) *Virsh {
+	return NewVirsh(&executable{
+		cli: virshPath,
+	}, uri)
+}
+
 // RunExecutablesInDocker determines if binary executables should be ran
 // from a docker container or native binaries from the host path
 // It reads MR_TOOLS_DISABLE variable.
@@ -321,6 +321,20 @@ func (c *Cmk) EnsureNoDuplicateNetwork(ctx context.Context, profile string, netw
 }
 
 func (c *Cmk) ValidateNetworkPresent(ctx context.Context, profile string, domainId string, network v1alpha1.CloudStackResourceIdentifier, zoneId string, account string) error {
+	_, err := c.findNetwork(ctx, profile, domainId, network, zoneId, account)
+	return err
+}
+
+// GetNetworkType returns the guest type of a network, Isolated, Shared or L2.
+func (c *Cmk) GetNetworkType(ctx context.Context, profile string, domainId string, network v1alpha1.CloudStackResourceIdentifier, zoneId string, account string) (string, error) {
+	net, err := c.findNetwork(ctx, profile, domainId, network, zoneId, account)
+	if err != nil {
+		return "", err
+	}
+	return net.Type, nil
+}
+
+func (c *Cmk) findNetwork(ctx context.Context, profile string, domainId string, network v1alpha1.CloudStackResourceIdentifier, zoneId string, account string) (*cmkNetwork, error) {
 	command := newCmkCommand("list networks")
 	// account must be specified within a domainId
 	// domainId can be specified without account
@@ -333,17 +347,17 @@ func (c *Cmk) ValidateNetworkPresent(ctx context.Context, profile string, domain
 	applyCmkArgs(&command, withCloudStackZoneId(zoneId))
 	result, err := c.exec(ctx, profile, command...)
 	if err != nil {
-		return fmt.Errorf("getting network info - %s: %v", result.String(), err)
+		return nil, fmt.Errorf("getting network info - %s: %v", result.String(), err)
 	}
 	if result.Len() == 0 {
-		return fmt.Errorf("network %s not found in zone %s", network, zoneId)
+		return nil, fmt.Errorf("network %s not found in zone %s", network, zoneId)
 	}
 
 	response := struct {
-		CmkNetworks []cmkResourceIdentifier `json:"network"`
+		CmkNetworks []cmkNetwork `json:"network"`
 	}{}
 	if err = json.Unmarshal(result.Bytes(), &response); err != nil {
-		return fmt.Errorf("parsing response into json: %v", err)
+		return nil, fmt.Errorf("parsing response into json: %v", err)
 	}
 	networks := response.CmkNetworks
 
@@ -352,7 +366,7 @@ func (c *Cmk) ValidateNetworkPresent(ctx context.Context, profile string, domain
 	// if only name is provided, the following code is to only get networks with specified name.
 
 	if len(network.Name) > 0 {
-		networks = []cmkResourceIdentifier{}
+		networks = []cmkNetwork{}
 		for _, net := range response.CmkNetworks {
 			if net.Name == network.Name {
 				networks = append(networks, net)
@@ -361,11 +375,11 @@ func (c *Cmk) ValidateNetworkPresent(ctx context.Context, profile string, domain
 	}
 
 	if len(networks) > 1 {
-		return fmt.Errorf("duplicate network %s found", network)
+		return nil, fmt.Errorf("duplicate network %s found", network)
 	} else if len(networks) == 0 {
-		return fmt.Errorf("network %s not found in zoneRef %s", network, zoneId)
+		return nil, fmt.Errorf("network %s not found in zoneRef %s", network, zoneId)
 	}
-	return nil
+	return &networks[0], nil
 }
 
 func (c *Cmk) ValidateAccountPresent(ctx context.Context, profile string, account string, domainId string) error {
@@ -464,6 +478,247 @@ func (c *Cmk) CleanupVms(ctx context.Context, profile string, clusterName string
 	return nil
 }
 
+// ListVirtualMachines returns the virtual machines whose name contains a keyword, in all the domains and accounts the profile can access.
+func (c *Cmk) ListVirtualMachines(ctx context.Context, profile string, keyword string) ([]CloudStackVirtualMachine, error) {
+	command := newCmkCommand("list virtualmachines")
+	applyCmkArgs(&command, withCloudStackKeyword(keyword), appendArgs("listall=true"))
+	response := struct {
+		CmkVirtualMachines []cmkResourceIdentifier `json:"virtualmachine"`
+	}{}
+	if err := c.execList(ctx, profile, "virtual machines", &response, command...); err != nil {
+		return nil, err
+	}
+
+	vms := make([]CloudStackVirtualMachine, 0, len(response.CmkVirtualMachines))
+	for _, vm := range response.CmkVirtualMachines {
+		vms = append(vms, CloudStackVirtualMachine{Id: vm.Id, Name: vm.Name})
+	}
+	return vms, nil
+}
+
+// DestroyVirtualMachine stops a virtual machine and destroys it, expunging it right away.
+func (c *Cmk) DestroyVirtualMachine(ctx context.Context, profile string, id string) error {
+	stopCommand := newCmkCommand("stop virtualmachine")
+	applyCmkArgs(&stopCommand, withCloudStackId(id), appendArgs("forced=true"))
+	if result, err := c.exec(ctx, profile, stopCommand...); err != nil {
+		return fmt.Errorf("stopping virtual machine %s - %s: %v", id, result.String(), err)
+	}
+
+	destroyCommand := newCmkCommand("destroy virtualmachine")
+	applyCmkArgs(&destroyCommand, withCloudStackId(id), appendArgs("expunge=true"))
+	if result, err := c.exec(ctx, profile, destroyCommand...); err != nil {
+		return fmt.Errorf("destroying virtual machine %s - %s: %v", id, result.String(), err)
+	}
+	return nil
+}
+
+// GetAccountResourceLimits returns the resource limits and usage of an account.
+func (c *Cmk) GetAccountResourceLimits(ctx context.Context, profile string, domainId string, account string) (*CloudStackResourceLimits, error) {
+	command := newCmkCommand("list accounts")
+	applyCmkArgs(&command, withCloudStackName(account), withCloudStackDomainId(domainId))
+	result, err := c.exec(ctx, profile, command...)
+	if err != nil {
+		return nil, fmt.Errorf("getting accounts info - %s: %v", result.String(), err)
+	}
+
+	response := struct {
+		CmkAccounts []cmkResourceLimits `json:"account"`
+	}{}
+	if result.Len() > 0 {
+		if err = json.Unmarshal(result.Bytes(), &response); err != nil {
+			return nil, fmt.Errorf("parsing response into json: %v", err)
+		}
+	}
+	if len(response.CmkAccounts) != 1 {
+		return nil, fmt.Errorf("expected one account %s, found %d", account, len(response.CmkAccounts))
+	}
+
+	return response.CmkAccounts[0].limits(), nil
+}
+
+// GetDomainResourceLimits returns the resource limits and usage of a domain.
+func (c *Cmk) GetDomainResourceLimits(ctx context.Context, profile string, domainId string) (*CloudStackResourceLimits, error) {
+	command := newCmkCommand("list domains")
+	applyCmkArgs(&command, withCloudStackId(domainId), appendArgs("listall=true"))
+	result, err := c.exec(ctx, profile, command...)
+	if err != nil {
+		return nil, fmt.Errorf("getting domain info - %s: %v", result.String(), err)
+	}
+
+	response := struct {
+		CmkDomains []cmkResourceLimits `json:"domain"`
+	}{}
+	if result.Len() > 0 {
+		if err = json.Unmarshal(result.Bytes(), &response); err != nil {
+			return nil, fmt.Errorf("parsing response into json: %v", err)
+		}
+	}
+	if len(response.CmkDomains) != 1 {
+		return nil, fmt.Errorf("expected one domain %s, found %d", domainId, len(response.CmkDomains))
+	}
+
+	return response.CmkDomains[0].limits(), nil
+}
+
+// GetMachineResources returns the resources counted against the account and domain limits for a machine
+// deployed in a zone with the given template, compute offering and disk offering.
+func (c *Cmk) GetMachineResources(ctx context.Context, profile string, domainId string, zoneId string, account string, machineConfig v1alpha1.CloudStackMachineConfigSpec) (*CloudStackMachineResources, error) {
+	offeringCommand := newCmkCommand("list serviceofferings")
+	if len(machineConfig.ComputeOffering.Id) > 0 {
+		applyCmkArgs(&offeringCommand, withCloudStackId(machineConfig.ComputeOffering.Id))
+	} else {
+		applyCmkArgs(&offeringCommand, withCloudStackName(machineConfig.ComputeOffering.Name))
+	}
+	applyCmkArgs(&offeringCommand, withCloudStackZoneId(zoneId))
+	offerings := struct {
+		CmkServiceOfferings []cmkServiceOffering `json:"serviceoffering"`
+	}{}
+	if err := c.execList(ctx, profile, "service offerings", &offerings, offeringCommand...); err != nil {
+		return nil, err
+	}
+	if len(offerings.CmkServiceOfferings) != 1 {
+		return nil, fmt.Errorf("expected one service offering %s, found %d", machineConfig.ComputeOffering, len(offerings.CmkServiceOfferings))
+	}
+	offering := offerings.CmkServiceOfferings[0]
+
+	resources := &CloudStackMachineResources{
+		CPUs:       int64(offering.CpuNumber),
+		MemoryMiB:  int64(offering.Memory),
+		StorageGiB: offering.RootDiskSize,
+	}
+
+	if resources.StorageGiB == 0 {
+		templateCommand := newCmkCommand("list templates")
+		applyCmkArgs(&templateCommand, appendArgs("templatefilter=all"), appendArgs("listall=true"))
+		if len(machineConfig.Template.Id) > 0 {
+			applyCmkArgs(&templateCommand, withCloudStackId(machineConfig.Template.Id))
+		} else {
+			applyCmkArgs(&templateCommand, withCloudStackName(machineConfig.Template.Name))
+		}
+		applyCmkArgs(&templateCommand, withCloudStackZoneId(zoneId))
+		if len(domainId) > 0 {
+			applyCmkArgs(&templateCommand, withCloudStackDomainId(domainId))
+			if len(account) > 0 {
+				applyCmkArgs(&templateCommand, withCloudStackAccount(account))
+			}
+		}
+		templates := listTemplatesResponse{}
+		if err := c.execList(ctx, profile, "templates", &templates, templateCommand...); err != nil {
+			return nil, err
+		}
+		if len(templates.CmkTemplates) != 1 {
+			return nil, fmt.Errorf("expected one template %s, found %d", machineConfig.Template, len(templates.CmkTemplates))
+		}
+		resources.StorageGiB = (templates.CmkTemplates[0].Size + 1<<30 - 1) >> 30
+	}
+
+	diskOffering := machineConfig.DiskOffering
+	if diskOffering != nil && diskOffering.CustomSize > 0 {
+		resources.StorageGiB += diskOffering.CustomSize
+	} else if diskOffering != nil && (len(diskOffering.Id) > 0 || len(diskOffering.Name) > 0) {
+		diskOfferingCommand := newCmkCommand("list diskofferings")
+		if len(diskOffering.Id) > 0 {
+			applyCmkArgs(&diskOfferingCommand, withCloudStackId(diskOffering.Id))
+		} else {
+			applyCmkArgs(&diskOfferingCommand, withCloudStackName(diskOffering.Name))
+		}
+		applyCmkArgs(&diskOfferingCommand, withCloudStackZoneId(zoneId))
+		diskOfferings := struct {
+			CmkDiskOfferings []cmkDiskOffering `json:"diskoffering"`
+		}{}
+		if err := c.execList(ctx, profile, "disk offerings", &diskOfferings, diskOfferingCommand...); err != nil {
+			return nil, err
+		}
+		if len(diskOfferings.CmkDiskOfferings) != 1 {
+			return nil, fmt.Errorf("expected one disk offering ID/Name %s/%s, found %d", diskOffering.Id, diskOffering.Name, len(diskOfferings.CmkDiskOfferings))
+		}
+		resources.StorageGiB += diskOfferings.CmkDiskOfferings[0].DiskSize
+	}
+
+	return resources, nil
+}
+
+// ListAffinityGroups returns the affinity groups of an account, or of a domain when no account is given.
+func (c *Cmk) ListAffinityGroups(ctx context.Context, profile string, domainId string, account string) ([]CloudStackAffinityGroup, error) {
+	command := newCmkCommand("list affinitygroups")
+	applyCmkArgs(&command, appendArgs("listall=true"))
+	if len(domainId) > 0 {
+		applyCmkArgs(&command, withCloudStackDomainId(domainId))
+		if len(account) > 0 {
+			applyCmkArgs(&command, withCloudStackAccount(account))
+		}
+	}
+	response := struct {
+		CmkAffinityGroups []cmkAffinityGroup `json:"affinitygroup"`
+	}{}
+	if err := c.execList(ctx, profile, "affinity groups", &response, command...); err != nil {
+		return nil, err
+	}
+
+	groups := make([]CloudStackAffinityGroup, 0, len(response.CmkAffinityGroups))
+	for _, group := range response.CmkAffinityGroups {
+		groups = append(groups, group.affinityGroup())
+	}
+	return groups, nil
+}
+
+// CreateAffinityGroup creates an affinity group in an account, or in a domain when no account is given,
+// and returns its id.
+func (c *Cmk) CreateAffinityGroup(ctx context.Context, profile string, domainId string, account string, group CloudStackAffinityGroup) (string, error) {
+	command := newCmkCommand("create affinitygroup")
+	applyCmkArgs(&command,
+		withCloudStackName(group.Name),
+		appendArgs(fmt.Sprintf("type=\"%s\"", group.Type)),
+		appendArgs(fmt.Sprintf("description=\"%s\"", group.Description)),
+	)
+	if len(domainId) > 0 {
+		applyCmkArgs(&command, withCloudStackDomainId(domainId))
+		if len(account) > 0 {
+			applyCmkArgs(&command, withCloudStackAccount(account))
+		}
+	}
+	result, err := c.exec(ctx, profile, command...)
+	if err != nil {
+		return "", fmt.Errorf("creating affinity group %s - %s: %v", group.Name, result.String(), err)
+	}
+
+	response := struct {
+		CmkAffinityGroup cmkAffinityGroup `json:"affinitygroup"`
+	}{}
+	if err = json.Unmarshal(result.Bytes(), &response); err != nil {
+		return "", fmt.Errorf("parsing response into json: %v", err)
+	}
+	if response.CmkAffinityGroup.Id == "" {
+		return "", fmt.Errorf("creating affinity group %s: no id returned", group.Name)
+	}
+	return response.CmkAffinityGroup.Id, nil
+}
+
+// DeleteAffinityGroup deletes an affinity group.
+func (c *Cmk) DeleteAffinityGroup(ctx context.Context, profile string, id string) error {
+	command := newCmkCommand("delete affinitygroup")
+	applyCmkArgs(&command, withCloudStackId(id))
+	result, err := c.exec(ctx, profile, command...)
+	if err != nil {
+		return fmt.Errorf("deleting affinity group %s - %s: %v", id, result.String(), err)
+	}
+	return nil
+}
+
+func (c *Cmk) execList(ctx context.Context, profile string, resource string, response interface{}, args ...string) error {
+	result, err := c.exec(ctx, profile, args...)
+	if err != nil {
+		return fmt.Errorf("getting %s info - %s: %v", resource, result.String(), err)
+	}
+	if result.Len() == 0 {
+		return nil
+	}
+	if err = json.Unmarshal(result.Bytes(), response); err != nil {
+		return fmt.Errorf("parsing response into json: %v", err)
+	}
+	return nil
+}
+
 func (c *Cmk) exec(ctx context.Context, profile string, args ...string) (stdout bytes.Buffer, err error) {
 	configFile, err := c.buildCmkConfigFile(profile)
 	if err != nil {
@@ -505,14 +760,16 @@ type cmkTemplate struct {
 	Id       string `json:"id"`
 	Name     string `json:"name"`
 	Zonename string `json:"zonename"`
+	Size     int64  `json:"size"`
 }
 
 type cmkServiceOffering struct {
-	CpuNumber int    `json:"cpunumber"`
-	CpuSpeed  int    `json:"cpuspeed"`
-	Memory    int    `json:"memory"`
-	Id        string `json:"id"`
-	Name      string `json:"name"`
+	CpuNumber    int    `json:"cpunumber"`
+	CpuSpeed     int    `json:"cpuspeed"`
+	Memory       int    `json:"memory"`
+	RootDiskSize int64  `json:"rootdisksize"`
+	Id           string `json:"id"`
+	Name         string `json:"name"`
 }
 
 type cmkNetwork struct {
@@ -530,12 +787,25 @@ type cmkDiskOffering struct {
 	Id         string `json:"id"`
 	Name       string `json:"name"`
 	Customized bool   `json:"iscustomized"`
+	DiskSize   int64  `json:"disksize"`
 }
 
 type cmkAffinityGroup struct {
-	Type string `json:"type"`
-	Id   string `json:"id"`
-	Name string `json:"name"`
+	Type              string   `json:"type"`
+	Id                string   `json:"id"`
+	Name              string   `json:"name"`
+	Description       string   `json:"description"`
+	VirtualMachineIds []string `json:"virtualmachineIds"`
+}
+
+func (g cmkAffinityGroup) affinityGroup() CloudStackAffinityGroup {
+	return CloudStackAffinityGroup{
+		Id:                g.Id,
+		Name:              g.Name,
+		Type:              g.Type,
+		Description:       g.Description,
+		VirtualMachineIds: g.VirtualMachineIds,
+	}
 }
 
 type cmkDomain struct {
@@ -550,3 +820,107 @@ type cmkAccount struct {
 	Id       string `json:"id"`
 	Name     string `json:"name"`
 }
+
+// CloudStackResourceLimit is the limit and the usage of a resource type for a CloudStack account or domain.
+type CloudStackResourceLimit struct {
+	// Limit is negative when the resource is unlimited.
+	Limit int64
+	Total int64
+}
+
+// Unlimited returns true if there is no limit for the resource.
+func (l CloudStackResourceLimit) Unlimited() bool {
+	return l.Limit < 0
+}
+
+// Available returns the amount of the resource that can still be allocated, or -1 when unlimited.
+func (l CloudStackResourceLimit) Available() int64 {
+	if l.Unlimited() {
+		return -1
+	}
+	if l.Total > l.Limit {
+		return 0
+	}
+	return l.Limit - l.Total
+}
+
+// CloudStackResourceLimits are the limits and usage of the resources consumed by the cluster machines.
+type CloudStackResourceLimits struct {
+	VMs               CloudStackResourceLimit
+	CPUs              CloudStackResourceLimit
+	MemoryMiB         CloudStackResourceLimit
+	PrimaryStorageGiB CloudStackResourceLimit
+	PublicIPs         CloudStackResourceLimit
+}
+
+// CloudStackMachineResources are the resources consumed by a machine.
+type CloudStackMachineResources struct {
+	CPUs       int64
+	MemoryMiB  int64
+	StorageGiB int64
+}
+
+// CloudStackAffinityGroup is a CloudStack affinity group and the virtual machines it contains.
+// CloudStackVirtualMachine is a CloudStack virtual machine.
+type CloudStackVirtualMachine struct {
+	Id   string
+	Name string
+}
+
+type CloudStackAffinityGroup struct {
+	Id                string
+	Name              string
+	Type              string
+	Description       string
+	VirtualMachineIds []string
+}
+
+type cmkResourceLimits struct {
+	VMLimit             *cmkResourceCount `json:"vmlimit"`
+	VMTotal             cmkResourceCount  `json:"vmtotal"`
+	CPULimit            *cmkResourceCount `json:"cpulimit"`
+	CPUTotal            cmkResourceCount  `json:"cputotal"`
+	MemoryLimit         *cmkResourceCount `json:"memorylimit"`
+	MemoryTotal         cmkResourceCount  `json:"memorytotal"`
+	PrimaryStorageLimit *cmkResourceCount `json:"primarystoragelimit"`
+	PrimaryStorageTotal cmkResourceCount  `json:"primarystoragetotal"`
+	IPLimit             *cmkResourceCount `json:"iplimit"`
+	IPTotal             cmkResourceCount  `json:"iptotal"`
+}
+
+func (r cmkResourceLimits) limits() *CloudStackResourceLimits {
+	return &CloudStackResourceLimits{
+		VMs:               CloudStackResourceLimit{Limit: r.VMLimit.limit(), Total: int64(r.VMTotal)},
+		CPUs:              CloudStackResourceLimit{Limit: r.CPULimit.limit(), Total: int64(r.CPUTotal)},
+		MemoryMiB:         CloudStackResourceLimit{Limit: r.MemoryLimit.limit(), Total: int64(r.MemoryTotal)},
+		PrimaryStorageGiB: CloudStackResourceLimit{Limit: r.PrimaryStorageLimit.limit(), Total: int64(r.PrimaryStorageTotal)},
+		PublicIPs:         CloudStackResourceLimit{Limit: r.IPLimit.limit(), Total: int64(r.IPTotal)},
+	}
+}
+
+// cmkResourceCount is a resource limit or total, returned by CloudStack either as a number,
+// a numeric string or "Unlimited", which is decoded as -1.
+type cmkResourceCount int64
+
+// limit returns the count as a limit, where a limit missing from the response is unlimited.
+func (c *cmkResourceCount) limit() int64 {
+	if c == nil {
+		return -1
+	}
+	return int64(*c)
+}
+
+func (c *cmkResourceCount) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), "\"")
+	if value == "" || strings.EqualFold(value, "Unlimited") {
+		*c = -1
+		return nil
+	}
+
+	count, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("invalid resource count %s: %v", data, err)
+	}
+	*c = cmkResourceCount(count)
+	return nil
+}
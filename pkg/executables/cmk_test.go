@@ -406,6 +406,109 @@ func TestCmkListOperations(t *testing.T) {
 			wantErr:          true,
 			wantResultCount:  0,
 		},
+		{
+			testName:         "get account resource limits success on unlimited account",
+			jsonResponseFile: "testdata/cmk_list_account_singular.json",
+			argumentsExecCall: []string{
+				"-c", configFilePath,
+				"list", "accounts", fmt.Sprintf("name=\"%s\"", accountName), fmt.Sprintf("domainid=\"%s\"", domainID),
+			},
+			cmkFunc: func(cmk executables.Cmk, ctx context.Context) error {
+				limits, err := cmk.GetAccountResourceLimits(ctx, execConfig.Profiles[0].Name, domainID, accountName)
+				if err != nil {
+					return err
+				}
+				if !limits.CPUs.Unlimited() || limits.CPUs.Total != 1 || limits.MemoryMiB.Total != 512 || limits.PrimaryStorageGiB.Total != 20 {
+					t.Fatalf("Unexpected account resource limits: %+v", limits)
+				}
+				return nil
+			},
+			cmkResponseError: nil,
+			wantErr:          false,
+			wantResultCount:  0,
+		},
+		{
+			testName:         "get account resource limits success on limited account",
+			jsonResponseFile: "testdata/cmk_list_account_limited.json",
+			argumentsExecCall: []string{
+				"-c", configFilePath,
+				"list", "accounts", fmt.Sprintf("name=\"%s\"", accountName), fmt.Sprintf("domainid=\"%s\"", domainID),
+			},
+			cmkFunc: func(cmk executables.Cmk, ctx context.Context) error {
+				limits, err := cmk.GetAccountResourceLimits(ctx, execConfig.Profiles[0].Name, domainID, accountName)
+				if err != nil {
+					return err
+				}
+				expected := &executables.CloudStackResourceLimits{
+					VMs:               executables.CloudStackResourceLimit{Limit: 10, Total: 8},
+					CPUs:              executables.CloudStackResourceLimit{Limit: 20, Total: 16},
+					MemoryMiB:         executables.CloudStackResourceLimit{Limit: 40960, Total: 32768},
+					PrimaryStorageGiB: executables.CloudStackResourceLimit{Limit: 200, Total: 240},
+					PublicIPs:         executables.CloudStackResourceLimit{Limit: -1, Total: 1},
+				}
+				if *limits != *expected {
+					t.Fatalf("Expected account resource limits: %+v, actual: %+v", expected, limits)
+				}
+				if limits.PrimaryStorageGiB.Available() != 0 || limits.CPUs.Available() != 4 || limits.PublicIPs.Available() != -1 {
+					t.Fatalf("Unexpected available resources: %+v", limits)
+				}
+				return nil
+			},
+			cmkResponseError: nil,
+			wantErr:          false,
+			wantResultCount:  0,
+		},
+		{
+			testName:         "get account resource limits failure on no account found",
+			jsonResponseFile: "testdata/cmk_list_empty_response.json",
+			argumentsExecCall: []string{
+				"-c", configFilePath,
+				"list", "accounts", fmt.Sprintf("name=\"%s\"", accountName), fmt.Sprintf("domainid=\"%s\"", domainID),
+			},
+			cmkFunc: func(cmk executables.Cmk, ctx context.Context) error {
+				_, err := cmk.GetAccountResourceLimits(ctx, execConfig.Profiles[0].Name, domainID, accountName)
+				return err
+			},
+			cmkResponseError: nil,
+			wantErr:          true,
+			wantResultCount:  0,
+		},
+		{
+			testName:         "get domain resource limits success",
+			jsonResponseFile: "testdata/cmk_list_domain_singular.json",
+			argumentsExecCall: []string{
+				"-c", configFilePath,
+				"list", "domains", fmt.Sprintf("id=\"%s\"", domainID), "listall=true",
+			},
+			cmkFunc: func(cmk executables.Cmk, ctx context.Context) error {
+				limits, err := cmk.GetDomainResourceLimits(ctx, execConfig.Profiles[0].Name, domainID)
+				if err != nil {
+					return err
+				}
+				if !limits.VMs.Unlimited() || limits.VMs.Total != 2 || limits.CPUs.Total != 4 || limits.MemoryMiB.Total != 8192 {
+					t.Fatalf("Unexpected domain resource limits: %+v", limits)
+				}
+				return nil
+			},
+			cmkResponseError: nil,
+			wantErr:          false,
+			wantResultCount:  0,
+		},
+		{
+			testName:         "get domain resource limits failure on cmk failure",
+			jsonResponseFile: "testdata/cmk_list_empty_response.json",
+			argumentsExecCall: []string{
+				"-c", configFilePath,
+				"list", "domains", fmt.Sprintf("id=\"%s\"", domainID), "listall=true",
+			},
+			cmkFunc: func(cmk executables.Cmk, ctx context.Context) error {
+				_, err := cmk.GetDomainResourceLimits(ctx, execConfig.Profiles[0].Name, domainID)
+				return err
+			},
+			cmkResponseError: errors.New("cmk calling return exception"),
+			wantErr:          true,
+			wantResultCount:  0,
+		},
 		{
 			testName:         "listaccounts success on name filter",
 			jsonResponseFile: "testdata/cmk_list_account_singular.json",
@@ -567,6 +670,42 @@ func TestCmkListOperations(t *testing.T) {
 			wantErr:          false,
 			wantResultCount:  1,
 		},
+		{
+			testName:         "getnetworktype success",
+			jsonResponseFile: "testdata/cmk_list_network_singular.json",
+			argumentsExecCall: []string{
+				"-c", configFilePath,
+				"list", "networks", fmt.Sprintf("domainid=\"%s\"", domainID), fmt.Sprintf("account=\"%s\"", accountName), fmt.Sprintf("zoneid=\"%s\"", "TEST_RESOURCE"),
+			},
+			cmkFunc: func(cmk executables.Cmk, ctx context.Context) error {
+				networkType, err := cmk.GetNetworkType(ctx, execConfig.Profiles[0].Name, domainID, zones[2].Network, zones[2].Id, accountName)
+				if err != nil {
+					return err
+				}
+				if networkType != "Shared" {
+					return fmt.Errorf("expected network type Shared, got %s", networkType)
+				}
+				return nil
+			},
+			cmkResponseError: nil,
+			wantErr:          false,
+			wantResultCount:  1,
+		},
+		{
+			testName:         "getnetworktype failure on none results",
+			jsonResponseFile: "testdata/cmk_list_network_none.json",
+			argumentsExecCall: []string{
+				"-c", configFilePath,
+				"list", "networks", fmt.Sprintf("domainid=\"%s\"", domainID), fmt.Sprintf("account=\"%s\"", accountName), fmt.Sprintf("zoneid=\"%s\"", "TEST_RESOURCE"),
+			},
+			cmkFunc: func(cmk executables.Cmk, ctx context.Context) error {
+				_, err := cmk.GetNetworkType(ctx, execConfig.Profiles[0].Name, domainID, zones[2].Network, zones[2].Id, accountName)
+				return err
+			},
+			cmkResponseError: nil,
+			wantErr:          true,
+			wantResultCount:  1,
+		},
 		{
 			testName:         "listnetworks failure on multiple results",
 			jsonResponseFile: "testdata/cmk_list_network_multiple.json",
@@ -1117,3 +1256,177 @@ func TestCmkGetManagementApiEndpoint(t *testing.T) {
 	_, err = cmk.GetManagementApiEndpoint("xxx")
 	tt.Expect(err).NotTo(BeNil())
 }
+
+func TestCmkGetMachineResources(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	tt := NewWithT(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	gomock.InOrder(
+		executable.EXPECT().Execute(ctx, "-c", configFilePath,
+			"list", "serviceofferings", fmt.Sprintf("name=\"%s\"", resourceName.Name), fmt.Sprintf("zoneid=\"%s\"", zoneID)).
+			Return(*bytes.NewBufferString(test.ReadFile(t, "testdata/cmk_list_serviceoffering_singular.json")), nil),
+		executable.EXPECT().Execute(ctx, "-c", configFilePath,
+			"list", "templates", "templatefilter=all", "listall=true", fmt.Sprintf("id=\"%s\"", resourceID.Id), fmt.Sprintf("zoneid=\"%s\"", zoneID), fmt.Sprintf("domainid=\"%s\"", domainID), fmt.Sprintf("account=\"%s\"", accountName)).
+			Return(*bytes.NewBufferString(test.ReadFile(t, "testdata/cmk_list_template_singular.json")), nil),
+		executable.EXPECT().Execute(ctx, "-c", configFilePath,
+			"list", "diskofferings", fmt.Sprintf("name=\"%s\"", diskOfferingResourceName.Name), fmt.Sprintf("zoneid=\"%s\"", zoneID)).
+			Return(*bytes.NewBufferString(test.ReadFile(t, "testdata/cmk_list_diskoffering_singular.json")), nil),
+	)
+	cmk, _ := executables.NewCmk(executable, writer, execConfig)
+
+	resources, err := cmk.GetMachineResources(ctx, execConfig.Profiles[0].Name, domainID, zoneID, accountName, v1alpha1.CloudStackMachineConfigSpec{
+		ComputeOffering: resourceName,
+		Template:        resourceID,
+		DiskOffering:    &diskOfferingResourceName,
+	})
+	tt.Expect(err).To(BeNil())
+	tt.Expect(*resources).To(Equal(executables.CloudStackMachineResources{CPUs: 1, MemoryMiB: 1024, StorageGiB: 28}))
+}
+
+func TestCmkGetMachineResourcesTemplateNotFound(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	tt := NewWithT(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	gomock.InOrder(
+		executable.EXPECT().Execute(ctx, "-c", configFilePath,
+			"list", "serviceofferings", fmt.Sprintf("id=\"%s\"", resourceID.Id), fmt.Sprintf("zoneid=\"%s\"", zoneID)).
+			Return(*bytes.NewBufferString(test.ReadFile(t, "testdata/cmk_list_serviceoffering_singular.json")), nil),
+		executable.EXPECT().Execute(ctx, "-c", configFilePath,
+			"list", "templates", "templatefilter=all", "listall=true", fmt.Sprintf("name=\"%s\"", resourceName.Name), fmt.Sprintf("zoneid=\"%s\"", zoneID), fmt.Sprintf("domainid=\"%s\"", domainID), fmt.Sprintf("account=\"%s\"", accountName)).
+			Return(*bytes.NewBufferString(test.ReadFile(t, "testdata/cmk_list_template_none.json")), nil),
+	)
+	cmk, _ := executables.NewCmk(executable, writer, execConfig)
+
+	_, err := cmk.GetMachineResources(ctx, execConfig.Profiles[0].Name, domainID, zoneID, accountName, v1alpha1.CloudStackMachineConfigSpec{
+		ComputeOffering: resourceID,
+		Template:        resourceName,
+		DiskOffering:    &diskOfferingCustomSizeInGB,
+	})
+	tt.Expect(err).To(MatchError(ContainSubstring("expected one template")))
+}
+
+func TestCmkListAffinityGroups(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	tt := NewWithT(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "-c", configFilePath,
+		"list", "affinitygroups", "listall=true", fmt.Sprintf("domainid=\"%s\"", domainID), fmt.Sprintf("account=\"%s\"", accountName)).
+		Return(*bytes.NewBufferString(test.ReadFile(t, "testdata/cmk_list_affinitygroups.json")), nil)
+	cmk, _ := executables.NewCmk(executable, writer, execConfig)
+
+	groups, err := cmk.ListAffinityGroups(ctx, execConfig.Profiles[0].Name, domainID, accountName)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(groups).To(Equal([]executables.CloudStackAffinityGroup{
+		{
+			Id:                "2d3f4c5e-1a2b-4c3d-9e8f-7a6b5c4d3e2f",
+			Name:              "eksa-test-control-plane",
+			Type:              "host anti-affinity",
+			Description:       "Managed by EKS Anywhere for cluster test",
+			VirtualMachineIds: []string{"0e5b3a8f-6c4d-4b2a-9f1e-8d7c6b5a4f3e"},
+		},
+	}))
+}
+
+func TestCmkCreateAffinityGroup(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	tt := NewWithT(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "-c", configFilePath,
+		"create", "affinitygroup", "name=\"eksa-test-control-plane\"", "type=\"host anti-affinity\"", "description=\"Managed by EKS Anywhere for cluster test\"",
+		fmt.Sprintf("domainid=\"%s\"", domainID), fmt.Sprintf("account=\"%s\"", accountName)).
+		Return(*bytes.NewBufferString(`{"affinitygroup": {"id": "2d3f4c5e-1a2b-4c3d-9e8f-7a6b5c4d3e2f", "name": "eksa-test-control-plane"}}`), nil)
+	cmk, _ := executables.NewCmk(executable, writer, execConfig)
+
+	id, err := cmk.CreateAffinityGroup(ctx, execConfig.Profiles[0].Name, domainID, accountName, executables.CloudStackAffinityGroup{
+		Name:        "eksa-test-control-plane",
+		Type:        "host anti-affinity",
+		Description: "Managed by EKS Anywhere for cluster test",
+	})
+	tt.Expect(err).To(BeNil())
+	tt.Expect(id).To(Equal("2d3f4c5e-1a2b-4c3d-9e8f-7a6b5c4d3e2f"))
+}
+
+func TestCmkDeleteAffinityGroupError(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	tt := NewWithT(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "-c", configFilePath, "delete", "affinitygroup", "id=\"2d3f4c5e-1a2b-4c3d-9e8f-7a6b5c4d3e2f\"").
+		Return(bytes.Buffer{}, errors.New("affinity group has vms"))
+	cmk, _ := executables.NewCmk(executable, writer, execConfig)
+
+	err := cmk.DeleteAffinityGroup(ctx, execConfig.Profiles[0].Name, "2d3f4c5e-1a2b-4c3d-9e8f-7a6b5c4d3e2f")
+	tt.Expect(err).To(MatchError(ContainSubstring("deleting affinity group 2d3f4c5e-1a2b-4c3d-9e8f-7a6b5c4d3e2f")))
+}
+
+func TestCmkListVirtualMachines(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	tt := NewWithT(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "-c", configFilePath, "list", "virtualmachines", "keyword=\"eksa-drib\"", "listall=true").
+		Return(*bytes.NewBufferString(test.ReadFile(t, "testdata/cmk_list_virtualmachine_singular.json")), nil)
+	cmk, _ := executables.NewCmk(executable, writer, execConfig)
+
+	vms, err := cmk.ListVirtualMachines(ctx, execConfig.Profiles[0].Name, "eksa-drib")
+	tt.Expect(err).To(BeNil())
+	tt.Expect(vms).To(Equal([]executables.CloudStackVirtualMachine{
+		{
+			Id:   "30e8b0b1-f286-4372-9f1f-441e199a3f49",
+			Name: "eksa-drib-a2dc6c5-control-plane-template-1652968428083-jx6dh",
+		},
+	}))
+}
+
+func TestCmkDestroyVirtualMachine(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	tt := NewWithT(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "-c", configFilePath, "stop", "virtualmachine", "id=\"30e8b0b1\"", "forced=true")
+	executable.EXPECT().Execute(ctx, "-c", configFilePath, "destroy", "virtualmachine", "id=\"30e8b0b1\"", "expunge=true")
+	cmk, _ := executables.NewCmk(executable, writer, execConfig)
+
+	tt.Expect(cmk.DestroyVirtualMachine(ctx, execConfig.Profiles[0].Name, "30e8b0b1")).To(Succeed())
+}
+
+func TestCmkDestroyVirtualMachineError(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	tt := NewWithT(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "-c", configFilePath, "stop", "virtualmachine", "id=\"30e8b0b1\"", "forced=true").
+		Return(bytes.Buffer{}, errors.New("vm not found"))
+	cmk, _ := executables.NewCmk(executable, writer, execConfig)
+
+	err := cmk.DestroyVirtualMachine(ctx, execConfig.Profiles[0].Name, "30e8b0b1")
+	tt.Expect(err).To(MatchError(ContainSubstring("stopping virtual machine 30e8b0b1")))
+}
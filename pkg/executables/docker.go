@@ -3,6 +3,7 @@ package executables
 import (
 	"context"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 
@@ -116,6 +117,14 @@ func (d *Docker) SaveToFile(ctx context.Context, filepath string, images ...stri
 	return nil
 }
 
+// BuildImage builds the Dockerfile of contextDir and tags the image with image.
+func (d *Docker) BuildImage(ctx context.Context, image, contextDir string) error {
+	if _, err := d.Execute(ctx, "build", "--tag", image, contextDir); err != nil {
+		return fmt.Errorf("building docker image %s: %v", image, err)
+	}
+	return nil
+}
+
 func (d *Docker) Run(ctx context.Context, image string, name string, cmd []string, flags ...string) error {
 	params := []string{"run", "-d", "-i"}
 	params = append(params, flags...)
@@ -137,6 +146,43 @@ func (d *Docker) ForceRemove(ctx context.Context, name string) error {
 	return nil
 }
 
+// ListContainers returns the names of the containers, running or stopped, matching a docker ps filter like label=key=value.
+func (d *Docker) ListContainers(ctx context.Context, filter string) ([]string, error) {
+	out, err := d.Execute(ctx, "ps", "-a", "--filter", filter, "--format", "{{.Names}}")
+	if err != nil {
+		return nil, fmt.Errorf("listing docker containers with filter %s: %v", filter, err)
+	}
+	return strings.Fields(out.String()), nil
+}
+
+// CreateNetwork creates a bridge network unless a network with the same name already exists.
+func (d *Docker) CreateNetwork(ctx context.Context, name string) error {
+	if _, err := d.Execute(ctx, "network", "create", name); err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			return nil
+		}
+		return fmt.Errorf("creating docker network %s: %v", name, err)
+	}
+	return nil
+}
+
+// NetworkIPv4 returns the IPv4 subnet of a network and its gateway, the address of the host in the network.
+func (d *Docker) NetworkIPv4(ctx context.Context, name string) (subnet, gateway string, err error) {
+	out, err := d.Execute(ctx, "network", "inspect", name, "--format", "{{range .IPAM.Config}}{{.Subnet}},{{.Gateway}} {{end}}")
+	if err != nil {
+		return "", "", fmt.Errorf("inspecting docker network %s: %v", name, err)
+	}
+
+	for _, config := range strings.Fields(out.String()) {
+		subnet, gateway, _ := strings.Cut(config, ",")
+		if ip, _, err := net.ParseCIDR(subnet); err == nil && ip.To4() != nil && gateway != "" {
+			return subnet, gateway, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("docker network %s doesn't have an IPv4 subnet", name)
+}
+
 // CheckContainerExistence checks whether a Docker container with the provided name exists
 // It returns true if a container with the name exists, false if it doesn't and an error if it encounters some other error.
 func (d *Docker) CheckContainerExistence(ctx context.Context, name string) (bool, error) {
@@ -232,6 +232,37 @@ func TestDockerForceRemoveFailure(t *testing.T) {
 	assert.EqualError(t, err, expectedError, "Error should be: %v, got: %v", expectedError, err)
 }
 
+func TestDockerListContainersSuccess(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	filter := "label=io.x-k8s.kind.cluster=test"
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	d := executables.NewDocker(executable)
+
+	executable.EXPECT().Execute(ctx, "ps", "-a", "--filter", filter, "--format", "{{.Names}}").Return(*bytes.NewBufferString("test-lb\ntest-md-0-abcd\n"), nil)
+
+	names, err := d.ListContainers(ctx, filter)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"test-lb", "test-md-0-abcd"}, names)
+}
+
+func TestDockerListContainersFailure(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	filter := "label=io.x-k8s.kind.cluster=test"
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	d := executables.NewDocker(executable)
+
+	executable.EXPECT().Execute(ctx, "ps", "-a", "--filter", filter, "--format", "{{.Names}}").Return(bytes.Buffer{}, errors.New("docker error"))
+
+	_, err := d.ListContainers(ctx, filter)
+	assert.EqualError(t, err, "listing docker containers with filter label=io.x-k8s.kind.cluster=test: docker error")
+}
+
 func TestDockerCheckContainerExistenceExists(t *testing.T) {
 	ctx := context.Background()
 	mockCtrl := gomock.NewController(t)
@@ -281,3 +312,79 @@ func TestDockerCheckContainerExistenceOtherError(t *testing.T) {
 	assert.False(t, exists)
 	assert.EqualError(t, err, expectedError, "Error should be: %v, got: %v", expectedError, err)
 }
+
+func TestDockerBuildImage(t *testing.T) {
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	d := executables.NewDocker(executable)
+
+	executable.EXPECT().Execute(ctx, "build", "--tag", "sushy-tools:1.2.0", "/tmp/emulator-image")
+
+	assert.Nil(t, d.BuildImage(ctx, "sushy-tools:1.2.0", "/tmp/emulator-image"))
+}
+
+func TestDockerBuildImageError(t *testing.T) {
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	d := executables.NewDocker(executable)
+
+	executable.EXPECT().Execute(ctx, "build", "--tag", "sushy-tools:1.2.0", "/tmp/emulator-image").Return(bytes.Buffer{}, errors.New("no space left on device"))
+
+	assert.EqualError(t, d.BuildImage(ctx, "sushy-tools:1.2.0", "/tmp/emulator-image"), "building docker image sushy-tools:1.2.0: no space left on device")
+}
+
+func TestDockerCreateNetwork(t *testing.T) {
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	d := executables.NewDocker(executable)
+
+	executable.EXPECT().Execute(ctx, "network", "create", "kind")
+
+	assert.Nil(t, d.CreateNetwork(ctx, "kind"))
+}
+
+func TestDockerCreateNetworkAlreadyExists(t *testing.T) {
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	d := executables.NewDocker(executable)
+
+	executable.EXPECT().Execute(ctx, "network", "create", "kind").Return(bytes.Buffer{}, errors.New("network with name kind already exists"))
+
+	assert.Nil(t, d.CreateNetwork(ctx, "kind"))
+}
+
+func TestDockerCreateNetworkFailure(t *testing.T) {
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	d := executables.NewDocker(executable)
+
+	executable.EXPECT().Execute(ctx, "network", "create", "kind").Return(bytes.Buffer{}, errors.New("permission denied"))
+
+	assert.EqualError(t, d.CreateNetwork(ctx, "kind"), "creating docker network kind: permission denied")
+}
+
+func TestDockerNetworkIPv4(t *testing.T) {
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	d := executables.NewDocker(executable)
+
+	executable.EXPECT().Execute(ctx, "network", "inspect", "kind", "--format", "{{range .IPAM.Config}}{{.Subnet}},{{.Gateway}} {{end}}").
+		Return(*bytes.NewBufferString("fc00:f853:ccd:e793::/64,fc00:f853:ccd:e793::1 172.18.0.0/16,172.18.0.1 \n"), nil)
+
+	subnet, gateway, err := d.NetworkIPv4(ctx, "kind")
+	assert.Nil(t, err)
+	assert.Equal(t, "172.18.0.0/16", subnet)
+	assert.Equal(t, "172.18.0.1", gateway)
+}
+
+func TestDockerNetworkIPv4NoIPv4Subnet(t *testing.T) {
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	d := executables.NewDocker(executable)
+
+	executable.EXPECT().Execute(ctx, "network", "inspect", "kind", "--format", "{{range .IPAM.Config}}{{.Subnet}},{{.Gateway}} {{end}}").
+		Return(*bytes.NewBufferString("fc00:f853:ccd:e793::/64,fc00:f853:ccd:e793::1 \n"), nil)
+
+	_, _, err := d.NetworkIPv4(ctx, "kind")
+	assert.EqualError(t, err, "docker network kind doesn't have an IPv4 subnet")
+}
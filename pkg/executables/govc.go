@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -178,6 +179,26 @@ func (g *Govc) SearchTemplate(ctx context.Context, datacenter, template string)
 	return foundTemplate, nil
 }
 
+// ListTemplates returns the paths of the templates in folder and its subfolders.
+func (g *Govc) ListTemplates(ctx context.Context, folder string) ([]string, error) {
+	templatesResponse, err := g.exec(ctx, "find", "-json", folder, "-type", "VirtualMachine", "-config.template", "true")
+	if err != nil {
+		return nil, fmt.Errorf("listing templates in %s: %v", folder, err)
+	}
+
+	response := strings.TrimSuffix(templatesResponse.String(), "\n")
+	if response == "null" || response == "" {
+		return nil, nil
+	}
+
+	templates := make([]string, 0)
+	if err = json.Unmarshal([]byte(response), &templates); err != nil {
+		return nil, fmt.Errorf("parsing templates in %s: %v", folder, err)
+	}
+
+	return templates, nil
+}
+
 func (g *Govc) LibraryElementExists(ctx context.Context, library string) (bool, error) {
 	response, err := g.exec(ctx, "library.ls", library)
 	if err != nil {
@@ -418,9 +439,16 @@ func (g *Govc) DeployTemplateFromLibrary(ctx context.Context, templateDir, templ
 	return nil
 }
 
+// ImportTemplate imports the ova at ovaURL into library. HTTP(S) URLs are pulled by vCenter,
+// any other location is read as a local file and uploaded by govc.
 func (g *Govc) ImportTemplate(ctx context.Context, library, ovaURL, name string) error {
 	logger.V(4).Info("Importing template", "ova", ovaURL, "templateName", name)
-	if _, err := g.exec(ctx, "library.import", "-k", "-pull", "-n", name, library, ovaURL); err != nil {
+	params := []string{"library.import", "-k"}
+	if strings.HasPrefix(ovaURL, "http://") || strings.HasPrefix(ovaURL, "https://") {
+		params = append(params, "-pull")
+	}
+	params = append(params, "-n", name, library, ovaURL)
+	if _, err := g.exec(ctx, params...); err != nil {
 		return fmt.Errorf("importing template: %v", err)
 	}
 	return nil
@@ -651,6 +679,36 @@ func (g *Govc) CleanupVms(ctx context.Context, clusterName string, dryRun bool)
 	return nil
 }
 
+// ListVMs returns the paths of the VMs of a datacenter whose name matches a pattern, like cluster-*.
+func (g *Govc) ListVMs(ctx context.Context, datacenter, namePattern string) ([]string, error) {
+	response, err := g.exec(ctx, "find", "/"+datacenter, "-type", "VirtualMachine", "-name", namePattern)
+	if err != nil {
+		return nil, fmt.Errorf("listing vms: %v", err)
+	}
+
+	vms := []string{}
+	for _, line := range strings.Split(response.String(), "\n") {
+		if vm := strings.TrimSpace(line); vm != "" {
+			vms = append(vms, vm)
+		}
+	}
+
+	return vms, nil
+}
+
+// DestroyVM powers off a VM, if it's running, and deletes it.
+func (g *Govc) DestroyVM(ctx context.Context, path string) error {
+	if _, err := g.exec(ctx, "vm.power", "-off", "-force", path); err != nil {
+		logger.V(4).Info("Failed to power off vm, it may be already off", "vm", path, "error", err)
+	}
+
+	if _, err := g.exec(ctx, "object.destroy", path); err != nil {
+		return fmt.Errorf("destroying vm %s: %v", path, err)
+	}
+
+	return nil
+}
+
 func (g *Govc) ValidateVCenterConnection(ctx context.Context, server string) error {
 	skipVerifyTransport := http.DefaultTransport.(*http.Transport).Clone()
 	skipVerifyTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
@@ -1252,6 +1310,79 @@ func (g *Govc) CreateRole(ctx context.Context, name string, privileges []string)
 	return nil
 }
 
+// GetRolePrivileges returns the privileges of a role.
+func (g *Govc) GetRolePrivileges(ctx context.Context, name string) ([]string, error) {
+	response, err := g.exec(ctx, "role.ls", name)
+	if err != nil {
+		return nil, fmt.Errorf("govc returned error %v", err)
+	}
+
+	var privileges []string
+	scanner := bufio.NewScanner(strings.NewReader(response.String()))
+	for scanner.Scan() {
+		if p := strings.TrimSpace(scanner.Text()); p != "" {
+			privileges = append(privileges, p)
+		}
+	}
+
+	return privileges, nil
+}
+
+// AddRolePrivileges adds privileges to an existing role.
+func (g *Govc) AddRolePrivileges(ctx context.Context, name string, privileges []string) error {
+	params := append([]string{"role.update", "-a", name}, privileges...)
+
+	if _, err := g.exec(ctx, params...); err != nil {
+		return fmt.Errorf("govc returned error %v", err)
+	}
+
+	return nil
+}
+
+type permissionsList struct {
+	Roles []struct {
+		RoleId int32
+		Name   string
+	}
+	Permissions []struct {
+		Principal string
+		Group     bool
+		RoleId    int32
+	}
+}
+
+// GetGroupRolesOnObject returns the roles set for a given group on target object, ignoring the ones inherited from its parents.
+func (g *Govc) GetGroupRolesOnObject(ctx context.Context, principal string, object string, domain string) ([]string, error) {
+	response, err := g.exec(ctx, "permissions.ls", "-a=false", "-json", object)
+	if err != nil {
+		return nil, fmt.Errorf("govc returned error %v", err)
+	}
+
+	list := &permissionsList{}
+	if err = json.Unmarshal(response.Bytes(), list); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling govc response from permissions.ls: %v", err)
+	}
+
+	roleNames := make(map[int32]string, len(list.Roles))
+	for _, r := range list.Roles {
+		roleNames[r.RoleId] = r.Name
+	}
+
+	var roles []string
+	for _, p := range list.Permissions {
+		if !p.Group {
+			continue
+		}
+		// vCenter returns principals as DOMAIN\name
+		if !strings.EqualFold(p.Principal, domain+"\\"+principal) && !strings.EqualFold(p.Principal, principal+"@"+domain) {
+			continue
+		}
+		roles = append(roles, roleNames[p.RoleId])
+	}
+
+	return roles, nil
+}
+
 // SetGroupRoleOnObject sets a role for a given group on target object.
 func (g *Govc) SetGroupRoleOnObject(ctx context.Context, principal string, role string, object string, domain string) error {
 	principal = principal + "@" + domain
@@ -1343,3 +1474,260 @@ func getValueFromString(str string) (int, error) {
 	}
 	return numValue, nil
 }
+
+// GetResourcePoolCPUThreads returns the number of logical CPU threads of the cluster or host backing the resource pool.
+func (g *Govc) GetResourcePoolCPUThreads(ctx context.Context, datacenter, resourcePool string) (int, error) {
+	owner, err := g.resourcePoolOwner(ctx, datacenter, resourcePool)
+	if err != nil {
+		return 0, err
+	}
+
+	threads, err := g.exec(ctx, "object.collect", "-s", "-dc", datacenter, owner, "summary.numCpuThreads")
+	if err != nil {
+		return 0, fmt.Errorf("getting cpu threads for resource pool %s: %v", resourcePool, err)
+	}
+
+	numThreads, err := strconv.Atoi(strings.TrimSpace(threads.String()))
+	if err != nil {
+		return 0, fmt.Errorf("parsing cpu threads for resource pool %s: %v", resourcePool, err)
+	}
+
+	return numThreads, nil
+}
+
+// GetResourcePoolHostCount returns the number of ESXi hosts of the cluster or host backing the resource pool.
+func (g *Govc) GetResourcePoolHostCount(ctx context.Context, datacenter, resourcePool string) (int, error) {
+	owner, err := g.resourcePoolOwner(ctx, datacenter, resourcePool)
+	if err != nil {
+		return 0, err
+	}
+
+	hosts, err := g.exec(ctx, "object.collect", "-s", "-dc", datacenter, owner, "summary.numHosts")
+	if err != nil {
+		return 0, fmt.Errorf("getting hosts for resource pool %s: %v", resourcePool, err)
+	}
+
+	numHosts, err := strconv.Atoi(strings.TrimSpace(hosts.String()))
+	if err != nil {
+		return 0, fmt.Errorf("parsing hosts for resource pool %s: %v", resourcePool, err)
+	}
+
+	return numHosts, nil
+}
+
+// resourcePoolOwner returns the managed object reference of the cluster or host backing the resource pool.
+func (g *Govc) resourcePoolOwner(ctx context.Context, datacenter, resourcePool string) (string, error) {
+	owner, err := g.exec(ctx, "object.collect", "-s", "-dc", datacenter, resourcePool, "owner")
+	if err != nil {
+		return "", fmt.Errorf("getting resource pool owner: %v", err)
+	}
+
+	return strings.TrimSpace(owner.String()), nil
+}
+
+// AntiAffinityRule is a DRS rule that keeps a group of VMs on different ESXi hosts.
+type AntiAffinityRule struct {
+	// Name identifies the rule in the compute cluster. The VM group and the VM-Host rule
+	// created for HostGroup use it as a prefix.
+	Name string
+	VMs  []string
+	// Mandatory makes DRS refuse to power on VMs that would violate the rules.
+	Mandatory bool
+	// HostGroup is an existing DRS host group the VMs are placed on. Optional.
+	HostGroup string
+}
+
+// VMGroupName returns the name of the DRS VM group created for the rule HostGroup.
+func (r AntiAffinityRule) VMGroupName() string {
+	return r.Name + "-vms"
+}
+
+// VMHostRuleName returns the name of the DRS VM-Host rule created for the rule HostGroup.
+func (r AntiAffinityRule) VMHostRuleName() string {
+	return r.Name + "-hosts"
+}
+
+// ApplyAntiAffinityRule creates or updates the DRS rules of the compute cluster backing the resource pool
+// so the rule VMs are kept on different hosts and, if it has a HostGroup, on the hosts of that group.
+func (g *Govc) ApplyAntiAffinityRule(ctx context.Context, datacenter, resourcePool string, rule AntiAffinityRule) error {
+	cluster, err := g.computeCluster(ctx, datacenter, resourcePool)
+	if err != nil {
+		return err
+	}
+
+	mandatory := fmt.Sprintf("-mandatory=%t", rule.Mandatory)
+
+	rules, err := g.listClusterNames(ctx, "cluster.rule.ls", "-cluster", cluster)
+	if err != nil {
+		return fmt.Errorf("listing DRS rules for compute cluster %s: %v", cluster, err)
+	}
+
+	if err := g.applyAntiAffinityRule(ctx, cluster, rule, rules, mandatory); err != nil {
+		return err
+	}
+
+	if rule.HostGroup == "" {
+		return nil
+	}
+
+	groups, err := g.listClusterNames(ctx, "cluster.group.ls", "-cluster", cluster)
+	if err != nil {
+		return fmt.Errorf("listing DRS groups for compute cluster %s: %v", cluster, err)
+	}
+	if !slices.Contains(groups, rule.HostGroup) {
+		return fmt.Errorf("DRS host group %s not found in compute cluster %s", rule.HostGroup, cluster)
+	}
+
+	vmGroup := rule.VMGroupName()
+	groupCmd := "cluster.group.change"
+	groupArgs := []string{"-cluster", cluster, "-name", vmGroup}
+	if !slices.Contains(groups, vmGroup) {
+		groupCmd = "cluster.group.create"
+		groupArgs = append(groupArgs, "-vm")
+	}
+	params := append([]string{groupCmd}, groupArgs...)
+	if _, err := g.exec(ctx, append(params, rule.VMs...)...); err != nil {
+		return fmt.Errorf("applying DRS VM group %s: %v", vmGroup, err)
+	}
+
+	vmHostRule := rule.VMHostRuleName()
+	if slices.Contains(rules, vmHostRule) {
+		params = []string{"cluster.rule.change", "-cluster", cluster, "-name", vmHostRule, "-enable", mandatory}
+	} else {
+		params = []string{"cluster.rule.create", "-cluster", cluster, "-name", vmHostRule, "-enable", mandatory, "-vm-host", "-vm-group", vmGroup, "-host-affine-group", rule.HostGroup}
+	}
+	if _, err := g.exec(ctx, params...); err != nil {
+		return fmt.Errorf("applying DRS VM-Host rule %s: %v", vmHostRule, err)
+	}
+
+	return nil
+}
+
+// ListDRSRules returns the names of the DRS rules of the compute cluster backing the resource pool.
+func (g *Govc) ListDRSRules(ctx context.Context, datacenter, resourcePool string) ([]string, error) {
+	cluster, err := g.computeCluster(ctx, datacenter, resourcePool)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := g.listClusterNames(ctx, "cluster.rule.ls", "-cluster", cluster)
+	if err != nil {
+		return nil, fmt.Errorf("listing DRS rules for compute cluster %s: %v", cluster, err)
+	}
+
+	return rules, nil
+}
+
+// RemoveDRSRule removes a DRS rule from the compute cluster backing the resource pool.
+func (g *Govc) RemoveDRSRule(ctx context.Context, datacenter, resourcePool, name string) error {
+	cluster, err := g.computeCluster(ctx, datacenter, resourcePool)
+	if err != nil {
+		return err
+	}
+
+	if _, err := g.exec(ctx, "cluster.rule.remove", "-cluster", cluster, "-name", name); err != nil {
+		return fmt.Errorf("removing DRS rule %s: %v", name, err)
+	}
+
+	return nil
+}
+
+// ListDRSGroups returns the names of the DRS VM and host groups of the compute cluster backing the resource pool.
+func (g *Govc) ListDRSGroups(ctx context.Context, datacenter, resourcePool string) ([]string, error) {
+	cluster, err := g.computeCluster(ctx, datacenter, resourcePool)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := g.listClusterNames(ctx, "cluster.group.ls", "-cluster", cluster)
+	if err != nil {
+		return nil, fmt.Errorf("listing DRS groups for compute cluster %s: %v", cluster, err)
+	}
+
+	return groups, nil
+}
+
+// RemoveDRSGroup removes a DRS group from the compute cluster backing the resource pool.
+func (g *Govc) RemoveDRSGroup(ctx context.Context, datacenter, resourcePool, name string) error {
+	cluster, err := g.computeCluster(ctx, datacenter, resourcePool)
+	if err != nil {
+		return err
+	}
+
+	if _, err := g.exec(ctx, "cluster.group.remove", "-cluster", cluster, "-name", name); err != nil {
+		return fmt.Errorf("removing DRS group %s: %v", name, err)
+	}
+
+	return nil
+}
+
+// computeCluster returns the path of the compute cluster backing the resource pool.
+func (g *Govc) computeCluster(ctx context.Context, datacenter, resourcePool string) (string, error) {
+	owner, err := g.resourcePoolOwner(ctx, datacenter, resourcePool)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(owner, "ClusterComputeResource:") {
+		return "", fmt.Errorf("resource pool %s does not belong to a compute cluster, DRS rules are not supported", resourcePool)
+	}
+
+	clusterPath, err := g.exec(ctx, "ls", "-L", owner)
+	if err != nil {
+		return "", fmt.Errorf("getting compute cluster path for resource pool %s: %v", resourcePool, err)
+	}
+
+	return strings.TrimSpace(clusterPath.String()), nil
+}
+
+// applyAntiAffinityRule creates the anti-affinity rule or, if it exists, recreates it when its VMs changed.
+func (g *Govc) applyAntiAffinityRule(ctx context.Context, cluster string, rule AntiAffinityRule, rules []string, mandatory string) error {
+	if slices.Contains(rules, rule.Name) {
+		vms, err := g.listClusterNames(ctx, "cluster.rule.ls", "-cluster", cluster, "-name", rule.Name)
+		if err != nil {
+			return fmt.Errorf("listing VMs of DRS rule %s: %v", rule.Name, err)
+		}
+
+		if sameElements(vms, rule.VMs) {
+			if _, err := g.exec(ctx, "cluster.rule.change", "-cluster", cluster, "-name", rule.Name, "-enable", mandatory); err != nil {
+				return fmt.Errorf("updating DRS rule %s: %v", rule.Name, err)
+			}
+			return nil
+		}
+
+		// The VMs of an anti-affinity rule can't be changed, so the rule is replaced.
+		if _, err := g.exec(ctx, "cluster.rule.remove", "-cluster", cluster, "-name", rule.Name); err != nil {
+			return fmt.Errorf("removing DRS rule %s: %v", rule.Name, err)
+		}
+	}
+
+	params := []string{"cluster.rule.create", "-cluster", cluster, "-name", rule.Name, "-enable", mandatory, "-anti-affinity"}
+	if _, err := g.exec(ctx, append(params, rule.VMs...)...); err != nil {
+		return fmt.Errorf("creating DRS rule %s: %v", rule.Name, err)
+	}
+
+	return nil
+}
+
+func (g *Govc) listClusterNames(ctx context.Context, args ...string) ([]string, error) {
+	response, err := g.exec(ctx, append(args, "-json")...)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if err := json.Unmarshal(response.Bytes(), &names); err != nil {
+		return nil, fmt.Errorf("parsing response: %v", err)
+	}
+
+	return names, nil
+}
+
+func sameElements(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
@@ -227,6 +227,51 @@ func TestSearchTemplateError(t *testing.T) {
 	}
 }
 
+func TestGovcListTemplates(t *testing.T) {
+	ctx := context.Background()
+	folder := "/SDDC-Datacenter/vm/Templates"
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "-json", folder, "-type", "VirtualMachine", "-config.template", "true").Return(*bytes.NewBufferString("[\"/SDDC-Datacenter/vm/Templates/bottlerocket-1.30\",\"/SDDC-Datacenter/vm/Templates/bottlerocket-1.31\"]\n"), nil)
+
+	templates, err := g.ListTemplates(ctx, folder)
+	if err != nil {
+		t.Fatalf("Govc.ListTemplates() err = %v, want err nil", err)
+	}
+	want := []string{"/SDDC-Datacenter/vm/Templates/bottlerocket-1.30", "/SDDC-Datacenter/vm/Templates/bottlerocket-1.31"}
+	if !reflect.DeepEqual(templates, want) {
+		t.Fatalf("Govc.ListTemplates() = %v, want %v", templates, want)
+	}
+}
+
+func TestGovcListTemplatesEmpty(t *testing.T) {
+	ctx := context.Background()
+	folder := "/SDDC-Datacenter/vm/Templates"
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "-json", folder, "-type", "VirtualMachine", "-config.template", "true").Return(*bytes.NewBufferString("null\n"), nil)
+
+	templates, err := g.ListTemplates(ctx, folder)
+	if err != nil {
+		t.Fatalf("Govc.ListTemplates() err = %v, want err nil", err)
+	}
+	if len(templates) != 0 {
+		t.Fatalf("Govc.ListTemplates() = %v, want empty", templates)
+	}
+}
+
+func TestGovcListTemplatesError(t *testing.T) {
+	ctx := context.Background()
+	folder := "/SDDC-Datacenter/vm/Templates"
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "-json", folder, "-type", "VirtualMachine", "-config.template", "true").Return(bytes.Buffer{}, errors.New("folder not found"))
+
+	if _, err := g.ListTemplates(ctx, folder); err == nil {
+		t.Fatal("Govc.ListTemplates() err = nil, want err not nil")
+	}
+}
+
 func TestLibraryElementExistsItExists(t *testing.T) {
 	ctx := context.Background()
 
@@ -1676,7 +1721,7 @@ func TestCreateCategoryForVMError(t *testing.T) {
 }
 
 func TestImportTemplateSuccess(t *testing.T) {
-	ovaURL := "ovaURL"
+	ovaURL := "https://ova.example.com/ubuntu.ova"
 	name := "name"
 	ctx := context.Background()
 
@@ -1688,8 +1733,21 @@ func TestImportTemplateSuccess(t *testing.T) {
 	}
 }
 
+func TestImportTemplateLocalFileSuccess(t *testing.T) {
+	ovaPath := "/ovas/ubuntu.ova"
+	name := "name"
+	ctx := context.Background()
+
+	_, g, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "library.import", "-k", "-n", name, templateLibrary, ovaPath).Return(*bytes.NewBufferString(""), nil)
+
+	if err := g.ImportTemplate(ctx, templateLibrary, ovaPath, name); err != nil {
+		t.Fatalf("Govc.ImportTemplate() err = %v, want err nil", err)
+	}
+}
+
 func TestImportTemplateError(t *testing.T) {
-	ovaURL := "ovaURL"
+	ovaURL := "https://ova.example.com/ubuntu.ova"
 	name := "name"
 	ctx := context.Background()
 
@@ -2245,6 +2303,98 @@ func TestGovcSetGroupRoleOnObject(t *testing.T) {
 	}
 }
 
+func TestGovcGetRolePrivileges(t *testing.T) {
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	role := "EKSACloudAdmin"
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "role.ls", role).Return(*bytes.NewBufferString("System.Anonymous\nSystem.Read\nVirtualMachine.Config.CPUCount\n"), nil)
+
+	privileges, err := g.GetRolePrivileges(ctx, role)
+	gt := NewWithT(t)
+	gt.Expect(err).To(BeNil())
+	gt.Expect(privileges).To(Equal([]string{"System.Anonymous", "System.Read", "VirtualMachine.Config.CPUCount"}))
+}
+
+func TestGovcGetRolePrivilegesError(t *testing.T) {
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	role := "EKSACloudAdmin"
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "role.ls", role).Return(*bytes.NewBufferString(""), errors.New("operation failed"))
+
+	_, err := g.GetRolePrivileges(ctx, role)
+	gt := NewWithT(t)
+	gt.Expect(err).ToNot(BeNil())
+}
+
+func TestGovcAddRolePrivileges(t *testing.T) {
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	role := "EKSACloudAdmin"
+	privileges := []string{"vSphereDataProtection.Recovery", "vSphereDataProtection.Protection"}
+
+	tests := []struct {
+		name    string
+		wantErr error
+	}{
+		{
+			name:    "test AddRolePrivileges success",
+			wantErr: nil,
+		},
+		{
+			name:    "test AddRolePrivileges error",
+			wantErr: errors.New("operation failed"),
+		},
+	}
+
+	for _, tt := range tests {
+		targetArgs := append([]string{"role.update", "-a", role}, privileges...)
+		executable.EXPECT().ExecuteWithEnv(ctx, env, targetArgs).Return(*bytes.NewBufferString(""), tt.wantErr)
+
+		err := g.AddRolePrivileges(ctx, role, privileges)
+		gt := NewWithT(t)
+		if tt.wantErr != nil {
+			gt.Expect(err).ToNot(BeNil())
+		} else {
+			gt.Expect(err).To(BeNil())
+		}
+	}
+}
+
+func TestGovcGetGroupRolesOnObject(t *testing.T) {
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	object := "/Datacenter/vm/MyVirtualMachines"
+	response := `{
+		"roles": [{"roleId": -1, "name": "Admin"}, {"roleId": 1001, "name": "EKSACloudAdmin"}, {"roleId": 1002, "name": "EKSAUser"}],
+		"permissions": [
+			{"principal": "VSPHERE.LOCAL\\EKSAGroup", "group": true, "roleId": 1001},
+			{"principal": "VSPHERE.LOCAL\\EKSAGroup", "group": false, "roleId": 1002},
+			{"principal": "VSPHERE.LOCAL\\Administrators", "group": true, "roleId": -1}
+		]
+	}`
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "permissions.ls", "-a=false", "-json", object).Return(*bytes.NewBufferString(response), nil)
+
+	roles, err := g.GetGroupRolesOnObject(ctx, "EKSAGroup", object, "vsphere.local")
+	gt := NewWithT(t)
+	gt.Expect(err).To(BeNil())
+	gt.Expect(roles).To(Equal([]string{"EKSACloudAdmin"}))
+}
+
+func TestGovcGetGroupRolesOnObjectError(t *testing.T) {
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	object := "/Datacenter/vm/MyVirtualMachines"
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "permissions.ls", "-a=false", "-json", object).Return(*bytes.NewBufferString(""), errors.New("operation failed"))
+
+	_, err := g.GetGroupRolesOnObject(ctx, "EKSAGroup", object, "vsphere.local")
+	gt := NewWithT(t)
+	gt.Expect(err).ToNot(BeNil())
+}
+
 func TestGovcGetVMDiskSizeInGB(t *testing.T) {
 	datacenter := "SDDC-Datacenter"
 	template := "bottlerocket-kube-v1.24.6"
@@ -2487,3 +2637,239 @@ func TestGovcGetResourcePoolInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestGovcGetResourcePoolCPUThreads(t *testing.T) {
+	datacenter := "SDDC-Datacenter"
+	resourcePool := "*/Resources/Test-ResourcePool"
+	owner := "ClusterComputeResource:domain-c7"
+	ctx := context.Background()
+
+	tests := []struct {
+		testName    string
+		ownerErr    error
+		threads     string
+		wantThreads int
+		wantErr     string
+	}{
+		{
+			testName:    "success",
+			threads:     "64\n",
+			wantThreads: 64,
+		},
+		{
+			testName: "owner_error",
+			ownerErr: errors.New("not found"),
+			wantErr:  "getting resource pool owner: not found",
+		},
+		{
+			testName: "threads_corrupt",
+			threads:  "corrupt",
+			wantErr:  "parsing cpu threads for resource pool */Resources/Test-ResourcePool: strconv.Atoi: parsing \"corrupt\": invalid syntax",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			_, govc, executable, env := setup(t)
+			executable.EXPECT().ExecuteWithEnv(ctx, env, "object.collect", "-s", "-dc", datacenter, resourcePool, "owner").Return(*bytes.NewBufferString(owner + "\n"), tt.ownerErr)
+			if tt.ownerErr == nil {
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "object.collect", "-s", "-dc", datacenter, owner, "summary.numCpuThreads").Return(*bytes.NewBufferString(tt.threads), nil)
+			}
+
+			threads, err := govc.GetResourcePoolCPUThreads(ctx, datacenter, resourcePool)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(threads).To(Equal(tt.wantThreads))
+		})
+	}
+}
+
+func TestGovcGetResourcePoolHostCount(t *testing.T) {
+	datacenter := "SDDC-Datacenter"
+	resourcePool := "*/Resources/Test-ResourcePool"
+	owner := "ClusterComputeResource:domain-c7"
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "object.collect", "-s", "-dc", datacenter, resourcePool, "owner").Return(*bytes.NewBufferString(owner + "\n"), nil)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "object.collect", "-s", "-dc", datacenter, owner, "summary.numHosts").Return(*bytes.NewBufferString("3\n"), nil)
+
+	hosts, err := g.GetResourcePoolHostCount(ctx, datacenter, resourcePool)
+	gt := NewWithT(t)
+	gt.Expect(err).ToNot(HaveOccurred())
+	gt.Expect(hosts).To(Equal(3))
+}
+
+func TestGovcApplyAntiAffinityRule(t *testing.T) {
+	datacenter := "SDDC-Datacenter"
+	resourcePool := "*/Resources"
+	owner := "ClusterComputeResource:domain-c7"
+	cluster := "/SDDC-Datacenter/host/Cluster-1"
+	ctx := context.Background()
+	rule := executables.AntiAffinityRule{
+		Name:      "test-control-plane",
+		VMs:       []string{"cp-1", "cp-2", "cp-3"},
+		Mandatory: true,
+	}
+
+	tests := []struct {
+		testName  string
+		owner     string
+		rule      executables.AntiAffinityRule
+		setupMock func(executable *mockexecutables.MockExecutable, env map[string]string)
+		wantErr   string
+	}{
+		{
+			testName: "create",
+			owner:    owner,
+			rule:     rule,
+			setupMock: func(executable *mockexecutables.MockExecutable, env map[string]string) {
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.ls", "-cluster", cluster, "-json").Return(*bytes.NewBufferString("null"), nil)
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.create", "-cluster", cluster, "-name", rule.Name, "-enable", "-mandatory=true", "-anti-affinity", "cp-1", "cp-2", "cp-3")
+			},
+		},
+		{
+			testName: "unchanged",
+			owner:    owner,
+			rule:     rule,
+			setupMock: func(executable *mockexecutables.MockExecutable, env map[string]string) {
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.ls", "-cluster", cluster, "-json").Return(*bytes.NewBufferString(`["test-control-plane"]`), nil)
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.ls", "-cluster", cluster, "-name", rule.Name, "-json").Return(*bytes.NewBufferString(`["cp-3","cp-1","cp-2"]`), nil)
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.change", "-cluster", cluster, "-name", rule.Name, "-enable", "-mandatory=true")
+			},
+		},
+		{
+			testName: "replace",
+			owner:    owner,
+			rule:     rule,
+			setupMock: func(executable *mockexecutables.MockExecutable, env map[string]string) {
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.ls", "-cluster", cluster, "-json").Return(*bytes.NewBufferString(`["test-control-plane"]`), nil)
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.ls", "-cluster", cluster, "-name", rule.Name, "-json").Return(*bytes.NewBufferString(`["cp-0","cp-1","cp-2"]`), nil)
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.remove", "-cluster", cluster, "-name", rule.Name)
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.create", "-cluster", cluster, "-name", rule.Name, "-enable", "-mandatory=true", "-anti-affinity", "cp-1", "cp-2", "cp-3")
+			},
+		},
+		{
+			testName: "host group",
+			owner:    owner,
+			rule: executables.AntiAffinityRule{
+				Name:      "test-control-plane",
+				VMs:       []string{"cp-1", "cp-2"},
+				HostGroup: "rack-a",
+			},
+			setupMock: func(executable *mockexecutables.MockExecutable, env map[string]string) {
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.ls", "-cluster", cluster, "-json").Return(*bytes.NewBufferString("null"), nil)
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.create", "-cluster", cluster, "-name", "test-control-plane", "-enable", "-mandatory=false", "-anti-affinity", "cp-1", "cp-2")
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.group.ls", "-cluster", cluster, "-json").Return(*bytes.NewBufferString(`["rack-a"]`), nil)
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.group.create", "-cluster", cluster, "-name", "test-control-plane-vms", "-vm", "cp-1", "cp-2")
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.create", "-cluster", cluster, "-name", "test-control-plane-hosts", "-enable", "-mandatory=false", "-vm-host", "-vm-group", "test-control-plane-vms", "-host-affine-group", "rack-a")
+			},
+		},
+		{
+			testName: "host group not found",
+			owner:    owner,
+			rule: executables.AntiAffinityRule{
+				Name:      "test-control-plane",
+				VMs:       []string{"cp-1", "cp-2"},
+				HostGroup: "rack-a",
+			},
+			setupMock: func(executable *mockexecutables.MockExecutable, env map[string]string) {
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.ls", "-cluster", cluster, "-json").Return(*bytes.NewBufferString(`["test-control-plane"]`), nil)
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.ls", "-cluster", cluster, "-name", "test-control-plane", "-json").Return(*bytes.NewBufferString(`["cp-1","cp-2"]`), nil)
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.change", "-cluster", cluster, "-name", "test-control-plane", "-enable", "-mandatory=false")
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.group.ls", "-cluster", cluster, "-json").Return(*bytes.NewBufferString("null"), nil)
+			},
+			wantErr: "DRS host group rack-a not found in compute cluster /SDDC-Datacenter/host/Cluster-1",
+		},
+		{
+			testName: "standalone host",
+			owner:    "ComputeResource:domain-s1",
+			rule:     rule,
+			wantErr:  "resource pool */Resources does not belong to a compute cluster, DRS rules are not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			_, govc, executable, env := setup(t)
+			executable.EXPECT().ExecuteWithEnv(ctx, env, "object.collect", "-s", "-dc", datacenter, resourcePool, "owner").Return(*bytes.NewBufferString(tt.owner + "\n"), nil)
+			if tt.setupMock != nil {
+				executable.EXPECT().ExecuteWithEnv(ctx, env, "ls", "-L", owner).Return(*bytes.NewBufferString(cluster + "\n"), nil)
+				tt.setupMock(executable, env)
+			}
+
+			err := govc.ApplyAntiAffinityRule(ctx, datacenter, resourcePool, tt.rule)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func TestGovcListVMs(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, govc, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "/SDDC-Datacenter", "-type", "VirtualMachine", "-name", "test-*").Return(
+		*bytes.NewBufferString("/SDDC-Datacenter/vm/test-cp-1\n/SDDC-Datacenter/vm/my vms/test-md-0-1\n"), nil,
+	)
+
+	vms, err := govc.ListVMs(ctx, "SDDC-Datacenter", "test-*")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(vms).To(Equal([]string{"/SDDC-Datacenter/vm/test-cp-1", "/SDDC-Datacenter/vm/my vms/test-md-0-1"}))
+}
+
+func TestGovcDestroyVM(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	vm := "/SDDC-Datacenter/vm/test-cp-1"
+	_, govc, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "vm.power", "-off", "-force", vm).Return(bytes.Buffer{}, errors.New("already off"))
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "object.destroy", vm)
+
+	g.Expect(govc.DestroyVM(ctx, vm)).To(Succeed())
+}
+
+func TestGovcDestroyVMError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	vm := "/SDDC-Datacenter/vm/test-cp-1"
+	_, govc, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "vm.power", "-off", "-force", vm)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "object.destroy", vm).Return(bytes.Buffer{}, errors.New("not found"))
+
+	g.Expect(govc.DestroyVM(ctx, vm)).To(MatchError("destroying vm /SDDC-Datacenter/vm/test-cp-1: not found"))
+}
+
+func TestGovcDRSRulesAndGroups(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	datacenter := "SDDC-Datacenter"
+	resourcePool := "*/Resources"
+	owner := "ClusterComputeResource:domain-c7"
+	cluster := "/SDDC-Datacenter/host/Cluster-1"
+	_, govc, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "object.collect", "-s", "-dc", datacenter, resourcePool, "owner").Return(*bytes.NewBufferString(owner + "\n"), nil).Times(4)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "ls", "-L", owner).Return(*bytes.NewBufferString(cluster + "\n"), nil).Times(4)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.ls", "-cluster", cluster, "-json").Return(*bytes.NewBufferString(`["test-cp-anti-affinity"]`), nil)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.remove", "-cluster", cluster, "-name", "test-cp-anti-affinity")
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.group.ls", "-cluster", cluster, "-json").Return(*bytes.NewBufferString(`["rack-a","test-cp-anti-affinity-vms"]`), nil)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.group.remove", "-cluster", cluster, "-name", "test-cp-anti-affinity-vms")
+
+	rules, err := govc.ListDRSRules(ctx, datacenter, resourcePool)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rules).To(Equal([]string{"test-cp-anti-affinity"}))
+	g.Expect(govc.RemoveDRSRule(ctx, datacenter, resourcePool, "test-cp-anti-affinity")).To(Succeed())
+
+	groups, err := govc.ListDRSGroups(ctx, datacenter, resourcePool)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(groups).To(Equal([]string{"rack-a", "test-cp-anti-affinity-vms"}))
+	g.Expect(govc.RemoveDRSGroup(ctx, datacenter, resourcePool, "test-cp-anti-affinity-vms")).To(Succeed())
+}
@@ -0,0 +1,115 @@
+package executables
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const virshPath = "virsh"
+
+// Virsh manages libvirt networks, storage volumes and domains through virsh. It's used to run
+// local virtual machines, like the ones of a virtual Tinkerbell lab.
+type Virsh struct {
+	Executable
+	uri string
+}
+
+// NewVirsh returns a new Virsh connected to the libvirt daemon at uri, like qemu:///system.
+func NewVirsh(executable Executable, uri string) *Virsh {
+	return &Virsh{
+		Executable: executable,
+		uri:        uri,
+	}
+}
+
+func (v *Virsh) execute(ctx context.Context, args ...string) (string, error) {
+	out, err := v.Execute(ctx, append([]string{"--connect", v.uri}, args...)...)
+	return out.String(), err
+}
+
+func (v *Virsh) executeWithStdin(ctx context.Context, in []byte, args ...string) error {
+	_, err := v.ExecuteWithStdin(ctx, in, append([]string{"--connect", v.uri}, args...)...)
+	return err
+}
+
+// DefineNetwork creates a persistent network from its libvirt XML definition.
+func (v *Virsh) DefineNetwork(ctx context.Context, definition []byte) error {
+	if err := v.executeWithStdin(ctx, definition, "net-define", "/dev/stdin"); err != nil {
+		return fmt.Errorf("defining libvirt network: %v", err)
+	}
+	return nil
+}
+
+// StartNetwork starts a defined network.
+func (v *Virsh) StartNetwork(ctx context.Context, name string) error {
+	if _, err := v.execute(ctx, "net-start", name); err != nil {
+		return fmt.Errorf("starting libvirt network %s: %v", name, err)
+	}
+	return nil
+}
+
+// DeleteNetwork stops a network, when it's active, and removes its definition.
+func (v *Virsh) DeleteNetwork(ctx context.Context, name string) error {
+	if _, err := v.execute(ctx, "net-destroy", name); err != nil && !strings.Contains(err.Error(), "is not active") {
+		return fmt.Errorf("stopping libvirt network %s: %v", name, err)
+	}
+	if _, err := v.execute(ctx, "net-undefine", name); err != nil {
+		return fmt.Errorf("undefining libvirt network %s: %v", name, err)
+	}
+	return nil
+}
+
+// ListNetworks returns the names of the active and inactive networks.
+func (v *Virsh) ListNetworks(ctx context.Context) ([]string, error) {
+	out, err := v.execute(ctx, "net-list", "--all", "--name")
+	if err != nil {
+		return nil, fmt.Errorf("listing libvirt networks: %v", err)
+	}
+	return strings.Fields(out), nil
+}
+
+// CreateVolume creates a qcow2 volume of size, like 40G, in a storage pool.
+func (v *Virsh) CreateVolume(ctx context.Context, pool, name, size string) error {
+	if _, err := v.execute(ctx, "vol-create-as", pool, name, size, "--format", "qcow2"); err != nil {
+		return fmt.Errorf("creating libvirt volume %s in pool %s: %v", name, pool, err)
+	}
+	return nil
+}
+
+// DefineDomain creates a persistent domain from its libvirt XML definition without starting it.
+func (v *Virsh) DefineDomain(ctx context.Context, definition []byte) error {
+	if err := v.executeWithStdin(ctx, definition, "define", "/dev/stdin"); err != nil {
+		return fmt.Errorf("defining libvirt domain: %v", err)
+	}
+	return nil
+}
+
+// DomainUUID returns the UUID of a domain.
+func (v *Virsh) DomainUUID(ctx context.Context, name string) (string, error) {
+	out, err := v.execute(ctx, "domuuid", name)
+	if err != nil {
+		return "", fmt.Errorf("getting uuid of libvirt domain %s: %v", name, err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// ListDomains returns the names of the running and stopped domains.
+func (v *Virsh) ListDomains(ctx context.Context) ([]string, error) {
+	out, err := v.execute(ctx, "list", "--all", "--name")
+	if err != nil {
+		return nil, fmt.Errorf("listing libvirt domains: %v", err)
+	}
+	return strings.Fields(out), nil
+}
+
+// DeleteDomain powers off a domain, when it's running, and removes its definition and volumes.
+func (v *Virsh) DeleteDomain(ctx context.Context, name string) error {
+	if _, err := v.execute(ctx, "destroy", name); err != nil && !strings.Contains(err.Error(), "domain is not running") {
+		return fmt.Errorf("stopping libvirt domain %s: %v", name, err)
+	}
+	if _, err := v.execute(ctx, "undefine", name, "--nvram", "--remove-all-storage"); err != nil {
+		return fmt.Errorf("undefining libvirt domain %s: %v", name, err)
+	}
+	return nil
+}
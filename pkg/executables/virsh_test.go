@@ -0,0 +1,98 @@
+package executables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
+)
+
+const virshTestURI = "qemu:///system"
+
+func newVirsh(t *testing.T) (*executables.Virsh, *mockexecutables.MockExecutable) {
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	return executables.NewVirsh(executable, virshTestURI), executable
+}
+
+func TestVirshDefineNetwork(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	virsh, executable := newVirsh(t)
+	definition := []byte("<network/>")
+
+	executable.EXPECT().ExecuteWithStdin(ctx, definition, "--connect", virshTestURI, "net-define", "/dev/stdin").Return(bytes.Buffer{}, nil)
+
+	g.Expect(virsh.DefineNetwork(ctx, definition)).To(Succeed())
+}
+
+func TestVirshDeleteNetworkNotActive(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	virsh, executable := newVirsh(t)
+
+	executable.EXPECT().Execute(ctx, "--connect", virshTestURI, "net-destroy", "lab").Return(bytes.Buffer{}, errors.New("error: network 'lab' is not active"))
+	executable.EXPECT().Execute(ctx, "--connect", virshTestURI, "net-undefine", "lab").Return(bytes.Buffer{}, nil)
+
+	g.Expect(virsh.DeleteNetwork(ctx, "lab")).To(Succeed())
+}
+
+func TestVirshCreateVolume(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	virsh, executable := newVirsh(t)
+
+	executable.EXPECT().Execute(ctx, "--connect", virshTestURI, "vol-create-as", "default", "lab-cp-0.qcow2", "40G", "--format", "qcow2").Return(bytes.Buffer{}, errors.New("pool not found"))
+
+	g.Expect(virsh.CreateVolume(ctx, "default", "lab-cp-0.qcow2", "40G")).To(MatchError("creating libvirt volume lab-cp-0.qcow2 in pool default: pool not found"))
+}
+
+func TestVirshDomainUUID(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	virsh, executable := newVirsh(t)
+
+	executable.EXPECT().Execute(ctx, "--connect", virshTestURI, "domuuid", "lab-cp-0").Return(*bytes.NewBufferString("4b7b2a31-7a4e-4d2c-9a36-9b0e6a1c7d2f\n\n"), nil)
+
+	uuid, err := virsh.DomainUUID(ctx, "lab-cp-0")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(uuid).To(Equal("4b7b2a31-7a4e-4d2c-9a36-9b0e6a1c7d2f"))
+}
+
+func TestVirshListDomains(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	virsh, executable := newVirsh(t)
+
+	executable.EXPECT().Execute(ctx, "--connect", virshTestURI, "list", "--all", "--name").Return(*bytes.NewBufferString("lab-cp-0\nlab-worker-0\n\n"), nil)
+
+	domains, err := virsh.ListDomains(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(domains).To(Equal([]string{"lab-cp-0", "lab-worker-0"}))
+}
+
+func TestVirshDeleteDomain(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	virsh, executable := newVirsh(t)
+
+	executable.EXPECT().Execute(ctx, "--connect", virshTestURI, "destroy", "lab-cp-0").Return(bytes.Buffer{}, errors.New("error: Requested operation is not valid: domain is not running"))
+	executable.EXPECT().Execute(ctx, "--connect", virshTestURI, "undefine", "lab-cp-0", "--nvram", "--remove-all-storage").Return(bytes.Buffer{}, nil)
+
+	g.Expect(virsh.DeleteDomain(ctx, "lab-cp-0")).To(Succeed())
+}
+
+func TestVirshDeleteDomainError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	virsh, executable := newVirsh(t)
+
+	executable.EXPECT().Execute(ctx, "--connect", virshTestURI, "destroy", "lab-cp-0").Return(bytes.Buffer{}, errors.New("failed to connect to the hypervisor"))
+
+	g.Expect(virsh.DeleteDomain(ctx, "lab-cp-0")).To(MatchError("stopping libvirt domain lab-cp-0: failed to connect to the hypervisor"))
+}
@@ -7,6 +7,7 @@ const (
 	UseControllerForCli             = "USE_CONTROLLER_FOR_CLI"
 	VSphereInPlaceEnvVar            = "VSPHERE_IN_PLACE_UPGRADE"
 	APIServerExtraArgsEnabledEnvVar = "API_SERVER_EXTRA_ARGS_ENABLED"
+	VSphereGovcClientEnvVar         = "VSPHERE_GOVC_CLIENT"
 )
 
 func FeedGates(featureGates []string) {
@@ -56,3 +57,11 @@ func APIServerExtraArgsEnabled() Feature {
 		IsActive: globalFeatures.isActiveForEnvVar(APIServerExtraArgsEnabledEnvVar),
 	}
 }
+
+// VSphereGovcClientEnabled is the feature flag for using govc instead of the native govmomi client in the vSphere provider.
+func VSphereGovcClientEnabled() Feature {
+	return Feature{
+		Name:     "Use govc instead of the native govmomi client in the vSphere provider",
+		IsActive: globalFeatures.isActiveForEnvVar(VSphereGovcClientEnvVar),
+	}
+}
@@ -85,3 +85,11 @@ func TestAPIServerExtraArgsEnabledFeatureFlag(t *testing.T) {
 	g.Expect(os.Setenv(APIServerExtraArgsEnabledEnvVar, "true")).To(Succeed())
 	g.Expect(IsActive(APIServerExtraArgsEnabled())).To(BeTrue())
 }
+
+func TestVSphereGovcClientEnabledFeatureFlag(t *testing.T) {
+	g := NewWithT(t)
+	setupContext(t)
+
+	g.Expect(os.Setenv(VSphereGovcClientEnvVar, "true")).To(Succeed())
+	g.Expect(IsActive(VSphereGovcClientEnabled())).To(BeTrue())
+}
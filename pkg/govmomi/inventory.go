@@ -0,0 +1,730 @@
+package govmomi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/vmware/govmomi/fault"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	byteToGiB = 1073741824.0
+	byteToMiB = 1048576
+	kibToGiB  = 1024 * 1024
+
+	disk1 = "Hard disk 1"
+	disk2 = "Hard disk 2"
+
+	datastoreFolder = "datastore"
+	vmFolder        = "vm"
+)
+
+func isNotFound(err error) bool {
+	var notFound *find.NotFoundError
+	return errors.As(err, &notFound)
+}
+
+// prependPath mirrors the path resolution used by govc so relative and absolute paths
+// resolve to the same objects with either client.
+func prependPath(folderType, folderPath, datacenter string) (string, error) {
+	prefix := fmt.Sprintf("/%s", datacenter)
+	if !strings.HasPrefix(folderPath, prefix) {
+		modPath := fmt.Sprintf("%s/%s/%s", prefix, folderType, folderPath)
+		logger.V(4).Info(fmt.Sprintf("Relative %s path specified, using path %s", folderType, modPath))
+		return modPath, nil
+	}
+	prefix += fmt.Sprintf("/%s", folderType)
+	if !strings.HasPrefix(folderPath, prefix) {
+		return folderPath, fmt.Errorf("invalid folder type, expected path under %s", prefix)
+	}
+	return folderPath, nil
+}
+
+// findPaths returns the inventory paths of the objects of kind named name under datacenter.
+func (p *ProviderClient) findPaths(ctx context.Context, datacenter, kind, name string) ([]string, error) {
+	f, err := p.finder(ctx, datacenter)
+	if err != nil {
+		return nil, err
+	}
+	dc, err := f.DefaultDatacenter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vc, err := p.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := view.NewManager(vc).CreateContainerView(ctx, dc.Reference(), []string{kind}, true)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = v.Destroy(ctx) }()
+
+	refs, err := v.Find(ctx, []string{kind}, property.Match{"name": name})
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		path, err := find.InventoryPath(ctx, vc, ref)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// findUniquePath returns the only path in paths ending with suffix.
+func findUniquePath(paths []string, suffix string) (path string, found bool, multiple bool) {
+	for _, p := range paths {
+		if strings.HasSuffix(p, suffix) {
+			if found {
+				return "", true, true
+			}
+			found = true
+			path = p
+		}
+	}
+	return path, found, false
+}
+
+// SearchTemplate looks for a vm template with the same base name as the provided template path.
+// If found, it returns the full qualified path to the template.
+// If multiple matching templates are found, it returns an error.
+func (p *ProviderClient) SearchTemplate(ctx context.Context, datacenter, template string) (string, error) {
+	paths, err := p.findPaths(ctx, datacenter, "VirtualMachine", filepath.Base(template))
+	if err != nil {
+		return "", fmt.Errorf("getting template: %v", err)
+	}
+
+	found, ok, multiple := findUniquePath(paths, template)
+	if multiple {
+		return "", fmt.Errorf("specified template '%s' maps to multiple paths within the datacenter '%s'", template, datacenter)
+	}
+	if !ok {
+		logger.V(2).Info(fmt.Sprintf("Template '%s' not found", template))
+		return "", nil
+	}
+
+	return found, nil
+}
+
+// TemplateHasSnapshot returns true if the template has at least one snapshot.
+func (p *ProviderClient) TemplateHasSnapshot(ctx context.Context, template string) (bool, error) {
+	vm, err := p.virtualMachine(ctx, "", template)
+	if err != nil {
+		return false, fmt.Errorf("failed to get snapshot details: %v", err)
+	}
+
+	var props mo.VirtualMachine
+	if err := vm.Properties(ctx, vm.Reference(), []string{"snapshot"}, &props); err != nil {
+		return false, fmt.Errorf("failed to get snapshot details: %v", err)
+	}
+
+	return props.Snapshot != nil && len(props.Snapshot.RootSnapshotList) > 0, nil
+}
+
+// GetWorkloadAvailableSpace returns the free space of the datastore in GiB.
+func (p *ProviderClient) GetWorkloadAvailableSpace(ctx context.Context, datastore string) (float64, error) {
+	f, err := p.finder(ctx, "")
+	if err != nil {
+		return 0, fmt.Errorf("getting datastore info: %v", err)
+	}
+
+	ds, err := f.Datastore(ctx, datastore)
+	if err != nil {
+		return 0, fmt.Errorf("getting datastore info: %v", err)
+	}
+
+	var props mo.Datastore
+	if err := ds.Properties(ctx, ds.Reference(), []string{"summary"}, &props); err != nil {
+		return 0, fmt.Errorf("getting datastore info: %v", err)
+	}
+
+	return float64(props.Summary.FreeSpace) / byteToGiB, nil
+}
+
+// DatacenterExists checks if the datacenter exists.
+func (p *ProviderClient) DatacenterExists(ctx context.Context, datacenter string) (bool, error) {
+	vc, err := p.client(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get datacenter: %v", err)
+	}
+
+	_, err = find.NewFinder(vc, true).Datacenter(ctx, datacenter)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get datacenter: %v", err)
+	}
+
+	return true, nil
+}
+
+// NetworkExists checks if the network exists.
+func (p *ProviderClient) NetworkExists(ctx context.Context, network string) (bool, error) {
+	f, err := p.finder(ctx, "")
+	if err != nil {
+		return false, fmt.Errorf("failed checking if network '%s' exists: %v", network, err)
+	}
+
+	_, err = f.Network(ctx, network)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed checking if network '%s' exists: %v", network, err)
+	}
+
+	return true, nil
+}
+
+// GetDatastorePath validates and returns the full path to a datastore in the specified datacenter.
+// Returns an error if the datastore doesn't exist or if the path is invalid.
+func (p *ProviderClient) GetDatastorePath(ctx context.Context, datacenter string, datastorePath string, _ map[string]string) (string, error) {
+	fullPath, err := prependPath(datastoreFolder, datastorePath, datacenter)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := p.finder(ctx, datacenter)
+	if err != nil {
+		return "", fmt.Errorf("failed to get datastore: %v", err)
+	}
+
+	if _, err := f.Datastore(ctx, fullPath); err != nil {
+		if _, folderErr := f.Folder(ctx, filepath.Dir(fullPath)); folderErr == nil {
+			return "", fmt.Errorf("failed to get datastore: valid path, but '%s' is not a datastore", filepath.Base(fullPath))
+		}
+		return "", fmt.Errorf("failed to get datastore: %v", err)
+	}
+
+	logger.MarkPass("Datastore validated")
+	return fullPath, nil
+}
+
+// GetFolderPath validates or creates a folder in the specified datacenter.
+// Returns the full path to the folder or an error if creation fails.
+func (p *ProviderClient) GetFolderPath(ctx context.Context, datacenter string, folder string, _ map[string]string) (string, error) {
+	if len(folder) == 0 {
+		return "", nil
+	}
+
+	fullPath, err := prependPath(vmFolder, folder, datacenter)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := p.finder(ctx, datacenter)
+	if err != nil {
+		return "", fmt.Errorf("failed to get folder: %v", err)
+	}
+
+	if _, err := f.Folder(ctx, fullPath); err != nil {
+		if err := p.createFolder(ctx, f, fullPath); err != nil {
+			currPath := "/" + datacenter + "/"
+			dirs := strings.Split(fullPath, "/")
+			for _, dir := range dirs[2:] {
+				currPath += dir + "/"
+				if _, err := f.Folder(ctx, currPath); err != nil {
+					return "", fmt.Errorf("failed to get folder: %s is an invalid intermediate directory", currPath)
+				}
+			}
+			return "", fmt.Errorf("failed to get folder: %v", err)
+		}
+	}
+
+	logger.MarkPass("Folder validated")
+	return fullPath, nil
+}
+
+func (p *ProviderClient) createFolder(ctx context.Context, f *find.Finder, folderPath string) error {
+	parent, err := f.Folder(ctx, filepath.Dir(folderPath))
+	if err != nil {
+		return fmt.Errorf("creating folder: %v", err)
+	}
+
+	if _, err := parent.CreateFolder(ctx, filepath.Base(folderPath)); err != nil {
+		if fault.Is(err, &types.DuplicateName{}) {
+			return nil
+		}
+		return fmt.Errorf("creating folder: %v", err)
+	}
+
+	return nil
+}
+
+// GetResourcePoolPath finds and validates a resource pool in the specified datacenter.
+// Returns an error if the pool doesn't exist or if multiple matching pools are found.
+func (p *ProviderClient) GetResourcePoolPath(ctx context.Context, datacenter string, resourcePool string, _ map[string]string) (string, error) {
+	paths, err := p.findPaths(ctx, datacenter, "ResourcePool", filepath.Base(resourcePool))
+	if err != nil {
+		return "", fmt.Errorf("getting resource pool: %v", err)
+	}
+
+	resourcePool = strings.TrimPrefix(resourcePool, "*/")
+	found, ok, multiple := findUniquePath(paths, resourcePool)
+	if multiple {
+		return "", fmt.Errorf("specified resource pool '%s' maps to multiple paths within the datacenter '%s'", resourcePool, datacenter)
+	}
+	if !ok {
+		return "", fmt.Errorf("resource pool '%s' not found", resourcePool)
+	}
+
+	logger.MarkPass("Resource pool validated")
+	return found, nil
+}
+
+// GetComputeClusterPath finds and validates a compute cluster in the specified datacenter.
+// Returns an error if the compute cluster doesn't exist or if multiple matching compute clusters are found.
+func (p *ProviderClient) GetComputeClusterPath(ctx context.Context, datacenter string, computeCluster string, _ map[string]string) (string, error) {
+	paths, err := p.findPaths(ctx, datacenter, "ClusterComputeResource", filepath.Base(computeCluster))
+	if err != nil {
+		return "", fmt.Errorf("getting compute cluster: %v", err)
+	}
+
+	computeCluster = strings.TrimPrefix(computeCluster, "*/")
+	found, ok, multiple := findUniquePath(paths, computeCluster)
+	if multiple {
+		return "", fmt.Errorf("specified compute cluster '%s' maps to multiple paths within the datacenter '%s'", computeCluster, datacenter)
+	}
+	if !ok {
+		return "", fmt.Errorf("compute cluster '%s' not found", computeCluster)
+	}
+
+	logger.MarkPass("Compute cluster validated")
+	return found, nil
+}
+
+// ValidateVCenterSetupMachineConfig validates that all resources specified in a
+// VSphereMachineConfig exist and are accessible.
+func (p *ProviderClient) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfig *v1alpha1.VSphereMachineConfig, _ *bool) error {
+	datastore, err := p.GetDatastorePath(ctx, datacenterConfig.Spec.Datacenter, machineConfig.Spec.Datastore, nil)
+	if err != nil {
+		return err
+	}
+	machineConfig.Spec.Datastore = datastore
+
+	folder, err := p.GetFolderPath(ctx, datacenterConfig.Spec.Datacenter, machineConfig.Spec.Folder, nil)
+	if err != nil {
+		return err
+	}
+	machineConfig.Spec.Folder = folder
+
+	resourcePool, err := p.GetResourcePoolPath(ctx, datacenterConfig.Spec.Datacenter, machineConfig.Spec.ResourcePool, nil)
+	if err != nil {
+		return err
+	}
+	machineConfig.Spec.ResourcePool = resourcePool
+
+	return nil
+}
+
+// ValidateFailureDomainConfig validates that all resources specified in a VSphere
+// failure domain exist and are accessible.
+func (p *ProviderClient) ValidateFailureDomainConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, failureDomain *v1alpha1.FailureDomain) error {
+	datastore, err := p.GetDatastorePath(ctx, datacenterConfig.Spec.Datacenter, failureDomain.Datastore, nil)
+	if err != nil {
+		return err
+	}
+	failureDomain.Datastore = datastore
+
+	folder, err := p.GetFolderPath(ctx, datacenterConfig.Spec.Datacenter, failureDomain.Folder, nil)
+	if err != nil {
+		return err
+	}
+	failureDomain.Folder = folder
+
+	resourcePool, err := p.GetResourcePoolPath(ctx, datacenterConfig.Spec.Datacenter, failureDomain.ResourcePool, nil)
+	if err != nil {
+		return err
+	}
+	failureDomain.ResourcePool = resourcePool
+
+	computeCluster, err := p.GetComputeClusterPath(ctx, datacenterConfig.Spec.Datacenter, failureDomain.ComputeCluster, nil)
+	if err != nil {
+		return err
+	}
+	failureDomain.ComputeCluster = computeCluster
+
+	return nil
+}
+
+func (p *ProviderClient) virtualMachine(ctx context.Context, datacenter, vm string) (*object.VirtualMachine, error) {
+	f, err := p.finder(ctx, datacenter)
+	if err != nil {
+		return nil, err
+	}
+	return f.VirtualMachine(ctx, vm)
+}
+
+func (p *ProviderClient) disks(ctx context.Context, datacenter, vm string) ([]*types.VirtualDisk, error) {
+	machine, err := p.virtualMachine(ctx, datacenter, vm)
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := machine.Device(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var disks []*types.VirtualDisk
+	for _, d := range devices.SelectByType((*types.VirtualDisk)(nil)) {
+		disks = append(disks, d.(*types.VirtualDisk))
+	}
+
+	return disks, nil
+}
+
+func diskLabel(disk *types.VirtualDisk) string {
+	if disk.DeviceInfo == nil {
+		return ""
+	}
+	return disk.DeviceInfo.GetDescription().Label
+}
+
+// GetVMDiskSizeInGB returns the size of the first disk on the VM in GB.
+func (p *ProviderClient) GetVMDiskSizeInGB(ctx context.Context, vm, datacenter string) (int, error) {
+	disks, err := p.disks(ctx, datacenter, vm)
+	if err != nil {
+		return 0, fmt.Errorf("getting disk size for vm %s: %v", vm, err)
+	}
+
+	if len(disks) == 0 {
+		return 0, fmt.Errorf("no disks found for vm %s", vm)
+	}
+
+	return int(disks[0].CapacityInKB / kibToGiB), nil
+}
+
+// GetHardDiskSize returns the size of all the hard disks for given VM.
+func (p *ProviderClient) GetHardDiskSize(ctx context.Context, vm, datacenter string) (map[string]float64, error) {
+	disks, err := p.disks(ctx, datacenter, vm)
+	if err != nil {
+		return nil, fmt.Errorf("getting hard disk sizes for vm %s: %v", vm, err)
+	}
+
+	if len(disks) == 0 {
+		return nil, fmt.Errorf("no hard disks found for vm %s", vm)
+	}
+
+	hardDiskMap := make(map[string]float64)
+	for _, disk := range disks {
+		label := diskLabel(disk)
+		if strings.EqualFold(label, disk1) {
+			hardDiskMap[disk1] = float64(disk.CapacityInKB)
+		} else if strings.EqualFold(label, disk2) {
+			hardDiskMap[disk2] = float64(disk.CapacityInKB)
+		}
+	}
+	return hardDiskMap, nil
+}
+
+// GetResourcePoolInfo returns the memory available in MiB in the provided resource pool,
+// or -1 if the pool has no memory limit. Extra args are accepted for compatibility with govc
+// and ignored.
+func (p *ProviderClient) GetResourcePoolInfo(ctx context.Context, datacenter, resourcepool string, _ ...string) (map[string]int, error) {
+	f, err := p.finder(ctx, datacenter)
+	if err != nil {
+		return nil, fmt.Errorf("getting resource pool information: %v", err)
+	}
+
+	pool, err := f.ResourcePool(ctx, resourcepool)
+	if err != nil {
+		return nil, fmt.Errorf("getting resource pool information: %v", err)
+	}
+
+	var props mo.ResourcePool
+	if err := pool.Properties(ctx, pool.Reference(), []string{"config.memoryAllocation", "runtime.memory"}, &props); err != nil {
+		return nil, fmt.Errorf("getting resource pool information: %v", err)
+	}
+
+	memoryLimit := -1
+	if limit := props.Config.MemoryAllocation.Limit; limit != nil {
+		memoryLimit = int(*limit)
+	}
+
+	poolInfo := make(map[string]int)
+	if memoryLimit != -1 {
+		memoryUsed := int(props.Runtime.Memory.OverallUsage / byteToMiB)
+		poolInfo[executables.MemoryAvailable] = memoryLimit - memoryUsed
+	} else {
+		poolInfo[executables.MemoryAvailable] = memoryLimit
+	}
+	return poolInfo, nil
+}
+
+// GetResourcePoolCPUThreads returns the number of logical CPU threads of the cluster or host backing the resource pool.
+func (p *ProviderClient) GetResourcePoolCPUThreads(ctx context.Context, datacenter, resourcePool string) (int, error) {
+	summary, err := p.resourcePoolOwnerSummary(ctx, datacenter, resourcePool)
+	if err != nil {
+		return 0, fmt.Errorf("getting cpu threads for resource pool %s: %v", resourcePool, err)
+	}
+
+	return int(summary.NumCpuThreads), nil
+}
+
+// GetResourcePoolHostCount returns the number of ESXi hosts of the cluster or host backing the resource pool.
+func (p *ProviderClient) GetResourcePoolHostCount(ctx context.Context, datacenter, resourcePool string) (int, error) {
+	summary, err := p.resourcePoolOwnerSummary(ctx, datacenter, resourcePool)
+	if err != nil {
+		return 0, fmt.Errorf("getting hosts for resource pool %s: %v", resourcePool, err)
+	}
+
+	return int(summary.NumHosts), nil
+}
+
+func (p *ProviderClient) resourcePoolOwner(ctx context.Context, datacenter, resourcePool string) (*object.ComputeResource, error) {
+	f, err := p.finder(ctx, datacenter)
+	if err != nil {
+		return nil, fmt.Errorf("getting resource pool owner: %v", err)
+	}
+
+	pool, err := f.ResourcePool(ctx, resourcePool)
+	if err != nil {
+		return nil, fmt.Errorf("getting resource pool owner: %v", err)
+	}
+
+	owner, err := pool.Owner(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting resource pool owner: %v", err)
+	}
+
+	return object.NewComputeResource(pool.Client(), owner.Reference()), nil
+}
+
+func (p *ProviderClient) resourcePoolOwnerSummary(ctx context.Context, datacenter, resourcePool string) (*types.ComputeResourceSummary, error) {
+	owner, err := p.resourcePoolOwner(ctx, datacenter, resourcePool)
+	if err != nil {
+		return nil, err
+	}
+
+	var props mo.ComputeResource
+	if err := owner.Properties(ctx, owner.Reference(), []string{"summary"}, &props); err != nil {
+		return nil, err
+	}
+	if props.Summary == nil {
+		return nil, errors.New("owner has no summary")
+	}
+
+	return props.Summary.GetComputeResourceSummary(), nil
+}
+
+// ApplyAntiAffinityRule creates or updates the DRS rules of the compute cluster backing the resource pool
+// so the rule VMs are kept on different hosts and, if it has a HostGroup, on the hosts of that group.
+func (p *ProviderClient) ApplyAntiAffinityRule(ctx context.Context, datacenter, resourcePool string, rule executables.AntiAffinityRule) error {
+	cluster, err := p.computeCluster(ctx, datacenter, resourcePool)
+	if err != nil {
+		return err
+	}
+
+	vms := make([]types.ManagedObjectReference, 0, len(rule.VMs))
+	for _, name := range rule.VMs {
+		vm, err := p.virtualMachine(ctx, datacenter, name)
+		if err != nil {
+			return fmt.Errorf("getting VM %s for DRS rule %s: %v", name, rule.Name, err)
+		}
+		vms = append(vms, vm.Reference())
+	}
+
+	config, err := cluster.Configuration(ctx)
+	if err != nil {
+		return fmt.Errorf("getting compute cluster configuration: %v", err)
+	}
+
+	rules := map[string]int32{}
+	for _, r := range config.Rule {
+		info := r.GetClusterRuleInfo()
+		rules[info.Name] = info.Key
+	}
+	groups := map[string]struct{}{}
+	for _, g := range config.Group {
+		groups[g.GetClusterGroupInfo().Name] = struct{}{}
+	}
+
+	enabled := true
+	mandatory := rule.Mandatory
+	spec := &types.ClusterConfigSpecEx{}
+	spec.RulesSpec = append(spec.RulesSpec, clusterRuleSpec(rules, &types.ClusterAntiAffinityRuleSpec{
+		ClusterRuleInfo: types.ClusterRuleInfo{Name: rule.Name, Enabled: &enabled, Mandatory: &mandatory},
+		Vm:              vms,
+	}))
+
+	if rule.HostGroup != "" {
+		if _, ok := groups[rule.HostGroup]; !ok {
+			return fmt.Errorf("DRS host group %s not found in compute cluster %s", rule.HostGroup, cluster.InventoryPath)
+		}
+
+		operation := types.ArrayUpdateOperationAdd
+		if _, ok := groups[rule.VMGroupName()]; ok {
+			operation = types.ArrayUpdateOperationEdit
+		}
+		spec.GroupSpec = append(spec.GroupSpec, types.ClusterGroupSpec{
+			ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: operation},
+			Info: &types.ClusterVmGroup{
+				ClusterGroupInfo: types.ClusterGroupInfo{Name: rule.VMGroupName()},
+				Vm:               vms,
+			},
+		})
+
+		spec.RulesSpec = append(spec.RulesSpec, clusterRuleSpec(rules, &types.ClusterVmHostRuleInfo{
+			ClusterRuleInfo:     types.ClusterRuleInfo{Name: rule.VMHostRuleName(), Enabled: &enabled, Mandatory: &mandatory},
+			VmGroupName:         rule.VMGroupName(),
+			AffineHostGroupName: rule.HostGroup,
+		}))
+	}
+
+	task, err := cluster.Reconfigure(ctx, spec, true)
+	if err != nil {
+		return fmt.Errorf("applying DRS rule %s: %v", rule.Name, err)
+	}
+	if err := task.Wait(ctx); err != nil {
+		return fmt.Errorf("applying DRS rule %s: %v", rule.Name, err)
+	}
+
+	return nil
+}
+
+// clusterRuleSpec adds the rule or, if a rule with the same name exists, replaces it.
+func clusterRuleSpec(existing map[string]int32, rule types.BaseClusterRuleInfo) types.ClusterRuleSpec {
+	info := rule.GetClusterRuleInfo()
+	operation := types.ArrayUpdateOperationAdd
+	if key, ok := existing[info.Name]; ok {
+		operation = types.ArrayUpdateOperationEdit
+		info.Key = key
+	}
+
+	return types.ClusterRuleSpec{
+		ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: operation},
+		Info:            rule,
+	}
+}
+
+// ListDRSRules returns the names of the DRS rules of the compute cluster backing the resource pool.
+func (p *ProviderClient) ListDRSRules(ctx context.Context, datacenter, resourcePool string) ([]string, error) {
+	_, config, err := p.computeClusterConfiguration(ctx, datacenter, resourcePool)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(config.Rule))
+	for _, r := range config.Rule {
+		names = append(names, r.GetClusterRuleInfo().Name)
+	}
+	return names, nil
+}
+
+// RemoveDRSRule removes a DRS rule from the compute cluster backing the resource pool.
+func (p *ProviderClient) RemoveDRSRule(ctx context.Context, datacenter, resourcePool, name string) error {
+	cluster, config, err := p.computeClusterConfiguration(ctx, datacenter, resourcePool)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range config.Rule {
+		info := r.GetClusterRuleInfo()
+		if info.Name != name {
+			continue
+		}
+		spec := &types.ClusterConfigSpecEx{
+			RulesSpec: []types.ClusterRuleSpec{{
+				ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationRemove, RemoveKey: info.Key},
+			}},
+		}
+		return reconfigureCluster(ctx, cluster, spec, fmt.Sprintf("removing DRS rule %s", name))
+	}
+
+	return fmt.Errorf("DRS rule %s not found in compute cluster %s", name, cluster.InventoryPath)
+}
+
+// ListDRSGroups returns the names of the DRS VM and host groups of the compute cluster backing the resource pool.
+func (p *ProviderClient) ListDRSGroups(ctx context.Context, datacenter, resourcePool string) ([]string, error) {
+	_, config, err := p.computeClusterConfiguration(ctx, datacenter, resourcePool)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(config.Group))
+	for _, g := range config.Group {
+		names = append(names, g.GetClusterGroupInfo().Name)
+	}
+	return names, nil
+}
+
+// RemoveDRSGroup removes a DRS group from the compute cluster backing the resource pool.
+func (p *ProviderClient) RemoveDRSGroup(ctx context.Context, datacenter, resourcePool, name string) error {
+	cluster, err := p.computeCluster(ctx, datacenter, resourcePool)
+	if err != nil {
+		return err
+	}
+
+	spec := &types.ClusterConfigSpecEx{
+		GroupSpec: []types.ClusterGroupSpec{{
+			ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationRemove, RemoveKey: name},
+		}},
+	}
+	return reconfigureCluster(ctx, cluster, spec, fmt.Sprintf("removing DRS group %s", name))
+}
+
+// computeCluster returns the compute cluster backing the resource pool.
+func (p *ProviderClient) computeCluster(ctx context.Context, datacenter, resourcePool string) (*object.ClusterComputeResource, error) {
+	owner, err := p.resourcePoolOwner(ctx, datacenter, resourcePool)
+	if err != nil {
+		return nil, err
+	}
+	if owner.Reference().Type != "ClusterComputeResource" {
+		return nil, fmt.Errorf("resource pool %s does not belong to a compute cluster, DRS rules are not supported", resourcePool)
+	}
+	cluster := object.NewClusterComputeResource(owner.Client(), owner.Reference())
+	if cluster.InventoryPath, err = find.InventoryPath(ctx, owner.Client(), owner.Reference()); err != nil {
+		return nil, fmt.Errorf("getting compute cluster path for resource pool %s: %v", resourcePool, err)
+	}
+
+	return cluster, nil
+}
+
+func (p *ProviderClient) computeClusterConfiguration(ctx context.Context, datacenter, resourcePool string) (*object.ClusterComputeResource, *types.ClusterConfigInfoEx, error) {
+	cluster, err := p.computeCluster(ctx, datacenter, resourcePool)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config, err := cluster.Configuration(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting compute cluster configuration: %v", err)
+	}
+
+	return cluster, config, nil
+}
+
+func reconfigureCluster(ctx context.Context, cluster *object.ClusterComputeResource, spec *types.ClusterConfigSpecEx, action string) error {
+	task, err := cluster.Reconfigure(ctx, spec, true)
+	if err != nil {
+		return fmt.Errorf("%s: %v", action, err)
+	}
+	if err := task.Wait(ctx); err != nil {
+		return fmt.Errorf("%s: %v", action, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,429 @@
+package govmomi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/ovf/importer"
+	"github.com/vmware/govmomi/vapi/library"
+	"github.com/vmware/govmomi/vapi/library/finder"
+	"github.com/vmware/govmomi/vapi/vcenter"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	libraryImportPollInterval = 3 * time.Second
+	rootSnapshotName          = "root"
+)
+
+func (p *ProviderClient) libraryManager(ctx context.Context) (*library.Manager, error) {
+	rc, err := p.restClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return library.NewManager(rc), nil
+}
+
+// findLibraryElements returns the libraries or library items matching the library path.
+func (p *ProviderClient) findLibraryElements(ctx context.Context, m *library.Manager, element string) ([]finder.FindResult, error) {
+	return finder.NewFinder(m).Find(ctx, element)
+}
+
+// LibraryElementExists checks if a content library or library item exists.
+func (p *ProviderClient) LibraryElementExists(ctx context.Context, element string) (bool, error) {
+	m, err := p.libraryManager(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed getting library to check if it exists: %v", err)
+	}
+
+	results, err := p.findLibraryElements(ctx, m, element)
+	if err != nil {
+		return false, fmt.Errorf("failed getting library to check if it exists: %v", err)
+	}
+
+	return len(results) > 0, nil
+}
+
+// GetLibraryElementContentVersion returns the content version of a library item,
+// or "-1" if the item doesn't exist.
+func (p *ProviderClient) GetLibraryElementContentVersion(ctx context.Context, element string) (string, error) {
+	m, err := p.libraryManager(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed getting library element info: %v", err)
+	}
+
+	results, err := p.findLibraryElements(ctx, m, element)
+	if err != nil {
+		return "", fmt.Errorf("failed getting library element info: %v", err)
+	}
+
+	if len(results) == 0 {
+		return "-1", nil
+	}
+
+	item, ok := results[0].GetResult().(library.Item)
+	if !ok {
+		return "", fmt.Errorf("library element %s is not a library item", element)
+	}
+
+	return item.ContentVersion, nil
+}
+
+// DeleteLibraryElement deletes a content library or library item.
+func (p *ProviderClient) DeleteLibraryElement(ctx context.Context, element string) error {
+	m, err := p.libraryManager(ctx)
+	if err != nil {
+		return fmt.Errorf("failed deleting library item: %v", err)
+	}
+
+	results, err := p.findLibraryElements(ctx, m, element)
+	if err != nil {
+		return fmt.Errorf("failed deleting library item: %v", err)
+	}
+	if len(results) != 1 {
+		return fmt.Errorf("failed deleting library item: %s matches %d elements", element, len(results))
+	}
+
+	switch e := results[0].GetResult().(type) {
+	case library.Library:
+		err = m.DeleteLibrary(ctx, &e)
+	case library.Item:
+		err = m.DeleteLibraryItem(ctx, &e)
+	default:
+		err = fmt.Errorf("%s is a %T", element, e)
+	}
+	if err != nil {
+		return fmt.Errorf("failed deleting library item: %v", err)
+	}
+
+	return nil
+}
+
+// CreateLibrary creates a local content library backed by datastore.
+func (p *ProviderClient) CreateLibrary(ctx context.Context, datastore, libraryName string) error {
+	f, err := p.finder(ctx, "")
+	if err != nil {
+		return fmt.Errorf("creating library %s: %v", libraryName, err)
+	}
+
+	ds, err := f.Datastore(ctx, datastore)
+	if err != nil {
+		return fmt.Errorf("creating library %s: %v", libraryName, err)
+	}
+
+	m, err := p.libraryManager(ctx)
+	if err != nil {
+		return fmt.Errorf("creating library %s: %v", libraryName, err)
+	}
+
+	_, err = m.CreateLibrary(ctx, library.Library{
+		Name: libraryName,
+		Type: "LOCAL",
+		Storage: []library.StorageBacking{{
+			DatastoreID: ds.Reference().Value,
+			Type:        "DATASTORE",
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("creating library %s: %v", libraryName, err)
+	}
+
+	return nil
+}
+
+// ImportTemplate imports the OVA at ovaURL into the library as a library item named name.
+// vCenter pulls http(s) URLs itself, any other path is a local file uploaded by the client.
+func (p *ProviderClient) ImportTemplate(ctx context.Context, libraryName, ovaURL, name string) error {
+	logger.V(4).Info("Importing template", "ova", ovaURL, "templateName", name)
+
+	m, err := p.libraryManager(ctx)
+	if err != nil {
+		return fmt.Errorf("importing template: %v", err)
+	}
+
+	lib, err := m.GetLibraryByName(ctx, libraryName)
+	if err != nil {
+		return fmt.Errorf("importing template: %v", err)
+	}
+
+	itemID, err := m.CreateLibraryItem(ctx, library.Item{
+		Name:      name,
+		Type:      library.ItemTypeOVF,
+		LibraryID: lib.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("importing template: %v", err)
+	}
+
+	session, err := m.CreateLibraryItemUpdateSession(ctx, library.Session{LibraryItemID: itemID})
+	if err != nil {
+		return fmt.Errorf("importing template: %v", err)
+	}
+
+	if importer.IsRemotePath(ovaURL) {
+		_, err = m.AddLibraryItemFileFromURI(ctx, session, path.Base(ovaURL), ovaURL)
+	} else {
+		err = p.uploadOVA(ctx, m, session, ovaURL)
+	}
+	if err != nil {
+		return fmt.Errorf("importing template: %v", err)
+	}
+
+	if err := m.CompleteLibraryItemUpdateSession(ctx, session); err != nil {
+		return fmt.Errorf("importing template: %v", err)
+	}
+
+	if err := m.WaitOnLibraryItemUpdateSession(ctx, session, libraryImportPollInterval, nil); err != nil {
+		return fmt.Errorf("importing template: %v", err)
+	}
+
+	return nil
+}
+
+// uploadOVA uploads the ovf descriptor of the local OVA at ovaPath and the files it references
+// to the library item update session, the same way govc library.import does.
+func (p *ProviderClient) uploadOVA(ctx context.Context, m *library.Manager, session, ovaPath string) error {
+	vc, err := p.client(ctx)
+	if err != nil {
+		return err
+	}
+	rc, err := p.restClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	archive := &importer.TapeArchive{Path: ovaPath, Opener: importer.Opener{Client: vc}}
+
+	// The manifest is optional, when present vCenter uses it to verify the uploaded files.
+	checksums := map[string]*library.Checksum{}
+	if f, _, err := archive.Open("*.mf"); err == nil {
+		checksums, err = library.ReadManifest(f)
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("reading manifest of %s: %v", ovaPath, err)
+		}
+	}
+
+	upload := func(name string) error {
+		f, size, err := archive.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if e, ok := f.(*importer.TapeArchiveEntry); ok {
+			name = e.Name
+		}
+
+		update, err := m.AddLibraryItemFile(ctx, session, library.UpdateFile{
+			Name:       name,
+			SourceType: "PUSH",
+			Checksum:   checksums[name],
+			Size:       size,
+		})
+		if err != nil {
+			return err
+		}
+
+		u, err := url.Parse(update.UploadEndpoint.URI)
+		if err != nil {
+			return err
+		}
+
+		params := soap.DefaultUpload
+		params.ContentLength = size
+		if err := rc.Upload(ctx, f, u, &params); err != nil {
+			return fmt.Errorf("uploading %s: %v", name, err)
+		}
+
+		return nil
+	}
+
+	if err := upload("*.ovf"); err != nil {
+		return err
+	}
+
+	descriptor, err := importer.ReadOvf("*.ovf", archive)
+	if err != nil {
+		return err
+	}
+
+	envelope, err := importer.ReadEnvelope(descriptor)
+	if err != nil {
+		return fmt.Errorf("parsing ovf of %s: %v", ovaPath, err)
+	}
+
+	for _, ref := range envelope.References {
+		if err := upload(ref.Href); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeployTemplateFromLibrary deploys a template from the content library into templateDir,
+// resizes its disks, takes a snapshot and marks it as a template.
+func (p *ProviderClient) DeployTemplateFromLibrary(ctx context.Context, templateDir, templateName, libraryName, datacenter, datastore, network, resourcePool string, resizeBRDisk bool) error {
+	logger.V(4).Info("Deploying template", "dir", templateDir, "templateName", templateName)
+
+	vm, err := p.deployTemplate(ctx, libraryName, templateName, templateDir, datacenter, datastore, network, resourcePool)
+	if err != nil {
+		return err
+	}
+
+	if resizeBRDisk {
+		if err := p.resizeBottlerocketDisk(ctx, vm, templateName); err != nil {
+			return err
+		}
+	}
+
+	templateFullPath := filepath.Join(templateDir, templateName)
+
+	logger.V(4).Info("Taking template snapshot", "templateName", templateFullPath)
+	task, err := vm.CreateSnapshot(ctx, rootSnapshotName, "", false, false)
+	if err != nil {
+		return fmt.Errorf("govmomi failed taking vm snapshot: %v", err)
+	}
+	if err := task.Wait(ctx); err != nil {
+		return fmt.Errorf("govmomi failed taking vm snapshot: %v", err)
+	}
+
+	logger.V(4).Info("Marking vm as template", "templateName", templateFullPath)
+	if err := vm.MarkAsTemplate(ctx); err != nil {
+		return fmt.Errorf("marking VM as template: %v", err)
+	}
+
+	return nil
+}
+
+func (p *ProviderClient) deployTemplate(ctx context.Context, libraryName, templateName, deployFolder, datacenter, datastore, network, resourcePool string) (*object.VirtualMachine, error) {
+	m, err := p.libraryManager(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("deploying template: %v", err)
+	}
+
+	templateInLibraryPath := "/" + strings.TrimPrefix(path.Join(libraryName, templateName), "/")
+	results, err := p.findLibraryElements(ctx, m, templateInLibraryPath)
+	if err != nil {
+		return nil, fmt.Errorf("deploying template: %v", err)
+	}
+	if len(results) != 1 {
+		return nil, fmt.Errorf("deploying template: library item %s not found", templateInLibraryPath)
+	}
+
+	f, err := p.finder(ctx, datacenter)
+	if err != nil {
+		return nil, fmt.Errorf("deploying template: %v", err)
+	}
+
+	folder, err := f.Folder(ctx, deployFolder)
+	if isNotFound(err) {
+		if err = p.createFolder(ctx, f, deployFolder); err == nil {
+			folder, err = f.Folder(ctx, deployFolder)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating folder: %v", err)
+	}
+
+	ds, err := f.Datastore(ctx, datastore)
+	if err != nil {
+		return nil, fmt.Errorf("deploying template: %v", err)
+	}
+
+	pool, err := f.ResourcePool(ctx, resourcePool)
+	if err != nil {
+		return nil, fmt.Errorf("deploying template: %v", err)
+	}
+
+	net, err := f.Network(ctx, network)
+	if err != nil {
+		return nil, fmt.Errorf("deploying template: %v", err)
+	}
+	networkID := net.Reference().Value
+
+	rc, err := p.restClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("deploying template: %v", err)
+	}
+
+	ref, err := vcenter.NewManager(rc).DeployLibraryItem(ctx, results[0].GetID(), vcenter.Deploy{
+		DeploymentSpec: vcenter.DeploymentSpec{
+			Name:                templateName,
+			DefaultDatastoreID:  ds.Reference().Value,
+			StorageProvisioning: "thin",
+			AcceptAllEULA:       true,
+			NetworkMappings: []vcenter.NetworkMapping{
+				{Key: "nic0", Value: networkID},       // needed for Ubuntu
+				{Key: "VM Network", Value: networkID}, // needed for Bottlerocket
+			},
+		},
+		Target: vcenter.Target{
+			ResourcePoolID: pool.Reference().Value,
+			FolderID:       folder.Reference().Value,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("deploying template: %v", err)
+	}
+
+	vc, err := p.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("deploying template: %v", err)
+	}
+
+	return object.NewVirtualMachine(vc, *ref), nil
+}
+
+// resizeBottlerocketDisk grows the data disk of a Bottlerocket template. Templates with two
+// disks get the second disk resized to 20G, single disk templates get the first one resized to 22G.
+func (p *ProviderClient) resizeBottlerocketDisk(ctx context.Context, vm *object.VirtualMachine, templateName string) error {
+	logger.V(4).Info("Getting devices info for template")
+	devices, err := vm.Device(ctx)
+	if err != nil {
+		return fmt.Errorf("getting template device information: %v", err)
+	}
+
+	var first, second *types.VirtualDisk
+	for _, d := range devices.SelectByType((*types.VirtualDisk)(nil)) {
+		disk := d.(*types.VirtualDisk)
+		label := diskLabel(disk)
+		if strings.EqualFold(label, disk1) {
+			first = disk
+		} else if strings.EqualFold(label, disk2) {
+			second = disk
+			break
+		}
+	}
+
+	var disk *types.VirtualDisk
+	var diskSizeInGB int64
+	switch {
+	case second != nil:
+		logger.V(4).Info("Resizing disk 2 of template to 20G")
+		disk, diskSizeInGB = second, 20
+	case first != nil:
+		logger.V(4).Info("Resizing disk 1 of template to 22G")
+		disk, diskSizeInGB = first, 22
+	default:
+		return fmt.Errorf("template %v is not valid as there are no associated disks", templateName)
+	}
+
+	disk.CapacityInKB = diskSizeInGB * kibToGiB
+	disk.CapacityInBytes = 0
+	if err := vm.EditDevice(ctx, disk); err != nil {
+		return fmt.Errorf("resizing disk %v to %dG: %v", diskLabel(disk), diskSizeInGB, err)
+	}
+
+	return nil
+}
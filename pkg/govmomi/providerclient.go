@@ -0,0 +1,344 @@
+package govmomi
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/session/keepalive"
+	"github.com/vmware/govmomi/ssoadmin"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/soap"
+
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	govcUsernameKey   = "GOVC_USERNAME"
+	govcPasswordKey   = "GOVC_PASSWORD"
+	govcURLKey        = "GOVC_URL"
+	govcInsecureKey   = "GOVC_INSECURE"
+	govcDatacenterKey = "GOVC_DATACENTER"
+	vSphereServerKey  = "VSPHERE_SERVER"
+
+	keepAliveIdleTime = 5 * time.Minute
+)
+
+// Credentials are the vCenter endpoint and user the ProviderClient logs in with.
+type Credentials struct {
+	Server   string
+	Username string
+	Password string
+	Insecure bool
+}
+
+// CredentialsFromEnv reads the vCenter credentials from the same environment variables
+// used to configure govc.
+func CredentialsFromEnv() (Credentials, error) {
+	username, err := lookupEnv(config.EksavSphereUsernameKey, govcUsernameKey)
+	if err != nil {
+		return Credentials{}, err
+	}
+	password, err := lookupEnv(config.EksavSpherePasswordKey, govcPasswordKey)
+	if err != nil {
+		return Credentials{}, err
+	}
+	server, err := lookupEnv(vSphereServerKey, govcURLKey)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	insecure := false
+	if v, ok := os.LookupEnv(govcInsecureKey); ok && v != "" {
+		if insecure, err = strconv.ParseBool(v); err != nil {
+			return Credentials{}, fmt.Errorf("parsing %s: %v", govcInsecureKey, err)
+		}
+	}
+
+	return Credentials{Server: server, Username: username, Password: password, Insecure: insecure}, nil
+}
+
+func lookupEnv(keys ...string) (string, error) {
+	for _, k := range keys {
+		if v, ok := os.LookupEnv(k); ok && v != "" {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not set or is empty", keys[len(keys)-1])
+}
+
+// ProviderClient is a govmomi implementation of the vSphere provider govc client. It logs in
+// once and reuses the same SOAP and REST sessions for every operation, which makes it safe
+// to share between validations running in parallel.
+type ProviderClient struct {
+	credentials func() (Credentials, error)
+
+	mu          sync.Mutex
+	thumbprints map[string]string
+	vim         *vim25.Client
+	rest        *rest.Client
+	sso         *ssoadmin.Client
+	username    string
+	password    string
+}
+
+// ProviderClientOpt customizes a ProviderClient.
+type ProviderClientOpt func(*ProviderClient)
+
+// WithCredentials makes the ProviderClient use the provided credentials instead of reading
+// them from the environment.
+func WithCredentials(creds Credentials) ProviderClientOpt {
+	return func(p *ProviderClient) {
+		p.credentials = func() (Credentials, error) { return creds, nil }
+	}
+}
+
+// NewProviderClient builds a ProviderClient. The session is established on first use.
+func NewProviderClient(opts ...ProviderClientOpt) *ProviderClient {
+	p := &ProviderClient{
+		credentials: CredentialsFromEnv,
+		thumbprints: map[string]string{},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+func (p *ProviderClient) serverURL(creds Credentials) (*url.URL, error) {
+	u, err := soap.ParseURL(creds.Server)
+	if err != nil {
+		return nil, fmt.Errorf("parsing vCenter server url %s: %v", creds.Server, err)
+	}
+	u.User = url.UserPassword(creds.Username, creds.Password)
+	return u, nil
+}
+
+// client returns the vim25 client for the current session, logging in if needed.
+func (p *ProviderClient) client(ctx context.Context) (*vim25.Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.vim != nil {
+		return p.vim, nil
+	}
+
+	creds, err := p.credentials()
+	if err != nil {
+		return nil, fmt.Errorf("getting vCenter credentials: %v", err)
+	}
+
+	u, err := p.serverURL(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := soap.NewClient(u, creds.Insecure)
+	for host, thumbprint := range p.thumbprints {
+		sc.SetThumbprint(host, thumbprint)
+	}
+
+	vc, err := vim25.NewClient(ctx, sc)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to vCenter %s: %v", u.Host, err)
+	}
+
+	vc.RoundTripper = keepalive.NewHandlerSOAP(vc.RoundTripper, keepAliveIdleTime, nil)
+
+	if err := session.NewManager(vc).Login(ctx, u.User); err != nil {
+		return nil, fmt.Errorf("logging in to vCenter %s: %v", u.Host, err)
+	}
+
+	p.vim = vc
+	p.username = creds.Username
+	p.password = creds.Password
+
+	return vc, nil
+}
+
+// restClient returns the vAPI client for the current session, logging in if needed.
+func (p *ProviderClient) restClient(ctx context.Context) (*rest.Client, error) {
+	vc, err := p.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.rest != nil {
+		return p.rest, nil
+	}
+
+	rc := rest.NewClient(vc)
+	if err := rc.Login(ctx, url.UserPassword(p.username, p.password)); err != nil {
+		return nil, fmt.Errorf("logging in to vCenter rest api: %v", err)
+	}
+
+	p.rest = rc
+
+	return rc, nil
+}
+
+// finder returns a finder scoped to datacenter. If datacenter is empty, the datacenter
+// configured for govc or the only datacenter in the inventory is used. If there isn't one,
+// the finder can only resolve absolute paths.
+func (p *ProviderClient) finder(ctx context.Context, datacenter string) (*find.Finder, error) {
+	vc, err := p.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	f := find.NewFinder(vc, true)
+	if datacenter == "" {
+		datacenter = os.Getenv(govcDatacenterKey)
+	}
+	dc, err := f.DatacenterOrDefault(ctx, datacenter)
+	if err != nil && datacenter == "" {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	f.SetDatacenter(dc)
+
+	return f, nil
+}
+
+// Close logs out of the sessions opened by the client.
+func (p *ProviderClient) Close(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.sso != nil {
+		if err := p.sso.Logout(ctx); err != nil {
+			return fmt.Errorf("logging out from sso admin: %v", err)
+		}
+		p.sso = nil
+	}
+
+	if p.rest != nil {
+		if err := p.rest.Logout(ctx); err != nil {
+			return fmt.Errorf("logging out from vCenter rest api: %v", err)
+		}
+		p.rest = nil
+	}
+
+	if p.vim != nil {
+		logger.V(3).Info("Logging out from current vCenter session")
+		if err := session.NewManager(p.vim).Logout(ctx); err != nil {
+			return fmt.Errorf("logging out from vCenter: %v", err)
+		}
+		p.vim = nil
+	}
+
+	return nil
+}
+
+// ValidateVCenterConnection checks the vCenter server is reachable over https.
+func (p *ProviderClient) ValidateVCenterConnection(ctx context.Context, server string) error {
+	skipVerifyTransport := http.DefaultTransport.(*http.Transport).Clone()
+	skipVerifyTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	client := &http.Client{Transport: skipVerifyTransport}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+server, nil)
+	if err != nil {
+		return fmt.Errorf("failed to reach server %s: %v", server, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach server %s: %v", server, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// ValidateVCenterAuthentication checks the credentials can log in to vCenter.
+func (p *ProviderClient) ValidateVCenterAuthentication(ctx context.Context) error {
+	if _, err := p.client(ctx); err != nil {
+		return fmt.Errorf("vSphere authentication failed: %v", err)
+	}
+	return nil
+}
+
+// IsCertSelfSigned returns true if the vCenter certificate isn't signed by a trusted authority.
+func (p *ProviderClient) IsCertSelfSigned(ctx context.Context) bool {
+	creds, err := p.credentials()
+	if err != nil {
+		return true
+	}
+
+	u, err := p.serverURL(creds)
+	if err != nil {
+		return true
+	}
+
+	conn, err := (&tls.Dialer{Config: &tls.Config{ServerName: u.Hostname()}}).DialContext(ctx, "tcp", hostPort(u))
+	if err != nil {
+		return true
+	}
+	conn.Close()
+
+	return false
+}
+
+// GetCertThumbprint returns the SHA-1 thumbprint of the vCenter certificate.
+func (p *ProviderClient) GetCertThumbprint(ctx context.Context) (string, error) {
+	creds, err := p.credentials()
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve thumbprint: %v", err)
+	}
+
+	u, err := p.serverURL(creds)
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve thumbprint: %v", err)
+	}
+
+	// Only the certificate is read, which is why it's not verified.
+	conn, err := (&tls.Dialer{Config: &tls.Config{InsecureSkipVerify: true}}).DialContext(ctx, "tcp", hostPort(u)) //nolint:gosec
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve thumbprint: %v", err)
+	}
+	defer conn.Close()
+
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return "", fmt.Errorf("unable to retrieve thumbprint: no certificate presented by %s", u.Host)
+	}
+
+	return soap.ThumbprintSHA1(certs[0]), nil
+}
+
+// ConfigureCertThumbprint trusts the certificate with the given thumbprint for server.
+// It must be called before the client connects to take effect.
+func (p *ProviderClient) ConfigureCertThumbprint(ctx context.Context, server, thumbprint string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.thumbprints[server] = thumbprint
+
+	return nil
+}
+
+func hostPort(u *url.URL) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	return net.JoinHostPort(u.Hostname(), "443")
+}
@@ -0,0 +1,420 @@
+package govmomi_test
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	vmomi "github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	_ "github.com/vmware/govmomi/lookup/simulator"
+	"github.com/vmware/govmomi/simulator"
+	_ "github.com/vmware/govmomi/ssoadmin/simulator"
+	_ "github.com/vmware/govmomi/sts/simulator"
+	_ "github.com/vmware/govmomi/vapi/simulator"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/govmomi"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+)
+
+var (
+	_ vsphere.TemplateManagerGovcClient = &govmomi.ProviderClient{}
+	_ vsphere.CleanupGovcClient         = &govmomi.ProviderClient{}
+)
+
+// newSimulatedProviderClient starts a vcsim server with the default vCenter inventory
+// (datacenter DC0, cluster DC0_C0, datastore LocalDS_0) and returns a client connected to it.
+func newSimulatedProviderClient(t *testing.T) *govmomi.ProviderClient {
+	t.Helper()
+	c, _ := newSimulatedProviderClientAndServer(t)
+	return c
+}
+
+// newSimulatedProviderClientAndServer is newSimulatedProviderClient but also returns the vcsim server,
+// for tests that need to prepare the inventory with their own client.
+func newSimulatedProviderClientAndServer(t *testing.T) (*govmomi.ProviderClient, *simulator.Server) {
+	t.Helper()
+	model := simulator.VPX()
+	if err := model.Create(); err != nil {
+		t.Fatalf("creating vcsim model: %v", err)
+	}
+	model.Service.TLS = new(tls.Config)
+	model.Service.RegisterEndpoints = true
+	server := model.Service.NewServer()
+	t.Cleanup(func() {
+		server.Close()
+		model.Remove()
+	})
+
+	password, _ := server.URL.User.Password()
+	c := govmomi.NewProviderClient(govmomi.WithCredentials(govmomi.Credentials{
+		Server:   server.URL.Host,
+		Username: server.URL.User.Username(),
+		Password: password,
+		Insecure: true,
+	}))
+	t.Cleanup(func() { _ = c.Close(context.Background()) })
+
+	return c, server
+}
+
+func TestProviderClientCredentialsFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("EKSA_VSPHERE_USERNAME", "user")
+	t.Setenv("EKSA_VSPHERE_PASSWORD", "pass")
+	t.Setenv("VSPHERE_SERVER", "vcenter.local")
+	t.Setenv("GOVC_INSECURE", "true")
+
+	creds, err := govmomi.CredentialsFromEnv()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(creds).To(Equal(govmomi.Credentials{Server: "vcenter.local", Username: "user", Password: "pass", Insecure: true}))
+}
+
+func TestProviderClientCredentialsFromEnvMissing(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("EKSA_VSPHERE_USERNAME", "")
+	t.Setenv("GOVC_USERNAME", "")
+
+	_, err := govmomi.CredentialsFromEnv()
+	g.Expect(err).To(MatchError("GOVC_USERNAME is not set or is empty"))
+}
+
+func TestProviderClientInventory(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSimulatedProviderClient(t)
+
+	g.Expect(c.ValidateVCenterAuthentication(ctx)).To(Succeed())
+
+	exists, err := c.DatacenterExists(ctx, "DC0")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeTrue())
+
+	exists, err = c.DatacenterExists(ctx, "DC1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	exists, err = c.NetworkExists(ctx, "/DC0/network/VM Network")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeTrue())
+
+	exists, err = c.NetworkExists(ctx, "/DC0/network/missing")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	template, err := c.SearchTemplate(ctx, "DC0", "DC0_H0_VM0")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(template).To(Equal("/DC0/vm/DC0_H0_VM0"))
+
+	template, err = c.SearchTemplate(ctx, "DC0", "missing")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(template).To(BeEmpty())
+
+	hasSnapshot, err := c.TemplateHasSnapshot(ctx, "/DC0/vm/DC0_H0_VM0")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(hasSnapshot).To(BeFalse())
+
+	space, err := c.GetWorkloadAvailableSpace(ctx, "LocalDS_0")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(space).To(BeNumerically(">", 0))
+
+	size, err := c.GetVMDiskSizeInGB(ctx, "DC0_H0_VM0", "DC0")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(size).To(BeNumerically(">=", 0))
+
+	_, err = c.GetHardDiskSize(ctx, "DC0_H0_VM0", "DC0")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	poolInfo, err := c.GetResourcePoolInfo(ctx, "DC0", "/DC0/host/DC0_C0/Resources")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(poolInfo).To(HaveKey(executables.MemoryAvailable))
+
+	threads, err := c.GetResourcePoolCPUThreads(ctx, "DC0", "/DC0/host/DC0_C0/Resources")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(threads).To(BeNumerically(">", 0))
+
+	hosts, err := c.GetResourcePoolHostCount(ctx, "DC0", "/DC0/host/DC0_C0/Resources")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(hosts).To(Equal(3))
+}
+
+func TestProviderClientApplyAntiAffinityRule(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSimulatedProviderClient(t)
+
+	rule := executables.AntiAffinityRule{
+		Name:      "test-control-plane",
+		VMs:       []string{"DC0_C0_RP0_VM0", "DC0_C0_RP0_VM1"},
+		Mandatory: true,
+	}
+	g.Expect(c.ApplyAntiAffinityRule(ctx, "DC0", "/DC0/host/DC0_C0/Resources", rule)).To(Succeed())
+	// Applying the rule again updates it in place.
+	g.Expect(c.ApplyAntiAffinityRule(ctx, "DC0", "/DC0/host/DC0_C0/Resources", rule)).To(Succeed())
+
+	rule.HostGroup = "rack-a"
+	g.Expect(c.ApplyAntiAffinityRule(ctx, "DC0", "/DC0/host/DC0_C0/Resources", rule)).To(
+		MatchError("DRS host group rack-a not found in compute cluster /DC0/host/DC0_C0"),
+	)
+
+	g.Expect(c.ApplyAntiAffinityRule(ctx, "DC0", "/DC0/host/DC0_H0/Resources", rule)).To(
+		MatchError("resource pool /DC0/host/DC0_H0/Resources does not belong to a compute cluster, DRS rules are not supported"),
+	)
+}
+
+func TestProviderClientRemoveDRSRule(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSimulatedProviderClient(t)
+	pool := "/DC0/host/DC0_C0/Resources"
+
+	rule := executables.AntiAffinityRule{Name: "test-control-plane", VMs: []string{"DC0_C0_RP0_VM0", "DC0_C0_RP0_VM1"}}
+	g.Expect(c.ApplyAntiAffinityRule(ctx, "DC0", pool, rule)).To(Succeed())
+
+	rules, err := c.ListDRSRules(ctx, "DC0", pool)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rules).To(ContainElement("test-control-plane"))
+
+	g.Expect(c.RemoveDRSRule(ctx, "DC0", pool, "test-control-plane")).To(Succeed())
+	rules, err = c.ListDRSRules(ctx, "DC0", pool)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rules).ToNot(ContainElement("test-control-plane"))
+
+	g.Expect(c.RemoveDRSRule(ctx, "DC0", pool, "test-control-plane")).To(
+		MatchError("DRS rule test-control-plane not found in compute cluster /DC0/host/DC0_C0"),
+	)
+
+	groups, err := c.ListDRSGroups(ctx, "DC0", pool)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(groups).To(BeEmpty())
+}
+
+func TestProviderClientValidateFailureDomainConfig(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSimulatedProviderClient(t)
+
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{Spec: v1alpha1.VSphereDatacenterConfigSpec{Datacenter: "DC0"}}
+	failureDomain := &v1alpha1.FailureDomain{
+		Datastore:      "LocalDS_0",
+		Folder:         "eksa",
+		ResourcePool:   "*/DC0_C0/Resources",
+		ComputeCluster: "DC0_C0",
+	}
+
+	g.Expect(c.ValidateFailureDomainConfig(ctx, datacenterConfig, failureDomain)).To(Succeed())
+	g.Expect(failureDomain).To(Equal(&v1alpha1.FailureDomain{
+		Datastore:      "/DC0/datastore/LocalDS_0",
+		Folder:         "/DC0/vm/eksa",
+		ResourcePool:   "/DC0/host/DC0_C0/Resources",
+		ComputeCluster: "/DC0/host/DC0_C0",
+	}))
+}
+
+func TestProviderClientPathErrors(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSimulatedProviderClient(t)
+
+	_, err := c.GetDatastorePath(ctx, "DC0", "/DC0/vm/LocalDS_0", nil)
+	g.Expect(err).To(MatchError("invalid folder type, expected path under /DC0/datastore"))
+
+	_, err = c.GetDatastorePath(ctx, "DC0", "missing", nil)
+	g.Expect(err).To(MatchError("failed to get datastore: valid path, but 'missing' is not a datastore"))
+
+	_, err = c.GetFolderPath(ctx, "DC0", "missing/folder", nil)
+	g.Expect(err).To(MatchError("failed to get folder: /DC0/vm/missing/ is an invalid intermediate directory"))
+
+	_, err = c.GetResourcePoolPath(ctx, "DC0", "Resources", nil)
+	g.Expect(err).To(MatchError("specified resource pool 'Resources' maps to multiple paths within the datacenter 'DC0'"))
+
+	_, err = c.GetComputeClusterPath(ctx, "DC0", "missing", nil)
+	g.Expect(err).To(MatchError("compute cluster 'missing' not found"))
+}
+
+func TestProviderClientTags(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSimulatedProviderClient(t)
+
+	g.Expect(c.CreateCategoryForVM(ctx, "os")).To(Succeed())
+	g.Expect(c.ListCategories(ctx)).To(ConsistOf("os"))
+
+	g.Expect(c.CreateTag(ctx, "os:ubuntu", "os")).To(Succeed())
+	tags, err := c.ListTags(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(tags).To(HaveLen(1))
+	g.Expect(tags[0].Name).To(Equal("os:ubuntu"))
+	g.Expect(tags[0].CategoryId).ToNot(BeEmpty())
+
+	g.Expect(c.AddTag(ctx, "/DC0/vm/DC0_H0_VM0", "os:ubuntu")).To(Succeed())
+	g.Expect(c.GetTags(ctx, "/DC0/vm/DC0_H0_VM0")).To(ConsistOf("os:ubuntu"))
+}
+
+func TestProviderClientRoles(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSimulatedProviderClient(t)
+
+	exists, err := c.RoleExists(ctx, "EKSACloudAdmin")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	g.Expect(c.CreateRole(ctx, "EKSACloudAdmin", []string{"VirtualMachine.Inventory.Create"})).To(Succeed())
+
+	exists, err = c.RoleExists(ctx, "EKSACloudAdmin")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeTrue())
+
+	g.Expect(c.SetGroupRoleOnObject(ctx, "EKSAUsers", "EKSACloudAdmin", "/DC0/vm", "vsphere.local")).To(Succeed())
+	g.Expect(c.SetGroupRoleOnObject(ctx, "EKSAUsers", "missing", "/DC0/vm", "vsphere.local")).To(MatchError("role missing not found"))
+}
+
+func TestProviderClientLibrary(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSimulatedProviderClient(t)
+
+	exists, err := c.LibraryElementExists(ctx, "/eks-a-templates")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	g.Expect(c.CreateLibrary(ctx, "LocalDS_0", "eks-a-templates")).To(Succeed())
+
+	exists, err = c.LibraryElementExists(ctx, "/eks-a-templates")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeTrue())
+
+	version, err := c.GetLibraryElementContentVersion(ctx, "/eks-a-templates/ubuntu")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(version).To(Equal("-1"))
+
+	g.Expect(c.DeleteLibraryElement(ctx, "/eks-a-templates")).To(Succeed())
+
+	exists, err = c.LibraryElementExists(ctx, "/eks-a-templates")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+}
+
+func TestProviderClientTemplatesAndVMs(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c, server := newSimulatedProviderClientAndServer(t)
+
+	vc, err := vmomi.NewClient(ctx, server.URL, true)
+	g.Expect(err).ToNot(HaveOccurred())
+	vm, err := find.NewFinder(vc.Client).VirtualMachine(ctx, "/DC0/vm/DC0_H0_VM0")
+	g.Expect(err).ToNot(HaveOccurred())
+	task, err := vm.PowerOff(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(task.Wait(ctx)).To(Succeed())
+	g.Expect(vm.MarkAsTemplate(ctx)).To(Succeed())
+
+	templates, err := c.ListTemplates(ctx, "/DC0/vm")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(templates).To(Equal([]string{"/DC0/vm/DC0_H0_VM0"}))
+
+	_, err = c.ListTemplates(ctx, "/DC0/vm/missing")
+	g.Expect(err).To(MatchError(ContainSubstring("listing templates in /DC0/vm/missing")))
+
+	g.Expect(c.DeleteTemplate(ctx, "/DC0/host/DC0_C0/Resources", "/DC0/vm/DC0_H0_VM0")).To(Succeed())
+
+	templates, err = c.ListTemplates(ctx, "/DC0/vm")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(templates).To(BeEmpty())
+
+	vms, err := c.ListVMs(ctx, "DC0", "DC0_C0_RP0_*")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(vms).To(Equal([]string{"/DC0/vm/DC0_C0_RP0_VM0", "/DC0/vm/DC0_C0_RP0_VM1"}))
+
+	g.Expect(c.DestroyVM(ctx, "/DC0/vm/DC0_C0_RP0_VM0")).To(Succeed())
+
+	vms, err = c.ListVMs(ctx, "DC0", "DC0_*")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(vms).To(Equal([]string{"/DC0/vm/DC0_C0_RP0_VM1", "/DC0/vm/DC0_H0_VM1"}))
+}
+
+func TestProviderClientImportTemplateFromFile(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSimulatedProviderClient(t)
+
+	ova := filepath.Join(t.TempDir(), "ubuntu.ova")
+	writeOVA(t, ova, map[string]string{
+		"ubuntu.ovf": `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1">
+  <References>
+    <File ovf:href="ubuntu.nvram" ovf:id="file1"/>
+  </References>
+</Envelope>`,
+		"ubuntu.nvram": "disk",
+	})
+
+	g.Expect(c.CreateLibrary(ctx, "LocalDS_0", "eks-a-templates")).To(Succeed())
+	g.Expect(c.ImportTemplate(ctx, "eks-a-templates", ova, "ubuntu")).To(Succeed())
+
+	exists, err := c.LibraryElementExists(ctx, "/eks-a-templates/ubuntu")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeTrue())
+
+	g.Expect(c.ImportTemplate(ctx, "eks-a-templates", filepath.Join(t.TempDir(), "missing.ova"), "missing")).To(
+		MatchError(ContainSubstring("importing template")),
+	)
+}
+
+func writeOVA(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tar.NewWriter(f)
+	for _, name := range names {
+		if err := w.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(files[name]))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(files[name])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProviderClientSSO(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newSimulatedProviderClient(t)
+
+	exists, err := c.GroupExists(ctx, "EKSAUsers")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	g.Expect(c.CreateGroup(ctx, "EKSAUsers")).To(Succeed())
+	g.Expect(c.CreateUser(ctx, "eksa", "Password1!")).To(Succeed())
+	g.Expect(c.AddUserToGroup(ctx, "EKSAUsers", "eksa")).To(Succeed())
+
+	exists, err = c.UserExists(ctx, "eksa")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeTrue())
+
+	exists, err = c.GroupExists(ctx, "EKSAUsers")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeTrue())
+}
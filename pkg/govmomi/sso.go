@@ -0,0 +1,207 @@
+package govmomi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/ssoadmin"
+	ssotypes "github.com/vmware/govmomi/ssoadmin/types"
+	"github.com/vmware/govmomi/sts"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// ssoClient returns an SSO admin client for the current session, logging in if needed.
+// The SSO admin service has its own session manager so it needs a token issued by STS.
+func (p *ProviderClient) ssoClient(ctx context.Context) (*ssoadmin.Client, error) {
+	vc, err := p.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.sso != nil {
+		return p.sso, nil
+	}
+
+	c, err := ssoadmin.NewClient(ctx, vc)
+	if err != nil {
+		return nil, fmt.Errorf("creating sso admin client: %v", err)
+	}
+
+	tokens, err := sts.NewClient(ctx, vc)
+	if err != nil {
+		return nil, fmt.Errorf("creating sts client: %v", err)
+	}
+
+	signer, err := tokens.Issue(ctx, sts.TokenRequest{
+		Certificate: vc.Certificate(),
+		Userinfo:    url.UserPassword(p.username, p.password),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("issuing sso token: %v", err)
+	}
+
+	if err := c.Login(c.WithHeader(ctx, soap.Header{Security: signer})); err != nil {
+		return nil, fmt.Errorf("logging in to sso admin: %v", err)
+	}
+
+	p.sso = c
+
+	return c, nil
+}
+
+// CreateUser creates a user.
+func (p *ProviderClient) CreateUser(ctx context.Context, username, password string) error {
+	c, err := p.ssoClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.CreatePersonUser(ctx, username, ssotypes.AdminPersonDetails{}, password); err != nil {
+		return fmt.Errorf("creating user %s: %v", username, err)
+	}
+
+	return nil
+}
+
+// UserExists checks if a user exists.
+func (p *ProviderClient) UserExists(ctx context.Context, username string) (bool, error) {
+	c, err := p.ssoClient(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	user, err := c.FindUser(ctx, username)
+	if err != nil {
+		return false, fmt.Errorf("finding user %s: %v", username, err)
+	}
+
+	return user != nil, nil
+}
+
+// CreateGroup creates a group.
+func (p *ProviderClient) CreateGroup(ctx context.Context, name string) error {
+	c, err := p.ssoClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.CreateGroup(ctx, name, ssotypes.AdminGroupDetails{}); err != nil {
+		return fmt.Errorf("creating group %s: %v", name, err)
+	}
+
+	return nil
+}
+
+// GroupExists checks if a group exists.
+func (p *ProviderClient) GroupExists(ctx context.Context, name string) (bool, error) {
+	c, err := p.ssoClient(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	group, err := c.FindGroup(ctx, name)
+	if err != nil {
+		return false, fmt.Errorf("finding group %s: %v", name, err)
+	}
+
+	return group != nil, nil
+}
+
+// AddUserToGroup adds a user to a group.
+func (p *ProviderClient) AddUserToGroup(ctx context.Context, name, username string) error {
+	c, err := p.ssoClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := c.FindUser(ctx, username)
+	if err != nil {
+		return fmt.Errorf("finding user %s: %v", username, err)
+	}
+	if user == nil {
+		return fmt.Errorf("user %s not found", username)
+	}
+
+	if err := c.AddUsersToGroup(ctx, name, user.Id); err != nil {
+		return fmt.Errorf("adding user %s to group %s: %v", username, name, err)
+	}
+
+	return nil
+}
+
+func (p *ProviderClient) authorizationManager(ctx context.Context) (*object.AuthorizationManager, error) {
+	vc, err := p.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return object.NewAuthorizationManager(vc), nil
+}
+
+// RoleExists checks if a role exists.
+func (p *ProviderClient) RoleExists(ctx context.Context, name string) (bool, error) {
+	m, err := p.authorizationManager(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	roles, err := m.RoleList(ctx)
+	if err != nil {
+		return false, fmt.Errorf("listing roles: %v", err)
+	}
+
+	return roles.ByName(name) != nil, nil
+}
+
+// CreateRole creates a role with specified privileges.
+func (p *ProviderClient) CreateRole(ctx context.Context, name string, privileges []string) error {
+	m, err := p.authorizationManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := m.AddRole(ctx, name, privileges); err != nil {
+		return fmt.Errorf("creating role %s: %v", name, err)
+	}
+
+	return nil
+}
+
+// SetGroupRoleOnObject sets a role for a given group on target object.
+func (p *ProviderClient) SetGroupRoleOnObject(ctx context.Context, principal, role, object, domain string) error {
+	m, err := p.authorizationManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	roles, err := m.RoleList(ctx)
+	if err != nil {
+		return fmt.Errorf("listing roles: %v", err)
+	}
+	r := roles.ByName(role)
+	if r == nil {
+		return fmt.Errorf("role %s not found", role)
+	}
+
+	ref, err := p.objectReference(ctx, object)
+	if err != nil {
+		return fmt.Errorf("setting permissions on %s: %v", object, err)
+	}
+
+	permission := types.Permission{
+		Principal: principal + "@" + domain,
+		Group:     true,
+		RoleId:    r.RoleId,
+		Propagate: true,
+	}
+	if err := m.SetEntityPermissions(ctx, ref, []types.Permission{permission}); err != nil {
+		return fmt.Errorf("setting permissions on %s: %v", object, err)
+	}
+
+	return nil
+}
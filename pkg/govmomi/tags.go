@@ -0,0 +1,164 @@
+package govmomi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+)
+
+const (
+	categoryCardinalitySingle = "SINGLE"
+	virtualMachineType        = "VirtualMachine"
+)
+
+func (p *ProviderClient) tagManager(ctx context.Context) (*tags.Manager, error) {
+	rc, err := p.restClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tags.NewManager(rc), nil
+}
+
+// objectReference resolves an inventory path to the reference of the only object it matches.
+func (p *ProviderClient) objectReference(ctx context.Context, path string) (types.ManagedObjectReference, error) {
+	f, err := p.finder(ctx, "")
+	if err != nil {
+		return types.ManagedObjectReference{}, err
+	}
+
+	elements, err := f.ManagedObjectList(ctx, path)
+	if err != nil {
+		return types.ManagedObjectReference{}, err
+	}
+	if len(elements) != 1 {
+		return types.ManagedObjectReference{}, fmt.Errorf("path %s resolves to %d objects", path, len(elements))
+	}
+
+	return elements[0].Object.Reference(), nil
+}
+
+// GetTags returns the names of the tags attached to the object at path.
+func (p *ProviderClient) GetTags(ctx context.Context, path string) ([]string, error) {
+	ref, err := p.objectReference(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("listing tags for %s: %v", path, err)
+	}
+
+	m, err := p.tagManager(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing tags for %s: %v", path, err)
+	}
+
+	attached, err := m.GetAttachedTags(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("listing tags for %s: %v", path, err)
+	}
+
+	names := make([]string, 0, len(attached))
+	for _, t := range attached {
+		names = append(names, t.Name)
+	}
+
+	return names, nil
+}
+
+// ListTags list all vSphere tags in vCenter.
+func (p *ProviderClient) ListTags(ctx context.Context) ([]executables.Tag, error) {
+	m, err := p.tagManager(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing tags: %v", err)
+	}
+
+	all, err := m.GetTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing tags: %v", err)
+	}
+
+	result := make([]executables.Tag, 0, len(all))
+	for _, t := range all {
+		result = append(result, executables.Tag{Id: t.ID, Name: t.Name, CategoryId: t.CategoryID})
+	}
+
+	return result, nil
+}
+
+// AddTag attaches the tag to the object at path.
+func (p *ProviderClient) AddTag(ctx context.Context, path, tag string) error {
+	ref, err := p.objectReference(ctx, path)
+	if err != nil {
+		return fmt.Errorf("attaching tag to %s: %v", path, err)
+	}
+
+	m, err := p.tagManager(ctx)
+	if err != nil {
+		return fmt.Errorf("attaching tag to %s: %v", path, err)
+	}
+
+	if err := m.AttachTag(ctx, tag, ref); err != nil {
+		return fmt.Errorf("attaching tag to %s: %v", path, err)
+	}
+
+	return nil
+}
+
+// CreateTag creates a tag in category.
+func (p *ProviderClient) CreateTag(ctx context.Context, tag, category string) error {
+	m, err := p.tagManager(ctx)
+	if err != nil {
+		return fmt.Errorf("creating tag %s: %v", tag, err)
+	}
+
+	c, err := m.GetCategory(ctx, category)
+	if err != nil {
+		return fmt.Errorf("creating tag %s: %v", tag, err)
+	}
+
+	if _, err := m.CreateTag(ctx, &tags.Tag{Name: tag, CategoryID: c.ID}); err != nil {
+		return fmt.Errorf("creating tag %s: %v", tag, err)
+	}
+
+	return nil
+}
+
+// ListCategories returns the names of all tag categories.
+func (p *ProviderClient) ListCategories(ctx context.Context) ([]string, error) {
+	m, err := p.tagManager(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing categories: %v", err)
+	}
+
+	categories, err := m.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing categories: %v", err)
+	}
+
+	names := make([]string, 0, len(categories))
+	for _, c := range categories {
+		names = append(names, c.Name)
+	}
+
+	return names, nil
+}
+
+// CreateCategoryForVM creates a single cardinality tag category for virtual machines.
+func (p *ProviderClient) CreateCategoryForVM(ctx context.Context, name string) error {
+	m, err := p.tagManager(ctx)
+	if err != nil {
+		return fmt.Errorf("creating category %s: %v", name, err)
+	}
+
+	_, err = m.CreateCategory(ctx, &tags.Category{
+		Name:            name,
+		Cardinality:     categoryCardinalitySingle,
+		AssociableTypes: []string{virtualMachineType},
+	})
+	if err != nil {
+		return fmt.Errorf("creating category %s: %v", name, err)
+	}
+
+	return nil
+}
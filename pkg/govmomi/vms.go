@@ -0,0 +1,141 @@
+package govmomi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/view"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// ListTemplates returns the inventory paths of the vm templates under folder.
+func (p *ProviderClient) ListTemplates(ctx context.Context, folder string) ([]string, error) {
+	f, err := p.finder(ctx, datacenterFromPath(folder))
+	if err != nil {
+		return nil, fmt.Errorf("listing templates in %s: %v", folder, err)
+	}
+
+	parent, err := f.Folder(ctx, folder)
+	if err != nil {
+		return nil, fmt.Errorf("listing templates in %s: %v", folder, err)
+	}
+
+	vc, err := p.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := view.NewManager(vc).CreateContainerView(ctx, parent.Reference(), []string{"VirtualMachine"}, true)
+	if err != nil {
+		return nil, fmt.Errorf("listing templates in %s: %v", folder, err)
+	}
+	defer func() { _ = v.Destroy(ctx) }()
+
+	refs, err := v.Find(ctx, []string{"VirtualMachine"}, property.Match{"config.template": true})
+	if err != nil {
+		return nil, fmt.Errorf("listing templates in %s: %v", folder, err)
+	}
+
+	templates := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		path, err := find.InventoryPath(ctx, vc, ref)
+		if err != nil {
+			return nil, fmt.Errorf("listing templates in %s: %v", folder, err)
+		}
+		templates = append(templates, path)
+	}
+	sort.Strings(templates)
+
+	return templates, nil
+}
+
+// DeleteTemplate converts the template back to a vm in resourcePool, removes its snapshots and destroys it.
+func (p *ProviderClient) DeleteTemplate(ctx context.Context, resourcePool, templatePath string) error {
+	f, err := p.finder(ctx, datacenterFromPath(templatePath))
+	if err != nil {
+		return err
+	}
+
+	vm, err := f.VirtualMachine(ctx, templatePath)
+	if err != nil {
+		return fmt.Errorf("getting template %s: %v", templatePath, err)
+	}
+
+	pool, err := f.ResourcePool(ctx, resourcePool)
+	if err != nil {
+		return fmt.Errorf("failed marking as vm: %v", err)
+	}
+
+	if err := vm.MarkAsVirtualMachine(ctx, *pool, nil); err != nil {
+		return fmt.Errorf("failed marking as vm: %v", err)
+	}
+
+	task, err := vm.RemoveAllSnapshot(ctx, nil)
+	if err == nil {
+		err = task.Wait(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("removing snapshots from vm: %v", err)
+	}
+
+	task, err = vm.Destroy(ctx)
+	if err == nil {
+		err = task.Wait(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("deleting vm: %v", err)
+	}
+
+	return nil
+}
+
+// ListVMs returns the inventory paths of the vms in datacenter whose name matches the glob namePattern.
+func (p *ProviderClient) ListVMs(ctx context.Context, datacenter, namePattern string) ([]string, error) {
+	vms, err := p.findPaths(ctx, datacenter, "VirtualMachine", namePattern)
+	if err != nil {
+		return nil, fmt.Errorf("listing vms: %v", err)
+	}
+	sort.Strings(vms)
+
+	return vms, nil
+}
+
+// DestroyVM powers off the vm at path, if it's running, and destroys it.
+func (p *ProviderClient) DestroyVM(ctx context.Context, path string) error {
+	vm, err := p.virtualMachine(ctx, datacenterFromPath(path), path)
+	if err != nil {
+		return fmt.Errorf("destroying vm %s: %v", path, err)
+	}
+
+	task, err := vm.PowerOff(ctx)
+	if err == nil {
+		err = task.Wait(ctx)
+	}
+	if err != nil {
+		logger.V(4).Info("Failed to power off vm, it may be already off", "vm", path, "error", err)
+	}
+
+	task, err = vm.Destroy(ctx)
+	if err == nil {
+		err = task.Wait(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("destroying vm %s: %v", path, err)
+	}
+
+	return nil
+}
+
+// datacenterFromPath returns the datacenter of an absolute inventory path, or an empty string
+// for relative paths so the finder falls back to the default datacenter.
+func datacenterFromPath(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		return ""
+	}
+	return strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]
+}
@@ -13,6 +13,8 @@ import (
 const (
 	upgradeBin = "/foo/eksa-upgrades/tools/upgrader"
 
+	kubeVipVolumeName = "kube-vip"
+
 	// CopierContainerName holds the name of the components copier container.
 	CopierContainerName = "components-copier"
 
@@ -58,6 +60,29 @@ func UpgradeWorkerPod(nodeName, image string) *corev1.Pod {
 	return p
 }
 
+// RemoveKubeVipManifest removes the kube-vip manifest volume from a control plane upgrader pod. It's used
+// for the control planes that don't run kube-vip, like the docker ones, which have no kube-vip config map.
+func RemoveKubeVipManifest(p *corev1.Pod) {
+	volumes := make([]corev1.Volume, 0, len(p.Spec.Volumes))
+	for _, v := range p.Spec.Volumes {
+		if v.Name != kubeVipVolumeName {
+			volumes = append(volumes, v)
+		}
+	}
+	p.Spec.Volumes = volumes
+
+	for i := range p.Spec.InitContainers {
+		c := &p.Spec.InitContainers[i]
+		mounts := make([]corev1.VolumeMount, 0, len(c.VolumeMounts))
+		for _, m := range c.VolumeMounts {
+			if m.Name != kubeVipVolumeName {
+				mounts = append(mounts, m)
+			}
+		}
+		c.VolumeMounts = mounts
+	}
+}
+
 func upgraderPod(nodeName, image string, isCP bool) *corev1.Pod {
 	volumes := []corev1.Volume{hostComponentsVolume()}
 	if isCP {
@@ -102,7 +127,7 @@ func copierContainer(image string, isCP bool) corev1.Container {
 	}
 	if isCP {
 		kubeVipVolMount := corev1.VolumeMount{
-			Name:      "kube-vip",
+			Name:      kubeVipVolumeName,
 			MountPath: fmt.Sprintf("/eksa-upgrades/%s", constants.KubeVipManifestName),
 			SubPath:   constants.KubeVipManifestName,
 		}
@@ -153,7 +178,7 @@ func hostComponentsVolume() corev1.Volume {
 
 func kubeVipVolume() corev1.Volume {
 	return corev1.Volume{
-		Name: "kube-vip",
+		Name: kubeVipVolumeName,
 		VolumeSource: corev1.VolumeSource{
 			ConfigMap: &corev1.ConfigMapVolumeSource{
 				LocalObjectReference: corev1.LocalObjectReference{
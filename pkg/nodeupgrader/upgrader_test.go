@@ -46,3 +46,13 @@ func TestUpgradeWorkerPod(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 	test.AssertContentToFile(t, string(data), "testdata/expected_worker_upgrader_pod.yaml")
 }
+
+func TestRemoveKubeVipManifest(t *testing.T) {
+	g := NewWithT(t)
+	pod := nodeupgrader.UpgradeFirstControlPlanePod(nodeName, upgraderImage, kubernetesVersion, etcdVersion)
+	nodeupgrader.RemoveKubeVipManifest(pod)
+
+	data, err := yaml.Marshal(pod)
+	g.Expect(err).ToNot(HaveOccurred())
+	test.AssertContentToFile(t, string(data), "testdata/expected_first_control_plane_upgrader_pod_without_kube_vip.yaml")
+}
@@ -0,0 +1,269 @@
+package cloudstack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+const (
+	proAffinityGroupType            = "host affinity"
+	antiAffinityGroupType           = "host anti-affinity"
+	affinityGroupDescriptionPrefix  = "Managed by EKS Anywhere for cluster "
+	controlPlaneAffinityGroupSuffix = "control-plane"
+	etcdAffinityGroupSuffix         = "etcd"
+	workerAffinityGroupSuffixPrefix = "worker-"
+)
+
+// AffinityGroups maps the node groups of a cluster to the ids of the affinity groups managed for them.
+type AffinityGroups map[string][]string
+
+func (a AffinityGroups) controlPlane() []string {
+	return a[controlPlaneAffinityGroupSuffix]
+}
+
+func (a AffinityGroups) etcd() []string {
+	return a[etcdAffinityGroupSuffix]
+}
+
+func (a AffinityGroups) worker(workerNodeGroupName string) []string {
+	return a[workerAffinityGroupSuffixPrefix+workerNodeGroupName]
+}
+
+// withAffinityGroups returns a copy of a machine config spec using the managed affinity group ids, if any,
+// instead of letting CAPC create one affinity group per machine template.
+func withAffinityGroups(spec anywherev1.CloudStackMachineConfigSpec, ids []string) *anywherev1.CloudStackMachineConfigSpec {
+	if !spec.ManageAffinityGroups || len(ids) == 0 {
+		return &spec
+	}
+	spec.Affinity = ""
+	spec.AffinityGroupIds = ids
+	return &spec
+}
+
+// managedAffinityGroup is an affinity group EKS Anywhere owns for a node group.
+type managedAffinityGroup struct {
+	key       string
+	name      string
+	groupType string
+}
+
+// managedAffinityGroups returns the affinity groups to manage for the node groups of a cluster spec
+// whose machine config opts into managed affinity groups.
+func managedAffinityGroups(spec *cluster.Spec) []managedAffinityGroup {
+	groups := []managedAffinityGroup{}
+	add := func(key string, machineConfig *anywherev1.CloudStackMachineConfig) {
+		if machineConfig == nil || !machineConfig.Spec.ManageAffinityGroups {
+			return
+		}
+		groupType := proAffinityGroupType
+		if machineConfig.Spec.Affinity == "anti" {
+			groupType = antiAffinityGroupType
+		}
+		groups = append(groups, managedAffinityGroup{
+			key:       key,
+			name:      fmt.Sprintf("eksa-%s-%s", spec.Cluster.Name, key),
+			groupType: groupType,
+		})
+	}
+
+	add(controlPlaneAffinityGroupSuffix, controlPlaneMachineConfig(spec))
+	if spec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		add(etcdAffinityGroupSuffix, etcdMachineConfig(spec))
+	}
+	for _, workers := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		add(workerAffinityGroupSuffixPrefix+workers.Name, workerMachineConfig(spec, workers))
+	}
+
+	return groups
+}
+
+// validateManagedAffinityGroupScope checks that all the availability zones share the same credentials,
+// domain and account when affinity groups are managed. Affinity groups belong to an account, and CAPC
+// applies the same affinity group ids to the machines of every availability zone.
+func validateManagedAffinityGroupScope(spec *cluster.Spec) error {
+	if len(managedAffinityGroups(spec)) == 0 {
+		return nil
+	}
+	zones := spec.CloudStackDatacenter.Spec.AvailabilityZones
+	for _, az := range zones[1:] {
+		if az.CredentialsRef != zones[0].CredentialsRef || az.Domain != zones[0].Domain || az.Account != zones[0].Account {
+			return fmt.Errorf("managed affinity groups require all availability zones to use the same credentials, domain and account, but %s and %s differ", zones[0].Name, az.Name)
+		}
+	}
+	return nil
+}
+
+// AffinityGroupManager creates, reuses and deletes the affinity groups owned by EKS Anywhere clusters.
+type AffinityGroupManager interface {
+	Reconcile(ctx context.Context, log logr.Logger, spec *cluster.Spec) (AffinityGroups, error)
+	Delete(ctx context.Context, log logr.Logger, clusterName string, datacenterConfig *anywherev1.CloudStackDatacenterConfig) error
+}
+
+// AffinityGroupRegistry returns the affinity group manager for a CloudStack exec config.
+type AffinityGroupRegistry interface {
+	Get(execConfig *decoder.CloudStackExecConfig) (AffinityGroupManager, error)
+}
+
+// AffinityGroupManagerFactory implements AffinityGroupRegistry, building managers with fresh CloudStack clients.
+type AffinityGroupManagerFactory struct {
+	builder CmkBuilder
+	writer  filewriter.FileWriter
+}
+
+// NewAffinityGroupManagerFactory initializes a factory for CloudStack affinity group managers.
+func NewAffinityGroupManagerFactory(builder CmkBuilder, writer filewriter.FileWriter) AffinityGroupManagerFactory {
+	return AffinityGroupManagerFactory{
+		builder: builder,
+		writer:  writer,
+	}
+}
+
+// Get returns an affinity group manager for a particular cloudstack exec config.
+func (f AffinityGroupManagerFactory) Get(execConfig *decoder.CloudStackExecConfig) (AffinityGroupManager, error) {
+	client, err := f.builder.BuildCloudstackClient(f.writer, execConfig)
+	if err != nil {
+		return nil, fmt.Errorf("building cloudstack client: %v", err)
+	}
+
+	return NewAffinityGroupClient(client), nil
+}
+
+// AffinityGroupClient manages the affinity groups of clusters with a CloudStack client.
+type AffinityGroupClient struct {
+	cmk ProviderCmkClient
+}
+
+// NewAffinityGroupClient returns an affinity group manager using a CloudStack client.
+func NewAffinityGroupClient(cmk ProviderCmkClient) *AffinityGroupClient {
+	return &AffinityGroupClient{cmk: cmk}
+}
+
+// affinityGroupScope is the owner of the affinity groups of one or more availability zones.
+type affinityGroupScope struct {
+	profile  string
+	domainId string
+	account  string
+}
+
+func (m *AffinityGroupClient) scopes(ctx context.Context, datacenterConfig *anywherev1.CloudStackDatacenterConfig) ([]affinityGroupScope, error) {
+	scopes := []affinityGroupScope{}
+	seen := map[affinityGroupScope]bool{}
+	for _, az := range datacenterConfig.Spec.AvailabilityZones {
+		domainId, err := m.cmk.ValidateDomainAndGetId(ctx, az.CredentialsRef, az.Domain)
+		if err != nil {
+			return nil, err
+		}
+		scope := affinityGroupScope{profile: az.CredentialsRef, domainId: domainId, account: az.Account}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+// ownedAffinityGroups returns the affinity groups of a scope owned by a cluster, indexed by name.
+func (m *AffinityGroupClient) ownedAffinityGroups(ctx context.Context, scope affinityGroupScope, clusterName string) (map[string]executables.CloudStackAffinityGroup, error) {
+	groups, err := m.cmk.ListAffinityGroups(ctx, scope.profile, scope.domainId, scope.account)
+	if err != nil {
+		return nil, err
+	}
+	owned := map[string]executables.CloudStackAffinityGroup{}
+	for _, group := range groups {
+		if group.Description == affinityGroupDescriptionPrefix+clusterName {
+			owned[group.Name] = group
+		}
+	}
+	return owned, nil
+}
+
+// Reconcile makes sure every node group opting into managed affinity groups has its affinity group,
+// reusing existing groups so machines keep their placement across rolling upgrades. It deletes the
+// groups of removed node groups once they have no machines left, and returns the group ids per node group.
+func (m *AffinityGroupClient) Reconcile(ctx context.Context, log logr.Logger, spec *cluster.Spec) (AffinityGroups, error) {
+	if err := validateManagedAffinityGroupScope(spec); err != nil {
+		return nil, err
+	}
+
+	scopes, err := m.scopes(ctx, spec.CloudStackDatacenter)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := managedAffinityGroups(spec)
+	affinityGroups := AffinityGroups{}
+	for _, scope := range scopes {
+		owned, err := m.ownedAffinityGroups(ctx, scope, spec.Cluster.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, group := range desired {
+			existing, ok := owned[group.name]
+			delete(owned, group.name)
+			id := existing.Id
+			if !ok {
+				log.Info("Creating affinity group", "name", group.name, "type", group.groupType)
+				id, err = m.cmk.CreateAffinityGroup(ctx, scope.profile, scope.domainId, scope.account, executables.CloudStackAffinityGroup{
+					Name:        group.name,
+					Type:        group.groupType,
+					Description: affinityGroupDescriptionPrefix + spec.Cluster.Name,
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+			affinityGroups[group.key] = append(affinityGroups[group.key], id)
+		}
+
+		if err := m.deleteEmpty(ctx, log, scope, owned); err != nil {
+			return nil, err
+		}
+	}
+
+	return affinityGroups, nil
+}
+
+// Delete deletes all the affinity groups owned by a cluster.
+func (m *AffinityGroupClient) Delete(ctx context.Context, log logr.Logger, clusterName string, datacenterConfig *anywherev1.CloudStackDatacenterConfig) error {
+	scopes, err := m.scopes(ctx, datacenterConfig)
+	if err != nil {
+		return err
+	}
+
+	for _, scope := range scopes {
+		owned, err := m.ownedAffinityGroups(ctx, scope, clusterName)
+		if err != nil {
+			return err
+		}
+		for _, group := range owned {
+			log.Info("Deleting affinity group", "name", group.Name)
+			if err := m.cmk.DeleteAffinityGroup(ctx, scope.profile, group.Id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *AffinityGroupClient) deleteEmpty(ctx context.Context, log logr.Logger, scope affinityGroupScope, groups map[string]executables.CloudStackAffinityGroup) error {
+	for _, group := range groups {
+		if len(group.VirtualMachineIds) > 0 {
+			log.Info("Affinity group of removed node group still has machines, deferring deletion", "name", group.Name, "machines", len(group.VirtualMachineIds))
+			continue
+		}
+		log.Info("Deleting affinity group", "name", group.Name)
+		if err := m.cmk.DeleteAffinityGroup(ctx, scope.profile, group.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
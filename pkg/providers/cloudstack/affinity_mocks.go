@@ -0,0 +1,106 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aws/eks-anywhere/pkg/providers/cloudstack (interfaces: AffinityGroupManager,AffinityGroupRegistry)
+
+// Package cloudstack is a generated GoMock package.
+package cloudstack
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	cluster "github.com/aws/eks-anywhere/pkg/cluster"
+	decoder "github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	logr "github.com/go-logr/logr"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAffinityGroupManager is a mock of AffinityGroupManager interface.
+type MockAffinityGroupManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockAffinityGroupManagerMockRecorder
+}
+
+// MockAffinityGroupManagerMockRecorder is the mock recorder for MockAffinityGroupManager.
+type MockAffinityGroupManagerMockRecorder struct {
+	mock *MockAffinityGroupManager
+}
+
+// NewMockAffinityGroupManager creates a new mock instance.
+func NewMockAffinityGroupManager(ctrl *gomock.Controller) *MockAffinityGroupManager {
+	mock := &MockAffinityGroupManager{ctrl: ctrl}
+	mock.recorder = &MockAffinityGroupManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAffinityGroupManager) EXPECT() *MockAffinityGroupManagerMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockAffinityGroupManager) Delete(arg0 context.Context, arg1 logr.Logger, arg2 string, arg3 *v1alpha1.CloudStackDatacenterConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAffinityGroupManagerMockRecorder) Delete(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAffinityGroupManager)(nil).Delete), arg0, arg1, arg2, arg3)
+}
+
+// Reconcile mocks base method.
+func (m *MockAffinityGroupManager) Reconcile(arg0 context.Context, arg1 logr.Logger, arg2 *cluster.Spec) (AffinityGroups, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(AffinityGroups)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockAffinityGroupManagerMockRecorder) Reconcile(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockAffinityGroupManager)(nil).Reconcile), arg0, arg1, arg2)
+}
+
+// MockAffinityGroupRegistry is a mock of AffinityGroupRegistry interface.
+type MockAffinityGroupRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *MockAffinityGroupRegistryMockRecorder
+}
+
+// MockAffinityGroupRegistryMockRecorder is the mock recorder for MockAffinityGroupRegistry.
+type MockAffinityGroupRegistryMockRecorder struct {
+	mock *MockAffinityGroupRegistry
+}
+
+// NewMockAffinityGroupRegistry creates a new mock instance.
+func NewMockAffinityGroupRegistry(ctrl *gomock.Controller) *MockAffinityGroupRegistry {
+	mock := &MockAffinityGroupRegistry{ctrl: ctrl}
+	mock.recorder = &MockAffinityGroupRegistryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAffinityGroupRegistry) EXPECT() *MockAffinityGroupRegistryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockAffinityGroupRegistry) Get(arg0 *decoder.CloudStackExecConfig) (AffinityGroupManager, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(AffinityGroupManager)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAffinityGroupRegistryMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAffinityGroupRegistry)(nil).Get), arg0)
+}
@@ -0,0 +1,155 @@
+package cloudstack
+
+import (
+	"context"
+	"errors"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/mocks"
+)
+
+const affinityTestDomainID = "5300cdac-74d5-11ec-8696-c81f66d3e962"
+
+func givenManagedAffinityClusterSpec(t *testing.T) *cluster.Spec {
+	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
+	for _, machineConfig := range clusterSpec.CloudStackMachineConfigs {
+		machineConfig.Spec.AffinityGroupIds = nil
+		machineConfig.Spec.Affinity = "anti"
+		machineConfig.Spec.ManageAffinityGroups = true
+	}
+	clusterSpec.CloudStackMachineConfigs["test-etcd"].Spec.ManageAffinityGroups = false
+	return clusterSpec
+}
+
+func ownedAffinityGroup(id, name string, vms ...string) executables.CloudStackAffinityGroup {
+	return executables.CloudStackAffinityGroup{
+		Id:                id,
+		Name:              name,
+		Type:              antiAffinityGroupType,
+		Description:       affinityGroupDescriptionPrefix + "test",
+		VirtualMachineIds: vms,
+	}
+}
+
+func TestAffinityGroupClientReconcile(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterSpec := givenManagedAffinityClusterSpec(t)
+	workerGroupName := clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Name
+	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+
+	cmk.EXPECT().ValidateDomainAndGetId(ctx, "global", "domain1").Return(affinityTestDomainID, nil)
+	cmk.EXPECT().ListAffinityGroups(ctx, "global", affinityTestDomainID, "admin").Return([]executables.CloudStackAffinityGroup{
+		ownedAffinityGroup("cp-id", "eksa-test-control-plane", "vm-1"),
+		ownedAffinityGroup("removed-id", "eksa-test-worker-removed"),
+		ownedAffinityGroup("draining-id", "eksa-test-worker-draining", "vm-2"),
+		{Id: "other-id", Name: "eksa-test-worker-other", Description: affinityGroupDescriptionPrefix + "test-other"},
+		{Id: "user-id", Name: "user-group"},
+	}, nil)
+	cmk.EXPECT().CreateAffinityGroup(ctx, "global", affinityTestDomainID, "admin", executables.CloudStackAffinityGroup{
+		Name:        "eksa-test-worker-" + workerGroupName,
+		Type:        antiAffinityGroupType,
+		Description: affinityGroupDescriptionPrefix + "test",
+	}).Return("worker-id", nil)
+	cmk.EXPECT().DeleteAffinityGroup(ctx, "global", "removed-id").Return(nil)
+
+	affinityGroups, err := NewAffinityGroupClient(cmk).Reconcile(ctx, logr.Discard(), clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(affinityGroups).To(Equal(AffinityGroups{
+		"control-plane":             {"cp-id"},
+		"worker-" + workerGroupName: {"worker-id"},
+	}))
+}
+
+func TestAffinityGroupClientReconcileCreateError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterSpec := givenManagedAffinityClusterSpec(t)
+	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+
+	cmk.EXPECT().ValidateDomainAndGetId(ctx, "global", "domain1").Return(affinityTestDomainID, nil)
+	cmk.EXPECT().ListAffinityGroups(ctx, "global", affinityTestDomainID, "admin").Return(nil, nil)
+	cmk.EXPECT().CreateAffinityGroup(ctx, "global", affinityTestDomainID, "admin", gomock.Any()).Return("", errors.New("quota exceeded"))
+
+	_, err := NewAffinityGroupClient(cmk).Reconcile(ctx, logr.Discard(), clusterSpec)
+	g.Expect(err).To(MatchError("quota exceeded"))
+}
+
+func TestAffinityGroupClientReconcileMultipleScopes(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := givenManagedAffinityClusterSpec(t)
+	az := clusterSpec.CloudStackDatacenter.Spec.AvailabilityZones[0]
+	az.Name = "other-az"
+	az.Account = "other-account"
+	clusterSpec.CloudStackDatacenter.Spec.AvailabilityZones = append(clusterSpec.CloudStackDatacenter.Spec.AvailabilityZones, az)
+	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+
+	_, err := NewAffinityGroupClient(cmk).Reconcile(context.Background(), logr.Discard(), clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("managed affinity groups require all availability zones to use the same credentials, domain and account")))
+}
+
+func TestAffinityGroupClientDelete(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterSpec := givenManagedAffinityClusterSpec(t)
+	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+
+	cmk.EXPECT().ValidateDomainAndGetId(ctx, "global", "domain1").Return(affinityTestDomainID, nil)
+	cmk.EXPECT().ListAffinityGroups(ctx, "global", affinityTestDomainID, "admin").Return([]executables.CloudStackAffinityGroup{
+		ownedAffinityGroup("cp-id", "eksa-test-control-plane"),
+		{Id: "user-id", Name: "user-group"},
+	}, nil)
+	cmk.EXPECT().DeleteAffinityGroup(ctx, "global", "cp-id").Return(nil)
+
+	g.Expect(NewAffinityGroupClient(cmk).Delete(ctx, logr.Discard(), "test", clusterSpec.CloudStackDatacenter)).To(Succeed())
+}
+
+type failingCmkBuilder struct{}
+
+func (failingCmkBuilder) BuildCloudstackClient(_ filewriter.FileWriter, _ *decoder.CloudStackExecConfig) (ProviderCmkClient, error) {
+	return nil, errors.New("invalid profile")
+}
+
+func TestAffinityGroupManagerFactoryGetError(t *testing.T) {
+	g := NewWithT(t)
+	factory := NewAffinityGroupManagerFactory(failingCmkBuilder{}, nil)
+
+	_, err := factory.Get(nil)
+	g.Expect(err).To(MatchError("building cloudstack client: invalid profile"))
+}
+
+func TestTemplateBuilderManagedAffinityGroups(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := givenManagedAffinityClusterSpec(t)
+	workerGroupName := clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Name
+	builder := NewTemplateBuilder(time.Now)
+	builder.affinityGroups = AffinityGroups{
+		"control-plane":             {"cp-id"},
+		"worker-" + workerGroupName: {"worker-id"},
+	}
+
+	cp, err := builder.GenerateCAPISpecControlPlane(clusterSpec, func(values map[string]interface{}) {
+		values["controlPlaneTemplateName"] = "test-control-plane"
+		values["etcdTemplateName"] = "test-etcd"
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(cp)).To(ContainSubstring("affinityGroupIDs:\n      - cp-id"))
+	// etcd machine config doesn't manage its affinity group, CAPC keeps creating one per machine set.
+	g.Expect(string(cp)).To(ContainSubstring("affinity: anti"))
+
+	workers, err := builder.GenerateCAPISpecWorkers(clusterSpec, map[string]string{workerGroupName: "test-md-0"}, map[string]string{workerGroupName: "test-md-0"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(workers)).To(ContainSubstring("affinityGroupIDs:\n      - worker-id"))
+	g.Expect(string(workers)).NotTo(ContainSubstring("affinity: anti"))
+}
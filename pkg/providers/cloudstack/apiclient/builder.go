@@ -0,0 +1,25 @@
+package apiclient
+
+import (
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+// Builder builds CloudStack API clients for the CloudStack validator factory.
+type Builder struct{}
+
+// NewBuilder initializes the CloudStack API client builder.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// BuildCloudstackClient builds a CloudStack API client for the profiles of an exec config.
+// The writer is unused since, unlike cmk, the client does not need config files.
+func (b *Builder) BuildCloudstackClient(_ filewriter.FileWriter, config *decoder.CloudStackExecConfig) (cloudstack.ProviderCmkClient, error) {
+	client, err := New(config)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,233 @@
+package apiclient
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+const (
+	defaultTimeout      = 30 * time.Second
+	timeoutEnv          = "CLOUDSTACK_PREFLIGHT_TIMEOUT"
+	maxIdleConnsPerHost = 10
+	errorResponseField  = "errorresponse"
+	asyncPollInterval   = 2 * time.Second
+)
+
+const (
+	jobStatusPending = 0
+	jobStatusFailed  = 2
+)
+
+// Client is an in-process CloudStack API client. It signs every request with the api and secret keys of
+// the exec config profile it targets and reuses one HTTP connection pool per profile.
+type Client struct {
+	profiles     map[string]*profileClient
+	pollInterval time.Duration
+}
+
+type profileClient struct {
+	config     decoder.CloudStackProfileConfig
+	httpClient *http.Client
+}
+
+// New builds a CloudStack API client for the profiles of an exec config.
+func New(config *decoder.CloudStackExecConfig) (*Client, error) {
+	if config == nil {
+		return nil, fmt.Errorf("nil exec config for CloudStack API client, unable to proceed")
+	}
+
+	timeout := defaultTimeout
+	if value, isSet := os.LookupEnv(timeoutEnv); isSet {
+		seconds, err := strconv.ParseUint(value, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number: %v", timeoutEnv, err)
+		}
+		timeout = time.Duration(seconds) * time.Second
+	}
+
+	profiles := make(map[string]*profileClient, len(config.Profiles))
+	for _, profile := range config.Profiles {
+		insecure := false
+		if profile.VerifySsl != "" {
+			verify, err := strconv.ParseBool(profile.VerifySsl)
+			if err != nil {
+				return nil, fmt.Errorf("invalid verify-ssl value %s for profile %s: %v", profile.VerifySsl, profile.Name, err)
+			}
+			insecure = !verify
+		}
+
+		profiles[profile.Name] = &profileClient{
+			config: profile,
+			httpClient: &http.Client{
+				Timeout: timeout,
+				Transport: &http.Transport{
+					Proxy:               http.ProxyFromEnvironment,
+					TLSClientConfig:     &tls.Config{InsecureSkipVerify: insecure}, // #nosec G402
+					MaxIdleConnsPerHost: maxIdleConnsPerHost,
+				},
+			},
+		}
+	}
+
+	return &Client{profiles: profiles, pollInterval: asyncPollInterval}, nil
+}
+
+// GetManagementApiEndpoint returns the management API url of a profile.
+func (c *Client) GetManagementApiEndpoint(profile string) (string, error) {
+	p, err := c.profile(profile)
+	if err != nil {
+		return "", err
+	}
+	return p.config.ManagementUrl, nil
+}
+
+// Close releases the idle connections of every profile.
+func (c *Client) Close(ctx context.Context) error {
+	for _, p := range c.profiles {
+		p.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (c *Client) profile(name string) (*profileClient, error) {
+	p, ok := c.profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %s does not exist", name)
+	}
+	return p, nil
+}
+
+type apiError struct {
+	ErrorCode int    `json:"errorcode"`
+	ErrorText string `json:"errortext"`
+}
+
+// call runs a CloudStack API command and decodes the content of its response object into response.
+func (c *Client) call(ctx context.Context, profile, command string, params url.Values, response interface{}) error {
+	p, err := c.profile(profile)
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	for k, v := range params {
+		query[k] = v
+	}
+	query.Set("command", command)
+	query.Set("response", "json")
+	query.Set("apiKey", p.config.ApiKey)
+	query.Set("signature", sign(query, p.config.SecretKey))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.ManagementUrl+"?"+encode(query), nil)
+	if err != nil {
+		return fmt.Errorf("building %s request: %v", command, err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling %s: %v", command, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading %s response: %v", command, err)
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return fmt.Errorf("calling %s: %s: %s", command, resp.Status, body)
+	}
+
+	for _, field := range []string{strings.ToLower(command) + "response", errorResponseField} {
+		raw, ok := fields[field]
+		if !ok {
+			continue
+		}
+		apiErr := apiError{}
+		if err := json.Unmarshal(raw, &apiErr); err == nil && apiErr.ErrorText != "" {
+			return fmt.Errorf("calling %s: %d: %s", command, apiErr.ErrorCode, apiErr.ErrorText)
+		}
+		if resp.StatusCode != http.StatusOK {
+			break
+		}
+		if err := json.Unmarshal(raw, response); err != nil {
+			return fmt.Errorf("parsing %s response: %v", command, err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("calling %s: %s: %s", command, resp.Status, body)
+}
+
+type asyncJob struct {
+	JobId     string          `json:"jobid"`
+	JobStatus int             `json:"jobstatus"`
+	JobResult json.RawMessage `json:"jobresult"`
+}
+
+// callAsync runs an asynchronous CloudStack API command, waits for its job to complete and decodes the
+// job result into response. A nil response discards the result.
+func (c *Client) callAsync(ctx context.Context, profile, command string, params url.Values, response interface{}) error {
+	job := asyncJob{}
+	if err := c.call(ctx, profile, command, params, &job); err != nil {
+		return err
+	}
+	if job.JobId == "" {
+		return fmt.Errorf("calling %s: no job id returned", command)
+	}
+
+	for {
+		if err := c.call(ctx, profile, "queryAsyncJobResult", url.Values{"jobid": {job.JobId}}, &job); err != nil {
+			return err
+		}
+		if job.JobStatus != jobStatusPending {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for %s job %s: %v", command, job.JobId, ctx.Err())
+		case <-time.After(c.pollInterval):
+		}
+	}
+
+	if job.JobStatus == jobStatusFailed {
+		apiErr := apiError{}
+		_ = json.Unmarshal(job.JobResult, &apiErr)
+		return fmt.Errorf("calling %s: %d: %s", command, apiErr.ErrorCode, apiErr.ErrorText)
+	}
+	if response == nil {
+		return nil
+	}
+	if err := json.Unmarshal(job.JobResult, response); err != nil {
+		return fmt.Errorf("parsing %s job result: %v", command, err)
+	}
+	return nil
+}
+
+// sign computes the signature of a request as described in the CloudStack API documentation:
+// the HMAC-SHA1 of the sorted, url encoded and lower cased query string, keyed with the secret key.
+func sign(query url.Values, secretKey string) string {
+	mac := hmac.New(sha1.New, []byte(secretKey))
+	mac.Write([]byte(strings.ToLower(encode(query))))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// encode url encodes a query sorted by key, with spaces encoded as %20 as CloudStack expects.
+func encode(query url.Values) string {
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
@@ -0,0 +1,521 @@
+package apiclient_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/apiclient"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+const (
+	profile   = "global"
+	apiKey    = "test-api-key"
+	secretKey = "test-secret-key"
+	domainID  = "7700cdac-74d5-11ec-8696-c81f66d3e965"
+	zoneID    = "4e3b338d-87a6-4189-b931-a1747edeea8f"
+)
+
+// fakeCloudStack is a local stand-in for the CloudStack API. It checks the signature of every request
+// and answers each command with a canned response.
+type fakeCloudStack struct {
+	t         *testing.T
+	server    *httptest.Server
+	responses map[string]string
+
+	mu          sync.Mutex
+	requests    []url.Values
+	connections int
+}
+
+func newFakeCloudStack(t *testing.T, responses map[string]string) *fakeCloudStack {
+	f := &fakeCloudStack{t: t, responses: responses}
+	f.server = httptest.NewUnstartedServer(http.HandlerFunc(f.serve))
+	f.server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			f.mu.Lock()
+			f.connections++
+			f.mu.Unlock()
+		}
+	}
+	f.server.Start()
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeCloudStack) serve(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	f.mu.Lock()
+	f.requests = append(f.requests, query)
+	f.mu.Unlock()
+
+	signature := query.Get("signature")
+	query.Del("signature")
+	mac := hmac.New(sha1.New, []byte(secretKey))
+	mac.Write([]byte(strings.ToLower(strings.ReplaceAll(query.Encode(), "+", "%20"))))
+	if query.Get("apiKey") != apiKey || signature != base64.StdEncoding.EncodeToString(mac.Sum(nil)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"errorresponse": {"errorcode": 401, "errortext": "unable to verify user credentials and/or request signature"}}`)
+		return
+	}
+
+	command := query.Get("command")
+	response, ok := f.responses[command]
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"errorresponse": {"errorcode": 432, "errortext": "The given command %s does not exist"}}`, command)
+		return
+	}
+	if strings.Contains(response, "errortext") && !strings.Contains(response, "jobstatus") {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	fmt.Fprintf(w, `{"%sresponse": %s}`, strings.ToLower(command), response)
+}
+
+func (f *fakeCloudStack) lastRequest() url.Values {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[len(f.requests)-1]
+}
+
+func (f *fakeCloudStack) newConnections() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.connections
+}
+
+func (f *fakeCloudStack) client(t *testing.T) *apiclient.Client {
+	client, err := apiclient.New(&decoder.CloudStackExecConfig{
+		Profiles: []decoder.CloudStackProfileConfig{{
+			Name:          profile,
+			ApiKey:        apiKey,
+			SecretKey:     secretKey,
+			ManagementUrl: f.server.URL + "/client/api",
+			VerifySsl:     "false",
+		}},
+	})
+	if err != nil {
+		t.Fatalf("building client: %v", err)
+	}
+	return client
+}
+
+func TestNewNilConfig(t *testing.T) {
+	g := NewWithT(t)
+	_, err := apiclient.New(nil)
+	g.Expect(err).To(MatchError(ContainSubstring("nil exec config")))
+}
+
+func TestNewInvalidVerifySsl(t *testing.T) {
+	g := NewWithT(t)
+	_, err := apiclient.New(&decoder.CloudStackExecConfig{
+		Profiles: []decoder.CloudStackProfileConfig{{Name: profile, VerifySsl: "maybe"}},
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid verify-ssl value maybe for profile global")))
+}
+
+func TestNewInvalidTimeout(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("CLOUDSTACK_PREFLIGHT_TIMEOUT", "soon")
+	_, err := apiclient.New(&decoder.CloudStackExecConfig{})
+	g.Expect(err).To(MatchError(ContainSubstring("CLOUDSTACK_PREFLIGHT_TIMEOUT must be a number")))
+}
+
+func TestGetManagementApiEndpoint(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeCloudStack(t, nil)
+	client := f.client(t)
+
+	endpoint, err := client.GetManagementApiEndpoint(profile)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(endpoint).To(Equal(f.server.URL + "/client/api"))
+
+	_, err = client.GetManagementApiEndpoint("missing")
+	g.Expect(err).To(MatchError("profile missing does not exist"))
+}
+
+func TestCallSignsRequestsAndReusesConnections(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeCloudStack(t, map[string]string{
+		"listZones": `{"count": 1, "zone": [{"id": "` + zoneID + `", "name": "zone 1"}]}`,
+	})
+	client := f.client(t)
+
+	for i := 0; i < 3; i++ {
+		id, err := client.ValidateZoneAndGetId(ctx, profile, v1alpha1.CloudStackZone{Name: "zone 1"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(id).To(Equal(zoneID))
+	}
+	g.Expect(f.lastRequest().Get("name")).To(Equal("zone 1"))
+	g.Expect(f.lastRequest().Get("response")).To(Equal("json"))
+	g.Expect(f.newConnections()).To(Equal(1))
+	g.Expect(client.Close(ctx)).To(Succeed())
+}
+
+func TestCallWrongSecretKey(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeCloudStack(t, nil)
+	client, err := apiclient.New(&decoder.CloudStackExecConfig{
+		Profiles: []decoder.CloudStackProfileConfig{{Name: profile, ApiKey: apiKey, SecretKey: "wrong", ManagementUrl: f.server.URL}},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = client.ValidateZoneAndGetId(context.Background(), profile, v1alpha1.CloudStackZone{Id: zoneID})
+	g.Expect(err).To(MatchError("getting zones info: calling listZones: 401: unable to verify user credentials and/or request signature"))
+}
+
+func TestCallErrorResponse(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeCloudStack(t, map[string]string{
+		"listZones": `{"uuidList": [], "errorcode": 431, "errortext": "Unable to execute API command listzones due to invalid value"}`,
+	})
+
+	_, err := f.client(t).ValidateZoneAndGetId(context.Background(), profile, v1alpha1.CloudStackZone{Id: "invalid"})
+	g.Expect(err).To(MatchError("getting zones info: calling listZones: 431: Unable to execute API command listzones due to invalid value"))
+}
+
+func TestCallNonJSONResponse(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+	defer server.Close()
+	client, err := apiclient.New(&decoder.CloudStackExecConfig{
+		Profiles: []decoder.CloudStackProfileConfig{{Name: profile, ManagementUrl: server.URL}},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = client.ValidateZoneAndGetId(context.Background(), profile, v1alpha1.CloudStackZone{Id: zoneID})
+	g.Expect(err).To(MatchError(ContainSubstring("calling listZones: 502 Bad Gateway: bad gateway")))
+}
+
+func TestCallVerifySsl(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"listzonesresponse": {"count": 1, "zone": [{"id": "`+zoneID+`"}]}}`)
+	}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	defer server.Close()
+
+	for verify, matcher := range map[string]OmegaMatcher{
+		"true":  MatchError(ContainSubstring("certificate")),
+		"false": Not(HaveOccurred()),
+	} {
+		client, err := apiclient.New(&decoder.CloudStackExecConfig{
+			Profiles: []decoder.CloudStackProfileConfig{{Name: profile, ManagementUrl: server.URL, VerifySsl: verify}},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		_, err = client.ValidateZoneAndGetId(context.Background(), profile, v1alpha1.CloudStackZone{Id: zoneID})
+		g.Expect(err).To(matcher)
+	}
+}
+
+func TestValidateTemplatePresent(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	template := v1alpha1.CloudStackResourceIdentifier{Name: "kubernetes_1_21"}
+	f := newFakeCloudStack(t, map[string]string{
+		"listTemplates": `{"count": 1, "template": [{"id": "t-1", "name": "kubernetes_1_21"}]}`,
+	})
+	client := f.client(t)
+
+	g.Expect(client.ValidateTemplatePresent(ctx, profile, domainID, zoneID, "admin", template)).To(Succeed())
+	request := f.lastRequest()
+	g.Expect(request.Get("templatefilter")).To(Equal("all"))
+	g.Expect(request.Get("listall")).To(Equal("true"))
+	g.Expect(request.Get("name")).To(Equal("kubernetes_1_21"))
+	g.Expect(request.Get("zoneid")).To(Equal(zoneID))
+	g.Expect(request.Get("domainid")).To(Equal(domainID))
+	g.Expect(request.Get("account")).To(Equal("admin"))
+
+	f.responses["listTemplates"] = `{}`
+	g.Expect(client.ValidateTemplatePresent(ctx, profile, "", zoneID, "admin", template)).To(MatchError("template { kubernetes_1_21} not found"))
+	g.Expect(f.lastRequest().Has("account")).To(BeFalse())
+
+	f.responses["listTemplates"] = `{"count": 2, "template": [{"id": "t-1"}, {"id": "t-2"}]}`
+	g.Expect(client.ValidateTemplatePresent(ctx, profile, domainID, zoneID, "", template)).To(MatchError("duplicate templates { kubernetes_1_21} found"))
+}
+
+func TestValidateServiceOfferingPresent(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeCloudStack(t, map[string]string{
+		"listServiceOfferings": `{"count": 1, "serviceoffering": [{"id": "so-1", "name": "m4-large"}]}`,
+	})
+	client := f.client(t)
+
+	g.Expect(client.ValidateServiceOfferingPresent(ctx, profile, zoneID, v1alpha1.CloudStackResourceIdentifier{Id: "so-1"})).To(Succeed())
+	g.Expect(f.lastRequest().Get("id")).To(Equal("so-1"))
+
+	f.responses["listServiceOfferings"] = `{}`
+	g.Expect(client.ValidateServiceOfferingPresent(ctx, profile, zoneID, v1alpha1.CloudStackResourceIdentifier{Name: "m4-large"})).To(MatchError("service offering { m4-large} not found"))
+}
+
+func TestValidateDiskOfferingPresent(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	offering := v1alpha1.CloudStackResourceDiskOffering{CloudStackResourceIdentifier: v1alpha1.CloudStackResourceIdentifier{Name: "Small"}}
+	f := newFakeCloudStack(t, map[string]string{
+		"listDiskOfferings": `{"count": 1, "diskoffering": [{"id": "do-1", "name": "Small", "iscustomized": false, "disksize": 20}]}`,
+	})
+	client := f.client(t)
+
+	g.Expect(client.ValidateDiskOfferingPresent(ctx, profile, zoneID, offering)).To(Succeed())
+
+	offering.CustomSize = 10
+	g.Expect(client.ValidateDiskOfferingPresent(ctx, profile, zoneID, offering)).To(MatchError("disk offering size 10 > 0 for non-customized disk offering"))
+
+	f.responses["listDiskOfferings"] = `{"count": 1, "diskoffering": [{"id": "do-1", "name": "Small", "iscustomized": true}]}`
+	offering.CustomSize = 0
+	g.Expect(client.ValidateDiskOfferingPresent(ctx, profile, zoneID, offering)).To(MatchError("disk offering size 0 <= 0 for customized disk offering"))
+}
+
+func TestValidateAffinityGroupsPresent(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeCloudStack(t, map[string]string{
+		"listAffinityGroups": `{"count": 1, "affinitygroup": [{"id": "ag-1", "name": "anti", "type": "host anti-affinity"}]}`,
+	})
+	client := f.client(t)
+
+	g.Expect(client.ValidateAffinityGroupsPresent(ctx, profile, domainID, "admin", []string{"ag-1"})).To(Succeed())
+	g.Expect(f.lastRequest().Get("id")).To(Equal("ag-1"))
+	g.Expect(f.lastRequest().Get("account")).To(Equal("admin"))
+
+	f.responses["listAffinityGroups"] = `{}`
+	g.Expect(client.ValidateAffinityGroupsPresent(ctx, profile, domainID, "admin", []string{"ag-2"})).To(MatchError("affinity group ag-2 not found"))
+}
+
+func TestValidateDomainAndGetId(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeCloudStack(t, map[string]string{
+		"listDomains": `{"count": 2, "domain": [
+			{"id": "d-1", "name": "eksa", "path": "ROOT/other/eksa"},
+			{"id": "d-2", "name": "eksa", "path": "ROOT/team/eksa"}
+		]}`,
+	})
+	client := f.client(t)
+
+	id, err := client.ValidateDomainAndGetId(ctx, profile, "team/eksa")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(id).To(Equal("d-2"))
+	g.Expect(f.lastRequest().Get("name")).To(Equal("eksa"))
+
+	_, err = client.ValidateDomainAndGetId(ctx, profile, "eksa")
+	g.Expect(err).To(MatchError("domain(s) found for domain name eksa, but not found a domain with domain path ROOT/eksa"))
+
+	f.responses["listDomains"] = `{}`
+	_, err = client.ValidateDomainAndGetId(ctx, profile, "eksa")
+	g.Expect(err).To(MatchError("domain eksa not found"))
+}
+
+func TestValidateNetworkPresent(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeCloudStack(t, map[string]string{
+		"listNetworks": `{"count": 2, "network": [{"id": "n-1", "name": "net1"}, {"id": "n-2", "name": "net2"}]}`,
+	})
+	client := f.client(t)
+
+	g.Expect(client.ValidateNetworkPresent(ctx, profile, domainID, v1alpha1.CloudStackResourceIdentifier{Name: "net1"}, zoneID, "admin")).To(Succeed())
+	g.Expect(client.ValidateNetworkPresent(ctx, profile, domainID, v1alpha1.CloudStackResourceIdentifier{Name: "net3"}, zoneID, "admin")).
+		To(MatchError(fmt.Sprintf("network { net3} not found in zoneRef %s", zoneID)))
+	g.Expect(client.ValidateNetworkPresent(ctx, profile, domainID, v1alpha1.CloudStackResourceIdentifier{Id: "n-1"}, zoneID, "admin")).
+		To(MatchError("duplicate network {n-1 } found"))
+}
+
+func TestGetNetworkType(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeCloudStack(t, map[string]string{
+		"listNetworks": `{"count": 2, "network": [{"id": "n-1", "name": "net1", "type": "Isolated"}, {"id": "n-2", "name": "net2", "type": "Shared"}]}`,
+	})
+	client := f.client(t)
+
+	networkType, err := client.GetNetworkType(ctx, profile, domainID, v1alpha1.CloudStackResourceIdentifier{Name: "net2"}, zoneID, "admin")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(networkType).To(Equal("Shared"))
+
+	_, err = client.GetNetworkType(ctx, profile, domainID, v1alpha1.CloudStackResourceIdentifier{Name: "net3"}, zoneID, "admin")
+	g.Expect(err).To(MatchError(fmt.Sprintf("network { net3} not found in zoneRef %s", zoneID)))
+}
+
+func TestValidateAccountPresent(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeCloudStack(t, map[string]string{
+		"listAccounts": `{"count": 1, "account": [{"id": "a-1", "name": "admin"}]}`,
+	})
+	client := f.client(t)
+
+	g.Expect(client.ValidateAccountPresent(ctx, profile, "", domainID)).To(Succeed())
+	g.Expect(f.requests).To(BeEmpty())
+	g.Expect(client.ValidateAccountPresent(ctx, profile, "admin", domainID)).To(Succeed())
+
+	f.responses["listAccounts"] = `{}`
+	g.Expect(client.ValidateAccountPresent(ctx, profile, "admin", domainID)).To(MatchError("account admin not found"))
+}
+
+func TestGetResourceLimits(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeCloudStack(t, map[string]string{
+		"listAccounts": `{"count": 1, "account": [{"name": "admin", "vmlimit": "10", "vmtotal": 8, "cpulimit": "Unlimited", "cputotal": 16,
+			"memorylimit": "40960", "memorytotal": 32768, "primarystoragelimit": "200", "primarystoragetotal": 240, "iptotal": 1}]}`,
+		"listDomains": `{"count": 1, "domain": [{"id": "` + domainID + `", "vmlimit": "Unlimited", "vmtotal": 2}]}`,
+	})
+	client := f.client(t)
+
+	limits, err := client.GetAccountResourceLimits(ctx, profile, domainID, "admin")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*limits).To(Equal(executables.CloudStackResourceLimits{
+		VMs:               executables.CloudStackResourceLimit{Limit: 10, Total: 8},
+		CPUs:              executables.CloudStackResourceLimit{Limit: -1, Total: 16},
+		MemoryMiB:         executables.CloudStackResourceLimit{Limit: 40960, Total: 32768},
+		PrimaryStorageGiB: executables.CloudStackResourceLimit{Limit: 200, Total: 240},
+		PublicIPs:         executables.CloudStackResourceLimit{Limit: -1, Total: 1},
+	}))
+
+	limits, err = client.GetDomainResourceLimits(ctx, profile, domainID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(limits.VMs).To(Equal(executables.CloudStackResourceLimit{Limit: -1, Total: 2}))
+	g.Expect(f.lastRequest().Get("id")).To(Equal(domainID))
+
+	f.responses["listAccounts"] = `{}`
+	_, err = client.GetAccountResourceLimits(ctx, profile, domainID, "admin")
+	g.Expect(err).To(MatchError("expected one account admin, found 0"))
+}
+
+func TestGetMachineResources(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeCloudStack(t, map[string]string{
+		"listServiceOfferings": `{"count": 1, "serviceoffering": [{"id": "so-1", "cpunumber": 2, "memory": 4096, "rootdisksize": 0}]}`,
+		"listTemplates":        `{"count": 1, "template": [{"id": "t-1", "size": 8589934593}]}`,
+		"listDiskOfferings":    `{"count": 1, "diskoffering": [{"id": "do-1", "disksize": 20}]}`,
+	})
+	client := f.client(t)
+	machineConfig := v1alpha1.CloudStackMachineConfigSpec{
+		ComputeOffering: v1alpha1.CloudStackResourceIdentifier{Id: "so-1"},
+		Template:        v1alpha1.CloudStackResourceIdentifier{Id: "t-1"},
+		DiskOffering:    &v1alpha1.CloudStackResourceDiskOffering{CloudStackResourceIdentifier: v1alpha1.CloudStackResourceIdentifier{Id: "do-1"}},
+	}
+
+	resources, err := client.GetMachineResources(ctx, profile, domainID, zoneID, "admin", machineConfig)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*resources).To(Equal(executables.CloudStackMachineResources{CPUs: 2, MemoryMiB: 4096, StorageGiB: 29}))
+
+	f.responses["listServiceOfferings"] = `{"count": 1, "serviceoffering": [{"id": "so-1", "cpunumber": 2, "memory": 4096, "rootdisksize": 50}]}`
+	machineConfig.DiskOffering.CustomSize = 100
+	resources, err = client.GetMachineResources(ctx, profile, domainID, zoneID, "admin", machineConfig)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resources.StorageGiB).To(Equal(int64(150)))
+	g.Expect(f.lastRequest().Get("command")).To(Equal("listServiceOfferings"))
+}
+
+func TestBuilderBuildCloudstackClient(t *testing.T) {
+	g := NewWithT(t)
+	b := apiclient.NewBuilder()
+
+	client, err := b.BuildCloudstackClient(nil, &decoder.CloudStackExecConfig{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(client).NotTo(BeNil())
+
+	client, err = b.BuildCloudstackClient(nil, nil)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(client).To(BeNil())
+}
+
+func TestListAffinityGroups(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeCloudStack(t, map[string]string{
+		"listAffinityGroups": `{"count": 1, "affinitygroup": [{"id": "ag-1", "name": "eksa-test-control-plane", "type": "host anti-affinity", "description": "Managed by EKS Anywhere for cluster test", "virtualmachineIds": ["vm-1"]}]}`,
+	})
+
+	groups, err := f.client(t).ListAffinityGroups(context.Background(), profile, domainID, "admin")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(groups).To(Equal([]executables.CloudStackAffinityGroup{{
+		Id:                "ag-1",
+		Name:              "eksa-test-control-plane",
+		Type:              "host anti-affinity",
+		Description:       "Managed by EKS Anywhere for cluster test",
+		VirtualMachineIds: []string{"vm-1"},
+	}}))
+	g.Expect(f.lastRequest().Get("listall")).To(Equal("true"))
+	g.Expect(f.lastRequest().Get("account")).To(Equal("admin"))
+}
+
+func TestCreateAffinityGroupWaitsForJob(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeCloudStack(t, map[string]string{
+		"createAffinityGroup": `{"jobid": "job-1"}`,
+		"queryAsyncJobResult": `{"jobid": "job-1", "jobstatus": 1, "jobresult": {"affinitygroup": {"id": "ag-1", "name": "eksa-test-control-plane"}}}`,
+	})
+
+	id, err := f.client(t).CreateAffinityGroup(context.Background(), profile, domainID, "admin", executables.CloudStackAffinityGroup{
+		Name:        "eksa-test-control-plane",
+		Type:        "host anti-affinity",
+		Description: "Managed by EKS Anywhere for cluster test",
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(id).To(Equal("ag-1"))
+	g.Expect(f.lastRequest().Get("jobid")).To(Equal("job-1"))
+	g.Expect(f.requests[0].Get("description")).To(Equal("Managed by EKS Anywhere for cluster test"))
+}
+
+func TestDeleteAffinityGroupJobFailed(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeCloudStack(t, map[string]string{
+		"deleteAffinityGroup": `{"jobid": "job-1"}`,
+		"queryAsyncJobResult": `{"jobid": "job-1", "jobstatus": 2, "jobresult": {"errorcode": 530, "errortext": "affinity group has virtual machines"}}`,
+	})
+
+	err := f.client(t).DeleteAffinityGroup(context.Background(), profile, "ag-1")
+	g.Expect(err).To(MatchError("deleting affinity group ag-1: calling deleteAffinityGroup: 530: affinity group has virtual machines"))
+}
+
+func TestListVirtualMachines(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeCloudStack(t, map[string]string{
+		"listVirtualMachines": `{"count": 1, "virtualmachine": [{"id": "vm-1", "name": "test-control-plane-abcd"}]}`,
+	})
+
+	vms, err := f.client(t).ListVirtualMachines(context.Background(), profile, "test")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(vms).To(Equal([]executables.CloudStackVirtualMachine{{Id: "vm-1", Name: "test-control-plane-abcd"}}))
+	g.Expect(f.lastRequest().Get("keyword")).To(Equal("test"))
+	g.Expect(f.lastRequest().Get("listall")).To(Equal("true"))
+}
+
+func TestDestroyVirtualMachine(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeCloudStack(t, map[string]string{
+		"stopVirtualMachine":    `{"jobid": "job-1"}`,
+		"destroyVirtualMachine": `{"jobid": "job-2"}`,
+		"queryAsyncJobResult":   `{"jobstatus": 1, "jobresult": {}}`,
+	})
+
+	g.Expect(f.client(t).DestroyVirtualMachine(context.Background(), profile, "vm-1")).To(Succeed())
+	g.Expect(f.requests[0].Get("command")).To(Equal("stopVirtualMachine"))
+	g.Expect(f.requests[0].Get("forced")).To(Equal("true"))
+	g.Expect(f.requests[2].Get("command")).To(Equal("destroyVirtualMachine"))
+	g.Expect(f.requests[2].Get("expunge")).To(Equal("true"))
+}
@@ -0,0 +1,514 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/executables"
+)
+
+const (
+	rootDomain      = "ROOT"
+	domainDelimiter = "/"
+)
+
+type resourceIdentifier struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type template struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+type serviceOffering struct {
+	Id           string `json:"id"`
+	Name         string `json:"name"`
+	CpuNumber    int64  `json:"cpunumber"`
+	Memory       int64  `json:"memory"`
+	RootDiskSize int64  `json:"rootdisksize"`
+}
+
+type diskOffering struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	Customized bool   `json:"iscustomized"`
+	DiskSize   int64  `json:"disksize"`
+}
+
+type affinityGroup struct {
+	Id                string   `json:"id"`
+	Name              string   `json:"name"`
+	Type              string   `json:"type"`
+	Description       string   `json:"description"`
+	VirtualMachineIds []string `json:"virtualmachineIds"`
+}
+
+type domain struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// identify sets the id parameter when the identifier has one, the name otherwise.
+func identify(params url.Values, id, name string) {
+	if len(id) > 0 {
+		params.Set("id", id)
+	} else {
+		params.Set("name", name)
+	}
+}
+
+// withOwner scopes a query to a domain and optionally to an account, which must be specified with a domain.
+func withOwner(params url.Values, domainId, account string) {
+	if len(domainId) > 0 {
+		params.Set("domainid", domainId)
+		if len(account) > 0 {
+			params.Set("account", account)
+		}
+	}
+}
+
+func (c *Client) listTemplates(ctx context.Context, profile, domainId, zoneId, account string, t v1alpha1.CloudStackResourceIdentifier) ([]template, error) {
+	params := url.Values{"templatefilter": {"all"}, "listall": {"true"}, "zoneid": {zoneId}}
+	identify(params, t.Id, t.Name)
+	withOwner(params, domainId, account)
+	response := struct {
+		Templates []template `json:"template"`
+	}{}
+	if err := c.call(ctx, profile, "listTemplates", params, &response); err != nil {
+		return nil, fmt.Errorf("getting templates info: %v", err)
+	}
+	return response.Templates, nil
+}
+
+func (c *Client) listServiceOfferings(ctx context.Context, profile, zoneId string, offering v1alpha1.CloudStackResourceIdentifier) ([]serviceOffering, error) {
+	params := url.Values{"zoneid": {zoneId}}
+	identify(params, offering.Id, offering.Name)
+	response := struct {
+		ServiceOfferings []serviceOffering `json:"serviceoffering"`
+	}{}
+	if err := c.call(ctx, profile, "listServiceOfferings", params, &response); err != nil {
+		return nil, fmt.Errorf("getting service offerings info: %v", err)
+	}
+	return response.ServiceOfferings, nil
+}
+
+func (c *Client) listDiskOfferings(ctx context.Context, profile, zoneId string, offering v1alpha1.CloudStackResourceDiskOffering) ([]diskOffering, error) {
+	params := url.Values{"zoneid": {zoneId}}
+	identify(params, offering.Id, offering.Name)
+	response := struct {
+		DiskOfferings []diskOffering `json:"diskoffering"`
+	}{}
+	if err := c.call(ctx, profile, "listDiskOfferings", params, &response); err != nil {
+		return nil, fmt.Errorf("getting disk offerings info: %v", err)
+	}
+	return response.DiskOfferings, nil
+}
+
+// ValidateTemplatePresent checks that exactly one template matches the identifier in a zone.
+func (c *Client) ValidateTemplatePresent(ctx context.Context, profile string, domainId string, zoneId string, account string, t v1alpha1.CloudStackResourceIdentifier) error {
+	templates, err := c.listTemplates(ctx, profile, domainId, zoneId, account, t)
+	if err != nil {
+		return err
+	}
+	if len(templates) > 1 {
+		return fmt.Errorf("duplicate templates %s found", t)
+	} else if len(templates) == 0 {
+		return fmt.Errorf("template %s not found", t)
+	}
+	return nil
+}
+
+// ValidateServiceOfferingPresent checks that exactly one service offering matches the identifier in a zone.
+func (c *Client) ValidateServiceOfferingPresent(ctx context.Context, profile string, zoneId string, offering v1alpha1.CloudStackResourceIdentifier) error {
+	offerings, err := c.listServiceOfferings(ctx, profile, zoneId, offering)
+	if err != nil {
+		return err
+	}
+	if len(offerings) > 1 {
+		return fmt.Errorf("duplicate service offering %s found", offering)
+	} else if len(offerings) == 0 {
+		return fmt.Errorf("service offering %s not found", offering)
+	}
+	return nil
+}
+
+// ValidateDiskOfferingPresent checks that exactly one disk offering matches the identifier in a zone
+// and that a custom size is set if and only if the offering is customized.
+func (c *Client) ValidateDiskOfferingPresent(ctx context.Context, profile string, zoneId string, offering v1alpha1.CloudStackResourceDiskOffering) error {
+	offerings, err := c.listDiskOfferings(ctx, profile, zoneId, offering)
+	if err != nil {
+		return err
+	}
+	if len(offerings) > 1 {
+		return fmt.Errorf("duplicate disk offering ID/Name %s/%s found", offering.Id, offering.Name)
+	} else if len(offerings) == 0 {
+		return fmt.Errorf("disk offering ID/Name %s/%s not found", offering.Id, offering.Name)
+	}
+
+	if offerings[0].Customized && offering.CustomSize <= 0 {
+		return fmt.Errorf("disk offering size %d <= 0 for customized disk offering", offering.CustomSize)
+	}
+	if !offerings[0].Customized && offering.CustomSize > 0 {
+		return fmt.Errorf("disk offering size %d > 0 for non-customized disk offering", offering.CustomSize)
+	}
+	return nil
+}
+
+// ValidateAffinityGroupsPresent checks that every affinity group exists for the domain and account.
+func (c *Client) ValidateAffinityGroupsPresent(ctx context.Context, profile string, domainId string, account string, affinityGroupIds []string) error {
+	for _, affinityGroupId := range affinityGroupIds {
+		params := url.Values{"id": {affinityGroupId}}
+		withOwner(params, domainId, account)
+		response := struct {
+			AffinityGroups []resourceIdentifier `json:"affinitygroup"`
+		}{}
+		if err := c.call(ctx, profile, "listAffinityGroups", params, &response); err != nil {
+			return fmt.Errorf("getting affinity group info: %v", err)
+		}
+		if len(response.AffinityGroups) > 1 {
+			return fmt.Errorf("duplicate affinity group %s found", affinityGroupId)
+		} else if len(response.AffinityGroups) == 0 {
+			return fmt.Errorf("affinity group %s not found", affinityGroupId)
+		}
+	}
+	return nil
+}
+
+// ListAffinityGroups returns the affinity groups of an account, or of a domain when no account is given.
+func (c *Client) ListAffinityGroups(ctx context.Context, profile string, domainId string, account string) ([]executables.CloudStackAffinityGroup, error) {
+	params := url.Values{"listall": {"true"}}
+	withOwner(params, domainId, account)
+	response := struct {
+		AffinityGroups []affinityGroup `json:"affinitygroup"`
+	}{}
+	if err := c.call(ctx, profile, "listAffinityGroups", params, &response); err != nil {
+		return nil, fmt.Errorf("getting affinity groups info: %v", err)
+	}
+
+	groups := make([]executables.CloudStackAffinityGroup, 0, len(response.AffinityGroups))
+	for _, g := range response.AffinityGroups {
+		groups = append(groups, executables.CloudStackAffinityGroup{
+			Id:                g.Id,
+			Name:              g.Name,
+			Type:              g.Type,
+			Description:       g.Description,
+			VirtualMachineIds: g.VirtualMachineIds,
+		})
+	}
+	return groups, nil
+}
+
+// CreateAffinityGroup creates an affinity group in an account, or in a domain when no account is given,
+// and returns its id.
+func (c *Client) CreateAffinityGroup(ctx context.Context, profile string, domainId string, account string, group executables.CloudStackAffinityGroup) (string, error) {
+	params := url.Values{
+		"name":        {group.Name},
+		"type":        {group.Type},
+		"description": {group.Description},
+	}
+	withOwner(params, domainId, account)
+	response := struct {
+		AffinityGroup affinityGroup `json:"affinitygroup"`
+	}{}
+	if err := c.callAsync(ctx, profile, "createAffinityGroup", params, &response); err != nil {
+		return "", fmt.Errorf("creating affinity group %s: %v", group.Name, err)
+	}
+	if response.AffinityGroup.Id == "" {
+		return "", fmt.Errorf("creating affinity group %s: no id returned", group.Name)
+	}
+	return response.AffinityGroup.Id, nil
+}
+
+// DeleteAffinityGroup deletes an affinity group.
+func (c *Client) DeleteAffinityGroup(ctx context.Context, profile string, id string) error {
+	if err := c.callAsync(ctx, profile, "deleteAffinityGroup", url.Values{"id": {id}}, nil); err != nil {
+		return fmt.Errorf("deleting affinity group %s: %v", id, err)
+	}
+	return nil
+}
+
+// ListVirtualMachines returns the virtual machines whose name contains a keyword, in all the domains and accounts the profile can access.
+func (c *Client) ListVirtualMachines(ctx context.Context, profile string, keyword string) ([]executables.CloudStackVirtualMachine, error) {
+	params := url.Values{"listall": {"true"}, "keyword": {keyword}}
+	response := struct {
+		VirtualMachines []resourceIdentifier `json:"virtualmachine"`
+	}{}
+	if err := c.call(ctx, profile, "listVirtualMachines", params, &response); err != nil {
+		return nil, fmt.Errorf("getting virtual machines info: %v", err)
+	}
+
+	vms := make([]executables.CloudStackVirtualMachine, 0, len(response.VirtualMachines))
+	for _, vm := range response.VirtualMachines {
+		vms = append(vms, executables.CloudStackVirtualMachine{Id: vm.Id, Name: vm.Name})
+	}
+	return vms, nil
+}
+
+// DestroyVirtualMachine stops a virtual machine and destroys it, expunging it right away.
+func (c *Client) DestroyVirtualMachine(ctx context.Context, profile string, id string) error {
+	if err := c.callAsync(ctx, profile, "stopVirtualMachine", url.Values{"id": {id}, "forced": {"true"}}, nil); err != nil {
+		return fmt.Errorf("stopping virtual machine %s: %v", id, err)
+	}
+	if err := c.callAsync(ctx, profile, "destroyVirtualMachine", url.Values{"id": {id}, "expunge": {"true"}}, nil); err != nil {
+		return fmt.Errorf("destroying virtual machine %s: %v", id, err)
+	}
+	return nil
+}
+
+// ValidateZoneAndGetId checks that exactly one zone matches the identifier and returns its id.
+func (c *Client) ValidateZoneAndGetId(ctx context.Context, profile string, zone v1alpha1.CloudStackZone) (string, error) {
+	params := url.Values{}
+	identify(params, zone.Id, zone.Name)
+	response := struct {
+		Zones []resourceIdentifier `json:"zone"`
+	}{}
+	if err := c.call(ctx, profile, "listZones", params, &response); err != nil {
+		return "", fmt.Errorf("getting zones info: %v", err)
+	}
+	if len(response.Zones) > 1 {
+		return "", fmt.Errorf("duplicate zone %s found", zone)
+	} else if len(response.Zones) == 0 {
+		return "", fmt.Errorf("zone %s not found", zone)
+	}
+	return response.Zones[0].Id, nil
+}
+
+// ValidateDomainAndGetId looks a domain up by path and returns its id.
+func (c *Client) ValidateDomainAndGetId(ctx context.Context, profile string, domainPath string) (string, error) {
+	// The listDomains API does not support querying by path, so look the domain up by name,
+	// which is the last element of the path, and match the path in the results.
+	tokens := strings.Split(domainPath, domainDelimiter)
+	params := url.Values{"name": {tokens[len(tokens)-1]}, "listall": {"true"}}
+	response := struct {
+		Domains []domain `json:"domain"`
+	}{}
+	if err := c.call(ctx, profile, "listDomains", params, &response); err != nil {
+		return "", fmt.Errorf("getting domain info: %v", err)
+	}
+	if len(response.Domains) == 0 {
+		return "", fmt.Errorf("domain %s not found", domainPath)
+	}
+
+	fullPath := rootDomain
+	if domainPath != rootDomain {
+		fullPath = strings.Join([]string{rootDomain, domainPath}, domainDelimiter)
+	}
+	for _, d := range response.Domains {
+		if d.Path == fullPath {
+			return d.Id, nil
+		}
+	}
+	return "", fmt.Errorf("domain(s) found for domain name %s, but not found a domain with domain path %s", domainPath, fullPath)
+}
+
+// ValidateNetworkPresent checks that exactly one network matches the identifier in a zone.
+func (c *Client) ValidateNetworkPresent(ctx context.Context, profile string, domainId string, network v1alpha1.CloudStackResourceIdentifier, zoneId string, account string) error {
+	_, err := c.findNetwork(ctx, profile, domainId, network, zoneId, account)
+	return err
+}
+
+// GetNetworkType returns the type of the network matching the identifier in a zone, Isolated or Shared.
+func (c *Client) GetNetworkType(ctx context.Context, profile string, domainId string, network v1alpha1.CloudStackResourceIdentifier, zoneId string, account string) (string, error) {
+	n, err := c.findNetwork(ctx, profile, domainId, network, zoneId, account)
+	if err != nil {
+		return "", err
+	}
+	return n.Type, nil
+}
+
+type networkInfo struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func (c *Client) findNetwork(ctx context.Context, profile string, domainId string, network v1alpha1.CloudStackResourceIdentifier, zoneId string, account string) (*networkInfo, error) {
+	params := url.Values{"zoneid": {zoneId}}
+	if len(network.Id) > 0 {
+		params.Set("id", network.Id)
+	}
+	withOwner(params, domainId, account)
+	response := struct {
+		Networks []networkInfo `json:"network"`
+	}{}
+	if err := c.call(ctx, profile, "listNetworks", params, &response); err != nil {
+		return nil, fmt.Errorf("getting network info: %v", err)
+	}
+
+	// listNetworks does not filter by name, so confirm the name of the network found by id, or keep only the networks with that name.
+	networks := response.Networks
+	if len(network.Name) > 0 {
+		networks = []networkInfo{}
+		for _, n := range response.Networks {
+			if n.Name == network.Name {
+				networks = append(networks, n)
+			}
+		}
+	}
+
+	if len(networks) > 1 {
+		return nil, fmt.Errorf("duplicate network %s found", network)
+	} else if len(networks) == 0 {
+		return nil, fmt.Errorf("network %s not found in zoneRef %s", network, zoneId)
+	}
+	return &networks[0], nil
+}
+
+// ValidateAccountPresent checks that an account exists in a domain. An empty account is always valid.
+func (c *Client) ValidateAccountPresent(ctx context.Context, profile string, account string, domainId string) error {
+	if len(account) == 0 {
+		return nil
+	}
+
+	accounts, err := c.listAccounts(ctx, profile, domainId, account)
+	if err != nil {
+		return err
+	}
+	if len(accounts) > 1 {
+		return fmt.Errorf("duplicate account %s found", account)
+	} else if len(accounts) == 0 {
+		return fmt.Errorf("account %s not found", account)
+	}
+	return nil
+}
+
+func (c *Client) listAccounts(ctx context.Context, profile, domainId, account string) ([]resourceLimits, error) {
+	params := url.Values{"name": {account}, "domainid": {domainId}}
+	response := struct {
+		Accounts []resourceLimits `json:"account"`
+	}{}
+	if err := c.call(ctx, profile, "listAccounts", params, &response); err != nil {
+		return nil, fmt.Errorf("getting accounts info: %v", err)
+	}
+	return response.Accounts, nil
+}
+
+// GetAccountResourceLimits returns the resource limits and usage of an account.
+func (c *Client) GetAccountResourceLimits(ctx context.Context, profile string, domainId string, account string) (*executables.CloudStackResourceLimits, error) {
+	accounts, err := c.listAccounts(ctx, profile, domainId, account)
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) != 1 {
+		return nil, fmt.Errorf("expected one account %s, found %d", account, len(accounts))
+	}
+	return accounts[0].limits(), nil
+}
+
+// GetDomainResourceLimits returns the resource limits and usage of a domain.
+func (c *Client) GetDomainResourceLimits(ctx context.Context, profile string, domainId string) (*executables.CloudStackResourceLimits, error) {
+	params := url.Values{"id": {domainId}, "listall": {"true"}}
+	response := struct {
+		Domains []resourceLimits `json:"domain"`
+	}{}
+	if err := c.call(ctx, profile, "listDomains", params, &response); err != nil {
+		return nil, fmt.Errorf("getting domain info: %v", err)
+	}
+	if len(response.Domains) != 1 {
+		return nil, fmt.Errorf("expected one domain %s, found %d", domainId, len(response.Domains))
+	}
+	return response.Domains[0].limits(), nil
+}
+
+// GetMachineResources returns the resources counted against the account and domain limits for a machine
+// deployed in a zone with the given template, compute offering and disk offering.
+func (c *Client) GetMachineResources(ctx context.Context, profile string, domainId string, zoneId string, account string, machineConfig v1alpha1.CloudStackMachineConfigSpec) (*executables.CloudStackMachineResources, error) {
+	offerings, err := c.listServiceOfferings(ctx, profile, zoneId, machineConfig.ComputeOffering)
+	if err != nil {
+		return nil, err
+	}
+	if len(offerings) != 1 {
+		return nil, fmt.Errorf("expected one service offering %s, found %d", machineConfig.ComputeOffering, len(offerings))
+	}
+
+	resources := &executables.CloudStackMachineResources{
+		CPUs:       offerings[0].CpuNumber,
+		MemoryMiB:  offerings[0].Memory,
+		StorageGiB: offerings[0].RootDiskSize,
+	}
+
+	if resources.StorageGiB == 0 {
+		templates, err := c.listTemplates(ctx, profile, domainId, zoneId, account, machineConfig.Template)
+		if err != nil {
+			return nil, err
+		}
+		if len(templates) != 1 {
+			return nil, fmt.Errorf("expected one template %s, found %d", machineConfig.Template, len(templates))
+		}
+		resources.StorageGiB = (templates[0].Size + 1<<30 - 1) >> 30
+	}
+
+	disk := machineConfig.DiskOffering
+	if disk != nil && disk.CustomSize > 0 {
+		resources.StorageGiB += disk.CustomSize
+	} else if disk != nil && (len(disk.Id) > 0 || len(disk.Name) > 0) {
+		diskOfferings, err := c.listDiskOfferings(ctx, profile, zoneId, *disk)
+		if err != nil {
+			return nil, err
+		}
+		if len(diskOfferings) != 1 {
+			return nil, fmt.Errorf("expected one disk offering ID/Name %s/%s, found %d", disk.Id, disk.Name, len(diskOfferings))
+		}
+		resources.StorageGiB += diskOfferings[0].DiskSize
+	}
+
+	return resources, nil
+}
+
+type resourceLimits struct {
+	VMLimit             *resourceCount `json:"vmlimit"`
+	VMTotal             resourceCount  `json:"vmtotal"`
+	CPULimit            *resourceCount `json:"cpulimit"`
+	CPUTotal            resourceCount  `json:"cputotal"`
+	MemoryLimit         *resourceCount `json:"memorylimit"`
+	MemoryTotal         resourceCount  `json:"memorytotal"`
+	PrimaryStorageLimit *resourceCount `json:"primarystoragelimit"`
+	PrimaryStorageTotal resourceCount  `json:"primarystoragetotal"`
+	IPLimit             *resourceCount `json:"iplimit"`
+	IPTotal             resourceCount  `json:"iptotal"`
+}
+
+func (r resourceLimits) limits() *executables.CloudStackResourceLimits {
+	return &executables.CloudStackResourceLimits{
+		VMs:               executables.CloudStackResourceLimit{Limit: r.VMLimit.limit(), Total: int64(r.VMTotal)},
+		CPUs:              executables.CloudStackResourceLimit{Limit: r.CPULimit.limit(), Total: int64(r.CPUTotal)},
+		MemoryMiB:         executables.CloudStackResourceLimit{Limit: r.MemoryLimit.limit(), Total: int64(r.MemoryTotal)},
+		PrimaryStorageGiB: executables.CloudStackResourceLimit{Limit: r.PrimaryStorageLimit.limit(), Total: int64(r.PrimaryStorageTotal)},
+		PublicIPs:         executables.CloudStackResourceLimit{Limit: r.IPLimit.limit(), Total: int64(r.IPTotal)},
+	}
+}
+
+// resourceCount is a resource limit or total, returned either as a number, a numeric string
+// or "Unlimited", which is decoded as -1.
+type resourceCount int64
+
+// limit returns the count as a limit, where a limit missing from the response is unlimited.
+func (c *resourceCount) limit() int64 {
+	if c == nil {
+		return -1
+	}
+	return int64(*c)
+}
+
+func (c *resourceCount) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), "\"")
+	if value == "" || strings.EqualFold(value, "Unlimited") {
+		*c = -1
+		return nil
+	}
+
+	count, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("invalid resource count %s: %v", data, err)
+	}
+	*c = resourceCount(count)
+	return nil
+}
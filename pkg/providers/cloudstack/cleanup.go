@@ -0,0 +1,107 @@
+package cloudstack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cleanup"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+const (
+	vmResourceKind            = "VirtualMachine"
+	affinityGroupResourceKind = "AffinityGroup"
+)
+
+// CleanupDiscoverer finds the virtual machines of a cluster, named after its machines by CAPC, and the
+// affinity groups EKS Anywhere manages for it in the profiles used by its availability zones.
+type CleanupDiscoverer struct {
+	cmk        ProviderCmkClient
+	datacenter *anywherev1.CloudStackDatacenterConfig
+	machines   *cleanup.MachineNames
+}
+
+// NewCleanupDiscoverer builds a CleanupDiscoverer for the availability zones and machines of a cluster spec.
+func NewCleanupDiscoverer(cmk ProviderCmkClient, spec *cluster.Spec) *CleanupDiscoverer {
+	return &CleanupDiscoverer{
+		cmk:        cmk,
+		datacenter: spec.CloudStackDatacenter,
+		machines:   cleanup.NewMachineNames(spec.Cluster),
+	}
+}
+
+// Name returns the infrastructure the CleanupDiscoverer looks in.
+func (d *CleanupDiscoverer) Name() string {
+	return "cloudstack"
+}
+
+// Discover returns the virtual machines of the cluster followed by its managed affinity groups,
+// which can only be deleted once they don't have virtual machines.
+func (d *CleanupDiscoverer) Discover(ctx context.Context, clusterName string) ([]cleanup.Resource, error) {
+	profiles := credentialsRefs(d.datacenter).ToSlice()
+	sort.Strings(profiles)
+
+	resources := []cleanup.Resource{}
+	seen := map[string]bool{}
+	for _, profile := range profiles {
+		vms, err := d.cmk.ListVirtualMachines(ctx, profile, clusterName)
+		if err != nil {
+			return nil, err
+		}
+		for _, vm := range vms {
+			// The keyword matches any part of the name, so only the machines of the cluster are kept.
+			if !d.machines.Match(vm.Name) || seen[vm.Id] {
+				continue
+			}
+			seen[vm.Id] = true
+			resources = append(resources, cleanup.Resource{Kind: vmResourceKind, Name: vm.Name, ID: vm.Id, Scope: profile})
+		}
+	}
+
+	groups, err := d.discoverAffinityGroups(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(resources, groups...), nil
+}
+
+func (d *CleanupDiscoverer) discoverAffinityGroups(ctx context.Context, clusterName string) ([]cleanup.Resource, error) {
+	affinityGroups := NewAffinityGroupClient(d.cmk)
+	scopes, err := affinityGroups.scopes(ctx, d.datacenter)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []cleanup.Resource{}
+	for _, scope := range scopes {
+		owned, err := affinityGroups.ownedAffinityGroups(ctx, scope, clusterName)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(owned))
+		for name := range owned {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			resources = append(resources, cleanup.Resource{Kind: affinityGroupResourceKind, Name: name, ID: owned[name].Id, Scope: scope.profile})
+		}
+	}
+
+	return resources, nil
+}
+
+// Delete destroys a virtual machine or deletes an affinity group with the profile it was found with.
+func (d *CleanupDiscoverer) Delete(ctx context.Context, resource cleanup.Resource) error {
+	switch resource.Kind {
+	case vmResourceKind:
+		return d.cmk.DestroyVirtualMachine(ctx, resource.Scope, resource.ID)
+	case affinityGroupResourceKind:
+		return d.cmk.DeleteAffinityGroup(ctx, resource.Scope, resource.ID)
+	default:
+		return fmt.Errorf("unsupported cloudstack resource kind %s", resource.Kind)
+	}
+}
@@ -0,0 +1,77 @@
+package cloudstack
+
+import (
+	"context"
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cleanup"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/mocks"
+)
+
+func TestCleanupDiscovererDiscover(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
+	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+
+	cmk.EXPECT().ListVirtualMachines(ctx, "global", "test").Return([]executables.CloudStackVirtualMachine{
+		{Id: "vm-1", Name: "test-x7k2p"},
+		{Id: "vm-2", Name: "test-etcd-q9w4z"},
+		{Id: "vm-3", Name: "test-md-0-5d8fc-abcde"},
+		{Id: "vm-4", Name: "mytest-x7k2p"},
+		{Id: "vm-5", Name: "test-prod-x7k2p"},
+		{Id: "vm-6", Name: "test"},
+	}, nil)
+	cmk.EXPECT().ValidateDomainAndGetId(ctx, "global", "domain1").Return(affinityTestDomainID, nil)
+	cmk.EXPECT().ListAffinityGroups(ctx, "global", affinityTestDomainID, "admin").Return([]executables.CloudStackAffinityGroup{
+		ownedAffinityGroup("wn-id", "eksa-test-worker-md-0"),
+		ownedAffinityGroup("cp-id", "eksa-test-control-plane", "vm-1"),
+		{Id: "user-id", Name: "user-group"},
+	}, nil)
+
+	d := NewCleanupDiscoverer(cmk, clusterSpec)
+	g.Expect(d.Name()).To(Equal("cloudstack"))
+	resources, err := d.Discover(ctx, "test")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resources).To(Equal([]cleanup.Resource{
+		{Kind: "VirtualMachine", Name: "test-x7k2p", ID: "vm-1", Scope: "global"},
+		{Kind: "VirtualMachine", Name: "test-etcd-q9w4z", ID: "vm-2", Scope: "global"},
+		{Kind: "VirtualMachine", Name: "test-md-0-5d8fc-abcde", ID: "vm-3", Scope: "global"},
+		{Kind: "AffinityGroup", Name: "eksa-test-control-plane", ID: "cp-id", Scope: "global"},
+		{Kind: "AffinityGroup", Name: "eksa-test-worker-md-0", ID: "wn-id", Scope: "global"},
+	}))
+}
+
+func TestCleanupDiscovererDiscoverError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
+	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+
+	cmk.EXPECT().ListVirtualMachines(ctx, "global", "test").Return(nil, errors.New("invalid api key"))
+
+	_, err := NewCleanupDiscoverer(cmk, clusterSpec).Discover(ctx, "test")
+	g.Expect(err).To(MatchError("invalid api key"))
+}
+
+func TestCleanupDiscovererDelete(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
+	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+	d := NewCleanupDiscoverer(cmk, clusterSpec)
+
+	cmk.EXPECT().DestroyVirtualMachine(ctx, "global", "vm-1")
+	cmk.EXPECT().DeleteAffinityGroup(ctx, "global", "cp-id")
+
+	g.Expect(d.Delete(ctx, cleanup.Resource{Kind: "VirtualMachine", Name: "test-x7k2p", ID: "vm-1", Scope: "global"})).To(Succeed())
+	g.Expect(d.Delete(ctx, cleanup.Resource{Kind: "AffinityGroup", Name: "eksa-test-control-plane", ID: "cp-id", Scope: "global"})).To(Succeed())
+	g.Expect(d.Delete(ctx, cleanup.Resource{Kind: "Network", Name: "test"})).To(MatchError("unsupported cloudstack resource kind Network"))
+}
@@ -363,6 +363,10 @@ func (p *cloudstackProvider) SetupAndValidateCreateCluster(ctx context.Context,
 		return fmt.Errorf("validating control plane endpoint uniqueness: %v", err)
 	}
 
+	if err := p.validator.ValidateResourceQuota(ctx, clusterSpec, nil); err != nil {
+		return fmt.Errorf("validating resource limits: %v", err)
+	}
+
 	if err := p.generateSSHKeysIfNotSet(clusterSpec.CloudStackMachineConfigs); err != nil {
 		return fmt.Errorf("setting up SSH keys: %v", err)
 	}
@@ -408,6 +412,10 @@ func (p *cloudstackProvider) SetupAndValidateUpgradeCluster(ctx context.Context,
 		return fmt.Errorf("validating secrets unchanged: %v", err)
 	}
 
+	if err := p.validator.ValidateResourceQuota(ctx, clusterSpec, currentSpec); err != nil {
+		return fmt.Errorf("validating resource limits: %v", err)
+	}
+
 	return nil
 }
 
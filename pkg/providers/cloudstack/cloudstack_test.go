@@ -73,6 +73,7 @@ func givenWildcardValidator(mockCtrl *gomock.Controller, clusterSpec *cluster.Sp
 	validator.EXPECT().ValidateClusterMachineConfigs(gomock.Any(), gomock.Any()).SetArg(1, *clusterSpec).AnyTimes()
 	validator.EXPECT().ValidateCloudStackDatacenterConfig(gomock.Any(), clusterSpec.CloudStackDatacenter).AnyTimes()
 	validator.EXPECT().ValidateControlPlaneEndpointUniqueness(gomock.Any()).AnyTimes()
+	validator.EXPECT().ValidateResourceQuota(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 	return validator
 }
 
@@ -354,6 +355,46 @@ func TestProviderSetupAndValidateUpgradeClusterFailureOnSecretChanged(t *testing
 	tt.Expect(err).NotTo(BeNil())
 }
 
+func TestSetupAndValidateCreateClusterResourceQuotaExceeded(t *testing.T) {
+	tt := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	ctx := context.Background()
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	validator := NewMockProviderValidator(mockCtrl)
+	validator.EXPECT().ValidateCloudStackDatacenterConfig(ctx, clusterSpec.CloudStackDatacenter)
+	validator.EXPECT().ValidateClusterMachineConfigs(ctx, clusterSpec)
+	validator.EXPECT().ValidateControlPlaneEndpointUniqueness(gomock.Any())
+	validator.EXPECT().ValidateResourceQuota(ctx, clusterSpec, nil).Return(errors.New("insufficient CloudStack resource limits"))
+	provider := newProviderWithKubectl(t, datacenterConfig, clusterSpec.Cluster, nil, validator)
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	tt.Expect(err).To(MatchError("validating resource limits: insufficient CloudStack resource limits"))
+}
+
+func TestProviderSetupAndValidateUpgradeClusterResourceQuotaExceeded(t *testing.T) {
+	tt := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	currentSpec := clusterSpec.DeepCopy()
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	ctx := context.Background()
+	validator := NewMockProviderValidator(mockCtrl)
+	validator.EXPECT().ValidateCloudStackDatacenterConfig(ctx, clusterSpec.CloudStackDatacenter)
+	validator.EXPECT().ValidateClusterMachineConfigs(ctx, clusterSpec)
+	validator.EXPECT().ValidateSecretsUnchanged(ctx, cluster, gomock.Any(), kubectl)
+	validator.EXPECT().ValidateResourceQuota(ctx, clusterSpec, currentSpec).Return(errors.New("insufficient CloudStack resource limits"))
+	provider := newProviderWithKubectl(t, datacenterConfig, clusterSpec.Cluster, kubectl, validator)
+	kubectl.EXPECT().GetEksaCluster(ctx, cluster, clusterSpec.Cluster.Name).Return(clusterSpec.Cluster, nil)
+
+	err := provider.SetupAndValidateUpgradeCluster(ctx, cluster, clusterSpec, currentSpec)
+	tt.Expect(err).To(MatchError("validating resource limits: insufficient CloudStack resource limits"))
+}
+
 func TestUpdateKubeConfig(t *testing.T) {
 	provider := givenProvider(t)
 	content := []byte{}
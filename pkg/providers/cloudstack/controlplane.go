@@ -31,8 +31,10 @@ func (p ControlPlane) Objects() []kubernetes.Object {
 }
 
 // ControlPlaneSpec builds a CloudStack ControlPlane definition based on an eks-a cluster spec.
-func ControlPlaneSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, clusterSpec *cluster.Spec) (*ControlPlane, error) {
+// Machine templates of node groups with managed affinity groups use the ids in affinityGroups.
+func ControlPlaneSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, clusterSpec *cluster.Spec, affinityGroups AffinityGroups) (*ControlPlane, error) {
 	templateBuilder := NewTemplateBuilder(time.Now)
+	templateBuilder.affinityGroups = affinityGroups
 	controlPlaneYaml, err := templateBuilder.GenerateCAPISpecControlPlane(
 		clusterSpec,
 		func(values map[string]interface{}) {
@@ -65,7 +65,7 @@ func TestControlPlaneSpecNewCluster(t *testing.T) {
 	client := test.NewFakeKubeClient()
 	spec := test.NewFullClusterSpec(t, testClusterConfigFilename)
 
-	cp, err := ControlPlaneSpec(ctx, logger, client, spec)
+	cp, err := ControlPlaneSpec(ctx, logger, client, spec, nil)
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(cp.Cluster).To(Equal(capiCluster()))
 	g.Expect(cp.KubeadmControlPlane).To(Equal(kubeadmControlPlane()))
@@ -89,7 +89,7 @@ func TestControlPlaneSpecNoChangesMachineTemplates(t *testing.T) {
 		originalCPMachineTemplate,
 	)
 
-	cp, err := ControlPlaneSpec(ctx, logger, client, spec)
+	cp, err := ControlPlaneSpec(ctx, logger, client, spec, nil)
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(cp).NotTo(BeNil())
 	g.Expect(cp.Cluster).To(Equal(capiCluster()))
@@ -126,7 +126,7 @@ func TestControlPlaneSpecUpdateMachineTemplates(t *testing.T) {
 
 	expectedCPTemplate.Name = "test-control-plane-1"
 
-	cp, err := ControlPlaneSpec(ctx, logger, client, spec)
+	cp, err := ControlPlaneSpec(ctx, logger, client, spec, nil)
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(cp).NotTo(BeNil())
 	g.Expect(cp.Cluster).To(Equal(capiCluster()))
@@ -161,7 +161,7 @@ func TestControlPlaneSpecRegistryMirrorConfiguration(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			spec.Cluster.Spec.RegistryMirrorConfiguration = tt.mirrorConfig
-			cp, err := ControlPlaneSpec(ctx, logger, client, spec)
+			cp, err := ControlPlaneSpec(ctx, logger, client, spec, nil)
 			g.Expect(err).NotTo(HaveOccurred())
 			g.Expect(cp).NotTo(BeNil())
 			g.Expect(cp.Cluster).To(Equal(capiCluster()))
@@ -197,7 +197,7 @@ func TestControlPlaneSpecWithUpgradeRolloutStrategy(t *testing.T) {
 		},
 	}
 
-	cp, err := ControlPlaneSpec(ctx, logger, client, spec)
+	cp, err := ControlPlaneSpec(ctx, logger, client, spec, nil)
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(cp).NotTo(BeNil())
 	g.Expect(cp.KubeadmControlPlane).To(Equal(kubeadmControlPlane(func(k *controlplanev1beta2.KubeadmControlPlane) {
@@ -2,13 +2,17 @@ package cloudstack
 
 import (
 	"context"
+	"fmt"
 
 	apiv1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/collection"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/credentials"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 )
 
@@ -41,3 +45,118 @@ func GetCloudstackExecConfig(ctx context.Context, cli client.Client, datacenterC
 		Profiles: profiles,
 	}, nil
 }
+
+// CredentialsRotator rotates the CloudStack API keys of a management cluster and its workload clusters.
+// The keys are stored in one secret per profile, shared by all the clusters with availability zones
+// using that profile and referenced directly by the CAPC failure domains.
+type CredentialsRotator struct {
+	validatorRegistry ValidatorRegistry
+}
+
+// NewCredentialsRotator returns a new CredentialsRotator.
+func NewCredentialsRotator(validatorRegistry ValidatorRegistry) *CredentialsRotator {
+	return &CredentialsRotator{
+		validatorRegistry: validatorRegistry,
+	}
+}
+
+// ValidateCredentials validates the availability zones of the management cluster with the new API keys.
+func (r *CredentialsRotator) ValidateCredentials(ctx context.Context, spec *cluster.Spec) error {
+	execConfig, err := decoder.ParseCloudStackCredsFromEnv()
+	if err != nil {
+		return err
+	}
+
+	validator, err := r.validatorRegistry.Get(execConfig)
+	if err != nil {
+		return err
+	}
+
+	return validator.ValidateCloudStackDatacenterConfig(ctx, spec.CloudStackDatacenter)
+}
+
+// UpdateSecrets updates the secrets of the profiles used by the availability zones of the management cluster.
+func (r *CredentialsRotator) UpdateSecrets(ctx context.Context, client kubernetes.Client, spec *cluster.Spec) error {
+	execConfig, err := decoder.ParseCloudStackCredsFromEnv()
+	if err != nil {
+		return err
+	}
+
+	profiles := credentialsRefs(spec.CloudStackDatacenter)
+	for _, profile := range execConfig.Profiles {
+		if !profiles.Contains(profile.Name) {
+			continue
+		}
+		secret := generateSecret(profile)
+		data := make(map[string][]byte, len(secret.StringData))
+		for k, v := range secret.StringData {
+			data[k] = []byte(v)
+		}
+		if err := credentials.UpdateSecretData(ctx, client, profile.Name, constants.EksaSystemNamespace, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UsesCredentials returns true if the cluster has availability zones using one of the profiles of the management cluster.
+func (r *CredentialsRotator) UsesCredentials(ctx context.Context, client kubernetes.Client, spec *cluster.Spec, c *v1alpha1.Cluster) (bool, error) {
+	datacenter := &v1alpha1.CloudStackDatacenterConfig{}
+	if err := client.Get(ctx, c.Spec.DatacenterRef.Name, c.Namespace, datacenter); err != nil {
+		return false, fmt.Errorf("getting CloudStackDatacenterConfig %s: %v", c.Spec.DatacenterRef.Name, err)
+	}
+
+	profiles := credentialsRefs(spec.CloudStackDatacenter)
+	for _, az := range datacenter.Spec.AvailabilityZones {
+		if profiles.Contains(az.CredentialsRef) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// UpdateClusterSecrets is a no-op: the clusters read the API keys from the profile secrets updated by UpdateSecrets.
+func (r *CredentialsRotator) UpdateClusterSecrets(_ context.Context, _ kubernetes.Client, _ *cluster.Spec, _ *v1alpha1.Cluster) error {
+	return nil
+}
+
+// VerifyClusterCredentials validates the availability zones of the cluster with the API keys stored in the secrets
+// of its profiles.
+func (r *CredentialsRotator) VerifyClusterCredentials(ctx context.Context, client kubernetes.Client, _ *cluster.Spec, c *v1alpha1.Cluster) error {
+	datacenter := &v1alpha1.CloudStackDatacenterConfig{}
+	if err := client.Get(ctx, c.Spec.DatacenterRef.Name, c.Namespace, datacenter); err != nil {
+		return fmt.Errorf("getting CloudStackDatacenterConfig %s: %v", c.Spec.DatacenterRef.Name, err)
+	}
+
+	execConfig := &decoder.CloudStackExecConfig{}
+	for _, profileName := range credentialsRefs(datacenter).ToSlice() {
+		secret := &apiv1.Secret{}
+		if err := client.Get(ctx, profileName, constants.EksaSystemNamespace, secret); err != nil {
+			return fmt.Errorf("getting secret %s: %v", profileName, err)
+		}
+		execConfig.Profiles = append(execConfig.Profiles, decoder.CloudStackProfileConfig{
+			Name:          profileName,
+			ApiKey:        string(secret.Data[decoder.APIKeyKey]),
+			SecretKey:     string(secret.Data[decoder.SecretKeyKey]),
+			ManagementUrl: string(secret.Data[decoder.APIUrlKey]),
+			VerifySsl:     string(secret.Data[decoder.VerifySslKey]),
+		})
+	}
+
+	validator, err := r.validatorRegistry.Get(execConfig)
+	if err != nil {
+		return err
+	}
+
+	return validator.ValidateCloudStackDatacenterConfig(ctx, datacenter)
+}
+
+func credentialsRefs(datacenter *v1alpha1.CloudStackDatacenterConfig) collection.Set[string] {
+	refs := collection.NewSet[string]()
+	for _, az := range datacenter.Spec.AvailabilityZones {
+		refs.Add(az.CredentialsRef)
+	}
+	return refs
+}
@@ -2,15 +2,20 @@ package cloudstack
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/aws/eks-anywhere/internal/test"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 )
@@ -128,3 +133,127 @@ func createCloudstackDatacenterConfig() *anywherev1.CloudStackDatacenterConfig {
 		},
 	}
 }
+
+func setRotatedCloudStackCredentials(t *testing.T) {
+	config := `[global]
+api-key = new-key
+secret-key = new-secret
+api-url = http://1.1.1.1:8080/client/api
+
+[other]
+api-key = other-key
+secret-key = other-secret
+api-url = http://1.1.1.2:8080/client/api
+`
+	t.Setenv(decoder.EksacloudStackCloudConfigB64SecretKey, base64.StdEncoding.EncodeToString([]byte(config)))
+}
+
+func credentialsRotationSpec() *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.CloudStackDatacenter = createCloudstackDatacenterConfig()
+		s.CloudStackDatacenter.Spec.AvailabilityZones[0].CredentialsRef = "global"
+	})
+}
+
+func TestCredentialsRotatorValidateCredentials(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	setRotatedCloudStackCredentials(t)
+	spec := credentialsRotationSpec()
+	registry := NewMockValidatorRegistry(ctrl)
+	validator := NewMockProviderValidator(ctrl)
+	registry.EXPECT().Get(gomock.Any()).Return(validator, nil)
+	validator.EXPECT().ValidateCloudStackDatacenterConfig(ctx, spec.CloudStackDatacenter).Return(errors.New("invalid api key"))
+
+	r := NewCredentialsRotator(registry)
+	g.Expect(r.ValidateCredentials(ctx, spec)).To(MatchError("invalid api key"))
+}
+
+func TestCredentialsRotatorUpdateSecrets(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	setRotatedCloudStackCredentials(t)
+	global := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "global", Namespace: constants.EksaSystemNamespace},
+		Data:       map[string][]byte{decoder.APIKeyKey: []byte("old-key")},
+	}
+	client := test.NewFakeKubeClient(global)
+
+	r := NewCredentialsRotator(nil)
+	g.Expect(r.UpdateSecrets(ctx, client, credentialsRotationSpec())).To(Succeed())
+
+	got := &apiv1.Secret{}
+	g.Expect(client.Get(ctx, "global", constants.EksaSystemNamespace, got)).To(Succeed())
+	g.Expect(got.Data).To(Equal(map[string][]byte{
+		decoder.APIKeyKey:    []byte("new-key"),
+		decoder.SecretKeyKey: []byte("new-secret"),
+		decoder.APIUrlKey:    []byte("http://1.1.1.1:8080/client/api"),
+		decoder.VerifySslKey: []byte("true"),
+	}))
+}
+
+func TestCredentialsRotatorUsesCredentials(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	datacenter := createCloudstackDatacenterConfig()
+	datacenter.Spec.AvailabilityZones[0].CredentialsRef = "global"
+	workload := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: namespace},
+		Spec: anywherev1.ClusterSpec{
+			DatacenterRef: anywherev1.Ref{Kind: anywherev1.CloudStackDatacenterKind, Name: name},
+		},
+	}
+	client := test.NewFakeKubeClient(datacenter)
+	r := NewCredentialsRotator(nil)
+
+	uses, err := r.UsesCredentials(ctx, client, credentialsRotationSpec(), workload)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(uses).To(BeTrue())
+
+	spec := credentialsRotationSpec()
+	spec.CloudStackDatacenter.Spec.AvailabilityZones[0].CredentialsRef = "other"
+	uses, err = r.UsesCredentials(ctx, client, spec, workload)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(uses).To(BeFalse())
+}
+
+func TestCredentialsRotatorVerifyClusterCredentials(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	datacenter := createCloudstackDatacenterConfig()
+	datacenter.Spec.AvailabilityZones = datacenter.Spec.AvailabilityZones[:1]
+	datacenter.Spec.AvailabilityZones[0].CredentialsRef = "global"
+	global := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "global", Namespace: constants.EksaSystemNamespace},
+		Data: map[string][]byte{
+			decoder.APIKeyKey:    []byte("new-key"),
+			decoder.SecretKeyKey: []byte("new-secret"),
+			decoder.APIUrlKey:    []byte("http://1.1.1.1:8080/client/api"),
+			decoder.VerifySslKey: []byte("true"),
+		},
+	}
+	workload := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: namespace},
+		Spec: anywherev1.ClusterSpec{
+			DatacenterRef: anywherev1.Ref{Kind: anywherev1.CloudStackDatacenterKind, Name: name},
+		},
+	}
+	registry := NewMockValidatorRegistry(ctrl)
+	validator := NewMockProviderValidator(ctrl)
+	registry.EXPECT().Get(&decoder.CloudStackExecConfig{
+		Profiles: []decoder.CloudStackProfileConfig{{
+			Name:          "global",
+			ApiKey:        "new-key",
+			SecretKey:     "new-secret",
+			ManagementUrl: "http://1.1.1.1:8080/client/api",
+			VerifySsl:     "true",
+		}},
+	}).Return(validator, nil)
+	validator.EXPECT().ValidateCloudStackDatacenterConfig(ctx, gomock.Any()).Return(errors.New("invalid api key"))
+
+	r := NewCredentialsRotator(registry)
+	err := r.VerifyClusterCredentials(ctx, test.NewFakeKubeClient(datacenter, global), credentialsRotationSpec(), workload)
+	g.Expect(err).To(MatchError("invalid api key"))
+}
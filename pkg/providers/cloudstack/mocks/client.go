@@ -41,6 +41,94 @@ func (m *MockProviderCmkClient) EXPECT() *MockProviderCmkClientMockRecorder {
 	return m.recorder
 }
 
+// CreateAffinityGroup mocks base method.
+func (m *MockProviderCmkClient) CreateAffinityGroup(arg0 context.Context, arg1, arg2, arg3 string, arg4 executables.CloudStackAffinityGroup) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAffinityGroup", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAffinityGroup indicates an expected call of CreateAffinityGroup.
+func (mr *MockProviderCmkClientMockRecorder) CreateAffinityGroup(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAffinityGroup", reflect.TypeOf((*MockProviderCmkClient)(nil).CreateAffinityGroup), arg0, arg1, arg2, arg3, arg4)
+}
+
+// DeleteAffinityGroup mocks base method.
+func (m *MockProviderCmkClient) DeleteAffinityGroup(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAffinityGroup", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAffinityGroup indicates an expected call of DeleteAffinityGroup.
+func (mr *MockProviderCmkClientMockRecorder) DeleteAffinityGroup(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAffinityGroup", reflect.TypeOf((*MockProviderCmkClient)(nil).DeleteAffinityGroup), arg0, arg1, arg2)
+}
+
+// DestroyVirtualMachine mocks base method.
+func (m *MockProviderCmkClient) DestroyVirtualMachine(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyVirtualMachine", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyVirtualMachine indicates an expected call of DestroyVirtualMachine.
+func (mr *MockProviderCmkClientMockRecorder) DestroyVirtualMachine(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyVirtualMachine", reflect.TypeOf((*MockProviderCmkClient)(nil).DestroyVirtualMachine), arg0, arg1, arg2)
+}
+
+// GetAccountResourceLimits mocks base method.
+func (m *MockProviderCmkClient) GetAccountResourceLimits(arg0 context.Context, arg1, arg2, arg3 string) (*executables.CloudStackResourceLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountResourceLimits", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*executables.CloudStackResourceLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountResourceLimits indicates an expected call of GetAccountResourceLimits.
+func (mr *MockProviderCmkClientMockRecorder) GetAccountResourceLimits(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountResourceLimits", reflect.TypeOf((*MockProviderCmkClient)(nil).GetAccountResourceLimits), arg0, arg1, arg2, arg3)
+}
+
+// GetDomainResourceLimits mocks base method.
+func (m *MockProviderCmkClient) GetDomainResourceLimits(arg0 context.Context, arg1, arg2 string) (*executables.CloudStackResourceLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDomainResourceLimits", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*executables.CloudStackResourceLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDomainResourceLimits indicates an expected call of GetDomainResourceLimits.
+func (mr *MockProviderCmkClientMockRecorder) GetDomainResourceLimits(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDomainResourceLimits", reflect.TypeOf((*MockProviderCmkClient)(nil).GetDomainResourceLimits), arg0, arg1, arg2)
+}
+
+// GetMachineResources mocks base method.
+func (m *MockProviderCmkClient) GetMachineResources(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 v1alpha1.CloudStackMachineConfigSpec) (*executables.CloudStackMachineResources, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMachineResources", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*executables.CloudStackMachineResources)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMachineResources indicates an expected call of GetMachineResources.
+func (mr *MockProviderCmkClientMockRecorder) GetMachineResources(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachineResources", reflect.TypeOf((*MockProviderCmkClient)(nil).GetMachineResources), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // GetManagementApiEndpoint mocks base method.
 func (m *MockProviderCmkClient) GetManagementApiEndpoint(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +144,51 @@ func (mr *MockProviderCmkClientMockRecorder) GetManagementApiEndpoint(arg0 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagementApiEndpoint", reflect.TypeOf((*MockProviderCmkClient)(nil).GetManagementApiEndpoint), arg0)
 }
 
+// GetNetworkType mocks base method.
+func (m *MockProviderCmkClient) GetNetworkType(arg0 context.Context, arg1, arg2 string, arg3 v1alpha1.CloudStackResourceIdentifier, arg4, arg5 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkType", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkType indicates an expected call of GetNetworkType.
+func (mr *MockProviderCmkClientMockRecorder) GetNetworkType(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkType", reflect.TypeOf((*MockProviderCmkClient)(nil).GetNetworkType), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// ListAffinityGroups mocks base method.
+func (m *MockProviderCmkClient) ListAffinityGroups(arg0 context.Context, arg1, arg2, arg3 string) ([]executables.CloudStackAffinityGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAffinityGroups", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]executables.CloudStackAffinityGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAffinityGroups indicates an expected call of ListAffinityGroups.
+func (mr *MockProviderCmkClientMockRecorder) ListAffinityGroups(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAffinityGroups", reflect.TypeOf((*MockProviderCmkClient)(nil).ListAffinityGroups), arg0, arg1, arg2, arg3)
+}
+
+// ListVirtualMachines mocks base method.
+func (m *MockProviderCmkClient) ListVirtualMachines(arg0 context.Context, arg1, arg2 string) ([]executables.CloudStackVirtualMachine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVirtualMachines", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]executables.CloudStackVirtualMachine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVirtualMachines indicates an expected call of ListVirtualMachines.
+func (mr *MockProviderCmkClientMockRecorder) ListVirtualMachines(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVirtualMachines", reflect.TypeOf((*MockProviderCmkClient)(nil).ListVirtualMachines), arg0, arg1, arg2)
+}
+
 // ValidateAccountPresent mocks base method.
 func (m *MockProviderCmkClient) ValidateAccountPresent(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,278 @@
+package cloudstack
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const isolatedNetworkType = "Isolated"
+
+// machineGroup is a set of machines sharing a machine config.
+type machineGroup struct {
+	machineConfig *anywherev1.CloudStackMachineConfig
+	count         int
+	surge         int
+}
+
+// machineGroups returns the machine groups of a cluster spec with the maximum number of machines
+// each group can scale to and the number of extra machines created during a rolling update.
+func machineGroups(spec *cluster.Spec) []machineGroup {
+	groups := []machineGroup{}
+	cp := spec.Cluster.Spec.ControlPlaneConfiguration
+	cpSurge := 1
+	if cp.UpgradeRolloutStrategy != nil && cp.UpgradeRolloutStrategy.RollingUpdate != nil {
+		cpSurge = cp.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+	}
+	groups = append(groups, machineGroup{machineConfig: controlPlaneMachineConfig(spec), count: cp.Count, surge: cpSurge})
+
+	if etcd := spec.Cluster.Spec.ExternalEtcdConfiguration; etcd != nil {
+		groups = append(groups, machineGroup{machineConfig: etcdMachineConfig(spec), count: etcd.Count, surge: 1})
+	}
+
+	for _, workers := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		count := 0
+		if workers.Count != nil {
+			count = *workers.Count
+		}
+		if workers.AutoScalingConfiguration != nil && workers.AutoScalingConfiguration.MaxCount > count {
+			count = workers.AutoScalingConfiguration.MaxCount
+		}
+		surge := 1
+		if workers.UpgradeRolloutStrategy != nil && workers.UpgradeRolloutStrategy.RollingUpdate != nil {
+			surge = workers.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+		}
+		groups = append(groups, machineGroup{machineConfig: workerMachineConfig(spec, workers), count: count, surge: surge})
+	}
+
+	return groups
+}
+
+// resourceDemand is the amount of each limited resource a cluster operation needs.
+type resourceDemand struct {
+	vms        int64
+	cpus       int64
+	memoryMiB  int64
+	storageGiB int64
+	publicIPs  int64
+}
+
+func (d *resourceDemand) add(machine *executables.CloudStackMachineResources, count int) {
+	n := int64(count)
+	d.vms += n
+	d.cpus += n * machine.CPUs
+	d.memoryMiB += n * machine.MemoryMiB
+	d.storageGiB += n * machine.StorageGiB
+}
+
+func (d *resourceDemand) sub(other resourceDemand) {
+	d.vms = nonNegative(d.vms - other.vms)
+	d.cpus = nonNegative(d.cpus - other.cpus)
+	d.memoryMiB = nonNegative(d.memoryMiB - other.memoryMiB)
+	d.storageGiB = nonNegative(d.storageGiB - other.storageGiB)
+	d.publicIPs = nonNegative(d.publicIPs - other.publicIPs)
+}
+
+// merge adds the demand of another availability zone sharing the same limits. The machines are spread
+// across the availability zones, so they count once, with the largest resources they have in any of the
+// zones. The public IPs are acquired in each zone, so they add up.
+func (d *resourceDemand) merge(other resourceDemand) {
+	d.vms = max(d.vms, other.vms)
+	d.cpus = max(d.cpus, other.cpus)
+	d.memoryMiB = max(d.memoryMiB, other.memoryMiB)
+	d.storageGiB = max(d.storageGiB, other.storageGiB)
+	d.publicIPs += other.publicIPs
+}
+
+func nonNegative(v int64) int64 {
+	if v < 0 {
+		return 0
+	}
+	return v
+}
+
+type quotaShortfall struct {
+	availabilityZone string
+	scope            string
+	resource         string
+	requested        int64
+	available        int64
+}
+
+// quotaScope is an account or domain whose resource limits are shared by one or more availability zones.
+type quotaScope struct {
+	name              string
+	profile           string
+	domainId          string
+	account           string
+	availabilityZones []string
+	demand            resourceDemand
+}
+
+// ValidateResourceQuota checks that the accounts and domains of the availability zones have enough
+// resource limits left for the cluster machines plus the machines created during a rolling update.
+// When currentSpec is not nil, only the machines added on top of the current cluster are counted.
+// Machines can be placed in any availability zone, so the whole machine demand is checked against the
+// limits of each account and domain, while the public IPs of the zones sharing limits are summed.
+func (v *Validator) ValidateResourceQuota(ctx context.Context, clusterSpec *cluster.Spec, currentSpec *cluster.Spec) error {
+	currentZones := map[string]bool{}
+	if currentSpec != nil {
+		for _, az := range currentSpec.CloudStackDatacenter.Spec.AvailabilityZones {
+			currentZones[az.Name] = true
+		}
+	}
+
+	scopes := []*quotaScope{}
+	scopesByKey := map[string]*quotaScope{}
+	addDemand := func(key string, scope quotaScope) {
+		if existing, ok := scopesByKey[key]; ok {
+			existing.availabilityZones = append(existing.availabilityZones, scope.availabilityZones...)
+			existing.demand.merge(scope.demand)
+			return
+		}
+		scopesByKey[key] = &scope
+		scopes = append(scopes, &scope)
+	}
+
+	for _, az := range clusterSpec.CloudStackDatacenter.Spec.AvailabilityZones {
+		domainId, err := v.cmk.ValidateDomainAndGetId(ctx, az.CredentialsRef, az.Domain)
+		if err != nil {
+			return err
+		}
+		zoneId, err := v.cmk.ValidateZoneAndGetId(ctx, az.CredentialsRef, az.Zone)
+		if err != nil {
+			return err
+		}
+
+		demand, err := v.resourceDemand(ctx, az, domainId, zoneId, clusterSpec, true)
+		if err != nil {
+			return err
+		}
+		if currentSpec != nil {
+			current, err := v.resourceDemand(ctx, az, domainId, zoneId, currentSpec, false)
+			if err != nil {
+				return err
+			}
+			demand.sub(current)
+		}
+
+		// CAPC acquires a public IP for the control plane endpoint of each isolated network. The IPs of the
+		// availability zones of the current cluster have already been acquired.
+		if !currentZones[az.Name] {
+			networkType, err := v.cmk.GetNetworkType(ctx, az.CredentialsRef, domainId, az.Zone.Network, zoneId, az.Account)
+			if err != nil {
+				return err
+			}
+			if networkType == isolatedNetworkType {
+				demand.publicIPs++
+			}
+		}
+
+		if len(az.Account) > 0 {
+			addDemand(fmt.Sprintf("%s/%s/account/%s", az.CredentialsRef, domainId, az.Account), quotaScope{
+				name:              "account " + az.Account,
+				profile:           az.CredentialsRef,
+				domainId:          domainId,
+				account:           az.Account,
+				availabilityZones: []string{az.Name},
+				demand:            demand,
+			})
+		}
+
+		addDemand(fmt.Sprintf("%s/%s/domain", az.CredentialsRef, domainId), quotaScope{
+			name:              "domain " + az.Domain,
+			profile:           az.CredentialsRef,
+			domainId:          domainId,
+			availabilityZones: []string{az.Name},
+			demand:            demand,
+		})
+	}
+
+	shortfalls := []quotaShortfall{}
+	for _, scope := range scopes {
+		var limits *executables.CloudStackResourceLimits
+		var err error
+		if len(scope.account) > 0 {
+			limits, err = v.cmk.GetAccountResourceLimits(ctx, scope.profile, scope.domainId, scope.account)
+		} else {
+			limits, err = v.cmk.GetDomainResourceLimits(ctx, scope.profile, scope.domainId)
+		}
+		if err != nil {
+			return err
+		}
+		shortfalls = append(shortfalls, quotaShortfalls(strings.Join(scope.availabilityZones, ","), scope.name, scope.demand, limits)...)
+	}
+
+	if len(shortfalls) > 0 {
+		return fmt.Errorf("insufficient CloudStack resource limits for cluster %s:\n%s", clusterSpec.Cluster.Name, shortfallTable(shortfalls))
+	}
+
+	logger.MarkPass("Validated CloudStack resource limits")
+	return nil
+}
+
+func (v *Validator) resourceDemand(ctx context.Context, az anywherev1.CloudStackAvailabilityZone, domainId, zoneId string, spec *cluster.Spec, withSurge bool) (resourceDemand, error) {
+	demand := resourceDemand{}
+	for _, group := range machineGroups(spec) {
+		if group.machineConfig == nil {
+			continue
+		}
+		machine, err := v.cmk.GetMachineResources(ctx, az.CredentialsRef, domainId, zoneId, az.Account, group.machineConfig.Spec)
+		if err != nil {
+			return demand, fmt.Errorf("getting resources for machine config %s: %v", group.machineConfig.Name, err)
+		}
+		count := group.count
+		if withSurge {
+			count += group.surge
+		}
+		demand.add(machine, count)
+	}
+	return demand, nil
+}
+
+func quotaShortfalls(availabilityZone, scope string, demand resourceDemand, limits *executables.CloudStackResourceLimits) []quotaShortfall {
+	resources := []struct {
+		name      string
+		requested int64
+		limit     executables.CloudStackResourceLimit
+	}{
+		{"VMs", demand.vms, limits.VMs},
+		{"CPUs", demand.cpus, limits.CPUs},
+		{"Memory (MiB)", demand.memoryMiB, limits.MemoryMiB},
+		{"Primary storage (GiB)", demand.storageGiB, limits.PrimaryStorageGiB},
+		{"Public IPs", demand.publicIPs, limits.PublicIPs},
+	}
+
+	shortfalls := []quotaShortfall{}
+	for _, r := range resources {
+		if r.limit.Unlimited() || r.requested <= r.limit.Available() {
+			continue
+		}
+		shortfalls = append(shortfalls, quotaShortfall{
+			availabilityZone: availabilityZone,
+			scope:            scope,
+			resource:         r.name,
+			requested:        r.requested,
+			available:        r.limit.Available(),
+		})
+	}
+	return shortfalls
+}
+
+func shortfallTable(shortfalls []quotaShortfall) string {
+	var b bytes.Buffer
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "AVAILABILITY ZONE\tSCOPE\tRESOURCE\tREQUESTED\tAVAILABLE\tSHORTFALL")
+	for _, s := range shortfalls {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\n", s.availabilityZone, s.scope, s.resource, s.requested, s.available, s.requested-s.available)
+	}
+	w.Flush()
+	return b.String()
+}
@@ -0,0 +1,163 @@
+package cloudstack
+
+import (
+	"context"
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/mocks"
+)
+
+const (
+	quotaTestDomainID = "5300cdac-74d5-11ec-8696-c81f66d3e962"
+	quotaTestZoneID   = "4e3b338d-87a6-4189-b931-a1747edeea82"
+)
+
+var unlimited = executables.CloudStackResourceLimit{Limit: -1}
+
+func unlimitedResourceLimits() *executables.CloudStackResourceLimits {
+	return &executables.CloudStackResourceLimits{
+		VMs:               unlimited,
+		CPUs:              unlimited,
+		MemoryMiB:         unlimited,
+		PrimaryStorageGiB: unlimited,
+		PublicIPs:         unlimited,
+	}
+}
+
+func givenQuotaCmk(t *testing.T, clusterSpec *cluster.Spec) *mocks.MockProviderCmkClient {
+	return givenQuotaCmkWithNetworkType(t, clusterSpec, "Shared")
+}
+
+func givenQuotaCmkWithNetworkType(t *testing.T, clusterSpec *cluster.Spec, networkType string) *mocks.MockProviderCmkClient {
+	ctx := context.Background()
+	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+	for _, az := range clusterSpec.CloudStackDatacenter.Spec.AvailabilityZones {
+		cmk.EXPECT().ValidateDomainAndGetId(ctx, az.CredentialsRef, az.Domain).Return(quotaTestDomainID, nil)
+		cmk.EXPECT().ValidateZoneAndGetId(ctx, az.CredentialsRef, az.Zone).Return(quotaTestZoneID, nil)
+		cmk.EXPECT().GetMachineResources(ctx, az.CredentialsRef, quotaTestDomainID, quotaTestZoneID, az.Account, gomock.Any()).
+			Return(&executables.CloudStackMachineResources{CPUs: 2, MemoryMiB: 4096, StorageGiB: 30}, nil).AnyTimes()
+		cmk.EXPECT().GetNetworkType(ctx, az.CredentialsRef, quotaTestDomainID, az.Zone.Network, quotaTestZoneID, az.Account).
+			Return(networkType, nil).AnyTimes()
+	}
+	return cmk
+}
+
+func TestValidateResourceQuotaSuccess(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
+	cmk := givenQuotaCmk(t, clusterSpec)
+	cmk.EXPECT().GetAccountResourceLimits(ctx, "global", quotaTestDomainID, "admin").Return(unlimitedResourceLimits(), nil)
+	cmk.EXPECT().GetDomainResourceLimits(ctx, "global", quotaTestDomainID).Return(unlimitedResourceLimits(), nil)
+	validator := NewValidator(cmk, &DummyNetClient{}, true)
+
+	g.Expect(validator.ValidateResourceQuota(ctx, clusterSpec, nil)).To(Succeed())
+}
+
+func TestValidateResourceQuotaShortfall(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
+	cmk := givenQuotaCmk(t, clusterSpec)
+	accountLimits := unlimitedResourceLimits()
+	accountLimits.VMs = executables.CloudStackResourceLimit{Limit: 10, Total: 4}
+	accountLimits.CPUs = executables.CloudStackResourceLimit{Limit: 40, Total: 30}
+	domainLimits := unlimitedResourceLimits()
+	domainLimits.PrimaryStorageGiB = executables.CloudStackResourceLimit{Limit: 500, Total: 600}
+	cmk.EXPECT().GetAccountResourceLimits(ctx, "global", quotaTestDomainID, "admin").Return(accountLimits, nil)
+	cmk.EXPECT().GetDomainResourceLimits(ctx, "global", quotaTestDomainID).Return(domainLimits, nil)
+	validator := NewValidator(cmk, &DummyNetClient{}, true)
+
+	// 3 control plane, 3 etcd and 3 worker machines, plus one surge machine per group.
+	g.Expect(validator.ValidateResourceQuota(ctx, clusterSpec, nil)).To(MatchError(
+		"insufficient CloudStack resource limits for cluster test:\n" +
+			"AVAILABILITY ZONE  SCOPE           RESOURCE               REQUESTED  AVAILABLE  SHORTFALL\n" +
+			"default-az-0       account admin   VMs                    12         6          6\n" +
+			"default-az-0       account admin   CPUs                   24         10         14\n" +
+			"default-az-0       domain domain1  Primary storage (GiB)  360        0          360\n",
+	))
+}
+
+func TestValidateResourceQuotaUpgradeCountsOnlyNewMachines(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	currentSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
+	clusterSpec := currentSpec.DeepCopy()
+	workers := 5
+	clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = &workers
+	cmk := givenQuotaCmk(t, clusterSpec)
+	accountLimits := unlimitedResourceLimits()
+	accountLimits.VMs = executables.CloudStackResourceLimit{Limit: 14, Total: 9}
+	cmk.EXPECT().GetAccountResourceLimits(ctx, "global", quotaTestDomainID, "admin").Return(accountLimits, nil)
+	cmk.EXPECT().GetDomainResourceLimits(ctx, "global", quotaTestDomainID).Return(unlimitedResourceLimits(), nil)
+	validator := NewValidator(cmk, &DummyNetClient{}, true)
+
+	// 2 new workers plus one surge machine for each of the 3 groups.
+	g.Expect(validator.ValidateResourceQuota(ctx, clusterSpec, currentSpec)).To(Succeed())
+
+	accountLimits.VMs.Total = 10
+	cmk.EXPECT().ValidateDomainAndGetId(ctx, gomock.Any(), gomock.Any()).Return(quotaTestDomainID, nil)
+	cmk.EXPECT().ValidateZoneAndGetId(ctx, gomock.Any(), gomock.Any()).Return(quotaTestZoneID, nil)
+	cmk.EXPECT().GetAccountResourceLimits(ctx, "global", quotaTestDomainID, "admin").Return(accountLimits, nil)
+	cmk.EXPECT().GetDomainResourceLimits(ctx, "global", quotaTestDomainID).Return(unlimitedResourceLimits(), nil)
+	g.Expect(validator.ValidateResourceQuota(ctx, clusterSpec, currentSpec)).To(MatchError(ContainSubstring("VMs       5          4          1")))
+}
+
+func TestValidateResourceQuotaPublicIPs(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
+	cmk := givenQuotaCmkWithNetworkType(t, clusterSpec, "Isolated")
+	accountLimits := unlimitedResourceLimits()
+	accountLimits.PublicIPs = executables.CloudStackResourceLimit{Limit: 5, Total: 5}
+	cmk.EXPECT().GetAccountResourceLimits(ctx, "global", quotaTestDomainID, "admin").Return(accountLimits, nil)
+	cmk.EXPECT().GetDomainResourceLimits(ctx, "global", quotaTestDomainID).Return(unlimitedResourceLimits(), nil)
+	validator := NewValidator(cmk, &DummyNetClient{}, true)
+
+	g.Expect(validator.ValidateResourceQuota(ctx, clusterSpec, nil)).To(MatchError(ContainSubstring(
+		"default-az-0       account admin  Public IPs  1          0          1",
+	)))
+}
+
+func TestValidateResourceQuotaSharedAccountAcrossAvailabilityZones(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
+	az := clusterSpec.CloudStackDatacenter.Spec.AvailabilityZones[0]
+	az.Name = "default-az-1"
+	clusterSpec.CloudStackDatacenter.Spec.AvailabilityZones = append(clusterSpec.CloudStackDatacenter.Spec.AvailabilityZones, az)
+	cmk := givenQuotaCmkWithNetworkType(t, clusterSpec, "Isolated")
+	accountLimits := unlimitedResourceLimits()
+	accountLimits.VMs = executables.CloudStackResourceLimit{Limit: 20, Total: 10}
+	accountLimits.PublicIPs = executables.CloudStackResourceLimit{Limit: 5, Total: 4}
+	cmk.EXPECT().GetAccountResourceLimits(ctx, "global", quotaTestDomainID, "admin").Return(accountLimits, nil)
+	cmk.EXPECT().GetDomainResourceLimits(ctx, "global", quotaTestDomainID).Return(unlimitedResourceLimits(), nil)
+	validator := NewValidator(cmk, &DummyNetClient{}, true)
+
+	// The 12 machines are spread across both zones and counted once, each zone needs a public IP.
+	g.Expect(validator.ValidateResourceQuota(ctx, clusterSpec, nil)).To(MatchError(
+		"insufficient CloudStack resource limits for cluster test:\n" +
+			"AVAILABILITY ZONE          SCOPE          RESOURCE    REQUESTED  AVAILABLE  SHORTFALL\n" +
+			"default-az-0,default-az-1  account admin  VMs         12         10         2\n" +
+			"default-az-0,default-az-1  account admin  Public IPs  2          1          1\n",
+	))
+}
+
+func TestValidateResourceQuotaGetLimitsError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
+	cmk := givenQuotaCmk(t, clusterSpec)
+	cmk.EXPECT().GetAccountResourceLimits(ctx, "global", quotaTestDomainID, "admin").Return(nil, errors.New("cmk failed"))
+	validator := NewValidator(cmk, &DummyNetClient{}, true)
+
+	g.Expect(validator.ValidateResourceQuota(ctx, clusterSpec, nil)).To(MatchError("cmk failed"))
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -34,21 +35,36 @@ type RemoteClientRegistry interface {
 
 // Reconciler for CloudStack.
 type Reconciler struct {
-	client               client.Client
-	ipValidator          IPValidator
-	cniReconciler        CNIReconciler
-	remoteClientRegistry RemoteClientRegistry
-	validatorRegistry    cloudstack.ValidatorRegistry
+	client                client.Client
+	ipValidator           IPValidator
+	cniReconciler         CNIReconciler
+	remoteClientRegistry  RemoteClientRegistry
+	validatorRegistry     cloudstack.ValidatorRegistry
+	affinityGroupRegistry cloudstack.AffinityGroupRegistry
+}
+
+// Scope object for CloudStack reconciler.
+type Scope struct {
+	ClusterSpec    *c.Spec
+	AffinityGroups cloudstack.AffinityGroups
+}
+
+// NewScope creates a new CloudStack Reconciler Scope.
+func NewScope(clusterSpec *c.Spec) *Scope {
+	return &Scope{
+		ClusterSpec: clusterSpec,
+	}
 }
 
 // New defines a new CloudStack reconciler.
-func New(client client.Client, ipValidator IPValidator, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, validatorRegistry cloudstack.ValidatorRegistry) *Reconciler {
+func New(client client.Client, ipValidator IPValidator, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, validatorRegistry cloudstack.ValidatorRegistry, affinityGroupRegistry cloudstack.AffinityGroupRegistry) *Reconciler {
 	return &Reconciler{
-		client:               client,
-		ipValidator:          ipValidator,
-		cniReconciler:        cniReconciler,
-		remoteClientRegistry: remoteClientRegistry,
-		validatorRegistry:    validatorRegistry,
+		client:                client,
+		ipValidator:           ipValidator,
+		cniReconciler:         cniReconciler,
+		remoteClientRegistry:  remoteClientRegistry,
+		validatorRegistry:     validatorRegistry,
+		affinityGroupRegistry: affinityGroupRegistry,
 	}
 }
 
@@ -60,20 +76,32 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *an
 		return controller.Result{}, err
 	}
 
-	return controller.NewPhaseRunner[*c.Spec]().Register(
-		r.ipValidator.ValidateControlPlaneIP,
+	return controller.NewPhaseRunner[*Scope]().Register(
+		r.ValidateControlPlaneIP,
 		r.ValidateDatacenterConfig,
 		r.ValidateMachineConfig,
-		clusters.CleanupStatusAfterValidate,
+		r.CleanupStatusAfterValidate,
+		r.ReconcileAffinityGroups,
 		r.ReconcileControlPlane,
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
 		r.ReconcileWorkers,
-	).Run(ctx, log, clusterSpec)
+	).Run(ctx, log, NewScope(clusterSpec))
+}
+
+// ValidateControlPlaneIP passes the cluster spec from cloudstackScope to the IP Validator.
+func (r *Reconciler) ValidateControlPlaneIP(ctx context.Context, log logr.Logger, cloudstackScope *Scope) (controller.Result, error) {
+	return r.ipValidator.ValidateControlPlaneIP(ctx, log, cloudstackScope.ClusterSpec)
+}
+
+// CleanupStatusAfterValidate removes errors from the cluster status with the cloudstackScope.
+func (r *Reconciler) CleanupStatusAfterValidate(ctx context.Context, log logr.Logger, cloudstackScope *Scope) (controller.Result, error) {
+	return clusters.CleanupStatusAfterValidate(ctx, log, cloudstackScope.ClusterSpec)
 }
 
 // ValidateDatacenterConfig updates the cluster status if the CloudStackDatacenter status indicates that the spec is invalid.
-func (r *Reconciler) ValidateDatacenterConfig(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
+func (r *Reconciler) ValidateDatacenterConfig(ctx context.Context, log logr.Logger, cloudstackScope *Scope) (controller.Result, error) {
+	spec := cloudstackScope.ClusterSpec
 	log = log.WithValues("phase", "validateDatacenterConfig")
 	log.Info("Validating datacenter config")
 	dataCenterConfig := spec.CloudStackDatacenter
@@ -93,7 +121,8 @@ func (r *Reconciler) ValidateDatacenterConfig(ctx context.Context, log logr.Logg
 }
 
 // ValidateMachineConfig performs additional, context-aware validations on the machine configs.
-func (r *Reconciler) ValidateMachineConfig(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
+func (r *Reconciler) ValidateMachineConfig(ctx context.Context, log logr.Logger, cloudstackScope *Scope) (controller.Result, error) {
+	spec := cloudstackScope.ClusterSpec
 	log = log.WithValues("phase", "validateMachineConfigs")
 	log.Info("Validating machine config")
 
@@ -116,11 +145,37 @@ func (r *Reconciler) ValidateMachineConfig(ctx context.Context, log logr.Logger,
 	return controller.Result{}, nil
 }
 
+// ReconcileAffinityGroups makes sure the affinity groups of the node groups with managed affinity groups
+// exist and stores their ids in the scope for the control plane and worker phases. Clusters without
+// managed affinity groups don't call the CloudStack API.
+func (r *Reconciler) ReconcileAffinityGroups(ctx context.Context, log logr.Logger, cloudstackScope *Scope) (controller.Result, error) {
+	spec := cloudstackScope.ClusterSpec
+	managed := false
+	for _, machineConfig := range spec.CloudStackMachineConfigs {
+		managed = managed || machineConfig.Spec.ManageAffinityGroups
+	}
+	if !managed {
+		return controller.Result{}, nil
+	}
+
+	log = log.WithValues("phase", "reconcileAffinityGroups")
+	manager, err := r.affinityGroupManager(ctx, spec.CloudStackDatacenter)
+	if err != nil {
+		return controller.Result{}, err
+	}
+	affinityGroups, err := manager.Reconcile(ctx, log, spec)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "reconciling affinity groups")
+	}
+	cloudstackScope.AffinityGroups = affinityGroups
+	return controller.Result{}, nil
+}
+
 // ReconcileControlPlane applies the control plane CAPI objects to the cluster.
-func (r *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
+func (r *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger, cloudstackScope *Scope) (controller.Result, error) {
 	log = log.WithValues("phase", "reconcileControlPlane")
 	log.Info("Applying control plane CAPI objects")
-	cp, err := cloudstack.ControlPlaneSpec(ctx, log, clientutil.NewKubeClient(r.client), spec)
+	cp, err := cloudstack.ControlPlaneSpec(ctx, log, clientutil.NewKubeClient(r.client), cloudstackScope.ClusterSpec, cloudstackScope.AffinityGroups)
 	if err != nil {
 		return controller.Result{}, err
 	}
@@ -137,17 +192,18 @@ func (r *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger,
 
 // CheckControlPlaneReady checks whether the control plane for an eks-a cluster is ready or not.
 // Requeues with the appropriate wait times whenever the control plane is not ready yet.
-func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
+func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger, cloudstackScope *Scope) (controller.Result, error) {
 	log = log.WithValues("phase", "checkControlPlaneReady")
-	return clusters.CheckControlPlaneReady(ctx, r.client, log, spec.Cluster)
+	return clusters.CheckControlPlaneReady(ctx, r.client, log, cloudstackScope.ClusterSpec.Cluster)
 }
 
 // ReconcileWorkers applies the worker CAPI objects to the cluster.
-func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, cloudstackScope *Scope) (controller.Result, error) {
 	log = log.WithValues("phase", "reconcileWorkers")
 	log.Info("Applying worker CAPI objects")
 
-	w, err := cloudstack.WorkersSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec)
+	clusterSpec := cloudstackScope.ClusterSpec
+	w, err := cloudstack.WorkersSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec, cloudstackScope.AffinityGroups)
 	if err != nil {
 		return controller.Result{}, errors.Wrap(err, "Generate worker node CAPI spec")
 	}
@@ -156,8 +212,9 @@ func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, clus
 }
 
 // ReconcileCNI reconciles the CNI to the desired state.
-func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, cloudstackScope *Scope) (controller.Result, error) {
 	log = log.WithValues("phase", "reconcileCNI")
+	clusterSpec := cloudstackScope.ClusterSpec
 	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
 	if err != nil {
 		return controller.Result{}, err
@@ -165,3 +222,50 @@ func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterS
 
 	return r.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
 }
+
+// ReconcileDelete deletes the affinity groups managed for the cluster once its machines are gone.
+// Clusters whose machine configs don't manage affinity groups are skipped.
+func (r *Reconciler) ReconcileDelete(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error {
+	log = log.WithValues("provider", "cloudstack", "phase", "reconcileDelete")
+	datacenterConfig := &anywherev1.CloudStackDatacenterConfig{}
+	key := client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.DatacenterRef.Name}
+	if err := r.client.Get(ctx, key, datacenterConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	managed := false
+	for _, ref := range cluster.MachineConfigRefs() {
+		machineConfig := &anywherev1.CloudStackMachineConfig{}
+		key := client.ObjectKey{Namespace: cluster.Namespace, Name: ref.Name}
+		if err := r.client.Get(ctx, key, machineConfig); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		managed = managed || machineConfig.Spec.ManageAffinityGroups
+	}
+	if !managed {
+		return nil
+	}
+
+	manager, err := r.affinityGroupManager(ctx, datacenterConfig)
+	if err != nil {
+		return err
+	}
+	if err := manager.Delete(ctx, log, cluster.Name, datacenterConfig); err != nil {
+		return errors.Wrap(err, "deleting affinity groups")
+	}
+	return nil
+}
+
+func (r *Reconciler) affinityGroupManager(ctx context.Context, datacenterConfig *anywherev1.CloudStackDatacenterConfig) (cloudstack.AffinityGroupManager, error) {
+	execConfig, err := cloudstack.GetCloudstackExecConfig(ctx, r.client, datacenterConfig)
+	if err != nil {
+		return nil, err
+	}
+	return r.affinityGroupRegistry.Get(execConfig)
+}
@@ -222,7 +222,7 @@ func TestReconcileControlPlaneUnstackedEtcdSuccess(t *testing.T) {
 	tt.eksaSupportObjs = append(tt.eksaSupportObjs, tt.secret)
 	tt.createAllObjs()
 	logger := test.NewNullLogger()
-	result, err := tt.reconciler().ReconcileControlPlane(tt.ctx, logger, tt.buildSpec())
+	result, err := tt.reconciler().ReconcileControlPlane(tt.ctx, logger, reconciler.NewScope(tt.buildSpec()))
 
 	tt.Expect(err).NotTo(HaveOccurred())
 	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
@@ -274,7 +274,7 @@ func TestReconcileControlPlaneStackedEtcdSuccess(t *testing.T) {
 	tt.eksaSupportObjs = append(tt.eksaSupportObjs, tt.secret)
 	tt.createAllObjs()
 	logger := test.NewNullLogger()
-	result, err := tt.reconciler().ReconcileControlPlane(tt.ctx, logger, tt.buildSpec())
+	result, err := tt.reconciler().ReconcileControlPlane(tt.ctx, logger, reconciler.NewScope(tt.buildSpec()))
 
 	tt.Expect(err).NotTo(HaveOccurred())
 	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
@@ -340,7 +340,7 @@ func TestReconcileCNISuccess(t *testing.T) {
 	).Return(remoteClient, nil)
 	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, spec)
 
-	result, err := tt.reconciler().ReconcileCNI(tt.ctx, logger, spec)
+	result, err := tt.reconciler().ReconcileCNI(tt.ctx, logger, reconciler.NewScope(spec))
 
 	tt.Expect(err).NotTo(HaveOccurred())
 	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
@@ -360,7 +360,7 @@ func TestReconcileCNIErrorClientRegistry(t *testing.T) {
 		tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: "eksa-system"},
 	).Return(nil, errors.New("building client"))
 
-	result, err := tt.reconciler().ReconcileCNI(tt.ctx, logger, spec)
+	result, err := tt.reconciler().ReconcileCNI(tt.ctx, logger, reconciler.NewScope(spec))
 
 	tt.Expect(err).To(MatchError(ContainSubstring("building client")))
 	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
@@ -378,7 +378,7 @@ func TestReconcilerReconcileWorkersSuccess(t *testing.T) {
 	tt.createAllObjs()
 
 	logger := test.NewNullLogger()
-	result, err := tt.reconciler().ReconcileWorkers(tt.ctx, logger, tt.buildSpec())
+	result, err := tt.reconciler().ReconcileWorkers(tt.ctx, logger, reconciler.NewScope(tt.buildSpec()))
 
 	tt.Expect(err).NotTo(HaveOccurred())
 	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
@@ -427,11 +427,86 @@ func TestReconcilerReconcileWorkersFailure(t *testing.T) {
 
 	logger := test.NewNullLogger()
 
-	_, err := tt.reconciler().ReconcileWorkers(tt.ctx, logger, clusterSpec)
+	_, err := tt.reconciler().ReconcileWorkers(tt.ctx, logger, reconciler.NewScope(clusterSpec))
 
 	tt.Expect(err).To(MatchError(ContainSubstring("Generate worker node CAPI spec")))
 }
 
+func TestReconcilerReconcileWorkersManagedAffinityGroups(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.machineConfigWorker.Spec.Affinity = "anti"
+	tt.machineConfigWorker.Spec.AffinityGroupIds = nil
+	tt.machineConfigWorker.Spec.ManageAffinityGroups = true
+	capiCluster := test.CAPICluster(func(c *clusterv1beta2.Cluster) {
+		c.Name = tt.cluster.Name
+	})
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, capiCluster, tt.secret)
+	tt.withFakeClient()
+	clusterSpec := tt.buildSpec()
+
+	manager := cloudstack.NewMockAffinityGroupManager(gomock.NewController(t))
+	tt.affinityGroupRegistry.EXPECT().Get(tt.execConfig).Return(manager, nil)
+	manager.EXPECT().Reconcile(tt.ctx, gomock.Any(), clusterSpec).Return(cloudstack.AffinityGroups{"worker-md-0": {"worker-affinity-group-id"}}, nil)
+
+	logger := test.NewNullLogger()
+	r := tt.reconciler()
+	scope := reconciler.NewScope(clusterSpec)
+	result, err := r.ReconcileAffinityGroups(tt.ctx, logger, scope)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(scope.AffinityGroups).To(HaveKey("worker-md-0"))
+
+	result, err = r.ReconcileWorkers(tt.ctx, logger, scope)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+
+	machineTemplate := &cloudstackv1.CloudStackMachineTemplate{}
+	tt.Expect(tt.client.Get(tt.ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: tt.cluster.Name + "-md-0-1"}, machineTemplate)).To(Succeed())
+	tt.Expect(machineTemplate.Spec.Template.Spec.AffinityGroupIDs).To(ConsistOf("worker-affinity-group-id"))
+	tt.Expect(machineTemplate.Spec.Template.Spec.Affinity).To(BeEmpty())
+}
+
+func TestReconcilerReconcileAffinityGroupsError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.machineConfigWorker.Spec.Affinity = "anti"
+	tt.machineConfigWorker.Spec.AffinityGroupIds = nil
+	tt.machineConfigWorker.Spec.ManageAffinityGroups = true
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, tt.secret)
+	tt.withFakeClient()
+	clusterSpec := tt.buildSpec()
+
+	manager := cloudstack.NewMockAffinityGroupManager(gomock.NewController(t))
+	tt.affinityGroupRegistry.EXPECT().Get(tt.execConfig).Return(manager, nil)
+	manager.EXPECT().Reconcile(tt.ctx, gomock.Any(), clusterSpec).Return(nil, errors.New("creating affinity group"))
+
+	_, err := tt.reconciler().ReconcileAffinityGroups(tt.ctx, test.NewNullLogger(), reconciler.NewScope(clusterSpec))
+
+	tt.Expect(err).To(MatchError(ContainSubstring("reconciling affinity groups: creating affinity group")))
+}
+
+func TestReconcilerReconcileDeleteManagedAffinityGroups(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.machineConfigWorker.Spec.Affinity = "anti"
+	tt.machineConfigWorker.Spec.AffinityGroupIds = nil
+	tt.machineConfigWorker.Spec.ManageAffinityGroups = true
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, tt.secret)
+	tt.withFakeClient()
+
+	manager := cloudstack.NewMockAffinityGroupManager(gomock.NewController(t))
+	tt.affinityGroupRegistry.EXPECT().Get(tt.execConfig).Return(manager, nil)
+	manager.EXPECT().Delete(tt.ctx, gomock.Any(), tt.cluster.Name, gomock.Any()).Return(nil)
+
+	tt.Expect(tt.reconciler().ReconcileDelete(tt.ctx, test.NewNullLogger(), tt.cluster)).To(Succeed())
+}
+
+func TestReconcilerReconcileDeleteUnmanagedAffinityGroups(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	tt.Expect(tt.reconciler().ReconcileDelete(tt.ctx, test.NewNullLogger(), tt.cluster)).To(Succeed())
+}
+
 func (tt *reconcilerTest) withFakeClient() {
 	tt.client = fake.NewClientBuilder().WithObjects(clientutil.ObjectsToClientObjects(tt.allObjs())...).Build()
 }
@@ -450,7 +525,7 @@ func (tt *reconcilerTest) allObjs() []client.Object {
 }
 
 func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
-	return reconciler.New(tt.client, tt.ipValidator, tt.cniReconciler, tt.remoteClientRegistry, tt.validatorRegistry)
+	return reconciler.New(tt.client, tt.ipValidator, tt.cniReconciler, tt.remoteClientRegistry, tt.validatorRegistry, tt.affinityGroupRegistry)
 }
 
 func (tt *reconcilerTest) buildSpec() *clusterspec.Spec {
@@ -476,6 +551,7 @@ type reconcilerTest struct {
 	cniReconciler             *cloudstackreconcilermocks.MockCNIReconciler
 	remoteClientRegistry      *cloudstackreconcilermocks.MockRemoteClientRegistry
 	validatorRegistry         *cloudstack.MockValidatorRegistry
+	affinityGroupRegistry     *cloudstack.MockAffinityGroupRegistry
 	execConfig                *decoder.CloudStackExecConfig
 	secret                    *corev1.Secret
 	kcp                       *controlplanev1beta2.KubeadmControlPlane
@@ -489,6 +565,7 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 	cniReconciler := cloudstackreconcilermocks.NewMockCNIReconciler(ctrl)
 	remoteClientRegistry := cloudstackreconcilermocks.NewMockRemoteClientRegistry(ctrl)
 	validatorRegistry := cloudstack.NewMockValidatorRegistry(ctrl)
+	affinityGroupRegistry := cloudstack.NewMockAffinityGroupRegistry(ctrl)
 	execConfig := &decoder.CloudStackExecConfig{
 		Profiles: []decoder.CloudStackProfileConfig{
 			{
@@ -650,6 +727,7 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 		cniReconciler:             cniReconciler,
 		remoteClientRegistry:      remoteClientRegistry,
 		validatorRegistry:         validatorRegistry,
+		affinityGroupRegistry:     affinityGroupRegistry,
 		execConfig:                execConfig,
 		secret:                    secret,
 		kcp:                       kcp,
@@ -23,7 +23,8 @@ import (
 
 // TemplateBuilder is responsible for building the CAPI templates.
 type TemplateBuilder struct {
-	now types.NowFunc
+	now            types.NowFunc
+	affinityGroups AffinityGroups
 }
 
 // NewTemplateBuilder creates a new TemplateBuilder.
@@ -52,13 +53,13 @@ func (cs *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spe
 		buildOption(values)
 	}
 
-	bytes, err := buildControlPlaneTemplate(&controlPlaneMachineConfig(clusterSpec).Spec, values)
+	bytes, err := buildControlPlaneTemplate(withAffinityGroups(controlPlaneMachineConfig(clusterSpec).Spec, cs.affinityGroups.controlPlane()), values)
 	if err != nil {
 		return nil, err
 	}
 
 	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
-		etcdMachineTemplateBytes, err := buildEtcdTemplate(&etcdMachineSpec, values)
+		etcdMachineTemplateBytes, err := buildEtcdTemplate(withAffinityGroups(etcdMachineSpec, cs.affinityGroups.etcd()), values)
 		if err != nil {
 			return nil, fmt.Errorf("marshalling etcd machine template to byte array: %v", err)
 		}
@@ -95,7 +96,8 @@ func (cs *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, wo
 		workerSpecs = append(workerSpecs, bytes)
 
 		workerMachineTemplateName := workloadTemplateNames[workerNodeGroupConfiguration.Name]
-		workerMachineTemplate := MachineTemplate(workerMachineTemplateName, &workerMachineConfig(clusterSpec, workerNodeGroupConfiguration).Spec)
+		workerMachineSpec := withAffinityGroups(workerMachineConfig(clusterSpec, workerNodeGroupConfiguration).Spec, cs.affinityGroups.worker(workerNodeGroupConfiguration.Name))
+		workerMachineTemplate := MachineTemplate(workerMachineTemplateName, workerMachineSpec)
 		workerMachineTemplateBytes, err := templater.ObjectsToYaml(workerMachineTemplate)
 		if err != nil {
 			return nil, fmt.Errorf("marshalling worker machine template to byte array: %v", err)
@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"strings"
 
+	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
@@ -47,8 +49,17 @@ type ProviderCmkClient interface {
 	ValidateAffinityGroupsPresent(ctx context.Context, profile string, domainId string, account string, affinityGroupIds []string) error
 	ValidateZoneAndGetId(ctx context.Context, profile string, zone anywherev1.CloudStackZone) (string, error)
 	ValidateNetworkPresent(ctx context.Context, profile string, domainId string, network anywherev1.CloudStackResourceIdentifier, zoneId string, account string) error
+	GetNetworkType(ctx context.Context, profile string, domainId string, network anywherev1.CloudStackResourceIdentifier, zoneId string, account string) (string, error)
 	ValidateDomainAndGetId(ctx context.Context, profile string, domain string) (string, error)
 	ValidateAccountPresent(ctx context.Context, profile string, account string, domainId string) error
+	GetAccountResourceLimits(ctx context.Context, profile string, domainId string, account string) (*executables.CloudStackResourceLimits, error)
+	GetDomainResourceLimits(ctx context.Context, profile string, domainId string) (*executables.CloudStackResourceLimits, error)
+	GetMachineResources(ctx context.Context, profile string, domainId string, zoneId string, account string, machineConfig anywherev1.CloudStackMachineConfigSpec) (*executables.CloudStackMachineResources, error)
+	ListAffinityGroups(ctx context.Context, profile string, domainId string, account string) ([]executables.CloudStackAffinityGroup, error)
+	CreateAffinityGroup(ctx context.Context, profile string, domainId string, account string, group executables.CloudStackAffinityGroup) (string, error)
+	DeleteAffinityGroup(ctx context.Context, profile string, id string) error
+	ListVirtualMachines(ctx context.Context, profile string, keyword string) ([]executables.CloudStackVirtualMachine, error)
+	DestroyVirtualMachine(ctx context.Context, profile string, id string) error
 }
 
 func (v *Validator) ValidateCloudStackDatacenterConfig(ctx context.Context, datacenterConfig *anywherev1.CloudStackDatacenterConfig) error {
@@ -57,36 +68,49 @@ func (v *Validator) ValidateCloudStackDatacenterConfig(ctx context.Context, data
 		return err
 	}
 
+	var g errgroup.Group
 	for _, az := range localAvailabilityZones {
-		endpoint, err := v.cmk.GetManagementApiEndpoint(az.CredentialsRef)
-		if err != nil {
-			return err
-		}
-		if endpoint != az.ManagementApiEndpoint {
-			return fmt.Errorf("cloudstack secret management url (%s) differs from cluster spec management url (%s)",
-				endpoint, az.ManagementApiEndpoint)
-		}
+		g.Go(func() error {
+			return v.validateAvailabilityZone(ctx, az)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
 
-		domainId, err := v.cmk.ValidateDomainAndGetId(ctx, az.CredentialsRef, az.Domain)
-		if err != nil {
-			return err
-		}
-		az.DomainId = domainId
+	logger.MarkPass("Datacenter validated")
+	return nil
+}
 
-		if err := v.cmk.ValidateAccountPresent(ctx, az.CredentialsRef, az.Account, az.DomainId); err != nil {
-			return err
-		}
+// validateAvailabilityZone checks the credentials, domain, account, zone and network of an availability zone.
+func (v *Validator) validateAvailabilityZone(ctx context.Context, az localAvailabilityZone) error {
+	endpoint, err := v.cmk.GetManagementApiEndpoint(az.CredentialsRef)
+	if err != nil {
+		return err
+	}
+	if endpoint != az.ManagementApiEndpoint {
+		return fmt.Errorf("cloudstack secret management url (%s) differs from cluster spec management url (%s)",
+			endpoint, az.ManagementApiEndpoint)
+	}
 
-		zoneId, err := v.cmk.ValidateZoneAndGetId(ctx, az.CredentialsRef, az.CloudStackAvailabilityZone.Zone)
-		if err != nil {
-			return err
-		}
-		if err := v.cmk.ValidateNetworkPresent(ctx, az.CredentialsRef, az.DomainId, az.CloudStackAvailabilityZone.Zone.Network, zoneId, az.Account); err != nil {
-			return err
-		}
+	domainId, err := v.cmk.ValidateDomainAndGetId(ctx, az.CredentialsRef, az.Domain)
+	if err != nil {
+		return err
+	}
+	az.DomainId = domainId
+
+	if err := v.cmk.ValidateAccountPresent(ctx, az.CredentialsRef, az.Account, az.DomainId); err != nil {
+		return err
+	}
+
+	zoneId, err := v.cmk.ValidateZoneAndGetId(ctx, az.CredentialsRef, az.CloudStackAvailabilityZone.Zone)
+	if err != nil {
+		return err
+	}
+	if err := v.cmk.ValidateNetworkPresent(ctx, az.CredentialsRef, az.DomainId, az.CloudStackAvailabilityZone.Zone.Network, zoneId, az.Account); err != nil {
+		return err
 	}
 
-	logger.MarkPass("Datacenter validated")
 	return nil
 }
 
@@ -157,6 +181,10 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, clusterSp
 		}
 	}
 
+	if err := validateManagedAffinityGroupScope(clusterSpec); err != nil {
+		return err
+	}
+
 	logger.MarkPass("Validated cluster Machine Configs")
 
 	return nil
@@ -183,31 +211,35 @@ func (v *Validator) validateMachineConfig(ctx context.Context, datacenterConfig
 		return err
 	}
 
+	var g errgroup.Group
 	for _, az := range localAvailabilityZones {
-		zoneId, err := v.cmk.ValidateZoneAndGetId(ctx, az.CredentialsRef, az.CloudStackAvailabilityZone.Zone)
-		if err != nil {
-			return err
-		}
+		g.Go(func() error {
+			zoneId, err := v.cmk.ValidateZoneAndGetId(ctx, az.CredentialsRef, az.CloudStackAvailabilityZone.Zone)
+			if err != nil {
+				return err
+			}
 
-		if err := v.cmk.ValidateTemplatePresent(ctx, az.CredentialsRef, az.DomainId, zoneId, az.Account, machineConfig.Spec.Template); err != nil {
-			return fmt.Errorf("validating template: %v", err)
-		}
-		if err := v.cmk.ValidateServiceOfferingPresent(ctx, az.CredentialsRef, zoneId, machineConfig.Spec.ComputeOffering); err != nil {
-			return fmt.Errorf("validating service offering: %v", err)
-		}
-		if machineConfig.Spec.DiskOffering != nil && (len(machineConfig.Spec.DiskOffering.Id) > 0 || len(machineConfig.Spec.DiskOffering.Name) > 0) {
-			if err := v.cmk.ValidateDiskOfferingPresent(ctx, az.CredentialsRef, zoneId, *machineConfig.Spec.DiskOffering); err != nil {
-				return fmt.Errorf("validating disk offering: %v", err)
+			if err := v.cmk.ValidateTemplatePresent(ctx, az.CredentialsRef, az.DomainId, zoneId, az.Account, machineConfig.Spec.Template); err != nil {
+				return fmt.Errorf("validating template: %v", err)
 			}
-		}
-		if len(machineConfig.Spec.AffinityGroupIds) > 0 {
-			if err := v.cmk.ValidateAffinityGroupsPresent(ctx, az.CredentialsRef, az.DomainId, az.Account, machineConfig.Spec.AffinityGroupIds); err != nil {
-				return fmt.Errorf("validating affinity group ids: %v", err)
+			if err := v.cmk.ValidateServiceOfferingPresent(ctx, az.CredentialsRef, zoneId, machineConfig.Spec.ComputeOffering); err != nil {
+				return fmt.Errorf("validating service offering: %v", err)
 			}
-		}
+			if machineConfig.Spec.DiskOffering != nil && (len(machineConfig.Spec.DiskOffering.Id) > 0 || len(machineConfig.Spec.DiskOffering.Name) > 0) {
+				if err := v.cmk.ValidateDiskOfferingPresent(ctx, az.CredentialsRef, zoneId, *machineConfig.Spec.DiskOffering); err != nil {
+					return fmt.Errorf("validating disk offering: %v", err)
+				}
+			}
+			if len(machineConfig.Spec.AffinityGroupIds) > 0 {
+				if err := v.cmk.ValidateAffinityGroupsPresent(ctx, az.CredentialsRef, az.DomainId, az.Account, machineConfig.Spec.AffinityGroupIds); err != nil {
+					return fmt.Errorf("validating affinity group ids: %v", err)
+				}
+			}
+			return nil
+		})
 	}
 
-	return nil
+	return g.Wait()
 }
 
 func (v *Validator) validateTemplateMatchesKubernetesVersion(ctx context.Context, templateName string, kubernetesVersionName string) error {
@@ -80,6 +80,20 @@ func (mr *MockProviderValidatorMockRecorder) ValidateControlPlaneEndpointUniquen
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateControlPlaneEndpointUniqueness", reflect.TypeOf((*MockProviderValidator)(nil).ValidateControlPlaneEndpointUniqueness), arg0)
 }
 
+// ValidateResourceQuota mocks base method.
+func (m *MockProviderValidator) ValidateResourceQuota(arg0 context.Context, arg1, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateResourceQuota", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateResourceQuota indicates an expected call of ValidateResourceQuota.
+func (mr *MockProviderValidatorMockRecorder) ValidateResourceQuota(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateResourceQuota", reflect.TypeOf((*MockProviderValidator)(nil).ValidateResourceQuota), arg0, arg1, arg2)
+}
+
 // ValidateSecretsUnchanged mocks base method.
 func (m *MockProviderValidator) ValidateSecretsUnchanged(arg0 context.Context, arg1 *types.Cluster, arg2 *decoder.CloudStackExecConfig, arg3 ProviderKubectlClient) error {
 	m.ctrl.T.Helper()
@@ -34,6 +34,7 @@ type ProviderValidator interface {
 	ValidateCloudStackDatacenterConfig(ctx context.Context, datacenterConfig *anywherev1.CloudStackDatacenterConfig) error
 	ValidateClusterMachineConfigs(ctx context.Context, clusterSpec *cluster.Spec) error
 	ValidateControlPlaneEndpointUniqueness(endpoint string) error
+	ValidateResourceQuota(ctx context.Context, clusterSpec *cluster.Spec, currentSpec *cluster.Spec) error
 	ValidateSecretsUnchanged(ctx context.Context, cluster *types.Cluster, execConfig *decoder.CloudStackExecConfig, client ProviderKubectlClient) error
 }
 
@@ -23,9 +23,10 @@ type (
 
 // WorkersSpec generates a cloudstack specific CAPI spec for an eks-a cluster worker nodes.
 // It talks to the cluster with a client to detect changes in immutable objects and generates new
-// names for them.
-func WorkersSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec) (*Workers, error) {
+// names for them. Machine templates of node groups with managed affinity groups use the ids in affinityGroups.
+func WorkersSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec, affinityGroups AffinityGroups) (*Workers, error) {
 	templateBuilder := NewTemplateBuilder(time.Now)
+	templateBuilder.affinityGroups = affinityGroups
 	machineTemplateNames, kubeadmConfigTemplateNames := clusterapi.InitialTemplateNamesForWorkers(spec)
 	workersYaml, err := templateBuilder.GenerateCAPISpecWorkers(spec, machineTemplateNames, kubeadmConfigTemplateNames)
 	if err != nil {
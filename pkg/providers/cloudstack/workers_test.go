@@ -597,7 +597,7 @@ func TestWorkersSpec(t *testing.T) {
 
 			expect := tc.Expect()
 
-			workers, err := cloudstack.WorkersSpec(ctx, logger, client, spec)
+			workers, err := cloudstack.WorkersSpec(ctx, logger, client, spec, nil)
 			g.Expect(err).NotTo(HaveOccurred())
 
 			// Optionally dump expect and got. This proved useful in debugging as the Ginkgo output
@@ -625,7 +625,7 @@ func TestWorkersSpecErrorFromClient(t *testing.T) {
 	ctx := context.Background()
 	spec := test.NewFullClusterSpec(t, "testdata/cluster_main_multiple_worker_node_groups.yaml")
 	client := test.NewFakeKubeClientAlwaysError()
-	_, err := cloudstack.WorkersSpec(ctx, logger, client, spec)
+	_, err := cloudstack.WorkersSpec(ctx, logger, client, spec, nil)
 	g.Expect(err).To(MatchError(ContainSubstring("updating cloudstack worker immutable object names")))
 }
 
@@ -635,7 +635,7 @@ func TestWorkersSpecMachineTemplateNotFound(t *testing.T) {
 	ctx := context.Background()
 	spec := test.NewFullClusterSpec(t, "testdata/cluster_main_multiple_worker_node_groups.yaml")
 	client := test.NewFakeKubeClient(machineDeployment())
-	_, err := cloudstack.WorkersSpec(ctx, logger, client, spec)
+	_, err := cloudstack.WorkersSpec(ctx, logger, client, spec, nil)
 	g.Expect(err).NotTo(HaveOccurred())
 }
 
@@ -666,7 +666,7 @@ func TestWorkersSpecRegistryMirrorConfiguration(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			spec.Cluster.Spec.RegistryMirrorConfiguration = tt.mirrorConfig
-			workers, err := cloudstack.WorkersSpec(ctx, logger, client, spec)
+			workers, err := cloudstack.WorkersSpec(ctx, logger, client, spec, nil)
 			g.Expect(err).NotTo(HaveOccurred())
 			g.Expect(workers).NotTo(BeNil())
 			g.Expect(workers.Groups).To(HaveLen(2))
@@ -728,7 +728,7 @@ func TestWorkersSpecUpgradeRolloutStrategy(t *testing.T) {
 	}
 	client := test.NewFakeKubeClient()
 
-	workers, err := cloudstack.WorkersSpec(ctx, logger, client, spec)
+	workers, err := cloudstack.WorkersSpec(ctx, logger, client, spec, nil)
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(workers).NotTo(BeNil())
 	g.Expect(workers.Groups).To(HaveLen(1))
@@ -260,6 +260,48 @@ func TestControlPlaneSpecRegistryMirrorConfiguration(t *testing.T) {
 	}
 }
 
+func TestControlPlaneSpecProxyConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	client := test.NewFakeKubeClient()
+	spec := testClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ProxyConfiguration = &anywherev1.ProxyConfiguration{
+			HttpProxy:  "http://eksa-proxy:3128",
+			HttpsProxy: "http://eksa-proxy:3128",
+			NoProxy:    []string{"172.18.0.0/16"},
+		}
+	})
+	noProxy := []string{"192.168.0.0/16", "10.96.0.0/12", "172.18.0.0/16", "localhost", "127.0.0.1", ".svc", "test-lb"}
+
+	cp, err := docker.ControlPlaneSpec(ctx, logger, client, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cp).NotTo(BeNil())
+	g.Expect(cp.KubeadmControlPlane).To(Equal(kubeadmControlPlane(func(kcp *controlplanev1beta2.KubeadmControlPlane) {
+		kcp.Spec.KubeadmConfigSpec.Files = append(kcp.Spec.KubeadmConfigSpec.Files, bootstrapv1beta2.File{
+			Path:  "/etc/systemd/system/containerd.service.d/http-proxy.conf",
+			Owner: "root:root",
+			Content: `[Service]
+Environment="HTTP_PROXY=http://eksa-proxy:3128"
+Environment="HTTPS_PROXY=http://eksa-proxy:3128"
+Environment="NO_PROXY=192.168.0.0/16,10.96.0.0/12,172.18.0.0/16,localhost,127.0.0.1,.svc,test-lb,DOCKER_NETWORK_CIDR"
+`,
+		})
+		kcp.Spec.KubeadmConfigSpec.PreKubeadmCommands = append(kcp.Spec.KubeadmConfigSpec.PreKubeadmCommands,
+			`sed -i "s|DOCKER_NETWORK_CIDR|$(ip -4 route show dev eth0 scope link | sed 's/ .*//' | paste -sd, -)|" /etc/systemd/system/containerd.service.d/http-proxy.conf`,
+			"systemctl daemon-reload",
+			"systemctl restart containerd",
+		)
+	})))
+	g.Expect(cp.EtcdCluster).To(Equal(etcdCluster(func(ec *etcdv1.EtcdadmCluster) {
+		ec.Spec.EtcdadmConfigSpec.Proxy = &etcdadmbootstrapv1.ProxyConfiguration{
+			HTTPProxy:  "http://eksa-proxy:3128",
+			HTTPSProxy: "http://eksa-proxy:3128",
+			NoProxy:    noProxy,
+		}
+	})))
+}
+
 func TestControlPlaneUpgradeRolloutStrategy(t *testing.T) {
 	g := NewWithT(t)
 	logger := test.NewNullLogger()
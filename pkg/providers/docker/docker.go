@@ -145,6 +145,9 @@ func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpe
 	if err := ValidateControlPlaneEndpoint(clusterSpec); err != nil {
 		return err
 	}
+	if err := ValidateUpgradeRolloutStrategy(clusterSpec); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -153,9 +156,9 @@ func (p *Provider) SetupAndValidateDeleteCluster(ctx context.Context, _ *types.C
 	return nil
 }
 
-// SetupAndValidateUpgradeCluster is a no-op. It implements providers.Provider.
-func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, _ *types.Cluster, _ *cluster.Spec, _ *cluster.Spec) error {
-	return nil
+// SetupAndValidateUpgradeCluster validates the upgrade rollout strategy of the new cluster spec. It implements providers.Provider.
+func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, _ *types.Cluster, clusterSpec *cluster.Spec, _ *cluster.Spec) error {
+	return ValidateUpgradeRolloutStrategy(clusterSpec)
 }
 
 // SetupAndValidateUpgradeManagementComponents performs necessary setup for upgrade management components operation.
@@ -212,8 +215,12 @@ func (d *DockerTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spe
 
 		if workerNodeGroupConfiguration.UpgradeRolloutStrategy != nil {
 			values["upgradeRolloutStrategy"] = true
-			values["maxSurge"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
-			values["maxUnavailable"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxUnavailable
+			if workerNodeGroupConfiguration.UpgradeRolloutStrategy.Type == v1alpha1.InPlaceStrategyType {
+				values["upgradeRolloutStrategyType"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.Type
+			} else {
+				values["maxSurge"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+				values["maxUnavailable"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxUnavailable
+			}
 		}
 
 		bytes, err := templater.Execute(defaultCAPIConfigMD, values)
@@ -337,9 +344,17 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		}
 	}
 
+	if clusterSpec.Cluster.Spec.ProxyConfiguration != nil {
+		populateProxyValues(clusterSpec, values)
+	}
+
 	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy != nil {
 		values["upgradeRolloutStrategy"] = true
-		values["maxSurge"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+		if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.Type == v1alpha1.InPlaceStrategyType {
+			values["upgradeRolloutStrategyType"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.Type
+		} else {
+			values["maxSurge"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+		}
 	}
 
 	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.KubeletConfiguration != nil {
@@ -433,6 +448,10 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupConfiguration
 		}
 	}
 
+	if clusterSpec.Cluster.Spec.ProxyConfiguration != nil {
+		populateProxyValues(clusterSpec, values)
+	}
+
 	if workerNodeGroupConfiguration.KubeletConfiguration != nil {
 		wnKubeletConfig := workerNodeGroupConfiguration.KubeletConfiguration.Object
 		if _, ok := wnKubeletConfig["tlsCipherSuites"]; !ok {
@@ -668,6 +687,35 @@ func (p *Provider) PreCoreComponentsUpgrade(
 	return nil
 }
 
+// populateProxyValues sets the proxy containerd pulls images through. Docker clusters don't have a
+// control plane endpoint host, the nodes reach the API server through the load balancer container of
+// the cluster, so its name is added to the noProxy list instead. The docker network CIDR, which
+// includes the load balancer and the other nodes, is only known on the nodes and is added to the
+// containerd noProxy list when they boot.
+func populateProxyValues(clusterSpec *cluster.Spec, values map[string]interface{}) {
+	proxy := clusterSpec.Cluster.Spec.ProxyConfiguration
+	capacity := len(clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks) +
+		len(clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks) +
+		len(proxy.NoProxy) + 4
+	noProxyList := make([]string, 0, capacity)
+	noProxyList = append(noProxyList, clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks...)
+	noProxyList = append(noProxyList, clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks...)
+	noProxyList = append(noProxyList, proxy.NoProxy...)
+	noProxyList = append(noProxyList, clusterapi.NoProxyDefaults()...)
+	noProxyList = append(noProxyList, loadBalancerName(clusterSpec.Cluster.Name))
+
+	values["proxyConfig"] = true
+	values["httpProxy"] = proxy.HttpProxy
+	values["httpsProxy"] = proxy.HttpsProxy
+	values["noProxy"] = noProxyList
+}
+
+// loadBalancerName returns the name of the load balancer container CAPD runs in front of the
+// control plane nodes of a cluster.
+func loadBalancerName(clusterName string) string {
+	return clusterName + "-lb"
+}
+
 func populateRegistryMirrorValues(clusterSpec *cluster.Spec, values map[string]interface{}) (map[string]interface{}, error) {
 	registryMirror := registrymirror.FromCluster(clusterSpec.Cluster)
 	values["registryMirrorMap"] = containerd.ToAPIEndpoints(registryMirror.NamespacedRegistryMap)
@@ -471,6 +471,36 @@ func TestDockerTemplateBuilderGenerateCAPISpecWorkers(t *testing.T) {
 	}
 }
 
+func TestDockerTemplateBuilderGenerateCAPISpecInPlaceUpgradeRolloutStrategy(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test-cluster"
+		s.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{
+			Type: v1alpha1.InPlaceStrategyType,
+		}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+			{
+				Count: ptr.Int(1),
+				Name:  "test",
+				UpgradeRolloutStrategy: &v1alpha1.WorkerNodesUpgradeRolloutStrategy{
+					Type: v1alpha1.InPlaceStrategyType,
+				},
+			},
+		}
+	})
+	builder := docker.NewDockerTemplateBuilder(time.Now)
+
+	cp, err := builder.GenerateCAPISpecControlPlane(clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(cp)).To(ContainSubstring("rollout:\n    strategy:\n      type: InPlace\n"))
+	g.Expect(string(cp)).NotTo(ContainSubstring("rollingUpdate:"))
+
+	md, err := builder.GenerateCAPISpecWorkers(clusterSpec, nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(md)).To(ContainSubstring("rollout:\n    strategy:\n      type: InPlace\n"))
+	g.Expect(string(md)).NotTo(ContainSubstring("rollingUpdate:"))
+}
+
 func TestInvalidDockerTemplateWithControlplaneEndpoint(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	ctx := context.Background()
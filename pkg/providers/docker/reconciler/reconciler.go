@@ -53,6 +53,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, c *anywhere
 	}
 
 	return controller.NewPhaseRunner[*cluster.Spec]().Register(
+		r.ValidateClusterSpec,
 		clusters.CleanupStatusAfterValidate,
 		r.ReconcileControlPlane,
 		r.CheckControlPlaneReady,
@@ -61,6 +62,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, c *anywhere
 	).Run(ctx, log, clusterSpec)
 }
 
+// ValidateClusterSpec performs the docker specific validations on the cluster spec.
+func (r *Reconciler) ValidateClusterSpec(ctx context.Context, log logr.Logger, spec *cluster.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "validateClusterSpec")
+
+	if err := docker.ValidateUpgradeRolloutStrategy(spec); err != nil {
+		log.Error(err, "Invalid cluster spec", "cluster", spec.Cluster.Name)
+		spec.Cluster.SetFailure(anywherev1.ClusterInvalidReason, err.Error())
+		return controller.ResultWithReturn(), nil
+	}
+
+	return controller.Result{}, nil
+}
+
 // CheckControlPlaneReady checks whether the control plane for an eks-a cluster is ready or not.
 // Requeues with the appropriate wait times whenever the cluster is not ready yet.
 func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger, spec *cluster.Spec) (controller.Result, error) {
@@ -165,6 +165,35 @@ func TestReconcileCNIErrorClientRegistry(t *testing.T) {
 	tt.Expect(result).To(Equal(controller.Result{}))
 }
 
+func TestReconcilerValidateClusterSpecSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+	spec := tt.buildSpec()
+
+	result, err := tt.reconciler().ValidateClusterSpec(tt.ctx, test.NewNullLogger(), spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(spec.Cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(spec.Cluster.Status.FailureReason).To(BeZero())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerValidateClusterSpecDifferentUpgradeRolloutStrategies(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &anywherev1.ControlPlaneUpgradeRolloutStrategy{
+		Type: anywherev1.InPlaceStrategyType,
+	}
+	tt.withFakeClient()
+	spec := tt.buildSpec()
+
+	result, err := tt.reconciler().ValidateClusterSpec(tt.ctx, test.NewNullLogger(), spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(spec.Cluster.Status.FailureMessage).To(HaveValue(ContainSubstring("cannot specify different upgrade rollout strategy types")))
+	tt.Expect(spec.Cluster.Status.FailureReason).To(HaveValue(Equal(anywherev1.ClusterInvalidReason)))
+	tt.Expect(result).To(Equal(controller.ResultWithReturn()))
+}
+
 func TestReconcilerReconcileWorkersSuccess(t *testing.T) {
 	tt := newReconcilerTest(t)
 	capiCluster := test.CAPICluster(func(c *clusterv1beta2.Cluster) {
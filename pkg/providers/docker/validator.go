@@ -1,8 +1,10 @@
 package docker
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 )
 
@@ -13,3 +15,26 @@ func ValidateControlPlaneEndpoint(clusterSpec *cluster.Spec) error {
 	}
 	return nil
 }
+
+// ValidateUpgradeRolloutStrategy checks that the control plane and the worker node groups use the same
+// upgrade rollout strategy type and that InPlace upgrades aren't combined with the autoscaler.
+func ValidateUpgradeRolloutStrategy(clusterSpec *cluster.Spec) error {
+	cpUpgradeRolloutStrategyType := v1alpha1.RollingUpdateStrategyType
+	if s := clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy; s != nil {
+		cpUpgradeRolloutStrategyType = s.Type
+	}
+
+	for _, group := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		wnUpgradeRolloutStrategyType := v1alpha1.RollingUpdateStrategyType
+		if group.UpgradeRolloutStrategy != nil {
+			wnUpgradeRolloutStrategyType = group.UpgradeRolloutStrategy.Type
+		}
+		if wnUpgradeRolloutStrategyType != cpUpgradeRolloutStrategyType {
+			return errors.New("cannot specify different upgrade rollout strategy types for control plane and worker node group configurations")
+		}
+		if cpUpgradeRolloutStrategyType == v1alpha1.InPlaceStrategyType && group.AutoScalingConfiguration != nil {
+			return errors.New("autoscaler configuration not supported with InPlace upgrades")
+		}
+	}
+	return nil
+}
@@ -29,3 +29,65 @@ func TestValidateControlplaneEndpoint(t *testing.T) {
 		t.Errorf("Got err %v, wanted %v", err, wantErr)
 	}
 }
+
+func TestValidateUpgradeRolloutStrategy(t *testing.T) {
+	tests := []struct {
+		name    string
+		cp      *v1alpha1.ControlPlaneUpgradeRolloutStrategy
+		workers *v1alpha1.WorkerNodesUpgradeRolloutStrategy
+		asc     *v1alpha1.AutoScalingConfiguration
+		wantErr string
+	}{
+		{
+			name: "default strategies",
+		},
+		{
+			name:    "in place",
+			cp:      &v1alpha1.ControlPlaneUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType},
+			workers: &v1alpha1.WorkerNodesUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType},
+		},
+		{
+			name:    "in place control plane only",
+			cp:      &v1alpha1.ControlPlaneUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType},
+			wantErr: "cannot specify different upgrade rollout strategy types for control plane and worker node group configurations",
+		},
+		{
+			name:    "in place workers only",
+			workers: &v1alpha1.WorkerNodesUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType},
+			wantErr: "cannot specify different upgrade rollout strategy types for control plane and worker node group configurations",
+		},
+		{
+			name:    "in place with autoscaler",
+			cp:      &v1alpha1.ControlPlaneUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType},
+			workers: &v1alpha1.WorkerNodesUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType},
+			asc:     &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3},
+			wantErr: "autoscaler configuration not supported with InPlace upgrades",
+		},
+		{
+			name: "rolling update with autoscaler",
+			asc:  &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = tt.cp
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{
+					Count:                    ptr.Int(3),
+					MachineGroupRef:          &v1alpha1.Ref{Name: "test-cluster"},
+					Name:                     "md-0",
+					UpgradeRolloutStrategy:   tt.workers,
+					AutoScalingConfiguration: tt.asc,
+				}}
+			})
+
+			err := docker.ValidateUpgradeRolloutStrategy(clusterSpec)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("ValidateUpgradeRolloutStrategy() got err %v, wanted nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("ValidateUpgradeRolloutStrategy() got err %v, wanted %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -336,6 +336,45 @@ func TestWorkersSpecRegistryMirrorConfiguration(t *testing.T) {
 	}
 }
 
+func TestWorkersSpecProxyConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	client := test.NewFakeKubeClient()
+	spec := testClusterSpec()
+	spec.Cluster.Spec.WorkerNodeGroupConfigurations = spec.Cluster.Spec.WorkerNodeGroupConfigurations[:1]
+	spec.Cluster.Spec.ProxyConfiguration = &anywherev1.ProxyConfiguration{
+		HttpProxy:  "http://eksa-proxy:3128",
+		HttpsProxy: "http://eksa-proxy:3128",
+	}
+
+	workers, err := docker.WorkersSpec(ctx, logger, client, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(workers).NotTo(BeNil())
+	g.Expect(workers.Groups).To(ConsistOf(
+		clusterapi.WorkerGroup[*dockerv1.DockerMachineTemplate]{
+			KubeadmConfigTemplate: kubeadmConfigTemplate(func(kct *bootstrapv1beta2.KubeadmConfigTemplate) {
+				kct.Spec.Template.Spec.Files = append(kct.Spec.Template.Spec.Files, bootstrapv1beta2.File{
+					Path:  "/etc/systemd/system/containerd.service.d/http-proxy.conf",
+					Owner: "root:root",
+					Content: `[Service]
+Environment="HTTP_PROXY=http://eksa-proxy:3128"
+Environment="HTTPS_PROXY=http://eksa-proxy:3128"
+Environment="NO_PROXY=192.168.0.0/16,10.96.0.0/12,localhost,127.0.0.1,.svc,test-lb,DOCKER_NETWORK_CIDR"
+`,
+				})
+				kct.Spec.Template.Spec.PreKubeadmCommands = append(kct.Spec.Template.Spec.PreKubeadmCommands,
+					`sed -i "s|DOCKER_NETWORK_CIDR|$(ip -4 route show dev eth0 scope link | sed 's/ .*//' | paste -sd, -)|" /etc/systemd/system/containerd.service.d/http-proxy.conf`,
+					"systemctl daemon-reload",
+					"systemctl restart containerd",
+				)
+			}),
+			MachineDeployment:       machineDeployment(),
+			ProviderMachineTemplate: dockerMachineTemplate("test-md-0-1"),
+		},
+	))
+}
+
 func TestWorkersSpecUpgradeRolloutStrategy(t *testing.T) {
 	g := NewWithT(t)
 	logger := test.NewNullLogger()
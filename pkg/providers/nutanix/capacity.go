@@ -0,0 +1,558 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+// Rollout strategy customization is not supported for Nutanix, so every machine group
+// rolls out one machine at a time during upgrades.
+const rolloutMaxSurge = 1
+
+// machineGroup is a group of machines created from the same machine config.
+type machineGroup struct {
+	name          string
+	machineConfig *anywherev1.NutanixMachineConfig
+	count         int
+	worker        bool
+	// workerNodeGroup is the name of the worker node group for worker machines.
+	workerNodeGroup string
+}
+
+// surge returns the number of extra machines of the group rolled out at once during an upgrade.
+func (g machineGroup) surge() int {
+	if rolloutMaxSurge > g.count {
+		return g.count
+	}
+	return rolloutMaxSurge
+}
+
+func machineGroups(spec *cluster.Spec) []machineGroup {
+	clusterSpec := spec.Cluster.Spec
+	groups := []machineGroup{{
+		name:          "control plane",
+		machineConfig: spec.NutanixMachineConfigs[clusterSpec.ControlPlaneConfiguration.MachineGroupRef.Name],
+		count:         clusterSpec.ControlPlaneConfiguration.Count,
+	}}
+
+	if clusterSpec.ExternalEtcdConfiguration != nil {
+		groups = append(groups, machineGroup{
+			name:          "etcd",
+			machineConfig: spec.NutanixMachineConfigs[clusterSpec.ExternalEtcdConfiguration.MachineGroupRef.Name],
+			count:         clusterSpec.ExternalEtcdConfiguration.Count,
+		})
+	}
+
+	for _, wng := range clusterSpec.WorkerNodeGroupConfigurations {
+		count := 0
+		if wng.Count != nil {
+			count = *wng.Count
+		}
+		if wng.AutoScalingConfiguration != nil && wng.AutoScalingConfiguration.MaxCount > count {
+			count = wng.AutoScalingConfiguration.MaxCount
+		}
+		groups = append(groups, machineGroup{
+			name:            "worker node group " + wng.Name,
+			machineConfig:   spec.NutanixMachineConfigs[wng.MachineGroupRef.Name],
+			count:           count,
+			worker:          true,
+			workerNodeGroup: wng.Name,
+		})
+	}
+
+	return groups
+}
+
+// CapacityReport compares the capacity requested by the machines of a cluster to the capacity
+// available in each Prism Element cluster they are placed in.
+type CapacityReport struct {
+	Clusters []ClusterCapacity `json:"clusters"`
+}
+
+// ClusterCapacity is the capacity requested from and available in a Prism Element cluster.
+type ClusterCapacity struct {
+	Name          string           `json:"name,omitempty"`
+	UUID          string           `json:"uuid"`
+	MachineGroups []string         `json:"machineGroups"`
+	VCPUs         ResourceCapacity `json:"vcpus"`
+	MemoryMiB     ResourceCapacity `json:"memoryMiB"`
+	StorageGiB    ResourceCapacity `json:"storageGiB"`
+	// StorageContainers are the storage containers data disks are explicitly placed in.
+	StorageContainers []StorageContainerCapacity `json:"storageContainers,omitempty"`
+	GPUs              []GPUCapacity              `json:"gpus,omitempty"`
+}
+
+// ResourceCapacity compares the amount of a resource requested to the amount available.
+type ResourceCapacity struct {
+	// Requested is the amount used by all the machines once rolled out, with worker node groups
+	// scaled to their autoscaling maxCount.
+	Requested int64 `json:"requested"`
+	// Surge is the amount used by the extra machines rolled out at once during an upgrade.
+	Surge int64 `json:"surge"`
+	// Available is the amount not used by VMs outside the cluster, nil when Prism Central doesn't report it.
+	Available *int64 `json:"available,omitempty"`
+}
+
+// Fits returns true if the requested amount, including the upgrade surge, is available.
+// It returns true when the available amount is unknown.
+func (r ResourceCapacity) Fits() bool {
+	return r.Available == nil || r.Requested+r.Surge <= *r.Available
+}
+
+// GPUCapacity is the capacity of a GPU model in a given mode, passthrough or vGPU.
+type GPUCapacity struct {
+	Model string `json:"model"`
+	Mode  string `json:"mode"`
+	ResourceCapacity
+}
+
+// StorageContainerCapacity is the storage in GiB requested from and available in a storage container.
+type StorageContainerCapacity struct {
+	Name string `json:"name,omitempty"`
+	UUID string `json:"uuid"`
+	ResourceCapacity
+}
+
+// Fits returns true if the memory, storage and GPUs requested, including the upgrade surge, are available.
+// vCPUs are commonly overcommitted, so they are not taken into account.
+func (c ClusterCapacity) Fits() bool {
+	if !c.MemoryMiB.Fits() || !c.StorageGiB.Fits() {
+		return false
+	}
+	for _, container := range c.StorageContainers {
+		if !container.Fits() {
+			return false
+		}
+	}
+	for _, gpu := range c.GPUs {
+		if !gpu.Fits() {
+			return false
+		}
+	}
+	return true
+}
+
+// Fits returns true if all the Prism Element clusters have enough capacity for the cluster machines.
+func (r *CapacityReport) Fits() bool {
+	for _, c := range r.Clusters {
+		if !c.Fits() {
+			return false
+		}
+	}
+	return true
+}
+
+// PlanCapacity builds the capacity report of a cluster spec with the credentials from the environment.
+// It only reads from Prism Central.
+func (v *Validator) PlanCapacity(ctx context.Context, spec *cluster.Spec) (*CapacityReport, error) {
+	if err := setupEnvVars(spec.NutanixDatacenter); err != nil {
+		return nil, err
+	}
+
+	client, err := v.clientCache.GetNutanixClient(spec.NutanixDatacenter, GetCredsFromEnv())
+	if err != nil {
+		return nil, err
+	}
+
+	return v.planCapacity(ctx, client, spec)
+}
+
+// machinePlacement is the number of machines of a group placed in a Prism Element cluster.
+type machinePlacement struct {
+	group *machineGroup
+	count int
+	surge int
+}
+
+// planCapacity accounts the machines of every machine group to the Prism Element clusters they are placed in.
+// The resources used by the existing VMs of the cluster are counted as available since the machines
+// are replaced during upgrades, which makes the report valid for both create and upgrade.
+func (v *Validator) planCapacity(ctx context.Context, client Client, spec *cluster.Spec) (*CapacityReport, error) {
+	placements := map[string][]machinePlacement{}
+	names := map[string]string{}
+	type resolvedCluster struct {
+		identifier anywherev1.NutanixResourceIdentifier
+		uuid       string
+	}
+	resolved := []resolvedCluster{}
+	resolve := func(identifier anywherev1.NutanixResourceIdentifier) (string, error) {
+		for _, r := range resolved {
+			if sameResourceIdentifier(r.identifier, identifier) {
+				return r.uuid, nil
+			}
+		}
+		uuid, err := getClusterUUID(ctx, client, identifier)
+		if err != nil {
+			return "", err
+		}
+		resolved = append(resolved, resolvedCluster{identifier: identifier, uuid: uuid})
+		if identifier.Type == anywherev1.NutanixIdentifierName {
+			names[uuid] = *identifier.Name
+		}
+		return uuid, nil
+	}
+
+	groups := machineGroups(spec)
+	for i := range groups {
+		group := &groups[i]
+		if group.machineConfig == nil || group.count == 0 {
+			continue
+		}
+
+		failureDomains := groupFailureDomains(spec.NutanixDatacenter, group)
+		if len(failureDomains) == 0 {
+			uuid, err := resolve(group.machineConfig.Spec.Cluster)
+			if err != nil {
+				return nil, err
+			}
+			placements[uuid] = append(placements[uuid], machinePlacement{group: group, count: group.count, surge: group.surge()})
+			continue
+		}
+
+		// CAPX picks the failure domain of the machines rolled out during an upgrade,
+		// so the surge is accounted to every failure domain of the group.
+		for j, count := range spreadOverFailureDomains(group.count, len(failureDomains)) {
+			uuid, err := resolve(failureDomains[j].Cluster)
+			if err != nil {
+				return nil, err
+			}
+			placements[uuid] = addMachinePlacement(placements[uuid], machinePlacement{group: group, count: count, surge: group.surge()})
+		}
+	}
+
+	hosts, err := client.ListAllHost(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hosts: %v", err)
+	}
+
+	vms, err := client.ListAllVM(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VMs: %v", err)
+	}
+
+	gpuDeviceIDToMode, gpuNameToMode, err := v.getGPUModeMapping(hosts.Entities)
+	if err != nil {
+		return nil, err
+	}
+	getGPUMode := createGetGpuModeFunc(gpuDeviceIDToMode, gpuNameToMode)
+
+	uuids := make([]string, 0, len(placements))
+	for uuid := range placements {
+		uuids = append(uuids, uuid)
+	}
+	sort.Strings(uuids)
+
+	report := &CapacityReport{}
+	for _, uuid := range uuids {
+		c := ClusterCapacity{Name: names[uuid], UUID: uuid}
+		gpus := map[string]*GPUCapacity{}
+		gpuRequests := []anywherev1.NutanixGPUIdentifier{}
+		storageContainers := map[string]*StorageContainerCapacity{}
+		storageContainerUUIDs := []string{}
+		var containers []*v3.StorageContainerReference
+
+		for _, p := range placements[uuid] {
+			c.MachineGroups = append(c.MachineGroups, p.group.name)
+			mcSpec := p.group.machineConfig.Spec
+			vcpus := int64(mcSpec.VCPUSockets) * int64(mcSpec.VCPUsPerSocket)
+			memoryMiB := mcSpec.MemorySize.Value() >> 20
+			diskGiB := mcSpec.SystemDiskSize.Value() >> 30
+			for _, disk := range mcSpec.DataDisks {
+				diskGiB += disk.Size.Value() >> 30
+			}
+
+			c.VCPUs.Requested += vcpus * int64(p.count)
+			c.VCPUs.Surge += vcpus * int64(p.surge)
+			c.MemoryMiB.Requested += memoryMiB * int64(p.count)
+			c.MemoryMiB.Surge += memoryMiB * int64(p.surge)
+			c.StorageGiB.Requested += diskGiB * int64(p.count)
+			c.StorageGiB.Surge += diskGiB * int64(p.surge)
+
+			for _, disk := range mcSpec.DataDisks {
+				if disk.StorageContainer == nil {
+					continue
+				}
+				if containers == nil {
+					if containers, err = client.ListAllStorageContainer(ctx, uuid); err != nil {
+						return nil, fmt.Errorf("failed to list storage containers: %v", err)
+					}
+				}
+				container := findStorageContainer(containers, *disk.StorageContainer)
+				if container == nil {
+					return nil, fmt.Errorf("storage container %s of machine config %s not found in cluster %s", resourceIdentifierString(*disk.StorageContainer), p.group.machineConfig.Name, uuid)
+				}
+				if _, ok := storageContainers[container.UUID]; !ok {
+					storageContainers[container.UUID] = &StorageContainerCapacity{Name: container.Name, UUID: container.UUID}
+					storageContainerUUIDs = append(storageContainerUUIDs, container.UUID)
+				}
+				sizeGiB := disk.Size.Value() >> 30
+				storageContainers[container.UUID].Requested += sizeGiB * int64(p.count)
+				storageContainers[container.UUID].Surge += sizeGiB * int64(p.surge)
+			}
+
+			for _, gpu := range mcSpec.GPUs {
+				key := gpuModel(gpu)
+				if _, ok := gpus[key]; !ok {
+					gpus[key] = &GPUCapacity{Model: key, Mode: getGPUMode(gpu)}
+					gpuRequests = append(gpuRequests, gpu)
+				}
+				gpus[key].Requested += int64(p.count)
+				gpus[key].Surge += int64(p.surge)
+			}
+		}
+
+		vcpus, memoryMiB := hostsCapacity(hosts.Entities, uuid)
+		usedVCPUs, usedMemoryMiB := vmsUsage(vms.Entities, uuid, spec.Cluster.Name)
+		c.VCPUs.Available = int64Ptr(vcpus - usedVCPUs)
+		c.MemoryMiB.Available = int64Ptr(memoryMiB - usedMemoryMiB)
+
+		freeBytes, err := client.ListAllStorageContainerFreeBytes(ctx, uuid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get free capacity of storage containers: %v", err)
+		}
+		clusterDiskBytes := clusterVMsDiskBytes(vms.Entities, uuid, spec.Cluster.Name)
+		if free, ok := storagePoolFreeBytes(freeBytes); ok {
+			var clusterBytes int64
+			for _, bytes := range clusterDiskBytes {
+				clusterBytes += bytes
+			}
+			c.StorageGiB.Available = int64Ptr((free + clusterBytes) >> 30)
+		}
+		for _, containerUUID := range storageContainerUUIDs {
+			container := storageContainers[containerUUID]
+			if free, ok := freeBytes[containerUUID]; ok {
+				container.Available = int64Ptr((free + clusterDiskBytes[containerUUID]) >> 30)
+			}
+			c.StorageContainers = append(c.StorageContainers, *container)
+		}
+
+		for _, requested := range gpuRequests {
+			gpu := gpus[gpuModel(requested)]
+			gpu.Available = int64Ptr(freeGPUs(hosts.Entities, vms.Entities, uuid, spec.Cluster.Name, requested))
+			c.GPUs = append(c.GPUs, *gpu)
+		}
+
+		report.Clusters = append(report.Clusters, c)
+	}
+
+	return report, nil
+}
+
+// addMachinePlacement merges the placements of a group spread over failure domains of the same Prism Element cluster.
+func addMachinePlacement(placements []machinePlacement, p machinePlacement) []machinePlacement {
+	for i := range placements {
+		if placements[i].group == p.group {
+			placements[i].count += p.count
+			return placements
+		}
+	}
+	return append(placements, p)
+}
+
+// groupFailureDomains returns the failure domains the machines of a group are spread over.
+// The control plane is spread over all the failure domains of the datacenter and worker node
+// groups over the failure domains listing them.
+func groupFailureDomains(datacenter *anywherev1.NutanixDatacenterConfig, group *machineGroup) []anywherev1.NutanixDatacenterFailureDomain {
+	if datacenter == nil {
+		return nil
+	}
+	if group.worker {
+		return getFailureDomainsForWorkerNodeGroup(datacenter.Spec.FailureDomains, group.workerNodeGroup)
+	}
+	if group.name == "control plane" {
+		return datacenter.Spec.FailureDomains
+	}
+	return nil
+}
+
+// spreadOverFailureDomains splits machines evenly over failure domains, the first one getting the remainder.
+func spreadOverFailureDomains(count, failureDomains int) []int {
+	counts := make([]int, failureDomains)
+	for i := range counts {
+		counts[i] = count / failureDomains
+	}
+	counts[0] += count % failureDomains
+	return counts
+}
+
+// hostsCapacity returns the number of CPU cores and the memory of the hosts of a Prism Element cluster.
+func hostsCapacity(hosts []*v3.HostResponse, clusterUUID string) (cores, memoryMiB int64) {
+	for _, host := range hosts {
+		if host.Status == nil || host.Status.Resources == nil || host.Status.ClusterReference == nil ||
+			host.Status.ClusterReference.UUID != clusterUUID {
+			continue
+		}
+		if host.Status.Resources.NumCPUCores != nil {
+			cores += *host.Status.Resources.NumCPUCores
+		}
+		if host.Status.Resources.MemoryVapacityMib != nil {
+			memoryMiB += *host.Status.Resources.MemoryVapacityMib
+		}
+	}
+	return cores, memoryMiB
+}
+
+// vmsUsage returns the vCPUs and memory of the powered on VMs of a Prism Element cluster
+// that don't belong to the EKS Anywhere cluster.
+func vmsUsage(vms []*v3.VMIntentResource, clusterUUID, clusterName string) (vcpus, memoryMiB int64) {
+	for _, vm := range vms {
+		if !isVMInCluster(vm, clusterUUID) || isClusterVM(vm, clusterName) {
+			continue
+		}
+		resources := vm.Status.Resources
+		if resources.PowerState == nil || *resources.PowerState != "ON" {
+			continue
+		}
+		if resources.NumSockets != nil && resources.NumVcpusPerSocket != nil {
+			vcpus += *resources.NumSockets * *resources.NumVcpusPerSocket
+		}
+		if resources.MemorySizeMib != nil {
+			memoryMiB += *resources.MemorySizeMib
+		}
+	}
+	return vcpus, memoryMiB
+}
+
+// storagePoolFreeBytes returns the free capacity of the storage pool of a Prism Element cluster. Storage containers
+// are thin provisioned from the pool, so it's the largest free capacity of the containers, smaller ones being
+// limited by their advertised capacity.
+func storagePoolFreeBytes(containersFreeBytes map[string]int64) (int64, bool) {
+	free, ok := int64(0), false
+	for _, bytes := range containersFreeBytes {
+		if !ok || bytes > free {
+			free, ok = bytes, true
+		}
+	}
+	return free, ok
+}
+
+// clusterVMsDiskBytes returns the size of the disks of the VMs of the EKS Anywhere cluster in a Prism Element
+// cluster by storage container UUID. The disks are replaced with the machines during upgrades, so their
+// storage is counted as available like the vCPUs and memory of the VMs.
+func clusterVMsDiskBytes(vms []*v3.VMIntentResource, clusterUUID, clusterName string) map[string]int64 {
+	bytes := map[string]int64{}
+	for _, vm := range vms {
+		if !isVMInCluster(vm, clusterUUID) || !isClusterVM(vm, clusterName) {
+			continue
+		}
+		for _, disk := range vm.Status.Resources.DiskList {
+			if disk == nil || disk.DiskSizeBytes == nil || disk.DeviceProperties == nil ||
+				disk.DeviceProperties.DeviceType == nil || *disk.DeviceProperties.DeviceType != "DISK" {
+				continue
+			}
+			containerUUID := ""
+			if disk.StorageConfig != nil && disk.StorageConfig.StorageContainerReference != nil {
+				containerUUID = disk.StorageConfig.StorageContainerReference.UUID
+			}
+			bytes[containerUUID] += *disk.DiskSizeBytes
+		}
+	}
+	return bytes
+}
+
+// freeGPUs returns the number of GPUs of a Prism Element cluster matching the requested GPU
+// that can be assigned, including the GPUs assigned to the VMs of the EKS Anywhere cluster.
+func freeGPUs(hosts []*v3.HostResponse, vms []*v3.VMIntentResource, clusterUUID, clusterName string, requested anywherev1.NutanixGPUIdentifier) int64 {
+	var free int64
+	for _, host := range hosts {
+		if host.Status == nil || host.Status.Resources == nil || host.Status.ClusterReference == nil ||
+			host.Status.ClusterReference.UUID != clusterUUID {
+			continue
+		}
+		for _, gpu := range host.Status.Resources.GPUList {
+			if gpu != nil && gpu.DeviceID != nil && isRequestedGPUAssignable(*gpu, requested) {
+				free++
+			}
+		}
+	}
+
+	for _, vm := range vms {
+		if !isVMInCluster(vm, clusterUUID) || !isClusterVM(vm, clusterName) {
+			continue
+		}
+		for _, gpu := range vm.Status.Resources.GpuList {
+			if gpu == nil {
+				continue
+			}
+			if requested.Type == anywherev1.NutanixGPUIdentifierDeviceID && gpu.DeviceID != nil && *gpu.DeviceID == *requested.DeviceID ||
+				requested.Type == anywherev1.NutanixGPUIdentifierName && gpu.Name != nil && *gpu.Name == requested.Name {
+				free++
+			}
+		}
+	}
+
+	return free
+}
+
+func isVMInCluster(vm *v3.VMIntentResource, clusterUUID string) bool {
+	return vm != nil && vm.Status != nil && vm.Status.Resources != nil && vm.Status.ClusterReference != nil &&
+		vm.Status.ClusterReference.UUID != nil && *vm.Status.ClusterReference.UUID == clusterUUID
+}
+
+// isClusterVM returns true if the VM is a machine of the EKS Anywhere cluster, from the category CAPX assigns to it.
+func isClusterVM(vm *v3.VMIntentResource, clusterName string) bool {
+	return inClusterCategory(vm.Metadata, clusterName)
+}
+
+func gpuModel(gpu anywherev1.NutanixGPUIdentifier) string {
+	if gpu.Type == anywherev1.NutanixGPUIdentifierDeviceID {
+		return fmt.Sprintf("device ID %d", *gpu.DeviceID)
+	}
+	return gpu.Name
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+// Write writes the report as a table per Prism Element cluster.
+func (r *CapacityReport) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	for i, c := range r.Clusters {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+		name := c.UUID
+		if c.Name != "" {
+			name = fmt.Sprintf("%s (%s)", c.Name, c.UUID)
+		}
+		fmt.Fprintf(tw, "Prism Element cluster %s: %s\n", name, strings.Join(c.MachineGroups, ", "))
+		fmt.Fprintln(tw, "RESOURCE\tREQUESTED\tUPGRADE SURGE\tAVAILABLE\tSTATUS")
+		writeResourceCapacity(tw, "vCPU", c.VCPUs, "overcommitted")
+		writeResourceCapacity(tw, "Memory (MiB)", c.MemoryMiB, "insufficient")
+		writeResourceCapacity(tw, "Storage (GiB)", c.StorageGiB, "insufficient")
+		for _, container := range c.StorageContainers {
+			name := container.Name
+			if name == "" {
+				name = container.UUID
+			}
+			writeResourceCapacity(tw, fmt.Sprintf("Storage container %s (GiB)", name), container.ResourceCapacity, "insufficient")
+		}
+		for _, gpu := range c.GPUs {
+			writeResourceCapacity(tw, fmt.Sprintf("GPU %s (%s)", gpu.Model, gpu.Mode), gpu.ResourceCapacity, "insufficient")
+		}
+	}
+
+	return tw.Flush()
+}
+
+func writeResourceCapacity(w io.Writer, name string, r ResourceCapacity, notFitting string) {
+	available, status := "unknown", "unknown"
+	if r.Available != nil {
+		available = fmt.Sprintf("%d", *r.Available)
+		status = "ok"
+		if !r.Fits() {
+			status = notFitting
+		}
+	}
+	fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\n", name, r.Requested, r.Surge, available, status)
+}
@@ -0,0 +1,346 @@
+package nutanix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	capxv1beta1 "github.com/nutanix-cloud-native/cluster-api-provider-nutanix/api/v1beta1"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	mocknutanix "github.com/aws/eks-anywhere/pkg/providers/nutanix/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const (
+	capacityTestClusterUUID  = "a15f6966-bfc7-4d1e-8575-224096fc1cdb"
+	capacityTestCluster2UUID = "4d69ca7d-022f-49d1-a454-74535993bda4"
+)
+
+type capacityTest struct {
+	*WithT
+	ctx       context.Context
+	client    *mocknutanix.MockClient
+	validator *Validator
+	spec      *cluster.Spec
+}
+
+func newCapacityTest(t *testing.T) *capacityTest {
+	machineConfig := func(name string, vcpus int32, memory, disk string) *anywherev1.NutanixMachineConfig {
+		return &anywherev1.NutanixMachineConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: anywherev1.NutanixMachineConfigSpec{
+				Cluster:        anywherev1.NutanixResourceIdentifier{Type: anywherev1.NutanixIdentifierName, Name: ptr.String("prism-cluster")},
+				VCPUSockets:    1,
+				VCPUsPerSocket: vcpus,
+				MemorySize:     resource.MustParse(memory),
+				SystemDiskSize: resource.MustParse(disk),
+			},
+		}
+	}
+
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test"
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+		s.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef = &anywherev1.Ref{Kind: anywherev1.NutanixMachineConfigKind, Name: "cp"}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{{
+			Name:            "gpu",
+			Count:           ptr.Int(2),
+			MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.NutanixMachineConfigKind, Name: "gpu"},
+		}}
+		s.NutanixDatacenter = &anywherev1.NutanixDatacenterConfig{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+		s.NutanixMachineConfigs = map[string]*anywherev1.NutanixMachineConfig{
+			"cp":  machineConfig("cp", 2, "4Gi", "40Gi"),
+			"gpu": machineConfig("gpu", 8, "16Gi", "100Gi"),
+		}
+		s.NutanixMachineConfigs["gpu"].Spec.GPUs = []anywherev1.NutanixGPUIdentifier{{Type: anywherev1.NutanixGPUIdentifierName, Name: "Ampere 40"}}
+		s.NutanixMachineConfigs["gpu"].Spec.DataDisks = []anywherev1.NutanixDataDisk{{Size: resource.MustParse("50Gi")}}
+	})
+
+	client := mocknutanix.NewMockClient(gomock.NewController(t))
+	client.EXPECT().ListAllCluster(gomock.Any(), "").Return(fakeClusterListForFreeGPUTest(), nil).AnyTimes()
+
+	return &capacityTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		client:    client,
+		validator: NewValidator(&ClientCache{}, nil, nil),
+		spec:      spec,
+	}
+}
+
+func capacityTestHost(clusterUUID string, cores, memoryMiB int64, gpus ...*v3.GPU) *v3.HostResponse {
+	return &v3.HostResponse{
+		Status: &v3.HostStatus{
+			ClusterReference: &v3.ReferenceValues{UUID: clusterUUID},
+			Resources: &v3.HostResources{
+				NumCPUCores:       ptr.Int64(cores),
+				MemoryVapacityMib: ptr.Int64(memoryMiB),
+				GPUList:           gpus,
+			},
+		},
+	}
+}
+
+func capacityTestGPU(assignable bool) *v3.GPU {
+	return &v3.GPU{Name: "Ampere 40", DeviceID: ptr.Int64(8757), Mode: "PASSTHROUGH_COMPUTE", Assignable: assignable}
+}
+
+func capacityTestVMWithDisk(name, clusterUUID, containerUUID string, vcpus, memoryMiB, diskGiB int64) *v3.VMIntentResource {
+	vm := capacityTestVM(name, clusterUUID, vcpus, memoryMiB)
+	vm.Status.Resources.DiskList = []*v3.VMDisk{
+		{
+			DeviceProperties: &v3.VMDiskDeviceProperties{DeviceType: ptr.String("DISK")},
+			DiskSizeBytes:    ptr.Int64(diskGiB << 30),
+			StorageConfig: &v3.VMStorageConfig{
+				StorageContainerReference: &v3.StorageContainerReference{UUID: containerUUID},
+			},
+		},
+		{
+			DeviceProperties: &v3.VMDiskDeviceProperties{DeviceType: ptr.String("CDROM")},
+			DiskSizeBytes:    ptr.Int64(1 << 30),
+		},
+	}
+	return vm
+}
+
+func capacityTestVM(name, clusterUUID string, vcpus, memoryMiB int64, gpus ...string) *v3.VMIntentResource {
+	vm := &v3.VMIntentResource{
+		Status: &v3.VMDefStatus{
+			Name:             ptr.String(name),
+			ClusterReference: &v3.Reference{UUID: ptr.String(clusterUUID)},
+			Resources: &v3.VMResourcesDefStatus{
+				PowerState:        ptr.String("ON"),
+				NumSockets:        ptr.Int64(1),
+				NumVcpusPerSocket: ptr.Int64(vcpus),
+				MemorySizeMib:     ptr.Int64(memoryMiB),
+			},
+		},
+	}
+	for _, gpu := range gpus {
+		vm.Status.Resources.GpuList = append(vm.Status.Resources.GpuList, &v3.VMGpuOutputStatus{Name: ptr.String(gpu)})
+	}
+	return vm
+}
+
+func TestPlanCapacity(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.client.EXPECT().ListAllHost(tt.ctx).Return(&v3.HostListResponse{
+		Entities: []*v3.HostResponse{
+			capacityTestHost(capacityTestClusterUUID, 32, 262144, capacityTestGPU(true), capacityTestGPU(false)),
+			capacityTestHost(capacityTestClusterUUID, 32, 262144, capacityTestGPU(true)),
+			capacityTestHost(capacityTestCluster2UUID, 64, 524288, capacityTestGPU(true)),
+		},
+	}, nil)
+	tt.client.EXPECT().ListAllVM(tt.ctx, "").Return(&v3.VMListIntentResponse{
+		Entities: []*v3.VMIntentResource{
+			capacityTestVMWithDisk("other-vm", capacityTestClusterUUID, "container-1", 16, 65536, 500),
+			inCAPXCategory(capacityTestVMWithDisk("test-cp-abcde", capacityTestClusterUUID, "container-1", 2, 4096, 40), "test"),
+			inCAPXCategory(capacityTestVM("test-gpu-abcde", capacityTestClusterUUID, 8, 16384, "Ampere 40"), "test"),
+			capacityTestVM("other-cluster-vm", capacityTestCluster2UUID, 4, 8192),
+		},
+	}, nil)
+	tt.client.EXPECT().ListAllStorageContainerFreeBytes(tt.ctx, capacityTestClusterUUID).Return(map[string]int64{
+		"container-1": 1000 << 30,
+		"container-2": 200 << 30,
+	}, nil)
+
+	report, err := tt.validator.planCapacity(tt.ctx, tt.client, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Clusters).To(Equal([]ClusterCapacity{{
+		Name:          "prism-cluster",
+		UUID:          capacityTestClusterUUID,
+		MachineGroups: []string{"control plane", "worker node group gpu"},
+		VCPUs:         ResourceCapacity{Requested: 22, Surge: 10, Available: ptr.Int64(48)},
+		MemoryMiB:     ResourceCapacity{Requested: 45056, Surge: 20480, Available: ptr.Int64(458752)},
+		StorageGiB:    ResourceCapacity{Requested: 420, Surge: 190, Available: ptr.Int64(1040)},
+		GPUs: []GPUCapacity{{
+			Model:            "Ampere 40",
+			Mode:             "PASSTHROUGH_COMPUTE",
+			ResourceCapacity: ResourceCapacity{Requested: 2, Surge: 1, Available: ptr.Int64(3)},
+		}},
+	}}))
+	tt.Expect(report.Fits()).To(BeTrue())
+}
+
+func TestPlanCapacityGPUSurgeNotAvailable(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.client.EXPECT().ListAllHost(tt.ctx).Return(&v3.HostListResponse{
+		Entities: []*v3.HostResponse{
+			capacityTestHost(capacityTestClusterUUID, 32, 262144, capacityTestGPU(false), capacityTestGPU(false)),
+		},
+	}, nil)
+	tt.client.EXPECT().ListAllVM(tt.ctx, "").Return(&v3.VMListIntentResponse{
+		Entities: []*v3.VMIntentResource{
+			inCAPXCategory(capacityTestVM("test-gpu-abcde", capacityTestClusterUUID, 8, 16384, "Ampere 40"), "test"),
+			inCAPXCategory(capacityTestVM("test-gpu-fghij", capacityTestClusterUUID, 8, 16384, "Ampere 40"), "test"),
+		},
+	}, nil)
+	tt.client.EXPECT().ListAllStorageContainerFreeBytes(tt.ctx, capacityTestClusterUUID).Return(map[string]int64{}, nil)
+
+	report, err := tt.validator.planCapacity(tt.ctx, tt.client, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Clusters[0].GPUs[0].ResourceCapacity).To(Equal(ResourceCapacity{Requested: 2, Surge: 1, Available: ptr.Int64(2)}))
+	tt.Expect(report.Fits()).To(BeFalse())
+
+	out := &bytes.Buffer{}
+	tt.Expect(report.Write(out)).To(Succeed())
+	tt.Expect(out.String()).To(Equal(`Prism Element cluster prism-cluster (a15f6966-bfc7-4d1e-8575-224096fc1cdb): control plane, worker node group gpu
+RESOURCE                              REQUESTED   UPGRADE SURGE   AVAILABLE   STATUS
+vCPU                                  22          10              32          ok
+Memory (MiB)                          45056       20480           262144      ok
+Storage (GiB)                         420         190             unknown     unknown
+GPU Ampere 40 (PASSTHROUGH_COMPUTE)   2           1               2           insufficient
+`))
+}
+
+func TestPlanCapacityFailureDomains(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
+	tt.spec.NutanixDatacenter.Spec.FailureDomains = []anywherev1.NutanixDatacenterFailureDomain{
+		{
+			Name:                "fd-1",
+			Cluster:             anywherev1.NutanixResourceIdentifier{Type: anywherev1.NutanixIdentifierName, Name: ptr.String("prism-cluster")},
+			WorkerMachineGroups: []string{"gpu"},
+		},
+		{
+			Name:                "fd-2",
+			Cluster:             anywherev1.NutanixResourceIdentifier{Type: anywherev1.NutanixIdentifierUUID, UUID: ptr.String(capacityTestCluster2UUID)},
+			WorkerMachineGroups: []string{"gpu"},
+		},
+	}
+	tt.client.EXPECT().ListAllHost(tt.ctx).Return(&v3.HostListResponse{}, nil)
+	tt.client.EXPECT().ListAllVM(tt.ctx, "").Return(&v3.VMListIntentResponse{}, nil)
+	tt.client.EXPECT().ListAllStorageContainerFreeBytes(tt.ctx, gomock.Any()).Return(map[string]int64{}, nil).Times(2)
+
+	report, err := tt.validator.planCapacity(tt.ctx, tt.client, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Clusters).To(HaveLen(2))
+
+	pe2 := report.Clusters[0]
+	tt.Expect(pe2.UUID).To(Equal(capacityTestCluster2UUID))
+	tt.Expect(pe2.Name).To(BeEmpty())
+	tt.Expect(pe2.MachineGroups).To(Equal([]string{"control plane", "worker node group gpu"}))
+	tt.Expect(pe2.GPUs[0].ResourceCapacity).To(Equal(ResourceCapacity{Requested: 1, Surge: 1, Available: ptr.Int64(0)}))
+
+	pe1 := report.Clusters[1]
+	tt.Expect(pe1.UUID).To(Equal(capacityTestClusterUUID))
+	tt.Expect(pe1.MachineGroups).To(Equal([]string{"control plane", "worker node group gpu"}))
+	tt.Expect(pe1.GPUs[0].ResourceCapacity).To(Equal(ResourceCapacity{Requested: 2, Surge: 1, Available: ptr.Int64(0)}))
+}
+
+func TestPlanCapacityListHostsError(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.client.EXPECT().ListAllHost(tt.ctx).Return(nil, errors.New("error"))
+
+	_, err := tt.validator.planCapacity(tt.ctx, tt.client, tt.spec)
+	tt.Expect(err).To(MatchError("failed to list hosts: error"))
+}
+
+func TestPlanCapacityStorageContainer(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.spec.NutanixMachineConfigs["gpu"].Spec.DataDisks[0].StorageContainer = &anywherev1.NutanixResourceIdentifier{
+		Type: anywherev1.NutanixIdentifierName,
+		Name: ptr.String("data"),
+	}
+	tt.client.EXPECT().ListAllHost(tt.ctx).Return(&v3.HostListResponse{
+		Entities: []*v3.HostResponse{
+			capacityTestHost(capacityTestClusterUUID, 32, 262144, capacityTestGPU(true), capacityTestGPU(true), capacityTestGPU(true)),
+		},
+	}, nil)
+	tt.client.EXPECT().ListAllVM(tt.ctx, "").Return(&v3.VMListIntentResponse{
+		Entities: []*v3.VMIntentResource{
+			inCAPXCategory(capacityTestVMWithDisk("test-gpu-abcde", capacityTestClusterUUID, "container-2", 8, 16384, 50), "test"),
+		},
+	}, nil)
+	tt.client.EXPECT().ListAllStorageContainer(tt.ctx, capacityTestClusterUUID).Return([]*v3.StorageContainerReference{
+		{Kind: "storage_container", UUID: "container-1", Name: "default"},
+		{Kind: "storage_container", UUID: "container-2", Name: "data"},
+	}, nil)
+	tt.client.EXPECT().ListAllStorageContainerFreeBytes(tt.ctx, capacityTestClusterUUID).Return(map[string]int64{
+		"container-1": 1000 << 30,
+		"container-2": 80 << 30,
+	}, nil)
+
+	report, err := tt.validator.planCapacity(tt.ctx, tt.client, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Clusters[0].StorageGiB).To(Equal(ResourceCapacity{Requested: 420, Surge: 190, Available: ptr.Int64(1050)}))
+	tt.Expect(report.Clusters[0].StorageContainers).To(Equal([]StorageContainerCapacity{{
+		Name:             "data",
+		UUID:             "container-2",
+		ResourceCapacity: ResourceCapacity{Requested: 100, Surge: 50, Available: ptr.Int64(130)},
+	}}))
+	tt.Expect(report.Fits()).To(BeFalse())
+
+	out := &bytes.Buffer{}
+	tt.Expect(report.Write(out)).To(Succeed())
+	tt.Expect(out.String()).To(ContainSubstring("Storage (GiB)                         420         190             1050        ok\n"))
+	tt.Expect(out.String()).To(ContainSubstring("Storage container data (GiB)          100         50              130         insufficient\n"))
+}
+
+func TestPlanCapacityStorageContainerNotFound(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.spec.NutanixMachineConfigs["gpu"].Spec.DataDisks[0].StorageContainer = &anywherev1.NutanixResourceIdentifier{
+		Type: anywherev1.NutanixIdentifierName,
+		Name: ptr.String("data"),
+	}
+	tt.client.EXPECT().ListAllHost(tt.ctx).Return(&v3.HostListResponse{}, nil)
+	tt.client.EXPECT().ListAllVM(tt.ctx, "").Return(&v3.VMListIntentResponse{}, nil)
+	tt.client.EXPECT().ListAllStorageContainer(tt.ctx, capacityTestClusterUUID).Return([]*v3.StorageContainerReference{}, nil)
+
+	_, err := tt.validator.planCapacity(tt.ctx, tt.client, tt.spec)
+	tt.Expect(err).To(MatchError("storage container data of machine config gpu not found in cluster " + capacityTestClusterUUID))
+}
+
+func TestPlanCapacityStorageFreeBytesError(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.client.EXPECT().ListAllHost(tt.ctx).Return(&v3.HostListResponse{}, nil)
+	tt.client.EXPECT().ListAllVM(tt.ctx, "").Return(&v3.VMListIntentResponse{}, nil)
+	tt.client.EXPECT().ListAllStorageContainerFreeBytes(tt.ctx, capacityTestClusterUUID).Return(nil, errors.New("error"))
+
+	_, err := tt.validator.planCapacity(tt.ctx, tt.client, tt.spec)
+	tt.Expect(err).To(MatchError("failed to get free capacity of storage containers: error"))
+}
+
+func TestIsClusterVM(t *testing.T) {
+	tests := []struct {
+		name string
+		vm   *v3.VMIntentResource
+		want bool
+	}{
+		{
+			name: "cluster category",
+			vm:   inCAPXCategory(capacityTestVM("test-md-0-abcde", capacityTestClusterUUID, 2, 4096), "test"),
+			want: true,
+		},
+		{
+			name: "obsolete cluster category",
+			vm: &v3.VMIntentResource{
+				Metadata: &v3.Metadata{Categories: map[string]string{capxv1beta1.ObsoleteDefaultCAPICategoryPrefix + "test": capxv1beta1.ObsoleteDefaultCAPICategoryOwnedValue}},
+			},
+			want: true,
+		},
+		{
+			name: "cluster sharing the name prefix",
+			vm:   inCAPXCategory(capacityTestVM("test-2-md-0-abcde", capacityTestClusterUUID, 2, 4096), "test-2"),
+		},
+		{
+			name: "no category",
+			vm:   capacityTestVM("test-md-0-abcde", capacityTestClusterUUID, 2, 4096),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(isClusterVM(tc.vm, "test")).To(Equal(tc.want))
+		})
+	}
+}
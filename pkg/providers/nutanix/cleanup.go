@@ -0,0 +1,102 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+
+	capxv1beta1 "github.com/nutanix-cloud-native/cluster-api-provider-nutanix/api/v1beta1"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cleanup"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+const vmResourceKind = "VirtualMachine"
+
+// CleanupDiscoverer finds the VMs of a cluster in Prism Central, named after its machines and
+// assigned the cluster category by CAPX.
+type CleanupDiscoverer struct {
+	clientCache *ClientCache
+	datacenter  *anywherev1.NutanixDatacenterConfig
+	machines    *cleanup.MachineNames
+}
+
+// NewCleanupDiscoverer builds a CleanupDiscoverer for the Prism Central and machines of a cluster spec.
+func NewCleanupDiscoverer(clientCache *ClientCache, spec *cluster.Spec) *CleanupDiscoverer {
+	return &CleanupDiscoverer{
+		clientCache: clientCache,
+		datacenter:  spec.NutanixDatacenter,
+		machines:    cleanup.NewMachineNames(spec.Cluster),
+	}
+}
+
+// Name returns the infrastructure the CleanupDiscoverer looks in.
+func (d *CleanupDiscoverer) Name() string {
+	return "nutanix"
+}
+
+// Discover returns the VMs named after the machines of the cluster which belong to its CAPX category.
+func (d *CleanupDiscoverer) Discover(ctx context.Context, clusterName string) ([]cleanup.Resource, error) {
+	client, err := d.client()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.ListAllVM(ctx, fmt.Sprintf("vm_name==%s-.*", clusterName))
+	if err != nil {
+		return nil, fmt.Errorf("listing VMs: %v", err)
+	}
+
+	resources := []cleanup.Resource{}
+	for _, vm := range response.Entities {
+		if vm.Spec == nil || vm.Spec.Name == nil || vm.Metadata == nil || vm.Metadata.UUID == nil {
+			continue
+		}
+		// The filter matches the machines of the clusters whose name starts with the cluster name too.
+		if !d.machines.Match(*vm.Spec.Name) || !inClusterCategory(vm.Metadata, clusterName) {
+			continue
+		}
+		resources = append(resources, cleanup.Resource{Kind: vmResourceKind, Name: *vm.Spec.Name, ID: *vm.Metadata.UUID})
+	}
+
+	return resources, nil
+}
+
+// inClusterCategory returns true if the VM has the category CAPX assigns to the VMs of a cluster,
+// or the one older CAPX versions assigned.
+func inClusterCategory(metadata *v3.Metadata, clusterName string) bool {
+	if metadata == nil {
+		return false
+	}
+	if metadata.Categories[capxv1beta1.DefaultCAPICategoryKeyForName] == clusterName {
+		return true
+	}
+	return metadata.Categories[capxv1beta1.ObsoleteDefaultCAPICategoryPrefix+clusterName] == capxv1beta1.ObsoleteDefaultCAPICategoryOwnedValue
+}
+
+// Delete deletes a VM.
+func (d *CleanupDiscoverer) Delete(ctx context.Context, resource cleanup.Resource) error {
+	if resource.Kind != vmResourceKind {
+		return fmt.Errorf("unsupported nutanix resource kind %s", resource.Kind)
+	}
+
+	client, err := d.client()
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.DeleteVM(ctx, resource.ID); err != nil {
+		return fmt.Errorf("deleting VM %s: %v", resource.ID, err)
+	}
+
+	return nil
+}
+
+func (d *CleanupDiscoverer) client() (Client, error) {
+	if err := setupEnvVars(d.datacenter); err != nil {
+		return nil, err
+	}
+
+	return d.clientCache.GetNutanixClient(d.datacenter, GetCredsFromEnv())
+}
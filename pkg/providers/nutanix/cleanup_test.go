@@ -0,0 +1,82 @@
+package nutanix
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	. "github.com/onsi/gomega"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cleanup"
+	mocknutanix "github.com/aws/eks-anywhere/pkg/providers/nutanix/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func cleanupTestVM(name, uuid string, categories map[string]string) *v3.VMIntentResource {
+	return &v3.VMIntentResource{
+		Metadata: &v3.Metadata{UUID: ptr.String(uuid), Categories: categories},
+		Spec:     &v3.VM{Name: ptr.String(name)},
+	}
+}
+
+func TestCleanupDiscovererDiscover(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	spec := credentialsTestSpec(t)
+	client := mocknutanix.NewMockClient(gomock.NewController(t))
+	clientCache := &ClientCache{clients: map[string]Client{"mgmt": client}}
+
+	spec.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{{Name: "md-0"}}
+
+	client.EXPECT().ListAllVM(ctx, "vm_name==mgmt-.*").Return(&v3.VMListIntentResponse{
+		Entities: []*v3.VMIntentResource{
+			cleanupTestVM("mgmt-x7k2p", "uuid-1", map[string]string{"KubernetesClusterName": "mgmt"}),
+			cleanupTestVM("mgmt-md-0-5d8fc-abcde", "uuid-2", map[string]string{"kubernetes-io-cluster-mgmt": "owned"}),
+			cleanupTestVM("mgmt-prod-x7k2p", "uuid-3", map[string]string{"KubernetesClusterName": "mgmt-prod"}),
+			cleanupTestVM("mgmt-q9w4z", "uuid-4", map[string]string{"KubernetesClusterName": "other"}),
+			cleanupTestVM("mgmt-md-0-5d8fc-abcde-backup", "uuid-5", map[string]string{"KubernetesClusterName": "mgmt"}),
+			{Metadata: &v3.Metadata{UUID: ptr.String("uuid-6")}},
+		},
+	}, nil)
+
+	d := NewCleanupDiscoverer(clientCache, spec)
+	g.Expect(d.Name()).To(Equal("nutanix"))
+	resources, err := d.Discover(ctx, "mgmt")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resources).To(Equal([]cleanup.Resource{
+		{Kind: "VirtualMachine", Name: "mgmt-x7k2p", ID: "uuid-1"},
+		{Kind: "VirtualMachine", Name: "mgmt-md-0-5d8fc-abcde", ID: "uuid-2"},
+	}))
+}
+
+func TestCleanupDiscovererDiscoverError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	spec := credentialsTestSpec(t)
+	client := mocknutanix.NewMockClient(gomock.NewController(t))
+	clientCache := &ClientCache{clients: map[string]Client{"mgmt": client}}
+
+	client.EXPECT().ListAllVM(ctx, "vm_name==mgmt-.*").Return(nil, errors.New("unauthorized"))
+
+	_, err := NewCleanupDiscoverer(clientCache, spec).Discover(ctx, "mgmt")
+	g.Expect(err).To(MatchError("listing VMs: unauthorized"))
+}
+
+func TestCleanupDiscovererDelete(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	spec := credentialsTestSpec(t)
+	client := mocknutanix.NewMockClient(gomock.NewController(t))
+	clientCache := &ClientCache{clients: map[string]Client{"mgmt": client}}
+	d := NewCleanupDiscoverer(clientCache, spec)
+
+	client.EXPECT().DeleteVM(ctx, "uuid-1").Return(&v3.DeleteResponse{}, nil)
+	client.EXPECT().DeleteVM(ctx, "uuid-2").Return(nil, errors.New("vm is protected"))
+
+	g.Expect(d.Delete(ctx, cleanup.Resource{Kind: "VirtualMachine", Name: "mgmt-abcd", ID: "uuid-1"})).To(Succeed())
+	g.Expect(d.Delete(ctx, cleanup.Resource{Kind: "VirtualMachine", Name: "mgmt-efgh", ID: "uuid-2"})).To(MatchError("deleting VM uuid-2: vm is protected"))
+	g.Expect(d.Delete(ctx, cleanup.Resource{Kind: "Category", Name: "mgmt"})).To(MatchError("unsupported nutanix resource kind Category"))
+}
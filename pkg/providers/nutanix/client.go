@@ -10,6 +10,10 @@ import (
 type Client interface {
 	GetSubnet(ctx context.Context, uuid string) (*v3.SubnetIntentResponse, error)
 	ListAllHost(ctx context.Context) (*v3.HostListResponse, error)
+	ListAllVM(ctx context.Context, filter string) (*v3.VMListIntentResponse, error)
+	GetVM(ctx context.Context, uuid string) (*v3.VMIntentResponse, error)
+	UpdateVM(ctx context.Context, uuid string, body *v3.VMIntentInput) (*v3.VMIntentResponse, error)
+	DeleteVM(ctx context.Context, uuid string) (*v3.DeleteResponse, error)
 	ListAllSubnet(ctx context.Context, filter string, clientSideFilters []*prismgoclient.AdditionalFilter) (*v3.SubnetListIntentResponse, error)
 	GetImage(ctx context.Context, uuid string) (*v3.ImageIntentResponse, error)
 	ListAllImage(ctx context.Context, filter string) (*v3.ImageListIntentResponse, error)
@@ -23,4 +27,6 @@ type Client interface {
 	ListCategoryValues(ctx context.Context, name string, getEntitiesRequest *v3.CategoryListMetadata) (*v3.CategoryValueListResponse, error)
 	GetCategoryValue(ctx context.Context, name string, value string) (*v3.CategoryValueStatus, error)
 	GetCategoryQuery(ctx context.Context, query *v3.CategoryQueryInput) (*v3.CategoryQueryResponse, error)
+	ListAllStorageContainer(ctx context.Context, clusterUUID string) ([]*v3.StorageContainerReference, error)
+	ListAllStorageContainerFreeBytes(ctx context.Context, clusterUUID string) (map[string]int64, error)
 }
@@ -6,16 +6,18 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"sync"
 
 	prismgoclient "github.com/nutanix-cloud-native/prism-go-client"
 	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
-	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 )
 
 // ClientCache is a map of NutanixDatacenterConfig name to Nutanix client.
+// It's safe for concurrent use, since the controllers share it.
 type ClientCache struct {
+	mu      sync.Mutex
 	clients map[string]Client
 }
 
@@ -28,11 +30,14 @@ func NewClientCache() *ClientCache {
 
 // GetNutanixClient returns a Nutanix client for the given NutanixDatacenterConfig.
 func (cb *ClientCache) GetNutanixClient(datacenterConfig *anywherev1.NutanixDatacenterConfig, creds credentials.BasicAuthCredential) (Client, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
 	if client, ok := cb.clients[datacenterConfig.Name]; ok {
 		return client, nil
 	}
 
-	clientOpts := make([]v3.ClientOption, 0)
+	var trustedCert *x509.Certificate
 	if datacenterConfig.Spec.AdditionalTrustBundle != "" {
 		block, _ := pem.Decode([]byte(datacenterConfig.Spec.AdditionalTrustBundle))
 		certs, err := x509.ParseCertificates(block.Bytes)
@@ -42,7 +47,7 @@ func (cb *ClientCache) GetNutanixClient(datacenterConfig *anywherev1.NutanixData
 		if len(certs) == 0 {
 			return nil, fmt.Errorf("unable to extract certs from the addtional trust bundle %s", datacenterConfig.Spec.AdditionalTrustBundle)
 		}
-		clientOpts = append(clientOpts, v3.WithCertificate(certs[0]))
+		trustedCert = certs[0]
 	}
 
 	endpoint := datacenterConfig.Spec.Endpoint
@@ -57,11 +62,11 @@ func (cb *ClientCache) GetNutanixClient(datacenterConfig *anywherev1.NutanixData
 		Insecure: datacenterConfig.Spec.Insecure,
 	}
 
-	client, err := v3.NewV3Client(nutanixCreds, clientOpts...)
+	client, err := newPrismCentralClient(nutanixCreds, trustedCert)
 	if err != nil {
 		return nil, fmt.Errorf("error creating nutanix client: %v", err)
 	}
 
-	cb.clients[datacenterConfig.Name] = client.V3
-	return client.V3, nil
+	cb.clients[datacenterConfig.Name] = client
+	return client, nil
 }
@@ -0,0 +1,25 @@
+#!/bin/bash
+set -euo pipefail
+source /etc/eks-a/static-ip.env
+iface=$(ip -o link show | awk -F': ' '$2 != "lo" {print $2; exit}')
+if command -v netplan >/dev/null 2>&1; then
+  cat > /etc/netplan/99-eksa-static-ip.yaml <<NETPLAN
+network:
+  version: 2
+  ethernets:
+    ${iface}:
+      dhcp4: false
+      addresses: [${ADDRESS}/${PREFIX}]
+      routes:
+        - to: default
+          via: ${GATEWAY}
+      nameservers:
+        addresses: [${NAMESERVERS}]
+NETPLAN
+  chmod 600 /etc/netplan/99-eksa-static-ip.yaml
+  netplan apply
+else
+  connection=$(nmcli -g GENERAL.CONNECTION device show "${iface}")
+  nmcli connection modify "${connection}" ipv4.method manual ipv4.addresses "${ADDRESS}/${PREFIX}" ipv4.gateway "${GATEWAY}" ipv4.dns "${NAMESERVERS}"
+  nmcli connection up "${connection}"
+fi
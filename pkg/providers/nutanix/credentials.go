@@ -0,0 +1,135 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	eksacredentials "github.com/aws/eks-anywhere/pkg/credentials"
+)
+
+// CredentialsRotator rotates the Prism Central credentials of a management cluster and its workload clusters.
+type CredentialsRotator struct {
+	clientCache *ClientCache
+	validator   *Validator
+}
+
+// NewCredentialsRotator returns a new CredentialsRotator.
+func NewCredentialsRotator(clientCache *ClientCache, validator *Validator) *CredentialsRotator {
+	return &CredentialsRotator{
+		clientCache: clientCache,
+		validator:   validator,
+	}
+}
+
+// ValidateCredentials logs in Prism Central with the new credentials.
+func (r *CredentialsRotator) ValidateCredentials(ctx context.Context, spec *cluster.Spec) error {
+	if err := setupEnvVars(spec.NutanixDatacenter); err != nil {
+		return err
+	}
+
+	client, err := r.clientCache.GetNutanixClient(spec.NutanixDatacenter, GetCredsFromEnv())
+	if err != nil {
+		return err
+	}
+
+	return r.validator.validateCredentials(ctx, client)
+}
+
+// UpdateSecrets updates the secret referenced by the NutanixDatacenterConfig of the management cluster.
+func (r *CredentialsRotator) UpdateSecrets(ctx context.Context, client kubernetes.Client, spec *cluster.Spec) error {
+	creds, err := credentialsJSON(GetCredsFromEnv())
+	if err != nil {
+		return err
+	}
+
+	return eksacredentials.UpdateSecretData(ctx, client, EKSASecretName(spec), constants.EksaSystemNamespace, map[string][]byte{
+		"credentials": creds,
+	})
+}
+
+// UsesCredentials returns true if the cluster uses the same Prism Central and credentials secret as the management cluster.
+func (r *CredentialsRotator) UsesCredentials(ctx context.Context, client kubernetes.Client, spec *cluster.Spec, c *anywherev1.Cluster) (bool, error) {
+	datacenter := &anywherev1.NutanixDatacenterConfig{}
+	if err := client.Get(ctx, c.Spec.DatacenterRef.Name, c.Namespace, datacenter); err != nil {
+		return false, fmt.Errorf("getting NutanixDatacenterConfig %s: %v", c.Spec.DatacenterRef.Name, err)
+	}
+
+	secretName := constants.NutanixCredentialsName
+	if datacenter.Spec.CredentialRef != nil {
+		secretName = datacenter.Spec.CredentialRef.Name
+	}
+
+	return datacenter.Spec.Endpoint == spec.NutanixDatacenter.Spec.Endpoint && secretName == EKSASecretName(spec), nil
+}
+
+// UpdateClusterSecrets updates the CAPX secret of the cluster and the cloud provider credentials
+// the ClusterResourceSet of the cluster applies to it.
+func (r *CredentialsRotator) UpdateClusterSecrets(ctx context.Context, client kubernetes.Client, _ *cluster.Spec, c *anywherev1.Cluster) error {
+	creds, err := credentialsJSON(GetCredsFromEnv())
+	if err != nil {
+		return err
+	}
+
+	if err := eksacredentials.UpdateSecretData(ctx, client, fmt.Sprintf("capx-%s", c.Name), constants.EksaSystemNamespace, map[string][]byte{
+		"credentials": creds,
+	}); err != nil {
+		return err
+	}
+
+	ccmSecret, err := yaml.Marshal(&corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nutanix-creds",
+			Namespace: "kube-system",
+		},
+		StringData: map[string]string{
+			"credentials": string(creds),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("marshalling cloud provider credentials secret: %v", err)
+	}
+
+	return eksacredentials.UpdateSecretData(ctx, client, fmt.Sprintf("%s-nutanix-ccm-secret", c.Name), constants.EksaSystemNamespace, map[string][]byte{
+		"nutanix-ccm-secret.yaml": ccmSecret,
+	})
+}
+
+// VerifyClusterCredentials checks the CAPX secret of the cluster holds the new credentials and calls Prism Central
+// with them. The client of the management cluster datacenter was built with the new credentials by ValidateCredentials.
+func (r *CredentialsRotator) VerifyClusterCredentials(ctx context.Context, client kubernetes.Client, spec *cluster.Spec, c *anywherev1.Cluster) error {
+	name := fmt.Sprintf("capx-%s", c.Name)
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, name, constants.EksaSystemNamespace, secret); err != nil {
+		return fmt.Errorf("getting secret %s: %v", name, err)
+	}
+
+	stored, err := credentials.ParseCredentials(secret.Data["credentials"])
+	if err != nil {
+		return fmt.Errorf("parsing credentials of secret %s: %v", name, err)
+	}
+
+	creds := GetCredsFromEnv()
+	if stored.Username != creds.PrismCentral.Username || stored.Password != creds.PrismCentral.Password {
+		return fmt.Errorf("secret %s doesn't hold the new credentials", name)
+	}
+
+	nutanixClient, err := r.clientCache.GetNutanixClient(spec.NutanixDatacenter, creds)
+	if err != nil {
+		return err
+	}
+
+	return r.validator.validateCredentials(ctx, nutanixClient)
+}
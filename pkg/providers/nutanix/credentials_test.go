@@ -0,0 +1,193 @@
+package nutanix
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	mocknutanix "github.com/aws/eks-anywhere/pkg/providers/nutanix/mocks"
+)
+
+const rotatedCredentials = `[{"type":"basic_auth","data":{"prismCentral":{"username":"new-user","password":"new-password"},"prismElements":null}}]`
+
+func credentialsTestSpec(t *testing.T) *cluster.Spec {
+	t.Setenv(constants.EksaNutanixUsernameKey, "new-user")
+	t.Setenv(constants.EksaNutanixPasswordKey, "new-password")
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "mgmt"
+		s.NutanixDatacenter = &anywherev1.NutanixDatacenterConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "mgmt"},
+			Spec: anywherev1.NutanixDatacenterConfigSpec{
+				Endpoint: "prism.nutanix.com",
+				Port:     9440,
+			},
+		}
+	})
+}
+
+func credentialsTestSecret(name string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+	}
+}
+
+func TestCredentialsRotatorValidateCredentials(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	spec := credentialsTestSpec(t)
+	client := mocknutanix.NewMockClient(ctrl)
+	client.EXPECT().GetCurrentLoggedInUser(ctx).Return(&v3.UserIntentResponse{}, nil)
+	clientCache := &ClientCache{clients: map[string]Client{"mgmt": client}}
+
+	r := NewCredentialsRotator(clientCache, NewValidator(clientCache, nil, nil))
+	g.Expect(r.ValidateCredentials(ctx, spec)).To(Succeed())
+}
+
+func TestCredentialsRotatorValidateCredentialsUnauthorized(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	spec := credentialsTestSpec(t)
+	client := mocknutanix.NewMockClient(ctrl)
+	client.EXPECT().GetCurrentLoggedInUser(ctx).Return(nil, errors.New("unauthorized"))
+	clientCache := &ClientCache{clients: map[string]Client{"mgmt": client}}
+
+	r := NewCredentialsRotator(clientCache, NewValidator(clientCache, nil, nil))
+	g.Expect(r.ValidateCredentials(ctx, spec)).To(MatchError("unauthorized"))
+}
+
+func TestCredentialsRotatorUpdateSecrets(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	spec := credentialsTestSpec(t)
+	client := test.NewFakeKubeClient(credentialsTestSecret(constants.NutanixCredentialsName))
+
+	r := NewCredentialsRotator(nil, nil)
+	g.Expect(r.UpdateSecrets(ctx, client, spec)).To(Succeed())
+
+	got := &corev1.Secret{}
+	g.Expect(client.Get(ctx, constants.NutanixCredentialsName, constants.EksaSystemNamespace, got)).To(Succeed())
+	g.Expect(string(got.Data["credentials"])).To(Equal(rotatedCredentials))
+}
+
+func TestCredentialsRotatorUpdateClusterSecrets(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	spec := credentialsTestSpec(t)
+	client := test.NewFakeKubeClient(credentialsTestSecret("capx-workload"), credentialsTestSecret("workload-nutanix-ccm-secret"))
+	workload := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "workload"}}
+
+	r := NewCredentialsRotator(nil, nil)
+	g.Expect(r.UpdateClusterSecrets(ctx, client, spec, workload)).To(Succeed())
+
+	got := &corev1.Secret{}
+	g.Expect(client.Get(ctx, "capx-workload", constants.EksaSystemNamespace, got)).To(Succeed())
+	g.Expect(string(got.Data["credentials"])).To(Equal(rotatedCredentials))
+
+	g.Expect(client.Get(ctx, "workload-nutanix-ccm-secret", constants.EksaSystemNamespace, got)).To(Succeed())
+	g.Expect(string(got.Data["nutanix-ccm-secret.yaml"])).To(Equal(`apiVersion: v1
+kind: Secret
+metadata:
+  name: nutanix-creds
+  namespace: kube-system
+stringData:
+  credentials: '` + rotatedCredentials + `'
+`))
+}
+
+func TestCredentialsRotatorUsesCredentials(t *testing.T) {
+	tests := []struct {
+		name          string
+		endpoint      string
+		credentialRef *anywherev1.Ref
+		want          bool
+	}{
+		{
+			name:     "same Prism Central and secret",
+			endpoint: "prism.nutanix.com",
+			want:     true,
+		},
+		{
+			name:     "other Prism Central",
+			endpoint: "other.nutanix.com",
+			want:     false,
+		},
+		{
+			name:          "other secret",
+			endpoint:      "prism.nutanix.com",
+			credentialRef: &anywherev1.Ref{Kind: constants.SecretKind, Name: "other-credentials"},
+			want:          false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			spec := credentialsTestSpec(t)
+			datacenter := &anywherev1.NutanixDatacenterConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+				Spec: anywherev1.NutanixDatacenterConfigSpec{
+					Endpoint:      tc.endpoint,
+					CredentialRef: tc.credentialRef,
+				},
+			}
+			workload := &anywherev1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+				Spec: anywherev1.ClusterSpec{
+					DatacenterRef: anywherev1.Ref{Kind: anywherev1.NutanixDatacenterKind, Name: "workload"},
+				},
+			}
+
+			r := NewCredentialsRotator(nil, nil)
+			got, err := r.UsesCredentials(ctx, test.NewFakeKubeClient(datacenter), spec, workload)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestCredentialsRotatorVerifyClusterCredentials(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	spec := credentialsTestSpec(t)
+	secret := credentialsTestSecret("capx-workload")
+	secret.Data = map[string][]byte{"credentials": []byte(rotatedCredentials)}
+	kubeClient := test.NewFakeKubeClient(secret)
+	client := mocknutanix.NewMockClient(ctrl)
+	client.EXPECT().GetCurrentLoggedInUser(ctx).Return(&v3.UserIntentResponse{}, nil)
+	clientCache := &ClientCache{clients: map[string]Client{"mgmt": client}}
+	workload := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "workload"}}
+
+	r := NewCredentialsRotator(clientCache, NewValidator(clientCache, nil, nil))
+	g.Expect(r.VerifyClusterCredentials(ctx, kubeClient, spec, workload)).To(Succeed())
+}
+
+func TestCredentialsRotatorVerifyClusterCredentialsNotUpdated(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	spec := credentialsTestSpec(t)
+	secret := credentialsTestSecret("capx-workload")
+	secret.Data = map[string][]byte{
+		"credentials": []byte(`[{"type":"basic_auth","data":{"prismCentral":{"username":"old-user","password":"old-password"}}}]`),
+	}
+	workload := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "workload"}}
+
+	r := NewCredentialsRotator(nil, nil)
+	err := r.VerifyClusterCredentials(ctx, test.NewFakeKubeClient(secret), spec, workload)
+	g.Expect(err).To(MatchError("secret capx-workload doesn't hold the new credentials"))
+}
@@ -0,0 +1,173 @@
+package nutanix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const (
+	// DataDiskMountScript is the script formatting and mounting the data disks with a mountPath during node bootstrap.
+	DataDiskMountScript = "/etc/eks-a/mount-data-disk.sh"
+
+	// DataDisksAnnotation lists the data disks of the NutanixMachines of machine configs with data disks.
+	// CAPX v1.3 can't create VMs with additional disks, so the EKS Anywhere controller attaches them to the VM
+	// once CAPX has created it, while the mount script waits for them during node bootstrap.
+	DataDisksAnnotation = "anywhere.eks.amazonaws.com/nutanix-data-disks"
+
+	// DataDisksAttachedAnnotation is set on a NutanixMachine once its data disks are attached to the VM.
+	DataDisksAttachedAnnotation = "anywhere.eks.amazonaws.com/nutanix-data-disks-attached"
+)
+
+// DataDisk is a NutanixDataDisk with the address it is attached at on the VM.
+type DataDisk struct {
+	Size             resource.Quantity                   `json:"size"`
+	AdapterType      v1alpha1.NutanixDiskDeviceType      `json:"adapterType"`
+	DeviceIndex      int                                 `json:"deviceIndex"`
+	StorageContainer *v1alpha1.NutanixResourceIdentifier `json:"storageContainer,omitempty"`
+	MountPath        string                              `json:"-"`
+}
+
+// dataDisks returns the data disks of a machine config with their device index per adapter type.
+// Index 0 of the SCSI adapter is taken by the system disk.
+func dataDisks(disks []v1alpha1.NutanixDataDisk) []DataDisk {
+	next := map[v1alpha1.NutanixDiskDeviceType]int{v1alpha1.NutanixDiskDeviceTypeSCSI: 1}
+	result := make([]DataDisk, 0, len(disks))
+	for _, disk := range disks {
+		adapterType := disk.DeviceType
+		if adapterType == "" {
+			adapterType = v1alpha1.NutanixDiskDeviceTypeSCSI
+		}
+
+		result = append(result, DataDisk{
+			Size:             disk.Size,
+			AdapterType:      adapterType,
+			DeviceIndex:      next[adapterType],
+			StorageContainer: disk.StorageContainer,
+			MountPath:        disk.MountPath,
+		})
+		next[adapterType]++
+	}
+
+	return result
+}
+
+// dataDiskMounts returns the data disks mounted during node bootstrap.
+func dataDiskMounts(disks []DataDisk) []DataDisk {
+	var mounts []DataDisk
+	for _, disk := range disks {
+		if disk.MountPath != "" {
+			mounts = append(mounts, disk)
+		}
+	}
+
+	return mounts
+}
+
+func addDataDisksTemplateValues(values map[string]interface{}, disks []v1alpha1.NutanixDataDisk) {
+	if len(disks) == 0 {
+		return
+	}
+
+	all := dataDisks(disks)
+	values["dataDisks"] = all
+	values["dataDisksAnnotation"] = DataDisksAnnotation
+	if mounts := dataDiskMounts(all); len(mounts) > 0 {
+		values["dataDiskMounts"] = mounts
+		values["dataDiskMountScript"] = DataDiskMountScript
+	}
+}
+
+// DataDisksFromAnnotation parses the data disks listed in the DataDisksAnnotation of a NutanixMachine.
+func DataDisksFromAnnotation(value string) ([]DataDisk, error) {
+	disks := []DataDisk{}
+	if err := json.Unmarshal([]byte(value), &disks); err != nil {
+		return nil, fmt.Errorf("parsing %s annotation: %v", DataDisksAnnotation, err)
+	}
+
+	return disks, nil
+}
+
+// AttachDataDisks adds the data disks missing from a VM at their address. Disks already attached at their
+// address are skipped, so it can be run again on the same VM.
+func AttachDataDisks(ctx context.Context, client Client, vmUUID string, disks []DataDisk) error {
+	vm, err := client.GetVM(ctx, vmUUID)
+	if err != nil {
+		return fmt.Errorf("getting VM %s: %v", vmUUID, err)
+	}
+	if vm.Spec == nil || vm.Spec.Resources == nil || vm.Metadata == nil {
+		return fmt.Errorf("VM %s has no spec", vmUUID)
+	}
+
+	attached := map[string]bool{}
+	for _, disk := range vm.Spec.Resources.DiskList {
+		if disk == nil || disk.DeviceProperties == nil || disk.DeviceProperties.DiskAddress == nil {
+			continue
+		}
+		address := disk.DeviceProperties.DiskAddress
+		if address.AdapterType != nil && address.DeviceIndex != nil {
+			attached[diskAddress(*address.AdapterType, *address.DeviceIndex)] = true
+		}
+	}
+
+	var containers []*v3.StorageContainerReference
+	missing := 0
+	for _, disk := range disks {
+		if attached[diskAddress(string(disk.AdapterType), int64(disk.DeviceIndex))] {
+			continue
+		}
+
+		vmDisk := &v3.VMDisk{
+			DeviceProperties: &v3.VMDiskDeviceProperties{
+				DeviceType: ptr.String("DISK"),
+				DiskAddress: &v3.DiskAddress{
+					AdapterType: ptr.String(string(disk.AdapterType)),
+					DeviceIndex: ptr.Int64(int64(disk.DeviceIndex)),
+				},
+			},
+			DiskSizeMib: ptr.Int64(disk.Size.Value() >> 20),
+		}
+
+		if disk.StorageContainer != nil {
+			if containers == nil {
+				if vm.Spec.ClusterReference == nil || vm.Spec.ClusterReference.UUID == nil {
+					return fmt.Errorf("VM %s has no cluster reference", vmUUID)
+				}
+				if containers, err = client.ListAllStorageContainer(ctx, *vm.Spec.ClusterReference.UUID); err != nil {
+					return fmt.Errorf("listing storage containers: %v", err)
+				}
+			}
+
+			container := findStorageContainer(containers, *disk.StorageContainer)
+			if container == nil {
+				return fmt.Errorf("storage container %s not found in cluster %s", resourceIdentifierString(*disk.StorageContainer), *vm.Spec.ClusterReference.UUID)
+			}
+			vmDisk.StorageConfig = &v3.VMStorageConfig{
+				StorageContainerReference: &v3.StorageContainerReference{Kind: "storage_container", UUID: container.UUID},
+			}
+		}
+
+		vm.Spec.Resources.DiskList = append(vm.Spec.Resources.DiskList, vmDisk)
+		missing++
+	}
+
+	if missing == 0 {
+		return nil
+	}
+
+	if _, err := client.UpdateVM(ctx, vmUUID, &v3.VMIntentInput{Metadata: vm.Metadata, Spec: vm.Spec}); err != nil {
+		return fmt.Errorf("attaching data disks to VM %s: %v", vmUUID, err)
+	}
+
+	return nil
+}
+
+func diskAddress(adapterType string, deviceIndex int64) string {
+	return fmt.Sprintf("%s.%d", adapterType, deviceIndex)
+}
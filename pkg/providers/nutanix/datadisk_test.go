@@ -0,0 +1,140 @@
+package nutanix
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func dataDiskTestVM(disks ...*v3.VMDisk) *v3.VMIntentResponse {
+	return &v3.VMIntentResponse{
+		Metadata: &v3.Metadata{Kind: ptr.String("vm"), SpecVersion: ptr.Int64(1)},
+		Spec: &v3.VM{
+			ClusterReference: &v3.Reference{UUID: ptr.String("cluster-uuid")},
+			Resources:        &v3.VMResources{DiskList: disks},
+		},
+	}
+}
+
+func dataDiskTestVMDisk(adapterType string, deviceIndex int64) *v3.VMDisk {
+	return &v3.VMDisk{
+		DeviceProperties: &v3.VMDiskDeviceProperties{
+			DeviceType:  ptr.String("DISK"),
+			DiskAddress: &v3.DiskAddress{AdapterType: ptr.String(adapterType), DeviceIndex: ptr.Int64(deviceIndex)},
+		},
+	}
+}
+
+func TestDataDisksAnnotationRoundTrip(t *testing.T) {
+	g := NewWithT(t)
+	values := map[string]interface{}{}
+	addDataDisksTemplateValues(values, []anywherev1.NutanixDataDisk{
+		{Size: resource.MustParse("100Gi"), MountPath: "/var/lib/containerd"},
+		{Size: resource.MustParse("50Gi"), DeviceType: anywherev1.NutanixDiskDeviceTypePCI, StorageContainer: &anywherev1.NutanixResourceIdentifier{
+			Type: anywherev1.NutanixIdentifierName,
+			Name: ptr.String("data"),
+		}},
+	})
+	g.Expect(values["dataDisksAnnotation"]).To(Equal(DataDisksAnnotation))
+
+	disks, err := DataDisksFromAnnotation(`[{"size":"100Gi","adapterType":"SCSI","deviceIndex":1},` +
+		`{"size":"50Gi","adapterType":"PCI","deviceIndex":0,"storageContainer":{"type":"name","name":"data"}}]`)
+	g.Expect(err).NotTo(HaveOccurred())
+	expected := values["dataDisks"].([]DataDisk)
+	expected[0].MountPath = ""
+	g.Expect(disks).To(HaveLen(2))
+	for i := range disks {
+		g.Expect(disks[i].Size.Cmp(expected[i].Size)).To(Equal(0))
+		g.Expect(disks[i].AdapterType).To(Equal(expected[i].AdapterType))
+		g.Expect(disks[i].DeviceIndex).To(Equal(expected[i].DeviceIndex))
+		g.Expect(disks[i].StorageContainer).To(Equal(expected[i].StorageContainer))
+	}
+}
+
+func TestDataDisksFromAnnotationInvalid(t *testing.T) {
+	g := NewWithT(t)
+	_, err := DataDisksFromAnnotation("disks")
+	g.Expect(err).To(MatchError(ContainSubstring("parsing " + DataDisksAnnotation + " annotation")))
+}
+
+func TestAttachDataDisks(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := mocks.NewMockClient(gomock.NewController(t))
+	disks := []DataDisk{
+		{Size: resource.MustParse("100Gi"), AdapterType: anywherev1.NutanixDiskDeviceTypeSCSI, DeviceIndex: 1},
+		{Size: resource.MustParse("50Gi"), AdapterType: anywherev1.NutanixDiskDeviceTypeSCSI, DeviceIndex: 2, StorageContainer: &anywherev1.NutanixResourceIdentifier{
+			Type: anywherev1.NutanixIdentifierName,
+			Name: ptr.String("data"),
+		}},
+	}
+
+	client.EXPECT().GetVM(ctx, "vm-uuid").Return(dataDiskTestVM(dataDiskTestVMDisk("SCSI", 0), dataDiskTestVMDisk("SCSI", 1)), nil)
+	client.EXPECT().ListAllStorageContainer(ctx, "cluster-uuid").Return([]*v3.StorageContainerReference{
+		{Kind: "storage_container", UUID: "container-uuid", Name: "data"},
+	}, nil)
+	client.EXPECT().UpdateVM(ctx, "vm-uuid", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, body *v3.VMIntentInput) (*v3.VMIntentResponse, error) {
+		g.Expect(*body.Metadata.SpecVersion).To(Equal(int64(1)))
+		g.Expect(body.Spec.Resources.DiskList).To(HaveLen(3))
+		g.Expect(body.Spec.Resources.DiskList[2]).To(Equal(&v3.VMDisk{
+			DeviceProperties: &v3.VMDiskDeviceProperties{
+				DeviceType:  ptr.String("DISK"),
+				DiskAddress: &v3.DiskAddress{AdapterType: ptr.String("SCSI"), DeviceIndex: ptr.Int64(2)},
+			},
+			DiskSizeMib: ptr.Int64(51200),
+			StorageConfig: &v3.VMStorageConfig{
+				StorageContainerReference: &v3.StorageContainerReference{Kind: "storage_container", UUID: "container-uuid"},
+			},
+		}))
+		return &v3.VMIntentResponse{}, nil
+	})
+
+	g.Expect(AttachDataDisks(ctx, client, "vm-uuid", disks)).To(Succeed())
+}
+
+func TestAttachDataDisksAlreadyAttached(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := mocks.NewMockClient(gomock.NewController(t))
+	disks := []DataDisk{{Size: resource.MustParse("100Gi"), AdapterType: anywherev1.NutanixDiskDeviceTypeSCSI, DeviceIndex: 1}}
+
+	client.EXPECT().GetVM(ctx, "vm-uuid").Return(dataDiskTestVM(dataDiskTestVMDisk("SCSI", 0), dataDiskTestVMDisk("SCSI", 1)), nil)
+
+	g.Expect(AttachDataDisks(ctx, client, "vm-uuid", disks)).To(Succeed())
+}
+
+func TestAttachDataDisksStorageContainerNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := mocks.NewMockClient(gomock.NewController(t))
+	disks := []DataDisk{{Size: resource.MustParse("100Gi"), AdapterType: anywherev1.NutanixDiskDeviceTypeSCSI, DeviceIndex: 1, StorageContainer: &anywherev1.NutanixResourceIdentifier{
+		Type: anywherev1.NutanixIdentifierUUID,
+		UUID: ptr.String("missing-uuid"),
+	}}}
+
+	client.EXPECT().GetVM(ctx, "vm-uuid").Return(dataDiskTestVM(), nil)
+	client.EXPECT().ListAllStorageContainer(ctx, "cluster-uuid").Return([]*v3.StorageContainerReference{}, nil)
+
+	g.Expect(AttachDataDisks(ctx, client, "vm-uuid", disks)).To(MatchError("storage container missing-uuid not found in cluster cluster-uuid"))
+}
+
+func TestAttachDataDisksUpdateError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := mocks.NewMockClient(gomock.NewController(t))
+	disks := []DataDisk{{Size: resource.MustParse("100Gi"), AdapterType: anywherev1.NutanixDiskDeviceTypeSCSI, DeviceIndex: 1}}
+
+	client.EXPECT().GetVM(ctx, "vm-uuid").Return(dataDiskTestVM(), nil)
+	client.EXPECT().UpdateVM(ctx, "vm-uuid", gomock.Any()).Return(nil, errors.New("error"))
+
+	g.Expect(AttachDataDisks(ctx, client, "vm-uuid", disks)).To(MatchError("attaching data disks to VM vm-uuid: error"))
+}
@@ -0,0 +1,291 @@
+package nutanix
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	"sigs.k8s.io/yaml"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	// IPPoolAnnotation is set on the NutanixMachines of the machines using an in-cluster ipPool, together with
+	// the CAPI paused annotation. CAPX creates the VM once the EKS Anywhere controller has written the address
+	// claimed for the machine to its bootstrap data and removed the paused annotation.
+	IPPoolAnnotation = "anywhere.eks.amazonaws.com/nutanix-ip-pool"
+
+	// StaticIPEnvFile is the file the EKS Anywhere controller writes the allocated address to.
+	StaticIPEnvFile = "/etc/eks-a/static-ip.env"
+
+	// StaticIPScriptFile configures the address of StaticIPEnvFile. It runs before any other bootstrap command.
+	StaticIPScriptFile = "/etc/eks-a/configure-static-ip.sh"
+)
+
+//go:embed config/configure-static-ip.sh
+var staticIPScript string
+
+// StaticIP is an address allocated from an in-cluster ipPool.
+type StaticIP struct {
+	Address     string
+	Prefix      int
+	Gateway     string
+	Nameservers []string
+}
+
+// AddStaticIPToBootstrapData adds the files and the command configuring a static address to the
+// cloud-config bootstrap data of a machine. It's idempotent, so it can be run again on the same data.
+func AddStaticIPToBootstrapData(data []byte, ip StaticIP) ([]byte, error) {
+	header, body := splitCloudConfigHeader(data)
+	if !strings.Contains(header, "#cloud-config") {
+		return nil, errors.New("bootstrap data is not in cloud-config format")
+	}
+
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal(body, &config); err != nil {
+		return nil, fmt.Errorf("parsing cloud-config bootstrap data: %v", err)
+	}
+
+	env := fmt.Sprintf("ADDRESS=%s\nPREFIX=%d\nGATEWAY=%s\nNAMESERVERS=%s\n", ip.Address, ip.Prefix, ip.Gateway, strings.Join(ip.Nameservers, ","))
+	files, _ := config["write_files"].([]interface{})
+	files = setCloudConfigFile(files, StaticIPEnvFile, "0644", env)
+	files = setCloudConfigFile(files, StaticIPScriptFile, "0755", staticIPScript)
+	config["write_files"] = files
+
+	commands, _ := config["runcmd"].([]interface{})
+	if len(commands) == 0 || commands[0] != StaticIPScriptFile {
+		config["runcmd"] = append([]interface{}{StaticIPScriptFile}, commands...)
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling cloud-config bootstrap data: %v", err)
+	}
+
+	return append([]byte(header), out...), nil
+}
+
+// splitCloudConfigHeader splits the leading comment lines, like the "#cloud-config" and the
+// "## template: jinja" lines, from the cloud-config document.
+func splitCloudConfigHeader(data []byte) (string, []byte) {
+	header := 0
+	for header < len(data) && data[header] == '#' {
+		end := bytes.IndexByte(data[header:], '\n')
+		if end < 0 {
+			return string(data), nil
+		}
+		header += end + 1
+	}
+	return string(data[:header]), data[header:]
+}
+
+func setCloudConfigFile(files []interface{}, path, permissions, content string) []interface{} {
+	file := map[string]interface{}{
+		"path":        path,
+		"owner":       "root:root",
+		"permissions": permissions,
+		"content":     content,
+	}
+	for i, f := range files {
+		if existing, ok := f.(map[string]interface{}); ok && existing["path"] == path {
+			files[i] = file
+			return files
+		}
+	}
+	return append(files, file)
+}
+
+// validateIPPools checks the machine configs using an ipPool reference a pool of their subnet, that
+// Prism pools are backed by a managed subnet and in-cluster pools by an unmanaged one, and that
+// every pool has enough free addresses for all the machines using it, including the machines
+// rolled out during an upgrade. The address of in-cluster pools is written to the cloud-config
+// bootstrap data, so they can't be used with Bottlerocket.
+func (v *Validator) validateIPPools(ctx context.Context, client Client, spec *cluster.Spec) error {
+	datacenter := spec.NutanixDatacenter
+	endpoint := spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host
+
+	requested := map[string]int{}
+	groups := map[string][]string{}
+	for _, group := range machineGroups(spec) {
+		if group.machineConfig == nil || group.machineConfig.Spec.IPPool == "" {
+			continue
+		}
+
+		pool := datacenter.IPPool(group.machineConfig.Spec.IPPool)
+		if pool == nil {
+			return fmt.Errorf("machine config %s ipPool %s not found in NutanixDatacenterConfig %s", group.machineConfig.Name, group.machineConfig.Spec.IPPool, datacenter.Name)
+		}
+		// machines placed in failure domains are attached to the failure domain subnets
+		if len(datacenter.Spec.FailureDomains) > 0 {
+			return fmt.Errorf("machine config %s ipPool %s can not be used with failure domains", group.machineConfig.Name, pool.Name)
+		}
+		if !sameResourceIdentifier(pool.Subnet, group.machineConfig.Spec.Subnet) {
+			return fmt.Errorf("machine config %s subnet is not the subnet of ipPool %s", group.machineConfig.Name, pool.Name)
+		}
+		if !pool.IsPrismManaged() && group.machineConfig.Spec.OSFamily == anywherev1.Bottlerocket {
+			return fmt.Errorf("in-cluster ipPool %s can not be used with %s, it is used by the %s", pool.Name, anywherev1.Bottlerocket, group.name)
+		}
+		if pool.Contains(endpoint) {
+			return fmt.Errorf("control plane endpoint %s can not be inside ipPool %s", endpoint, pool.Name)
+		}
+
+		requested[pool.Name] += group.count + group.surge()
+		groups[pool.Name] = append(groups[pool.Name], group.name)
+	}
+
+	poolNames := make([]string, 0, len(requested))
+	for name := range requested {
+		poolNames = append(poolNames, name)
+	}
+	sort.Strings(poolNames)
+
+	for _, name := range poolNames {
+		pool := datacenter.IPPool(name)
+		subnet, err := getIPPoolSubnet(ctx, client, spec, pool)
+		if err != nil {
+			return err
+		}
+		managed := subnet.Spec != nil && subnet.Spec.Resources != nil && subnet.Spec.Resources.IPConfig != nil &&
+			len(subnet.Spec.Resources.IPConfig.PoolList) > 0
+
+		available := pool.Size()
+		if pool.IsPrismManaged() {
+			if !managed {
+				return fmt.Errorf("ipPool %s has no ranges but its subnet is not managed by Prism IPAM", name)
+			}
+			available, err = prismSubnetFreeAddresses(ctx, client, subnet, spec.Cluster.Name)
+			if err != nil {
+				return err
+			}
+		} else if managed {
+			return fmt.Errorf("ipPool %s has ranges but its subnet is managed by Prism IPAM, remove the ranges to use the subnet IPAM", name)
+		}
+
+		if available < requested[name] {
+			return fmt.Errorf("ipPool %s has %d free addresses but %v need %d, including the machines rolled out during upgrades", name, available, groups[name], requested[name])
+		}
+	}
+
+	logger.V(5).Info("Nutanix ipPools validated")
+	return nil
+}
+
+func sameResourceIdentifier(a, b anywherev1.NutanixResourceIdentifier) bool {
+	if a.Type != b.Type {
+		return false
+	}
+	if a.Type == anywherev1.NutanixIdentifierName {
+		return a.Name != nil && b.Name != nil && *a.Name == *b.Name
+	}
+	return a.UUID != nil && b.UUID != nil && *a.UUID == *b.UUID
+}
+
+// getIPPoolSubnet returns the subnet of a pool. Subnet names are resolved in the Prism Element
+// cluster of the machine configs using the pool.
+func getIPPoolSubnet(ctx context.Context, client Client, spec *cluster.Spec, pool *anywherev1.NutanixIPPool) (*v3.SubnetIntentResponse, error) {
+	subnetUUID := pool.Subnet.UUID
+	if pool.Subnet.Type == anywherev1.NutanixIdentifierName {
+		var machineConfig *anywherev1.NutanixMachineConfig
+		for _, mc := range spec.NutanixMachineConfigs {
+			if mc.Spec.IPPool == pool.Name {
+				machineConfig = mc
+				break
+			}
+		}
+		clusterUUID, err := getClusterUUID(ctx, client, machineConfig.Spec.Cluster)
+		if err != nil {
+			return nil, err
+		}
+		subnetUUID, err = findSubnetUUIDByName(ctx, client, clusterUUID, *pool.Subnet.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find subnet of ipPool %s: %v", pool.Name, err)
+		}
+	}
+
+	subnet, err := client.GetSubnet(ctx, *subnetUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subnet of ipPool %s: %v", pool.Name, err)
+	}
+
+	return subnet, nil
+}
+
+// prismSubnetFreeAddresses returns the number of addresses of the Prism IPAM pools of a managed subnet
+// that are not assigned to a VM. The addresses of the VMs of the cluster, found from the category CAPX
+// assigns to them, are counted as free since they are released when the machines are replaced.
+func prismSubnetFreeAddresses(ctx context.Context, client Client, subnet *v3.SubnetIntentResponse, clusterName string) (int, error) {
+	type ipRange struct{ start, end uint32 }
+	ranges := []ipRange{}
+	size := 0
+	for _, p := range subnet.Spec.Resources.IPConfig.PoolList {
+		if p == nil || p.Range == nil {
+			continue
+		}
+		bounds := strings.Fields(*p.Range)
+		if len(bounds) != 2 {
+			return 0, fmt.Errorf("invalid Prism IPAM pool range %q", *p.Range)
+		}
+		start, startOk := ipv4ToUint32(bounds[0])
+		end, endOk := ipv4ToUint32(bounds[1])
+		if !startOk || !endOk || start > end {
+			return 0, fmt.Errorf("invalid Prism IPAM pool range %q", *p.Range)
+		}
+		ranges = append(ranges, ipRange{start: start, end: end})
+		size += int(end-start) + 1
+	}
+
+	vms, err := client.ListAllVM(ctx, "")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list VMs: %v", err)
+	}
+
+	used := map[uint32]bool{}
+	for _, vm := range vms.Entities {
+		if vm == nil || vm.Status == nil || vm.Status.Resources == nil {
+			continue
+		}
+		if inClusterCategory(vm.Metadata, clusterName) {
+			continue
+		}
+		for _, nic := range vm.Status.Resources.NicList {
+			if nic == nil || nic.SubnetReference == nil || nic.SubnetReference.UUID == nil ||
+				subnet.Metadata == nil || subnet.Metadata.UUID == nil || *nic.SubnetReference.UUID != *subnet.Metadata.UUID {
+				continue
+			}
+			for _, endpoint := range nic.IPEndpointList {
+				if endpoint == nil || endpoint.IP == nil {
+					continue
+				}
+				addr, ok := ipv4ToUint32(*endpoint.IP)
+				if !ok {
+					continue
+				}
+				for _, r := range ranges {
+					if addr >= r.start && addr <= r.end {
+						used[addr] = true
+					}
+				}
+			}
+		}
+	}
+
+	return size - len(used), nil
+}
+
+func ipv4ToUint32(s string) (uint32, bool) {
+	ip := net.ParseIP(s).To4()
+	if ip == nil {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(ip), true
+}
@@ -0,0 +1,284 @@
+package nutanix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	capxv1beta1 "github.com/nutanix-cloud-native/cluster-api-provider-nutanix/api/v1beta1"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	mocknutanix "github.com/aws/eks-anywhere/pkg/providers/nutanix/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const ipPoolTestSubnetUUID = "b15f6966-bfc7-4d1e-8575-224096fc1cdb"
+
+type ipPoolTest struct {
+	*WithT
+	ctx       context.Context
+	client    *mocknutanix.MockClient
+	validator *Validator
+	spec      *cluster.Spec
+}
+
+func newIPPoolTest(t *testing.T) *ipPoolTest {
+	subnet := anywherev1.NutanixResourceIdentifier{Type: anywherev1.NutanixIdentifierName, Name: ptr.String("prism-subnet")}
+	machineConfig := func(name string) *anywherev1.NutanixMachineConfig {
+		return &anywherev1.NutanixMachineConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: anywherev1.NutanixMachineConfigSpec{
+				Cluster: anywherev1.NutanixResourceIdentifier{Type: anywherev1.NutanixIdentifierUUID, UUID: ptr.String("a15f6966-bfc7-4d1e-8575-224096fc1cdb")},
+				Subnet:  subnet,
+				IPPool:  "pool",
+			},
+		}
+	}
+
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test"
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+		s.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef = &anywherev1.Ref{Kind: anywherev1.NutanixMachineConfigKind, Name: "cp"}
+		s.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &anywherev1.Endpoint{Host: "10.0.0.2"}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{{
+			Name:            "md-0",
+			Count:           ptr.Int(3),
+			MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.NutanixMachineConfigKind, Name: "worker"},
+		}}
+		s.NutanixDatacenter = &anywherev1.NutanixDatacenterConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: anywherev1.NutanixDatacenterConfigSpec{
+				IPPools: []anywherev1.NutanixIPPool{{
+					Name:    "pool",
+					Subnet:  subnet,
+					Ranges:  []anywherev1.NutanixIPRange{{Start: "10.0.0.10", End: "10.0.0.13"}},
+					Prefix:  24,
+					Gateway: "10.0.0.1",
+				}},
+			},
+		}
+		s.NutanixMachineConfigs = map[string]*anywherev1.NutanixMachineConfig{
+			"cp":     machineConfig("cp"),
+			"worker": machineConfig("worker"),
+		}
+		s.NutanixMachineConfigs["cp"].Spec.IPPool = ""
+	})
+
+	client := mocknutanix.NewMockClient(gomock.NewController(t))
+	client.EXPECT().ListAllSubnet(gomock.Any(), "", nil).Return(fakeSubnetList(), nil).AnyTimes()
+
+	return &ipPoolTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		client:    client,
+		validator: NewValidator(&ClientCache{}, nil, nil),
+		spec:      spec,
+	}
+}
+
+func (tt *ipPoolTest) expectSubnet(poolRanges ...string) {
+	subnet := &v3.SubnetIntentResponse{
+		Metadata: &v3.Metadata{UUID: ptr.String(ipPoolTestSubnetUUID)},
+		Spec:     &v3.Subnet{Resources: &v3.SubnetResources{}},
+	}
+	if len(poolRanges) > 0 {
+		subnet.Spec.Resources.IPConfig = &v3.IPConfig{}
+		for _, r := range poolRanges {
+			subnet.Spec.Resources.IPConfig.PoolList = append(subnet.Spec.Resources.IPConfig.PoolList, &v3.IPPool{Range: ptr.String(r)})
+		}
+	}
+	tt.client.EXPECT().GetSubnet(tt.ctx, ipPoolTestSubnetUUID).Return(subnet, nil)
+}
+
+func (tt *ipPoolTest) usePrismPool() {
+	pool := &tt.spec.NutanixDatacenter.Spec.IPPools[0]
+	pool.Ranges = nil
+	pool.Prefix = 0
+	pool.Gateway = ""
+}
+
+// inCAPXCategory assigns a VM the category CAPX assigns to the VMs of a cluster.
+func inCAPXCategory(vm *v3.VMIntentResource, clusterName string) *v3.VMIntentResource {
+	vm.Metadata = &v3.Metadata{Categories: map[string]string{capxv1beta1.DefaultCAPICategoryKeyForName: clusterName}}
+	return vm
+}
+
+func vmWithAddress(name, subnetUUID, ip string) *v3.VMIntentResource {
+	return &v3.VMIntentResource{
+		Status: &v3.VMDefStatus{
+			Name: ptr.String(name),
+			Resources: &v3.VMResourcesDefStatus{
+				NicList: []*v3.VMNicOutputStatus{{
+					SubnetReference: &v3.Reference{UUID: ptr.String(subnetUUID)},
+					IPEndpointList:  []*v3.IPAddress{{IP: ptr.String(ip)}},
+				}},
+			},
+		},
+	}
+}
+
+func TestValidateIPPoolsInClusterPool(t *testing.T) {
+	tt := newIPPoolTest(t)
+	tt.expectSubnet()
+
+	tt.Expect(tt.validator.validateIPPools(tt.ctx, tt.client, tt.spec)).To(Succeed())
+}
+
+func TestValidateIPPoolsInClusterPoolNotEnoughAddresses(t *testing.T) {
+	tt := newIPPoolTest(t)
+	tt.spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(4)
+	tt.expectSubnet()
+
+	err := tt.validator.validateIPPools(tt.ctx, tt.client, tt.spec)
+	tt.Expect(err).To(MatchError("ipPool pool has 4 free addresses but [worker node group md-0] need 5, including the machines rolled out during upgrades"))
+}
+
+func TestValidateIPPoolsInClusterPoolManagedSubnet(t *testing.T) {
+	tt := newIPPoolTest(t)
+	tt.expectSubnet("10.0.0.100 10.0.0.200")
+
+	err := tt.validator.validateIPPools(tt.ctx, tt.client, tt.spec)
+	tt.Expect(err).To(MatchError("ipPool pool has ranges but its subnet is managed by Prism IPAM, remove the ranges to use the subnet IPAM"))
+}
+
+func TestValidateIPPoolsInClusterPoolControlPlane(t *testing.T) {
+	tt := newIPPoolTest(t)
+	tt.spec.NutanixMachineConfigs["cp"].Spec.IPPool = "pool"
+	tt.spec.NutanixDatacenter.Spec.IPPools[0].Ranges[0].End = "10.0.0.20"
+	tt.expectSubnet()
+
+	tt.Expect(tt.validator.validateIPPools(tt.ctx, tt.client, tt.spec)).To(Succeed())
+}
+
+func TestValidateIPPoolsInClusterPoolBottlerocket(t *testing.T) {
+	tt := newIPPoolTest(t)
+	tt.spec.NutanixMachineConfigs["worker"].Spec.OSFamily = anywherev1.Bottlerocket
+
+	err := tt.validator.validateIPPools(tt.ctx, tt.client, tt.spec)
+	tt.Expect(err).To(MatchError("in-cluster ipPool pool can not be used with bottlerocket, it is used by the worker node group md-0"))
+}
+
+func TestValidateIPPoolsControlPlaneEndpointInPool(t *testing.T) {
+	tt := newIPPoolTest(t)
+	tt.spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "10.0.0.11"
+
+	err := tt.validator.validateIPPools(tt.ctx, tt.client, tt.spec)
+	tt.Expect(err).To(MatchError("control plane endpoint 10.0.0.11 can not be inside ipPool pool"))
+}
+
+func TestValidateIPPoolsPoolNotFound(t *testing.T) {
+	tt := newIPPoolTest(t)
+	tt.spec.NutanixMachineConfigs["worker"].Spec.IPPool = "other"
+
+	err := tt.validator.validateIPPools(tt.ctx, tt.client, tt.spec)
+	tt.Expect(err).To(MatchError("machine config worker ipPool other not found in NutanixDatacenterConfig test"))
+}
+
+func TestValidateIPPoolsFailureDomains(t *testing.T) {
+	tt := newIPPoolTest(t)
+	tt.spec.NutanixDatacenter.Spec.FailureDomains = []anywherev1.NutanixDatacenterFailureDomain{{Name: "fd-1"}}
+
+	err := tt.validator.validateIPPools(tt.ctx, tt.client, tt.spec)
+	tt.Expect(err).To(MatchError("machine config worker ipPool pool can not be used with failure domains"))
+}
+
+func TestValidateIPPoolsSubnetMismatch(t *testing.T) {
+	tt := newIPPoolTest(t)
+	tt.spec.NutanixMachineConfigs["worker"].Spec.Subnet.Name = ptr.String("other-subnet")
+
+	err := tt.validator.validateIPPools(tt.ctx, tt.client, tt.spec)
+	tt.Expect(err).To(MatchError("machine config worker subnet is not the subnet of ipPool pool"))
+}
+
+func TestValidateIPPoolsPrismPool(t *testing.T) {
+	tt := newIPPoolTest(t)
+	tt.usePrismPool()
+	tt.spec.NutanixMachineConfigs["cp"].Spec.IPPool = "pool"
+	tt.expectSubnet("10.0.0.100 10.0.0.107", "10.0.0.200 10.0.0.201")
+	tt.client.EXPECT().ListAllVM(tt.ctx, "").Return(&v3.VMListIntentResponse{
+		Entities: []*v3.VMIntentResource{
+			vmWithAddress("other-vm", ipPoolTestSubnetUUID, "10.0.0.101"),
+			inCAPXCategory(vmWithAddress("test-md-0-abcde", ipPoolTestSubnetUUID, "10.0.0.102"), "test"),
+			vmWithAddress("outside-pool", ipPoolTestSubnetUUID, "10.0.0.50"),
+			vmWithAddress("other-subnet", "c15f6966-bfc7-4d1e-8575-224096fc1cdb", "10.0.0.103"),
+		},
+	}, nil)
+
+	tt.Expect(tt.validator.validateIPPools(tt.ctx, tt.client, tt.spec)).To(Succeed())
+}
+
+func TestValidateIPPoolsPrismPoolNotEnoughAddresses(t *testing.T) {
+	tt := newIPPoolTest(t)
+	tt.usePrismPool()
+	tt.spec.NutanixMachineConfigs["cp"].Spec.IPPool = "pool"
+	tt.expectSubnet("10.0.0.100 10.0.0.107")
+	tt.client.EXPECT().ListAllVM(tt.ctx, "").Return(&v3.VMListIntentResponse{
+		Entities: []*v3.VMIntentResource{
+			inCAPXCategory(vmWithAddress("test-2-md-0-abcde", ipPoolTestSubnetUUID, "10.0.0.101"), "test-2"),
+		},
+	}, nil)
+
+	err := tt.validator.validateIPPools(tt.ctx, tt.client, tt.spec)
+	tt.Expect(err).To(MatchError("ipPool pool has 7 free addresses but [control plane worker node group md-0] need 8, including the machines rolled out during upgrades"))
+}
+
+func TestValidateIPPoolsPrismPoolUnmanagedSubnet(t *testing.T) {
+	tt := newIPPoolTest(t)
+	tt.usePrismPool()
+	tt.expectSubnet()
+
+	err := tt.validator.validateIPPools(tt.ctx, tt.client, tt.spec)
+	tt.Expect(err).To(MatchError("ipPool pool has no ranges but its subnet is not managed by Prism IPAM"))
+}
+
+func TestAddStaticIPToBootstrapData(t *testing.T) {
+	g := NewWithT(t)
+	data := []byte(`## template: jinja
+#cloud-config
+
+write_files:
+-   path: /etc/kubernetes/audit-policy.yaml
+    owner: root:root
+    content: |
+      apiVersion: audit.k8s.io/v1
+runcmd:
+  - hostnamectl set-hostname "{{ ds.meta_data.hostname }}"
+  - kubeadm join --config /run/kubeadm/kubeadm-join-config.yaml
+`)
+	ip := StaticIP{Address: "10.0.0.10", Prefix: 24, Gateway: "10.0.0.1", Nameservers: []string{"10.0.0.2", "10.0.0.3"}}
+
+	got, err := AddStaticIPToBootstrapData(data, ip)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(got)).To(HavePrefix("## template: jinja\n#cloud-config\n"))
+
+	config := map[string]interface{}{}
+	g.Expect(yaml.Unmarshal(got[len("## template: jinja\n#cloud-config\n"):], &config)).To(Succeed())
+	g.Expect(config["runcmd"]).To(Equal([]interface{}{
+		StaticIPScriptFile,
+		`hostnamectl set-hostname "{{ ds.meta_data.hostname }}"`,
+		"kubeadm join --config /run/kubeadm/kubeadm-join-config.yaml",
+	}))
+	g.Expect(config["write_files"]).To(HaveLen(3))
+	g.Expect(config["write_files"]).To(ContainElement(map[string]interface{}{
+		"path":        StaticIPEnvFile,
+		"owner":       "root:root",
+		"permissions": "0644",
+		"content":     "ADDRESS=10.0.0.10\nPREFIX=24\nGATEWAY=10.0.0.1\nNAMESERVERS=10.0.0.2,10.0.0.3\n",
+	}))
+
+	again, err := AddStaticIPToBootstrapData(got, ip)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(again)).To(Equal(string(got)))
+}
+
+func TestAddStaticIPToBootstrapDataNotCloudConfig(t *testing.T) {
+	g := NewWithT(t)
+	_, err := AddStaticIPToBootstrapData([]byte("[settings.kubernetes]\n"), StaticIP{})
+	g.Expect(err).To(MatchError("bootstrap data is not in cloud-config format"))
+}
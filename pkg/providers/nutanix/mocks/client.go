@@ -36,6 +36,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// DeleteVM mocks base method.
+func (m *MockClient) DeleteVM(ctx context.Context, uuid string) (*v3.DeleteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVM", ctx, uuid)
+	ret0, _ := ret[0].(*v3.DeleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteVM indicates an expected call of DeleteVM.
+func (mr *MockClientMockRecorder) DeleteVM(ctx, uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVM", reflect.TypeOf((*MockClient)(nil).DeleteVM), ctx, uuid)
+}
+
 // GetCategoryKey mocks base method.
 func (m *MockClient) GetCategoryKey(ctx context.Context, name string) (*v3.CategoryKeyStatus, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +171,21 @@ func (mr *MockClientMockRecorder) GetSubnet(ctx, uuid interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnet", reflect.TypeOf((*MockClient)(nil).GetSubnet), ctx, uuid)
 }
 
+// GetVM mocks base method.
+func (m *MockClient) GetVM(ctx context.Context, uuid string) (*v3.VMIntentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVM", ctx, uuid)
+	ret0, _ := ret[0].(*v3.VMIntentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVM indicates an expected call of GetVM.
+func (mr *MockClientMockRecorder) GetVM(ctx, uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVM", reflect.TypeOf((*MockClient)(nil).GetVM), ctx, uuid)
+}
+
 // ListAllCluster mocks base method.
 func (m *MockClient) ListAllCluster(ctx context.Context, filter string) (*v3.ClusterListIntentResponse, error) {
 	m.ctrl.T.Helper()
@@ -216,6 +246,36 @@ func (mr *MockClientMockRecorder) ListAllProject(ctx, filter interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllProject", reflect.TypeOf((*MockClient)(nil).ListAllProject), ctx, filter)
 }
 
+// ListAllStorageContainer mocks base method.
+func (m *MockClient) ListAllStorageContainer(ctx context.Context, clusterUUID string) ([]*v3.StorageContainerReference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllStorageContainer", ctx, clusterUUID)
+	ret0, _ := ret[0].([]*v3.StorageContainerReference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllStorageContainer indicates an expected call of ListAllStorageContainer.
+func (mr *MockClientMockRecorder) ListAllStorageContainer(ctx, clusterUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllStorageContainer", reflect.TypeOf((*MockClient)(nil).ListAllStorageContainer), ctx, clusterUUID)
+}
+
+// ListAllStorageContainerFreeBytes mocks base method.
+func (m *MockClient) ListAllStorageContainerFreeBytes(ctx context.Context, clusterUUID string) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllStorageContainerFreeBytes", ctx, clusterUUID)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllStorageContainerFreeBytes indicates an expected call of ListAllStorageContainerFreeBytes.
+func (mr *MockClientMockRecorder) ListAllStorageContainerFreeBytes(ctx, clusterUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllStorageContainerFreeBytes", reflect.TypeOf((*MockClient)(nil).ListAllStorageContainerFreeBytes), ctx, clusterUUID)
+}
+
 // ListAllSubnet mocks base method.
 func (m *MockClient) ListAllSubnet(ctx context.Context, filter string, clientSideFilters []*prismgoclient.AdditionalFilter) (*v3.SubnetListIntentResponse, error) {
 	m.ctrl.T.Helper()
@@ -231,6 +291,21 @@ func (mr *MockClientMockRecorder) ListAllSubnet(ctx, filter, clientSideFilters i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllSubnet", reflect.TypeOf((*MockClient)(nil).ListAllSubnet), ctx, filter, clientSideFilters)
 }
 
+// ListAllVM mocks base method.
+func (m *MockClient) ListAllVM(ctx context.Context, filter string) (*v3.VMListIntentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllVM", ctx, filter)
+	ret0, _ := ret[0].(*v3.VMListIntentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllVM indicates an expected call of ListAllVM.
+func (mr *MockClientMockRecorder) ListAllVM(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllVM", reflect.TypeOf((*MockClient)(nil).ListAllVM), ctx, filter)
+}
+
 // ListCategories mocks base method.
 func (m *MockClient) ListCategories(ctx context.Context, getEntitiesRequest *v3.CategoryListMetadata) (*v3.CategoryKeyListResponse, error) {
 	m.ctrl.T.Helper()
@@ -260,3 +335,18 @@ func (mr *MockClientMockRecorder) ListCategoryValues(ctx, name, getEntitiesReque
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoryValues", reflect.TypeOf((*MockClient)(nil).ListCategoryValues), ctx, name, getEntitiesRequest)
 }
+
+// UpdateVM mocks base method.
+func (m *MockClient) UpdateVM(ctx context.Context, uuid string, body *v3.VMIntentInput) (*v3.VMIntentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVM", ctx, uuid, body)
+	ret0, _ := ret[0].(*v3.VMIntentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVM indicates an expected call of UpdateVM.
+func (mr *MockClientMockRecorder) UpdateVM(ctx, uuid, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVM", reflect.TypeOf((*MockClient)(nil).UpdateVM), ctx, uuid, body)
+}
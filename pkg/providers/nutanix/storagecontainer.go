@@ -0,0 +1,193 @@
+package nutanix
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	prismgoclient "github.com/nutanix-cloud-native/prism-go-client"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+)
+
+const (
+	groupsAPIPath            = "/api/nutanix/v3/groups"
+	storageContainersPerPage = 500
+
+	storageContainerNameAttribute      = "container_name"
+	storageContainerFreeBytesAttribute = "storage.user_free_bytes"
+)
+
+// prismCentralClient extends the v3 service of prism-go-client with the Prism Central groups API, which
+// prism-go-client v0.3.4 doesn't expose. The groups requests go through the transport and credentials of
+// the v3 client, so they honor the same trust bundle, insecure setting and proxy.
+type prismCentralClient struct {
+	v3.Service
+
+	httpClient  *http.Client
+	credentials prismgoclient.Credentials
+}
+
+func newPrismCentralClient(creds prismgoclient.Credentials, cert *x509.Certificate) (*prismCentralClient, error) {
+	transport := newPrismTransport(creds, cert)
+	client, err := v3.NewV3Client(creds, v3.WithRoundTripper(transport))
+	if err != nil {
+		return nil, err
+	}
+
+	return &prismCentralClient{
+		Service:     client.V3,
+		httpClient:  &http.Client{Transport: transport},
+		credentials: creds,
+	}, nil
+}
+
+// newPrismTransport returns the transport shared by the v3 client and the groups requests. prism-go-client
+// otherwise configures the TLS settings of http.DefaultTransport, which the groups requests can't reach.
+func newPrismTransport(creds prismgoclient.Credentials, cert *x509.Certificate) *http.Transport {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if cert != nil {
+		pool.AddCert(cert)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, InsecureSkipVerify: creds.Insecure} // #nosec G402
+	return transport
+}
+
+type groupsRequest struct {
+	EntityType            string                 `json:"entity_type"`
+	FilterCriteria        string                 `json:"filter_criteria,omitempty"`
+	GroupMemberAttributes []groupMemberAttribute `json:"group_member_attributes"`
+	GroupMemberCount      int                    `json:"group_member_count"`
+	GroupMemberOffset     int                    `json:"group_member_offset"`
+}
+
+type groupMemberAttribute struct {
+	Attribute string `json:"attribute"`
+}
+
+type groupsResponse struct {
+	FilteredEntityCount int `json:"filtered_entity_count"`
+	GroupResults        []struct {
+		EntityResults []struct {
+			EntityID string `json:"entity_id"`
+			Data     []struct {
+				Name   string `json:"name"`
+				Values []struct {
+					Values []string `json:"values"`
+				} `json:"values"`
+			} `json:"data"`
+		} `json:"entity_results"`
+	} `json:"group_results"`
+}
+
+// ListAllStorageContainer lists the storage containers of a Prism Element cluster using the
+// Prism Central groups API, which the v3 service does not expose.
+func (c *prismCentralClient) ListAllStorageContainer(ctx context.Context, clusterUUID string) ([]*v3.StorageContainerReference, error) {
+	containers := []*v3.StorageContainerReference{}
+	err := c.listStorageContainerGroups(ctx, clusterUUID, storageContainerNameAttribute, func(uuid, name string) {
+		containers = append(containers, &v3.StorageContainerReference{Kind: "storage_container", UUID: uuid, Name: name})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return containers, nil
+}
+
+// ListAllStorageContainerFreeBytes returns the logical capacity left in the storage containers of a
+// Prism Element cluster by container UUID. Containers Prism Central doesn't report it for are omitted.
+func (c *prismCentralClient) ListAllStorageContainerFreeBytes(ctx context.Context, clusterUUID string) (map[string]int64, error) {
+	freeBytes := map[string]int64{}
+	err := c.listStorageContainerGroups(ctx, clusterUUID, storageContainerFreeBytesAttribute, func(uuid, value string) {
+		if free, err := strconv.ParseInt(value, 10, 64); err == nil {
+			freeBytes[uuid] = free
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return freeBytes, nil
+}
+
+// listStorageContainerGroups pages through the storage containers of a Prism Element cluster, calling
+// add with the UUID of each container and the value of the requested attribute.
+func (c *prismCentralClient) listStorageContainerGroups(ctx context.Context, clusterUUID, attribute string, add func(uuid, value string)) error {
+	for offset, listed := 0, 0; ; offset += storageContainersPerPage {
+		resp, err := c.listGroups(ctx, groupsRequest{
+			EntityType:            "storage_container",
+			FilterCriteria:        "cluster==" + clusterUUID,
+			GroupMemberAttributes: []groupMemberAttribute{{Attribute: attribute}},
+			GroupMemberCount:      storageContainersPerPage,
+			GroupMemberOffset:     offset,
+		})
+		if err != nil {
+			return err
+		}
+
+		page := 0
+		for _, group := range resp.GroupResults {
+			for _, entity := range group.EntityResults {
+				page++
+				value := ""
+				for _, data := range entity.Data {
+					if data.Name == attribute && len(data.Values) > 0 && len(data.Values[0].Values) > 0 {
+						value = data.Values[0].Values[0]
+					}
+				}
+				add(entity.EntityID, value)
+			}
+		}
+
+		listed += page
+		if page == 0 || listed >= resp.FilteredEntityCount {
+			return nil
+		}
+	}
+}
+
+func (c *prismCentralClient) listGroups(ctx context.Context, request groupsRequest) (*groupsResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+c.credentials.URL+groupsAPIPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(c.credentials.Username, c.credentials.Password)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("listing %s groups: %v", request.EntityType, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s groups response: %v", request.EntityType, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("listing %s groups: %s: %s", request.EntityType, resp.Status, respBody)
+	}
+
+	groups := &groupsResponse{}
+	if err := json.Unmarshal(respBody, groups); err != nil {
+		return nil, fmt.Errorf("parsing %s groups response: %v", request.EntityType, err)
+	}
+
+	return groups, nil
+}
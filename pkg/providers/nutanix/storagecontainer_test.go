@@ -0,0 +1,120 @@
+package nutanix
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	prismgoclient "github.com/nutanix-cloud-native/prism-go-client"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	. "github.com/onsi/gomega"
+)
+
+func TestPrismCentralClientListAllStorageContainer(t *testing.T) {
+	g := NewWithT(t)
+	requests := []groupsRequest{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodPost))
+		g.Expect(r.URL.Path).To(Equal(groupsAPIPath))
+		username, password, ok := r.BasicAuth()
+		g.Expect(ok).To(BeTrue())
+		g.Expect(username).To(Equal("admin"))
+		g.Expect(password).To(Equal("password"))
+
+		request := groupsRequest{}
+		g.Expect(json.NewDecoder(r.Body).Decode(&request)).To(Succeed())
+		requests = append(requests, request)
+
+		fmt.Fprintf(w, `{"filtered_entity_count": %d, "group_results": [{"entity_results": [
+			{"entity_id": "container-%d", "data": [{"name": "container_name", "values": [{"values": ["name-%d"]}]}]}
+		]}]}`, 2, request.GroupMemberOffset, request.GroupMemberOffset)
+	}))
+	defer server.Close()
+
+	client := newTestPrismCentralClient(g, server, true, nil)
+	containers, err := client.ListAllStorageContainer(context.Background(), "cluster-uuid")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(containers).To(Equal([]*v3.StorageContainerReference{
+		{Kind: "storage_container", UUID: "container-0", Name: "name-0"},
+		{Kind: "storage_container", UUID: "container-500", Name: "name-500"},
+	}))
+	g.Expect(requests).To(HaveLen(2))
+	g.Expect(requests[0].EntityType).To(Equal("storage_container"))
+	g.Expect(requests[0].FilterCriteria).To(Equal("cluster==cluster-uuid"))
+	g.Expect(requests[1].GroupMemberOffset).To(Equal(storageContainersPerPage))
+}
+
+func TestPrismCentralClientListAllStorageContainerFreeBytes(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := groupsRequest{}
+		g.Expect(json.NewDecoder(r.Body).Decode(&request)).To(Succeed())
+		g.Expect(request.GroupMemberAttributes).To(Equal([]groupMemberAttribute{{Attribute: "storage.user_free_bytes"}}))
+
+		fmt.Fprint(w, `{"filtered_entity_count": 2, "group_results": [{"entity_results": [
+			{"entity_id": "container-1", "data": [{"name": "storage.user_free_bytes", "values": [{"values": ["1073741824"]}]}]},
+			{"entity_id": "container-2", "data": [{"name": "storage.user_free_bytes", "values": []}]}
+		]}]}`)
+	}))
+	defer server.Close()
+
+	client := newTestPrismCentralClient(g, server, true, nil)
+	freeBytes, err := client.ListAllStorageContainerFreeBytes(context.Background(), "cluster-uuid")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(freeBytes).To(Equal(map[string]int64{"container-1": 1073741824}))
+}
+
+func TestPrismCentralClientListAllStorageContainerError(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := newTestPrismCentralClient(g, server, true, nil)
+	_, err := client.ListAllStorageContainer(context.Background(), "cluster-uuid")
+	g.Expect(err).To(MatchError(ContainSubstring("listing storage_container groups: 401 Unauthorized: unauthorized")))
+}
+
+func TestPrismCentralClientListAllStorageContainerUntrustedCertificate(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"filtered_entity_count": 0}`)
+	}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	defer server.Close()
+
+	client := newTestPrismCentralClient(g, server, false, nil)
+	_, err := client.ListAllStorageContainer(context.Background(), "cluster-uuid")
+	g.Expect(err).To(MatchError(ContainSubstring("certificate")))
+	_, err = client.GetCluster(context.Background(), "cluster-uuid")
+	g.Expect(err).To(MatchError(ContainSubstring("certificate")))
+
+	client = newTestPrismCentralClient(g, server, false, server.Certificate())
+	_, err = client.ListAllStorageContainer(context.Background(), "cluster-uuid")
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = client.GetCluster(context.Background(), "cluster-uuid")
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func newTestPrismCentralClient(g Gomega, server *httptest.Server, insecure bool, cert *x509.Certificate) *prismCentralClient {
+	u, err := url.Parse(server.URL)
+	g.Expect(err).NotTo(HaveOccurred())
+	client, err := newPrismCentralClient(prismgoclient.Credentials{
+		URL:      u.Host,
+		Endpoint: u.Hostname(),
+		Port:     u.Port(),
+		Username: "admin",
+		Password: "password",
+		Insecure: insecure,
+	}, cert)
+	g.Expect(err).NotTo(HaveOccurred())
+	return client
+}
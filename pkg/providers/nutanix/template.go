@@ -258,6 +258,8 @@ func buildTemplateMapCP(
 		values["bootType"] = controlPlaneMachineSpec.BootType
 	}
 
+	addDataDisksTemplateValues(values, controlPlaneMachineSpec.DataDisks)
+
 	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration != nil {
 		registryMirror := registrymirror.FromCluster(clusterSpec.Cluster)
 		values["registryMirrorMap"] = containerd.ToAPIEndpoints(registryMirror.NamespacedRegistryMap)
@@ -312,6 +314,16 @@ func buildTemplateMapCP(
 		if len(etcdMachineSpec.AdditionalCategories) > 0 {
 			values["etcdAdditionalCategories"] = etcdMachineSpec.AdditionalCategories
 		}
+
+		if pool := inClusterIPPool(clusterSpec, etcdMachineSpec); pool != "" {
+			values["etcdIPPool"] = pool
+			values["ipPoolAnnotation"] = IPPoolAnnotation
+		}
+	}
+
+	if pool := inClusterIPPool(clusterSpec, controlPlaneMachineSpec); pool != "" {
+		values["ipPool"] = pool
+		values["ipPoolAnnotation"] = IPPoolAnnotation
 	}
 
 	if clusterSpec.AWSIamConfig != nil {
@@ -374,6 +386,16 @@ func buildTemplateMapCP(
 	return values, nil
 }
 
+// inClusterIPPool returns the name of the in-cluster ipPool of a machine config, or an empty string
+// if the machines don't use an ipPool or use a Prism managed one.
+func inClusterIPPool(clusterSpec *cluster.Spec, machineSpec v1alpha1.NutanixMachineConfigSpec) string {
+	pool := clusterSpec.NutanixDatacenter.IPPool(machineSpec.IPPool)
+	if pool == nil || pool.IsPrismManaged() {
+		return ""
+	}
+	return pool.Name
+}
+
 func calcFailureDomainReplicas(workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration, failureDomains []v1alpha1.NutanixDatacenterFailureDomain) map[string]int {
 	replicasPerFailureDomain := make(map[string]int)
 	failureDomainCount := len(failureDomains)
@@ -496,6 +518,13 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 		values["GPUs"] = workerNodeGroupMachineSpec.GPUs
 	}
 
+	addDataDisksTemplateValues(values, workerNodeGroupMachineSpec.DataDisks)
+
+	if pool := inClusterIPPool(clusterSpec, workerNodeGroupMachineSpec); pool != "" {
+		values["ipPool"] = pool
+		values["ipPoolAnnotation"] = IPPoolAnnotation
+	}
+
 	if workerNodeGroupConfiguration.KubeletConfiguration != nil {
 		wnKubeletConfig := workerNodeGroupConfiguration.KubeletConfiguration.Object
 		if _, ok := wnKubeletConfig["tlsCipherSuites"]; !ok {
@@ -528,16 +557,7 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 }
 
 func buildTemplateMapSecret(clusterSpec *cluster.Spec, secretName string, creds credentials.BasicAuthCredential) (map[string]interface{}, error) {
-	encodedCreds, err := jsonMarshal(creds)
-	if err != nil {
-		return nil, err
-	}
-
-	nutanixCreds := []credentials.Credential{{
-		Type: credentials.BasicAuthCredentialType,
-		Data: encodedCreds,
-	}}
-	credsJSON, err := jsonMarshal(nutanixCreds)
+	credsJSON, err := credentialsJSON(creds)
 	if err != nil {
 		return nil, err
 	}
@@ -554,6 +574,21 @@ func buildTemplateMapSecret(clusterSpec *cluster.Spec, secretName string, creds
 	return values, nil
 }
 
+// credentialsJSON returns the credentials in the format of the secrets read by CAPX and the Nutanix cloud provider.
+func credentialsJSON(creds credentials.BasicAuthCredential) ([]byte, error) {
+	encodedCreds, err := jsonMarshal(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	nutanixCreds := []credentials.Credential{{
+		Type: credentials.BasicAuthCredentialType,
+		Data: encodedCreds,
+	}}
+
+	return jsonMarshal(nutanixCreds)
+}
+
 func generateNoProxyList(clusterSpec *cluster.Spec) []string {
 	capacity := len(clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks) +
 		len(clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks) +
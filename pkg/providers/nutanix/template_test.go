@@ -850,6 +850,49 @@ func TestTemplateBuilderGPUs(t *testing.T) {
 	}
 }
 
+func TestTemplateBuilderDataDisks(t *testing.T) {
+	for _, tc := range []struct {
+		Input    string
+		Output   string
+		OutputMD string
+	}{
+		{
+			Input:    "testdata/eksa-cluster-data-disks.yaml",
+			Output:   "testdata/expected_results_data_disks.yaml",
+			OutputMD: "testdata/expected_results_data_disks_md.yaml",
+		},
+	} {
+		clusterSpec := test.NewFullClusterSpec(t, tc.Input)
+
+		machineCfg := clusterSpec.NutanixMachineConfig(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name)
+		workerConfs := map[string]anywherev1.NutanixMachineConfigSpec{
+			"eksa-unit-test": machineCfg.Spec,
+		}
+
+		t.Setenv(constants.EksaNutanixUsernameKey, "admin")
+		t.Setenv(constants.EksaNutanixPasswordKey, "password")
+		creds := GetCredsFromEnv()
+
+		bldr := NewNutanixTemplateBuilder(&clusterSpec.NutanixDatacenter.Spec, &machineCfg.Spec, &machineCfg.Spec,
+			workerConfs, creds, time.Now)
+
+		cpSpec, err := bldr.GenerateCAPISpecControlPlane(clusterSpec)
+		assert.NoError(t, err)
+		test.AssertContentToFile(t, string(cpSpec), tc.Output)
+
+		workloadTemplateNames := map[string]string{
+			"eksa-unit-test": "eksa-unit-test",
+		}
+		kubeadmconfigTemplateNames := map[string]string{
+			"eksa-unit-test": "eksa-unit-test",
+		}
+
+		data, err := bldr.GenerateCAPISpecWorkers(clusterSpec, workloadTemplateNames, kubeadmconfigTemplateNames)
+		assert.NoError(t, err)
+		test.AssertContentToFile(t, string(data), tc.OutputMD)
+	}
+}
+
 func TestTemplateBuilderBootType(t *testing.T) {
 	for _, tc := range []struct {
 		Input    string
@@ -1012,3 +1055,34 @@ func minimalNutanixConfigSpec(t *testing.T) (*anywherev1.NutanixDatacenterConfig
 
 	return dcConf, machineConf, workerConfs
 }
+
+func TestTemplateBuilderInClusterIPPool(t *testing.T) {
+	clusterSpec := test.NewFullClusterSpec(t, "testdata/eksa-cluster-ippool.yaml")
+
+	machineCfg := clusterSpec.NutanixMachineConfig(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name)
+	workerConfs := map[string]anywherev1.NutanixMachineConfigSpec{
+		"eksa-unit-test": machineCfg.Spec,
+	}
+
+	t.Setenv(constants.EksaNutanixUsernameKey, "admin")
+	t.Setenv(constants.EksaNutanixPasswordKey, "password")
+	creds := GetCredsFromEnv()
+
+	bldr := NewNutanixTemplateBuilder(&clusterSpec.NutanixDatacenter.Spec, &machineCfg.Spec, &machineCfg.Spec,
+		workerConfs, creds, time.Now)
+
+	cpSpec, err := bldr.GenerateCAPISpecControlPlane(clusterSpec)
+	assert.NoError(t, err)
+	test.AssertContentToFile(t, string(cpSpec), "testdata/expected_results_ippool_cp.yaml")
+
+	workloadTemplateNames := map[string]string{
+		"eksa-unit-test": "eksa-unit-test",
+	}
+	kubeadmconfigTemplateNames := map[string]string{
+		"eksa-unit-test": "eksa-unit-test",
+	}
+
+	data, err := bldr.GenerateCAPISpecWorkers(clusterSpec, workloadTemplateNames, kubeadmconfigTemplateNames)
+	assert.NoError(t, err)
+	test.AssertContentToFile(t, string(data), "testdata/expected_results_ippool_md.yaml")
+}
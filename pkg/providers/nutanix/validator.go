@@ -85,6 +85,10 @@ func (v *Validator) ValidateClusterSpec(ctx context.Context, spec *cluster.Spec,
 		return err
 	}
 
+	if err := v.validateIPPools(ctx, client, spec); err != nil {
+		return err
+	}
+
 	return v.checkImageNameMatchesKubernetesVersion(ctx, spec, client)
 }
 
@@ -379,9 +383,75 @@ func (v *Validator) ValidateMachineConfig(ctx context.Context, client Client, cl
 		return err
 	}
 
+	if err := v.validateDataDisksInMachineConfig(ctx, client, cluster, config); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func (v *Validator) validateDataDisksInMachineConfig(ctx context.Context, client Client, cluster *anywherev1.Cluster, config *anywherev1.NutanixMachineConfig) error {
+	if len(config.Spec.DataDisks) == 0 {
+		return nil
+	}
+
+	if cluster.Spec.ExternalEtcdConfiguration != nil && config.Name == cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name {
+		return fmt.Errorf("data disks are not supported for external etcd machine")
+	}
+
+	var containers []*v3.StorageContainerReference
+	for i, disk := range config.Spec.DataDisks {
+		if disk.StorageContainer == nil {
+			continue
+		}
+
+		if containers == nil {
+			clusterUUID, err := getClusterUUID(ctx, client, config.Spec.Cluster)
+			if err != nil {
+				return err
+			}
+
+			if containers, err = client.ListAllStorageContainer(ctx, clusterUUID); err != nil {
+				return fmt.Errorf("failed to list storage containers: %v", err)
+			}
+		}
+
+		if findStorageContainer(containers, *disk.StorageContainer) == nil {
+			return fmt.Errorf("storage container %s of data disk %d not found in cluster %s", resourceIdentifierString(*disk.StorageContainer), i, resourceIdentifierString(config.Spec.Cluster))
+		}
+	}
+
+	return nil
+}
+
+// findStorageContainer returns the storage container matching an identifier, nil if none does.
+func findStorageContainer(containers []*v3.StorageContainerReference, identifier anywherev1.NutanixResourceIdentifier) *v3.StorageContainerReference {
+	for _, container := range containers {
+		switch identifier.Type {
+		case anywherev1.NutanixIdentifierName:
+			if identifier.Name != nil && container.Name == *identifier.Name {
+				return container
+			}
+		case anywherev1.NutanixIdentifierUUID:
+			if identifier.UUID != nil && container.UUID == *identifier.UUID {
+				return container
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceIdentifierString(identifier anywherev1.NutanixResourceIdentifier) string {
+	if identifier.Type == anywherev1.NutanixIdentifierUUID && identifier.UUID != nil {
+		return *identifier.UUID
+	}
+	if identifier.Name != nil {
+		return *identifier.Name
+	}
+	return ""
+}
+
 func (v *Validator) validateGPUInMachineConfig(cluster *anywherev1.Cluster, config *anywherev1.NutanixMachineConfig) error {
 	if config.Spec.GPUs != nil {
 		if err := checkMachineConfigIsForWorker(config, cluster); err != nil {
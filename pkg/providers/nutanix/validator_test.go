@@ -805,6 +805,74 @@ func TestNutanixValidatorValidateMachineConfig(t *testing.T) {
 			},
 			expectedError: "GPUs are not supported for external etcd machine",
 		},
+		{
+			name: "data disks are not supported for external etcd machine",
+			setup: func(machineConf *anywherev1.NutanixMachineConfig, mockClient *mocknutanix.MockClient, validator *mockCrypto.MockTlsValidator, transport *mocknutanix.MockRoundTripper) *Validator {
+				mockClient.EXPECT().ListAllCluster(gomock.Any(), gomock.Any()).Return(fakeClusterList(), nil).Times(2)
+				mockClient.EXPECT().ListAllSubnet(gomock.Any(), gomock.Any(), gomock.Any()).Return(fakeSubnetList(), nil)
+				mockClient.EXPECT().ListAllImage(gomock.Any(), gomock.Any()).Return(fakeImageList(), nil)
+				machineConf.Name = "test-etcd"
+				machineConf.Spec.DataDisks = []anywherev1.NutanixDataDisk{{Size: resource.MustParse("100Gi")}}
+				return NewValidator(&ClientCache{}, validator, &http.Client{Transport: transport})
+			},
+			expectedError: "data disks are not supported for external etcd machine",
+		},
+		{
+			name: "data disk storage container not found",
+			setup: func(machineConf *anywherev1.NutanixMachineConfig, mockClient *mocknutanix.MockClient, validator *mockCrypto.MockTlsValidator, transport *mocknutanix.MockRoundTripper) *Validator {
+				mockClient.EXPECT().ListAllCluster(gomock.Any(), gomock.Any()).Return(fakeClusterList(), nil).Times(3)
+				mockClient.EXPECT().ListAllSubnet(gomock.Any(), gomock.Any(), gomock.Any()).Return(fakeSubnetList(), nil)
+				mockClient.EXPECT().ListAllImage(gomock.Any(), gomock.Any()).Return(fakeImageList(), nil)
+				mockClient.EXPECT().ListAllStorageContainer(gomock.Any(), "a15f6966-bfc7-4d1e-8575-224096fc1cdb").Return(fakeStorageContainerList(), nil)
+				machineConf.Spec.DataDisks = []anywherev1.NutanixDataDisk{{
+					Size:             resource.MustParse("100Gi"),
+					StorageContainer: &anywherev1.NutanixResourceIdentifier{Type: anywherev1.NutanixIdentifierName, Name: utils.StringPtr("missing-container")},
+				}}
+				return NewValidator(&ClientCache{}, validator, &http.Client{Transport: transport})
+			},
+			expectedError: "storage container missing-container of data disk 0 not found in cluster prism-cluster",
+		},
+		{
+			name: "list storage containers error",
+			setup: func(machineConf *anywherev1.NutanixMachineConfig, mockClient *mocknutanix.MockClient, validator *mockCrypto.MockTlsValidator, transport *mocknutanix.MockRoundTripper) *Validator {
+				mockClient.EXPECT().ListAllCluster(gomock.Any(), gomock.Any()).Return(fakeClusterList(), nil).Times(3)
+				mockClient.EXPECT().ListAllSubnet(gomock.Any(), gomock.Any(), gomock.Any()).Return(fakeSubnetList(), nil)
+				mockClient.EXPECT().ListAllImage(gomock.Any(), gomock.Any()).Return(fakeImageList(), nil)
+				mockClient.EXPECT().ListAllStorageContainer(gomock.Any(), "a15f6966-bfc7-4d1e-8575-224096fc1cdb").Return(nil, errors.New("error"))
+				machineConf.Spec.DataDisks = []anywherev1.NutanixDataDisk{{
+					Size:             resource.MustParse("100Gi"),
+					StorageContainer: &anywherev1.NutanixResourceIdentifier{Type: anywherev1.NutanixIdentifierName, Name: utils.StringPtr("default-container")},
+				}}
+				return NewValidator(&ClientCache{}, validator, &http.Client{Transport: transport})
+			},
+			expectedError: "failed to list storage containers: error",
+		},
+		{
+			name: "data disks validation pass",
+			setup: func(machineConf *anywherev1.NutanixMachineConfig, mockClient *mocknutanix.MockClient, validator *mockCrypto.MockTlsValidator, transport *mocknutanix.MockRoundTripper) *Validator {
+				mockClient.EXPECT().ListAllCluster(gomock.Any(), gomock.Any()).Return(fakeClusterList(), nil).Times(3)
+				mockClient.EXPECT().ListAllSubnet(gomock.Any(), gomock.Any(), gomock.Any()).Return(fakeSubnetList(), nil)
+				mockClient.EXPECT().ListAllImage(gomock.Any(), gomock.Any()).Return(fakeImageList(), nil)
+				mockClient.EXPECT().ListAllStorageContainer(gomock.Any(), "a15f6966-bfc7-4d1e-8575-224096fc1cdb").Return(fakeStorageContainerList(), nil)
+				machineConf.Name = "test-cp"
+				machineConf.Spec.DataDisks = []anywherev1.NutanixDataDisk{
+					{
+						Size:             resource.MustParse("100Gi"),
+						StorageContainer: &anywherev1.NutanixResourceIdentifier{Type: anywherev1.NutanixIdentifierName, Name: utils.StringPtr("default-container")},
+						MountPath:        "/var/lib/containerd",
+					},
+					{
+						Size:             resource.MustParse("10Gi"),
+						StorageContainer: &anywherev1.NutanixResourceIdentifier{Type: anywherev1.NutanixIdentifierUUID, UUID: utils.StringPtr("c15f6966-bfc7-4d1e-8575-224096fc1cdb")},
+					},
+					{
+						Size: resource.MustParse("10Gi"),
+					},
+				}
+				return NewValidator(&ClientCache{}, validator, &http.Client{Transport: transport})
+			},
+			expectedError: "",
+		},
 		{
 			name: "validation pass",
 			setup: func(machineConf *anywherev1.NutanixMachineConfig, mockClient *mocknutanix.MockClient, validator *mockCrypto.MockTlsValidator, transport *mocknutanix.MockRoundTripper) *Validator {
@@ -869,6 +937,16 @@ func TestNutanixValidatorValidateMachineConfig(t *testing.T) {
 	}
 }
 
+func fakeStorageContainerList() []*v3.StorageContainerReference {
+	return []*v3.StorageContainerReference{
+		{
+			Kind: "storage_container",
+			UUID: "c15f6966-bfc7-4d1e-8575-224096fc1cdb",
+			Name: "default-container",
+		},
+	}
+}
+
 func fakeHostList() *v3.HostListResponse {
 	return &v3.HostListResponse{
 		Entities: []*v3.HostResponse{
@@ -2,6 +2,7 @@ package snow
 
 import (
 	"context"
+	"io"
 
 	"github.com/aws/eks-anywhere/pkg/aws"
 )
@@ -11,8 +12,16 @@ type AwsClient interface {
 	EC2KeyNameExists(ctx context.Context, keyName string) (bool, error)
 	EC2ImportKeyPair(ctx context.Context, keyName string, keyMaterial []byte) error
 	EC2InstanceTypes(ctx context.Context) ([]aws.EC2InstanceType, error)
+	EC2Images(ctx context.Context) ([]aws.EC2Image, error)
+	EC2Instances(ctx context.Context) ([]aws.EC2Instance, error)
+	EC2ImportSnapshot(ctx context.Context, bucket, key, description string) (string, error)
+	EC2ImportSnapshotStatus(ctx context.Context, taskID string) (string, error)
+	EC2RegisterImage(ctx context.Context, name, snapshotID string) (string, error)
+	S3UploadObject(ctx context.Context, bucket, key string, body io.Reader) error
+	S3DeleteObject(ctx context.Context, bucket, key string) error
 	IsSnowballDeviceUnlocked(ctx context.Context) (bool, error)
 	SnowballDeviceSoftwareVersion(ctx context.Context) (string, error)
+	SnowballDeviceCapacities(ctx context.Context) ([]aws.SnowballDeviceCapacity, error)
 }
 
 // LocalIMDSClient contains methods that fetch metadata from the local imds.
@@ -50,6 +50,7 @@ func ControlPlaneSpec(ctx context.Context, logger logr.Logger, client kubernetes
 	capasPools.addPools(cpMachineConfig.Spec.Network.DirectNetworkInterfaces, clusterSpec.SnowIPPools)
 
 	cpMachineTemplate := MachineTemplate(clusterapi.ControlPlaneMachineTemplateName(clusterSpec.Cluster), cpMachineConfig, capasPools)
+	setMachineTemplatePlacement(cpMachineTemplate, cpMachineConfig, clusterSpec.SnowDatacenter)
 
 	kubeadmControlPlane, err := KubeadmControlPlane(logger, clusterSpec, cpMachineTemplate)
 	if err != nil {
@@ -63,6 +64,7 @@ func ControlPlaneSpec(ctx context.Context, logger logr.Logger, client kubernetes
 		etcdMachineConfig := clusterSpec.SnowMachineConfigs[clusterSpec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name]
 		capasPools.addPools(etcdMachineConfig.Spec.Network.DirectNetworkInterfaces, clusterSpec.SnowIPPools)
 		etcdMachineTemplate = MachineTemplate(clusterapi.EtcdMachineTemplateName(clusterSpec.Cluster), etcdMachineConfig, capasPools)
+		setMachineTemplatePlacement(etcdMachineTemplate, etcdMachineConfig, clusterSpec.SnowDatacenter)
 		etcdCluster = EtcdadmCluster(logger, clusterSpec, etcdMachineTemplate)
 	}
 
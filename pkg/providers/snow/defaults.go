@@ -128,6 +128,89 @@ func (md *MachineConfigDefaulters) defaultSSHKeyName(clusterName string) string
 	return fmt.Sprintf("%s-%s-%s", defaultAwsSshKeyName, clusterName, md.defaulters.uuid.String())
 }
 
+// SetupDefaultAMIIDs sets the AMI ID of the machine configs with no AMI ID to the image imported for their
+// Kubernetes version and os family with the import snow-images command, when it's registered with the same ID
+// on every device their machines can be placed on. An image imported onto several devices gets a different ID
+// on each one, so those machine configs keep no AMI ID and CAPAS looks the image up by name on each device.
+func (d *Defaulters) SetupDefaultAMIIDs(ctx context.Context, clusterSpec *cluster.Spec) error {
+	// image names of the node groups of each machine config, a machine config used with several
+	// Kubernetes versions can't default to a single image.
+	imageNames := map[string]map[string]bool{}
+	addImageName := func(ref *v1alpha1.Ref, versionsBundle *cluster.VersionsBundle) {
+		if ref == nil {
+			return
+		}
+		machineConfig, ok := clusterSpec.SnowMachineConfigs[ref.Name]
+		if !ok || machineConfig.Spec.AMIID != "" {
+			return
+		}
+		if imageNames[ref.Name] == nil {
+			imageNames[ref.Name] = map[string]bool{}
+		}
+		name := ""
+		if versionsBundle != nil {
+			var err error
+			if name, err = ImageName(machineConfig.OSFamily(), *versionsBundle.VersionsBundle); err != nil {
+				logger.V(4).Info("Not defaulting AMI ID", "machineConfig", ref.Name, "reason", err)
+			}
+		}
+		imageNames[ref.Name][name] = true
+	}
+
+	cp := clusterSpec.Cluster.Spec.ControlPlaneConfiguration
+	addImageName(cp.MachineGroupRef, clusterSpec.RootVersionsBundle())
+	if etcd := clusterSpec.Cluster.Spec.ExternalEtcdConfiguration; etcd != nil {
+		addImageName(etcd.MachineGroupRef, clusterSpec.RootVersionsBundle())
+	}
+	for _, wng := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		addImageName(wng.MachineGroupRef, clusterSpec.WorkerNodeGroupVersionsBundle(wng))
+	}
+
+	if len(imageNames) == 0 {
+		return nil
+	}
+
+	clientMap, err := d.clientRegistry.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	for machineConfigName, names := range imageNames {
+		if len(names) != 1 || names[""] {
+			continue
+		}
+		var imageName string
+		for name := range names {
+			imageName = name
+		}
+
+		machineConfig := clusterSpec.SnowMachineConfigs[machineConfigName]
+		imageIDs := map[string]bool{}
+		for _, ip := range placementDevices(machineConfig, clusterSpec.SnowDatacenter) {
+			client, ok := clientMap[ip]
+			if !ok {
+				return fmt.Errorf("credentials not found for device [%s]", ip)
+			}
+			imageID, err := existingImage(ctx, client, imageName)
+			if err != nil {
+				return fmt.Errorf("describing images on snow device [%s]: %v", ip, err)
+			}
+			imageIDs[imageID] = true
+		}
+
+		if len(imageIDs) != 1 || imageIDs[""] {
+			logger.V(4).Info("Not defaulting AMI ID, the image doesn't have the same ID on every device", "machineConfig", machineConfigName, "image", imageName)
+			continue
+		}
+		for imageID := range imageIDs {
+			logger.V(1).Info("SnowMachineConfig AMI ID is empty. Using the imported image", "machineConfig", machineConfigName, "image", imageName, "amiID", imageID)
+			machineConfig.Spec.AMIID = imageID
+		}
+	}
+
+	return nil
+}
+
 func SetupEksaCredentialsSecret(c *cluster.Config) error {
 	creds, err := aws.EncodeFileFromEnv(eksaSnowCredentialsFileKey)
 	if err != nil {
@@ -6,7 +6,10 @@ import (
 
 	. "github.com/onsi/gomega"
 
+	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/aws"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 )
 
 const (
@@ -100,3 +103,81 @@ func TestSetDefaultSSHKeyDeviceNotFoundInClientMap(t *testing.T) {
 	err := g.machineConfigDefaulters.SetupDefaultSSHKey(g.ctx, g.machineConfig, g.clusterName)
 	g.Expect(err).To(MatchError(ContainSubstring("credentials not found for device")))
 }
+
+func givenAMIClusterSpec(machineConfig *v1alpha1.SnowMachineConfig) *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube129
+		s.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef = &v1alpha1.Ref{Kind: v1alpha1.SnowMachineConfigKind, Name: machineConfig.Name}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = nil
+		s.SnowMachineConfigs = map[string]*v1alpha1.SnowMachineConfig{machineConfig.Name: machineConfig}
+		s.SnowDatacenter = &v1alpha1.SnowDatacenterConfig{}
+		vb := givenImageVersionsBundle()
+		s.VersionsBundles = map[v1alpha1.KubernetesVersion]*cluster.VersionsBundle{
+			v1alpha1.Kube129: {VersionsBundle: &vb, KubeDistro: &cluster.KubeDistro{}},
+		}
+	})
+}
+
+func TestSetupDefaultAMIIDs(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.machineConfig.Spec.AMIID = ""
+	g.machineConfig.Spec.OSFamily = v1alpha1.Bottlerocket
+	spec := givenAMIClusterSpec(g.machineConfig)
+	g.aws.EXPECT().EC2Images(g.ctx).Return([]aws.EC2Image{{ID: "s.ami-1", Name: testImageName}}, nil).Times(2)
+
+	g.Expect(g.defaulters.SetupDefaultAMIIDs(g.ctx, spec)).To(Succeed())
+	g.Expect(g.machineConfig.Spec.AMIID).To(Equal("s.ami-1"))
+}
+
+func TestSetupDefaultAMIIDsPinned(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.machineConfig.Spec.AMIID = ""
+	g.machineConfig.Spec.OSFamily = v1alpha1.Bottlerocket
+	g.machineConfig.Spec.Placement = &v1alpha1.SnowPlacement{Policy: v1alpha1.SnowPlacementPin}
+	spec := givenAMIClusterSpec(g.machineConfig)
+	g.aws.EXPECT().EC2Images(g.ctx).Return([]aws.EC2Image{{ID: "s.ami-1", Name: testImageName}}, nil)
+
+	g.Expect(g.defaulters.SetupDefaultAMIIDs(g.ctx, spec)).To(Succeed())
+	g.Expect(g.machineConfig.Spec.AMIID).To(Equal("s.ami-1"))
+}
+
+func TestSetupDefaultAMIIDsDifferentIDsPerDevice(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.machineConfig.Spec.AMIID = ""
+	g.machineConfig.Spec.OSFamily = v1alpha1.Bottlerocket
+	spec := givenAMIClusterSpec(g.machineConfig)
+	g.aws.EXPECT().EC2Images(g.ctx).Return([]aws.EC2Image{{ID: "s.ami-1", Name: testImageName}}, nil)
+	g.aws.EXPECT().EC2Images(g.ctx).Return([]aws.EC2Image{{ID: "s.ami-2", Name: testImageName}}, nil)
+
+	g.Expect(g.defaulters.SetupDefaultAMIIDs(g.ctx, spec)).To(Succeed())
+	g.Expect(g.machineConfig.Spec.AMIID).To(BeEmpty())
+}
+
+func TestSetupDefaultAMIIDsImageMissing(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.machineConfig.Spec.AMIID = ""
+	g.machineConfig.Spec.OSFamily = v1alpha1.Bottlerocket
+	spec := givenAMIClusterSpec(g.machineConfig)
+	g.aws.EXPECT().EC2Images(g.ctx).Return(nil, nil).Times(2)
+
+	g.Expect(g.defaulters.SetupDefaultAMIIDs(g.ctx, spec)).To(Succeed())
+	g.Expect(g.machineConfig.Spec.AMIID).To(BeEmpty())
+}
+
+func TestSetupDefaultAMIIDsExisting(t *testing.T) {
+	g := newConfigManagerTest(t)
+	spec := givenAMIClusterSpec(g.machineConfig)
+
+	g.Expect(g.defaulters.SetupDefaultAMIIDs(g.ctx, spec)).To(Succeed())
+	g.Expect(g.machineConfig.Spec.AMIID).To(Equal("ami-1"))
+}
+
+func TestSetupDefaultAMIIDsError(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.machineConfig.Spec.AMIID = ""
+	g.machineConfig.Spec.OSFamily = v1alpha1.Bottlerocket
+	spec := givenAMIClusterSpec(g.machineConfig)
+	g.aws.EXPECT().EC2Images(g.ctx).Return(nil, errors.New("test error"))
+
+	g.Expect(g.defaulters.SetupDefaultAMIIDs(g.ctx, spec)).To(MatchError("describing images on snow device [device-1]: test error"))
+}
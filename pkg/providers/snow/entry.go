@@ -36,6 +36,12 @@ func (cm *ConfigManager) SetDefaultsAndValidate(ctx context.Context, config *clu
 	return nil
 }
 
+// SetDefaultAMIIDs sets the AMI ID of the machine configs with no AMI ID to the image imported on their devices
+// for the Kubernetes version of their node groups.
+func (cm *ConfigManager) SetDefaultAMIIDs(ctx context.Context, spec *cluster.Spec) error {
+	return cm.defaulters.SetupDefaultAMIIDs(ctx, spec)
+}
+
 func (cm *ConfigManager) snowEntry(ctx context.Context) *cluster.ConfigManagerEntry {
 	return &cluster.ConfigManagerEntry{
 		Defaulters: []cluster.Defaulter{
@@ -90,6 +96,10 @@ func (cm *ConfigManager) snowEntry(ctx context.Context) *cluster.ConfigManagerEn
 			func(c *cluster.Config) error {
 				return cm.validator.ValidateControlPlaneIP(ctx, c.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host)
 			},
+			func(c *cluster.Config) error {
+				return cm.validator.ValidatePlacement(ctx, c)
+			},
+			ValidateIPPoolCapacity,
 		},
 	}
 }
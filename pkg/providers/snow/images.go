@@ -0,0 +1,283 @@
+package snow
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	// imageNameFormat matches the default image lookup format of CAPAS, capas-ami-{{.BaseOS}}-.?{{.K8sVersion}}-*,
+	// so machines with no AMI ID are created from the image imported on their device.
+	imageNameFormat           = "capas-ami-%s-%s-eks-a"
+	ubuntuImageFileFormat     = "ubuntu-%s.raw"
+	eksdReleaseNumberSep      = "-eks-"
+	gzipExtension             = ".gz"
+	imageObjectKeyExtension   = ".raw"
+	defaultImportPollInterval = 10 * time.Second
+	defaultImportTimeout      = 2 * time.Hour
+)
+
+// ImageName returns the name a node image for a Kubernetes release is registered with on the devices.
+func ImageName(osFamily v1alpha1.OSFamily, versionsBundle releasev1.VersionsBundle) (string, error) {
+	eksd := versionsBundle.EksD
+	i := strings.LastIndex(eksd.Name, eksdReleaseNumberSep)
+	if eksd.KubeVersion == "" || eksd.ReleaseChannel == "" || i < 0 {
+		return "", fmt.Errorf("eks-d release for kubernetes version %s is incomplete in the bundles manifest", versionsBundle.KubeVersion)
+	}
+	number := eksd.Name[i+len(eksdReleaseNumberSep):]
+
+	// The machines kubernetes version is the tag of the eks-d kube-apiserver image, v1.28.3-eks-1-28-12.
+	tag := fmt.Sprintf("%s-eks-%s-%s", eksd.KubeVersion, eksd.ReleaseChannel, number)
+	return fmt.Sprintf(imageNameFormat, osFamily, tag), nil
+}
+
+// ImageFile returns the name of the raw image file for a Kubernetes release. The Bottlerocket image is the
+// one published in the bundles manifest, while Ubuntu images have to be built and named after the eks-d channel.
+func ImageFile(osFamily v1alpha1.OSFamily, versionsBundle releasev1.VersionsBundle) (string, error) {
+	switch osFamily {
+	case v1alpha1.Bottlerocket:
+		uri := versionsBundle.EksD.Ami.Bottlerocket.URI
+		if uri == "" {
+			return "", fmt.Errorf("no bottlerocket image in the bundles manifest for kubernetes version %s", versionsBundle.KubeVersion)
+		}
+		return path.Base(uri), nil
+	case v1alpha1.Ubuntu:
+		return fmt.Sprintf(ubuntuImageFileFormat, versionsBundle.EksD.ReleaseChannel), nil
+	default:
+		return "", fmt.Errorf("os family %s is not supported for snow images", osFamily)
+	}
+}
+
+// ImportedImage is an image imported onto a device.
+type ImportedImage struct {
+	Device  string `json:"device"`
+	Name    string `json:"name"`
+	ImageID string `json:"imageID"`
+	// Existing is true if the image was already on the device.
+	Existing bool `json:"existing"`
+}
+
+// ImportedImages is the list of images imported onto the devices.
+type ImportedImages []ImportedImage
+
+// Write writes the imported images in a human readable format.
+func (i ImportedImages) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(tw, "DEVICE\tIMAGE NAME\tIMAGE ID\tEXISTING")
+	for _, image := range i {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%t\n", image.Device, image.Name, image.ImageID, image.Existing)
+	}
+	return tw.Flush()
+}
+
+// ImageImporter imports raw images onto the snow devices through their s3 adapter and ec2 endpoints.
+type ImageImporter struct {
+	clientRegistry ClientRegistry
+	bucket         string
+	pollInterval   time.Duration
+	timeout        time.Duration
+}
+
+// ImageImporterOpt configures an ImageImporter.
+type ImageImporterOpt func(*ImageImporter)
+
+// WithImportPollInterval sets the interval to check the status of the snapshot imports.
+func WithImportPollInterval(interval time.Duration) ImageImporterOpt {
+	return func(i *ImageImporter) {
+		i.pollInterval = interval
+	}
+}
+
+// WithImportTimeout sets the maximum time to wait for a snapshot import.
+func WithImportTimeout(timeout time.Duration) ImageImporterOpt {
+	return func(i *ImageImporter) {
+		i.timeout = timeout
+	}
+}
+
+// NewImageImporter builds an ImageImporter that uploads the images to a bucket of the devices.
+func NewImageImporter(clientRegistry ClientRegistry, bucket string, opts ...ImageImporterOpt) *ImageImporter {
+	i := &ImageImporter{
+		clientRegistry: clientRegistry,
+		bucket:         bucket,
+		pollInterval:   defaultImportPollInterval,
+		timeout:        defaultImportTimeout,
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// Import imports the raw image in file onto every device and registers it with name. Devices with an image
+// with that name are skipped, so the import can be retried. Gzip compressed files are decompressed first.
+func (i *ImageImporter) Import(ctx context.Context, name, file string) (ImportedImages, error) {
+	clientMap, err := i.clientRegistry.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := make([]string, 0, len(clientMap))
+	for ip := range clientMap {
+		devices = append(devices, ip)
+	}
+	sort.Strings(devices)
+
+	var image *os.File
+	var size int64
+	imported := make(ImportedImages, 0, len(devices))
+	for _, device := range devices {
+		client := clientMap[device]
+		imageID, err := existingImage(ctx, client, name)
+		if err != nil {
+			return nil, fmt.Errorf("checking images on device [%s]: %v", device, err)
+		}
+		if imageID != "" {
+			logger.V(4).Info("Image already exists on device", "device", device, "image", name)
+			imported = append(imported, ImportedImage{Device: device, Name: name, ImageID: imageID, Existing: true})
+			continue
+		}
+
+		if image == nil {
+			var cleanup func()
+			if image, size, cleanup, err = openRawImage(file); err != nil {
+				return nil, err
+			}
+			defer cleanup()
+		}
+
+		logger.Info("Importing image onto device", "device", device, "image", name)
+		if imageID, err = i.importOnDevice(ctx, client, name, io.NewSectionReader(image, 0, size)); err != nil {
+			return nil, fmt.Errorf("importing image %s onto device [%s]: %v", name, device, err)
+		}
+		imported = append(imported, ImportedImage{Device: device, Name: name, ImageID: imageID})
+	}
+
+	return imported, nil
+}
+
+func (i *ImageImporter) importOnDevice(ctx context.Context, client AwsClient, name string, image io.Reader) (string, error) {
+	key := name + imageObjectKeyExtension
+	if err := client.S3UploadObject(ctx, i.bucket, key, image); err != nil {
+		return "", err
+	}
+
+	// The snapshot holds a copy of the image, so the object only takes space on the device once it's imported.
+	defer func() {
+		if err := client.S3DeleteObject(ctx, i.bucket, key); err != nil {
+			logger.Info("Warning: failed to delete the uploaded image from the device bucket", "bucket", i.bucket, "key", key, "error", err)
+		}
+	}()
+
+	taskID, err := client.EC2ImportSnapshot(ctx, i.bucket, key, name)
+	if err != nil {
+		return "", err
+	}
+
+	snapshotID, err := i.waitForSnapshot(ctx, client, taskID)
+	if err != nil {
+		return "", err
+	}
+
+	return client.EC2RegisterImage(ctx, name, snapshotID)
+}
+
+func (i *ImageImporter) waitForSnapshot(ctx context.Context, client AwsClient, taskID string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(i.pollInterval)
+	defer ticker.Stop()
+	for {
+		snapshotID, err := client.EC2ImportSnapshotStatus(ctx, taskID)
+		if err != nil {
+			return "", err
+		}
+		if snapshotID != "" {
+			return snapshotID, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("waiting for import snapshot task [%s]: %v", taskID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func existingImage(ctx context.Context, client AwsClient, name string) (string, error) {
+	images, err := client.EC2Images(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, image := range images {
+		if image.Name == name {
+			return image.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// openRawImage opens a raw image file, decompressing it to a temporary file if it's gzip compressed.
+func openRawImage(file string) (image *os.File, size int64, cleanup func(), err error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("opening image: %v", err)
+	}
+
+	if strings.HasSuffix(file, gzipExtension) {
+		defer f.Close()
+		if f, err = decompressImage(f); err != nil {
+			return nil, 0, nil, err
+		}
+		tmp := f
+		cleanup = func() {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	} else {
+		cleanup = func() { f.Close() }
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		cleanup()
+		return nil, 0, nil, fmt.Errorf("reading image size: %v", err)
+	}
+
+	return f, info.Size(), cleanup, nil
+}
+
+func decompressImage(compressed io.Reader) (*os.File, error) {
+	gz, err := gzip.NewReader(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("reading gzip image: %v", err)
+	}
+	defer gz.Close()
+
+	raw, err := os.CreateTemp("", "snow-image-*.raw")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary image file: %v", err)
+	}
+
+	logger.V(4).Info("Decompressing image", "file", raw.Name())
+	if _, err := io.Copy(raw, gz); err != nil {
+		raw.Close()
+		os.Remove(raw.Name())
+		return nil, fmt.Errorf("decompressing image: %v", err)
+	}
+
+	return raw, nil
+}
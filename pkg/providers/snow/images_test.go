@@ -0,0 +1,240 @@
+package snow_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/aws"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	"github.com/aws/eks-anywhere/pkg/providers/snow/mocks"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const testImageName = "capas-ami-bottlerocket-v1.29.15-eks-1-29-58-eks-a"
+
+func givenImageVersionsBundle() releasev1.VersionsBundle {
+	return releasev1.VersionsBundle{
+		KubeVersion: "1.29",
+		EksD: releasev1.EksDRelease{
+			Name:           "kubernetes-1-29-eks-58",
+			ReleaseChannel: "1-29",
+			KubeVersion:    "v1.29.15",
+			Ami: releasev1.OSImageBundle{
+				Bottlerocket: releasev1.Archive{
+					URI: "https://release-bucket/artifacts/1-29/bottlerocket-v1.29.15-eks-d-1-29-58-amd64.img.gz",
+				},
+			},
+		},
+	}
+}
+
+func TestImageName(t *testing.T) {
+	g := NewWithT(t)
+	got, err := snow.ImageName(v1alpha1.Bottlerocket, givenImageVersionsBundle())
+	g.Expect(err).To(Succeed())
+	g.Expect(got).To(Equal(testImageName))
+}
+
+func TestImageNameIncompleteEksD(t *testing.T) {
+	g := NewWithT(t)
+	vb := givenImageVersionsBundle()
+	vb.EksD.Name = "kubernetes-1-29"
+	_, err := snow.ImageName(v1alpha1.Ubuntu, vb)
+	g.Expect(err).To(MatchError("eks-d release for kubernetes version 1.29 is incomplete in the bundles manifest"))
+}
+
+func TestImageFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		osFamily v1alpha1.OSFamily
+		bundle   func(*releasev1.VersionsBundle)
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "bottlerocket",
+			osFamily: v1alpha1.Bottlerocket,
+			want:     "bottlerocket-v1.29.15-eks-d-1-29-58-amd64.img.gz",
+		},
+		{
+			name:     "bottlerocket not in bundle",
+			osFamily: v1alpha1.Bottlerocket,
+			bundle: func(vb *releasev1.VersionsBundle) {
+				vb.EksD.Ami.Bottlerocket.URI = ""
+			},
+			wantErr: "no bottlerocket image in the bundles manifest for kubernetes version 1.29",
+		},
+		{
+			name:     "ubuntu",
+			osFamily: v1alpha1.Ubuntu,
+			want:     "ubuntu-1-29.raw",
+		},
+		{
+			name:     "unsupported",
+			osFamily: v1alpha1.RedHat,
+			wantErr:  "os family redhat is not supported for snow images",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			vb := givenImageVersionsBundle()
+			if tc.bundle != nil {
+				tc.bundle(&vb)
+			}
+			got, err := snow.ImageFile(tc.osFamily, vb)
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(tc.wantErr))
+				return
+			}
+			g.Expect(err).To(Succeed())
+			g.Expect(got).To(Equal(tc.want))
+		})
+	}
+}
+
+type imageImporterTest struct {
+	*WithT
+	ctx      context.Context
+	devices  map[string]*mocks.MockAwsClient
+	importer *snow.ImageImporter
+}
+
+func newImageImporterTest(t *testing.T) *imageImporterTest {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	devices := map[string]*mocks.MockAwsClient{
+		"1.2.3.4": mocks.NewMockAwsClient(ctrl),
+		"1.2.3.5": mocks.NewMockAwsClient(ctrl),
+	}
+	clientMap := snow.AwsClientMap{}
+	for ip, client := range devices {
+		clientMap[ip] = client
+	}
+	registry := mocks.NewMockClientRegistry(ctrl)
+	registry.EXPECT().Get(ctx).Return(clientMap, nil)
+	return &imageImporterTest{
+		WithT:    NewWithT(t),
+		ctx:      ctx,
+		devices:  devices,
+		importer: snow.NewImageImporter(registry, "bucket", snow.WithImportPollInterval(time.Millisecond), snow.WithImportTimeout(time.Second)),
+	}
+}
+
+func writeImageFile(t *testing.T, name string, content []byte) string {
+	file := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(file, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func (tt *imageImporterTest) expectImport(device, content string) {
+	client := tt.devices[device]
+	client.EXPECT().EC2Images(tt.ctx).Return([]aws.EC2Image{{ID: "s.ami-other", Name: "other"}}, nil)
+	client.EXPECT().S3UploadObject(tt.ctx, "bucket", testImageName+".raw", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, body io.Reader) error {
+			b, err := io.ReadAll(body)
+			tt.Expect(err).To(Succeed())
+			tt.Expect(string(b)).To(Equal(content))
+			return nil
+		})
+	client.EXPECT().EC2ImportSnapshot(tt.ctx, "bucket", testImageName+".raw", testImageName).Return("s.import-snap-1", nil)
+	gomock.InOrder(
+		client.EXPECT().EC2ImportSnapshotStatus(gomock.Any(), "s.import-snap-1").Return("", nil),
+		client.EXPECT().EC2ImportSnapshotStatus(gomock.Any(), "s.import-snap-1").Return("s.snap-1", nil),
+	)
+	client.EXPECT().EC2RegisterImage(tt.ctx, testImageName, "s.snap-1").Return("s.ami-"+device, nil)
+	client.EXPECT().S3DeleteObject(tt.ctx, "bucket", testImageName+".raw").Return(nil)
+}
+
+func TestImageImporterImport(t *testing.T) {
+	tt := newImageImporterTest(t)
+	file := writeImageFile(t, "image.raw", []byte("raw image"))
+	tt.devices["1.2.3.4"].EXPECT().EC2Images(tt.ctx).Return([]aws.EC2Image{{ID: "s.ami-existing", Name: testImageName}}, nil)
+	tt.expectImport("1.2.3.5", "raw image")
+
+	got, err := tt.importer.Import(tt.ctx, testImageName, file)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(got).To(Equal(snow.ImportedImages{
+		{Device: "1.2.3.4", Name: testImageName, ImageID: "s.ami-existing", Existing: true},
+		{Device: "1.2.3.5", Name: testImageName, ImageID: "s.ami-1.2.3.5"},
+	}))
+}
+
+func TestImageImporterImportCompressed(t *testing.T) {
+	tt := newImageImporterTest(t)
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err := gz.Write([]byte("raw image"))
+	tt.Expect(err).To(Succeed())
+	tt.Expect(gz.Close()).To(Succeed())
+	file := writeImageFile(t, "image.img.gz", compressed.Bytes())
+	tt.expectImport("1.2.3.4", "raw image")
+	tt.expectImport("1.2.3.5", "raw image")
+
+	got, err := tt.importer.Import(tt.ctx, testImageName, file)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(got).To(HaveLen(2))
+}
+
+func TestImageImporterImportSnapshotFailed(t *testing.T) {
+	tt := newImageImporterTest(t)
+	file := writeImageFile(t, "image.raw", []byte("raw image"))
+	client := tt.devices["1.2.3.4"]
+	client.EXPECT().EC2Images(tt.ctx).Return(nil, nil)
+	client.EXPECT().S3UploadObject(tt.ctx, "bucket", testImageName+".raw", gomock.Any()).Return(nil)
+	client.EXPECT().EC2ImportSnapshot(tt.ctx, "bucket", testImageName+".raw", testImageName).Return("s.import-snap-1", nil)
+	client.EXPECT().EC2ImportSnapshotStatus(gomock.Any(), "s.import-snap-1").Return("", errors.New("import snapshot task [s.import-snap-1] failed: invalid image"))
+	client.EXPECT().S3DeleteObject(tt.ctx, "bucket", testImageName+".raw").Return(nil)
+
+	_, err := tt.importer.Import(tt.ctx, testImageName, file)
+	tt.Expect(err).To(MatchError("importing image " + testImageName + " onto device [1.2.3.4]: import snapshot task [s.import-snap-1] failed: invalid image"))
+}
+
+func TestImageImporterImportDeleteObjectError(t *testing.T) {
+	tt := newImageImporterTest(t)
+	file := writeImageFile(t, "image.raw", []byte("raw image"))
+	tt.devices["1.2.3.4"].EXPECT().EC2Images(tt.ctx).Return([]aws.EC2Image{{ID: "s.ami-existing", Name: testImageName}}, nil)
+	client := tt.devices["1.2.3.5"]
+	client.EXPECT().EC2Images(tt.ctx).Return(nil, nil)
+	client.EXPECT().S3UploadObject(tt.ctx, "bucket", testImageName+".raw", gomock.Any()).Return(nil)
+	client.EXPECT().EC2ImportSnapshot(tt.ctx, "bucket", testImageName+".raw", testImageName).Return("s.import-snap-1", nil)
+	client.EXPECT().EC2ImportSnapshotStatus(gomock.Any(), "s.import-snap-1").Return("s.snap-1", nil)
+	client.EXPECT().EC2RegisterImage(tt.ctx, testImageName, "s.snap-1").Return("s.ami-1", nil)
+	client.EXPECT().S3DeleteObject(tt.ctx, "bucket", testImageName+".raw").Return(errors.New("delete failed"))
+
+	got, err := tt.importer.Import(tt.ctx, testImageName, file)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(got[1].ImageID).To(Equal("s.ami-1"))
+}
+
+func TestImageImporterImportFileNotFound(t *testing.T) {
+	tt := newImageImporterTest(t)
+	tt.devices["1.2.3.4"].EXPECT().EC2Images(tt.ctx).Return(nil, nil)
+
+	_, err := tt.importer.Import(tt.ctx, testImageName, filepath.Join(t.TempDir(), "missing.raw"))
+	tt.Expect(err).To(MatchError(ContainSubstring("opening image")))
+}
+
+func TestImportedImagesWrite(t *testing.T) {
+	g := NewWithT(t)
+	images := snow.ImportedImages{
+		{Device: "1.2.3.4", Name: testImageName, ImageID: "s.ami-1", Existing: true},
+	}
+	var b bytes.Buffer
+	g.Expect(images.Write(&b)).To(Succeed())
+	g.Expect(b.String()).To(ContainSubstring("DEVICE"))
+	g.Expect(b.String()).To(ContainSubstring("1.2.3.4   " + testImageName + "   s.ami-1    true"))
+}
@@ -0,0 +1,255 @@
+package snow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/eks-anywhere/pkg/aws"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+)
+
+const (
+	vCPUCapacityName          = "vCPU"
+	terminatedInstanceState   = "terminated"
+	instanceNameTagKey        = "Name"
+	deviceHealthy             = "healthy"
+	deviceHealthLocked        = "locked"
+	deviceHealthUnsupported   = "unsupported software"
+	deviceHealthUnreachable   = "unreachable"
+	unknownInventoryValueText = "unknown"
+)
+
+// DeviceInventory is the inventory of the snow devices configured in the aws credentials file.
+type DeviceInventory struct {
+	Devices []DeviceReport `json:"devices"`
+}
+
+// DeviceReport reports the state of a snow device and the cluster machines running on it.
+type DeviceReport struct {
+	IP              string                       `json:"ip"`
+	Health          string                       `json:"health"`
+	Unlocked        bool                         `json:"unlocked"`
+	SoftwareVersion string                       `json:"softwareVersion,omitempty"`
+	Capacities      []aws.SnowballDeviceCapacity `json:"capacities,omitempty"`
+	InstanceTypes   []InstanceTypeCapacity       `json:"instanceTypes,omitempty"`
+	Images          []aws.EC2Image               `json:"images,omitempty"`
+	Machines        []DeviceMachine              `json:"machines,omitempty"`
+	// Error is the reason the device couldn't be inventoried, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// InstanceTypeCapacity is the number of instances of a type that fit in the vCPUs available in a device.
+type InstanceTypeCapacity struct {
+	Name        string `json:"name"`
+	DefaultVCPU *int32 `json:"defaultVCPU,omitempty"`
+	// Available is nil when the vCPUs of the instance type or the device are unknown.
+	Available *int64 `json:"available,omitempty"`
+}
+
+// DeviceMachine is a cluster machine running on a device.
+type DeviceMachine struct {
+	Cluster      string `json:"cluster"`
+	Name         string `json:"name"`
+	InstanceID   string `json:"instanceID"`
+	InstanceType string `json:"instanceType"`
+	State        string `json:"state"`
+}
+
+// Healthy returns true if all the devices are reachable, unlocked and run a supported software version.
+func (i *DeviceInventory) Healthy() bool {
+	for _, d := range i.Devices {
+		if d.Health != deviceHealthy {
+			return false
+		}
+	}
+	return true
+}
+
+// GetDeviceInventory reports the state of every device with a client in the registry.
+// Errors reaching a device are recorded in its report instead of failing the whole inventory.
+func GetDeviceInventory(ctx context.Context, clientRegistry ClientRegistry) (*DeviceInventory, error) {
+	clientMap, err := clientRegistry.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ips := make([]string, 0, len(clientMap))
+	for ip := range clientMap {
+		ips = append(ips, ip)
+	}
+	sort.Strings(ips)
+
+	inventory := &DeviceInventory{Devices: make([]DeviceReport, 0, len(ips))}
+	for _, ip := range ips {
+		report := DeviceReport{IP: ip}
+		if err := inventoryDevice(ctx, clientMap[ip], &report); err != nil {
+			report.Health = deviceHealthUnreachable
+			report.Error = err.Error()
+		}
+		inventory.Devices = append(inventory.Devices, report)
+	}
+
+	return inventory, nil
+}
+
+func inventoryDevice(ctx context.Context, client AwsClient, report *DeviceReport) error {
+	unlocked, err := client.IsSnowballDeviceUnlocked(ctx)
+	if err != nil {
+		return fmt.Errorf("checking unlock status: %v", err)
+	}
+	report.Unlocked = unlocked
+	if !unlocked {
+		// The device services are not available until the device is unlocked.
+		report.Health = deviceHealthLocked
+		return nil
+	}
+
+	if report.SoftwareVersion, err = client.SnowballDeviceSoftwareVersion(ctx); err != nil {
+		return fmt.Errorf("checking software version: %v", err)
+	}
+
+	if report.Capacities, err = client.SnowballDeviceCapacities(ctx); err != nil {
+		return fmt.Errorf("checking capacities: %v", err)
+	}
+
+	instanceTypes, err := client.EC2InstanceTypes(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching supported instance types: %v", err)
+	}
+	report.InstanceTypes = instanceTypeCapacities(instanceTypes, report.Capacities)
+
+	if report.Images, err = client.EC2Images(ctx); err != nil {
+		return fmt.Errorf("fetching images: %v", err)
+	}
+
+	instances, err := client.EC2Instances(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching instances: %v", err)
+	}
+	report.Machines = clusterMachines(instances)
+
+	report.Health = deviceHealthy
+	if version, err := strconv.Atoi(report.SoftwareVersion); err != nil || version < snowballMinSoftwareVersion {
+		report.Health = deviceHealthUnsupported
+	}
+
+	return nil
+}
+
+func instanceTypeCapacities(instanceTypes []aws.EC2InstanceType, capacities []aws.SnowballDeviceCapacity) []InstanceTypeCapacity {
+	var availableVCPU *int64
+	for _, c := range capacities {
+		if c.Name == vCPUCapacityName {
+			available := c.Available
+			availableVCPU = &available
+		}
+	}
+
+	its := make([]InstanceTypeCapacity, 0, len(instanceTypes))
+	for _, it := range instanceTypes {
+		itc := InstanceTypeCapacity{Name: it.Name, DefaultVCPU: it.DefaultVCPU}
+		if availableVCPU != nil && it.DefaultVCPU != nil && *it.DefaultVCPU > 0 {
+			available := *availableVCPU / int64(*it.DefaultVCPU)
+			itc.Available = &available
+		}
+		its = append(its, itc)
+	}
+	sort.Slice(its, func(i, j int) bool { return its[i].Name < its[j].Name })
+
+	return its
+}
+
+// clusterMachines returns the instances tagged by CAPAS as owned by a cluster.
+func clusterMachines(instances []aws.EC2Instance) []DeviceMachine {
+	machines := []DeviceMachine{}
+	for _, instance := range instances {
+		if instance.State == terminatedInstanceState {
+			continue
+		}
+		for key, value := range instance.Tags {
+			clusterName := strings.TrimPrefix(key, snowv1.NameAWSProviderOwned)
+			if clusterName == key || snowv1.ResourceLifecycle(value) != snowv1.ResourceLifecycleOwned {
+				continue
+			}
+			name := instance.Tags[snowv1.MachineNameTagKey]
+			if name == "" {
+				name = instance.Tags[instanceNameTagKey]
+			}
+			machines = append(machines, DeviceMachine{
+				Cluster:      clusterName,
+				Name:         name,
+				InstanceID:   instance.ID,
+				InstanceType: instance.Type,
+				State:        instance.State,
+			})
+		}
+	}
+	sort.Slice(machines, func(i, j int) bool {
+		if machines[i].Cluster != machines[j].Cluster {
+			return machines[i].Cluster < machines[j].Cluster
+		}
+		return machines[i].Name < machines[j].Name
+	})
+
+	return machines
+}
+
+// Write writes the inventory in a human readable format.
+func (i *DeviceInventory) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(tw, "DEVICE\tHEALTH\tUNLOCKED\tSOFTWARE VERSION\tVCPU AVAILABLE\tIMAGES\tMACHINES")
+	for _, d := range i.Devices {
+		version := d.SoftwareVersion
+		if version == "" {
+			version = unknownInventoryValueText
+		}
+		vcpu := unknownInventoryValueText
+		for _, c := range d.Capacities {
+			if c.Name == vCPUCapacityName {
+				vcpu = fmt.Sprintf("%d/%d", c.Available, c.Total)
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%t\t%s\t%s\t%d\t%d\n", d.IP, d.Health, d.Unlocked, version, vcpu, len(d.Images), len(d.Machines))
+	}
+
+	for _, d := range i.Devices {
+		fmt.Fprintf(tw, "\nDevice %s\n", d.IP)
+		if d.Error != "" {
+			fmt.Fprintf(tw, "Error: %s\n", d.Error)
+			continue
+		}
+		if !d.Unlocked {
+			fmt.Fprintln(tw, "Device is locked")
+			continue
+		}
+
+		fmt.Fprintln(tw, "INSTANCE TYPE\tDEFAULT VCPU\tAVAILABLE INSTANCES")
+		for _, it := range d.InstanceTypes {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", it.Name, optionalInt(it.DefaultVCPU), optionalInt(it.Available))
+		}
+
+		fmt.Fprintln(tw, "\nIMAGE ID\tNAME")
+		for _, image := range d.Images {
+			fmt.Fprintf(tw, "%s\t%s\n", image.ID, image.Name)
+		}
+
+		fmt.Fprintln(tw, "\nCLUSTER\tMACHINE\tINSTANCE ID\tINSTANCE TYPE\tSTATE")
+		for _, m := range d.Machines {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", m.Cluster, m.Name, m.InstanceID, m.InstanceType, m.State)
+		}
+	}
+
+	return tw.Flush()
+}
+
+func optionalInt[T int32 | int64](v *T) string {
+	if v == nil {
+		return unknownInventoryValueText
+	}
+	return strconv.FormatInt(int64(*v), 10)
+}
@@ -0,0 +1,166 @@
+package snow_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/aws"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	"github.com/aws/eks-anywhere/pkg/providers/snow/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+type inventoryTest struct {
+	*WithT
+	ctx      context.Context
+	devices  map[string]*mocks.MockAwsClient
+	registry *mocks.MockClientRegistry
+}
+
+func newInventoryTest(t *testing.T) *inventoryTest {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	devices := map[string]*mocks.MockAwsClient{
+		"1.2.3.5": mocks.NewMockAwsClient(ctrl),
+		"1.2.3.4": mocks.NewMockAwsClient(ctrl),
+	}
+	clientMap := snow.AwsClientMap{}
+	for ip, client := range devices {
+		clientMap[ip] = client
+	}
+	registry := mocks.NewMockClientRegistry(ctrl)
+	registry.EXPECT().Get(ctx).Return(clientMap, nil).AnyTimes()
+	return &inventoryTest{
+		WithT:    NewWithT(t),
+		ctx:      ctx,
+		devices:  devices,
+		registry: registry,
+	}
+}
+
+func (tt *inventoryTest) expectHealthyDevice(ip, version string) {
+	client := tt.devices[ip]
+	client.EXPECT().IsSnowballDeviceUnlocked(tt.ctx).Return(true, nil)
+	client.EXPECT().SnowballDeviceSoftwareVersion(tt.ctx).Return(version, nil)
+	client.EXPECT().SnowballDeviceCapacities(tt.ctx).Return([]aws.SnowballDeviceCapacity{
+		{Name: "vCPU", Unit: "Number", Total: 52, Used: 12, Available: 40},
+		{Name: "Memory", Unit: "Byte", Total: 100, Used: 50, Available: 50},
+	}, nil)
+	client.EXPECT().EC2InstanceTypes(tt.ctx).Return([]aws.EC2InstanceType{
+		{Name: "sbe-c.xlarge", DefaultVCPU: ptr.Int32(4)},
+		{Name: "sbe-c.large", DefaultVCPU: ptr.Int32(2)},
+		{Name: "sbe-c.unknown"},
+	}, nil)
+	client.EXPECT().EC2Images(tt.ctx).Return([]aws.EC2Image{{ID: "ami-1", Name: "bottlerocket"}}, nil)
+	client.EXPECT().EC2Instances(tt.ctx).Return([]aws.EC2Instance{
+		{
+			ID:    "s.i-2",
+			Type:  "sbe-c.xlarge",
+			State: "running",
+			Tags: map[string]string{
+				"sigs.k8s.io/cluster-api-provider-aws-snow/cluster/snow-test": "owned",
+				"MachineName": "snow-test-md-0-1",
+			},
+		},
+		{
+			ID:    "s.i-1",
+			Type:  "sbe-c.large",
+			State: "running",
+			Tags: map[string]string{
+				"sigs.k8s.io/cluster-api-provider-aws-snow/cluster/snow-test": "owned",
+				"Name": "snow-test-cp-1",
+			},
+		},
+		{
+			ID:    "s.i-3",
+			Type:  "sbe-c.large",
+			State: "terminated",
+			Tags: map[string]string{
+				"sigs.k8s.io/cluster-api-provider-aws-snow/cluster/snow-test": "owned",
+			},
+		},
+		{
+			ID:    "s.i-4",
+			Type:  "sbe-c.large",
+			State: "running",
+		},
+	}, nil)
+}
+
+func TestGetDeviceInventory(t *testing.T) {
+	tt := newInventoryTest(t)
+	tt.expectHealthyDevice("1.2.3.4", "102")
+	tt.devices["1.2.3.5"].EXPECT().IsSnowballDeviceUnlocked(tt.ctx).Return(false, nil)
+
+	inventory, err := snow.GetDeviceInventory(tt.ctx, tt.registry)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(inventory.Healthy()).To(BeFalse())
+	tt.Expect(inventory.Devices).To(HaveLen(2))
+
+	device := inventory.Devices[0]
+	tt.Expect(device.IP).To(Equal("1.2.3.4"))
+	tt.Expect(device.Health).To(Equal("healthy"))
+	tt.Expect(device.SoftwareVersion).To(Equal("102"))
+	tt.Expect(device.InstanceTypes).To(Equal([]snow.InstanceTypeCapacity{
+		{Name: "sbe-c.large", DefaultVCPU: ptr.Int32(2), Available: ptr.Int64(20)},
+		{Name: "sbe-c.unknown"},
+		{Name: "sbe-c.xlarge", DefaultVCPU: ptr.Int32(4), Available: ptr.Int64(10)},
+	}))
+	tt.Expect(device.Images).To(Equal([]aws.EC2Image{{ID: "ami-1", Name: "bottlerocket"}}))
+	tt.Expect(device.Machines).To(Equal([]snow.DeviceMachine{
+		{Cluster: "snow-test", Name: "snow-test-cp-1", InstanceID: "s.i-1", InstanceType: "sbe-c.large", State: "running"},
+		{Cluster: "snow-test", Name: "snow-test-md-0-1", InstanceID: "s.i-2", InstanceType: "sbe-c.xlarge", State: "running"},
+	}))
+
+	tt.Expect(inventory.Devices[1]).To(Equal(snow.DeviceReport{IP: "1.2.3.5", Health: "locked"}))
+
+	buf := &bytes.Buffer{}
+	tt.Expect(inventory.Write(buf)).To(Succeed())
+	tt.Expect(buf.String()).To(ContainSubstring("1.2.3.4   healthy"))
+	tt.Expect(buf.String()).To(ContainSubstring("snow-test-cp-1"))
+	tt.Expect(buf.String()).To(ContainSubstring("Device is locked"))
+}
+
+func TestGetDeviceInventoryUnsupportedSoftware(t *testing.T) {
+	tt := newInventoryTest(t)
+	tt.expectHealthyDevice("1.2.3.4", "102")
+	tt.expectHealthyDevice("1.2.3.5", "101")
+
+	inventory, err := snow.GetDeviceInventory(tt.ctx, tt.registry)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(inventory.Healthy()).To(BeFalse())
+	tt.Expect(inventory.Devices[1].Health).To(Equal("unsupported software"))
+}
+
+func TestGetDeviceInventoryDeviceUnreachable(t *testing.T) {
+	tt := newInventoryTest(t)
+	tt.expectHealthyDevice("1.2.3.4", "102")
+	tt.devices["1.2.3.5"].EXPECT().IsSnowballDeviceUnlocked(tt.ctx).Return(true, nil)
+	tt.devices["1.2.3.5"].EXPECT().SnowballDeviceSoftwareVersion(tt.ctx).Return("", errors.New("connection refused"))
+
+	inventory, err := snow.GetDeviceInventory(tt.ctx, tt.registry)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(inventory.Healthy()).To(BeFalse())
+	tt.Expect(inventory.Devices[0].Health).To(Equal("healthy"))
+	tt.Expect(inventory.Devices[1]).To(Equal(snow.DeviceReport{
+		IP:       "1.2.3.5",
+		Health:   "unreachable",
+		Unlocked: true,
+		Error:    "checking software version: connection refused",
+	}))
+}
+
+func TestGetDeviceInventoryRegistryError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	registry := mocks.NewMockClientRegistry(gomock.NewController(t))
+	registry.EXPECT().Get(ctx).Return(nil, errors.New("aws clients for snow not initialized"))
+
+	_, err := snow.GetDeviceInventory(ctx, registry)
+	g.Expect(err).To(MatchError("aws clients for snow not initialized"))
+}
@@ -0,0 +1,35 @@
+package snow
+
+import (
+	"sort"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+// ValidateIPPoolCapacity validates that the SnowIPPools referenced by the machine configs have enough
+// addresses for the machines of the cluster, including the extra machine created by each node group during
+// a rollout. The addresses assigned to machines of other clusters, as recorded in the pool status, are not
+// available, while the ones assigned to this cluster are, since its machines are the ones being replaced.
+func ValidateIPPoolCapacity(c *cluster.Config) error {
+	required := v1alpha1.SnowIPPoolAddressesRequired(c.Cluster, c.SnowMachineConfigs)
+
+	names := make([]string, 0, len(required))
+	for name := range required {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		pool, ok := c.SnowIPPools[name]
+		if !ok {
+			continue
+		}
+
+		if err := pool.ValidateCapacity(c.Cluster.Name, required[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
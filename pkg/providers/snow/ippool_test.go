@@ -0,0 +1,59 @@
+package snow_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+)
+
+func givenIPPoolCapacityClusterSpec(allocations ...v1alpha1.SnowIPAllocation) *cluster.Spec {
+	spec := givenClusterSpec()
+	network := v1alpha1.SnowNetwork{
+		DirectNetworkInterfaces: []v1alpha1.SnowDirectNetworkInterface{
+			{
+				Index:     1,
+				IPPoolRef: &v1alpha1.Ref{Kind: v1alpha1.SnowIPPoolKind, Name: "ip-pool-1"},
+				Primary:   true,
+			},
+		},
+	}
+	spec.SnowMachineConfig("test-cp").Spec.Network = network
+	spec.SnowMachineConfig("test-wn").Spec.Network = network
+	spec.SnowIPPools["ip-pool-1"].Spec.Pools = []v1alpha1.IPPool{
+		{IPStart: "1.2.3.10", IPEnd: "1.2.3.19", Subnet: "1.2.3.0/24", Gateway: "1.2.3.1"},
+	}
+	spec.SnowIPPools["ip-pool-1"].Status.Allocations = allocations
+	return spec
+}
+
+func TestValidateIPPoolCapacitySuccess(t *testing.T) {
+	g := NewWithT(t)
+	// 3 control plane and 3 worker machines plus one rollout machine for each require 8 addresses.
+	spec := givenIPPoolCapacityClusterSpec(
+		v1alpha1.SnowIPAllocation{IP: "1.2.3.10", Machine: "other-1", Cluster: "other"},
+		v1alpha1.SnowIPAllocation{IP: "1.2.3.11", Machine: "other-2", Cluster: "other"},
+		v1alpha1.SnowIPAllocation{IP: "1.2.3.12", Machine: "cp-1", Cluster: "snow-test"},
+	)
+	g.Expect(snow.ValidateIPPoolCapacity(spec.Config)).To(Succeed())
+}
+
+func TestValidateIPPoolCapacityNotEnoughAddresses(t *testing.T) {
+	g := NewWithT(t)
+	spec := givenIPPoolCapacityClusterSpec(
+		v1alpha1.SnowIPAllocation{IP: "1.2.3.10", Machine: "other-1", Cluster: "other"},
+		v1alpha1.SnowIPAllocation{IP: "1.2.3.11", Machine: "other-2", Cluster: "other"},
+		v1alpha1.SnowIPAllocation{IP: "1.2.3.12", Machine: "other-3", Cluster: "other"},
+	)
+	g.Expect(snow.ValidateIPPoolCapacity(spec.Config)).To(MatchError(
+		"SnowIPPool ip-pool-1 doesn't have enough addresses for cluster snow-test, 8 required including rollouts and 7 available",
+	))
+}
+
+func TestValidateIPPoolCapacityNoIPPools(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(snow.ValidateIPPoolCapacity(givenClusterSpec().Config)).To(Succeed())
+}
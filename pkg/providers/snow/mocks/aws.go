@@ -6,6 +6,7 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	aws "github.com/aws/eks-anywhere/pkg/aws"
@@ -50,6 +51,21 @@ func (mr *MockAwsClientMockRecorder) EC2ImageExists(ctx, imageID interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2ImageExists", reflect.TypeOf((*MockAwsClient)(nil).EC2ImageExists), ctx, imageID)
 }
 
+// EC2Images mocks base method.
+func (m *MockAwsClient) EC2Images(ctx context.Context) ([]aws.EC2Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EC2Images", ctx)
+	ret0, _ := ret[0].([]aws.EC2Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EC2Images indicates an expected call of EC2Images.
+func (mr *MockAwsClientMockRecorder) EC2Images(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2Images", reflect.TypeOf((*MockAwsClient)(nil).EC2Images), ctx)
+}
+
 // EC2ImportKeyPair mocks base method.
 func (m *MockAwsClient) EC2ImportKeyPair(ctx context.Context, keyName string, keyMaterial []byte) error {
 	m.ctrl.T.Helper()
@@ -64,6 +80,36 @@ func (mr *MockAwsClientMockRecorder) EC2ImportKeyPair(ctx, keyName, keyMaterial
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2ImportKeyPair", reflect.TypeOf((*MockAwsClient)(nil).EC2ImportKeyPair), ctx, keyName, keyMaterial)
 }
 
+// EC2ImportSnapshot mocks base method.
+func (m *MockAwsClient) EC2ImportSnapshot(ctx context.Context, bucket, key, description string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EC2ImportSnapshot", ctx, bucket, key, description)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EC2ImportSnapshot indicates an expected call of EC2ImportSnapshot.
+func (mr *MockAwsClientMockRecorder) EC2ImportSnapshot(ctx, bucket, key, description interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2ImportSnapshot", reflect.TypeOf((*MockAwsClient)(nil).EC2ImportSnapshot), ctx, bucket, key, description)
+}
+
+// EC2ImportSnapshotStatus mocks base method.
+func (m *MockAwsClient) EC2ImportSnapshotStatus(ctx context.Context, taskID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EC2ImportSnapshotStatus", ctx, taskID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EC2ImportSnapshotStatus indicates an expected call of EC2ImportSnapshotStatus.
+func (mr *MockAwsClientMockRecorder) EC2ImportSnapshotStatus(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2ImportSnapshotStatus", reflect.TypeOf((*MockAwsClient)(nil).EC2ImportSnapshotStatus), ctx, taskID)
+}
+
 // EC2InstanceTypes mocks base method.
 func (m *MockAwsClient) EC2InstanceTypes(ctx context.Context) ([]aws.EC2InstanceType, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +125,21 @@ func (mr *MockAwsClientMockRecorder) EC2InstanceTypes(ctx interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2InstanceTypes", reflect.TypeOf((*MockAwsClient)(nil).EC2InstanceTypes), ctx)
 }
 
+// EC2Instances mocks base method.
+func (m *MockAwsClient) EC2Instances(ctx context.Context) ([]aws.EC2Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EC2Instances", ctx)
+	ret0, _ := ret[0].([]aws.EC2Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EC2Instances indicates an expected call of EC2Instances.
+func (mr *MockAwsClientMockRecorder) EC2Instances(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2Instances", reflect.TypeOf((*MockAwsClient)(nil).EC2Instances), ctx)
+}
+
 // EC2KeyNameExists mocks base method.
 func (m *MockAwsClient) EC2KeyNameExists(ctx context.Context, keyName string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +155,21 @@ func (mr *MockAwsClientMockRecorder) EC2KeyNameExists(ctx, keyName interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2KeyNameExists", reflect.TypeOf((*MockAwsClient)(nil).EC2KeyNameExists), ctx, keyName)
 }
 
+// EC2RegisterImage mocks base method.
+func (m *MockAwsClient) EC2RegisterImage(ctx context.Context, name, snapshotID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EC2RegisterImage", ctx, name, snapshotID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EC2RegisterImage indicates an expected call of EC2RegisterImage.
+func (mr *MockAwsClientMockRecorder) EC2RegisterImage(ctx, name, snapshotID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2RegisterImage", reflect.TypeOf((*MockAwsClient)(nil).EC2RegisterImage), ctx, name, snapshotID)
+}
+
 // IsSnowballDeviceUnlocked mocks base method.
 func (m *MockAwsClient) IsSnowballDeviceUnlocked(ctx context.Context) (bool, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +185,49 @@ func (mr *MockAwsClientMockRecorder) IsSnowballDeviceUnlocked(ctx interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSnowballDeviceUnlocked", reflect.TypeOf((*MockAwsClient)(nil).IsSnowballDeviceUnlocked), ctx)
 }
 
+// S3DeleteObject mocks base method.
+func (m *MockAwsClient) S3DeleteObject(ctx context.Context, bucket, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "S3DeleteObject", ctx, bucket, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// S3DeleteObject indicates an expected call of S3DeleteObject.
+func (mr *MockAwsClientMockRecorder) S3DeleteObject(ctx, bucket, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "S3DeleteObject", reflect.TypeOf((*MockAwsClient)(nil).S3DeleteObject), ctx, bucket, key)
+}
+
+// S3UploadObject mocks base method.
+func (m *MockAwsClient) S3UploadObject(ctx context.Context, bucket, key string, body io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "S3UploadObject", ctx, bucket, key, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// S3UploadObject indicates an expected call of S3UploadObject.
+func (mr *MockAwsClientMockRecorder) S3UploadObject(ctx, bucket, key, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "S3UploadObject", reflect.TypeOf((*MockAwsClient)(nil).S3UploadObject), ctx, bucket, key, body)
+}
+
+// SnowballDeviceCapacities mocks base method.
+func (m *MockAwsClient) SnowballDeviceCapacities(ctx context.Context) ([]aws.SnowballDeviceCapacity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnowballDeviceCapacities", ctx)
+	ret0, _ := ret[0].([]aws.SnowballDeviceCapacity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnowballDeviceCapacities indicates an expected call of SnowballDeviceCapacities.
+func (mr *MockAwsClientMockRecorder) SnowballDeviceCapacities(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnowballDeviceCapacities", reflect.TypeOf((*MockAwsClient)(nil).SnowballDeviceCapacities), ctx)
+}
+
 // SnowballDeviceSoftwareVersion mocks base method.
 func (m *MockAwsClient) SnowballDeviceSoftwareVersion(ctx context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -36,9 +36,15 @@ func WorkersObjects(ctx context.Context, log logr.Logger, clusterSpec *cluster.S
 // new subset slice equal to the original slice. i.e. DeepDerivative([]int{1}, []int{1, 2}) returns true.
 // Custom logic is added to justify this usecase since removing a device from the devices list shall trigger machine
 // rollout and recreate or the snow cluster goes into a state where the machines on the removed device can’t be deleted.
+// The placement annotations are compared too, so changing the placement policy rolls out the machines.
 func MachineTemplateDeepDerivative(new, old *snowv1.AWSSnowMachineTemplate) bool {
 	if len(new.Spec.Template.Spec.Devices) != len(old.Spec.Template.Spec.Devices) {
 		return false
 	}
+	for _, annotation := range []string{PlacementPolicyAnnotation, MaxMachinesPerDeviceAnnotation} {
+		if new.Annotations[annotation] != old.Annotations[annotation] {
+			return false
+		}
+	}
 	return equality.Semantic.DeepDerivative(new.Spec, old.Spec)
 }
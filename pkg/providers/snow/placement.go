@@ -0,0 +1,317 @@
+package snow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+)
+
+const (
+	// PlacementPolicyAnnotation records the spread or pack placement policy of the machines created from an
+	// AWSSnowMachineTemplate.
+	PlacementPolicyAnnotation = "anywhere.eks.amazonaws.com/snow-placement-policy"
+
+	// MaxMachinesPerDeviceAnnotation records the max machines per device of the pack placement policy of the
+	// machines created from an AWSSnowMachineTemplate.
+	MaxMachinesPerDeviceAnnotation = "anywhere.eks.amazonaws.com/snow-max-machines-per-device"
+
+	// Snow doesn't support rollout strategy customization, so every node group rolls out one machine at a time.
+	placementRolloutSurge = 1
+)
+
+// nodeGroup is a group of machines created from the same machine template.
+type nodeGroup struct {
+	name          string
+	machineConfig *v1alpha1.SnowMachineConfig
+	machines      int
+	controlPlane  bool
+}
+
+// nodeGroups returns the control plane, etcd and worker node groups of a cluster, counting the max count
+// of the worker node groups with autoscaling.
+func nodeGroups(c *cluster.Config) []nodeGroup {
+	cp := c.Cluster.Spec.ControlPlaneConfiguration
+	groups := []nodeGroup{{
+		name:          "control plane",
+		machineConfig: c.SnowMachineConfigs[cp.MachineGroupRef.Name],
+		machines:      cp.Count,
+		controlPlane:  true,
+	}}
+
+	if etcd := c.Cluster.Spec.ExternalEtcdConfiguration; etcd != nil {
+		groups = append(groups, nodeGroup{
+			name:          "etcd",
+			machineConfig: c.SnowMachineConfigs[etcd.MachineGroupRef.Name],
+			machines:      etcd.Count,
+		})
+	}
+
+	for _, wc := range c.Cluster.Spec.WorkerNodeGroupConfigurations {
+		groups = append(groups, nodeGroup{
+			name:          fmt.Sprintf("worker node group %s", wc.Name),
+			machineConfig: c.SnowMachineConfigs[wc.MachineGroupRef.Name],
+			machines:      workerMachineCount(wc),
+		})
+	}
+
+	return groups
+}
+
+func workerMachineCount(wc v1alpha1.WorkerNodeGroupConfiguration) int {
+	count := 0
+	if wc.Count != nil {
+		count = *wc.Count
+	}
+	if wc.AutoScalingConfiguration != nil && wc.AutoScalingConfiguration.MaxCount > count {
+		count = wc.AutoScalingConfiguration.MaxCount
+	}
+	return count
+}
+
+// usableDevices returns the devices of a machine config that are not being decommissioned.
+func usableDevices(machineConfig *v1alpha1.SnowMachineConfig, datacenter *v1alpha1.SnowDatacenterConfig) []string {
+	decommissioned := map[string]bool{}
+	if datacenter != nil {
+		for _, device := range datacenter.Spec.DecommissionedDevices {
+			decommissioned[device] = true
+		}
+	}
+
+	devices := make([]string, 0, len(machineConfig.Spec.Devices))
+	for _, device := range machineConfig.Spec.Devices {
+		if !decommissioned[device] {
+			devices = append(devices, device)
+		}
+	}
+	return devices
+}
+
+// placementDevices returns the devices the machines of a node group can be placed on, following the
+// placement policy of its machine config and skipping the devices being decommissioned.
+func placementDevices(machineConfig *v1alpha1.SnowMachineConfig, datacenter *v1alpha1.SnowDatacenterConfig) []string {
+	devices := usableDevices(machineConfig, datacenter)
+	if placement := machineConfig.Spec.Placement; placement != nil && placement.Policy == v1alpha1.SnowPlacementPin && len(devices) > 0 {
+		return devices[:1]
+	}
+
+	return devices
+}
+
+// setMachineTemplatePlacement sets the devices of a machine template and records the spread and pack
+// placement policies in its annotations. CAPAS places a machine on any of the devices of its template,
+// so the machines created from templates with these policies are placed on a single device when they
+// are created, see MachineDevice.
+func setMachineTemplatePlacement(template *snowv1.AWSSnowMachineTemplate, machineConfig *v1alpha1.SnowMachineConfig, datacenter *v1alpha1.SnowDatacenterConfig) {
+	template.Spec.Template.Spec.Devices = placementDevices(machineConfig, datacenter)
+
+	placement := machineConfig.Spec.Placement
+	if placement == nil || placement.Policy == v1alpha1.SnowPlacementPin {
+		return
+	}
+
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[PlacementPolicyAnnotation] = string(placement.Policy)
+	if placement.Policy == v1alpha1.SnowPlacementPack {
+		template.Annotations[MaxMachinesPerDeviceAnnotation] = strconv.Itoa(placement.MaxMachinesPerDevice)
+	}
+}
+
+// MachineDevice returns the device a new machine created from a machine template is placed on, following the
+// placement policy recorded in the template annotations. It returns an empty string when the template has no
+// spread or pack placement. siblings are the other machines created from the same template, only the ones
+// already placed on a single device count.
+//
+// With spread, the machine is placed on the device with the fewest machines, the first one in the template
+// devices on ties. With pack, it's placed on the first device with less than the max machines per device.
+// Machines are only counted per template, so the machines of a node group being replaced during a rollout
+// don't change where the new ones are placed.
+func MachineDevice(template *snowv1.AWSSnowMachineTemplate, siblings []snowv1.AWSSnowMachine) (string, error) {
+	policy := v1alpha1.SnowPlacementPolicy(template.Annotations[PlacementPolicyAnnotation])
+	if policy != v1alpha1.SnowPlacementSpread && policy != v1alpha1.SnowPlacementPack {
+		return "", nil
+	}
+
+	devices := template.Spec.Template.Spec.Devices
+	if len(devices) == 0 {
+		return "", fmt.Errorf("AWSSnowMachineTemplate %s has no devices", template.Name)
+	}
+
+	loads := make(map[string]int, len(devices))
+	for _, m := range siblings {
+		if m.DeletionTimestamp.IsZero() && len(m.Spec.Devices) == 1 {
+			loads[m.Spec.Devices[0]]++
+		}
+	}
+
+	if policy == v1alpha1.SnowPlacementSpread {
+		device := devices[0]
+		for _, d := range devices[1:] {
+			if loads[d] < loads[device] {
+				device = d
+			}
+		}
+		return device, nil
+	}
+
+	maxMachines, err := strconv.Atoi(template.Annotations[MaxMachinesPerDeviceAnnotation])
+	if err != nil || maxMachines <= 0 {
+		return "", fmt.Errorf("AWSSnowMachineTemplate %s has an invalid %s annotation %q", template.Name, MaxMachinesPerDeviceAnnotation, template.Annotations[MaxMachinesPerDeviceAnnotation])
+	}
+	for _, d := range devices {
+		if loads[d] < maxMachines {
+			return d, nil
+		}
+	}
+
+	return "", fmt.Errorf("all the devices of AWSSnowMachineTemplate %s already have %d machines", template.Name, maxMachines)
+}
+
+// deviceLoads returns the number of machines of a node group placed on each device in the worst case,
+// including the extra machine created during a rollout.
+func deviceLoads(placement *v1alpha1.SnowPlacement, devices []string, machines int) map[string]int {
+	loads := make(map[string]int, len(devices))
+	remaining := machines + placementRolloutSurge
+	if placement.Policy == v1alpha1.SnowPlacementPack {
+		for _, device := range devices {
+			n := placement.MaxMachinesPerDevice
+			if n > remaining {
+				n = remaining
+			}
+			loads[device] = n
+			remaining -= n
+		}
+	}
+
+	// The machines left, all of them for spread, are distributed evenly.
+	for i := 0; i < remaining; i++ {
+		loads[devices[i%len(devices)]]++
+	}
+
+	return loads
+}
+
+// ValidatePlacement validates that every node group has devices left after removing the devices being
+// decommissioned, that the placement policies can be satisfied with those devices, and that the devices have
+// enough vCPU for the machines placed on them by the placement policies. The vCPU used by the machines of the
+// cluster already running on a device are counted as available.
+func (v *Validator) ValidatePlacement(ctx context.Context, c *cluster.Config) error {
+	// machines placed on each device per instance type.
+	demand := map[string]map[string]int{}
+	for _, group := range nodeGroups(c) {
+		if group.machineConfig == nil {
+			continue
+		}
+		devices := placementDevices(group.machineConfig, c.SnowDatacenter)
+		if len(devices) == 0 {
+			return fmt.Errorf("%s has no devices left to place machines on, all the devices of SnowMachineConfig %s are decommissioned", group.name, group.machineConfig.Name)
+		}
+
+		placement := group.machineConfig.Spec.Placement
+		if placement == nil {
+			continue
+		}
+
+		if placement.Policy == v1alpha1.SnowPlacementSpread && group.controlPlane && group.machines > len(devices) {
+			return fmt.Errorf("control plane with placement policy %s requires at least %d devices, SnowMachineConfig %s has %d", v1alpha1.SnowPlacementSpread, group.machines, group.machineConfig.Name, len(devices))
+		}
+
+		if placement.Policy == v1alpha1.SnowPlacementPack && group.machines > placement.MaxMachinesPerDevice*len(devices) {
+			return fmt.Errorf("%s with placement policy %s can have at most %d machines on %d devices with %d machines per device, it has %d", group.name, v1alpha1.SnowPlacementPack, placement.MaxMachinesPerDevice*len(devices), len(devices), placement.MaxMachinesPerDevice, group.machines)
+		}
+
+		for device, machines := range deviceLoads(placement, devices, group.machines) {
+			if demand[device] == nil {
+				demand[device] = map[string]int{}
+			}
+			demand[device][group.machineConfig.Spec.InstanceType] += machines
+		}
+	}
+
+	if len(demand) == 0 {
+		return nil
+	}
+
+	clientMap, err := v.clientRegistry.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	devices := make([]string, 0, len(demand))
+	for device := range demand {
+		devices = append(devices, device)
+	}
+	sort.Strings(devices)
+
+	for _, device := range devices {
+		client, ok := clientMap[device]
+		if !ok {
+			return fmt.Errorf("credentials not found for device [%s]", device)
+		}
+
+		if err := validateDeviceVCPU(ctx, client, device, c.Cluster.Name, demand[device]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateDeviceVCPU(ctx context.Context, client AwsClient, device, clusterName string, machines map[string]int) error {
+	instanceTypes, err := client.EC2InstanceTypes(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching supported instance types for device [%s]: %v", device, err)
+	}
+	vcpus := make(map[string]int64, len(instanceTypes))
+	for _, it := range instanceTypes {
+		if it.DefaultVCPU != nil {
+			vcpus[it.Name] = int64(*it.DefaultVCPU)
+		}
+	}
+
+	capacities, err := client.SnowballDeviceCapacities(ctx)
+	if err != nil {
+		return fmt.Errorf("checking capacities for device [%s]: %v", device, err)
+	}
+	var available *int64
+	for _, c := range capacities {
+		if c.Name == vCPUCapacityName {
+			a := c.Available
+			available = &a
+		}
+	}
+	if available == nil {
+		return fmt.Errorf("vCPU capacity not reported by device [%s]", device)
+	}
+
+	instances, err := client.EC2Instances(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching instances for device [%s]: %v", device, err)
+	}
+	for _, m := range clusterMachines(instances) {
+		if m.Cluster == clusterName {
+			*available += vcpus[m.InstanceType]
+		}
+	}
+
+	var required int64
+	placed := make([]string, 0, len(machines))
+	for instanceType, count := range machines {
+		required += vcpus[instanceType] * int64(count)
+		placed = append(placed, fmt.Sprintf("%d %s", count, instanceType))
+	}
+	sort.Strings(placed)
+
+	if required > *available {
+		return fmt.Errorf("device [%s] doesn't have enough vCPU for the machines placed on it (%s), %d vCPU required and %d available", device, strings.Join(placed, ", "), required, *available)
+	}
+
+	return nil
+}
@@ -0,0 +1,323 @@
+package snow_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/aws"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+	"github.com/aws/eks-anywhere/pkg/providers/snow/mocks"
+)
+
+func TestWorkersObjectsPlacement(t *testing.T) {
+	tests := []struct {
+		name            string
+		placement       *v1alpha1.SnowPlacement
+		decommissioned  []string
+		wantDevices     []string
+		wantAnnotations map[string]string
+	}{
+		{
+			name:        "no placement",
+			wantDevices: []string{"1.2.3.4", "1.2.3.5"},
+		},
+		{
+			name:           "no placement with decommissioned device",
+			decommissioned: []string{"1.2.3.4"},
+			wantDevices:    []string{"1.2.3.5"},
+		},
+		{
+			name:        "spread",
+			placement:   &v1alpha1.SnowPlacement{Policy: v1alpha1.SnowPlacementSpread},
+			wantDevices: []string{"1.2.3.4", "1.2.3.5"},
+			wantAnnotations: map[string]string{
+				snow.PlacementPolicyAnnotation: "spread",
+			},
+		},
+		{
+			name:        "pin",
+			placement:   &v1alpha1.SnowPlacement{Policy: v1alpha1.SnowPlacementPin},
+			wantDevices: []string{"1.2.3.4"},
+		},
+		{
+			name:           "pin with decommissioned device",
+			placement:      &v1alpha1.SnowPlacement{Policy: v1alpha1.SnowPlacementPin},
+			decommissioned: []string{"1.2.3.4"},
+			wantDevices:    []string{"1.2.3.5"},
+		},
+		{
+			name:        "pack",
+			placement:   &v1alpha1.SnowPlacement{Policy: v1alpha1.SnowPlacementPack, MaxMachinesPerDevice: 2},
+			wantDevices: []string{"1.2.3.4", "1.2.3.5"},
+			wantAnnotations: map[string]string{
+				snow.PlacementPolicyAnnotation:      "pack",
+				snow.MaxMachinesPerDeviceAnnotation: "2",
+			},
+		},
+		{
+			name:           "pack with decommissioned device",
+			placement:      &v1alpha1.SnowPlacement{Policy: v1alpha1.SnowPlacementPack, MaxMachinesPerDevice: 3},
+			decommissioned: []string{"1.2.3.4"},
+			wantDevices:    []string{"1.2.3.5"},
+			wantAnnotations: map[string]string{
+				snow.PlacementPolicyAnnotation:      "pack",
+				snow.MaxMachinesPerDeviceAnnotation: "3",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newSnowTest(t)
+			g.clusterSpec.SnowMachineConfigs["test-wn"].Spec.Placement = tc.placement
+			g.clusterSpec.SnowDatacenter.Spec.DecommissionedDevices = tc.decommissioned
+			g.kubeconfigClient.EXPECT().
+				Get(g.ctx, "snow-test-md-0", gomock.Any(), gomock.Any()).
+				Return(apierrors.NewNotFound(schema.GroupResource{}, ""))
+
+			w, err := snow.WorkersSpec(g.ctx, g.logger, g.clusterSpec, g.kubeconfigClient)
+			g.Expect(err).To(Succeed())
+			g.Expect(w.Groups[0].ProviderMachineTemplate.Spec.Template.Spec.Devices).To(Equal(tc.wantDevices))
+			g.Expect(w.Groups[0].ProviderMachineTemplate.Annotations).To(Equal(tc.wantAnnotations))
+		})
+	}
+}
+
+func TestControlPlaneSpecPlacementPin(t *testing.T) {
+	g := newSnowTest(t)
+	g.clusterSpec.SnowMachineConfigs["test-cp"].Spec.Placement = &v1alpha1.SnowPlacement{Policy: v1alpha1.SnowPlacementPin}
+	g.kubeconfigClient.EXPECT().
+		Get(g.ctx, "snow-test", gomock.Any(), gomock.Any()).
+		Return(apierrors.NewNotFound(schema.GroupResource{}, ""))
+
+	cp, err := snow.ControlPlaneSpec(g.ctx, g.logger, g.kubeconfigClient, g.clusterSpec)
+	g.Expect(err).To(Succeed())
+	g.Expect(cp.ControlPlaneMachineTemplate.Spec.Template.Spec.Devices).To(Equal([]string{"1.2.3.4"}))
+	g.Expect(cp.ControlPlaneMachineTemplate.Annotations).To(BeEmpty())
+}
+
+func TestControlPlaneSpecPlacementSpread(t *testing.T) {
+	g := newSnowTest(t)
+	g.clusterSpec.SnowMachineConfigs["test-cp"].Spec.Placement = &v1alpha1.SnowPlacement{Policy: v1alpha1.SnowPlacementSpread}
+	g.kubeconfigClient.EXPECT().
+		Get(g.ctx, "snow-test", gomock.Any(), gomock.Any()).
+		Return(apierrors.NewNotFound(schema.GroupResource{}, ""))
+
+	cp, err := snow.ControlPlaneSpec(g.ctx, g.logger, g.kubeconfigClient, g.clusterSpec)
+	g.Expect(err).To(Succeed())
+	g.Expect(cp.ControlPlaneMachineTemplate.Spec.Template.Spec.Devices).To(Equal([]string{"1.2.3.4", "1.2.3.5"}))
+	g.Expect(cp.ControlPlaneMachineTemplate.Annotations).To(HaveKeyWithValue(snow.PlacementPolicyAnnotation, "spread"))
+}
+
+func TestMachineDevice(t *testing.T) {
+	devices := []string{"1.2.3.4", "1.2.3.5", "1.2.3.6"}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		siblings    []string
+		want        string
+		wantErr     string
+	}{
+		{
+			name:     "no placement",
+			siblings: []string{"1.2.3.4"},
+			want:     "",
+		},
+		{
+			name:        "spread on first device",
+			annotations: map[string]string{snow.PlacementPolicyAnnotation: "spread"},
+			want:        "1.2.3.4",
+		},
+		{
+			name:        "spread on least loaded device",
+			annotations: map[string]string{snow.PlacementPolicyAnnotation: "spread"},
+			siblings:    []string{"1.2.3.4", "1.2.3.6", "1.2.3.4"},
+			want:        "1.2.3.5",
+		},
+		{
+			name:        "spread on first device on ties",
+			annotations: map[string]string{snow.PlacementPolicyAnnotation: "spread"},
+			siblings:    []string{"1.2.3.4", "1.2.3.5", "1.2.3.6"},
+			want:        "1.2.3.4",
+		},
+		{
+			name:        "pack on first device with room",
+			annotations: map[string]string{snow.PlacementPolicyAnnotation: "pack", snow.MaxMachinesPerDeviceAnnotation: "2"},
+			siblings:    []string{"1.2.3.4", "1.2.3.4", "1.2.3.5"},
+			want:        "1.2.3.5",
+		},
+		{
+			name:        "pack on first device",
+			annotations: map[string]string{snow.PlacementPolicyAnnotation: "pack", snow.MaxMachinesPerDeviceAnnotation: "2"},
+			siblings:    []string{"1.2.3.4", "1.2.3.6"},
+			want:        "1.2.3.4",
+		},
+		{
+			name:        "pack devices full",
+			annotations: map[string]string{snow.PlacementPolicyAnnotation: "pack", snow.MaxMachinesPerDeviceAnnotation: "1"},
+			siblings:    devices,
+			wantErr:     "all the devices of AWSSnowMachineTemplate test-wn-1 already have 1 machines",
+		},
+		{
+			name:        "pack invalid max machines per device",
+			annotations: map[string]string{snow.PlacementPolicyAnnotation: "pack"},
+			wantErr:     "AWSSnowMachineTemplate test-wn-1 has an invalid anywhere.eks.amazonaws.com/snow-max-machines-per-device annotation \"\"",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			template := &snowv1.AWSSnowMachineTemplate{}
+			template.Name = "test-wn-1"
+			template.Annotations = tc.annotations
+			template.Spec.Template.Spec.Devices = devices
+
+			siblings := make([]snowv1.AWSSnowMachine, 0, len(tc.siblings)+1)
+			for _, device := range tc.siblings {
+				m := snowv1.AWSSnowMachine{}
+				m.Spec.Devices = []string{device}
+				siblings = append(siblings, m)
+			}
+			// Machines not placed on a single device yet aren't counted.
+			unplaced := snowv1.AWSSnowMachine{}
+			unplaced.Spec.Devices = devices
+			siblings = append(siblings, unplaced)
+
+			got, err := snow.MachineDevice(template, siblings)
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(tc.wantErr))
+				return
+			}
+			g.Expect(err).To(Succeed())
+			g.Expect(got).To(Equal(tc.want))
+		})
+	}
+}
+
+type placementTest struct {
+	*WithT
+	ctx       context.Context
+	devices   map[string]*mocks.MockAwsClient
+	validator *snow.Validator
+}
+
+func newPlacementTest(t *testing.T) *placementTest {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	devices := map[string]*mocks.MockAwsClient{
+		"1.2.3.4": mocks.NewMockAwsClient(ctrl),
+		"1.2.3.5": mocks.NewMockAwsClient(ctrl),
+	}
+	clientMap := snow.AwsClientMap{}
+	for ip, client := range devices {
+		clientMap[ip] = client
+	}
+	registry := mocks.NewMockClientRegistry(ctrl)
+	registry.EXPECT().Get(ctx).Return(clientMap, nil).AnyTimes()
+	return &placementTest{
+		WithT:     NewWithT(t),
+		ctx:       ctx,
+		devices:   devices,
+		validator: snow.NewValidator(registry),
+	}
+}
+
+func (tt *placementTest) expectDeviceVCPU(ip string, available int64, clusterInstanceTypes ...string) {
+	client := tt.devices[ip]
+	client.EXPECT().EC2InstanceTypes(tt.ctx).Return(supportedInstanceTypes(), nil)
+	client.EXPECT().SnowballDeviceCapacities(tt.ctx).Return([]aws.SnowballDeviceCapacity{
+		{Name: "vCPU", Total: 52, Available: available, Used: 52 - available},
+	}, nil)
+	instances := []aws.EC2Instance{}
+	for _, instanceType := range clusterInstanceTypes {
+		instances = append(instances, aws.EC2Instance{
+			Type:  instanceType,
+			State: "running",
+			Tags:  map[string]string{"sigs.k8s.io/cluster-api-provider-aws-snow/cluster/snow-test": "owned"},
+		})
+	}
+	client.EXPECT().EC2Instances(tt.ctx).Return(instances, nil)
+}
+
+func TestValidatePlacementNoPlacement(t *testing.T) {
+	tt := newPlacementTest(t)
+	tt.Expect(tt.validator.ValidatePlacement(tt.ctx, givenClusterSpec().Config)).To(Succeed())
+}
+
+func TestValidatePlacementAllDevicesDecommissioned(t *testing.T) {
+	tt := newPlacementTest(t)
+	config := givenClusterSpec().Config
+	config.SnowDatacenter.Spec.DecommissionedDevices = []string{"1.2.3.4", "1.2.3.5"}
+
+	err := tt.validator.ValidatePlacement(tt.ctx, config)
+	tt.Expect(err).To(MatchError("control plane has no devices left to place machines on, all the devices of SnowMachineConfig test-cp are decommissioned"))
+}
+
+func TestValidatePlacementSpreadControlPlaneNotEnoughDevices(t *testing.T) {
+	tt := newPlacementTest(t)
+	config := givenClusterSpec().Config
+	config.SnowMachineConfigs["test-cp"].Spec.Placement = &v1alpha1.SnowPlacement{Policy: v1alpha1.SnowPlacementSpread}
+
+	err := tt.validator.ValidatePlacement(tt.ctx, config)
+	tt.Expect(err).To(MatchError("control plane with placement policy spread requires at least 3 devices, SnowMachineConfig test-cp has 2"))
+}
+
+func TestValidatePlacementSpreadWorkers(t *testing.T) {
+	tt := newPlacementTest(t)
+	config := givenClusterSpec().Config
+	config.SnowMachineConfigs["test-wn"].Spec.Placement = &v1alpha1.SnowPlacement{Policy: v1alpha1.SnowPlacementSpread}
+	// 3 workers and the rollout surge are spread on 2 devices, 2 sbe-c.xlarge machines per device.
+	tt.expectDeviceVCPU("1.2.3.4", 8)
+	tt.expectDeviceVCPU("1.2.3.5", 4, "sbe-c.xlarge")
+
+	tt.Expect(tt.validator.ValidatePlacement(tt.ctx, config)).To(Succeed())
+}
+
+func TestValidatePlacementPackAndPin(t *testing.T) {
+	tt := newPlacementTest(t)
+	config := givenClusterSpec().Config
+	config.SnowMachineConfigs["test-cp"].Spec.Placement = &v1alpha1.SnowPlacement{Policy: v1alpha1.SnowPlacementPin}
+	config.SnowMachineConfigs["test-wn"].Spec.Placement = &v1alpha1.SnowPlacement{Policy: v1alpha1.SnowPlacementPack, MaxMachinesPerDevice: 3}
+	// 4 sbe-c.large control plane machines and 3 sbe-c.xlarge worker machines on the first device,
+	// the rollout surge worker machine on the second one.
+	tt.expectDeviceVCPU("1.2.3.4", 20, "sbe-c.xlarge")
+	tt.expectDeviceVCPU("1.2.3.5", 4)
+
+	tt.Expect(tt.validator.ValidatePlacement(tt.ctx, config)).To(Succeed())
+}
+
+func TestValidatePlacementNotEnoughVCPU(t *testing.T) {
+	tt := newPlacementTest(t)
+	config := givenClusterSpec().Config
+	config.SnowMachineConfigs["test-cp"].Spec.Placement = &v1alpha1.SnowPlacement{Policy: v1alpha1.SnowPlacementPin}
+	config.SnowMachineConfigs["test-wn"].Spec.Placement = &v1alpha1.SnowPlacement{Policy: v1alpha1.SnowPlacementPack, MaxMachinesPerDevice: 3}
+	tt.expectDeviceVCPU("1.2.3.4", 15, "sbe-c.xlarge")
+
+	err := tt.validator.ValidatePlacement(tt.ctx, config)
+	tt.Expect(err).To(MatchError("device [1.2.3.4] doesn't have enough vCPU for the machines placed on it (3 sbe-c.xlarge, 4 sbe-c.large), 20 vCPU required and 19 available"))
+}
+
+func TestValidatePlacementPackNotEnoughDevices(t *testing.T) {
+	tt := newPlacementTest(t)
+	config := givenClusterSpec().Config
+	config.SnowMachineConfigs["test-wn"].Spec.Placement = &v1alpha1.SnowPlacement{Policy: v1alpha1.SnowPlacementPack, MaxMachinesPerDevice: 1}
+
+	err := tt.validator.ValidatePlacement(tt.ctx, config)
+	tt.Expect(err).To(MatchError("worker node group md-0 with placement policy pack can have at most 2 machines on 2 devices with 1 machines per device, it has 3"))
+}
+
+func TestValidatePlacementPinWithDecommissionedDevice(t *testing.T) {
+	tt := newPlacementTest(t)
+	config := givenClusterSpec().Config
+	config.SnowDatacenter.Spec.DecommissionedDevices = []string{"1.2.3.4"}
+	config.SnowMachineConfigs["test-wn"].Spec.Placement = &v1alpha1.SnowPlacement{Policy: v1alpha1.SnowPlacementPin}
+	tt.expectDeviceVCPU("1.2.3.5", 16)
+
+	tt.Expect(tt.validator.ValidatePlacement(tt.ctx, config)).To(Succeed())
+}
@@ -7,15 +7,19 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	capiutil "sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
 	"github.com/aws/eks-anywhere/pkg/controller/clusters"
 	"github.com/aws/eks-anywhere/pkg/controller/serverside"
 	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
 )
 
 type CNIReconciler interface {
@@ -60,7 +64,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, c *anywhere
 	return controller.NewPhaseRunner[*cluster.Spec]().Register(
 		r.ipValidator.ValidateControlPlaneIP,
 		r.ValidateMachineConfigs,
+		r.ValidateIPPools,
 		clusters.CleanupStatusAfterValidate,
+		r.ReconcileDecommissionedDevices,
 		r.ReconcileControlPlane,
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
@@ -87,6 +93,69 @@ func (r *Reconciler) ValidateMachineConfigs(ctx context.Context, log logr.Logger
 	return controller.Result{}, nil
 }
 
+// ValidateIPPools validates the SnowIPPools of the cluster have enough addresses for its machines.
+func (r *Reconciler) ValidateIPPools(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "validateIPPools")
+	if err := snow.ValidateIPPoolCapacity(clusterSpec.Config); err != nil {
+		log.Error(err, "Insufficient SnowIPPool addresses")
+		clusterSpec.Cluster.SetFailure(anywherev1.IPPoolInsufficientReason, err.Error())
+		return controller.ResultWithReturn(), nil
+	}
+
+	return controller.Result{}, nil
+}
+
+// ReconcileDecommissionedDevices marks the machines running on the devices being decommissioned for deletion.
+// Machine templates are immutable, so removing the devices from the templates rolls out the node groups using them;
+// KCP and MachineSets delete the marked machines first, moving the machines off the devices before the others.
+func (r *Reconciler) ReconcileDecommissionedDevices(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
+	decommissioned := map[string]bool{}
+	for _, device := range clusterSpec.SnowDatacenter.Spec.DecommissionedDevices {
+		decommissioned[device] = true
+	}
+	if len(decommissioned) == 0 {
+		return controller.Result{}, nil
+	}
+
+	log = log.WithValues("phase", "reconcileDecommissionedDevices")
+	snowMachines := &snowv1.AWSSnowMachineList{}
+	if err := r.client.List(ctx, snowMachines,
+		client.InNamespace(constants.EksaSystemNamespace),
+		client.MatchingLabels{clusterv1beta2.ClusterNameLabel: clusterSpec.Cluster.Name},
+	); err != nil {
+		return controller.Result{}, errors.Wrap(err, "listing snow machines")
+	}
+
+	for _, snowMachine := range snowMachines.Items {
+		if !decommissioned[snowMachine.DeviceIP] {
+			continue
+		}
+
+		machine, err := capiutil.GetOwnerMachine(ctx, r.client, snowMachine.ObjectMeta)
+		if err != nil {
+			return controller.Result{}, errors.Wrapf(err, "getting owner machine of snow machine %s", snowMachine.Name)
+		}
+		if machine == nil {
+			continue
+		}
+		if _, ok := machine.Annotations[clusterv1beta2.DeleteMachineAnnotation]; ok {
+			continue
+		}
+
+		patch := client.MergeFrom(machine.DeepCopy())
+		if machine.Annotations == nil {
+			machine.Annotations = map[string]string{}
+		}
+		machine.Annotations[clusterv1beta2.DeleteMachineAnnotation] = "true"
+		if err := r.client.Patch(ctx, machine, patch); err != nil {
+			return controller.Result{}, errors.Wrapf(err, "marking machine %s for deletion", machine.Name)
+		}
+		log.Info("Marked machine on decommissioned device for deletion", "machine", machine.Name, "device", snowMachine.DeviceIP)
+	}
+
+	return controller.Result{}, nil
+}
+
 func (s *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "reconcileControlPlane")
 	log.Info("Applying control plane CAPI objects")
@@ -116,6 +116,70 @@ func TestReconcilerValidateMachineConfigsMachineConfigNotValidated(t *testing.T)
 	tt.Expect(tt.cluster.Status.FailureReason).To(BeNil())
 }
 
+func TestReconcilerValidateIPPoolsSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ValidateIPPools(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestReconcilerValidateIPPoolsNotEnoughAddresses(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+	spec := tt.buildSpec()
+	spec.SnowIPPools["test-ip-pool"].Spec.Pools[0].IPEnd = "1.2.3.10"
+
+	result, err := tt.reconciler().ValidateIPPools(tt.ctx, test.NewNullLogger(), spec)
+
+	tt.Expect(err).To(BeNil(), "error should be nil to prevent requeue")
+	tt.Expect(result).To(Equal(controller.Result{Result: &reconcile.Result{}}), "result should stop reconciliation")
+	tt.Expect(tt.cluster.Status.FailureMessage).To(HaveValue(ContainSubstring("SnowIPPool test-ip-pool doesn't have enough addresses")))
+	tt.Expect(tt.cluster.Status.FailureReason).To(HaveValue(Equal(anywherev1.IPPoolInsufficientReason)))
+}
+
+func TestReconcilerReconcileDecommissionedDevices(t *testing.T) {
+	tt := newReconcilerTest(t)
+	onDecommissioned := snowMachineOnDevice(tt.cluster.Name, "machine-1", "1.2.3.4")
+	onActive := snowMachineOnDevice(tt.cluster.Name, "machine-2", "1.2.3.5")
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs,
+		capiMachine(tt.cluster.Name, "machine-1"), onDecommissioned,
+		capiMachine(tt.cluster.Name, "machine-2"), onActive,
+	)
+	tt.withFakeClient()
+	spec := tt.buildSpec()
+	spec.SnowDatacenter.Spec.DecommissionedDevices = []string{"1.2.3.4"}
+
+	result, err := tt.reconciler().ReconcileDecommissionedDevices(tt.ctx, test.NewNullLogger(), spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	machine := &clusterv1beta2.Machine{}
+	tt.Expect(tt.client.Get(tt.ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: "machine-1"}, machine)).To(Succeed())
+	tt.Expect(machine.Annotations).To(HaveKeyWithValue(clusterv1beta2.DeleteMachineAnnotation, "true"))
+	tt.Expect(tt.client.Get(tt.ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: "machine-2"}, machine)).To(Succeed())
+	tt.Expect(machine.Annotations).NotTo(HaveKey(clusterv1beta2.DeleteMachineAnnotation))
+}
+
+func TestReconcilerReconcileDecommissionedDevicesNoDevices(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs,
+		capiMachine(tt.cluster.Name, "machine-1"), snowMachineOnDevice(tt.cluster.Name, "machine-1", "1.2.3.4"),
+	)
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ReconcileDecommissionedDevices(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	machine := &clusterv1beta2.Machine{}
+	tt.Expect(tt.client.Get(tt.ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: "machine-1"}, machine)).To(Succeed())
+	tt.Expect(machine.Annotations).NotTo(HaveKey(clusterv1beta2.DeleteMachineAnnotation))
+}
+
 func TestReconcilerReconcileWorkers(t *testing.T) {
 	t.Skip("Flaky (https://github.com/aws/eks-anywhere/issues/6999)")
 
@@ -516,6 +580,32 @@ func snowMachineConfig(opts ...snowMachineOpt) *anywherev1.SnowMachineConfig {
 	return m
 }
 
+func capiMachine(clusterName, name string) *clusterv1beta2.Machine {
+	return &clusterv1beta2.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1beta2.ClusterNameLabel: clusterName},
+		},
+	}
+}
+
+func snowMachineOnDevice(clusterName, machineName, device string) *snowv1.AWSSnowMachine {
+	return &snowv1.AWSSnowMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      machineName,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1beta2.ClusterNameLabel: clusterName},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: clusterv1beta2.GroupVersion.String(),
+				Kind:       "Machine",
+				Name:       machineName,
+			}},
+		},
+		DeviceIP: device,
+	}
+}
+
 func credentialsSecret() *corev1.Secret {
 	return &corev1.Secret{
 		TypeMeta: metav1.TypeMeta{
@@ -547,10 +637,10 @@ func ipPool() *anywherev1.SnowIPPool {
 		Spec: anywherev1.SnowIPPoolSpec{
 			Pools: []anywherev1.IPPool{
 				{
-					IPStart: "start",
-					IPEnd:   "end",
-					Gateway: "gateway",
-					Subnet:  "subnet",
+					IPStart: "1.2.3.10",
+					IPEnd:   "1.2.3.20",
+					Gateway: "1.2.3.1",
+					Subnet:  "1.2.3.0/24",
 				},
 			},
 		},
@@ -51,6 +51,7 @@ func WorkersSpec(ctx context.Context, log logr.Logger, spec *cluster.Spec, clien
 		capasPools.addPools(machineConfig.Spec.Network.DirectNetworkInterfaces, spec.SnowIPPools)
 
 		machineTemplate := MachineTemplate(clusterapi.WorkerMachineTemplateName(spec, wc), spec.SnowMachineConfigs[wc.MachineGroupRef.Name], capasPools)
+		setMachineTemplatePlacement(machineTemplate, machineConfig, spec.SnowDatacenter)
 
 		kubeadmConfigTemplate, err := KubeadmConfigTemplate(log, spec, wc)
 		if err != nil {
@@ -1,17 +1,22 @@
 package tinkerbell
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 
+	tinkv1alpha1 "github.com/tinkerbell/tink/api/v1alpha1"
 	controlplanev1beta2 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	tinkerbellv1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/capt/v1beta1"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
 // TODO(chrisdoherty) Add worker node group assertions
@@ -241,6 +246,81 @@ func addSelectorsFromMachineConfig(config *v1alpha1.TinkerbellMachineConfig, sel
 	return selectors.Add(config.Spec.HardwareSelector)
 }
 
+// StaticInterfacesSupportedAssertion asserts that hardware in catalogue with bonded NICs or
+// additional VLAN interfaces is only selected by machine configs whose OS family can render their
+// network configuration.
+func StaticInterfacesSupportedAssertion(catalogue *hardware.Catalogue) ClusterSpecAssertion {
+	return func(spec *ClusterSpec) error {
+		machineConfigs := []*v1alpha1.TinkerbellMachineConfig{spec.ControlPlaneMachineConfig()}
+		if spec.HasExternalEtcd() {
+			machineConfigs = append(machineConfigs, spec.ExternalEtcdMachineConfig())
+		}
+		for _, nodeGroup := range spec.WorkerNodeGroupConfigurations() {
+			machineConfigs = append(machineConfigs, spec.WorkerNodeGroupMachineConfig(nodeGroup))
+		}
+
+		for _, config := range machineConfigs {
+			if config.OSFamily() != v1alpha1.RedHat {
+				continue
+			}
+
+			selectors := selectorSet{}
+			if err := addSelectorsFromMachineConfig(config, &selectors); err != nil {
+				return err
+			}
+
+			for _, hw := range catalogue.AllHardware() {
+				if len(hw.Spec.Interfaces) > 1 && len(getMatchingHardwareSelectors(hw, selectors)) > 0 {
+					return fmt.Errorf(
+						"hardware %v has bonded NICs or additional VLANs which aren't supported by the %v os family of machine config %v",
+						hw.Name, v1alpha1.RedHat, config.Name,
+					)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// BMCHealthAssertionForCreate asserts the BMC of every hardware in catalogue selected by the
+// spec's machine configs is reachable with its credentials. All hardware is checked before
+// failing so every problem is reported at once. Problems that may not prevent provisioning,
+// such as a machine already powered on, are logged as warnings.
+func BMCHealthAssertionForCreate(ctx context.Context, catalogue *hardware.Catalogue, inspector bmc.Inspector) ClusterSpecAssertion {
+	return func(spec *ClusterSpec) error {
+		selectors, err := selectorsFromClusterSpec(spec)
+		if err != nil {
+			return err
+		}
+
+		var selected []*tinkv1alpha1.Hardware
+		for _, hw := range catalogue.AllHardware() {
+			if len(getMatchingHardwareSelectors(hw, selectors)) > 0 {
+				selected = append(selected, hw)
+			}
+		}
+
+		targets, err := bmc.TargetsFromCatalogue(catalogue, selected)
+		if err != nil {
+			return err
+		}
+
+		report := bmc.CheckHealth(ctx, inspector, targets)
+		if !report.Healthy() {
+			return fmt.Errorf("hardware bmc health checks failed, fix the problems below or skip the checks with --skip-validations=%s\n%s",
+				validations.TinkerbellBMCHealth, report.Table())
+		}
+
+		if report.HasFindings() {
+			logger.MarkWarning("Hardware bmc health checks found potential problems")
+			logger.Info(report.Table())
+		}
+
+		return nil
+	}
+}
+
 // MinimumHardwareAvailableAssertionForCreate asserts that catalogue has sufficient hardware to
 // support the ClusterSpec during a create workflow.
 //
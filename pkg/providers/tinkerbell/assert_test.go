@@ -1,6 +1,7 @@
 package tinkerbell_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
@@ -10,6 +11,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/onsi/gomega"
 	"github.com/tinkerbell/tink/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	controlplanev1beta2 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
@@ -21,6 +23,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/networkutils/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
@@ -723,6 +726,112 @@ func TestMinimumHardwareAvailableAssertionForCreate_InsufficientFailsWithoutExte
 	g.Expect(assertion(clusterSpec)).ToNot(gomega.Succeed())
 }
 
+func TestStaticInterfacesSupportedAssertion(t *testing.T) {
+	for name, tc := range map[string]struct {
+		osFamily eksav1alpha1.OSFamily
+		wantErr  string
+	}{
+		"Ubuntu":       {osFamily: eksav1alpha1.Ubuntu},
+		"RedHat":       {osFamily: eksav1alpha1.RedHat, wantErr: "hardware worker1 has bonded NICs or additional VLANs"},
+		"Bottlerocket": {osFamily: eksav1alpha1.Bottlerocket},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+			workerConfig := clusterSpec.WorkerNodeGroupMachineConfig(clusterSpec.WorkerNodeGroupConfigurations()[0])
+			workerConfig.Spec.OSFamily = tc.osFamily
+
+			catalogue := hardware.NewCatalogue()
+			writer := hardware.NewHardwareCatalogueWriter(catalogue)
+			g.Expect(writer.Write(hardware.Machine{
+				Hostname:        "worker1",
+				MACAddress:      "00:00:00:00:00:01",
+				Labels:          hardware.Labels(workerConfig.Spec.HardwareSelector),
+				AdditionalVLANs: hardware.VLANInterfaces{{ID: "300", IPAddress: "10.10.30.10", Netmask: "255.255.255.0"}},
+			})).To(gomega.Succeed())
+			g.Expect(writer.Write(hardware.Machine{
+				Hostname:   "cp1",
+				MACAddress: "00:00:00:00:00:02",
+				Labels:     hardware.Labels(clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector),
+			})).To(gomega.Succeed())
+
+			err := tinkerbell.StaticInterfacesSupportedAssertion(catalogue)(clusterSpec)
+			if tc.wantErr == "" {
+				g.Expect(err).To(gomega.Succeed())
+			} else {
+				g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(tc.wantErr)))
+			}
+		})
+	}
+}
+
+type fakeInspector struct {
+	inspections map[string]bmc.Inspection
+	errs        map[string]error
+}
+
+func (f fakeInspector) Inspect(_ context.Context, t bmc.Target) (bmc.Inspection, error) {
+	return f.inspections[t.Hostname], f.errs[t.Hostname]
+}
+
+func newBMCHealthCatalogue(g *gomega.WithT, clusterSpec *tinkerbell.ClusterSpec) *hardware.Catalogue {
+	catalogue := hardware.NewCatalogue(hardware.WithBMCNameIndex(), hardware.WithSecretNameIndex())
+	for hostname, selector := range map[string]eksav1alpha1.HardwareSelector{
+		"cp1":     clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector,
+		"etcd1":   clusterSpec.ExternalEtcdMachineConfig().Spec.HardwareSelector,
+		"worker1": clusterSpec.WorkerNodeGroupMachineConfig(clusterSpec.WorkerNodeGroupConfigurations()[0]).Spec.HardwareSelector,
+		"spare1":  {"type": "spare"},
+	} {
+		g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+			ObjectMeta: v1.ObjectMeta{Name: hostname, Labels: selector},
+			Spec: v1alpha1.HardwareSpec{
+				BMCRef:   &corev1.TypedLocalObjectReference{Name: "bmc-" + hostname, Kind: "Machine"},
+				Metadata: &v1alpha1.HardwareMetadata{Instance: &v1alpha1.MetadataInstance{Hostname: hostname}},
+			},
+		})).To(gomega.Succeed())
+	}
+	return catalogue
+}
+
+func TestBMCHealthAssertionForCreate_HealthySucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	catalogue := newBMCHealthCatalogue(g, clusterSpec)
+
+	inspector := fakeInspector{
+		inspections: map[string]bmc.Inspection{
+			"cp1":     {PowerState: string(bmc.PowerOff), Drives: 1},
+			"etcd1":   {PowerState: string(bmc.PowerOn), Drives: 1},
+			"worker1": {PowerState: string(bmc.PowerOff), Drives: bmc.DrivesUnknown},
+		},
+		// Hardware not selected by the spec must not be checked.
+		errs: map[string]error{"spare1": errors.New("connection refused")},
+	}
+
+	assertion := tinkerbell.BMCHealthAssertionForCreate(context.Background(), catalogue, inspector)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestBMCHealthAssertionForCreate_UnreachableFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	catalogue := newBMCHealthCatalogue(g, clusterSpec)
+
+	inspector := fakeInspector{
+		inspections: map[string]bmc.Inspection{
+			"cp1":   {PowerState: string(bmc.PowerOff), Drives: 1},
+			"etcd1": {PowerState: string(bmc.PowerOff), Drives: 1},
+		},
+		errs: map[string]error{"worker1": errors.New("401 unauthorized")},
+	}
+
+	assertion := tinkerbell.BMCHealthAssertionForCreate(context.Background(), catalogue, inspector)
+	err := assertion(clusterSpec)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("hardware bmc health checks failed")))
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("--skip-validations=tinkerbell-bmc-health")))
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("bmc unreachable or credentials rejected: 401 unauthorized")))
+}
+
 func TestValidatableClusterControlPlaneReplicaCount(t *testing.T) {
 	g := gomega.NewWithT(t)
 
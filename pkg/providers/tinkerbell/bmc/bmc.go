@@ -0,0 +1,186 @@
+// Package bmc provides power and boot device management of Tinkerbell hardware through their
+// baseboard management controllers (BMC). Operations are executed either through Rufio on a
+// management cluster or by connecting directly to the BMC when no cluster is available.
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// PowerAction is a power operation that can be executed against a BMC.
+type PowerAction string
+
+const (
+	// PowerOn powers on the machine.
+	PowerOn PowerAction = "on"
+	// PowerOff powers off the machine.
+	PowerOff PowerAction = "off"
+	// PowerCycle powers the machine off and back on.
+	PowerCycle PowerAction = "cycle"
+)
+
+// PowerActions lists the supported power actions.
+var PowerActions = []PowerAction{PowerOn, PowerOff, PowerCycle}
+
+// ParsePowerAction parses s into a PowerAction.
+func ParsePowerAction(s string) (PowerAction, error) {
+	for _, a := range PowerActions {
+		if strings.EqualFold(string(a), s) {
+			return a, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported power action %q: must be one of %v", s, PowerActions)
+}
+
+// BootDevice is a device a machine can be configured to boot from on its next boot.
+type BootDevice string
+
+const (
+	// BootDevicePXE boots the machine from the network.
+	BootDevicePXE BootDevice = "pxe"
+	// BootDeviceDisk boots the machine from its local disk.
+	BootDeviceDisk BootDevice = "disk"
+)
+
+// BootDevices lists the supported boot devices.
+var BootDevices = []BootDevice{BootDevicePXE, BootDeviceDisk}
+
+// ParseBootDevice parses s into a BootDevice.
+func ParseBootDevice(s string) (BootDevice, error) {
+	for _, d := range BootDevices {
+		if strings.EqualFold(string(d), s) {
+			return d, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported boot device %q: must be one of %v", s, BootDevices)
+}
+
+// PowerStateUnknown is reported when the power state of a machine can't be determined.
+const PowerStateUnknown = "unknown"
+
+// Target identifies the BMC of a single piece of hardware.
+type Target struct {
+	// Hostname is the hostname of the hardware the BMC belongs to.
+	Hostname string
+
+	// Machine references the Rufio Machine of the hardware. It is used when operating through a
+	// management cluster.
+	Machine types.NamespacedName
+
+	// Host, Username and Password are the BMC connection details. They are used when connecting
+	// directly to the BMC.
+	Host     string
+	Username string
+	Password string
+
+	// RedfishPort and RedfishBasicAuth customize the Redfish connection when connecting directly
+	// to the BMC, for example for virtual BMCs emulating Redfish for local VMs.
+	RedfishPort      int
+	RedfishBasicAuth bool
+
+	// EFIBoot instructs the BMC to set the boot device in EFI mode.
+	EFIBoot bool
+}
+
+// Client executes BMC operations against a Target.
+type Client interface {
+	// PowerState retrieves the current power state of t.
+	PowerState(ctx context.Context, t Target) (string, error)
+
+	// SetPowerState executes action against t.
+	SetPowerState(ctx context.Context, t Target, action PowerAction) error
+
+	// SetBootDevice configures t to boot from device on its next boot.
+	SetBootDevice(ctx context.Context, t Target, device BootDevice) error
+}
+
+// Selector selects hardware by hostname or by labels. An empty Selector matches nothing.
+type Selector struct {
+	Hostnames []string
+	Labels    labels.Selector
+}
+
+// IsEmpty returns true if s doesn't select any hardware.
+func (s Selector) IsEmpty() bool {
+	return len(s.Hostnames) == 0 && (s.Labels == nil || s.Labels.Empty())
+}
+
+func (s Selector) matches(hostname string, lbls map[string]string) bool {
+	for _, h := range s.Hostnames {
+		if h == hostname {
+			return true
+		}
+	}
+	return s.Labels != nil && !s.Labels.Empty() && s.Labels.Matches(labels.Set(lbls))
+}
+
+// missingHostnames returns the hostnames in s that aren't present in found.
+func (s Selector) missingHostnames(found map[string]struct{}) []string {
+	var missing []string
+	for _, h := range s.Hostnames {
+		if _, ok := found[h]; !ok {
+			missing = append(missing, h)
+		}
+	}
+	return missing
+}
+
+// Result is the outcome of a BMC operation against a single Target.
+type Result struct {
+	Hostname   string
+	PowerState string
+	Err        error
+}
+
+// Operation is a BMC operation executed against a single Target.
+type Operation func(ctx context.Context, c Client, t Target) Result
+
+// PowerStateOperation retrieves the power state of a target.
+func PowerStateOperation() Operation {
+	return func(ctx context.Context, c Client, t Target) Result {
+		state, err := c.PowerState(ctx, t)
+		return Result{Hostname: t.Hostname, PowerState: state, Err: err}
+	}
+}
+
+// SetPowerStateOperation executes action against a target.
+func SetPowerStateOperation(action PowerAction) Operation {
+	return func(ctx context.Context, c Client, t Target) Result {
+		return Result{Hostname: t.Hostname, Err: c.SetPowerState(ctx, t, action)}
+	}
+}
+
+// SetBootDeviceOperation configures a target to boot from device on its next boot.
+func SetBootDeviceOperation(device BootDevice) Operation {
+	return func(ctx context.Context, c Client, t Target) Result {
+		return Result{Hostname: t.Hostname, Err: c.SetBootDevice(ctx, t, device)}
+	}
+}
+
+// Run executes op against every target concurrently and returns the results sorted by hostname.
+func Run(ctx context.Context, c Client, targets []Target, op Operation) []Result {
+	results := make([]Result, len(targets))
+
+	var wg sync.WaitGroup
+	for i := range targets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = op(ctx, c, targets[i])
+		}(i)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Hostname < results[j].Hostname
+	})
+
+	return results
+}
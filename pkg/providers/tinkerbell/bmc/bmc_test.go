@@ -0,0 +1,70 @@
+package bmc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
+)
+
+func TestParsePowerAction(t *testing.T) {
+	g := NewWithT(t)
+
+	action, err := bmc.ParsePowerAction("Cycle")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(action).To(Equal(bmc.PowerCycle))
+
+	_, err = bmc.ParsePowerAction("reboot")
+	g.Expect(err).To(MatchError(ContainSubstring("unsupported power action")))
+}
+
+func TestParseBootDevice(t *testing.T) {
+	g := NewWithT(t)
+
+	device, err := bmc.ParseBootDevice("PXE")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(device).To(Equal(bmc.BootDevicePXE))
+
+	_, err = bmc.ParseBootDevice("usb")
+	g.Expect(err).To(MatchError(ContainSubstring("unsupported boot device")))
+}
+
+type fakeBMCClient struct {
+	state string
+	err   error
+}
+
+func (f fakeBMCClient) PowerState(context.Context, bmc.Target) (string, error) {
+	return f.state, f.err
+}
+
+func (f fakeBMCClient) SetPowerState(context.Context, bmc.Target, bmc.PowerAction) error {
+	return f.err
+}
+
+func (f fakeBMCClient) SetBootDevice(context.Context, bmc.Target, bmc.BootDevice) error {
+	return f.err
+}
+
+func TestRunSortsResults(t *testing.T) {
+	g := NewWithT(t)
+	targets := []bmc.Target{{Hostname: "worker1"}, {Hostname: "cp1"}}
+
+	results := bmc.Run(context.Background(), fakeBMCClient{state: "on"}, targets, bmc.PowerStateOperation())
+	g.Expect(results).To(Equal([]bmc.Result{
+		{Hostname: "cp1", PowerState: "on"},
+		{Hostname: "worker1", PowerState: "on"},
+	}))
+}
+
+func TestRunReportsErrors(t *testing.T) {
+	g := NewWithT(t)
+	targets := []bmc.Target{{Hostname: "cp1"}}
+	err := errors.New("connection refused")
+
+	results := bmc.Run(context.Background(), fakeBMCClient{err: err}, targets, bmc.SetBootDeviceOperation(bmc.BootDevicePXE))
+	g.Expect(results).To(Equal([]bmc.Result{{Hostname: "cp1", Err: err}}))
+}
@@ -0,0 +1,151 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/v2"
+	bmclibbmc "github.com/bmc-toolbox/bmclib/v2/bmc"
+	"github.com/go-logr/logr"
+)
+
+const defaultDirectTimeout = 2 * time.Minute
+
+// DirectClient executes BMC operations by connecting directly to the BMC with the credentials
+// of the target. It prefers Redfish and falls back to the remaining bmclib providers, including
+// IPMI. It's intended for troubleshooting hardware before a management cluster exists.
+type DirectClient struct {
+	log     logr.Logger
+	timeout time.Duration
+}
+
+var (
+	_ Client    = &DirectClient{}
+	_ Inspector = &DirectClient{}
+)
+
+// NewDirectClient returns a new DirectClient.
+func NewDirectClient(log logr.Logger) *DirectClient {
+	return &DirectClient{
+		log:     log,
+		timeout: defaultDirectTimeout,
+	}
+}
+
+// PowerState retrieves the power state of t.
+func (d *DirectClient) PowerState(ctx context.Context, t Target) (state string, reterr error) {
+	err := d.withConnection(ctx, t, func(ctx context.Context, c *bmclib.Client) error {
+		s, err := c.GetPowerState(ctx)
+		if err != nil {
+			return fmt.Errorf("getting power state: %v", err)
+		}
+		state = normalizePowerState(s)
+		return nil
+	})
+	if err != nil {
+		return PowerStateUnknown, err
+	}
+	return state, nil
+}
+
+// Inspect retrieves the power state, persistent boot override and drives of t. The boot override
+// and drives are best effort as not every BMC exposes them.
+func (d *DirectClient) Inspect(ctx context.Context, t Target) (Inspection, error) {
+	inspection := Inspection{PowerState: PowerStateUnknown, Drives: DrivesUnknown}
+	err := d.withConnection(ctx, t, func(ctx context.Context, c *bmclib.Client) error {
+		s, err := c.GetPowerState(ctx)
+		if err != nil {
+			return fmt.Errorf("getting power state: %v", err)
+		}
+		inspection.PowerState = normalizePowerState(s)
+
+		if override, err := c.GetBootDeviceOverride(ctx); err != nil {
+			d.log.V(4).Info("Boot device override not available", "host", t.Host, "error", err)
+		} else if override.IsPersistent && override.Device != bmclibbmc.BootDeviceTypeNone {
+			inspection.BootOverride = string(override.Device)
+		}
+
+		if device, err := c.Inventory(ctx); err != nil {
+			d.log.V(4).Info("Inventory not available", "host", t.Host, "error", err)
+		} else if device != nil {
+			inspection.Drives = len(device.Drives)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Inspection{PowerState: PowerStateUnknown, Drives: DrivesUnknown}, err
+	}
+	return inspection, nil
+}
+
+// SetPowerState executes action against t.
+func (d *DirectClient) SetPowerState(ctx context.Context, t Target, action PowerAction) error {
+	return d.withConnection(ctx, t, func(ctx context.Context, c *bmclib.Client) error {
+		if _, err := c.SetPowerState(ctx, string(action)); err != nil {
+			return fmt.Errorf("setting power state to %s: %v", action, err)
+		}
+		return nil
+	})
+}
+
+// SetBootDevice configures t to boot from device on its next boot.
+func (d *DirectClient) SetBootDevice(ctx context.Context, t Target, device BootDevice) error {
+	return d.withConnection(ctx, t, func(ctx context.Context, c *bmclib.Client) error {
+		if _, err := c.SetBootDevice(ctx, string(device), false, t.EFIBoot); err != nil {
+			return fmt.Errorf("setting boot device to %s: %v", device, err)
+		}
+		return nil
+	})
+}
+
+func (d *DirectClient) withConnection(ctx context.Context, t Target, fn func(context.Context, *bmclib.Client) error) (reterr error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	c := newBmclibClient(d.log, t)
+	if err := c.Open(ctx); err != nil {
+		md := c.GetMetadata()
+		return fmt.Errorf("connecting to bmc %s for %s (providers attempted: %v): %v", t.Host, t.Hostname, md.ProvidersAttempted, err)
+	}
+	defer func() {
+		if err := c.Close(ctx); err != nil && reterr == nil {
+			reterr = fmt.Errorf("closing connection to bmc %s for %s: %v", t.Host, t.Hostname, err)
+		}
+	}()
+
+	if err := fn(ctx, c); err != nil {
+		return fmt.Errorf("bmc %s for %s: %v", t.Host, t.Hostname, err)
+	}
+
+	return nil
+}
+
+func newBmclibClient(log logr.Logger, t Target) *bmclib.Client {
+	log = log.WithValues("host", t.Host, "username", t.Username)
+	opts := []bmclib.Option{bmclib.WithLogger(log), bmclib.WithRedfishUseBasicAuth(t.RedfishBasicAuth)}
+	if t.RedfishPort != 0 {
+		opts = append(opts, bmclib.WithRedfishPort(strconv.Itoa(t.RedfishPort)))
+	}
+	client := bmclib.NewClient(t.Host, t.Username, t.Password, opts...)
+	// Redfish bmc client generally seems to be more reliable in bmc interactions
+	// compared to other clients, including IPMI. Prefer it if available.
+	client.Registry.Drivers = client.Registry.PreferProtocol("redfish")
+	return client
+}
+
+// normalizePowerState converts the provider specific power states reported by bmclib, such as
+// "On" or "poweredOff", into on or off.
+func normalizePowerState(s string) string {
+	switch l := strings.ToLower(s); {
+	case strings.Contains(l, "off"):
+		return "off"
+	case strings.Contains(l, "on"):
+		return "on"
+	default:
+		return PowerStateUnknown
+	}
+}
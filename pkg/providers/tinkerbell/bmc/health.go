@@ -0,0 +1,162 @@
+package bmc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"text/tabwriter"
+)
+
+// DrivesUnknown is reported when the BMC doesn't expose the drives of the machine.
+const DrivesUnknown = -1
+
+// Inspection is the state of a machine as reported by its BMC.
+type Inspection struct {
+	// PowerState is the current power state of the machine.
+	PowerState string
+
+	// BootOverride is the device the machine is persistently configured to boot from. It's empty
+	// when there is no persistent override or the BMC doesn't report it.
+	BootOverride string
+
+	// Drives is the number of drives reported by the BMC or DrivesUnknown.
+	Drives int
+}
+
+// Inspector retrieves the state of machines from their BMC.
+type Inspector interface {
+	// Inspect connects to the BMC of t and retrieves the state of the machine. An error means the
+	// BMC is unreachable or rejected the credentials.
+	Inspect(ctx context.Context, t Target) (Inspection, error)
+}
+
+// NewInspector returns an Inspector that connects directly to the BMC of targets with connection
+// details and goes through Rufio for targets that only reference a Rufio Machine. rufio may be
+// nil when all targets carry connection details.
+func NewInspector(direct, rufio Inspector) Inspector {
+	return &routingInspector{direct: direct, rufio: rufio}
+}
+
+type routingInspector struct {
+	direct, rufio Inspector
+}
+
+func (r *routingInspector) Inspect(ctx context.Context, t Target) (Inspection, error) {
+	if t.Host != "" {
+		return r.direct.Inspect(ctx, t)
+	}
+	if r.rufio == nil {
+		return Inspection{}, fmt.Errorf("no bmc connection details for %s", t.Hostname)
+	}
+	return r.rufio.Inspect(ctx, t)
+}
+
+// Health is the outcome of checking the BMC of a single Target.
+type Health struct {
+	Hostname   string
+	PowerState string
+
+	// Errors are problems that prevent the machine from being provisioned.
+	Errors []string
+
+	// Warnings are problems that may prevent the machine from being provisioned.
+	Warnings []string
+}
+
+// Healthy returns true if no errors were found.
+func (h Health) Healthy() bool {
+	return len(h.Errors) == 0
+}
+
+// HealthReport aggregates the health of multiple targets.
+type HealthReport []Health
+
+// Healthy returns true if none of the targets has errors.
+func (r HealthReport) Healthy() bool {
+	for _, h := range r {
+		if !h.Healthy() {
+			return false
+		}
+	}
+	return true
+}
+
+// HasFindings returns true if any of the targets has errors or warnings.
+func (r HealthReport) HasFindings() bool {
+	for _, h := range r {
+		if len(h.Errors) > 0 || len(h.Warnings) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Table formats the errors and warnings of every target as a table, one finding per row.
+func (r HealthReport) Table() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "HOSTNAME\tPOWER\tSEVERITY\tPROBLEM")
+	for _, h := range r {
+		for _, e := range h.Errors {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", h.Hostname, h.PowerState, "error", e)
+		}
+		for _, warn := range h.Warnings {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", h.Hostname, h.PowerState, "warning", warn)
+		}
+	}
+	_ = w.Flush()
+	return buf.String()
+}
+
+// CheckHealth inspects every target concurrently and reports the problems that would prevent
+// them from being provisioned by Tinkerbell. The report is sorted by hostname.
+func CheckHealth(ctx context.Context, i Inspector, targets []Target) HealthReport {
+	report := make(HealthReport, len(targets))
+
+	var wg sync.WaitGroup
+	for idx := range targets {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			report[idx] = checkHealth(ctx, i, targets[idx])
+		}(idx)
+	}
+	wg.Wait()
+
+	sort.Slice(report, func(i, j int) bool {
+		return report[i].Hostname < report[j].Hostname
+	})
+
+	return report
+}
+
+func checkHealth(ctx context.Context, i Inspector, t Target) Health {
+	h := Health{Hostname: t.Hostname, PowerState: PowerStateUnknown}
+
+	inspection, err := i.Inspect(ctx, t)
+	if err != nil {
+		h.Errors = append(h.Errors, fmt.Sprintf("bmc unreachable or credentials rejected: %v", err))
+		return h
+	}
+	h.PowerState = inspection.PowerState
+
+	switch inspection.PowerState {
+	case PowerStateUnknown, "":
+		h.PowerState = PowerStateUnknown
+		h.Warnings = append(h.Warnings, "power state can't be determined")
+	case string(PowerOn):
+		h.Warnings = append(h.Warnings, "machine is powered on and may be running an existing operating system")
+	}
+
+	if inspection.BootOverride != "" && inspection.BootOverride != string(BootDevicePXE) {
+		h.Warnings = append(h.Warnings, fmt.Sprintf("persistent boot override to %s may prevent netboot", inspection.BootOverride))
+	}
+
+	if inspection.Drives == 0 {
+		h.Warnings = append(h.Warnings, "no drives reported by the bmc")
+	}
+
+	return h
+}
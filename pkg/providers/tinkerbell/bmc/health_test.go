@@ -0,0 +1,111 @@
+package bmc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
+)
+
+type fakeInspector map[string]struct {
+	inspection bmc.Inspection
+	err        error
+}
+
+func (f fakeInspector) Inspect(_ context.Context, t bmc.Target) (bmc.Inspection, error) {
+	r := f[t.Hostname]
+	return r.inspection, r.err
+}
+
+func TestCheckHealth(t *testing.T) {
+	g := NewWithT(t)
+	inspector := fakeInspector{
+		"worker1": {inspection: bmc.Inspection{PowerState: "off", Drives: 2}},
+		"cp2":     {err: errors.New("401 unauthorized")},
+		"cp1":     {inspection: bmc.Inspection{PowerState: "on", BootOverride: "disk", Drives: bmc.DrivesUnknown}},
+	}
+	targets := []bmc.Target{{Hostname: "worker1"}, {Hostname: "cp2"}, {Hostname: "cp1"}}
+
+	report := bmc.CheckHealth(context.Background(), inspector, targets)
+	g.Expect(report).To(Equal(bmc.HealthReport{
+		{
+			Hostname:   "cp1",
+			PowerState: "on",
+			Warnings: []string{
+				"machine is powered on and may be running an existing operating system",
+				"persistent boot override to disk may prevent netboot",
+			},
+		},
+		{
+			Hostname:   "cp2",
+			PowerState: bmc.PowerStateUnknown,
+			Errors:     []string{"bmc unreachable or credentials rejected: 401 unauthorized"},
+		},
+		{Hostname: "worker1", PowerState: "off"},
+	}))
+	g.Expect(report.Healthy()).To(BeFalse())
+	g.Expect(report.HasFindings()).To(BeTrue())
+}
+
+func TestCheckHealthHealthy(t *testing.T) {
+	g := NewWithT(t)
+	inspector := fakeInspector{
+		"cp1": {inspection: bmc.Inspection{PowerState: "off", BootOverride: "pxe", Drives: 1}},
+	}
+
+	report := bmc.CheckHealth(context.Background(), inspector, []bmc.Target{{Hostname: "cp1"}})
+	g.Expect(report.Healthy()).To(BeTrue())
+	g.Expect(report.HasFindings()).To(BeFalse())
+}
+
+func TestCheckHealthNoDrives(t *testing.T) {
+	g := NewWithT(t)
+	inspector := fakeInspector{
+		"cp1": {inspection: bmc.Inspection{PowerState: "off"}},
+	}
+
+	report := bmc.CheckHealth(context.Background(), inspector, []bmc.Target{{Hostname: "cp1"}})
+	g.Expect(report.Healthy()).To(BeTrue())
+	g.Expect(report[0].Warnings).To(ConsistOf("no drives reported by the bmc"))
+}
+
+func TestHealthReportTable(t *testing.T) {
+	g := NewWithT(t)
+	report := bmc.HealthReport{
+		{Hostname: "cp1", PowerState: "unknown", Errors: []string{"bmc unreachable"}},
+		{Hostname: "worker1", PowerState: "on", Warnings: []string{"machine is powered on"}},
+		{Hostname: "worker2", PowerState: "off"},
+	}
+
+	g.Expect(report.Table()).To(Equal(
+		"HOSTNAME  POWER    SEVERITY  PROBLEM\n" +
+			"cp1       unknown  error     bmc unreachable\n" +
+			"worker1   on       warning   machine is powered on\n",
+	))
+}
+
+func TestInspectorRoutesTargets(t *testing.T) {
+	g := NewWithT(t)
+	direct := fakeInspector{"cp1": {inspection: bmc.Inspection{PowerState: "on"}}}
+	rufio := fakeInspector{"cp2": {inspection: bmc.Inspection{PowerState: "off"}}}
+	inspector := bmc.NewInspector(direct, rufio)
+
+	inspection, err := inspector.Inspect(context.Background(), bmc.Target{Hostname: "cp1", Host: "192.168.0.10"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(inspection.PowerState).To(Equal("on"))
+
+	inspection, err = inspector.Inspect(context.Background(), bmc.Target{Hostname: "cp2"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(inspection.PowerState).To(Equal("off"))
+}
+
+func TestInspectorNoRufio(t *testing.T) {
+	g := NewWithT(t)
+	inspector := bmc.NewInspector(fakeInspector{}, nil)
+
+	_, err := inspector.Inspect(context.Background(), bmc.Target{Hostname: "cp2"})
+	g.Expect(err).To(MatchError(ContainSubstring("no bmc connection details for cp2")))
+}
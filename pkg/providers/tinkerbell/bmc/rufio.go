@@ -0,0 +1,215 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rufiov1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/rufio"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	defaultJobPollInterval = 2 * time.Second
+	defaultJobTimeout      = 3 * time.Minute
+
+	// jobHostnameLabel is set on the Rufio Jobs created by RufioClient to identify the hardware.
+	jobHostnameLabel = "anywhere.eks.amazonaws.com/bmc-hostname"
+)
+
+// RufioClient executes BMC operations by creating Rufio Jobs on a management cluster and waiting
+// for their completion.
+type RufioClient struct {
+	client       client.Client
+	pollInterval time.Duration
+	timeout      time.Duration
+}
+
+var (
+	_ Client    = &RufioClient{}
+	_ Inspector = &RufioClient{}
+)
+
+// RufioClientOpt configures a RufioClient.
+type RufioClientOpt func(*RufioClient)
+
+// WithJobTimeout sets the maximum time to wait for a Rufio Job to complete.
+func WithJobTimeout(timeout time.Duration) RufioClientOpt {
+	return func(c *RufioClient) {
+		c.timeout = timeout
+	}
+}
+
+// WithJobPollInterval sets the interval at which Rufio Jobs are checked for completion.
+func WithJobPollInterval(interval time.Duration) RufioClientOpt {
+	return func(c *RufioClient) {
+		c.pollInterval = interval
+	}
+}
+
+// NewRufioClient returns a new RufioClient that uses c to interact with the management cluster.
+func NewRufioClient(c client.Client, opts ...RufioClientOpt) *RufioClient {
+	r := &RufioClient{
+		client:       c,
+		pollInterval: defaultJobPollInterval,
+		timeout:      defaultJobTimeout,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// PowerState retrieves the power state reported by the Rufio Machine of t.
+func (r *RufioClient) PowerState(ctx context.Context, t Target) (string, error) {
+	machine := &rufiov1.Machine{}
+	if err := r.client.Get(ctx, t.Machine, machine); err != nil {
+		return PowerStateUnknown, fmt.Errorf("getting rufio machine %s: %v", t.Machine, err)
+	}
+
+	for _, c := range machine.Status.Conditions {
+		if c.Type == rufiov1.Contactable && c.Status == rufiov1.ConditionFalse {
+			return PowerStateUnknown, fmt.Errorf("bmc for %s is not contactable: %s", t.Hostname, c.Message)
+		}
+	}
+
+	if machine.Status.Power == "" {
+		return PowerStateUnknown, nil
+	}
+
+	return string(machine.Status.Power), nil
+}
+
+// Inspect retrieves the power state reported by the Rufio Machine of t. Rufio doesn't expose the
+// boot override or the drives of the machine so they're reported as unknown.
+func (r *RufioClient) Inspect(ctx context.Context, t Target) (Inspection, error) {
+	state, err := r.PowerState(ctx, t)
+	if err != nil {
+		return Inspection{}, err
+	}
+	return Inspection{PowerState: state, Drives: DrivesUnknown}, nil
+}
+
+// SetPowerState executes action against t by creating a Rufio Job and waiting for it to complete.
+func (r *RufioClient) SetPowerState(ctx context.Context, t Target, action PowerAction) error {
+	var task rufiov1.PowerAction
+	switch action {
+	case PowerOn:
+		task = rufiov1.PowerOn
+	case PowerOff:
+		task = rufiov1.PowerHardOff
+	case PowerCycle:
+		task = rufiov1.PowerCycle
+	default:
+		return fmt.Errorf("unsupported power action %q", action)
+	}
+
+	return r.runJob(ctx, t, "power-"+string(action), rufiov1.Action{PowerAction: &task})
+}
+
+// SetBootDevice configures t to boot from device on its next boot by creating a Rufio Job and
+// waiting for it to complete.
+func (r *RufioClient) SetBootDevice(ctx context.Context, t Target, device BootDevice) error {
+	var dev rufiov1.BootDevice
+	switch device {
+	case BootDevicePXE:
+		dev = rufiov1.PXE
+	case BootDeviceDisk:
+		dev = rufiov1.Disk
+	default:
+		return fmt.Errorf("unsupported boot device %q", device)
+	}
+
+	return r.runJob(ctx, t, "boot-"+string(device), rufiov1.Action{
+		OneTimeBootDeviceAction: &rufiov1.OneTimeBootDeviceAction{
+			Devices: []rufiov1.BootDevice{dev},
+			EFIBoot: t.EFIBoot,
+		},
+	})
+}
+
+func (r *RufioClient) runJob(ctx context.Context, t Target, operation string, tasks ...rufiov1.Action) error {
+	job := &rufiov1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rufiov1.GroupVersion.String(),
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: jobNamePrefix(t.Machine.Name, operation),
+			Namespace:    t.Machine.Namespace,
+			Labels: map[string]string{
+				jobHostnameLabel: t.Hostname,
+			},
+		},
+		Spec: rufiov1.JobSpec{
+			MachineRef: rufiov1.MachineRef{
+				Name:      t.Machine.Name,
+				Namespace: t.Machine.Namespace,
+			},
+			Tasks: tasks,
+		},
+	}
+
+	if err := r.client.Create(ctx, job); err != nil {
+		return fmt.Errorf("creating rufio job for %s: %v", t.Hostname, err)
+	}
+
+	key := client.ObjectKeyFromObject(job)
+	finished, err := r.waitForJob(ctx, t, key)
+	if !finished {
+		// A Job that didn't finish is left for Rufio to complete, deleting it wouldn't cancel
+		// the BMC operation.
+		return err
+	}
+
+	if deleteErr := r.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); deleteErr != nil && !apierrors.IsNotFound(deleteErr) {
+		logger.Info("Warning: failed to delete finished rufio job", "job", key, "error", deleteErr)
+	}
+
+	return err
+}
+
+// waitForJob waits for the Job key to complete or fail and returns whether it finished. The error
+// is the failure of the Job when it finished.
+func (r *RufioClient) waitForJob(ctx context.Context, t Target, key client.ObjectKey) (bool, error) {
+	var failure error
+	err := wait.PollUntilContextTimeout(ctx, r.pollInterval, r.timeout, true, func(ctx context.Context) (bool, error) {
+		job := &rufiov1.Job{}
+		if err := r.client.Get(ctx, key, job); err != nil {
+			return false, fmt.Errorf("getting rufio job %s: %v", key, err)
+		}
+
+		if job.HasCondition(rufiov1.JobFailed, rufiov1.ConditionTrue) {
+			failure = fmt.Errorf("rufio job %s for %s failed: %s", key, t.Hostname, jobConditionMessage(job, rufiov1.JobFailed))
+			return true, nil
+		}
+
+		return job.HasCondition(rufiov1.JobCompleted, rufiov1.ConditionTrue), nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("waiting for rufio job %s for %s: %v", key, t.Hostname, err)
+	}
+
+	return true, failure
+}
+
+func jobConditionMessage(job *rufiov1.Job, cType rufiov1.JobConditionType) string {
+	for _, c := range job.Status.Conditions {
+		if c.Type == cType {
+			return c.Message
+		}
+	}
+	return ""
+}
+
+// jobNamePrefix builds the DNS compliant prefix of the Job names for machine. The API server
+// appends a random suffix so concurrent operations on the same machine don't collide.
+func jobNamePrefix(machine, operation string) string {
+	return strings.ToLower(fmt.Sprintf("%s-%s-", machine, operation))
+}
@@ -0,0 +1,132 @@
+package bmc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	rufiov1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/rufio"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
+)
+
+var rufioTarget = bmc.Target{
+	Hostname: "cp1",
+	Machine:  types.NamespacedName{Name: "bmc-cp1", Namespace: constants.EksaSystemNamespace},
+}
+
+// newRufioClient returns a RufioClient whose Jobs are given status condition cType as soon as
+// they're created, and the Jobs it created.
+func newRufioClient(cType rufiov1alpha1.JobConditionType, message string) (*bmc.RufioClient, client.Client, *[]*rufiov1alpha1.Job) {
+	created := &[]*rufiov1alpha1.Job{}
+	cl := newFakeClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if job, ok := obj.(*rufiov1alpha1.Job); ok {
+				job.Status.Conditions = []rufiov1alpha1.JobCondition{
+					{Type: cType, Status: rufiov1alpha1.ConditionTrue, Message: message},
+				}
+				*created = append(*created, job)
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+
+	return bmc.NewRufioClient(cl, bmc.WithJobPollInterval(time.Millisecond), bmc.WithJobTimeout(time.Second)), cl, created
+}
+
+func TestRufioClientSetPowerState(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c, cl, created := newRufioClient(rufiov1alpha1.JobCompleted, "")
+
+	g.Expect(c.SetPowerState(ctx, rufioTarget, bmc.PowerOff)).To(Succeed())
+	g.Expect(c.SetPowerState(ctx, rufioTarget, bmc.PowerOff)).To(Succeed())
+
+	g.Expect(*created).To(HaveLen(2))
+	job := (*created)[0]
+	g.Expect(job.GenerateName).To(Equal("bmc-cp1-power-off-"))
+	g.Expect(job.Name).NotTo(Equal((*created)[1].Name))
+	g.Expect(job.Spec.MachineRef).To(Equal(rufiov1alpha1.MachineRef{Name: "bmc-cp1", Namespace: constants.EksaSystemNamespace}))
+	g.Expect(job.Spec.Tasks).To(HaveLen(1))
+	g.Expect(*job.Spec.Tasks[0].PowerAction).To(Equal(rufiov1alpha1.PowerHardOff))
+
+	jobs := &rufiov1alpha1.JobList{}
+	g.Expect(cl.List(ctx, jobs)).To(Succeed())
+	g.Expect(jobs.Items).To(BeEmpty())
+}
+
+func TestRufioClientSetBootDevice(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c, _, created := newRufioClient(rufiov1alpha1.JobCompleted, "")
+
+	target := rufioTarget
+	target.EFIBoot = true
+	g.Expect(c.SetBootDevice(ctx, target, bmc.BootDevicePXE)).To(Succeed())
+
+	g.Expect(*created).To(HaveLen(1))
+	g.Expect((*created)[0].Spec.Tasks[0].OneTimeBootDeviceAction).To(Equal(&rufiov1alpha1.OneTimeBootDeviceAction{
+		Devices: []rufiov1alpha1.BootDevice{rufiov1alpha1.PXE},
+		EFIBoot: true,
+	}))
+}
+
+func TestRufioClientJobFailed(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c, cl, _ := newRufioClient(rufiov1alpha1.JobFailed, "bmc unreachable")
+
+	err := c.SetPowerState(ctx, rufioTarget, bmc.PowerCycle)
+	g.Expect(err).To(MatchError(ContainSubstring("bmc unreachable")))
+
+	jobs := &rufiov1alpha1.JobList{}
+	g.Expect(cl.List(ctx, jobs)).To(Succeed())
+	g.Expect(jobs.Items).To(BeEmpty())
+}
+
+func TestRufioClientJobTimeout(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c, cl, _ := newRufioClient(rufiov1alpha1.JobRunning, "")
+
+	err := c.SetPowerState(ctx, rufioTarget, bmc.PowerOn)
+	g.Expect(err).To(MatchError(ContainSubstring("waiting for rufio job")))
+
+	jobs := &rufiov1alpha1.JobList{}
+	g.Expect(cl.List(ctx, jobs)).To(Succeed())
+	g.Expect(jobs.Items).To(HaveLen(1))
+}
+
+func TestRufioClientPowerState(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	machine := &rufiov1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "bmc-cp1", Namespace: constants.EksaSystemNamespace},
+		Status:     rufiov1alpha1.MachineStatus{Power: rufiov1alpha1.On},
+	}
+	c := bmc.NewRufioClient(newFakeClient(machine))
+
+	state, err := c.PowerState(ctx, rufioTarget)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(state).To(Equal("on"))
+}
+
+func TestRufioClientPowerStateNotContactable(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	machine := &rufiov1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "bmc-cp1", Namespace: constants.EksaSystemNamespace},
+	}
+	machine.SetCondition(rufiov1alpha1.Contactable, rufiov1alpha1.ConditionFalse, rufiov1alpha1.WithMachineConditionMessage("invalid credentials"))
+	c := bmc.NewRufioClient(newFakeClient(machine))
+
+	state, err := c.PowerState(ctx, rufioTarget)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid credentials")))
+	g.Expect(state).To(Equal(bmc.PowerStateUnknown))
+}
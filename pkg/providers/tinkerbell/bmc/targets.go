@@ -0,0 +1,168 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	tinkv1alpha1 "github.com/tinkerbell/tink/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+// TargetsFromMachines builds the targets for the machines read from reader that match s.
+// The targets carry the BMC credentials from the machine definition so they can be used to
+// connect directly to the BMC.
+func TargetsFromMachines(reader hardware.MachineReader, s Selector) ([]Target, error) {
+	var targets []Target
+	found := map[string]struct{}{}
+	for {
+		m, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading hardware: %v", err)
+		}
+
+		if !s.matches(m.Hostname, m.Labels) {
+			continue
+		}
+		found[m.Hostname] = struct{}{}
+
+		if !m.HasBMC() {
+			return nil, fmt.Errorf("hardware %s has no bmc configuration", m.Hostname)
+		}
+
+		targets = append(targets, Target{
+			Hostname: m.Hostname,
+			Host:     m.BMCIPAddress,
+			Username: m.BMCUsername,
+			Password: m.BMCPassword,
+
+			RedfishPort:      m.BMCPort,
+			RedfishBasicAuth: m.BMCVirtual,
+		})
+	}
+
+	if missing := s.missingHostnames(found); len(missing) > 0 {
+		return nil, fmt.Errorf("hardware not found: %v", missing)
+	}
+
+	return targets, nil
+}
+
+// TargetsFromCluster builds the targets for the Tinkerbell Hardware in the cluster that match s.
+// The targets reference the Rufio Machine associated with each Hardware.
+func TargetsFromCluster(ctx context.Context, c client.Client, s Selector) ([]Target, error) {
+	var hwList tinkv1alpha1.HardwareList
+	if err := c.List(ctx, &hwList, client.InNamespace(constants.EksaSystemNamespace)); err != nil {
+		return nil, fmt.Errorf("listing hardware: %v", err)
+	}
+
+	var targets []Target
+	found := map[string]struct{}{}
+	for _, hw := range hwList.Items {
+		hostname := hardwareHostname(hw)
+		if !s.matches(hostname, hw.Labels) {
+			continue
+		}
+		found[hostname] = struct{}{}
+
+		if hw.Spec.BMCRef == nil {
+			return nil, fmt.Errorf("hardware %s has no bmc reference", hostname)
+		}
+
+		targets = append(targets, Target{
+			Hostname: hostname,
+			Machine: types.NamespacedName{
+				Name:      hw.Spec.BMCRef.Name,
+				Namespace: hw.Namespace,
+			},
+			EFIBoot: hardwareUEFI(hw),
+		})
+	}
+
+	if missing := s.missingHostnames(found); len(missing) > 0 {
+		return nil, fmt.Errorf("hardware not found: %v", missing)
+	}
+
+	return targets, nil
+}
+
+// TargetsFromCatalogue builds the targets for hw. Hardware whose Rufio Machine and credentials
+// are in the catalogue, such as hardware read from a CSV, carries the connection details so its
+// BMC can be reached directly. Other hardware references its Rufio Machine. Hardware without a
+// BMC and hardware whose BMC is only reachable through RPC are skipped.
+func TargetsFromCatalogue(catalogue *hardware.Catalogue, hw []*tinkv1alpha1.Hardware) ([]Target, error) {
+	var targets []Target
+	for _, h := range hw {
+		if h.Spec.BMCRef == nil {
+			continue
+		}
+
+		target := Target{
+			Hostname: hardwareHostname(*h),
+			Machine: types.NamespacedName{
+				Name:      h.Spec.BMCRef.Name,
+				Namespace: h.Namespace,
+			},
+			EFIBoot: hardwareUEFI(*h),
+		}
+
+		machines, err := catalogue.LookupBMC(hardware.BMCNameIndex, h.Spec.BMCRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		if len(machines) == 0 {
+			targets = append(targets, target)
+			continue
+		}
+
+		conn := machines[0].Spec.Connection
+		if conn.ProviderOptions != nil && conn.ProviderOptions.RPC != nil {
+			continue
+		}
+
+		secrets, err := catalogue.LookupSecret(hardware.SecretNameIndex, conn.AuthSecretRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		if len(secrets) == 0 {
+			return nil, fmt.Errorf("bmc credentials %s for hardware %s not found", conn.AuthSecretRef.Name, target.Hostname)
+		}
+
+		target.Host = conn.Host
+		target.Username = string(secrets[0].Data["username"])
+		target.Password = string(secrets[0].Data["password"])
+		if conn.ProviderOptions != nil && conn.ProviderOptions.Redfish != nil {
+			target.RedfishPort = conn.ProviderOptions.Redfish.Port
+			target.RedfishBasicAuth = conn.ProviderOptions.Redfish.UseBasicAuth
+		}
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+// hardwareHostname returns the hostname of hw falling back to the object name when the
+// instance metadata isn't populated.
+func hardwareHostname(hw tinkv1alpha1.Hardware) string {
+	if hw.Spec.Metadata != nil && hw.Spec.Metadata.Instance != nil && hw.Spec.Metadata.Instance.Hostname != "" {
+		return hw.Spec.Metadata.Instance.Hostname
+	}
+	return hw.Name
+}
+
+func hardwareUEFI(hw tinkv1alpha1.Hardware) bool {
+	for _, iface := range hw.Spec.Interfaces {
+		if iface.DHCP != nil && iface.DHCP.UEFI {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,248 @@
+package bmc_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	tinkv1alpha1 "github.com/tinkerbell/tink/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rufiov1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/rufio"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+func TestTargetsFromMachinesByHostname(t *testing.T) {
+	g := NewWithT(t)
+	reader, err := hardware.NewNormalizedCSVReaderFromFile("testdata/hardware.csv", nil)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	targets, err := bmc.TargetsFromMachines(reader, bmc.Selector{Hostnames: []string{"worker1"}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(targets).To(ConsistOf(bmc.Target{
+		Hostname: "worker1",
+		Host:     "192.168.0.10",
+		Username: "Admin",
+		Password: "admin",
+	}))
+}
+
+func TestTargetsFromMachinesBySelector(t *testing.T) {
+	g := NewWithT(t)
+	reader, err := hardware.NewNormalizedCSVReaderFromFile("testdata/hardware.csv", nil)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	targets, err := bmc.TargetsFromMachines(reader, bmc.Selector{Labels: labels.SelectorFromSet(labels.Set{"type": "cp"})})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(targets).To(HaveLen(2))
+	g.Expect(targets[0].Hostname).To(Equal("cp1"))
+	g.Expect(targets[1].Hostname).To(Equal("cp2"))
+}
+
+func TestTargetsFromMachinesVirtualBMC(t *testing.T) {
+	g := NewWithT(t)
+	reader, err := hardware.NewNormalizedCSVReaderFromFile("testdata/virtual-hardware.csv", nil)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	targets, err := bmc.TargetsFromMachines(reader, bmc.Selector{Hostnames: []string{"lab-worker-0"}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(targets).To(ConsistOf(bmc.Target{
+		Hostname:         "lab-worker-0",
+		Host:             "10.80.0.1",
+		Username:         "admin",
+		Password:         "password",
+		RedfishPort:      8002,
+		RedfishBasicAuth: true,
+	}))
+}
+
+func TestTargetsFromMachinesHostnameNotFound(t *testing.T) {
+	g := NewWithT(t)
+	reader, err := hardware.NewNormalizedCSVReaderFromFile("testdata/hardware.csv", nil)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = bmc.TargetsFromMachines(reader, bmc.Selector{Hostnames: []string{"worker1", "missing"}})
+	g.Expect(err).To(MatchError(ContainSubstring("hardware not found: [missing]")))
+}
+
+func TestTargetsFromMachinesNoBMC(t *testing.T) {
+	g := NewWithT(t)
+	reader, err := hardware.NewNormalizedCSVReaderFromFile("testdata/hardware.csv", nil)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = bmc.TargetsFromMachines(reader, bmc.Selector{Hostnames: []string{"nobmc"}})
+	g.Expect(err).To(MatchError(ContainSubstring("hardware nobmc has no bmc configuration")))
+}
+
+func TestTargetsFromCluster(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	cl := newFakeClient(
+		newHardware("hw-cp1", "cp1", map[string]string{"type": "cp"}, true),
+		newHardware("hw-worker1", "worker1", map[string]string{"type": "worker"}, false),
+	)
+
+	targets, err := bmc.TargetsFromCluster(ctx, cl, bmc.Selector{Labels: labels.SelectorFromSet(labels.Set{"type": "cp"})})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(targets).To(ConsistOf(bmc.Target{
+		Hostname: "cp1",
+		Machine:  types.NamespacedName{Name: "bmc-cp1", Namespace: constants.EksaSystemNamespace},
+		EFIBoot:  true,
+	}))
+}
+
+func TestTargetsFromClusterMissingBMCRef(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	hw := newHardware("hw-cp1", "cp1", nil, false)
+	hw.Spec.BMCRef = nil
+	cl := newFakeClient(hw)
+
+	_, err := bmc.TargetsFromCluster(ctx, cl, bmc.Selector{Hostnames: []string{"cp1"}})
+	g.Expect(err).To(MatchError(ContainSubstring("hardware cp1 has no bmc reference")))
+}
+
+func TestTargetsFromClusterHostnameNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	_, err := bmc.TargetsFromCluster(ctx, newFakeClient(), bmc.Selector{Hostnames: []string{"cp1"}})
+	g.Expect(err).To(MatchError(ContainSubstring("hardware not found: [cp1]")))
+}
+
+func TestTargetsFromCatalogue(t *testing.T) {
+	g := NewWithT(t)
+	catalogue := hardware.NewCatalogue(
+		hardware.WithBMCNameIndex(),
+		hardware.WithSecretNameIndex(),
+	)
+	reader, err := hardware.NewNormalizedCSVReaderFromFile("testdata/hardware.csv", nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(hardware.TranslateAll(reader, hardware.NewMachineCatalogueWriter(catalogue), hardware.NewDefaultMachineValidator())).To(Succeed())
+	// Hardware read from a management cluster doesn't have its BMC in the catalogue.
+	g.Expect(catalogue.InsertHardware(newHardware("hw-cp3", "cp3", nil, true))).To(Succeed())
+
+	targets, err := bmc.TargetsFromCatalogue(catalogue, catalogue.AllHardware())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(targets).To(ConsistOf(
+		bmc.Target{
+			Hostname: "worker1",
+			Machine:  types.NamespacedName{Name: "bmc-worker1", Namespace: constants.EksaSystemNamespace},
+			EFIBoot:  true,
+			Host:     "192.168.0.10",
+			Username: "Admin",
+			Password: "admin",
+		},
+		bmc.Target{
+			Hostname: "cp1",
+			Machine:  types.NamespacedName{Name: "bmc-cp1", Namespace: constants.EksaSystemNamespace},
+			EFIBoot:  true,
+			Host:     "192.168.0.11",
+			Username: "Admin",
+			Password: "admin",
+		},
+		bmc.Target{
+			Hostname: "cp2",
+			Machine:  types.NamespacedName{Name: "bmc-cp2", Namespace: constants.EksaSystemNamespace},
+			EFIBoot:  true,
+			Host:     "192.168.0.12",
+			Username: "Admin",
+			Password: "admin",
+		},
+		bmc.Target{
+			Hostname: "cp3",
+			Machine:  types.NamespacedName{Name: "bmc-cp3", Namespace: constants.EksaSystemNamespace},
+			EFIBoot:  true,
+		},
+	))
+}
+
+func TestTargetsFromCatalogueVirtualBMC(t *testing.T) {
+	g := NewWithT(t)
+	catalogue := hardware.NewCatalogue(
+		hardware.WithBMCNameIndex(),
+		hardware.WithSecretNameIndex(),
+	)
+	reader, err := hardware.NewNormalizedCSVReaderFromFile("testdata/virtual-hardware.csv", nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(hardware.TranslateAll(reader, hardware.NewMachineCatalogueWriter(catalogue), hardware.NewDefaultMachineValidator())).To(Succeed())
+
+	targets, err := bmc.TargetsFromCatalogue(catalogue, catalogue.AllHardware())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(targets).To(HaveLen(2))
+	g.Expect(targets[0].Hostname).To(Equal("lab-cp-0"))
+	g.Expect(targets[0].Host).To(Equal("10.80.0.1"))
+	g.Expect(targets[0].RedfishPort).To(Equal(8001))
+	g.Expect(targets[0].RedfishBasicAuth).To(BeTrue())
+}
+
+func TestTargetsFromCatalogueMissingCredentials(t *testing.T) {
+	g := NewWithT(t)
+	catalogue := hardware.NewCatalogue(
+		hardware.WithBMCNameIndex(),
+		hardware.WithSecretNameIndex(),
+	)
+	hw := newHardware("hw-cp1", "cp1", nil, false)
+	g.Expect(catalogue.InsertHardware(hw)).To(Succeed())
+	g.Expect(catalogue.InsertBMC(&rufiov1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "bmc-cp1", Namespace: constants.EksaSystemNamespace},
+		Spec: rufiov1alpha1.MachineSpec{
+			Connection: rufiov1alpha1.Connection{
+				Host:          "192.168.0.11",
+				AuthSecretRef: corev1.SecretReference{Name: "bmc-cp1-auth", Namespace: constants.EksaSystemNamespace},
+			},
+		},
+	})).To(Succeed())
+
+	_, err := bmc.TargetsFromCatalogue(catalogue, catalogue.AllHardware())
+	g.Expect(err).To(MatchError(ContainSubstring("bmc credentials bmc-cp1-auth for hardware cp1 not found")))
+}
+
+func newHardware(name, hostname string, lbls map[string]string, uefi bool) *tinkv1alpha1.Hardware {
+	return &tinkv1alpha1.Hardware{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    lbls,
+		},
+		Spec: tinkv1alpha1.HardwareSpec{
+			BMCRef: &corev1.TypedLocalObjectReference{
+				Name: "bmc-" + hostname,
+				Kind: "Machine",
+			},
+			Interfaces: []tinkv1alpha1.Interface{
+				{
+					DHCP: &tinkv1alpha1.DHCP{
+						UEFI: uefi,
+					},
+				},
+			},
+			Metadata: &tinkv1alpha1.HardwareMetadata{
+				Instance: &tinkv1alpha1.MetadataInstance{
+					Hostname: hostname,
+				},
+			},
+		},
+	}
+}
+
+func newFakeClientBuilder(objs ...runtime.Object) *fake.ClientBuilder {
+	scheme := runtime.NewScheme()
+	_ = tinkv1alpha1.AddToScheme(scheme)
+	_ = rufiov1alpha1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...)
+}
+
+func newFakeClient(objs ...runtime.Object) client.Client {
+	return newFakeClientBuilder(objs...).Build()
+}
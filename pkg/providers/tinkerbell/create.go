@@ -197,6 +197,7 @@ func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpe
 	clusterSpecValidator := NewClusterSpecValidator(
 		MinimumHardwareAvailableAssertionForCreate(p.catalogue),
 		HardwareSatisfiesOnlyOneSelectorAssertion(p.catalogue),
+		StaticInterfacesSupportedAssertion(p.catalogue),
 	)
 
 	clusterSpecValidator.Register(AssertPortsNotInUse(p.netClient))
@@ -207,6 +208,20 @@ func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpe
 			clusterSpecValidator.Register(AssertTinkerbellIPNotInUse(p.netClient))
 		}
 	}
+
+	if p.BMCInspectorFactory != nil {
+		var managementKubeconfig string
+		if p.clusterConfig.IsManaged() {
+			managementKubeconfig = clusterSpec.ManagementCluster.KubeconfigFile
+		}
+		inspector, err := p.BMCInspectorFactory(managementKubeconfig)
+		if err != nil {
+			return err
+		}
+		// Registered last so BMCs are only contacted once the hardware selection is known to be valid.
+		clusterSpecValidator.Register(BMCHealthAssertionForCreate(ctx, p.catalogue, inspector))
+	}
+
 	// Validate must happen last beacuse we depend on the catalogue entries for some checks.
 	if err := clusterSpecValidator.Validate(spec); err != nil {
 		return err
@@ -106,6 +106,12 @@ func toRufioMachine(m Machine) *v1alpha1.Machine {
 			PreferredOrder: []v1alpha1.ProviderName{GofishProviderOption},
 		},
 	}
+	if m.BMCPort != 0 || m.BMCVirtual {
+		conn.ProviderOptions.Redfish = &v1alpha1.RedfishOptions{
+			Port:         m.BMCPort,
+			UseBasicAuth: m.BMCVirtual,
+		}
+	}
 	if m.BMCOptions != nil && m.BMCOptions.RPC.ConsumerURL != "" {
 		conn.ProviderOptions.RPC = toRPCOptions(m.BMCOptions.RPC, m)
 	}
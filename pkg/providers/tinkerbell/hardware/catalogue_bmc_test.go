@@ -82,6 +82,26 @@ func TestBMCCatalogueWriter_Write(t *testing.T) {
 	g.Expect(bmcs[0].Spec.Connection.AuthSecretRef.Name).To(gomega.ContainSubstring(machine.Hostname))
 }
 
+func TestBMCCatalogueWriter_WriteVirtualBMC(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	writer := hardware.NewBMCCatalogueWriter(catalogue)
+	machine := NewValidMachine()
+	machine.BMCPort = 8001
+	machine.BMCVirtual = true
+
+	err := writer.Write(machine)
+	g.Expect(err).To(gomega.Succeed())
+
+	bmcs := catalogue.AllBMCs()
+	g.Expect(bmcs).To(gomega.HaveLen(1))
+	g.Expect(bmcs[0].Spec.Connection.ProviderOptions.Redfish).To(gomega.Equal(&v1alpha1.RedfishOptions{
+		Port:         8001,
+		UseBasicAuth: true,
+	}))
+}
+
 func TestBMCMachineWithOptions(t *testing.T) {
 	g := gomega.NewWithT(t)
 
@@ -152,7 +152,7 @@ func hardwareFromMachine(m Machine) *tinkv1alpha1.Hardware {
 	allow := true
 
 	// TODO(chrisdoherty4) Set the namespace to the CAPT namespace.
-	return &tinkv1alpha1.Hardware{
+	hw := &tinkv1alpha1.Hardware{
 		TypeMeta: newHardwareTypeMeta(),
 		ObjectMeta: v1.ObjectMeta{
 			Name:      m.Hostname,
@@ -218,6 +218,72 @@ func hardwareFromMachine(m Machine) *tinkv1alpha1.Hardware {
 			},
 		},
 	}
+
+	if m.HasStaticInterfaces() {
+		addStaticInterfaces(hw, m)
+	}
+
+	return hw
+}
+
+const (
+	// BondInterfaceName is the name of the bond created on machines with bonded NICs. It's set as
+	// the DHCP interface name of every bonded NIC so the templates rendering the OS network
+	// configuration can identify them.
+	BondInterfaceName = "bond0"
+
+	// BondModeTagPrefix prefixes the instance metadata tag recording the bonding mode of machines
+	// with bonded NICs.
+	BondModeTagPrefix = "bond-mode="
+)
+
+// addStaticInterfaces appends the interfaces that can't be configured through DHCP to hw. The
+// primary interface remains first so the OS templates find the machine IP on it.
+//
+// Bonded NICs are appended with the BondInterfaceName and the primary interface's netboot and
+// DHCP configuration. Firmware can't netboot over a bond so whichever bonded NIC the machine
+// netboots from is served the machine IP and VLAN, and the hook, either netbooted or ISO booted
+// with static IPAM, reaches Tinkerbell through it.
+//
+// Additional VLANs are only created by the OS on top of the primary link, the bond or the primary
+// NIC, so they're appended without a MAC address and can't netboot.
+func addStaticInterfaces(hw *tinkv1alpha1.Hardware, m Machine) {
+	primary := hw.Spec.Interfaces[0]
+
+	if m.HasBond() {
+		mode := m.BondMode
+		if mode == "" {
+			mode = BondModeActiveBackup
+		}
+		hw.Spec.Metadata.Instance.Tags = append(hw.Spec.Metadata.Instance.Tags, BondModeTagPrefix+mode)
+		primary.DHCP.IfaceName = BondInterfaceName
+
+		for _, mac := range m.BondMACAddresses {
+			dhcp := primary.DHCP.DeepCopy()
+			dhcp.MAC = mac
+			hw.Spec.Interfaces = append(hw.Spec.Interfaces, tinkv1alpha1.Interface{
+				Netboot: primary.Netboot.DeepCopy(),
+				DHCP:    dhcp,
+			})
+		}
+	}
+
+	disallow := false
+	for _, vlan := range m.AdditionalVLANs {
+		hw.Spec.Interfaces = append(hw.Spec.Interfaces, tinkv1alpha1.Interface{
+			Netboot:     &tinkv1alpha1.Netboot{AllowPXE: &disallow, AllowWorkflow: &disallow},
+			DisableDHCP: true,
+			DHCP: &tinkv1alpha1.DHCP{
+				IfaceName: "vlan" + vlan.ID,
+				VLANID:    vlan.ID,
+				IP: &tinkv1alpha1.IP{
+					Address: vlan.IPAddress,
+					Netmask: vlan.Netmask,
+					Family:  4,
+				},
+			},
+		})
+	}
 }
 
 // newBMCRefFromMachine returns a BMCRef pointer for Hardware.
@@ -280,3 +280,42 @@ func TestHardwareCatalogueWriter_Write(t *testing.T) {
 	g.Expect(hardware).To(gomega.HaveLen(1))
 	g.Expect(hardware[0].Name).To(gomega.Equal(machine.Hostname))
 }
+
+func TestHardwareCatalogueWriter_WriteStaticInterfaces(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	writer := hardware.NewHardwareCatalogueWriter(catalogue)
+	machine := NewValidMachine()
+	machine.BondMACAddresses = hardware.MACAddresses{"00:00:00:00:00:01"}
+	machine.BondMode = hardware.BondModeLACP
+	machine.AdditionalVLANs = hardware.VLANInterfaces{{ID: "300", IPAddress: "10.10.30.10", Netmask: "255.255.255.0"}}
+
+	g.Expect(writer.Write(machine)).To(gomega.Succeed())
+
+	hw := catalogue.AllHardware()[0]
+	g.Expect(hw.Spec.Metadata.Instance.Tags).To(gomega.ConsistOf(hardware.BondModeTagPrefix + hardware.BondModeLACP))
+
+	interfaces := hw.Spec.Interfaces
+	g.Expect(interfaces).To(gomega.HaveLen(3))
+
+	// Every bonded NIC netboots with the machine IP so the hook can boot from any of them.
+	for i, mac := range []string{machine.MACAddress, "00:00:00:00:00:01"} {
+		g.Expect(interfaces[i].DHCP.MAC).To(gomega.Equal(mac))
+		g.Expect(interfaces[i].DHCP.IfaceName).To(gomega.Equal(hardware.BondInterfaceName))
+		g.Expect(interfaces[i].DHCP.IP.Address).To(gomega.Equal(machine.IPAddress))
+		g.Expect(interfaces[i].DHCP.VLANID).To(gomega.Equal(machine.VLANID))
+		g.Expect(*interfaces[i].Netboot.AllowPXE).To(gomega.BeTrue())
+		g.Expect(interfaces[i].DisableDHCP).To(gomega.BeFalse())
+	}
+	g.Expect(interfaces[0].Netboot).ToNot(gomega.BeIdenticalTo(interfaces[1].Netboot))
+
+	// Additional VLANs are created on the parent link so they have no MAC of their own.
+	g.Expect(interfaces[2].DHCP.MAC).To(gomega.BeEmpty())
+	g.Expect(*interfaces[2].Netboot.AllowPXE).To(gomega.BeFalse())
+	g.Expect(interfaces[2].DHCP.IfaceName).To(gomega.Equal("vlan300"))
+	g.Expect(interfaces[2].DHCP.VLANID).To(gomega.Equal("300"))
+	g.Expect(interfaces[2].DHCP.IP.Address).To(gomega.Equal("10.10.30.10"))
+	g.Expect(interfaces[2].DHCP.IP.Netmask).To(gomega.Equal("255.255.255.0"))
+	g.Expect(interfaces[2].DisableDHCP).To(gomega.BeTrue())
+}
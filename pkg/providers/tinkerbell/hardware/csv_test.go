@@ -53,6 +53,44 @@ func TestCSVReaderWithMultipleLabels(t *testing.T) {
 	g.Expect(machine).To(gomega.BeEquivalentTo(expect))
 }
 
+func TestCSVReaderWithStaticInterfaces(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	buf := NewBufferedCSV()
+
+	expect := NewValidMachine()
+	expect.BondMACAddresses = hardware.MACAddresses{"00:00:00:00:00:01", "00:00:00:00:00:02"}
+	expect.BondMode = hardware.BondModeLACP
+	expect.AdditionalVLANs = hardware.VLANInterfaces{
+		{ID: "300", IPAddress: "10.10.30.10", Netmask: "255.255.255.0"},
+		{ID: "400", IPAddress: "10.10.40.10", Netmask: "255.255.255.0"},
+	}
+
+	err := csv.MarshalCSV([]hardware.Machine{expect}, buf)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(buf.Buffer.String()).To(gomega.ContainSubstring("300:10.10.30.10:255.255.255.0|400:10.10.40.10:255.255.255.0"))
+
+	reader, err := hardware.NewCSVReader(buf.Buffer, nil)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	machine, err := reader.Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(machine).To(gomega.BeEquivalentTo(expect))
+}
+
+func TestCSVReaderWithBadlyFormattedAdditionalVLANs(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	buf := bytes.NewBufferString("hostname,ip_address,netmask,gateway,nameservers,mac,disk,labels,additional_vlans\n" +
+		"worker1,10.10.10.10,255.255.255.0,10.10.10.1,1.1.1.1,00:00:00:00:00:01,/dev/sda,type=worker,300:10.10.30.10\n")
+
+	reader, err := hardware.NewCSVReader(buf, nil)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	_, err = reader.Read()
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("badly formatted vlan interface")))
+}
+
 func TestCSVReaderFromFile(t *testing.T) {
 	g := gomega.NewWithT(t)
 
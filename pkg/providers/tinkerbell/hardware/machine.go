@@ -29,6 +29,27 @@ type Machine struct {
 	BMCPassword  string `csv:"bmc_password, omitempty"`
 	VLANID       string `csv:"vlan_id, omitempty"`
 
+	// BMCPort is the port of the Redfish service of the BMC. When unspecified, the standard HTTPS
+	// port is used.
+	BMCPort int `csv:"bmc_port, omitempty"`
+
+	// BMCVirtual indicates the BMC is a Redfish emulator, such as sushy-tools, managing a local
+	// virtual machine. It's intended for development and CI labs without physical hardware. Redfish
+	// emulators don't support sessions so basic auth is used instead.
+	BMCVirtual bool `csv:"bmc_virtual, omitempty"`
+
+	// BondMACAddresses are the MAC addresses of the NICs bonded with the NIC identified by
+	// MACAddress. When specified, the machine's IP is configured on the bond instead of the NIC.
+	BondMACAddresses MACAddresses `csv:"bond_macs, omitempty"`
+
+	// BondMode is the bonding mode used when BondMACAddresses is specified. Defaults to
+	// BondModeActiveBackup.
+	BondMode string `csv:"bond_mode, omitempty"`
+
+	// AdditionalVLANs are VLAN interfaces with static IPs created on top of the machine's primary
+	// link, the bond when BondMACAddresses is specified or the NIC otherwise.
+	AdditionalVLANs VLANInterfaces `csv:"additional_vlans, omitempty"`
+
 	// BMCOptions are the options used for Rufio providers.
 	BMCOptions *BMCOptions `csv:"-"`
 }
@@ -104,6 +125,112 @@ func (m *Machine) HasBMC() bool {
 	return m.BMCIPAddress != "" || m.BMCUsername != "" || m.BMCPassword != ""
 }
 
+// HasBond determines if m bonds multiple NICs.
+func (m *Machine) HasBond() bool {
+	return len(m.BondMACAddresses) > 0
+}
+
+// HasStaticInterfaces determines if m requires more than a single NIC to be configured. Such
+// machines need network configuration beyond what DHCP can provide.
+func (m *Machine) HasStaticInterfaces() bool {
+	return m.HasBond() || len(m.AdditionalVLANs) > 0
+}
+
+const (
+	// BondModeActiveBackup uses a single bonded NIC at a time and fails over to another NIC when
+	// it loses its link. It doesn't require any switch configuration.
+	BondModeActiveBackup = "active-backup"
+
+	// BondModeLACP aggregates bonded NICs using IEEE 802.3ad. It requires the switch ports to be
+	// configured as a LACP port channel.
+	BondModeLACP = "802.3ad"
+)
+
+// MACAddressesSeparator is used to unmarshal MACAddresses.
+const MACAddressesSeparator = "|"
+
+// MACAddresses is a custom type that can unmarshal a CSV representation of MAC addresses.
+type MACAddresses []string
+
+func (m *MACAddresses) String() string {
+	return strings.Join(*m, MACAddressesSeparator)
+}
+
+// UnmarshalCSV unmarshalls s where s is a list of MAC addresses separated by MACAddressesSeparator.
+func (m *MACAddresses) UnmarshalCSV(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+
+	for _, mac := range strings.Split(s, MACAddressesSeparator) {
+		*m = append(*m, strings.TrimSpace(mac))
+	}
+	return nil
+}
+
+// MarshalCSV marshalls MACAddresses into a string list of MAC addresses separated by MACAddressesSeparator.
+func (m *MACAddresses) MarshalCSV() (string, error) {
+	return m.String(), nil
+}
+
+// VLANInterface is a VLAN interface with a static IP.
+type VLANInterface struct {
+	ID        string
+	IPAddress string
+	Netmask   string
+}
+
+func (v VLANInterface) String() string {
+	return strings.Join([]string{v.ID, v.IPAddress, v.Netmask}, VLANInterfaceFieldSeparator)
+}
+
+const (
+	// VLANInterfacesSeparator is used to separate VLAN interfaces in a CSV field.
+	VLANInterfacesSeparator = "|"
+
+	// VLANInterfaceFieldSeparator is used to separate the ID, IP address and netmask of a VLAN
+	// interface.
+	VLANInterfaceFieldSeparator = ":"
+)
+
+// VLANInterfaces is a custom type that can unmarshal a CSV representation of VLAN interfaces in
+// the form <vlan_id>:<ip_address>:<netmask> separated by VLANInterfacesSeparator.
+type VLANInterfaces []VLANInterface
+
+func (v *VLANInterfaces) String() string {
+	vlans := make([]string, 0, len(*v))
+	for _, vlan := range *v {
+		vlans = append(vlans, vlan.String())
+	}
+	return strings.Join(vlans, VLANInterfacesSeparator)
+}
+
+// UnmarshalCSV unmarshalls s where s is a list of VLAN interfaces separated by VLANInterfacesSeparator.
+func (v *VLANInterfaces) UnmarshalCSV(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+
+	for _, vlan := range strings.Split(s, VLANInterfacesSeparator) {
+		fields := strings.Split(strings.TrimSpace(vlan), VLANInterfaceFieldSeparator)
+		if len(fields) != 3 {
+			return fmt.Errorf("badly formatted vlan interface, expected <vlan_id>:<ip_address>:<netmask>: %v", vlan)
+		}
+
+		*v = append(*v, VLANInterface{
+			ID:        strings.TrimSpace(fields[0]),
+			IPAddress: strings.TrimSpace(fields[1]),
+			Netmask:   strings.TrimSpace(fields[2]),
+		})
+	}
+	return nil
+}
+
+// MarshalCSV marshalls VLANInterfaces into a string list of VLAN interfaces separated by VLANInterfacesSeparator.
+func (v *VLANInterfaces) MarshalCSV() (string, error) {
+	return v.String(), nil
+}
+
 // NameserversSeparator is used to unmarshal Nameservers.
 const NameserversSeparator = "|"
 
@@ -46,9 +46,12 @@ func (n *Normalizer) Register(fn NormalizerFunc) {
 	n.normalizers = append(n.normalizers, fn)
 }
 
-// LowercaseMACAddress ensures m's MACAddress field has lower chase characters.
+// LowercaseMACAddress ensures m's MACAddress and BondMACAddresses fields have lower chase characters.
 func LowercaseMACAddress(m Machine) Machine {
 	m.MACAddress = strings.ToLower(m.MACAddress)
+	for i, mac := range m.BondMACAddresses {
+		m.BondMACAddresses[i] = strings.ToLower(mac)
+	}
 	return m
 }
 
@@ -127,6 +127,10 @@ func StaticMachineAssertions() MachineAssertion {
 				return fmt.Errorf("BMCIPAddress: %v", err)
 			}
 
+			if m.BMCPort < 0 || m.BMCPort > 65535 {
+				return errors.New("BMCPort: must be between 1 and 65535")
+			}
+
 			if m.BMCOptions == nil || m.BMCOptions.RPC == nil {
 				if m.BMCUsername == "" {
 					return newEmptyFieldError("BMCUsername")
@@ -139,27 +143,100 @@ func StaticMachineAssertions() MachineAssertion {
 		}
 
 		if m.VLANID != "" {
-			i, err := strconv.Atoi(m.VLANID)
-			if err != nil {
-				return errors.New("VLANID: must be a string integer")
+			if err := validateVLANID(m.VLANID); err != nil {
+				return fmt.Errorf("VLANID: %v", err)
 			}
+		}
 
-			// valid VLAN IDs are between 1 and 4094 - https://en.m.wikipedia.org/wiki/VLAN#IEEE_802.1Q
-			const (
-				maxVLANID = 4094
-				minVLANID = 1
-			)
-			if i < minVLANID || i > maxVLANID {
-				return errors.New("VLANID: must be between 1 and 4094")
-			}
+		if err := validateBond(m); err != nil {
+			return err
 		}
 
+		return validateAdditionalVLANs(m)
+	}
+}
+
+func validateVLANID(id string) error {
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return errors.New("must be a string integer")
+	}
+
+	// valid VLAN IDs are between 1 and 4094 - https://en.m.wikipedia.org/wiki/VLAN#IEEE_802.1Q
+	const (
+		maxVLANID = 4094
+		minVLANID = 1
+	)
+	if i < minVLANID || i > maxVLANID {
+		return errors.New("must be between 1 and 4094")
+	}
+
+	return nil
+}
+
+func validateBond(m Machine) error {
+	if !m.HasBond() {
+		if m.BondMode != "" {
+			return errors.New("BondMode: requires BondMACAddresses")
+		}
 		return nil
 	}
+
+	switch m.BondMode {
+	case "", BondModeActiveBackup, BondModeLACP:
+	default:
+		return fmt.Errorf("BondMode: must be one of %v or %v", BondModeActiveBackup, BondModeLACP)
+	}
+
+	seen := map[string]struct{}{m.MACAddress: {}}
+	for _, mac := range m.BondMACAddresses {
+		if _, err := net.ParseMAC(mac); err != nil {
+			return fmt.Errorf("BondMACAddresses: %v", err)
+		}
+
+		if _, ok := seen[mac]; ok {
+			return fmt.Errorf("BondMACAddresses: duplicate MAC address: %v", mac)
+		}
+		seen[mac] = struct{}{}
+	}
+
+	return nil
 }
 
-// UniqueIPAddress asserts a given Machine instance has a unique IPAddress field relative to previously seen Machine
-// instances. It is not thread safe. It has a 1 time use.
+func validateAdditionalVLANs(m Machine) error {
+	seen := map[string]struct{}{}
+	if m.VLANID != "" {
+		seen[m.VLANID] = struct{}{}
+	}
+
+	for _, vlan := range m.AdditionalVLANs {
+		if err := validateVLANID(vlan.ID); err != nil {
+			return fmt.Errorf("AdditionalVLANs: VLAN ID %v %v", vlan.ID, err)
+		}
+
+		if _, ok := seen[vlan.ID]; ok {
+			return fmt.Errorf("AdditionalVLANs: duplicate VLAN ID: %v", vlan.ID)
+		}
+		seen[vlan.ID] = struct{}{}
+
+		if err := networkutils.ValidateIP(vlan.IPAddress); err != nil {
+			return fmt.Errorf("AdditionalVLANs: VLAN %v: %v", vlan.ID, err)
+		}
+
+		if vlan.Netmask == "" {
+			return fmt.Errorf("AdditionalVLANs: VLAN %v: netmask is empty", vlan.ID)
+		}
+
+		if ip := net.ParseIP(vlan.Netmask).To4(); ip == nil {
+			return fmt.Errorf("AdditionalVLANs: VLAN %v: invalid netmask: %v", vlan.ID, vlan.Netmask)
+		}
+	}
+
+	return nil
+}
+
+// UniqueIPAddress asserts a given Machine instance has unique IPAddress and AdditionalVLANs IP addresses relative
+// to previously seen Machine instances. It is not thread safe. It has a 1 time use.
 func UniqueIPAddress() MachineAssertion {
 	ips := make(map[string]struct{})
 	return func(m Machine) error {
@@ -169,20 +246,30 @@ func UniqueIPAddress() MachineAssertion {
 
 		ips[m.IPAddress] = struct{}{}
 
+		for _, vlan := range m.AdditionalVLANs {
+			if _, seen := ips[vlan.IPAddress]; seen {
+				return fmt.Errorf("duplicate IPAddress: %v", vlan.IPAddress)
+			}
+
+			ips[vlan.IPAddress] = struct{}{}
+		}
+
 		return nil
 	}
 }
 
-// UniqueMACAddress asserts a given Machine instance has a unique MACAddress field relative to previously seen Machine
-// instances. It is not thread safe. It has a 1 time use.
+// UniqueMACAddress asserts a given Machine instance has unique MACAddress and BondMACAddresses fields relative to
+// previously seen Machine instances. It is not thread safe. It has a 1 time use.
 func UniqueMACAddress() MachineAssertion {
 	macs := make(map[string]struct{})
 	return func(m Machine) error {
-		if _, seen := macs[m.MACAddress]; seen {
-			return fmt.Errorf("duplicate MACAddress: %v", m.MACAddress)
-		}
+		for _, mac := range append([]string{m.MACAddress}, m.BondMACAddresses...) {
+			if _, seen := macs[mac]; seen {
+				return fmt.Errorf("duplicate MACAddress: %v", mac)
+			}
 
-		macs[m.MACAddress] = struct{}{}
+			macs[mac] = struct{}{}
+		}
 
 		return nil
 	}
@@ -204,7 +291,8 @@ func UniqueHostnames() MachineAssertion {
 }
 
 // UniqueBMCIPAddress asserts a given Machine instance has a unique BMCIPAddress field relative to previously seen
-// Machine instances. If there is no BMC configuration as defined by machine.HasBMC() the check is a noop. It is
+// Machine instances. Machines with a BMCPort, like virtual BMCs sharing the address of their host, are unique by
+// address and port. If there is no BMC configuration as defined by machine.HasBMC() the check is a noop. It is
 // not thread safe. It has a 1 time use.
 func UniqueBMCIPAddress() MachineAssertion {
 	ips := make(map[string]struct{})
@@ -217,11 +305,16 @@ func UniqueBMCIPAddress() MachineAssertion {
 			return fmt.Errorf("missing BMCIPAddress (mac=\"%v\")", m.MACAddress)
 		}
 
-		if _, seen := ips[m.BMCIPAddress]; seen {
-			return fmt.Errorf("duplicate IPAddress: %v", m.BMCIPAddress)
+		address := m.BMCIPAddress
+		if m.BMCPort != 0 {
+			address = net.JoinHostPort(m.BMCIPAddress, strconv.Itoa(m.BMCPort))
+		}
+
+		if _, seen := ips[address]; seen {
+			return fmt.Errorf("duplicate IPAddress: %v", address)
 		}
 
-		ips[m.BMCIPAddress] = struct{}{}
+		ips[address] = struct{}{}
 
 		return nil
 	}
@@ -82,6 +82,13 @@ func TestUniquenessAssertions(t *testing.T) {
 				{BMCIPAddress: "bar"},
 			},
 		},
+		"BMCIPAddressesWithPorts": {
+			Assertion: hardware.UniqueBMCIPAddress(),
+			Machines: []hardware.Machine{
+				{BMCIPAddress: "foo", BMCPort: 8001},
+				{BMCIPAddress: "foo", BMCPort: 8002},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -119,6 +126,20 @@ func TestUniquenessAssertionsWithDupes(t *testing.T) {
 				{Hostname: "foo"},
 			},
 		},
+		"AdditionalVLANIPAddresses": {
+			Assertion: hardware.UniqueIPAddress(),
+			Machines: []hardware.Machine{
+				{IPAddress: "foo"},
+				{IPAddress: "bar", AdditionalVLANs: hardware.VLANInterfaces{{IPAddress: "foo"}}},
+			},
+		},
+		"BondMACAddresses": {
+			Assertion: hardware.UniqueMACAddress(),
+			Machines: []hardware.Machine{
+				{MACAddress: "foo"},
+				{MACAddress: "bar", BondMACAddresses: hardware.MACAddresses{"foo"}},
+			},
+		},
 		"BMCIPAddresses": {
 			Assertion: hardware.UniqueBMCIPAddress(),
 			Machines: []hardware.Machine{
@@ -126,6 +147,13 @@ func TestUniquenessAssertionsWithDupes(t *testing.T) {
 				{BMCIPAddress: "foo"},
 			},
 		},
+		"BMCIPAddressesWithPorts": {
+			Assertion: hardware.UniqueBMCIPAddress(),
+			Machines: []hardware.Machine{
+				{BMCIPAddress: "foo", BMCPort: 8001},
+				{BMCIPAddress: "foo", BMCPort: 8001},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -146,6 +174,21 @@ func TestStaticMachineAssertions_ValidMachine(t *testing.T) {
 	g.Expect(validate(machine)).ToNot(gomega.HaveOccurred())
 }
 
+func TestStaticMachineAssertions_ValidMachineWithStaticInterfaces(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	machine := NewValidMachine()
+	machine.BondMACAddresses = hardware.MACAddresses{"00:00:00:00:00:01"}
+	machine.BondMode = hardware.BondModeLACP
+	machine.AdditionalVLANs = hardware.VLANInterfaces{
+		{ID: "300", IPAddress: "10.10.30.10", Netmask: "255.255.255.0"},
+		{ID: "400", IPAddress: "10.10.40.10", Netmask: "255.255.255.0"},
+	}
+
+	validate := hardware.StaticMachineAssertions()
+	g.Expect(validate(machine)).ToNot(gomega.HaveOccurred())
+}
+
 func TestStaticMachineAssertions_InvalidMachines(t *testing.T) {
 	g := gomega.NewWithT(t)
 
@@ -195,6 +238,9 @@ func TestStaticMachineAssertions_InvalidMachines(t *testing.T) {
 		"EmptyBMCPassword": func(h *hardware.Machine) {
 			h.BMCPassword = ""
 		},
+		"InvalidBMCPort": func(h *hardware.Machine) {
+			h.BMCPort = 65536
+		},
 		"InvalidLabelKey": func(h *hardware.Machine) {
 			h.Labels["?$?$?"] = "foo"
 		},
@@ -216,6 +262,31 @@ func TestStaticMachineAssertions_InvalidMachines(t *testing.T) {
 		"NonIntVLAN": func(h *hardware.Machine) {
 			h.VLANID = "im not an int"
 		},
+		"InvalidBondMACAddress": func(h *hardware.Machine) {
+			h.BondMACAddresses = hardware.MACAddresses{"invalid mac"}
+		},
+		"BondMACAddressIsPrimary": func(h *hardware.Machine) {
+			h.BondMACAddresses = hardware.MACAddresses{h.MACAddress}
+		},
+		"InvalidBondMode": func(h *hardware.Machine) {
+			h.BondMACAddresses = hardware.MACAddresses{"00:00:00:00:00:01"}
+			h.BondMode = "balance-rr"
+		},
+		"BondModeWithoutBond": func(h *hardware.Machine) {
+			h.BondMode = hardware.BondModeLACP
+		},
+		"InvalidAdditionalVLANID": func(h *hardware.Machine) {
+			h.AdditionalVLANs = hardware.VLANInterfaces{{ID: "4095", IPAddress: "10.10.20.10", Netmask: "255.255.255.0"}}
+		},
+		"AdditionalVLANIsPrimaryVLAN": func(h *hardware.Machine) {
+			h.AdditionalVLANs = hardware.VLANInterfaces{{ID: h.VLANID, IPAddress: "10.10.20.10", Netmask: "255.255.255.0"}}
+		},
+		"InvalidAdditionalVLANIPAddress": func(h *hardware.Machine) {
+			h.AdditionalVLANs = hardware.VLANInterfaces{{ID: "300", IPAddress: "invalid", Netmask: "255.255.255.0"}}
+		},
+		"InvalidAdditionalVLANNetmask": func(h *hardware.Machine) {
+			h.AdditionalVLANs = hardware.VLANInterfaces{{ID: "300", IPAddress: "10.10.20.10", Netmask: "invalid"}}
+		},
 	}
 
 	validate := hardware.StaticMachineAssertions()
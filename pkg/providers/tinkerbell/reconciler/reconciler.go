@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -23,6 +26,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/controller/clusters"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/workflow"
 )
 
 const (
@@ -104,6 +108,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *an
 		r.ValidateRufioMachines,
 		r.CleanupStatusAfterValidate,
 		r.ReconcileControlPlane,
+		r.ReconcileWorkflowStatus,
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
 		r.ReconcileWorkers,
@@ -199,6 +204,38 @@ func (r *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger,
 	return clusters.ReconcileControlPlane(ctx, log, r.client, toClientControlPlane(tinkerbellScope.ControlPlane))
 }
 
+// ReconcileWorkflowStatus reports the progress of the Tinkerbell workflows provisioning the cluster machines
+// in the HardwareProvisioned condition. It never blocks the following phases: a failed workflow is surfaced
+// in the condition so clients waiting on the cluster can stop early.
+func (r *Reconciler) ReconcileWorkflowStatus(ctx context.Context, log logr.Logger, tinkerbellScope *Scope) (controller.Result, error) {
+	cluster := tinkerbellScope.ClusterSpec.Cluster
+	log = log.WithValues("phase", "reconcileWorkflowStatus")
+
+	summary, err := workflow.ForCluster(ctx, clientutil.NewKubeClient(r.client), cluster.Name, time.Now())
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if len(summary.Machines) == 0 {
+		return controller.Result{}, nil
+	}
+
+	if failures := summary.Failures(); len(failures) > 0 {
+		message := summary.FailureMessage()
+		log.Info("Tinkerbell workflow failed", "message", message)
+		v1beta1conditions.MarkFalse(cluster, anywherev1.HardwareProvisionedCondition, anywherev1.WorkflowFailedReason, clusterv1.ConditionSeverityError, "%s", message)
+		return controller.Result{}, nil
+	}
+
+	if !summary.Completed() {
+		v1beta1conditions.MarkFalse(cluster, anywherev1.HardwareProvisionedCondition, anywherev1.WorkflowsInProgressReason, clusterv1.ConditionSeverityInfo, "%s", summary.ProgressMessage())
+		return controller.Result{}, nil
+	}
+
+	v1beta1conditions.MarkTrue(cluster, anywherev1.HardwareProvisionedCondition)
+	return controller.Result{}, nil
+}
+
 // CheckControlPlaneReady checks whether the control plane for an eks-a cluster is ready or not.
 // Requeues with the appropriate wait times whenever the cluster is not ready yet.
 func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger, tinkerbellScope *Scope) (controller.Result, error) {
@@ -16,7 +16,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	bootstrapv1beta2 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	controlplanev1beta2 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -779,6 +781,87 @@ func TestReconcilerValidateRufioMachinesWithSkipLabel(t *testing.T) {
 	tt.cleanup()
 }
 
+func TestReconcilerReconcileWorkflowStatusNoMachines(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ReconcileWorkflowStatus(tt.ctx, test.NewNullLogger(), tt.buildScope())
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(v1beta1conditions.Has(tt.cluster, anywherev1.HardwareProvisionedCondition)).To(BeFalse())
+	tt.cleanup()
+}
+
+func TestReconcilerReconcileWorkflowStatusInProgress(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs,
+		tinkerbellMachine(tt.cluster.Name, "cp-1", "hw1"),
+		tinkerbellMachine(tt.cluster.Name, "md-1", "hw2"),
+		tinkWorkflow("cp-1", tinkv1alpha1.WorkflowStateSuccess),
+		tinkWorkflow("md-1", tinkv1alpha1.WorkflowStateRunning, tinkv1alpha1.Action{
+			Name:   "stream-image",
+			Status: tinkv1alpha1.WorkflowStateRunning,
+		}),
+	)
+	tt.withFakeClient()
+	scope := tt.buildScope()
+
+	result, err := tt.reconciler().ReconcileWorkflowStatus(tt.ctx, test.NewNullLogger(), scope)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	condition := v1beta1conditions.Get(scope.ClusterSpec.Cluster, anywherev1.HardwareProvisionedCondition)
+	tt.Expect(condition).NotTo(BeNil())
+	tt.Expect(condition.Reason).To(Equal(anywherev1.WorkflowsInProgressReason))
+	tt.Expect(condition.Severity).To(Equal(clusterv1.ConditionSeverityInfo))
+	tt.Expect(condition.Message).To(ContainSubstring("1/2 machines provisioned"))
+	tt.Expect(condition.Message).To(ContainSubstring("md-1 (hardware hw2): running action stream-image"))
+	tt.cleanup()
+}
+
+func TestReconcilerReconcileWorkflowStatusFailed(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs,
+		tinkerbellMachine(tt.cluster.Name, "cp-1", "hw1"),
+		tinkWorkflow("cp-1", tinkv1alpha1.WorkflowStateFailed, tinkv1alpha1.Action{
+			Name:    "stream-image",
+			Status:  tinkv1alpha1.WorkflowStateFailed,
+			Seconds: 30,
+			Message: "image not found",
+		}),
+	)
+	tt.withFakeClient()
+	scope := tt.buildScope()
+
+	result, err := tt.reconciler().ReconcileWorkflowStatus(tt.ctx, test.NewNullLogger(), scope)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}), "result should not stop reconciliation")
+	condition := v1beta1conditions.Get(scope.ClusterSpec.Cluster, anywherev1.HardwareProvisionedCondition)
+	tt.Expect(condition).NotTo(BeNil())
+	tt.Expect(condition.Reason).To(Equal(anywherev1.WorkflowFailedReason))
+	tt.Expect(condition.Severity).To(Equal(clusterv1.ConditionSeverityError))
+	tt.Expect(condition.Message).To(Equal("provisioning workflow failed for cp-1 (hardware hw1): action stream-image failed: image not found"))
+	tt.cleanup()
+}
+
+func TestReconcilerReconcileWorkflowStatusCompleted(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs,
+		tinkerbellMachine(tt.cluster.Name, "cp-1", "hw1"),
+		tinkWorkflow("cp-1", tinkv1alpha1.WorkflowStateSuccess),
+	)
+	tt.withFakeClient()
+	scope := tt.buildScope()
+
+	_, err := tt.reconciler().ReconcileWorkflowStatus(tt.ctx, test.NewNullLogger(), scope)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(v1beta1conditions.IsTrue(scope.ClusterSpec.Cluster, anywherev1.HardwareProvisionedCondition)).To(BeTrue())
+	tt.cleanup()
+}
+
 func TestReconcilerDetectOperationK8sVersionUpgrade(t *testing.T) {
 	tt := newReconcilerTest(t)
 	tt.createAllObjs()
@@ -1238,6 +1321,30 @@ func controlPlaneMachineTemplate() *tinkerbellv1.TinkerbellMachineTemplate {
 	}
 }
 
+func tinkerbellMachine(clusterName, name, hardwareName string) *tinkerbellv1.TinkerbellMachine {
+	return &tinkerbellv1.TinkerbellMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1beta2.ClusterNameLabel: clusterName},
+		},
+		Spec: tinkerbellv1.TinkerbellMachineSpec{HardwareName: hardwareName},
+	}
+}
+
+func tinkWorkflow(name string, state tinkv1alpha1.WorkflowState, actions ...tinkv1alpha1.Action) *tinkv1alpha1.Workflow {
+	return &tinkv1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Status: tinkv1alpha1.WorkflowStatus{
+			State: state,
+			Tasks: []tinkv1alpha1.Task{{Name: "os-installation", Actions: actions}},
+		},
+	}
+}
+
 func tinkHardware(hardwareName, labelType string) *tinkv1alpha1.Hardware {
 	return &tinkv1alpha1.Hardware{
 		TypeMeta: metav1.TypeMeta{
@@ -0,0 +1,330 @@
+package stack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// stackName is the name given to the deployment and service of the Tinkerbell stack chart.
+	stackName = "tinkerbell"
+
+	tootlesPort = "7172"
+	dhcpPort    = "67"
+
+	defaultProbeTimeout = 5 * time.Second
+)
+
+// Port is a network port the Tinkerbell stack serves on the Tinkerbell IP.
+type Port struct {
+	// Component is the stack component serving the port.
+	Component string
+	// Protocol is either tcp or udp.
+	Protocol string
+	// Number is the port number.
+	Number string
+}
+
+func (p Port) String() string {
+	return fmt.Sprintf("%s/%s", p.Number, p.Protocol)
+}
+
+// StackPorts returns the ports the Tinkerbell stack is expected to answer on the Tinkerbell IP.
+func StackPorts() []Port {
+	return []Port{
+		{Component: "smee (dhcp)", Protocol: "udp", Number: dhcpPort},
+		{Component: "smee (http)", Protocol: "tcp", Number: smeeHTTPPort},
+		{Component: "tootles (metadata)", Protocol: "tcp", Number: tootlesPort},
+		{Component: "tink-server (grpc)", Protocol: "tcp", Number: grpcPort},
+	}
+}
+
+// Check is the result of a single Tinkerbell stack health check.
+type Check struct {
+	// Component is the part of the stack the check applies to.
+	Component string
+	// Name describes what was checked.
+	Name string
+	// Healthy is true when the check passed.
+	Healthy bool
+	// Unverified is true when the check found no problem but couldn't confirm the component works.
+	// Unverified checks aren't reported as failed.
+	Unverified bool
+	// Details explains the result.
+	Details string
+}
+
+// HealthReport aggregates the result of the Tinkerbell stack health checks.
+type HealthReport struct {
+	Checks []Check
+}
+
+// Failed returns the checks that didn't pass.
+func (r HealthReport) Failed() []Check {
+	var failed []Check
+	for _, c := range r.Checks {
+		if !c.Healthy && !c.Unverified {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// Healthy returns true when every check passed.
+func (r HealthReport) Healthy() bool {
+	return len(r.Failed()) == 0
+}
+
+// HealthChecker checks the health of a Tinkerbell stack deployed to a cluster.
+type HealthChecker struct {
+	client    client.Client
+	namespace string
+	ports     []Port
+	timeout   time.Duration
+}
+
+// HealthCheckerOpt customizes a HealthChecker.
+type HealthCheckerOpt func(*HealthChecker)
+
+// WithPorts overrides the ports probed on the Tinkerbell IP.
+func WithPorts(ports []Port) HealthCheckerOpt {
+	return func(h *HealthChecker) {
+		h.ports = ports
+	}
+}
+
+// WithProbeTimeout sets the maximum time spent probing each port.
+func WithProbeTimeout(timeout time.Duration) HealthCheckerOpt {
+	return func(h *HealthChecker) {
+		h.timeout = timeout
+	}
+}
+
+// NewHealthChecker returns a HealthChecker for the stack installed in namespace.
+func NewHealthChecker(client client.Client, namespace string, opts ...HealthCheckerOpt) *HealthChecker {
+	h := &HealthChecker{
+		client:    client,
+		namespace: namespace,
+		ports:     StackPorts(),
+		timeout:   defaultProbeTimeout,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Check runs all the stack health checks. Checks that fail are reported in the HealthReport,
+// an error is only returned when the cluster can't be queried.
+func (h *HealthChecker) Check(ctx context.Context, tinkerbellIP string) (HealthReport, error) {
+	report := HealthReport{}
+
+	deploymentChecks, err := h.checkDeployment(ctx)
+	if err != nil {
+		return HealthReport{}, err
+	}
+	report.Checks = append(report.Checks, deploymentChecks...)
+
+	serviceCheck, err := h.checkService(ctx, tinkerbellIP)
+	if err != nil {
+		return HealthReport{}, err
+	}
+	report.Checks = append(report.Checks, serviceCheck)
+
+	report.Checks = append(report.Checks, h.ProbePorts(ctx, tinkerbellIP)...)
+
+	return report, nil
+}
+
+func (h *HealthChecker) checkDeployment(ctx context.Context) ([]Check, error) {
+	deployment := &appsv1.Deployment{}
+	err := h.client.Get(ctx, client.ObjectKey{Name: stackName, Namespace: h.namespace}, deployment)
+	if apierrors.IsNotFound(err) {
+		return []Check{{
+			Component: stackName,
+			Name:      "deployment",
+			Details:   fmt.Sprintf("deployment %s/%s not found", h.namespace, stackName),
+		}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting tinkerbell stack deployment: %v", err)
+	}
+
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	checks := []Check{{
+		Component: stackName,
+		Name:      "deployment",
+		Healthy:   deployment.Status.ReadyReplicas >= desired,
+		Details:   fmt.Sprintf("%d/%d replicas ready", deployment.Status.ReadyReplicas, desired),
+	}}
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("parsing tinkerbell stack deployment selector: %v", err)
+	}
+
+	pods := &corev1.PodList{}
+	if err := h.client.List(ctx, pods, client.InNamespace(h.namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("listing tinkerbell stack pods: %v", err)
+	}
+
+	sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+	for _, pod := range pods.Items {
+		checks = append(checks, podCheck(pod))
+	}
+
+	return checks, nil
+}
+
+func podCheck(pod corev1.Pod) Check {
+	check := Check{
+		Component: stackName,
+		Name:      fmt.Sprintf("pod %s", pod.Name),
+		Healthy:   pod.Status.Phase == corev1.PodRunning,
+	}
+
+	var restarts int32
+	var notReady []string
+	for _, c := range pod.Status.ContainerStatuses {
+		restarts += c.RestartCount
+		if !c.Ready {
+			notReady = append(notReady, containerNotReadyReason(c))
+		}
+	}
+
+	details := []string{string(pod.Status.Phase)}
+	if len(notReady) > 0 {
+		check.Healthy = false
+		details = append(details, fmt.Sprintf("containers not ready: %s", strings.Join(notReady, ", ")))
+	}
+	if restarts > 0 {
+		details = append(details, fmt.Sprintf("%d restarts", restarts))
+	}
+	check.Details = strings.Join(details, "; ")
+
+	return check
+}
+
+func containerNotReadyReason(c corev1.ContainerStatus) string {
+	switch {
+	case c.State.Waiting != nil && c.State.Waiting.Reason != "":
+		return fmt.Sprintf("%s (%s)", c.Name, c.State.Waiting.Reason)
+	case c.State.Terminated != nil && c.State.Terminated.Reason != "":
+		return fmt.Sprintf("%s (%s)", c.Name, c.State.Terminated.Reason)
+	default:
+		return c.Name
+	}
+}
+
+func (h *HealthChecker) checkService(ctx context.Context, tinkerbellIP string) (Check, error) {
+	check := Check{Component: stackName, Name: "service"}
+
+	service := &corev1.Service{}
+	err := h.client.Get(ctx, client.ObjectKey{Name: stackName, Namespace: h.namespace}, service)
+	if apierrors.IsNotFound(err) {
+		check.Details = fmt.Sprintf("service %s/%s not found", h.namespace, stackName)
+		return check, nil
+	}
+	if err != nil {
+		return Check{}, fmt.Errorf("getting tinkerbell stack service: %v", err)
+	}
+
+	var ips []string
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			ips = append(ips, ingress.IP)
+		}
+	}
+
+	switch {
+	case len(ips) == 0:
+		check.Details = "load balancer has no ingress ip assigned"
+	case !contains(ips, tinkerbellIP):
+		check.Details = fmt.Sprintf("load balancer ingress %s doesn't match tinkerbell ip %s", strings.Join(ips, ","), tinkerbellIP)
+	default:
+		check.Healthy = true
+		check.Details = fmt.Sprintf("load balancer ingress %s", strings.Join(ips, ","))
+	}
+
+	return check, nil
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// ProbePorts checks the stack ports answer on tinkerbellIP. TCP ports must accept a connection.
+// UDP is connectionless and the DHCP server only answers the MAC addresses of known hardware, on
+// ports the CLI can't listen on, so UDP ports are reported unhealthy when the host actively refuses
+// the datagram and unverified otherwise.
+func (h *HealthChecker) ProbePorts(ctx context.Context, tinkerbellIP string) []Check {
+	checks := make([]Check, 0, len(h.ports))
+	for _, p := range h.ports {
+		check := Check{Component: p.Component, Name: fmt.Sprintf("port %s", p)}
+		address := net.JoinHostPort(tinkerbellIP, p.Number)
+		switch err := probe(ctx, tinkerbellIP, p, h.timeout); {
+		case err != nil:
+			check.Details = err.Error()
+		case p.Protocol == "udp":
+			check.Unverified = true
+			check.Details = fmt.Sprintf("%s didn't refuse the datagram but udp ports can't be confirmed open", address)
+		default:
+			check.Healthy = true
+			check.Details = fmt.Sprintf("%s answering", address)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func probe(ctx context.Context, ip string, port Port, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	address := net.JoinHostPort(ip, port.Number)
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, port.Protocol, address)
+	if err != nil {
+		return fmt.Errorf("connecting to %s: %v", address, err)
+	}
+	defer conn.Close()
+
+	if port.Protocol != "udp" {
+		return nil
+	}
+
+	// A closed UDP port only manifests as an ICMP port unreachable reported on the next read.
+	// No answer before the deadline means the port is open or filtered, which we can't tell apart.
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return fmt.Errorf("setting deadline for %s: %v", address, err)
+	}
+	if _, err := conn.Write([]byte{0}); err != nil {
+		return fmt.Errorf("writing to %s: %v", address, err)
+	}
+	if _, err := conn.Read(make([]byte, 1)); errors.Is(err, syscall.ECONNREFUSED) {
+		return fmt.Errorf("%s refused the connection", address)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package stack_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/stack"
+)
+
+func newStackDeployment(ready int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "tinkerbell", Namespace: constants.EksaSystemNamespace},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "tinkerbell"}},
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: ready},
+	}
+}
+
+func newStackPod(name string, phase corev1.PodPhase, containers ...corev1.ContainerStatus) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{"app": "tinkerbell"},
+		},
+		Status: corev1.PodStatus{Phase: phase, ContainerStatuses: containers},
+	}
+}
+
+func newStackService(ips ...string) *corev1.Service {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "tinkerbell", Namespace: constants.EksaSystemNamespace},
+	}
+	for _, ip := range ips {
+		svc.Status.LoadBalancer.Ingress = append(svc.Status.LoadBalancer.Ingress, corev1.LoadBalancerIngress{IP: ip})
+	}
+	return svc
+}
+
+func newHealthChecker(ports []stack.Port, objs ...runtime.Object) *stack.HealthChecker {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+	return stack.NewHealthChecker(c, constants.EksaSystemNamespace, stack.WithPorts(ports), stack.WithProbeTimeout(time.Second))
+}
+
+func listenTCP(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+	_, port, _ := net.SplitHostPort(l.Addr().String())
+	return port
+}
+
+func closedTCPPort(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	_, port, _ := net.SplitHostPort(l.Addr().String())
+	l.Close()
+	return port
+}
+
+func TestHealthCheckerCheckHealthy(t *testing.T) {
+	g := NewWithT(t)
+	ports := []stack.Port{{Component: "tink-server (grpc)", Protocol: "tcp", Number: listenTCP(t)}}
+	h := newHealthChecker(ports,
+		newStackDeployment(1),
+		newStackPod("tinkerbell-abc", corev1.PodRunning, corev1.ContainerStatus{Name: "tinkerbell", Ready: true}),
+		newStackService("127.0.0.1"),
+	)
+
+	report, err := h.Check(context.Background(), "127.0.0.1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(report.Checks).To(HaveLen(4))
+	g.Expect(report.Healthy()).To(BeTrue(), "failed checks: %v", report.Failed())
+}
+
+func TestHealthCheckerCheckUnhealthy(t *testing.T) {
+	g := NewWithT(t)
+	ports := []stack.Port{{Component: "smee (http)", Protocol: "tcp", Number: closedTCPPort(t)}}
+	h := newHealthChecker(ports,
+		newStackDeployment(0),
+		newStackPod("tinkerbell-abc", corev1.PodRunning, corev1.ContainerStatus{
+			Name:         "tinkerbell",
+			RestartCount: 3,
+			State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		}),
+		newStackService("10.0.0.1"),
+	)
+
+	report, err := h.Check(context.Background(), "127.0.0.1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(report.Healthy()).To(BeFalse())
+
+	failed := report.Failed()
+	g.Expect(failed).To(HaveLen(4))
+	g.Expect(failed[0].Details).To(Equal("0/1 replicas ready"))
+	g.Expect(failed[1].Name).To(Equal("pod tinkerbell-abc"))
+	g.Expect(failed[1].Details).To(Equal("Running; containers not ready: tinkerbell (CrashLoopBackOff); 3 restarts"))
+	g.Expect(failed[2].Details).To(Equal("load balancer ingress 10.0.0.1 doesn't match tinkerbell ip 127.0.0.1"))
+	g.Expect(failed[3].Component).To(Equal("smee (http)"))
+}
+
+func TestHealthCheckerCheckStackMissing(t *testing.T) {
+	g := NewWithT(t)
+	h := newHealthChecker(nil)
+
+	report, err := h.Check(context.Background(), "127.0.0.1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(report.Checks).To(HaveLen(2))
+	g.Expect(report.Checks[0].Details).To(Equal("deployment eksa-system/tinkerbell not found"))
+	g.Expect(report.Checks[1].Details).To(Equal("service eksa-system/tinkerbell not found"))
+}
+
+func TestHealthCheckerProbePortsUDP(t *testing.T) {
+	g := NewWithT(t)
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	g.Expect(err).ToNot(HaveOccurred())
+	defer conn.Close()
+	_, open, _ := net.SplitHostPort(conn.LocalAddr().String())
+
+	closedConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	g.Expect(err).ToNot(HaveOccurred())
+	_, closed, _ := net.SplitHostPort(closedConn.LocalAddr().String())
+	closedConn.Close()
+
+	h := newHealthChecker([]stack.Port{
+		{Component: "open", Protocol: "udp", Number: open},
+		{Component: "closed", Protocol: "udp", Number: closed},
+	})
+
+	checks := h.ProbePorts(context.Background(), "127.0.0.1")
+	g.Expect(checks).To(HaveLen(2))
+	g.Expect(checks[0].Healthy).To(BeFalse())
+	g.Expect(checks[0].Unverified).To(BeTrue())
+	g.Expect(checks[1].Healthy).To(BeFalse())
+	g.Expect(checks[1].Unverified).To(BeFalse())
+	g.Expect(checks[1].Details).To(ContainSubstring("refused the connection"))
+
+	report := stack.HealthReport{Checks: checks}
+	g.Expect(report.Failed()).To(ConsistOf(checks[1]))
+}
+
+func TestStackPorts(t *testing.T) {
+	g := NewWithT(t)
+	var ports []string
+	for _, p := range stack.StackPorts() {
+		ports = append(ports, p.String())
+	}
+	g.Expect(ports).To(Equal([]string{"67/udp", "7171/tcp", "7172/tcp", "42113/tcp"}))
+}
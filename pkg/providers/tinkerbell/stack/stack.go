@@ -553,7 +553,7 @@ func (s *Installer) createValuesOverride(bundle releasev1alpha1.TinkerbellBundle
 	tinkerbellImage, tinkerbellTag := parseImageURI(tinkerbellImageURI)
 
 	valuesMap := map[string]any{
-		"name":     "tinkerbell",
+		"name":     stackName,
 		"publicIP": tinkerbellIP,
 		"trustedProxies": []string{
 			s.podCidrRange,
@@ -14,13 +14,16 @@ import (
 	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/rufiounreleased"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/stack"
@@ -62,6 +65,9 @@ type Provider struct {
 	tinkerbellIP    string
 	// BMCOptions are Rufio BMC options that are used when creating Rufio machine CRDs.
 	BMCOptions *hardware.BMCOptions
+	// BMCInspectorFactory builds the inspector used to check the health of the hardware BMCs
+	// before creating a cluster. The checks are skipped when nil.
+	BMCInspectorFactory BMCInspectorFactory
 
 	// TODO(chrisdoheryt4) Temporarily depend on the netclient until the validator can be injected.
 	// This is already a dependency, just uncached, because we require it during the initializing
@@ -74,6 +80,26 @@ type Provider struct {
 	retrier      *retrier.Retrier
 }
 
+// BMCInspectorFactory builds a bmc.Inspector. managementKubeconfig is the kubeconfig of the
+// management cluster when creating a workload cluster and empty otherwise.
+type BMCInspectorFactory func(managementKubeconfig string) (bmc.Inspector, error)
+
+// NewBMCInspector returns a bmc.Inspector that connects directly to the BMCs of hardware read from
+// the hardware CSV and, when managementKubeconfig is provided, goes through Rufio on the management
+// cluster for the hardware that already exists there.
+func NewBMCInspector(managementKubeconfig string) (bmc.Inspector, error) {
+	var rufio bmc.Inspector
+	if managementKubeconfig != "" {
+		c, err := kubernetes.NewRuntimeClientFromFileName(managementKubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("building management cluster client for bmc health checks: %v", err)
+		}
+		rufio = bmc.NewRufioClient(c)
+	}
+
+	return bmc.NewInspector(bmc.NewDirectClient(logger.Get()), rufio), nil
+}
+
 type ProviderKubectlClient interface {
 	ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *types.Cluster, data []byte) error
 	ApplyKubeSpecFromBytesWithNamespace(ctx context.Context, cluster *types.Cluster, data []byte, namespace string) error
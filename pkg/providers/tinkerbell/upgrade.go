@@ -130,6 +130,7 @@ func (p *Provider) SetupAndValidateUpgradeManagementComponents(_ context.Context
 func (p *Provider) validateAvailableHardwareForUpgrade(ctx context.Context, currentSpec, newClusterSpec *cluster.Spec) (err error) {
 	clusterSpecValidator := NewClusterSpecValidator(
 		HardwareSatisfiesOnlyOneSelectorAssertion(p.catalogue),
+		StaticInterfacesSupportedAssertion(p.catalogue),
 	)
 	eksaVersionUpgrade := currentSpec.Bundles.Spec.Number != newClusterSpec.Bundles.Spec.Number
 
@@ -0,0 +1,265 @@
+// Package workflow inspects the Tinkerbell workflows that provision the machines of a cluster
+// so their progress and failures can be surfaced to users.
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tinkv1alpha1 "github.com/tinkerbell/tink/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	tinkerbellv1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/capt/v1beta1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// MachineStatus is the provisioning status of a single TinkerbellMachine derived from its workflow.
+type MachineStatus struct {
+	// Machine is the name of the TinkerbellMachine.
+	Machine string
+	// Hardware is the name of the Hardware the machine was scheduled on.
+	Hardware string
+	// State is the overall state of the workflow. It's empty when the workflow doesn't exist yet.
+	State tinkv1alpha1.WorkflowState
+	// CurrentAction is the action being executed or, for failed workflows, the action that failed.
+	CurrentAction string
+	// Elapsed is the time spent on CurrentAction.
+	Elapsed time.Duration
+	// Message is the message reported by the failed action.
+	Message string
+}
+
+// Succeeded returns true if the workflow completed successfully.
+func (s MachineStatus) Succeeded() bool {
+	return s.State == tinkv1alpha1.WorkflowStateSuccess
+}
+
+// Failed returns true if the workflow failed or timed out.
+func (s MachineStatus) Failed() bool {
+	return s.State == tinkv1alpha1.WorkflowStateFailed || s.State == tinkv1alpha1.WorkflowStateTimeout
+}
+
+func (s MachineStatus) String() string {
+	return s.describe(true)
+}
+
+// describe describes the status, with the time spent on the current action if withElapsed is true.
+// Condition messages don't include it, otherwise they would change on every reconciliation.
+func (s MachineStatus) describe(withElapsed bool) string {
+	name := s.Machine
+	if s.Hardware != "" {
+		name = fmt.Sprintf("%s (hardware %s)", s.Machine, s.Hardware)
+	}
+
+	switch {
+	case s.State == "":
+		return fmt.Sprintf("%s: waiting for workflow", name)
+	case s.Succeeded():
+		return fmt.Sprintf("%s: provisioned", name)
+	case s.Failed():
+		msg := fmt.Sprintf("%s: action %s %s", name, s.CurrentAction, stateDescription(s.State))
+		if withElapsed {
+			msg = fmt.Sprintf("%s after %s", msg, s.Elapsed.Round(time.Second))
+		}
+		if s.Message != "" {
+			msg = fmt.Sprintf("%s: %s", msg, s.Message)
+		}
+		return msg
+	case s.CurrentAction != "":
+		msg := fmt.Sprintf("%s: running action %s", name, s.CurrentAction)
+		if withElapsed {
+			msg = fmt.Sprintf("%s for %s", msg, s.Elapsed.Round(time.Second))
+		}
+		return msg
+	default:
+		return fmt.Sprintf("%s: %s", name, stateDescription(s.State))
+	}
+}
+
+func stateDescription(state tinkv1alpha1.WorkflowState) string {
+	if state == tinkv1alpha1.WorkflowStateTimeout {
+		return "timed out"
+	}
+	return strings.ToLower(strings.TrimPrefix(string(state), "STATE_"))
+}
+
+// Summary aggregates the workflow status of all the machines of a cluster.
+type Summary struct {
+	Machines []MachineStatus
+}
+
+// Succeeded returns the number of machines whose workflow completed successfully.
+func (s Summary) Succeeded() int {
+	n := 0
+	for _, m := range s.Machines {
+		if m.Succeeded() {
+			n++
+		}
+	}
+	return n
+}
+
+// Failures returns the machines whose workflow failed.
+func (s Summary) Failures() []MachineStatus {
+	var failures []MachineStatus
+	for _, m := range s.Machines {
+		if m.Failed() {
+			failures = append(failures, m)
+		}
+	}
+	return failures
+}
+
+// InProgress returns the machines whose workflow hasn't finished yet.
+func (s Summary) InProgress() []MachineStatus {
+	var inProgress []MachineStatus
+	for _, m := range s.Machines {
+		if !m.Succeeded() && !m.Failed() {
+			inProgress = append(inProgress, m)
+		}
+	}
+	return inProgress
+}
+
+// Completed returns true when every machine has been provisioned successfully.
+func (s Summary) Completed() bool {
+	return s.Succeeded() == len(s.Machines)
+}
+
+// ProgressMessage describes the provisioning progress, listing the machines still in progress. It
+// only changes when the workflows progress so it can be used as a condition message.
+func (s Summary) ProgressMessage() string {
+	msg := fmt.Sprintf("%d/%d machines provisioned", s.Succeeded(), len(s.Machines))
+	if inProgress := s.InProgress(); len(inProgress) > 0 {
+		msg = fmt.Sprintf("%s; %s", msg, joinStatus(inProgress))
+	}
+	return msg
+}
+
+// FailureMessage describes the failed workflows.
+func (s Summary) FailureMessage() string {
+	return fmt.Sprintf("provisioning workflow failed for %s", joinStatus(s.Failures()))
+}
+
+func joinStatus(machines []MachineStatus) string {
+	s := make([]string, 0, len(machines))
+	for _, m := range machines {
+		s = append(s, m.describe(false))
+	}
+	return strings.Join(s, "; ")
+}
+
+// ProgressDetails describes the machines still in progress with the action each of them is running
+// and for how long. It changes on every call so, unlike ProgressMessage, it's meant for CLI output.
+func (s Summary) ProgressDetails() string {
+	inProgress := s.InProgress()
+	details := make([]string, 0, len(inProgress))
+	for _, m := range inProgress {
+		details = append(details, m.String())
+	}
+	return strings.Join(details, "; ")
+}
+
+// ForCluster builds a Summary of the workflows provisioning the TinkerbellMachines of the cluster
+// named clusterName. Workflows are created by CAPT with the same name as the TinkerbellMachine.
+func ForCluster(ctx context.Context, c kubernetes.Reader, clusterName string, now time.Time) (Summary, error) {
+	machines := &tinkerbellv1.TinkerbellMachineList{}
+	if err := c.List(ctx, machines, kubernetes.ListOptions{Namespace: constants.EksaSystemNamespace}); err != nil {
+		return Summary{}, fmt.Errorf("listing tinkerbell machines for cluster %s: %v", clusterName, err)
+	}
+
+	summary := Summary{Machines: make([]MachineStatus, 0, len(machines.Items))}
+	for _, m := range machines.Items {
+		if m.Labels[clusterv1beta2.ClusterNameLabel] != clusterName {
+			continue
+		}
+
+		wf := &tinkv1alpha1.Workflow{}
+		err := c.Get(ctx, m.Name, m.Namespace, wf)
+		if apierrors.IsNotFound(err) {
+			summary.Machines = append(summary.Machines, MachineStatus{Machine: m.Name, Hardware: m.Spec.HardwareName})
+			continue
+		}
+		if err != nil {
+			return Summary{}, fmt.Errorf("getting workflow for tinkerbell machine %s: %v", m.Name, err)
+		}
+
+		status := Status(wf, now)
+		status.Machine = m.Name
+		if m.Spec.HardwareName != "" {
+			status.Hardware = m.Spec.HardwareName
+		}
+		summary.Machines = append(summary.Machines, status)
+	}
+
+	sort.Slice(summary.Machines, func(i, j int) bool {
+		return summary.Machines[i].Machine < summary.Machines[j].Machine
+	})
+
+	return summary, nil
+}
+
+// Status computes the MachineStatus for wf.
+func Status(wf *tinkv1alpha1.Workflow, now time.Time) MachineStatus {
+	status := MachineStatus{
+		Machine:  wf.Name,
+		Hardware: wf.Spec.HardwareRef,
+		State:    wf.Status.State,
+	}
+
+	for _, task := range wf.Status.Tasks {
+		for _, action := range task.Actions {
+			switch action.Status {
+			case tinkv1alpha1.WorkflowStateFailed, tinkv1alpha1.WorkflowStateTimeout:
+				status.CurrentAction = action.Name
+				status.Elapsed = time.Duration(action.Seconds) * time.Second
+				status.Message = action.Message
+				return status
+			case tinkv1alpha1.WorkflowStateRunning:
+				status.CurrentAction = action.Name
+				if action.StartedAt != nil {
+					status.Elapsed = now.Sub(action.StartedAt.Time)
+				}
+			}
+		}
+	}
+
+	if status.CurrentAction == "" {
+		status.CurrentAction = wf.Status.CurrentAction
+	}
+
+	return status
+}
+
+// Pending returns the status of every workflow in namespace that hasn't completed successfully,
+// sorted by hardware so the workflows scheduled on the same machine are listed together.
+func Pending(ctx context.Context, c client.Client, namespace string, now time.Time) ([]MachineStatus, error) {
+	workflows := &tinkv1alpha1.WorkflowList{}
+	if err := c.List(ctx, workflows, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("listing workflows: %v", err)
+	}
+
+	var pending []MachineStatus
+	for i := range workflows.Items {
+		status := Status(&workflows.Items[i], now)
+		if status.Succeeded() {
+			continue
+		}
+		pending = append(pending, status)
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		if pending[i].Hardware != pending[j].Hardware {
+			return pending[i].Hardware < pending[j].Hardware
+		}
+		return pending[i].Machine < pending[j].Machine
+	})
+
+	return pending, nil
+}
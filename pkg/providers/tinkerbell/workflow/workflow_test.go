@@ -0,0 +1,163 @@
+package workflow_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	tinkv1alpha1 "github.com/tinkerbell/tink/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	tinkerbellv1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/capt/v1beta1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/workflow"
+)
+
+var now = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func newMachine(name, hardware string) *tinkerbellv1.TinkerbellMachine {
+	return &tinkerbellv1.TinkerbellMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1beta2.ClusterNameLabel: "test-cluster"},
+		},
+		Spec: tinkerbellv1.TinkerbellMachineSpec{HardwareName: hardware},
+	}
+}
+
+func newWorkflow(name string, state tinkv1alpha1.WorkflowState, actions ...tinkv1alpha1.Action) *tinkv1alpha1.Workflow {
+	return &tinkv1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: constants.EksaSystemNamespace},
+		Status: tinkv1alpha1.WorkflowStatus{
+			State: state,
+			Tasks: []tinkv1alpha1.Task{{Name: "os-installation", Actions: actions}},
+		},
+	}
+}
+
+func newFakeClient(objs ...runtime.Object) *fake.ClientBuilder {
+	scheme := runtime.NewScheme()
+	_ = tinkv1alpha1.AddToScheme(scheme)
+	_ = tinkerbellv1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...)
+}
+
+func TestStatusRunning(t *testing.T) {
+	g := NewWithT(t)
+	wf := newWorkflow("cp-1", tinkv1alpha1.WorkflowStateRunning,
+		tinkv1alpha1.Action{Name: "stream-image", Status: tinkv1alpha1.WorkflowStateSuccess, Seconds: 60},
+		tinkv1alpha1.Action{Name: "write-netplan", Status: tinkv1alpha1.WorkflowStateRunning, StartedAt: &metav1.Time{Time: now.Add(-90 * time.Second)}},
+		tinkv1alpha1.Action{Name: "reboot", Status: tinkv1alpha1.WorkflowStatePending},
+	)
+
+	status := workflow.Status(wf, now)
+	g.Expect(status.CurrentAction).To(Equal("write-netplan"))
+	g.Expect(status.Elapsed).To(Equal(90 * time.Second))
+	g.Expect(status.Failed()).To(BeFalse())
+	g.Expect(status.String()).To(Equal("cp-1: running action write-netplan for 1m30s"))
+}
+
+func TestStatusFailed(t *testing.T) {
+	g := NewWithT(t)
+	wf := newWorkflow("cp-1", tinkv1alpha1.WorkflowStateFailed,
+		tinkv1alpha1.Action{Name: "stream-image", Status: tinkv1alpha1.WorkflowStateFailed, Seconds: 12, Message: "unable to download image"},
+		tinkv1alpha1.Action{Name: "reboot", Status: tinkv1alpha1.WorkflowStatePending},
+	)
+	wf.Spec.HardwareRef = "hw-1"
+
+	status := workflow.Status(wf, now)
+	g.Expect(status.Failed()).To(BeTrue())
+	g.Expect(status.String()).To(Equal("cp-1 (hardware hw-1): action stream-image failed after 12s: unable to download image"))
+}
+
+func TestForCluster(t *testing.T) {
+	g := NewWithT(t)
+	other := newMachine("other-cp", "hw-other")
+	other.Labels[clusterv1beta2.ClusterNameLabel] = "other-cluster"
+	c := newFakeClient(
+		newMachine("cp-1", "hw-1"),
+		newMachine("cp-2", "hw-2"),
+		newMachine("md-1", "hw-3"),
+		other,
+		newWorkflow("cp-1", tinkv1alpha1.WorkflowStateSuccess),
+		newWorkflow("cp-2", tinkv1alpha1.WorkflowStateRunning,
+			tinkv1alpha1.Action{Name: "stream-image", Status: tinkv1alpha1.WorkflowStateRunning, StartedAt: &metav1.Time{Time: now.Add(-time.Minute)}},
+		),
+	).Build()
+
+	summary, err := workflow.ForCluster(context.Background(), clientutil.NewKubeClient(c), "test-cluster", now)
+	g.Expect(err).ToNot(HaveOccurred())
+	later, err := workflow.ForCluster(context.Background(), clientutil.NewKubeClient(c), "test-cluster", now.Add(time.Minute))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(later.ProgressMessage()).To(Equal(summary.ProgressMessage()))
+	g.Expect(summary.Machines).To(HaveLen(3))
+	g.Expect(summary.Completed()).To(BeFalse())
+	g.Expect(summary.Failures()).To(BeEmpty())
+	g.Expect(summary.ProgressMessage()).To(Equal(
+		"1/3 machines provisioned; cp-2 (hardware hw-2): running action stream-image; md-1 (hardware hw-3): waiting for workflow",
+	))
+	g.Expect(summary.ProgressDetails()).To(Equal(
+		"cp-2 (hardware hw-2): running action stream-image for 1m0s; md-1 (hardware hw-3): waiting for workflow",
+	))
+	g.Expect(later.ProgressDetails()).To(Equal(
+		"cp-2 (hardware hw-2): running action stream-image for 2m0s; md-1 (hardware hw-3): waiting for workflow",
+	))
+}
+
+func TestForClusterFailure(t *testing.T) {
+	g := NewWithT(t)
+	c := newFakeClient(
+		newMachine("cp-1", "hw-1"),
+		newWorkflow("cp-1", tinkv1alpha1.WorkflowStateTimeout,
+			tinkv1alpha1.Action{Name: "stream-image", Status: tinkv1alpha1.WorkflowStateTimeout, Seconds: 600},
+		),
+	).Build()
+
+	summary, err := workflow.ForCluster(context.Background(), clientutil.NewKubeClient(c), "test-cluster", now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(summary.Failures()).To(HaveLen(1))
+	g.Expect(summary.FailureMessage()).To(Equal("provisioning workflow failed for cp-1 (hardware hw-1): action stream-image timed out"))
+}
+
+func TestForClusterCompleted(t *testing.T) {
+	g := NewWithT(t)
+	c := newFakeClient(
+		newMachine("cp-1", "hw-1"),
+		newWorkflow("cp-1", tinkv1alpha1.WorkflowStateSuccess),
+	).Build()
+
+	summary, err := workflow.ForCluster(context.Background(), clientutil.NewKubeClient(c), "test-cluster", now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(summary.Completed()).To(BeTrue())
+	g.Expect(summary.ProgressMessage()).To(Equal("1/1 machines provisioned"))
+}
+
+func TestPending(t *testing.T) {
+	g := NewWithT(t)
+	running := newWorkflow("md-1", tinkv1alpha1.WorkflowStateRunning,
+		tinkv1alpha1.Action{Name: "stream-image", Status: tinkv1alpha1.WorkflowStateRunning, StartedAt: &metav1.Time{Time: now.Add(-time.Minute)}},
+	)
+	running.Spec.HardwareRef = "hw-1"
+	failed := newWorkflow("cp-1", tinkv1alpha1.WorkflowStateFailed,
+		tinkv1alpha1.Action{Name: "write-netplan", Status: tinkv1alpha1.WorkflowStateFailed, Seconds: 5},
+	)
+	failed.Spec.HardwareRef = "hw-1"
+	succeeded := newWorkflow("cp-2", tinkv1alpha1.WorkflowStateSuccess)
+	succeeded.Spec.HardwareRef = "hw-2"
+	c := newFakeClient(running, failed, succeeded).Build()
+
+	pending, err := workflow.Pending(context.Background(), c, constants.EksaSystemNamespace, now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pending).To(HaveLen(2))
+	g.Expect(pending[0].Machine).To(Equal("cp-1"))
+	g.Expect(pending[0].Failed()).To(BeTrue())
+	g.Expect(pending[1].Machine).To(Equal("md-1"))
+	g.Expect(pending[1].Hardware).To(Equal("hw-1"))
+	g.Expect(pending[1].CurrentAction).To(Equal("stream-image"))
+}
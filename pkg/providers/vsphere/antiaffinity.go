@@ -0,0 +1,309 @@
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/go-logr/logr"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// DRSObjectsAnnotation records on the cluster the DRS rules and groups created for its machine
+// groups, so they can be removed when antiAffinity is dropped or the cluster is deleted. DRS rules
+// and groups have no description or tags to find them by.
+const DRSObjectsAnnotation = "anywhere.eks.amazonaws.com/vsphere-drs-objects"
+
+// drsObject is a DRS rule or group in the compute cluster backing a resource pool.
+type drsObject struct {
+	ResourcePool string `json:"resourcePool"`
+	Name         string `json:"name"`
+	Group        bool   `json:"group,omitempty"`
+}
+
+// AntiAffinity keeps the DRS anti-affinity rules of the cluster machine groups in sync with
+// the VMs of their CAPI Machines.
+type AntiAffinity struct {
+	govc ProviderGovcClient
+}
+
+// NewAntiAffinity builds an AntiAffinity.
+func NewAntiAffinity(govc ProviderGovcClient) *AntiAffinity {
+	return &AntiAffinity{
+		govc: govc,
+	}
+}
+
+// Reconcile creates or updates a DRS anti-affinity rule for each machine group whose machine config
+// has antiAffinity, with the VMs of the group Machines that have already been provisioned. Since rules
+// are updated with the current VMs, it needs to run again as machines are rolled out. The rules and
+// groups created previously that are no longer needed, because antiAffinity, its host group or the
+// machine group were removed, are deleted first.
+func (a *AntiAffinity) Reconcile(ctx context.Context, log logr.Logger, c client.Client, spec *cluster.Spec) error {
+	datacenter := spec.VSphereDatacenter.Spec.Datacenter
+	recorded, err := recordedDRSObjects(spec.Cluster)
+	if err != nil {
+		return err
+	}
+
+	rules := []executables.AntiAffinityRule{}
+	resourcePools := []string{}
+	groupNames := []string{}
+	var desired []drsObject
+	for _, placement := range machinePlacements(NewSpec(spec), nil) {
+		antiAffinity := placement.machineConfig.Spec.AntiAffinity
+		if antiAffinity == nil {
+			continue
+		}
+
+		vms, err := machineGroupVMs(ctx, c, placement.machineLabels)
+		if err != nil {
+			return fmt.Errorf("listing machines for %s: %v", placement.name, err)
+		}
+
+		rule := executables.AntiAffinityRule{
+			Name:      antiAffinityRuleName(spec.Cluster.Name, placement.id),
+			VMs:       vms,
+			Mandatory: antiAffinity.IsHard(),
+			HostGroup: antiAffinity.HostGroup,
+		}
+		objects := ruleDRSObjects(placement.resourcePool, rule)
+
+		// An anti-affinity rule needs at least two VMs. The rule previously applied, if any, is kept
+		// until there are enough VMs to update it.
+		if len(vms) < 2 {
+			log.V(4).Info("Skipping DRS anti-affinity rule, not enough VMs", "machineGroup", placement.name, "vms", len(vms))
+			for _, o := range objects {
+				if slices.Contains(recorded, o) {
+					desired = append(desired, o)
+				}
+			}
+			continue
+		}
+
+		rules = append(rules, rule)
+		resourcePools = append(resourcePools, placement.resourcePool)
+		groupNames = append(groupNames, placement.name)
+		desired = append(desired, objects...)
+	}
+
+	var stale []drsObject
+	for _, o := range recorded {
+		if !slices.Contains(desired, o) {
+			stale = append(stale, o)
+		}
+	}
+	if err := a.removeDRSObjects(ctx, log, datacenter, stale); err != nil {
+		return err
+	}
+
+	// The objects are recorded before they're created so they can be removed even if applying
+	// the rules fails halfway through.
+	if err := recordDRSObjects(ctx, c, spec.Cluster, desired); err != nil {
+		return err
+	}
+
+	for i, rule := range rules {
+		log.V(4).Info("Applying DRS anti-affinity rule", "rule", rule.Name, "resourcePool", resourcePools[i], "vms", rule.VMs)
+		if err := a.govc.ApplyAntiAffinityRule(ctx, datacenter, resourcePools[i], rule); err != nil {
+			return fmt.Errorf("applying DRS anti-affinity rule for %s: %v", groupNames[i], err)
+		}
+	}
+
+	return nil
+}
+
+// Delete removes the DRS rules and groups created for the cluster machine groups.
+func (a *AntiAffinity) Delete(ctx context.Context, log logr.Logger, cluster *v1alpha1.Cluster, datacenter string) error {
+	recorded, err := recordedDRSObjects(cluster)
+	if err != nil {
+		return err
+	}
+
+	return a.removeDRSObjects(ctx, log, datacenter, recorded)
+}
+
+// HasDRSObjects determines if DRS rules or groups have been created for the cluster.
+func HasDRSObjects(cluster *v1alpha1.Cluster) bool {
+	_, ok := cluster.Annotations[DRSObjectsAnnotation]
+	return ok
+}
+
+// removeDRSObjects removes the DRS rules and then the groups they reference. Objects that
+// don't exist anymore are skipped.
+func (a *AntiAffinity) removeDRSObjects(ctx context.Context, log logr.Logger, datacenter string, objects []drsObject) error {
+	byResourcePool := map[string][]drsObject{}
+	for _, o := range objects {
+		byResourcePool[o.ResourcePool] = append(byResourcePool[o.ResourcePool], o)
+	}
+
+	for _, resourcePool := range sortedStringKeys(byResourcePool) {
+		objects := byResourcePool[resourcePool]
+		rules, err := a.govc.ListDRSRules(ctx, datacenter, resourcePool)
+		if err != nil {
+			return fmt.Errorf("listing DRS rules for resource pool %s: %v", resourcePool, err)
+		}
+		for _, o := range objects {
+			if o.Group || !slices.Contains(rules, o.Name) {
+				continue
+			}
+			log.V(4).Info("Removing DRS rule", "rule", o.Name, "resourcePool", resourcePool)
+			if err := a.govc.RemoveDRSRule(ctx, datacenter, resourcePool, o.Name); err != nil {
+				return err
+			}
+		}
+
+		groups, err := a.govc.ListDRSGroups(ctx, datacenter, resourcePool)
+		if err != nil {
+			return fmt.Errorf("listing DRS groups for resource pool %s: %v", resourcePool, err)
+		}
+		for _, o := range objects {
+			if !o.Group || !slices.Contains(groups, o.Name) {
+				continue
+			}
+			log.V(4).Info("Removing DRS group", "group", o.Name, "resourcePool", resourcePool)
+			if err := a.govc.RemoveDRSGroup(ctx, datacenter, resourcePool, o.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ruleDRSObjects returns the DRS rules and groups ApplyAntiAffinityRule creates for rule.
+func ruleDRSObjects(resourcePool string, rule executables.AntiAffinityRule) []drsObject {
+	objects := []drsObject{{ResourcePool: resourcePool, Name: rule.Name}}
+	if rule.HostGroup != "" {
+		objects = append(objects,
+			drsObject{ResourcePool: resourcePool, Name: rule.VMHostRuleName()},
+			drsObject{ResourcePool: resourcePool, Name: rule.VMGroupName(), Group: true},
+		)
+	}
+	return objects
+}
+
+func recordedDRSObjects(cluster *v1alpha1.Cluster) ([]drsObject, error) {
+	value, ok := cluster.Annotations[DRSObjectsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	var objects []drsObject
+	if err := json.Unmarshal([]byte(value), &objects); err != nil {
+		return nil, fmt.Errorf("parsing annotation %s: %v", DRSObjectsAnnotation, err)
+	}
+	return objects, nil
+}
+
+// recordDRSObjects patches the DRSObjectsAnnotation of the cluster, removing it when there are no objects.
+// The cluster object itself is left untouched so the cluster controller doesn't patch the annotation again.
+func recordDRSObjects(ctx context.Context, c client.Client, cluster *v1alpha1.Cluster, objects []drsObject) error {
+	recorded, err := recordedDRSObjects(cluster)
+	if err != nil {
+		return err
+	}
+	if slices.Equal(recorded, objects) {
+		return nil
+	}
+
+	updated := cluster.DeepCopy()
+	patch := client.MergeFrom(updated.DeepCopy())
+	if len(objects) == 0 {
+		delete(updated.Annotations, DRSObjectsAnnotation)
+	} else {
+		value, err := json.Marshal(objects)
+		if err != nil {
+			return err
+		}
+		if updated.Annotations == nil {
+			updated.Annotations = map[string]string{}
+		}
+		updated.Annotations[DRSObjectsAnnotation] = string(value)
+	}
+
+	if err := c.Patch(ctx, updated, patch); err != nil {
+		return fmt.Errorf("recording DRS objects for cluster %s: %v", cluster.Name, err)
+	}
+	return nil
+}
+
+func sortedStringKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func antiAffinityRuleName(clusterName, group string) string {
+	return fmt.Sprintf("%s-%s-anti-affinity", clusterName, group)
+}
+
+// machineGroupVMs returns the names of the VMs of the Machines matching the labels.
+// Machines without a provider ID don't have a VM yet and machines being deleted are skipped.
+func machineGroupVMs(ctx context.Context, c client.Reader, labels map[string]string) ([]string, error) {
+	machines := &clusterv1beta2.MachineList{}
+	if err := c.List(ctx, machines, client.InNamespace(constants.EksaSystemNamespace), client.MatchingLabels(labels)); err != nil {
+		return nil, err
+	}
+
+	vms := make([]string, 0, len(machines.Items))
+	for _, m := range machines.Items {
+		if m.Spec.ProviderID == "" || !m.DeletionTimestamp.IsZero() {
+			continue
+		}
+		// CAPV names the VM after the infrastructure machine.
+		vms = append(vms, m.Spec.InfrastructureRef.Name)
+	}
+	sort.Strings(vms)
+
+	return vms, nil
+}
+
+// validateAntiAffinityHosts checks the compute clusters the machine groups with antiAffinity are placed in
+// have a host for each machine of the group. A hard rule also needs a host for each surge machine of a rolling
+// upgrade and fails the validation, while a soft rule only logs a warning.
+func (v *Validator) validateAntiAffinityHosts(ctx context.Context, spec *Spec) error {
+	datacenter := spec.VSphereDatacenter.Spec.Datacenter
+	hostCounts := map[string]int{}
+	for _, placement := range machinePlacements(spec, nil) {
+		antiAffinity := placement.machineConfig.Spec.AntiAffinity
+		if antiAffinity == nil || placement.count < 2 {
+			continue
+		}
+
+		hosts, ok := hostCounts[placement.resourcePool]
+		if !ok {
+			var err error
+			if hosts, err = v.govc.GetResourcePoolHostCount(ctx, datacenter, placement.resourcePool); err != nil {
+				return fmt.Errorf("getting hosts for resource pool %s: %v", placement.resourcePool, err)
+			}
+			hostCounts[placement.resourcePool] = hosts
+		}
+
+		// During a rolling upgrade the surge machines must be placed apart from the machines they replace.
+		if antiAffinity.IsHard() {
+			if required := placement.count + placement.maxSurge; hosts < required {
+				return fmt.Errorf("hard anti-affinity for %s requires %d hosts for %d machines and a max surge of %d but the compute cluster of resource pool %s only has %d", placement.name, required, placement.count, placement.maxSurge, placement.resourcePool, hosts)
+			}
+			continue
+		}
+		if hosts >= placement.count {
+			continue
+		}
+		logger.Info("Warning: not enough hosts to keep all the machines of the group apart, some of them will share a host", "machineGroup", placement.name, "resourcePool", placement.resourcePool, "machines", placement.count, "hosts", hosts)
+	}
+
+	return nil
+}
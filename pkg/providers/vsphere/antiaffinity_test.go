@@ -0,0 +1,272 @@
+package vsphere
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func antiAffinityTestMachine(name string, labels map[string]string, opts ...func(*clusterv1beta2.Machine)) *clusterv1beta2.Machine {
+	m := &clusterv1beta2.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    labels,
+		},
+		Spec: clusterv1beta2.MachineSpec{
+			ClusterName: "test",
+			ProviderID:  "vsphere://" + name,
+			InfrastructureRef: clusterv1beta2.ContractVersionedObjectReference{
+				Name: name + "-vm",
+			},
+		},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+func TestAntiAffinityReconcile(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.VSphereMachineConfigs["test-cp"].Spec.AntiAffinity = &v1alpha1.VSphereAntiAffinity{Type: v1alpha1.HardAntiAffinity}
+	tt.clusterSpec.VSphereMachineConfigs["test-wn"].Spec.AntiAffinity = &v1alpha1.VSphereAntiAffinity{Type: v1alpha1.SoftAntiAffinity, HostGroup: "rack-a"}
+	cpLabels := map[string]string{clusterv1beta2.ClusterNameLabel: "test", clusterv1beta2.MachineControlPlaneLabel: ""}
+	mdLabels := map[string]string{clusterv1beta2.ClusterNameLabel: "test", clusterv1beta2.MachineDeploymentNameLabel: "test-md-0"}
+	c := fake.NewClientBuilder().WithObjects(
+		antiAffinityTestMachine("cp-2", cpLabels),
+		antiAffinityTestMachine("cp-1", cpLabels),
+		antiAffinityTestMachine("cp-3", cpLabels, func(m *clusterv1beta2.Machine) { m.Spec.ProviderID = "" }),
+		antiAffinityTestMachine("cp-0", cpLabels, func(m *clusterv1beta2.Machine) {
+			m.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			m.Finalizers = []string{"machine.cluster.x-k8s.io"}
+		}),
+		antiAffinityTestMachine("md-1", mdLabels),
+		antiAffinityTestMachine("md-2", mdLabels),
+		antiAffinityTestMachine("other", map[string]string{clusterv1beta2.ClusterNameLabel: "other", clusterv1beta2.MachineControlPlaneLabel: ""}),
+		tt.clusterSpec.Cluster,
+	).Build()
+	datacenter := tt.datacenterConfig.Spec.Datacenter
+
+	tt.govc.EXPECT().ApplyAntiAffinityRule(tt.ctx, datacenter, capacityTestResourcePool, executables.AntiAffinityRule{
+		Name:      "test-control-plane-anti-affinity",
+		VMs:       []string{"cp-1-vm", "cp-2-vm"},
+		Mandatory: true,
+	})
+	tt.govc.EXPECT().ApplyAntiAffinityRule(tt.ctx, datacenter, capacityTestResourcePool, executables.AntiAffinityRule{
+		Name:      "test-md-0-anti-affinity",
+		VMs:       []string{"md-1-vm", "md-2-vm"},
+		HostGroup: "rack-a",
+	})
+
+	a := NewAntiAffinity(tt.govc)
+	tt.Expect(a.Reconcile(tt.ctx, test.NewNullLogger(), c, tt.clusterSpec)).To(Succeed())
+
+	cluster := &v1alpha1.Cluster{}
+	tt.Expect(c.Get(tt.ctx, client.ObjectKeyFromObject(tt.clusterSpec.Cluster), cluster)).To(Succeed())
+	tt.Expect(recordedDRSObjects(cluster)).To(Equal([]drsObject{
+		{ResourcePool: capacityTestResourcePool, Name: "test-control-plane-anti-affinity"},
+		{ResourcePool: capacityTestResourcePool, Name: "test-md-0-anti-affinity"},
+		{ResourcePool: capacityTestResourcePool, Name: "test-md-0-anti-affinity-hosts"},
+		{ResourcePool: capacityTestResourcePool, Name: "test-md-0-anti-affinity-vms", Group: true},
+	}))
+}
+
+func TestAntiAffinityReconcileRemovesStaleObjects(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.VSphereMachineConfigs["test-wn"].Spec.AntiAffinity = &v1alpha1.VSphereAntiAffinity{}
+	tt.clusterSpec.Cluster.Annotations = map[string]string{
+		DRSObjectsAnnotation: `[{"resourcePool":"*/Resources","name":"test-control-plane-anti-affinity"},` +
+			`{"resourcePool":"*/Resources","name":"test-md-0-anti-affinity"},` +
+			`{"resourcePool":"*/Resources","name":"test-md-0-anti-affinity-hosts"},` +
+			`{"resourcePool":"*/Resources","name":"test-md-0-anti-affinity-vms","group":true},` +
+			`{"resourcePool":"*/Resources","name":"test-removed-anti-affinity"}]`,
+	}
+	mdLabels := map[string]string{clusterv1beta2.ClusterNameLabel: "test", clusterv1beta2.MachineDeploymentNameLabel: "test-md-0"}
+	c := fake.NewClientBuilder().WithObjects(
+		antiAffinityTestMachine("md-1", mdLabels),
+		antiAffinityTestMachine("md-2", mdLabels),
+		tt.clusterSpec.Cluster,
+	).Build()
+	datacenter := tt.datacenterConfig.Spec.Datacenter
+
+	// The control plane dropped antiAffinity, the workers dropped their host group and
+	// the removed rule was already deleted.
+	gomock.InOrder(
+		tt.govc.EXPECT().ListDRSRules(tt.ctx, datacenter, capacityTestResourcePool).Return(
+			[]string{"test-control-plane-anti-affinity", "test-md-0-anti-affinity", "test-md-0-anti-affinity-hosts", "other-rule"}, nil,
+		),
+		tt.govc.EXPECT().RemoveDRSRule(tt.ctx, datacenter, capacityTestResourcePool, "test-control-plane-anti-affinity"),
+		tt.govc.EXPECT().RemoveDRSRule(tt.ctx, datacenter, capacityTestResourcePool, "test-md-0-anti-affinity-hosts"),
+		tt.govc.EXPECT().ListDRSGroups(tt.ctx, datacenter, capacityTestResourcePool).Return([]string{"test-md-0-anti-affinity-vms", "rack-a"}, nil),
+		tt.govc.EXPECT().RemoveDRSGroup(tt.ctx, datacenter, capacityTestResourcePool, "test-md-0-anti-affinity-vms"),
+		tt.govc.EXPECT().ApplyAntiAffinityRule(tt.ctx, datacenter, capacityTestResourcePool, executables.AntiAffinityRule{
+			Name: "test-md-0-anti-affinity",
+			VMs:  []string{"md-1-vm", "md-2-vm"},
+		}),
+	)
+
+	a := NewAntiAffinity(tt.govc)
+	tt.Expect(a.Reconcile(tt.ctx, test.NewNullLogger(), c, tt.clusterSpec)).To(Succeed())
+
+	cluster := &v1alpha1.Cluster{}
+	tt.Expect(c.Get(tt.ctx, client.ObjectKeyFromObject(tt.clusterSpec.Cluster), cluster)).To(Succeed())
+	tt.Expect(recordedDRSObjects(cluster)).To(Equal([]drsObject{
+		{ResourcePool: capacityTestResourcePool, Name: "test-md-0-anti-affinity"},
+	}))
+}
+
+func TestAntiAffinityReconcileRemovesAnnotation(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.Cluster.Annotations = map[string]string{
+		DRSObjectsAnnotation: `[{"resourcePool":"*/Resources","name":"test-etcd-anti-affinity"}]`,
+	}
+	c := fake.NewClientBuilder().WithObjects(tt.clusterSpec.Cluster).Build()
+	datacenter := tt.datacenterConfig.Spec.Datacenter
+
+	tt.govc.EXPECT().ListDRSRules(tt.ctx, datacenter, capacityTestResourcePool).Return([]string{"test-etcd-anti-affinity"}, nil)
+	tt.govc.EXPECT().RemoveDRSRule(tt.ctx, datacenter, capacityTestResourcePool, "test-etcd-anti-affinity")
+	tt.govc.EXPECT().ListDRSGroups(tt.ctx, datacenter, capacityTestResourcePool)
+
+	a := NewAntiAffinity(tt.govc)
+	tt.Expect(a.Reconcile(tt.ctx, test.NewNullLogger(), c, tt.clusterSpec)).To(Succeed())
+
+	cluster := &v1alpha1.Cluster{}
+	tt.Expect(c.Get(tt.ctx, client.ObjectKeyFromObject(tt.clusterSpec.Cluster), cluster)).To(Succeed())
+	tt.Expect(HasDRSObjects(cluster)).To(BeFalse())
+}
+
+func TestAntiAffinityDelete(t *testing.T) {
+	tt := newProviderTest(t)
+	cluster := tt.clusterSpec.Cluster
+	cluster.Annotations = map[string]string{
+		DRSObjectsAnnotation: `[{"resourcePool":"/pool-a","name":"test-md-0-anti-affinity-hosts"},` +
+			`{"resourcePool":"/pool-a","name":"test-md-0-anti-affinity-vms","group":true},` +
+			`{"resourcePool":"/pool-b","name":"test-etcd-anti-affinity"}]`,
+	}
+	datacenter := tt.datacenterConfig.Spec.Datacenter
+
+	tt.govc.EXPECT().ListDRSRules(tt.ctx, datacenter, "/pool-a").Return([]string{"test-md-0-anti-affinity-hosts"}, nil)
+	tt.govc.EXPECT().RemoveDRSRule(tt.ctx, datacenter, "/pool-a", "test-md-0-anti-affinity-hosts")
+	tt.govc.EXPECT().ListDRSGroups(tt.ctx, datacenter, "/pool-a").Return([]string{"test-md-0-anti-affinity-vms"}, nil)
+	tt.govc.EXPECT().RemoveDRSGroup(tt.ctx, datacenter, "/pool-a", "test-md-0-anti-affinity-vms")
+	tt.govc.EXPECT().ListDRSRules(tt.ctx, datacenter, "/pool-b").Return(nil, errors.New("error"))
+
+	a := NewAntiAffinity(tt.govc)
+	tt.Expect(a.Delete(tt.ctx, test.NewNullLogger(), cluster, datacenter)).To(MatchError("listing DRS rules for resource pool /pool-b: error"))
+}
+
+func TestAntiAffinityReconcileNotEnoughVMs(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.VSphereMachineConfigs["test-etcd"].Spec.AntiAffinity = &v1alpha1.VSphereAntiAffinity{}
+	c := fake.NewClientBuilder().WithObjects(
+		antiAffinityTestMachine("etcd-1", map[string]string{clusterv1beta2.ClusterNameLabel: "test", etcdClusterLabel: "test-etcd"}),
+		tt.clusterSpec.Cluster,
+	).Build()
+
+	a := NewAntiAffinity(tt.govc)
+	tt.Expect(a.Reconcile(tt.ctx, test.NewNullLogger(), c, tt.clusterSpec)).To(Succeed())
+}
+
+func TestAntiAffinityReconcileError(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.VSphereMachineConfigs["test-etcd"].Spec.AntiAffinity = &v1alpha1.VSphereAntiAffinity{}
+	etcdLabels := map[string]string{clusterv1beta2.ClusterNameLabel: "test", etcdClusterLabel: "test-etcd"}
+	objs := []client.Object{
+		antiAffinityTestMachine("etcd-1", etcdLabels),
+		antiAffinityTestMachine("etcd-2", etcdLabels),
+		tt.clusterSpec.Cluster,
+	}
+	c := fake.NewClientBuilder().WithObjects(objs...).Build()
+
+	tt.govc.EXPECT().ApplyAntiAffinityRule(tt.ctx, tt.datacenterConfig.Spec.Datacenter, capacityTestResourcePool, executables.AntiAffinityRule{
+		Name: "test-etcd-anti-affinity",
+		VMs:  []string{"etcd-1-vm", "etcd-2-vm"},
+	}).Return(errors.New("error"))
+
+	a := NewAntiAffinity(tt.govc)
+	tt.Expect(a.Reconcile(tt.ctx, test.NewNullLogger(), c, tt.clusterSpec)).To(MatchError("applying DRS anti-affinity rule for etcd: error"))
+}
+
+func TestValidatorValidateAntiAffinityHosts(t *testing.T) {
+	tests := []struct {
+		name         string
+		antiAffinity *v1alpha1.VSphereAntiAffinity
+		maxSurge     *int
+		hosts        int
+		wantErr      string
+	}{
+		{
+			name:         "enough hosts",
+			antiAffinity: &v1alpha1.VSphereAntiAffinity{Type: v1alpha1.HardAntiAffinity},
+			hosts:        4,
+		},
+		{
+			name:         "enough hosts without surge",
+			antiAffinity: &v1alpha1.VSphereAntiAffinity{Type: v1alpha1.HardAntiAffinity},
+			maxSurge:     ptr.Int(0),
+			hosts:        3,
+		},
+		{
+			name:         "soft not enough hosts",
+			antiAffinity: &v1alpha1.VSphereAntiAffinity{Type: v1alpha1.SoftAntiAffinity},
+			hosts:        2,
+		},
+		{
+			name:         "hard not enough hosts",
+			antiAffinity: &v1alpha1.VSphereAntiAffinity{Type: v1alpha1.HardAntiAffinity},
+			hosts:        2,
+			wantErr:      "hard anti-affinity for control plane requires 4 hosts for 3 machines and a max surge of 1 but the compute cluster of resource pool */Resources only has 2",
+		},
+		{
+			name:         "hard not enough hosts for surge",
+			antiAffinity: &v1alpha1.VSphereAntiAffinity{Type: v1alpha1.HardAntiAffinity},
+			hosts:        3,
+			wantErr:      "hard anti-affinity for control plane requires 4 hosts for 3 machines and a max surge of 1 but the compute cluster of resource pool */Resources only has 3",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newProviderTest(t)
+			tt.clusterSpec.VSphereMachineConfigs["test-cp"].Spec.AntiAffinity = tc.antiAffinity
+			if tc.maxSurge != nil {
+				tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{
+					RollingUpdate: &v1alpha1.ControlPlaneRollingUpdateParams{MaxSurge: *tc.maxSurge},
+				}
+			} else {
+				tt.clusterSpec.VSphereMachineConfigs["test-etcd"].Spec.AntiAffinity = tc.antiAffinity
+			}
+			tt.govc.EXPECT().GetResourcePoolHostCount(tt.ctx, tt.datacenterConfig.Spec.Datacenter, capacityTestResourcePool).Return(tc.hosts, nil)
+
+			v := NewValidator(tt.govc, nil)
+			err := v.validateAntiAffinityHosts(tt.ctx, NewSpec(tt.clusterSpec))
+			if tc.wantErr != "" {
+				tt.Expect(err).To(MatchError(tc.wantErr))
+				return
+			}
+			tt.Expect(err).To(Succeed())
+		})
+	}
+}
+
+func TestValidatorValidateAntiAffinityHostsError(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.VSphereMachineConfigs["test-wn"].Spec.AntiAffinity = &v1alpha1.VSphereAntiAffinity{}
+	tt.govc.EXPECT().GetResourcePoolHostCount(tt.ctx, tt.datacenterConfig.Spec.Datacenter, capacityTestResourcePool).Return(0, errors.New("error"))
+
+	v := NewValidator(tt.govc, nil)
+	tt.Expect(v.validateAntiAffinityHosts(tt.ctx, NewSpec(tt.clusterSpec))).To(MatchError("getting hosts for resource pool */Resources: error"))
+}
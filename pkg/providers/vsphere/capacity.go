@@ -0,0 +1,273 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// defaultMaxSurge is the number of extra machines CAPI rolls out per machine group when
+// no rolling update strategy is configured.
+const defaultMaxSurge = 1
+
+// etcdClusterLabel is the label the etcdadm controller sets on the external etcd Machines.
+const etcdClusterLabel = "cluster.x-k8s.io/etcd-cluster"
+
+// machinePlacement is a group of machines cloned from the same machine config into the
+// same datastore and resource pool.
+type machinePlacement struct {
+	name          string
+	machineConfig *v1alpha1.VSphereMachineConfig
+	datastore     string
+	resourcePool  string
+	count         int
+
+	// id identifies the group in the names of the vSphere objects created for it.
+	id string
+	// machineLabels select the CAPI Machines of the group.
+	machineLabels map[string]string
+	// maxSurge is the number of extra machines rolled out at once during an upgrade.
+	maxSurge int
+	// failureDomain is the VSphereDatacenterConfig failure domain the machines are placed in, if any.
+	failureDomain string
+	// networks are the networks of the machine NICs.
+	networks []string
+}
+
+// capacityRequest is the capacity requested from a single datastore or resource pool.
+type capacityRequest struct {
+	diskGiB   int
+	memoryMiB int
+	numCPUs   int
+	groups    []string
+}
+
+// validateCapacity checks the datastores and resource pools the cluster machines are placed in
+// have enough free capacity for all the machines that are going to be cloned. When cluster is not
+// nil, only the machines added by the upgrade, including the rollout surge, are accounted for.
+// Missing disk space or memory fails the validation while vCPU overcommit only logs a warning.
+func (p *vsphereProvider) validateCapacity(ctx context.Context, spec *Spec, cluster *types.Cluster) error {
+	var prevCluster *v1alpha1.Cluster
+	if cluster != nil {
+		var err error
+		if prevCluster, err = p.providerKubectlClient.GetEksaCluster(ctx, cluster, spec.Cluster.GetName()); err != nil {
+			return err
+		}
+	}
+
+	datastores := map[string]*capacityRequest{}
+	pools := map[string]*capacityRequest{}
+	for _, placement := range machinePlacements(spec, prevCluster) {
+		if placement.count <= 0 {
+			continue
+		}
+		mc := placement.machineConfig
+		addCapacityRequest(datastores, placement.datastore, placement.name, capacityRequest{diskGiB: mc.Spec.DiskGiB * placement.count})
+		addCapacityRequest(pools, placement.resourcePool, placement.name, capacityRequest{
+			memoryMiB: mc.Spec.MemoryMiB * placement.count,
+			numCPUs:   mc.Spec.NumCPUs * placement.count,
+		})
+	}
+
+	for _, datastore := range sortedKeys(datastores) {
+		request := datastores[datastore]
+		availableGiB, err := p.providerGovcClient.GetWorkloadAvailableSpace(ctx, datastore)
+		if err != nil {
+			return fmt.Errorf("getting free space in datastore %s: %v", datastore, err)
+		}
+		if float64(request.diskGiB) > availableGiB {
+			return fmt.Errorf("not enough space in datastore %s: %v need %d GiB but only %.2f GiB are available", datastore, request.groups, request.diskGiB, availableGiB)
+		}
+	}
+
+	datacenter := spec.VSphereDatacenter.Spec.Datacenter
+	for _, pool := range sortedKeys(pools) {
+		request := pools[pool]
+		poolInfo, err := p.providerGovcClient.GetResourcePoolInfo(ctx, datacenter, pool)
+		if err != nil {
+			return fmt.Errorf("getting resource pool %s info: %v", pool, err)
+		}
+		// A resource pool without a memory limit reports -1 as available memory.
+		if availableMiB := poolInfo[MemoryAvailable]; availableMiB != -1 && request.memoryMiB > availableMiB {
+			return fmt.Errorf("not enough memory in resource pool %s: %v need %d MiB but only %d MiB are available", pool, request.groups, request.memoryMiB, availableMiB)
+		}
+
+		threads, err := p.providerGovcClient.GetResourcePoolCPUThreads(ctx, datacenter, pool)
+		if err != nil {
+			return fmt.Errorf("getting resource pool %s cpu threads: %v", pool, err)
+		}
+		if request.numCPUs > threads {
+			logger.Info("Warning: machines requested in resource pool overcommit its cpu threads", "resourcePool", pool, "machineGroups", request.groups, "numCPUs", request.numCPUs, "cpuThreads", threads)
+		}
+	}
+
+	logger.V(5).Info("Datastore and resource pool capacity for machine configs validated")
+	return nil
+}
+
+// machinePlacements returns where the control plane, etcd and worker machines are going to be cloned.
+// Worker node groups assigned to a failure domain use the failure domain datastore and resource pool.
+func machinePlacements(spec *Spec, prevCluster *v1alpha1.Cluster) []machinePlacement {
+	placements := make([]machinePlacement, 0, len(spec.Cluster.Spec.WorkerNodeGroupConfigurations)+2)
+
+	cp := spec.Cluster.Spec.ControlPlaneConfiguration
+	cpMachineConfig := spec.controlPlaneMachineConfig()
+	cpCount := cp.Count
+	if prevCluster != nil {
+		cpCount = upgradeMachineCount(cp.Count, prevCluster.Spec.ControlPlaneConfiguration.Count, controlPlaneMaxSurge(cp.UpgradeRolloutStrategy))
+	}
+	cpPlacement := newMachinePlacement("control plane", cpMachineConfig, cpCount)
+	cpPlacement.id = "control-plane"
+	cpPlacement.maxSurge = controlPlaneMaxSurge(cp.UpgradeRolloutStrategy)
+	cpPlacement.networks = machineNetworks(spec.VSphereDatacenter.Spec, cpMachineConfig.Spec, false, "")
+	cpPlacement.machineLabels = map[string]string{
+		clusterv1beta2.ClusterNameLabel:         spec.Cluster.Name,
+		clusterv1beta2.MachineControlPlaneLabel: "",
+	}
+	placements = append(placements, cpPlacement)
+
+	if etcd := spec.Cluster.Spec.ExternalEtcdConfiguration; etcd != nil {
+		etcdCount := etcd.Count
+		if prevCluster != nil {
+			prevCount := 0
+			if prevCluster.Spec.ExternalEtcdConfiguration != nil {
+				prevCount = prevCluster.Spec.ExternalEtcdConfiguration.Count
+			}
+			etcdCount = upgradeMachineCount(etcd.Count, prevCount, defaultMaxSurge)
+		}
+		etcdPlacement := newMachinePlacement("etcd", spec.etcdMachineConfig(), etcdCount)
+		etcdPlacement.id = "etcd"
+		etcdPlacement.maxSurge = defaultMaxSurge
+		etcdPlacement.networks = machineNetworks(spec.VSphereDatacenter.Spec, etcdPlacement.machineConfig.Spec, false, "")
+		etcdPlacement.machineLabels = map[string]string{
+			clusterv1beta2.ClusterNameLabel: spec.Cluster.Name,
+			etcdClusterLabel:                clusterapi.EtcdClusterName(spec.Cluster.Name),
+		}
+		placements = append(placements, etcdPlacement)
+	}
+
+	prevWorkers := map[string]int{}
+	if prevCluster != nil {
+		for _, wng := range prevCluster.Spec.WorkerNodeGroupConfigurations {
+			if wng.Count != nil {
+				prevWorkers[wng.Name] = *wng.Count
+			}
+		}
+	}
+
+	failureDomains := map[string]v1alpha1.FailureDomain{}
+	for _, fd := range spec.VSphereDatacenter.Spec.FailureDomains {
+		failureDomains[fd.Name] = fd
+	}
+
+	for _, wng := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		count := 0
+		if wng.Count != nil {
+			count = *wng.Count
+		}
+		if prevCluster != nil {
+			prevCount, ok := prevWorkers[wng.Name]
+			surge := 0
+			if ok {
+				surge = workersMaxSurge(wng.UpgradeRolloutStrategy)
+			}
+			count = upgradeMachineCount(count, prevCount, surge)
+		}
+
+		placement := newMachinePlacement("worker node group "+wng.Name, spec.workerMachineConfig(wng), count)
+		placement.id = wng.Name
+		placement.maxSurge = workersMaxSurge(wng.UpgradeRolloutStrategy)
+		placement.machineLabels = map[string]string{
+			clusterv1beta2.ClusterNameLabel:           spec.Cluster.Name,
+			clusterv1beta2.MachineDeploymentNameLabel: clusterapi.MachineDeploymentName(spec.Cluster, wng),
+		}
+		if len(wng.FailureDomains) > 0 {
+			if fd, ok := failureDomains[wng.FailureDomains[0]]; ok {
+				placement.datastore = fd.Datastore
+				placement.resourcePool = fd.ResourcePool
+				placement.failureDomain = fd.Name
+			}
+		}
+		placement.networks = machineNetworks(spec.VSphereDatacenter.Spec, placement.machineConfig.Spec, true, placement.failureDomain)
+		placements = append(placements, placement)
+	}
+
+	return placements
+}
+
+func newMachinePlacement(name string, mc *v1alpha1.VSphereMachineConfig, count int) machinePlacement {
+	return machinePlacement{
+		name:          name,
+		machineConfig: mc,
+		datastore:     mc.Spec.Datastore,
+		resourcePool:  mc.Spec.ResourcePool,
+		count:         count,
+	}
+}
+
+// upgradeMachineCount returns the number of machines cloned during an upgrade on top of the
+// existing ones: the machines added by scaling up plus the extra machines rolled out at once.
+func upgradeMachineCount(count, prevCount, surge int) int {
+	added := count - prevCount
+	if added < 0 {
+		added = 0
+	}
+	if surge > count {
+		surge = count
+	}
+	return added + surge
+}
+
+func controlPlaneMaxSurge(strategy *v1alpha1.ControlPlaneUpgradeRolloutStrategy) int {
+	if strategy == nil {
+		return defaultMaxSurge
+	}
+	if strategy.Type == v1alpha1.InPlaceStrategyType {
+		return 0
+	}
+	if strategy.RollingUpdate != nil {
+		return strategy.RollingUpdate.MaxSurge
+	}
+	return defaultMaxSurge
+}
+
+func workersMaxSurge(strategy *v1alpha1.WorkerNodesUpgradeRolloutStrategy) int {
+	if strategy == nil {
+		return defaultMaxSurge
+	}
+	if strategy.Type == v1alpha1.InPlaceStrategyType {
+		return 0
+	}
+	if strategy.RollingUpdate != nil {
+		return strategy.RollingUpdate.MaxSurge
+	}
+	return defaultMaxSurge
+}
+
+func addCapacityRequest(requests map[string]*capacityRequest, key, group string, r capacityRequest) {
+	request, ok := requests[key]
+	if !ok {
+		request = &capacityRequest{}
+		requests[key] = request
+	}
+	request.diskGiB += r.diskGiB
+	request.memoryMiB += r.memoryMiB
+	request.numCPUs += r.numCPUs
+	request.groups = append(request.groups, group)
+}
+
+func sortedKeys(m map[string]*capacityRequest) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,158 @@
+package vsphere
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const (
+	capacityTestDatastore    = "/SDDC-Datacenter/datastore/WorkloadDatastore"
+	capacityTestResourcePool = "*/Resources"
+)
+
+func TestValidateCapacityCreateSuccess(t *testing.T) {
+	tt := newProviderTest(t)
+	datacenter := tt.datacenterConfig.Spec.Datacenter
+
+	tt.govc.EXPECT().GetWorkloadAvailableSpace(tt.ctx, capacityTestDatastore).Return(225.0, nil)
+	tt.govc.EXPECT().GetResourcePoolInfo(tt.ctx, datacenter, capacityTestResourcePool).Return(map[string]int{MemoryAvailable: 49152}, nil)
+	tt.govc.EXPECT().GetResourcePoolCPUThreads(tt.ctx, datacenter, capacityTestResourcePool).Return(24, nil)
+
+	tt.Expect(tt.provider.validateCapacity(tt.ctx, NewSpec(tt.clusterSpec), nil)).To(Succeed())
+}
+
+func TestValidateCapacityCreateNotEnoughDiskSpace(t *testing.T) {
+	tt := newProviderTest(t)
+
+	tt.govc.EXPECT().GetWorkloadAvailableSpace(tt.ctx, capacityTestDatastore).Return(100.0, nil)
+
+	tt.Expect(tt.provider.validateCapacity(tt.ctx, NewSpec(tt.clusterSpec), nil)).To(MatchError(
+		"not enough space in datastore /SDDC-Datacenter/datastore/WorkloadDatastore: [control plane etcd worker node group md-0] need 225 GiB but only 100.00 GiB are available",
+	))
+}
+
+func TestValidateCapacityCreateNotEnoughMemory(t *testing.T) {
+	tt := newProviderTest(t)
+	datacenter := tt.datacenterConfig.Spec.Datacenter
+
+	tt.govc.EXPECT().GetWorkloadAvailableSpace(tt.ctx, capacityTestDatastore).Return(1000.0, nil)
+	tt.govc.EXPECT().GetResourcePoolInfo(tt.ctx, datacenter, capacityTestResourcePool).Return(map[string]int{MemoryAvailable: 10000}, nil)
+
+	tt.Expect(tt.provider.validateCapacity(tt.ctx, NewSpec(tt.clusterSpec), nil)).To(MatchError(
+		"not enough memory in resource pool */Resources: [control plane etcd worker node group md-0] need 49152 MiB but only 10000 MiB are available",
+	))
+}
+
+func TestValidateCapacityCreateUnlimitedMemoryAndCPUOvercommit(t *testing.T) {
+	tt := newProviderTest(t)
+	datacenter := tt.datacenterConfig.Spec.Datacenter
+
+	tt.govc.EXPECT().GetWorkloadAvailableSpace(tt.ctx, capacityTestDatastore).Return(1000.0, nil)
+	tt.govc.EXPECT().GetResourcePoolInfo(tt.ctx, datacenter, capacityTestResourcePool).Return(map[string]int{MemoryAvailable: -1}, nil)
+	tt.govc.EXPECT().GetResourcePoolCPUThreads(tt.ctx, datacenter, capacityTestResourcePool).Return(4, nil)
+
+	tt.Expect(tt.provider.validateCapacity(tt.ctx, NewSpec(tt.clusterSpec), nil)).To(Succeed())
+}
+
+func TestValidateCapacityCreateGovcError(t *testing.T) {
+	tt := newProviderTest(t)
+	datacenter := tt.datacenterConfig.Spec.Datacenter
+
+	tt.govc.EXPECT().GetWorkloadAvailableSpace(tt.ctx, capacityTestDatastore).Return(1000.0, nil)
+	tt.govc.EXPECT().GetResourcePoolInfo(tt.ctx, datacenter, capacityTestResourcePool).Return(map[string]int{MemoryAvailable: -1}, nil)
+	tt.govc.EXPECT().GetResourcePoolCPUThreads(tt.ctx, datacenter, capacityTestResourcePool).Return(0, errors.New("error"))
+
+	tt.Expect(tt.provider.validateCapacity(tt.ctx, NewSpec(tt.clusterSpec), nil)).To(MatchError("getting resource pool */Resources cpu threads: error"))
+}
+
+func TestValidateCapacityCreateFailureDomains(t *testing.T) {
+	tt := newProviderTest(t)
+	datacenter := tt.datacenterConfig.Spec.Datacenter
+	tt.clusterSpec.VSphereDatacenter.Spec.FailureDomains = []v1alpha1.FailureDomain{
+		{Name: "fd-1", Datastore: "/SDDC-Datacenter/datastore/fd-1", ResourcePool: "/SDDC-Datacenter/host/fd-1/Resources"},
+	}
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].FailureDomains = []string{"fd-1"}
+
+	tt.govc.EXPECT().GetWorkloadAvailableSpace(tt.ctx, capacityTestDatastore).Return(150.0, nil)
+	tt.govc.EXPECT().GetWorkloadAvailableSpace(tt.ctx, "/SDDC-Datacenter/datastore/fd-1").Return(75.0, nil)
+	tt.govc.EXPECT().GetResourcePoolInfo(tt.ctx, datacenter, capacityTestResourcePool).Return(map[string]int{MemoryAvailable: 36864}, nil)
+	tt.govc.EXPECT().GetResourcePoolCPUThreads(tt.ctx, datacenter, capacityTestResourcePool).Return(15, nil)
+	tt.govc.EXPECT().GetResourcePoolInfo(tt.ctx, datacenter, "/SDDC-Datacenter/host/fd-1/Resources").Return(map[string]int{MemoryAvailable: 12288}, nil)
+	tt.govc.EXPECT().GetResourcePoolCPUThreads(tt.ctx, datacenter, "/SDDC-Datacenter/host/fd-1/Resources").Return(9, nil)
+
+	tt.Expect(tt.provider.validateCapacity(tt.ctx, NewSpec(tt.clusterSpec), nil)).To(Succeed())
+}
+
+func TestValidateCapacityUpgradeSurge(t *testing.T) {
+	tt := newProviderTest(t)
+	datacenter := tt.datacenterConfig.Spec.Datacenter
+	prevCluster := tt.clusterSpec.Cluster.DeepCopy()
+
+	tt.kubectl.EXPECT().GetEksaCluster(tt.ctx, tt.workloadCluster, tt.clusterSpec.Cluster.Name).Return(prevCluster, nil)
+	// One surge machine per group.
+	tt.govc.EXPECT().GetWorkloadAvailableSpace(tt.ctx, capacityTestDatastore).Return(75.0, nil)
+	tt.govc.EXPECT().GetResourcePoolInfo(tt.ctx, datacenter, capacityTestResourcePool).Return(map[string]int{MemoryAvailable: 16384}, nil)
+	tt.govc.EXPECT().GetResourcePoolCPUThreads(tt.ctx, datacenter, capacityTestResourcePool).Return(8, nil)
+
+	tt.Expect(tt.provider.validateCapacity(tt.ctx, NewSpec(tt.clusterSpec), tt.workloadCluster)).To(Succeed())
+}
+
+func TestValidateCapacityUpgradeScaleUpNotEnoughDiskSpace(t *testing.T) {
+	tt := newProviderTest(t)
+	prevCluster := tt.clusterSpec.Cluster.DeepCopy()
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(5)
+
+	tt.kubectl.EXPECT().GetEksaCluster(tt.ctx, tt.workloadCluster, tt.clusterSpec.Cluster.Name).Return(prevCluster, nil)
+	tt.govc.EXPECT().GetWorkloadAvailableSpace(tt.ctx, capacityTestDatastore).Return(75.0, nil)
+
+	tt.Expect(tt.provider.validateCapacity(tt.ctx, NewSpec(tt.clusterSpec), tt.workloadCluster)).To(MatchError(
+		"not enough space in datastore /SDDC-Datacenter/datastore/WorkloadDatastore: [control plane etcd worker node group md-0] need 125 GiB but only 75.00 GiB are available",
+	))
+}
+
+func TestValidateCapacityUpgradeGetClusterError(t *testing.T) {
+	tt := newProviderTest(t)
+
+	tt.kubectl.EXPECT().GetEksaCluster(tt.ctx, tt.workloadCluster, tt.clusterSpec.Cluster.Name).Return(nil, errors.New("error"))
+
+	tt.Expect(tt.provider.validateCapacity(tt.ctx, NewSpec(tt.clusterSpec), tt.workloadCluster)).To(MatchError("error"))
+}
+
+func TestUpgradeMachineCount(t *testing.T) {
+	tests := []struct {
+		name                    string
+		count, prevCount, surge int
+		want                    int
+	}{
+		{name: "rollout", count: 3, prevCount: 3, surge: 1, want: 1},
+		{name: "in place", count: 3, prevCount: 3, surge: 0, want: 0},
+		{name: "scale up", count: 5, prevCount: 3, surge: 1, want: 3},
+		{name: "scale down", count: 1, prevCount: 3, surge: 2, want: 1},
+		{name: "new group", count: 2, prevCount: 0, surge: 0, want: 2},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := upgradeMachineCount(tc.count, tc.prevCount, tc.surge); got != tc.want {
+				t.Errorf("upgradeMachineCount() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMachinePlacementsInPlaceUpgrade(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType}
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy = &v1alpha1.WorkerNodesUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType}
+	prevCluster := tt.clusterSpec.Cluster.DeepCopy()
+
+	counts := map[string]int{}
+	for _, p := range machinePlacements(NewSpec(tt.clusterSpec), prevCluster) {
+		counts[p.name] = p.count
+	}
+	tt.Expect(counts).To(Equal(map[string]int{"control plane": 0, "etcd": 1, "worker node group md-0": 0}))
+}
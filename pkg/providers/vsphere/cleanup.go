@@ -0,0 +1,126 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/aws/eks-anywhere/pkg/cleanup"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/executables"
+)
+
+const (
+	vmResourceKind       = "VirtualMachine"
+	drsRuleResourceKind  = "DRSRule"
+	drsGroupResourceKind = "DRSGroup"
+)
+
+// CleanupGovcClient is the vSphere client the CleanupDiscoverer uses to find and delete the infrastructure of a cluster.
+type CleanupGovcClient interface {
+	ListVMs(ctx context.Context, datacenter, namePattern string) ([]string, error)
+	DestroyVM(ctx context.Context, path string) error
+	ListDRSRules(ctx context.Context, datacenter, resourcePool string) ([]string, error)
+	RemoveDRSRule(ctx context.Context, datacenter, resourcePool, name string) error
+	ListDRSGroups(ctx context.Context, datacenter, resourcePool string) ([]string, error)
+	RemoveDRSGroup(ctx context.Context, datacenter, resourcePool, name string) error
+}
+
+// CleanupDiscoverer finds the VMs of a cluster, named after its machines by CAPV, and the DRS rules
+// and groups created for the machine groups with antiAffinity.
+//
+// The tags and content library items are not discovered: they belong to the templates, which are
+// shared by the clusters using the same OS and Kubernetes version and are deleted with prune templates.
+type CleanupDiscoverer struct {
+	govc     CleanupGovcClient
+	spec     *Spec
+	machines *cleanup.MachineNames
+}
+
+// NewCleanupDiscoverer builds a CleanupDiscoverer for the datacenter and machine configs of a cluster spec.
+func NewCleanupDiscoverer(govc CleanupGovcClient, spec *cluster.Spec) *CleanupDiscoverer {
+	return &CleanupDiscoverer{
+		govc:     govc,
+		spec:     NewSpec(spec),
+		machines: cleanup.NewMachineNames(spec.Cluster),
+	}
+}
+
+// Name returns the infrastructure the CleanupDiscoverer looks in.
+func (d *CleanupDiscoverer) Name() string {
+	return "vsphere"
+}
+
+// Discover returns the VMs of the cluster followed by its DRS rules and groups, so the rules
+// are removed once they don't have VMs anymore and the VM-Host rules before their VM groups.
+func (d *CleanupDiscoverer) Discover(ctx context.Context, clusterName string) ([]cleanup.Resource, error) {
+	datacenter := d.spec.VSphereDatacenter.Spec.Datacenter
+	vms, err := d.govc.ListVMs(ctx, datacenter, clusterName+"-*")
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]cleanup.Resource, 0, len(vms))
+	for _, vm := range vms {
+		// The pattern matches the machines of the clusters whose name starts with the cluster name too.
+		if !d.machines.Match(path.Base(vm)) {
+			continue
+		}
+		resources = append(resources, cleanup.Resource{Kind: vmResourceKind, Name: vm})
+	}
+
+	drs, err := d.discoverDRS(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(resources, drs...), nil
+}
+
+func (d *CleanupDiscoverer) discoverDRS(ctx context.Context, clusterName string) ([]cleanup.Resource, error) {
+	datacenter := d.spec.VSphereDatacenter.Spec.Datacenter
+	rules := []cleanup.Resource{}
+	groups := []cleanup.Resource{}
+	for _, placement := range machinePlacements(d.spec, nil) {
+		if placement.machineConfig.Spec.AntiAffinity == nil {
+			continue
+		}
+
+		rule := executables.AntiAffinityRule{Name: antiAffinityRuleName(clusterName, placement.id)}
+		existingRules, err := d.govc.ListDRSRules(ctx, datacenter, placement.resourcePool)
+		if err != nil {
+			return nil, fmt.Errorf("listing DRS rules for %s: %v", placement.name, err)
+		}
+		for _, name := range []string{rule.Name, rule.VMHostRuleName()} {
+			if slices.Contains(existingRules, name) {
+				rules = append(rules, cleanup.Resource{Kind: drsRuleResourceKind, Name: name, Scope: placement.resourcePool})
+			}
+		}
+
+		existingGroups, err := d.govc.ListDRSGroups(ctx, datacenter, placement.resourcePool)
+		if err != nil {
+			return nil, fmt.Errorf("listing DRS groups for %s: %v", placement.name, err)
+		}
+		if slices.Contains(existingGroups, rule.VMGroupName()) {
+			groups = append(groups, cleanup.Resource{Kind: drsGroupResourceKind, Name: rule.VMGroupName(), Scope: placement.resourcePool})
+		}
+	}
+
+	return append(rules, groups...), nil
+}
+
+// Delete destroys a VM or removes a DRS rule or group from the compute cluster of its resource pool.
+func (d *CleanupDiscoverer) Delete(ctx context.Context, resource cleanup.Resource) error {
+	datacenter := d.spec.VSphereDatacenter.Spec.Datacenter
+	switch resource.Kind {
+	case vmResourceKind:
+		return d.govc.DestroyVM(ctx, resource.Name)
+	case drsRuleResourceKind:
+		return d.govc.RemoveDRSRule(ctx, datacenter, resource.Scope, resource.Name)
+	case drsGroupResourceKind:
+		return d.govc.RemoveDRSGroup(ctx, datacenter, resource.Scope, resource.Name)
+	default:
+		return fmt.Errorf("unsupported vsphere resource kind %s", resource.Kind)
+	}
+}
@@ -0,0 +1,82 @@
+package vsphere
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cleanup"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+)
+
+func TestCleanupDiscovererDiscover(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.VSphereMachineConfigs["test-cp"].Spec.AntiAffinity = &v1alpha1.VSphereAntiAffinity{Type: v1alpha1.HardAntiAffinity}
+	tt.clusterSpec.VSphereMachineConfigs["test-wn"].Spec.AntiAffinity = &v1alpha1.VSphereAntiAffinity{Type: v1alpha1.SoftAntiAffinity, HostGroup: "rack-a"}
+	govc := mocks.NewMockCleanupGovcClient(gomock.NewController(t))
+	datacenter := tt.datacenterConfig.Spec.Datacenter
+
+	govc.EXPECT().ListVMs(tt.ctx, datacenter, "test-*").Return([]string{
+		"/dc/vm/test-x7k2p", "/dc/vm/test-md-0-5d8fc-abcde", "/dc/vm/test-prod-x7k2p", "/dc/vm/test-md-0-1",
+	}, nil)
+	govc.EXPECT().ListDRSRules(tt.ctx, datacenter, capacityTestResourcePool).Return([]string{
+		"test-control-plane-anti-affinity", "test-md-0-anti-affinity", "test-md-0-anti-affinity-hosts", "other-md-0-anti-affinity",
+	}, nil).Times(2)
+	govc.EXPECT().ListDRSGroups(tt.ctx, datacenter, capacityTestResourcePool).Return([]string{"rack-a", "test-md-0-anti-affinity-vms"}, nil).Times(2)
+
+	d := NewCleanupDiscoverer(govc, tt.clusterSpec)
+	tt.Expect(d.Name()).To(Equal("vsphere"))
+	resources, err := d.Discover(tt.ctx, "test")
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(resources).To(Equal([]cleanup.Resource{
+		{Kind: "VirtualMachine", Name: "/dc/vm/test-x7k2p"},
+		{Kind: "VirtualMachine", Name: "/dc/vm/test-md-0-5d8fc-abcde"},
+		{Kind: "DRSRule", Name: "test-control-plane-anti-affinity", Scope: capacityTestResourcePool},
+		{Kind: "DRSRule", Name: "test-md-0-anti-affinity", Scope: capacityTestResourcePool},
+		{Kind: "DRSRule", Name: "test-md-0-anti-affinity-hosts", Scope: capacityTestResourcePool},
+		{Kind: "DRSGroup", Name: "test-md-0-anti-affinity-vms", Scope: capacityTestResourcePool},
+	}))
+}
+
+func TestCleanupDiscovererDiscoverNoAntiAffinity(t *testing.T) {
+	tt := newProviderTest(t)
+	govc := mocks.NewMockCleanupGovcClient(gomock.NewController(t))
+
+	govc.EXPECT().ListVMs(tt.ctx, tt.datacenterConfig.Spec.Datacenter, "test-*").Return(nil, nil)
+
+	resources, err := NewCleanupDiscoverer(govc, tt.clusterSpec).Discover(tt.ctx, "test")
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(resources).To(BeEmpty())
+}
+
+func TestCleanupDiscovererDiscoverError(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.VSphereMachineConfigs["test-cp"].Spec.AntiAffinity = &v1alpha1.VSphereAntiAffinity{Type: v1alpha1.HardAntiAffinity}
+	govc := mocks.NewMockCleanupGovcClient(gomock.NewController(t))
+	datacenter := tt.datacenterConfig.Spec.Datacenter
+
+	govc.EXPECT().ListVMs(tt.ctx, datacenter, "test-*").Return(nil, nil)
+	govc.EXPECT().ListDRSRules(tt.ctx, datacenter, capacityTestResourcePool).Return(nil, errors.New("not a compute cluster"))
+
+	_, err := NewCleanupDiscoverer(govc, tt.clusterSpec).Discover(tt.ctx, "test")
+	tt.Expect(err).To(MatchError("listing DRS rules for control plane: not a compute cluster"))
+}
+
+func TestCleanupDiscovererDelete(t *testing.T) {
+	tt := newProviderTest(t)
+	govc := mocks.NewMockCleanupGovcClient(gomock.NewController(t))
+	datacenter := tt.datacenterConfig.Spec.Datacenter
+	d := NewCleanupDiscoverer(govc, tt.clusterSpec)
+
+	govc.EXPECT().DestroyVM(tt.ctx, "/dc/vm/test-cp-1")
+	govc.EXPECT().RemoveDRSRule(tt.ctx, datacenter, capacityTestResourcePool, "test-md-0-anti-affinity")
+	govc.EXPECT().RemoveDRSGroup(tt.ctx, datacenter, capacityTestResourcePool, "test-md-0-anti-affinity-vms")
+
+	tt.Expect(d.Delete(tt.ctx, cleanup.Resource{Kind: "VirtualMachine", Name: "/dc/vm/test-cp-1"})).To(Succeed())
+	tt.Expect(d.Delete(tt.ctx, cleanup.Resource{Kind: "DRSRule", Name: "test-md-0-anti-affinity", Scope: capacityTestResourcePool})).To(Succeed())
+	tt.Expect(d.Delete(tt.ctx, cleanup.Resource{Kind: "DRSGroup", Name: "test-md-0-anti-affinity-vms", Scope: capacityTestResourcePool})).To(Succeed())
+	tt.Expect(d.Delete(tt.ctx, cleanup.Resource{Kind: "Tag", Name: "test"})).To(MatchError("unsupported vsphere resource kind Tag"))
+}
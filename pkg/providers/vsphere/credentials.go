@@ -0,0 +1,147 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/credentials"
+)
+
+// CredentialsRotator rotates the vSphere credentials of a management cluster and its workload clusters.
+// It updates the same secrets the EKS Anywhere controller generates from the vsphere-credentials secret:
+// the CAPV identity and the cloud provider credentials, applied to the clusters with a ClusterResourceSet.
+type CredentialsRotator struct {
+	validator *Validator
+}
+
+// NewCredentialsRotator returns a new CredentialsRotator.
+func NewCredentialsRotator(validator *Validator) *CredentialsRotator {
+	return &CredentialsRotator{
+		validator: validator,
+	}
+}
+
+// ValidateCredentials logs in vCenter with the new credentials and checks the users have the privileges
+// required by EKS Anywhere.
+func (r *CredentialsRotator) ValidateCredentials(ctx context.Context, spec *cluster.Spec) error {
+	if err := SetupEnvVars(spec.VSphereDatacenter); err != nil {
+		return err
+	}
+
+	if err := r.validator.validateVCenterAccess(ctx, spec.VSphereDatacenter.Spec.Server); err != nil {
+		return err
+	}
+
+	return r.validator.validateVsphereUserPrivs(ctx, NewSpec(spec))
+}
+
+// UpdateSecrets updates the vsphere-credentials secret of the management cluster.
+// Clusters created before the vSphere CSI driver was removed also have CSI credentials, which are rotated with the others.
+func (r *CredentialsRotator) UpdateSecrets(ctx context.Context, client kubernetes.Client, _ *cluster.Spec) error {
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, constants.VSphereCredentialsName, constants.EksaSystemNamespace, secret); err != nil {
+		return fmt.Errorf("getting secret %s: %v", constants.VSphereCredentialsName, err)
+	}
+
+	vuc := config.NewVsphereUserConfig()
+	data := map[string][]byte{
+		"username":   []byte(vuc.EksaVsphereUsername),
+		"password":   []byte(vuc.EksaVspherePassword),
+		"usernameCP": []byte(vuc.EksaVsphereCPUsername),
+		"passwordCP": []byte(vuc.EksaVsphereCPPassword),
+	}
+	if _, ok := secret.Data["passwordCSI"]; ok {
+		data["usernameCSI"] = []byte(vuc.EksaVsphereUsername)
+		data["passwordCSI"] = []byte(vuc.EksaVspherePassword)
+	}
+
+	return credentials.UpdateSecretData(ctx, client, constants.VSphereCredentialsName, constants.EksaSystemNamespace, data)
+}
+
+// UsesCredentials returns true if the cluster uses the same vCenter as the management cluster.
+func (r *CredentialsRotator) UsesCredentials(ctx context.Context, client kubernetes.Client, spec *cluster.Spec, c *anywherev1.Cluster) (bool, error) {
+	datacenter := &anywherev1.VSphereDatacenterConfig{}
+	if err := client.Get(ctx, c.Spec.DatacenterRef.Name, c.Namespace, datacenter); err != nil {
+		return false, fmt.Errorf("getting VSphereDatacenterConfig %s: %v", c.Spec.DatacenterRef.Name, err)
+	}
+
+	return datacenter.Spec.Server == spec.VSphereDatacenter.Spec.Server, nil
+}
+
+// UpdateClusterSecrets updates the CAPV identity of the cluster and the cloud provider credentials
+// the ClusterResourceSet of the cluster applies to it.
+func (r *CredentialsRotator) UpdateClusterSecrets(ctx context.Context, client kubernetes.Client, spec *cluster.Spec, c *anywherev1.Cluster) error {
+	vuc := config.NewVsphereUserConfig()
+	if err := credentials.UpdateSecretData(ctx, client, fmt.Sprintf("%s-vsphere-credentials", c.Name), constants.EksaSystemNamespace, map[string][]byte{
+		"username": []byte(vuc.EksaVsphereUsername),
+		"password": []byte(vuc.EksaVspherePassword),
+	}); err != nil {
+		return err
+	}
+
+	cloudProviderSecret, err := cloudProviderCredentialsSecret(spec.VSphereDatacenter.Spec.Server, vuc)
+	if err != nil {
+		return err
+	}
+
+	return credentials.UpdateSecretData(ctx, client, fmt.Sprintf("%s-cloud-provider-vsphere-credentials", c.Name), constants.EksaSystemNamespace, map[string][]byte{
+		"data": cloudProviderSecret,
+	})
+}
+
+// VerifyClusterCredentials logs in vCenter with the credentials of the CAPV identity secret of the cluster.
+func (r *CredentialsRotator) VerifyClusterCredentials(ctx context.Context, client kubernetes.Client, spec *cluster.Spec, c *anywherev1.Cluster) error {
+	name := fmt.Sprintf("%s-vsphere-credentials", c.Name)
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, name, constants.EksaSystemNamespace, secret); err != nil {
+		return fmt.Errorf("getting secret %s: %v", name, err)
+	}
+
+	datacenter := spec.VSphereDatacenter.Spec
+	if _, err := r.validator.vSphereClientBuilder.Build(
+		ctx,
+		datacenter.Server,
+		string(secret.Data["username"]),
+		string(secret.Data["password"]),
+		datacenter.Insecure,
+		datacenter.Datacenter,
+	); err != nil {
+		return fmt.Errorf("logging in vCenter with the credentials of secret %s: %v", name, err)
+	}
+
+	return nil
+}
+
+func cloudProviderCredentialsSecret(server string, vuc *config.VSphereUserConfig) ([]byte, error) {
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cloud-provider-vsphere-credentials",
+			Namespace: "kube-system",
+		},
+		Data: map[string][]byte{
+			server + ".username": []byte(vuc.EksaVsphereCPUsername),
+			server + ".password": []byte(vuc.EksaVsphereCPPassword),
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+
+	b, err := yaml.Marshal(secret)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling cloud provider credentials secret: %v", err)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,237 @@
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/govmomi/mocks"
+	govcmocks "github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+)
+
+func setRotatedCredentials(t *testing.T) {
+	t.Setenv(config.EksavSphereUsernameKey, "new-user")
+	t.Setenv(config.EksavSpherePasswordKey, "new-password")
+	t.Setenv(config.EksavSphereCPUsernameKey, "")
+	t.Setenv(config.EksavSphereCPPasswordKey, "")
+}
+
+func credentialsTestSecret(name string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Data: data,
+	}
+}
+
+func TestCredentialsRotatorValidateCredentials(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	govc := govcmocks.NewMockProviderGovcClient(ctrl)
+	vscb := govcmocks.NewMockVSphereClientBuilder(ctrl)
+	vsc := mocks.NewMockVSphereClient(ctrl)
+	setRotatedCredentials(t)
+	spec := clusterSpec()
+
+	var privs []string
+	for _, file := range []string{config.VSphereGlobalPrivsFile, config.VSphereUserPrivsFile, config.VSphereAdminPrivsFile} {
+		var p []string
+		g.Expect(json.Unmarshal([]byte(file), &p)).To(Succeed())
+		privs = append(privs, p...)
+	}
+
+	govc.EXPECT().ValidateVCenterConnection(ctx, "server")
+	govc.EXPECT().ValidateVCenterAuthentication(ctx)
+	vscb.EXPECT().Build(ctx, "server", "new-user", "new-password", false, spec.VSphereDatacenter.Spec.Datacenter).Return(vsc, nil)
+	vsc.EXPECT().Username().Return("new-user").AnyTimes()
+	vsc.EXPECT().GetPrivsOnEntity(ctx, gomock.Any(), gomock.Any(), "new-user").Return(privs, nil).AnyTimes()
+
+	r := NewCredentialsRotator(NewValidator(govc, vscb))
+	g.Expect(r.ValidateCredentials(ctx, spec.Spec)).To(Succeed())
+}
+
+func TestCredentialsRotatorValidateCredentialsUnauthorized(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	govc := govcmocks.NewMockProviderGovcClient(ctrl)
+	setRotatedCredentials(t)
+	spec := clusterSpec()
+
+	govc.EXPECT().ValidateVCenterConnection(ctx, "server")
+	govc.EXPECT().ValidateVCenterAuthentication(ctx).Return(errors.New("unauthorized"))
+
+	r := NewCredentialsRotator(NewValidator(govc, nil))
+	g.Expect(r.ValidateCredentials(ctx, spec.Spec)).To(MatchError("failed validating credentials for vCenter: unauthorized"))
+}
+
+func TestCredentialsRotatorUpdateSecrets(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	setRotatedCredentials(t)
+	client := test.NewFakeKubeClient(credentialsTestSecret(constants.VSphereCredentialsName, map[string][]byte{"username": []byte("old-user")}))
+
+	r := NewCredentialsRotator(nil)
+	g.Expect(r.UpdateSecrets(ctx, client, clusterSpec().Spec)).To(Succeed())
+
+	got := &corev1.Secret{}
+	g.Expect(client.Get(ctx, constants.VSphereCredentialsName, constants.EksaSystemNamespace, got)).To(Succeed())
+	g.Expect(got.Data).To(Equal(map[string][]byte{
+		"username":   []byte("new-user"),
+		"password":   []byte("new-password"),
+		"usernameCP": []byte("new-user"),
+		"passwordCP": []byte("new-password"),
+	}))
+}
+
+func TestCredentialsRotatorUpdateSecretsWithCSICredentials(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	setRotatedCredentials(t)
+	client := test.NewFakeKubeClient(credentialsTestSecret(constants.VSphereCredentialsName, map[string][]byte{
+		"username":    []byte("old-user"),
+		"usernameCSI": []byte("old-user"),
+		"passwordCSI": []byte("old-password"),
+	}))
+
+	r := NewCredentialsRotator(nil)
+	g.Expect(r.UpdateSecrets(ctx, client, clusterSpec().Spec)).To(Succeed())
+
+	got := &corev1.Secret{}
+	g.Expect(client.Get(ctx, constants.VSphereCredentialsName, constants.EksaSystemNamespace, got)).To(Succeed())
+	g.Expect(got.Data).To(HaveKeyWithValue("usernameCSI", []byte("new-user")))
+	g.Expect(got.Data).To(HaveKeyWithValue("passwordCSI", []byte("new-password")))
+}
+
+func TestCredentialsRotatorUpdateClusterSecrets(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	setRotatedCredentials(t)
+	client := test.NewFakeKubeClient(
+		credentialsTestSecret("workload-vsphere-credentials", nil),
+		credentialsTestSecret("workload-cloud-provider-vsphere-credentials", nil),
+	)
+	workload := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "workload"}}
+
+	r := NewCredentialsRotator(nil)
+	g.Expect(r.UpdateClusterSecrets(ctx, client, clusterSpec().Spec, workload)).To(Succeed())
+
+	got := &corev1.Secret{}
+	g.Expect(client.Get(ctx, "workload-vsphere-credentials", constants.EksaSystemNamespace, got)).To(Succeed())
+	g.Expect(got.Data).To(Equal(map[string][]byte{
+		"username": []byte("new-user"),
+		"password": []byte("new-password"),
+	}))
+
+	g.Expect(client.Get(ctx, "workload-cloud-provider-vsphere-credentials", constants.EksaSystemNamespace, got)).To(Succeed())
+	g.Expect(string(got.Data["data"])).To(Equal(`apiVersion: v1
+data:
+  server.password: bmV3LXBhc3N3b3Jk
+  server.username: bmV3LXVzZXI=
+kind: Secret
+metadata:
+  name: cloud-provider-vsphere-credentials
+  namespace: kube-system
+type: Opaque
+`))
+}
+
+func TestCredentialsRotatorUpdateClusterSecretsMissingSecret(t *testing.T) {
+	g := NewWithT(t)
+	setRotatedCredentials(t)
+	workload := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "workload"}}
+
+	r := NewCredentialsRotator(nil)
+	err := r.UpdateClusterSecrets(context.Background(), test.NewFakeKubeClient(), clusterSpec().Spec, workload)
+	g.Expect(err).To(MatchError(ContainSubstring("getting secret workload-vsphere-credentials")))
+}
+
+func TestCredentialsRotatorVerifyClusterCredentials(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	vscb := govcmocks.NewMockVSphereClientBuilder(ctrl)
+	spec := clusterSpec()
+	client := test.NewFakeKubeClient(credentialsTestSecret("workload-vsphere-credentials", map[string][]byte{
+		"username": []byte("new-user"),
+		"password": []byte("new-password"),
+	}))
+	workload := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "workload"}}
+
+	vscb.EXPECT().Build(ctx, "server", "new-user", "new-password", false, spec.VSphereDatacenter.Spec.Datacenter).Return(mocks.NewMockVSphereClient(ctrl), nil)
+
+	r := NewCredentialsRotator(NewValidator(nil, vscb))
+	g.Expect(r.VerifyClusterCredentials(ctx, client, spec.Spec, workload)).To(Succeed())
+}
+
+func TestCredentialsRotatorVerifyClusterCredentialsUnauthorized(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	vscb := govcmocks.NewMockVSphereClientBuilder(ctrl)
+	spec := clusterSpec()
+	client := test.NewFakeKubeClient(credentialsTestSecret("workload-vsphere-credentials", map[string][]byte{
+		"username": []byte("old-user"),
+		"password": []byte("old-password"),
+	}))
+	workload := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "workload"}}
+
+	vscb.EXPECT().Build(ctx, "server", "old-user", "old-password", false, spec.VSphereDatacenter.Spec.Datacenter).Return(nil, errors.New("unauthorized"))
+
+	r := NewCredentialsRotator(NewValidator(nil, vscb))
+	err := r.VerifyClusterCredentials(ctx, client, spec.Spec, workload)
+	g.Expect(err).To(MatchError("logging in vCenter with the credentials of secret workload-vsphere-credentials: unauthorized"))
+}
+
+func TestCredentialsRotatorUsesCredentials(t *testing.T) {
+	tests := []struct {
+		name   string
+		server string
+		want   bool
+	}{
+		{
+			name:   "same vCenter",
+			server: "server",
+			want:   true,
+		},
+		{
+			name:   "other vCenter",
+			server: "other-server",
+			want:   false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			datacenter := &v1alpha1.VSphereDatacenterConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+				Spec:       v1alpha1.VSphereDatacenterConfigSpec{Server: tc.server},
+			}
+			workload := &v1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+				Spec: v1alpha1.ClusterSpec{
+					DatacenterRef: v1alpha1.Ref{Kind: v1alpha1.VSphereDatacenterKind, Name: "workload"},
+				},
+			}
+
+			r := NewCredentialsRotator(nil)
+			got, err := r.UsesCredentials(ctx, test.NewFakeKubeClient(datacenter), clusterSpec().Spec, workload)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tc.want))
+		})
+	}
+}
@@ -105,17 +105,12 @@ func (d *Defaulter) setDefaultTemplateIfMissing(ctx context.Context, spec *Spec,
 
 func (d *Defaulter) setupDefaultTemplate(ctx context.Context, spec *Spec, machineConfig *anywherev1.VSphereMachineConfig, versionsBundle *cluster.VersionsBundle) error {
 	osFamily := machineConfig.Spec.OSFamily
-	eksd := versionsBundle.EksD
-	var ova releasev1.Archive
-	switch osFamily {
-	case anywherev1.Bottlerocket:
-		ova = eksd.Ova.Bottlerocket
-	default:
-		return fmt.Errorf("can not import ova for osFamily: %s, please use %s as osFamily for auto-importing or provide a valid template", osFamily, anywherev1.Bottlerocket)
+	ova, err := ovaForOSFamily(versionsBundle, osFamily)
+	if err != nil {
+		return err
 	}
 
-	templateName := fmt.Sprintf("%s-%s-%s-%s-%s", osFamily, eksd.KubeVersion, eksd.Name, strings.Join(ova.Arch, "-"), ova.SHA256[:7])
-	machineConfig.Spec.Template = filepath.Join("/", spec.VSphereDatacenter.Spec.Datacenter, defaultTemplatesFolder, templateName)
+	machineConfig.Spec.Template = defaultTemplatePath(spec.VSphereDatacenter.Spec.Datacenter, osFamily, versionsBundle, ova)
 
 	tags := requiredTemplateTagsByCategory(machineConfig, versionsBundle)
 
@@ -130,6 +125,23 @@ func (d *Defaulter) setupDefaultTemplate(ctx context.Context, spec *Spec, machin
 	return nil
 }
 
+// ovaForOSFamily returns the OVA of the bundle that can be imported as a template for osFamily.
+func ovaForOSFamily(versionsBundle *cluster.VersionsBundle, osFamily anywherev1.OSFamily) (releasev1.Archive, error) {
+	switch osFamily {
+	case anywherev1.Bottlerocket:
+		return versionsBundle.EksD.Ova.Bottlerocket, nil
+	default:
+		return releasev1.Archive{}, fmt.Errorf("can not import ova for osFamily: %s, please use %s as osFamily for auto-importing or provide a valid template", osFamily, anywherev1.Bottlerocket)
+	}
+}
+
+// defaultTemplatePath returns the path of the template imported from ova in the default templates folder.
+func defaultTemplatePath(datacenter string, osFamily anywherev1.OSFamily, versionsBundle *cluster.VersionsBundle, ova releasev1.Archive) string {
+	eksd := versionsBundle.EksD
+	templateName := fmt.Sprintf("%s-%s-%s-%s-%s", osFamily, eksd.KubeVersion, eksd.Name, strings.Join(ova.Arch, "-"), ova.SHA256[:7])
+	return filepath.Join("/", datacenter, defaultTemplatesFolder, templateName)
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -0,0 +1,177 @@
+package vsphere
+
+import (
+	"fmt"
+	"sort"
+
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1beta1 "sigs.k8s.io/cluster-api/api/ipam/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// ipPoolDevice is a machine NIC that gets a static address from a VSphereIPPool.
+type ipPoolDevice struct {
+	NetworkName string
+	IPPool      string
+	Nameservers []string
+}
+
+// machineNetworks returns the networks of the NICs of the machines created from a machine config.
+// Only worker node groups can attach several networks. For machines placed in a failure domain,
+// CAPV replaces the network of the first NIC with the failure domain network.
+func machineNetworks(datacenter v1alpha1.VSphereDatacenterConfigSpec, machineSpec v1alpha1.VSphereMachineConfigSpec, worker bool, failureDomain string) []string {
+	networks := []string{datacenter.Network}
+	if worker && len(machineSpec.Networks) > 0 {
+		networks = append([]string{}, machineSpec.Networks...)
+	}
+
+	if failureDomain == "" {
+		return networks
+	}
+	for _, fd := range datacenter.FailureDomains {
+		if fd.Name == failureDomain && fd.Network != "" {
+			networks[0] = fd.Network
+		}
+	}
+
+	return networks
+}
+
+// ipPoolDevices returns the NICs of the machines created from a machine config with the pool each
+// of them gets its address from. A NIC uses the referenced pool of its network and failure domain.
+// It returns nil when the machine config doesn't reference any pool, the NICs then use DHCP.
+func ipPoolDevices(spec *cluster.Spec, machineSpec v1alpha1.VSphereMachineConfigSpec, networks []string, failureDomain string) ([]ipPoolDevice, error) {
+	if len(machineSpec.IPPoolRefs) == 0 {
+		return nil, nil
+	}
+
+	devices := make([]ipPoolDevice, 0, len(networks))
+	for _, network := range networks {
+		pool := findIPPool(spec, machineSpec, network, failureDomain)
+		if pool == nil {
+			if failureDomain != "" {
+				return nil, fmt.Errorf("no VSphereIPPool referenced for network %s in failure domain %s", network, failureDomain)
+			}
+			return nil, fmt.Errorf("no VSphereIPPool referenced for network %s", network)
+		}
+		devices = append(devices, ipPoolDevice{
+			NetworkName: network,
+			IPPool:      pool.Name,
+			Nameservers: pool.Spec.Nameservers,
+		})
+	}
+
+	return devices, nil
+}
+
+func findIPPool(spec *cluster.Spec, machineSpec v1alpha1.VSphereMachineConfigSpec, network, failureDomain string) *v1alpha1.VSphereIPPool {
+	for _, ref := range machineSpec.IPPoolRefs {
+		pool := spec.VSphereIPPool(ref.Name)
+		if pool == nil {
+			continue
+		}
+		if pool.Spec.Network == network && pool.Spec.FailureDomain == failureDomain {
+			return pool
+		}
+	}
+
+	return nil
+}
+
+// validateIPPools checks every NIC of the machines using static addresses has a pool, that the pools
+// have enough addresses for all the machines using them, including the machines rolled out during an
+// upgrade, and that the control plane endpoint is not one of the pool addresses.
+func (v *Validator) validateIPPools(spec *Spec) error {
+	endpoint := spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host
+	for _, name := range ipPoolNames(spec) {
+		if spec.VSphereIPPool(name).Contains(endpoint) {
+			return fmt.Errorf("control plane endpoint %s can not be inside VSphereIPPool %s", endpoint, name)
+		}
+	}
+
+	if err := validateIPPoolCapacity(spec, nil); err != nil {
+		return err
+	}
+
+	logger.V(5).Info("VSphereIPPools validated")
+	return nil
+}
+
+// ValidateIPPoolAllocations checks the VSphereIPPools have enough free addresses for the machines of the
+// cluster once the addresses allocated to the machines of other clusters sharing them are subtracted.
+// addresses are the IPAddresses of the management cluster.
+func (v *Validator) ValidateIPPoolAllocations(spec *Spec, addresses []ipamv1beta1.IPAddress) error {
+	if err := validateIPPoolCapacity(spec, ipPoolAllocations(addresses, spec.Cluster.Name)); err != nil {
+		return fmt.Errorf("validating ip pools: %v", err)
+	}
+
+	return nil
+}
+
+// ipPoolAllocations counts the addresses allocated from each VSphereIPPool to other clusters than
+// clusterName. The IPAddresses are labeled with the name of the cluster of the machine they belong to.
+func ipPoolAllocations(addresses []ipamv1beta1.IPAddress, clusterName string) map[string]int {
+	allocated := map[string]int{}
+	for _, a := range addresses {
+		ref := a.Spec.PoolRef
+		if ref.APIGroup == nil || *ref.APIGroup != v1alpha1.GroupVersion.Group || ref.Kind != v1alpha1.VSphereIPPoolKind {
+			continue
+		}
+		if a.Labels[clusterv1beta2.ClusterNameLabel] == clusterName {
+			continue
+		}
+		allocated[ref.Name]++
+	}
+
+	return allocated
+}
+
+// validateIPPoolCapacity checks the pools have enough addresses for the machines of the cluster,
+// after the addresses allocated to other clusters.
+func validateIPPoolCapacity(spec *Spec, allocated map[string]int) error {
+	requested := map[string]int{}
+	groups := map[string][]string{}
+	for _, placement := range machinePlacements(spec, nil) {
+		devices, err := ipPoolDevices(spec.Spec, placement.machineConfig.Spec, placement.networks, placement.failureDomain)
+		if err != nil {
+			return fmt.Errorf("%s: %v", placement.name, err)
+		}
+		surge := placement.maxSurge
+		if surge > placement.count {
+			surge = placement.count
+		}
+		for _, device := range devices {
+			requested[device.IPPool] += placement.count + surge
+			groups[device.IPPool] = append(groups[device.IPPool], placement.name)
+		}
+	}
+
+	for _, name := range ipPoolNames(spec) {
+		count, ok := requested[name]
+		if !ok {
+			continue
+		}
+		size := spec.VSphereIPPool(name).Size()
+		if inUse := allocated[name]; inUse > 0 && size-inUse < count {
+			return fmt.Errorf("VSphereIPPool %s has %d addresses and %d of them are allocated to other clusters but %v need %d, including the machines rolled out during upgrades", name, size, inUse, groups[name], count)
+		}
+		if size < count {
+			return fmt.Errorf("VSphereIPPool %s has %d addresses but %v need %d, including the machines rolled out during upgrades", name, size, groups[name], count)
+		}
+	}
+
+	return nil
+}
+
+func ipPoolNames(spec *Spec) []string {
+	names := make([]string, 0, len(spec.VSphereIPPools))
+	for name := range spec.VSphereIPPools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,232 @@
+package vsphere
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1beta1 "sigs.k8s.io/cluster-api/api/ipam/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const ipPoolTestNetwork = "/SDDC-Datacenter/network/sddc-cgw-network-1"
+
+func ipPoolTestPool(name, network, failureDomain, end string) *v1alpha1.VSphereIPPool {
+	return &v1alpha1.VSphereIPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.VSphereIPPoolSpec{
+			Network:       network,
+			FailureDomain: failureDomain,
+			Ranges:        []v1alpha1.VSphereIPRange{{Start: "10.0.0.10", End: end}},
+			Prefix:        24,
+			Gateway:       "10.0.0.1",
+		},
+	}
+}
+
+func givenIPPools(tt *providerTest, pools ...*v1alpha1.VSphereIPPool) {
+	tt.clusterSpec.VSphereIPPools = map[string]*v1alpha1.VSphereIPPool{}
+	refs := make([]v1alpha1.Ref, 0, len(pools))
+	for _, p := range pools {
+		tt.clusterSpec.VSphereIPPools[p.Name] = p
+		refs = append(refs, v1alpha1.Ref{Kind: v1alpha1.VSphereIPPoolKind, Name: p.Name})
+	}
+	for _, mc := range tt.clusterSpec.VSphereMachineConfigs {
+		mc.Spec.IPPoolRefs = refs
+	}
+}
+
+func TestValidatorValidateIPPools(t *testing.T) {
+	tests := []struct {
+		name    string
+		end     string
+		host    string
+		wantErr string
+	}{
+		{
+			name: "enough addresses for count and surge",
+			end:  "10.0.0.21",
+			host: "1.2.3.4",
+		},
+		{
+			name:    "not enough addresses",
+			end:     "10.0.0.20",
+			host:    "1.2.3.4",
+			wantErr: "VSphereIPPool pool has 11 addresses but [control plane etcd worker node group md-0] need 12, including the machines rolled out during upgrades",
+		},
+		{
+			name:    "control plane endpoint inside pool",
+			end:     "10.0.0.21",
+			host:    "10.0.0.15",
+			wantErr: "control plane endpoint 10.0.0.15 can not be inside VSphereIPPool pool",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newProviderTest(t)
+			givenIPPools(tt, ipPoolTestPool("pool", ipPoolTestNetwork, "", tc.end))
+			tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = tc.host
+
+			v := NewValidator(tt.govc, nil)
+			err := v.validateIPPools(NewSpec(tt.clusterSpec))
+			if tc.wantErr != "" {
+				tt.Expect(err).To(MatchError(tc.wantErr))
+				return
+			}
+			tt.Expect(err).To(Succeed())
+		})
+	}
+}
+
+func TestValidatorValidateIPPoolsMissingNetworkPool(t *testing.T) {
+	tt := newProviderTest(t)
+	givenIPPools(tt, ipPoolTestPool("pool", ipPoolTestNetwork, "", "10.0.0.30"))
+	tt.clusterSpec.VSphereMachineConfigs["test-wn"].Spec.Networks = []string{ipPoolTestNetwork, "/SDDC-Datacenter/network/storage"}
+
+	v := NewValidator(tt.govc, nil)
+	tt.Expect(v.validateIPPools(NewSpec(tt.clusterSpec))).To(MatchError(
+		"worker node group md-0: no VSphereIPPool referenced for network /SDDC-Datacenter/network/storage",
+	))
+}
+
+func TestValidatorValidateIPPoolsNoRefs(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "10.0.0.15"
+
+	v := NewValidator(tt.govc, nil)
+	tt.Expect(v.validateIPPools(NewSpec(tt.clusterSpec))).To(Succeed())
+}
+
+func TestValidatorValidateIPPoolsFailureDomain(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.VSphereDatacenter.Spec.FailureDomains = []v1alpha1.FailureDomain{
+		{Name: "fd-1", Network: "/SDDC-Datacenter/network/fd-1"},
+	}
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].FailureDomains = []string{"fd-1"}
+	givenIPPools(tt,
+		ipPoolTestPool("pool", ipPoolTestNetwork, "", "10.0.0.17"),
+		ipPoolTestPool("pool-fd-1", "/SDDC-Datacenter/network/fd-1", "fd-1", "10.0.0.12"),
+	)
+
+	v := NewValidator(tt.govc, nil)
+	tt.Expect(v.validateIPPools(NewSpec(tt.clusterSpec))).To(MatchError(
+		"VSphereIPPool pool-fd-1 has 3 addresses but [worker node group md-0] need 4, including the machines rolled out during upgrades",
+	))
+}
+
+func ipPoolTestAddress(name, pool, clusterName string) ipamv1beta1.IPAddress {
+	return ipamv1beta1.IPAddress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1beta2.ClusterNameLabel: clusterName},
+		},
+		Spec: ipamv1beta1.IPAddressSpec{
+			PoolRef: corev1.TypedLocalObjectReference{
+				APIGroup: ptr.String(v1alpha1.GroupVersion.Group),
+				Kind:     v1alpha1.VSphereIPPoolKind,
+				Name:     pool,
+			},
+		},
+	}
+}
+
+func TestValidatorValidateIPPoolAllocations(t *testing.T) {
+	tests := []struct {
+		name      string
+		addresses []ipamv1beta1.IPAddress
+		wantErr   string
+	}{
+		{
+			name: "addresses of the cluster",
+			addresses: []ipamv1beta1.IPAddress{
+				ipPoolTestAddress("test-cp-1", "pool", "test"),
+				ipPoolTestAddress("test-cp-2", "pool", "test"),
+			},
+		},
+		{
+			name: "addresses of other pools",
+			addresses: []ipamv1beta1.IPAddress{
+				ipPoolTestAddress("other-cp-1", "other-pool", "other"),
+			},
+		},
+		{
+			name: "addresses of other clusters",
+			addresses: []ipamv1beta1.IPAddress{
+				ipPoolTestAddress("test-cp-1", "pool", "test"),
+				ipPoolTestAddress("other-cp-1", "pool", "other"),
+			},
+			wantErr: "validating ip pools: VSphereIPPool pool has 12 addresses and 1 of them are allocated to other clusters but [control plane etcd worker node group md-0] need 12, including the machines rolled out during upgrades",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newProviderTest(t)
+			givenIPPools(tt, ipPoolTestPool("pool", ipPoolTestNetwork, "", "10.0.0.21"))
+
+			v := NewValidator(tt.govc, nil)
+			err := v.ValidateIPPoolAllocations(NewSpec(tt.clusterSpec), tc.addresses)
+			if tc.wantErr != "" {
+				tt.Expect(err).To(MatchError(tc.wantErr))
+				return
+			}
+			tt.Expect(err).To(Succeed())
+		})
+	}
+}
+
+func TestProviderValidateIPPoolAllocations(t *testing.T) {
+	tt := newProviderTest(t)
+	givenIPPools(tt, ipPoolTestPool("pool", ipPoolTestNetwork, "", "10.0.0.21"))
+	tt.kubectl.EXPECT().Get(
+		tt.ctx, "ipaddresses.ipam.cluster.x-k8s.io", tt.managementCluster.KubeconfigFile, &ipamv1beta1.IPAddressList{},
+		&kubernetes.KubectlGetOptions{Namespace: constants.EksaSystemNamespace},
+	).DoAndReturn(func(_ context.Context, _, _ string, obj runtime.Object, _ ...kubernetes.KubectlGetOption) error {
+		obj.(*ipamv1beta1.IPAddressList).Items = []ipamv1beta1.IPAddress{ipPoolTestAddress("other-cp-1", "pool", "other")}
+		return nil
+	})
+
+	err := tt.provider.validateIPPoolAllocations(tt.ctx, NewSpec(tt.clusterSpec), tt.managementCluster.KubeconfigFile)
+	tt.Expect(err).To(MatchError(ContainSubstring("1 of them are allocated to other clusters")))
+}
+
+func TestProviderValidateIPPoolAllocationsNoPools(t *testing.T) {
+	tt := newProviderTest(t)
+
+	tt.Expect(tt.provider.validateIPPoolAllocations(tt.ctx, NewSpec(tt.clusterSpec), tt.managementCluster.KubeconfigFile)).To(Succeed())
+}
+
+func TestProviderValidateIPPoolAllocationsListError(t *testing.T) {
+	tt := newProviderTest(t)
+	givenIPPools(tt, ipPoolTestPool("pool", ipPoolTestNetwork, "", "10.0.0.21"))
+	tt.kubectl.EXPECT().Get(tt.ctx, "ipaddresses.ipam.cluster.x-k8s.io", tt.managementCluster.KubeconfigFile, gomock.Any(), gomock.Any()).Return(errors.New("error"))
+
+	err := tt.provider.validateIPPoolAllocations(tt.ctx, NewSpec(tt.clusterSpec), tt.managementCluster.KubeconfigFile)
+	tt.Expect(err).To(MatchError("listing ipaddresses: error"))
+}
+
+func TestMachineNetworks(t *testing.T) {
+	g := NewWithT(t)
+	datacenter := v1alpha1.VSphereDatacenterConfigSpec{
+		Network: "dc-network",
+		FailureDomains: []v1alpha1.FailureDomain{
+			{Name: "fd-1", Network: "fd-network"},
+		},
+	}
+	machineSpec := v1alpha1.VSphereMachineConfigSpec{Networks: []string{"network-1", "network-2"}}
+
+	g.Expect(machineNetworks(datacenter, machineSpec, false, "")).To(Equal([]string{"dc-network"}))
+	g.Expect(machineNetworks(datacenter, machineSpec, true, "")).To(Equal([]string{"network-1", "network-2"}))
+	g.Expect(machineNetworks(datacenter, machineSpec, true, "fd-1")).To(Equal([]string{"fd-network", "network-2"}))
+	g.Expect(machineSpec.Networks).To(Equal([]string{"network-1", "network-2"}))
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/providers/vsphere (interfaces: ProviderGovcClient,ProviderKubectlClient,IPValidator,VSphereClientBuilder)
+// Source: github.com/aws/eks-anywhere/pkg/providers/vsphere (interfaces: ProviderGovcClient,ProviderKubectlClient,IPValidator,VSphereClientBuilder,TemplateManagerGovcClient,CleanupGovcClient)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -9,12 +9,14 @@ import (
 	reflect "reflect"
 
 	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	kubernetes "github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	executables "github.com/aws/eks-anywhere/pkg/executables"
 	govmomi "github.com/aws/eks-anywhere/pkg/govmomi"
 	types "github.com/aws/eks-anywhere/pkg/types"
 	v1beta1 "github.com/aws/etcdadm-controller/api/v1beta1"
 	gomock "github.com/golang/mock/gomock"
 	v1 "k8s.io/api/core/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 	v1beta2 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	v1beta20 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
@@ -70,6 +72,20 @@ func (mr *MockProviderGovcClientMockRecorder) AddUserToGroup(arg0, arg1, arg2 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToGroup", reflect.TypeOf((*MockProviderGovcClient)(nil).AddUserToGroup), arg0, arg1, arg2)
 }
 
+// ApplyAntiAffinityRule mocks base method.
+func (m *MockProviderGovcClient) ApplyAntiAffinityRule(arg0 context.Context, arg1, arg2 string, arg3 executables.AntiAffinityRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyAntiAffinityRule", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyAntiAffinityRule indicates an expected call of ApplyAntiAffinityRule.
+func (mr *MockProviderGovcClientMockRecorder) ApplyAntiAffinityRule(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyAntiAffinityRule", reflect.TypeOf((*MockProviderGovcClient)(nil).ApplyAntiAffinityRule), arg0, arg1, arg2, arg3)
+}
+
 // ConfigureCertThumbprint mocks base method.
 func (m *MockProviderGovcClient) ConfigureCertThumbprint(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -301,6 +317,36 @@ func (mr *MockProviderGovcClientMockRecorder) GetLibraryElementContentVersion(ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLibraryElementContentVersion", reflect.TypeOf((*MockProviderGovcClient)(nil).GetLibraryElementContentVersion), arg0, arg1)
 }
 
+// GetResourcePoolCPUThreads mocks base method.
+func (m *MockProviderGovcClient) GetResourcePoolCPUThreads(arg0 context.Context, arg1, arg2 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourcePoolCPUThreads", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourcePoolCPUThreads indicates an expected call of GetResourcePoolCPUThreads.
+func (mr *MockProviderGovcClientMockRecorder) GetResourcePoolCPUThreads(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcePoolCPUThreads", reflect.TypeOf((*MockProviderGovcClient)(nil).GetResourcePoolCPUThreads), arg0, arg1, arg2)
+}
+
+// GetResourcePoolHostCount mocks base method.
+func (m *MockProviderGovcClient) GetResourcePoolHostCount(arg0 context.Context, arg1, arg2 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourcePoolHostCount", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourcePoolHostCount indicates an expected call of GetResourcePoolHostCount.
+func (mr *MockProviderGovcClientMockRecorder) GetResourcePoolHostCount(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcePoolHostCount", reflect.TypeOf((*MockProviderGovcClient)(nil).GetResourcePoolHostCount), arg0, arg1, arg2)
+}
+
 // GetResourcePoolInfo mocks base method.
 func (m *MockProviderGovcClient) GetResourcePoolInfo(arg0 context.Context, arg1, arg2 string, arg3 ...string) (map[string]int, error) {
 	m.ctrl.T.Helper()
@@ -454,6 +500,36 @@ func (mr *MockProviderGovcClientMockRecorder) ListCategories(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockProviderGovcClient)(nil).ListCategories), arg0)
 }
 
+// ListDRSGroups mocks base method.
+func (m *MockProviderGovcClient) ListDRSGroups(arg0 context.Context, arg1, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDRSGroups", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDRSGroups indicates an expected call of ListDRSGroups.
+func (mr *MockProviderGovcClientMockRecorder) ListDRSGroups(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDRSGroups", reflect.TypeOf((*MockProviderGovcClient)(nil).ListDRSGroups), arg0, arg1, arg2)
+}
+
+// ListDRSRules mocks base method.
+func (m *MockProviderGovcClient) ListDRSRules(arg0 context.Context, arg1, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDRSRules", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDRSRules indicates an expected call of ListDRSRules.
+func (mr *MockProviderGovcClientMockRecorder) ListDRSRules(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDRSRules", reflect.TypeOf((*MockProviderGovcClient)(nil).ListDRSRules), arg0, arg1, arg2)
+}
+
 // ListTags mocks base method.
 func (m *MockProviderGovcClient) ListTags(arg0 context.Context) ([]executables.Tag, error) {
 	m.ctrl.T.Helper()
@@ -484,6 +560,34 @@ func (mr *MockProviderGovcClientMockRecorder) NetworkExists(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkExists", reflect.TypeOf((*MockProviderGovcClient)(nil).NetworkExists), arg0, arg1)
 }
 
+// RemoveDRSGroup mocks base method.
+func (m *MockProviderGovcClient) RemoveDRSGroup(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDRSGroup", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDRSGroup indicates an expected call of RemoveDRSGroup.
+func (mr *MockProviderGovcClientMockRecorder) RemoveDRSGroup(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDRSGroup", reflect.TypeOf((*MockProviderGovcClient)(nil).RemoveDRSGroup), arg0, arg1, arg2, arg3)
+}
+
+// RemoveDRSRule mocks base method.
+func (m *MockProviderGovcClient) RemoveDRSRule(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDRSRule", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDRSRule indicates an expected call of RemoveDRSRule.
+func (mr *MockProviderGovcClientMockRecorder) RemoveDRSRule(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDRSRule", reflect.TypeOf((*MockProviderGovcClient)(nil).RemoveDRSRule), arg0, arg1, arg2, arg3)
+}
+
 // RoleExists mocks base method.
 func (m *MockProviderGovcClient) RoleExists(arg0 context.Context, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -707,6 +811,25 @@ func (mr *MockProviderKubectlClientMockRecorder) DeleteEksaMachineConfig(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEksaMachineConfig", reflect.TypeOf((*MockProviderKubectlClient)(nil).DeleteEksaMachineConfig), arg0, arg1, arg2, arg3, arg4)
 }
 
+// Get mocks base method.
+func (m *MockProviderKubectlClient) Get(arg0 context.Context, arg1, arg2 string, arg3 runtime.Object, arg4 ...kubernetes.KubectlGetOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockProviderKubectlClientMockRecorder) Get(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProviderKubectlClient)(nil).Get), varargs...)
+}
+
 // GetEksaCluster mocks base method.
 func (m *MockProviderKubectlClient) GetEksaCluster(arg0 context.Context, arg1 *types.Cluster, arg2 string) (*v1alpha1.Cluster, error) {
 	m.ctrl.T.Helper()
@@ -992,3 +1115,839 @@ func (mr *MockVSphereClientBuilderMockRecorder) Build(arg0, arg1, arg2, arg3, ar
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockVSphereClientBuilder)(nil).Build), arg0, arg1, arg2, arg3, arg4, arg5)
 }
+
+// MockTemplateManagerGovcClient is a mock of TemplateManagerGovcClient interface.
+type MockTemplateManagerGovcClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplateManagerGovcClientMockRecorder
+}
+
+// MockTemplateManagerGovcClientMockRecorder is the mock recorder for MockTemplateManagerGovcClient.
+type MockTemplateManagerGovcClientMockRecorder struct {
+	mock *MockTemplateManagerGovcClient
+}
+
+// NewMockTemplateManagerGovcClient creates a new mock instance.
+func NewMockTemplateManagerGovcClient(ctrl *gomock.Controller) *MockTemplateManagerGovcClient {
+	mock := &MockTemplateManagerGovcClient{ctrl: ctrl}
+	mock.recorder = &MockTemplateManagerGovcClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplateManagerGovcClient) EXPECT() *MockTemplateManagerGovcClientMockRecorder {
+	return m.recorder
+}
+
+// AddTag mocks base method.
+func (m *MockTemplateManagerGovcClient) AddTag(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTag", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTag indicates an expected call of AddTag.
+func (mr *MockTemplateManagerGovcClientMockRecorder) AddTag(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTag", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).AddTag), arg0, arg1, arg2)
+}
+
+// AddUserToGroup mocks base method.
+func (m *MockTemplateManagerGovcClient) AddUserToGroup(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserToGroup", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUserToGroup indicates an expected call of AddUserToGroup.
+func (mr *MockTemplateManagerGovcClientMockRecorder) AddUserToGroup(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToGroup", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).AddUserToGroup), arg0, arg1, arg2)
+}
+
+// ApplyAntiAffinityRule mocks base method.
+func (m *MockTemplateManagerGovcClient) ApplyAntiAffinityRule(arg0 context.Context, arg1, arg2 string, arg3 executables.AntiAffinityRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyAntiAffinityRule", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyAntiAffinityRule indicates an expected call of ApplyAntiAffinityRule.
+func (mr *MockTemplateManagerGovcClientMockRecorder) ApplyAntiAffinityRule(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyAntiAffinityRule", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).ApplyAntiAffinityRule), arg0, arg1, arg2, arg3)
+}
+
+// ConfigureCertThumbprint mocks base method.
+func (m *MockTemplateManagerGovcClient) ConfigureCertThumbprint(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigureCertThumbprint", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfigureCertThumbprint indicates an expected call of ConfigureCertThumbprint.
+func (mr *MockTemplateManagerGovcClientMockRecorder) ConfigureCertThumbprint(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureCertThumbprint", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).ConfigureCertThumbprint), arg0, arg1, arg2)
+}
+
+// CreateCategoryForVM mocks base method.
+func (m *MockTemplateManagerGovcClient) CreateCategoryForVM(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategoryForVM", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCategoryForVM indicates an expected call of CreateCategoryForVM.
+func (mr *MockTemplateManagerGovcClientMockRecorder) CreateCategoryForVM(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategoryForVM", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).CreateCategoryForVM), arg0, arg1)
+}
+
+// CreateGroup mocks base method.
+func (m *MockTemplateManagerGovcClient) CreateGroup(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateGroup indicates an expected call of CreateGroup.
+func (mr *MockTemplateManagerGovcClientMockRecorder) CreateGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).CreateGroup), arg0, arg1)
+}
+
+// CreateLibrary mocks base method.
+func (m *MockTemplateManagerGovcClient) CreateLibrary(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLibrary", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLibrary indicates an expected call of CreateLibrary.
+func (mr *MockTemplateManagerGovcClientMockRecorder) CreateLibrary(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLibrary", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).CreateLibrary), arg0, arg1, arg2)
+}
+
+// CreateRole mocks base method.
+func (m *MockTemplateManagerGovcClient) CreateRole(arg0 context.Context, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockTemplateManagerGovcClientMockRecorder) CreateRole(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).CreateRole), arg0, arg1, arg2)
+}
+
+// CreateTag mocks base method.
+func (m *MockTemplateManagerGovcClient) CreateTag(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockTemplateManagerGovcClientMockRecorder) CreateTag(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).CreateTag), arg0, arg1, arg2)
+}
+
+// CreateUser mocks base method.
+func (m *MockTemplateManagerGovcClient) CreateUser(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockTemplateManagerGovcClientMockRecorder) CreateUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).CreateUser), arg0, arg1, arg2)
+}
+
+// DatacenterExists mocks base method.
+func (m *MockTemplateManagerGovcClient) DatacenterExists(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DatacenterExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DatacenterExists indicates an expected call of DatacenterExists.
+func (mr *MockTemplateManagerGovcClientMockRecorder) DatacenterExists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DatacenterExists", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).DatacenterExists), arg0, arg1)
+}
+
+// DeleteLibraryElement mocks base method.
+func (m *MockTemplateManagerGovcClient) DeleteLibraryElement(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLibraryElement", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLibraryElement indicates an expected call of DeleteLibraryElement.
+func (mr *MockTemplateManagerGovcClientMockRecorder) DeleteLibraryElement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLibraryElement", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).DeleteLibraryElement), arg0, arg1)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockTemplateManagerGovcClient) DeleteTemplate(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockTemplateManagerGovcClientMockRecorder) DeleteTemplate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).DeleteTemplate), arg0, arg1, arg2)
+}
+
+// DeployTemplateFromLibrary mocks base method.
+func (m *MockTemplateManagerGovcClient) DeployTemplateFromLibrary(arg0 context.Context, arg1, arg2, arg3, arg4, arg5, arg6, arg7 string, arg8 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeployTemplateFromLibrary", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeployTemplateFromLibrary indicates an expected call of DeployTemplateFromLibrary.
+func (mr *MockTemplateManagerGovcClientMockRecorder) DeployTemplateFromLibrary(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployTemplateFromLibrary", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).DeployTemplateFromLibrary), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+}
+
+// GetCertThumbprint mocks base method.
+func (m *MockTemplateManagerGovcClient) GetCertThumbprint(arg0 context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCertThumbprint", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCertThumbprint indicates an expected call of GetCertThumbprint.
+func (mr *MockTemplateManagerGovcClientMockRecorder) GetCertThumbprint(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertThumbprint", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).GetCertThumbprint), arg0)
+}
+
+// GetComputeClusterPath mocks base method.
+func (m *MockTemplateManagerGovcClient) GetComputeClusterPath(arg0 context.Context, arg1, arg2 string, arg3 map[string]string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComputeClusterPath", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComputeClusterPath indicates an expected call of GetComputeClusterPath.
+func (mr *MockTemplateManagerGovcClientMockRecorder) GetComputeClusterPath(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComputeClusterPath", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).GetComputeClusterPath), arg0, arg1, arg2, arg3)
+}
+
+// GetDatastorePath mocks base method.
+func (m *MockTemplateManagerGovcClient) GetDatastorePath(arg0 context.Context, arg1, arg2 string, arg3 map[string]string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatastorePath", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatastorePath indicates an expected call of GetDatastorePath.
+func (mr *MockTemplateManagerGovcClientMockRecorder) GetDatastorePath(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatastorePath", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).GetDatastorePath), arg0, arg1, arg2, arg3)
+}
+
+// GetFolderPath mocks base method.
+func (m *MockTemplateManagerGovcClient) GetFolderPath(arg0 context.Context, arg1, arg2 string, arg3 map[string]string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFolderPath", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFolderPath indicates an expected call of GetFolderPath.
+func (mr *MockTemplateManagerGovcClientMockRecorder) GetFolderPath(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolderPath", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).GetFolderPath), arg0, arg1, arg2, arg3)
+}
+
+// GetHardDiskSize mocks base method.
+func (m *MockTemplateManagerGovcClient) GetHardDiskSize(arg0 context.Context, arg1, arg2 string) (map[string]float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHardDiskSize", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHardDiskSize indicates an expected call of GetHardDiskSize.
+func (mr *MockTemplateManagerGovcClientMockRecorder) GetHardDiskSize(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHardDiskSize", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).GetHardDiskSize), arg0, arg1, arg2)
+}
+
+// GetLibraryElementContentVersion mocks base method.
+func (m *MockTemplateManagerGovcClient) GetLibraryElementContentVersion(arg0 context.Context, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLibraryElementContentVersion", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLibraryElementContentVersion indicates an expected call of GetLibraryElementContentVersion.
+func (mr *MockTemplateManagerGovcClientMockRecorder) GetLibraryElementContentVersion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLibraryElementContentVersion", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).GetLibraryElementContentVersion), arg0, arg1)
+}
+
+// GetResourcePoolCPUThreads mocks base method.
+func (m *MockTemplateManagerGovcClient) GetResourcePoolCPUThreads(arg0 context.Context, arg1, arg2 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourcePoolCPUThreads", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourcePoolCPUThreads indicates an expected call of GetResourcePoolCPUThreads.
+func (mr *MockTemplateManagerGovcClientMockRecorder) GetResourcePoolCPUThreads(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcePoolCPUThreads", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).GetResourcePoolCPUThreads), arg0, arg1, arg2)
+}
+
+// GetResourcePoolHostCount mocks base method.
+func (m *MockTemplateManagerGovcClient) GetResourcePoolHostCount(arg0 context.Context, arg1, arg2 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourcePoolHostCount", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourcePoolHostCount indicates an expected call of GetResourcePoolHostCount.
+func (mr *MockTemplateManagerGovcClientMockRecorder) GetResourcePoolHostCount(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcePoolHostCount", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).GetResourcePoolHostCount), arg0, arg1, arg2)
+}
+
+// GetResourcePoolInfo mocks base method.
+func (m *MockTemplateManagerGovcClient) GetResourcePoolInfo(arg0 context.Context, arg1, arg2 string, arg3 ...string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetResourcePoolInfo", varargs...)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourcePoolInfo indicates an expected call of GetResourcePoolInfo.
+func (mr *MockTemplateManagerGovcClientMockRecorder) GetResourcePoolInfo(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcePoolInfo", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).GetResourcePoolInfo), varargs...)
+}
+
+// GetResourcePoolPath mocks base method.
+func (m *MockTemplateManagerGovcClient) GetResourcePoolPath(arg0 context.Context, arg1, arg2 string, arg3 map[string]string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourcePoolPath", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourcePoolPath indicates an expected call of GetResourcePoolPath.
+func (mr *MockTemplateManagerGovcClientMockRecorder) GetResourcePoolPath(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcePoolPath", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).GetResourcePoolPath), arg0, arg1, arg2, arg3)
+}
+
+// GetTags mocks base method.
+func (m *MockTemplateManagerGovcClient) GetTags(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockTemplateManagerGovcClientMockRecorder) GetTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).GetTags), arg0, arg1)
+}
+
+// GetVMDiskSizeInGB mocks base method.
+func (m *MockTemplateManagerGovcClient) GetVMDiskSizeInGB(arg0 context.Context, arg1, arg2 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVMDiskSizeInGB", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVMDiskSizeInGB indicates an expected call of GetVMDiskSizeInGB.
+func (mr *MockTemplateManagerGovcClientMockRecorder) GetVMDiskSizeInGB(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVMDiskSizeInGB", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).GetVMDiskSizeInGB), arg0, arg1, arg2)
+}
+
+// GetWorkloadAvailableSpace mocks base method.
+func (m *MockTemplateManagerGovcClient) GetWorkloadAvailableSpace(arg0 context.Context, arg1 string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkloadAvailableSpace", arg0, arg1)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkloadAvailableSpace indicates an expected call of GetWorkloadAvailableSpace.
+func (mr *MockTemplateManagerGovcClientMockRecorder) GetWorkloadAvailableSpace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkloadAvailableSpace", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).GetWorkloadAvailableSpace), arg0, arg1)
+}
+
+// GroupExists mocks base method.
+func (m *MockTemplateManagerGovcClient) GroupExists(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GroupExists indicates an expected call of GroupExists.
+func (mr *MockTemplateManagerGovcClientMockRecorder) GroupExists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupExists", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).GroupExists), arg0, arg1)
+}
+
+// ImportTemplate mocks base method.
+func (m *MockTemplateManagerGovcClient) ImportTemplate(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTemplate", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportTemplate indicates an expected call of ImportTemplate.
+func (mr *MockTemplateManagerGovcClientMockRecorder) ImportTemplate(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTemplate", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).ImportTemplate), arg0, arg1, arg2, arg3)
+}
+
+// IsCertSelfSigned mocks base method.
+func (m *MockTemplateManagerGovcClient) IsCertSelfSigned(arg0 context.Context) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCertSelfSigned", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsCertSelfSigned indicates an expected call of IsCertSelfSigned.
+func (mr *MockTemplateManagerGovcClientMockRecorder) IsCertSelfSigned(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCertSelfSigned", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).IsCertSelfSigned), arg0)
+}
+
+// LibraryElementExists mocks base method.
+func (m *MockTemplateManagerGovcClient) LibraryElementExists(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LibraryElementExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LibraryElementExists indicates an expected call of LibraryElementExists.
+func (mr *MockTemplateManagerGovcClientMockRecorder) LibraryElementExists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LibraryElementExists", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).LibraryElementExists), arg0, arg1)
+}
+
+// ListCategories mocks base method.
+func (m *MockTemplateManagerGovcClient) ListCategories(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockTemplateManagerGovcClientMockRecorder) ListCategories(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).ListCategories), arg0)
+}
+
+// ListDRSGroups mocks base method.
+func (m *MockTemplateManagerGovcClient) ListDRSGroups(arg0 context.Context, arg1, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDRSGroups", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDRSGroups indicates an expected call of ListDRSGroups.
+func (mr *MockTemplateManagerGovcClientMockRecorder) ListDRSGroups(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDRSGroups", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).ListDRSGroups), arg0, arg1, arg2)
+}
+
+// ListDRSRules mocks base method.
+func (m *MockTemplateManagerGovcClient) ListDRSRules(arg0 context.Context, arg1, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDRSRules", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDRSRules indicates an expected call of ListDRSRules.
+func (mr *MockTemplateManagerGovcClientMockRecorder) ListDRSRules(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDRSRules", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).ListDRSRules), arg0, arg1, arg2)
+}
+
+// ListTags mocks base method.
+func (m *MockTemplateManagerGovcClient) ListTags(arg0 context.Context) ([]executables.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", arg0)
+	ret0, _ := ret[0].([]executables.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockTemplateManagerGovcClientMockRecorder) ListTags(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).ListTags), arg0)
+}
+
+// ListTemplates mocks base method.
+func (m *MockTemplateManagerGovcClient) ListTemplates(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockTemplateManagerGovcClientMockRecorder) ListTemplates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).ListTemplates), arg0, arg1)
+}
+
+// NetworkExists mocks base method.
+func (m *MockTemplateManagerGovcClient) NetworkExists(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetworkExists indicates an expected call of NetworkExists.
+func (mr *MockTemplateManagerGovcClientMockRecorder) NetworkExists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkExists", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).NetworkExists), arg0, arg1)
+}
+
+// RemoveDRSGroup mocks base method.
+func (m *MockTemplateManagerGovcClient) RemoveDRSGroup(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDRSGroup", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDRSGroup indicates an expected call of RemoveDRSGroup.
+func (mr *MockTemplateManagerGovcClientMockRecorder) RemoveDRSGroup(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDRSGroup", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).RemoveDRSGroup), arg0, arg1, arg2, arg3)
+}
+
+// RemoveDRSRule mocks base method.
+func (m *MockTemplateManagerGovcClient) RemoveDRSRule(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDRSRule", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDRSRule indicates an expected call of RemoveDRSRule.
+func (mr *MockTemplateManagerGovcClientMockRecorder) RemoveDRSRule(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDRSRule", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).RemoveDRSRule), arg0, arg1, arg2, arg3)
+}
+
+// RoleExists mocks base method.
+func (m *MockTemplateManagerGovcClient) RoleExists(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoleExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RoleExists indicates an expected call of RoleExists.
+func (mr *MockTemplateManagerGovcClientMockRecorder) RoleExists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoleExists", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).RoleExists), arg0, arg1)
+}
+
+// SearchTemplate mocks base method.
+func (m *MockTemplateManagerGovcClient) SearchTemplate(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTemplate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTemplate indicates an expected call of SearchTemplate.
+func (mr *MockTemplateManagerGovcClientMockRecorder) SearchTemplate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTemplate", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).SearchTemplate), arg0, arg1, arg2)
+}
+
+// SetGroupRoleOnObject mocks base method.
+func (m *MockTemplateManagerGovcClient) SetGroupRoleOnObject(arg0 context.Context, arg1, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGroupRoleOnObject", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetGroupRoleOnObject indicates an expected call of SetGroupRoleOnObject.
+func (mr *MockTemplateManagerGovcClientMockRecorder) SetGroupRoleOnObject(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGroupRoleOnObject", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).SetGroupRoleOnObject), arg0, arg1, arg2, arg3, arg4)
+}
+
+// TemplateHasSnapshot mocks base method.
+func (m *MockTemplateManagerGovcClient) TemplateHasSnapshot(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplateHasSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TemplateHasSnapshot indicates an expected call of TemplateHasSnapshot.
+func (mr *MockTemplateManagerGovcClientMockRecorder) TemplateHasSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateHasSnapshot", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).TemplateHasSnapshot), arg0, arg1)
+}
+
+// UserExists mocks base method.
+func (m *MockTemplateManagerGovcClient) UserExists(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserExists indicates an expected call of UserExists.
+func (mr *MockTemplateManagerGovcClientMockRecorder) UserExists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserExists", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).UserExists), arg0, arg1)
+}
+
+// ValidateFailureDomainConfig mocks base method.
+func (m *MockTemplateManagerGovcClient) ValidateFailureDomainConfig(arg0 context.Context, arg1 *v1alpha1.VSphereDatacenterConfig, arg2 *v1alpha1.FailureDomain) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateFailureDomainConfig", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateFailureDomainConfig indicates an expected call of ValidateFailureDomainConfig.
+func (mr *MockTemplateManagerGovcClientMockRecorder) ValidateFailureDomainConfig(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateFailureDomainConfig", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).ValidateFailureDomainConfig), arg0, arg1, arg2)
+}
+
+// ValidateVCenterAuthentication mocks base method.
+func (m *MockTemplateManagerGovcClient) ValidateVCenterAuthentication(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateVCenterAuthentication", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateVCenterAuthentication indicates an expected call of ValidateVCenterAuthentication.
+func (mr *MockTemplateManagerGovcClientMockRecorder) ValidateVCenterAuthentication(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateVCenterAuthentication", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).ValidateVCenterAuthentication), arg0)
+}
+
+// ValidateVCenterConnection mocks base method.
+func (m *MockTemplateManagerGovcClient) ValidateVCenterConnection(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateVCenterConnection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateVCenterConnection indicates an expected call of ValidateVCenterConnection.
+func (mr *MockTemplateManagerGovcClientMockRecorder) ValidateVCenterConnection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateVCenterConnection", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).ValidateVCenterConnection), arg0, arg1)
+}
+
+// ValidateVCenterSetupMachineConfig mocks base method.
+func (m *MockTemplateManagerGovcClient) ValidateVCenterSetupMachineConfig(arg0 context.Context, arg1 *v1alpha1.VSphereDatacenterConfig, arg2 *v1alpha1.VSphereMachineConfig, arg3 *bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateVCenterSetupMachineConfig", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateVCenterSetupMachineConfig indicates an expected call of ValidateVCenterSetupMachineConfig.
+func (mr *MockTemplateManagerGovcClientMockRecorder) ValidateVCenterSetupMachineConfig(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateVCenterSetupMachineConfig", reflect.TypeOf((*MockTemplateManagerGovcClient)(nil).ValidateVCenterSetupMachineConfig), arg0, arg1, arg2, arg3)
+}
+
+// MockCleanupGovcClient is a mock of CleanupGovcClient interface.
+type MockCleanupGovcClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCleanupGovcClientMockRecorder
+}
+
+// MockCleanupGovcClientMockRecorder is the mock recorder for MockCleanupGovcClient.
+type MockCleanupGovcClientMockRecorder struct {
+	mock *MockCleanupGovcClient
+}
+
+// NewMockCleanupGovcClient creates a new mock instance.
+func NewMockCleanupGovcClient(ctrl *gomock.Controller) *MockCleanupGovcClient {
+	mock := &MockCleanupGovcClient{ctrl: ctrl}
+	mock.recorder = &MockCleanupGovcClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCleanupGovcClient) EXPECT() *MockCleanupGovcClientMockRecorder {
+	return m.recorder
+}
+
+// DestroyVM mocks base method.
+func (m *MockCleanupGovcClient) DestroyVM(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyVM", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyVM indicates an expected call of DestroyVM.
+func (mr *MockCleanupGovcClientMockRecorder) DestroyVM(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyVM", reflect.TypeOf((*MockCleanupGovcClient)(nil).DestroyVM), arg0, arg1)
+}
+
+// ListDRSGroups mocks base method.
+func (m *MockCleanupGovcClient) ListDRSGroups(arg0 context.Context, arg1, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDRSGroups", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDRSGroups indicates an expected call of ListDRSGroups.
+func (mr *MockCleanupGovcClientMockRecorder) ListDRSGroups(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDRSGroups", reflect.TypeOf((*MockCleanupGovcClient)(nil).ListDRSGroups), arg0, arg1, arg2)
+}
+
+// ListDRSRules mocks base method.
+func (m *MockCleanupGovcClient) ListDRSRules(arg0 context.Context, arg1, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDRSRules", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDRSRules indicates an expected call of ListDRSRules.
+func (mr *MockCleanupGovcClientMockRecorder) ListDRSRules(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDRSRules", reflect.TypeOf((*MockCleanupGovcClient)(nil).ListDRSRules), arg0, arg1, arg2)
+}
+
+// ListVMs mocks base method.
+func (m *MockCleanupGovcClient) ListVMs(arg0 context.Context, arg1, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVMs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVMs indicates an expected call of ListVMs.
+func (mr *MockCleanupGovcClientMockRecorder) ListVMs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVMs", reflect.TypeOf((*MockCleanupGovcClient)(nil).ListVMs), arg0, arg1, arg2)
+}
+
+// RemoveDRSGroup mocks base method.
+func (m *MockCleanupGovcClient) RemoveDRSGroup(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDRSGroup", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDRSGroup indicates an expected call of RemoveDRSGroup.
+func (mr *MockCleanupGovcClientMockRecorder) RemoveDRSGroup(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDRSGroup", reflect.TypeOf((*MockCleanupGovcClient)(nil).RemoveDRSGroup), arg0, arg1, arg2, arg3)
+}
+
+// RemoveDRSRule mocks base method.
+func (m *MockCleanupGovcClient) RemoveDRSRule(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDRSRule", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDRSRule indicates an expected call of RemoveDRSRule.
+func (mr *MockCleanupGovcClientMockRecorder) RemoveDRSRule(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDRSRule", reflect.TypeOf((*MockCleanupGovcClient)(nil).RemoveDRSRule), arg0, arg1, arg2, arg3)
+}
@@ -8,9 +8,11 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
 	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/v1beta1"
+	ipamv1beta1 "sigs.k8s.io/cluster-api/api/ipam/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -18,6 +20,7 @@ import (
 	c "github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/collection"
 	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
 	"github.com/aws/eks-anywhere/pkg/controller/clusters"
@@ -47,11 +50,12 @@ type Reconciler struct {
 	cniReconciler        CNIReconciler
 	remoteClientRegistry RemoteClientRegistry
 	ipValidator          IPValidator
+	antiAffinity         *vsphere.AntiAffinity
 	*serverside.ObjectApplier
 }
 
 // New defines a new VSphere reconciler.
-func New(client client.Client, validator *vsphere.Validator, defaulter *vsphere.Defaulter, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, ipValidator IPValidator) *Reconciler {
+func New(client client.Client, validator *vsphere.Validator, defaulter *vsphere.Defaulter, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, ipValidator IPValidator, antiAffinity *vsphere.AntiAffinity) *Reconciler {
 	return &Reconciler{
 		client:               client,
 		validator:            validator,
@@ -59,6 +63,7 @@ func New(client client.Client, validator *vsphere.Validator, defaulter *vsphere.
 		cniReconciler:        cniReconciler,
 		remoteClientRegistry: remoteClientRegistry,
 		ipValidator:          ipValidator,
+		antiAffinity:         antiAffinity,
 		ObjectApplier:        serverside.NewObjectApplier(client),
 	}
 }
@@ -128,6 +133,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *an
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
 		r.ReconcileWorkers,
+		r.ReconcileAntiAffinity,
 	).Run(ctx, log, clusterSpec)
 }
 
@@ -170,6 +176,18 @@ func (r *Reconciler) ValidateMachineConfigs(ctx context.Context, log logr.Logger
 		clusterSpec.Cluster.SetFailure(anywherev1.MachineConfigInvalidReason, failureMessage)
 		return controller.ResultWithReturn(), nil
 	}
+
+	if len(vsphereClusterSpec.VSphereIPPools) > 0 {
+		addresses := &ipamv1beta1.IPAddressList{}
+		if err := r.client.List(ctx, addresses, client.InNamespace(constants.EksaSystemNamespace)); err != nil {
+			return controller.Result{}, fmt.Errorf("listing ipaddresses: %v", err)
+		}
+		if err := r.validator.ValidateIPPoolAllocations(vsphereClusterSpec, addresses.Items); err != nil {
+			log.Error(err, "Invalid VSphereMachineConfig")
+			clusterSpec.Cluster.SetFailure(anywherev1.MachineConfigInvalidReason, err.Error())
+			return controller.ResultWithReturn(), nil
+		}
+	}
 	return controller.Result{}, nil
 }
 
@@ -279,6 +297,44 @@ func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, spec
 	return clusters.ReconcileWorkersForEKSA(ctx, log, r.client, spec.Cluster, clusters.ToWorkers(w))
 }
 
+// ReconcileAntiAffinity applies the DRS anti-affinity rules of the machine groups that request them.
+// The cluster is reconciled again while it's not ready, which keeps the rules in sync as machines roll out.
+func (r *Reconciler) ReconcileAntiAffinity(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileAntiAffinity")
+	if err := r.antiAffinity.Reconcile(ctx, log, r.client, spec); err != nil {
+		return controller.Result{}, err
+	}
+
+	return controller.Result{}, nil
+}
+
+// ReconcileDelete removes the DRS rules and groups created for the cluster machine groups once its machines are gone.
+// Clusters without DRS rules are skipped.
+func (r *Reconciler) ReconcileDelete(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error {
+	if !vsphere.HasDRSObjects(cluster) {
+		return nil
+	}
+	log = log.WithValues("provider", "vsphere", "phase", "reconcileDelete")
+
+	datacenterConfig := &anywherev1.VSphereDatacenterConfig{}
+	key := client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.DatacenterRef.Name}
+	if err := r.client.Get(ctx, key, datacenterConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := SetupEnvVars(ctx, datacenterConfig, r.client); err != nil {
+		return err
+	}
+
+	if err := r.antiAffinity.Delete(ctx, log, cluster, datacenterConfig.Spec.Datacenter); err != nil {
+		return errors.Wrap(err, "deleting DRS rules")
+	}
+	return nil
+}
+
 func toClientControlPlane(cp *vsphere.ControlPlane) *clusters.ControlPlane {
 	other := make([]client.Object, 0, len(cp.ConfigMaps)+len(cp.Secrets)+len(cp.ClusterResourceSets)+1)
 	for _, o := range cp.ClusterResourceSets {
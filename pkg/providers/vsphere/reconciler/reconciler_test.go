@@ -336,6 +336,27 @@ func TestReconcileCNIErrorClientRegistry(t *testing.T) {
 	tt.Expect(result).To(Equal(controller.Result{}))
 }
 
+func TestReconcilerReconcileDeleteNoDRSObjects(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	tt.Expect(tt.reconciler().ReconcileDelete(tt.ctx, test.NewNullLogger(), tt.cluster)).To(Succeed())
+}
+
+func TestReconcilerReconcileDeleteDRSObjects(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Annotations = map[string]string{
+		vsphere.DRSObjectsAnnotation: `[{"resourcePool":"/pool","name":"rule"}]`,
+	}
+	tt.withFakeClient()
+	datacenter := tt.datacenterConfig.Spec.Datacenter
+
+	tt.govcClient.EXPECT().ListDRSRules(tt.ctx, datacenter, "/pool").Return([]string{"rule"}, nil)
+	tt.govcClient.EXPECT().RemoveDRSRule(tt.ctx, datacenter, "/pool", "rule").Return(errors.New("removing rule"))
+
+	tt.Expect(tt.reconciler().ReconcileDelete(tt.ctx, test.NewNullLogger(), tt.cluster)).To(MatchError("deleting DRS rules: removing rule"))
+}
+
 func TestReconcilerReconcileControlPlaneSuccess(t *testing.T) {
 	tt := newReconcilerTest(t)
 	tt.createAllObjs()
@@ -393,6 +414,7 @@ type reconcilerTest struct {
 	govcClient                *mocks.MockProviderGovcClient
 	validator                 *vsphere.Validator
 	defaulter                 *vsphere.Defaulter
+	antiAffinity              *vsphere.AntiAffinity
 	remoteClientRegistry      *vspherereconcilermocks.MockRemoteClientRegistry
 	cluster                   *anywherev1.Cluster
 	client                    client.Client
@@ -526,6 +548,7 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 		govcClient:           govcClient,
 		validator:            validator,
 		defaulter:            defaulter,
+		antiAffinity:         vsphere.NewAntiAffinity(govcClient),
 		ipValidator:          ipValidator,
 		remoteClientRegistry: remoteClientRegistry,
 		client:               c,
@@ -574,7 +597,7 @@ func (tt *reconcilerTest) withFakeClient() {
 }
 
 func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
-	return reconciler.New(tt.client, tt.validator, tt.defaulter, tt.cniReconciler, tt.remoteClientRegistry, tt.ipValidator)
+	return reconciler.New(tt.client, tt.validator, tt.defaulter, tt.cniReconciler, tt.remoteClientRegistry, tt.ipValidator, tt.antiAffinity)
 }
 
 func (tt *reconcilerTest) createAllObjs() {
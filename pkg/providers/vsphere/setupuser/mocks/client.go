@@ -34,6 +34,20 @@ func (m *MockGovcClient) EXPECT() *MockGovcClientMockRecorder {
 	return m.recorder
 }
 
+// AddRolePrivileges mocks base method.
+func (m *MockGovcClient) AddRolePrivileges(arg0 context.Context, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRolePrivileges", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRolePrivileges indicates an expected call of AddRolePrivileges.
+func (mr *MockGovcClientMockRecorder) AddRolePrivileges(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRolePrivileges", reflect.TypeOf((*MockGovcClient)(nil).AddRolePrivileges), arg0, arg1, arg2)
+}
+
 // AddUserToGroup mocks base method.
 func (m *MockGovcClient) AddUserToGroup(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -90,6 +104,36 @@ func (mr *MockGovcClientMockRecorder) CreateUser(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockGovcClient)(nil).CreateUser), arg0, arg1, arg2)
 }
 
+// GetGroupRolesOnObject mocks base method.
+func (m *MockGovcClient) GetGroupRolesOnObject(arg0 context.Context, arg1, arg2, arg3 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupRolesOnObject", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupRolesOnObject indicates an expected call of GetGroupRolesOnObject.
+func (mr *MockGovcClientMockRecorder) GetGroupRolesOnObject(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupRolesOnObject", reflect.TypeOf((*MockGovcClient)(nil).GetGroupRolesOnObject), arg0, arg1, arg2, arg3)
+}
+
+// GetRolePrivileges mocks base method.
+func (m *MockGovcClient) GetRolePrivileges(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolePrivileges", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolePrivileges indicates an expected call of GetRolePrivileges.
+func (mr *MockGovcClientMockRecorder) GetRolePrivileges(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolePrivileges", reflect.TypeOf((*MockGovcClient)(nil).GetRolePrivileges), arg0, arg1)
+}
+
 // GroupExists mocks base method.
 func (m *MockGovcClient) GroupExists(arg0 context.Context, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,177 @@
+package setupuser
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// vSphere adds these privileges to every role, they are not reported as extra privileges.
+var systemPrivileges = []string{"System.Anonymous", "System.Read", "System.View"}
+
+// Plan describes the changes required for an existing vSphere setup to match a setup user configuration.
+type Plan struct {
+	CreateUser  bool
+	CreateGroup bool
+	Roles       []RolePlan
+	Permissions []PermissionPlan
+}
+
+// RolePlan describes the changes required for a role to have the privileges EKS Anywhere requires.
+type RolePlan struct {
+	Name              string
+	Create            bool
+	MissingPrivileges []string
+	// ExtraPrivileges are privileges of the existing role that EKS Anywhere doesn't require. They are never removed.
+	ExtraPrivileges []string
+}
+
+// PermissionPlan is a role the group is missing on an object.
+type PermissionPlan struct {
+	Role   string
+	Object string
+}
+
+// HasChanges returns true if applying the configuration would change the vSphere setup.
+func (p *Plan) HasChanges() bool {
+	if p.CreateUser || p.CreateGroup || len(p.Permissions) > 0 {
+		return true
+	}
+
+	for _, r := range p.Roles {
+		if r.Create || len(r.MissingPrivileges) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BuildPlan compares the existing user, group, roles and permissions to the ones required by the configuration.
+// It doesn't change anything in vSphere.
+func BuildPlan(ctx context.Context, vsuc *VSphereSetupUserConfig, govc GovcClient) (*Plan, error) {
+	p := &Plan{}
+
+	userExists, err := govc.UserExists(ctx, vsuc.Spec.Username)
+	if err != nil {
+		return nil, err
+	}
+	p.CreateUser = !userExists
+
+	groupExists, err := govc.GroupExists(ctx, vsuc.Spec.GroupName)
+	if err != nil {
+		return nil, err
+	}
+	p.CreateGroup = !groupExists
+
+	roles, err := getRoles(vsuc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range roles {
+		rp, err := planRole(ctx, r, govc)
+		if err != nil {
+			return nil, err
+		}
+		p.Roles = append(p.Roles, *rp)
+	}
+
+	assignments := []struct {
+		role    string
+		objects []string
+	}{
+		{role: vsuc.Spec.GlobalRole, objects: []string{vSphereRootPath}},
+		{role: vsuc.Spec.AdminRole, objects: append(vsuc.Spec.Objects.Folders, vsuc.Spec.Objects.Templates...)},
+		{role: vsuc.Spec.UserRole, objects: getUserRoleObjects(vsuc)},
+	}
+
+	for _, a := range assignments {
+		for _, obj := range a.objects {
+			// a new group has no permissions yet
+			if p.CreateGroup {
+				p.Permissions = append(p.Permissions, PermissionPlan{Role: a.role, Object: obj})
+				continue
+			}
+
+			existing, err := govc.GetGroupRolesOnObject(ctx, vsuc.Spec.GroupName, obj, vsuc.Spec.VSphereDomain)
+			if err != nil {
+				return nil, err
+			}
+			if len(difference([]string{a.role}, existing)) > 0 {
+				p.Permissions = append(p.Permissions, PermissionPlan{Role: a.role, Object: obj})
+			}
+		}
+	}
+
+	return p, nil
+}
+
+func planRole(ctx context.Context, r vsphereRole, govc GovcClient) (*RolePlan, error) {
+	rp := &RolePlan{Name: r.name}
+
+	exists, err := govc.RoleExists(ctx, r.name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		rp.Create = true
+		rp.MissingPrivileges = r.privs
+		return rp, nil
+	}
+
+	privs, err := govc.GetRolePrivileges(ctx, r.name)
+	if err != nil {
+		return nil, err
+	}
+	rp.MissingPrivileges = difference(r.privs, privs)
+	rp.ExtraPrivileges = difference(difference(privs, r.privs), systemPrivileges)
+
+	return rp, nil
+}
+
+// Write prints the plan as a diff: "+" for what will be created or added and "*" for existing privileges
+// EKS Anywhere doesn't require, which are kept.
+func (p *Plan) Write(w io.Writer, vsuc *VSphereSetupUserConfig) error {
+	b := &strings.Builder{}
+
+	if !p.HasChanges() {
+		b.WriteString("No changes: the vSphere user, group, roles and permissions match the configuration\n")
+	}
+
+	if p.CreateUser {
+		fmt.Fprintf(b, "+ user %s\n", vsuc.Spec.Username)
+	}
+	if p.CreateGroup {
+		fmt.Fprintf(b, "+ group %s\n", vsuc.Spec.GroupName)
+	}
+
+	for _, r := range p.Roles {
+		switch {
+		case r.Create:
+			fmt.Fprintf(b, "+ role %s (%d privileges)\n", r.Name, len(r.MissingPrivileges))
+			continue
+		case len(r.MissingPrivileges) > 0:
+			fmt.Fprintf(b, "~ role %s\n", r.Name)
+		case len(r.ExtraPrivileges) > 0:
+			fmt.Fprintf(b, "  role %s\n", r.Name)
+		default:
+			continue
+		}
+
+		for _, priv := range r.MissingPrivileges {
+			fmt.Fprintf(b, "    + %s\n", priv)
+		}
+		for _, priv := range r.ExtraPrivileges {
+			fmt.Fprintf(b, "    * %s (not required, kept)\n", priv)
+		}
+	}
+
+	for _, perm := range p.Permissions {
+		fmt.Fprintf(b, "+ permission %s on %s for group %s\n", perm.Role, perm.Object, vsuc.Spec.GroupName)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,146 @@
+package setupuser_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/setupuser"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/setupuser/mocks"
+)
+
+type planTest struct {
+	*WithT
+	ctx  context.Context
+	govc *mocks.MockGovcClient
+	c    *setupuser.VSphereSetupUserConfig
+}
+
+func newPlanTest(t *testing.T) *planTest {
+	ctx := context.Background()
+	g := NewWithT(t)
+	c, err := setupuser.GenerateConfig(ctx, "./testdata/configs/valid.yaml")
+	g.Expect(err).To(Succeed())
+
+	return &planTest{
+		WithT: g,
+		ctx:   ctx,
+		govc:  mocks.NewMockGovcClient(gomock.NewController(t)),
+		c:     c,
+	}
+}
+
+func (tt *planTest) expectExistingSetup() {
+	tt.govc.EXPECT().UserExists(tt.ctx, tt.c.Spec.Username).Return(true, nil)
+	tt.govc.EXPECT().GroupExists(tt.ctx, tt.c.Spec.GroupName).Return(true, nil)
+	for _, role := range []string{tt.c.Spec.GlobalRole, tt.c.Spec.UserRole, tt.c.Spec.AdminRole} {
+		tt.govc.EXPECT().RoleExists(tt.ctx, role).Return(true, nil)
+	}
+}
+
+func (tt *planTest) expectPermissions(userRoleOnDatastore string) {
+	objects := tt.c.Spec.Objects
+	roles := map[string]string{
+		"/":                      tt.c.Spec.GlobalRole,
+		objects.Folders[0]:       tt.c.Spec.AdminRole,
+		objects.Templates[0]:     tt.c.Spec.AdminRole,
+		objects.Networks[0]:      tt.c.Spec.UserRole,
+		objects.Datastores[0]:    userRoleOnDatastore,
+		objects.ResourcePools[0]: tt.c.Spec.UserRole,
+	}
+	for obj, role := range roles {
+		tt.govc.EXPECT().GetGroupRolesOnObject(tt.ctx, tt.c.Spec.GroupName, obj, tt.c.Spec.VSphereDomain).Return([]string{role}, nil)
+	}
+}
+
+func TestBuildPlanNewSetup(t *testing.T) {
+	tt := newPlanTest(t)
+	tt.govc.EXPECT().UserExists(tt.ctx, tt.c.Spec.Username).Return(false, nil)
+	tt.govc.EXPECT().GroupExists(tt.ctx, tt.c.Spec.GroupName).Return(false, nil)
+	for _, role := range []string{tt.c.Spec.GlobalRole, tt.c.Spec.UserRole, tt.c.Spec.AdminRole} {
+		tt.govc.EXPECT().RoleExists(tt.ctx, role).Return(false, nil)
+	}
+
+	p, err := setupuser.BuildPlan(tt.ctx, tt.c, tt.govc)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(p.HasChanges()).To(BeTrue())
+	tt.Expect(p.CreateUser).To(BeTrue())
+	tt.Expect(p.CreateGroup).To(BeTrue())
+	tt.Expect(p.Roles).To(HaveLen(3))
+	tt.Expect(p.Permissions).To(HaveLen(6))
+
+	out := &bytes.Buffer{}
+	tt.Expect(p.Write(out, tt.c)).To(Succeed())
+	tt.Expect(out.String()).To(ContainSubstring("+ user eksa\n+ group MyExistingGroup\n"))
+	tt.Expect(out.String()).To(ContainSubstring(fmt.Sprintf("+ role MyExistingUserRole (%d privileges)\n", len(requiredPrivileges(tt.c, tt.c.Spec.UserRole)))))
+	tt.Expect(out.String()).To(ContainSubstring("+ permission MyExistingGlobalRole on / for group MyExistingGroup\n"))
+}
+
+func TestBuildPlanNoChanges(t *testing.T) {
+	tt := newPlanTest(t)
+	tt.expectExistingSetup()
+	for _, role := range []string{tt.c.Spec.GlobalRole, tt.c.Spec.UserRole, tt.c.Spec.AdminRole} {
+		tt.govc.EXPECT().GetRolePrivileges(tt.ctx, role).Return(append(requiredPrivileges(tt.c, role), "System.Read"), nil)
+	}
+	tt.expectPermissions(tt.c.Spec.UserRole)
+
+	p, err := setupuser.BuildPlan(tt.ctx, tt.c, tt.govc)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(p.HasChanges()).To(BeFalse())
+
+	out := &bytes.Buffer{}
+	tt.Expect(p.Write(out, tt.c)).To(Succeed())
+	tt.Expect(out.String()).To(Equal("No changes: the vSphere user, group, roles and permissions match the configuration\n"))
+}
+
+func TestBuildPlanMissingPrivilegesAndPermissions(t *testing.T) {
+	tt := newPlanTest(t)
+	tt.expectExistingSetup()
+	userPrivs := requiredPrivileges(tt.c, tt.c.Spec.UserRole)
+	tt.govc.EXPECT().GetRolePrivileges(tt.ctx, tt.c.Spec.GlobalRole).Return(requiredPrivileges(tt.c, tt.c.Spec.GlobalRole), nil)
+	tt.govc.EXPECT().GetRolePrivileges(tt.ctx, tt.c.Spec.UserRole).Return(append(userPrivs[1:], "Extra.Privilege"), nil)
+	tt.govc.EXPECT().GetRolePrivileges(tt.ctx, tt.c.Spec.AdminRole).Return(requiredPrivileges(tt.c, tt.c.Spec.AdminRole), nil)
+	tt.expectPermissions("ReadOnly")
+
+	p, err := setupuser.BuildPlan(tt.ctx, tt.c, tt.govc)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(p.HasChanges()).To(BeTrue())
+	tt.Expect(p.Roles[1]).To(Equal(setupuser.RolePlan{
+		Name:              tt.c.Spec.UserRole,
+		MissingPrivileges: userPrivs[:1],
+		ExtraPrivileges:   []string{"Extra.Privilege"},
+	}))
+	tt.Expect(p.Permissions).To(Equal([]setupuser.PermissionPlan{
+		{Role: tt.c.Spec.UserRole, Object: tt.c.Spec.Objects.Datastores[0]},
+	}))
+
+	out := &bytes.Buffer{}
+	tt.Expect(p.Write(out, tt.c)).To(Succeed())
+	tt.Expect(out.String()).To(Equal(fmt.Sprintf(`~ role MyExistingUserRole
+    + %s
+    * Extra.Privilege (not required, kept)
++ permission MyExistingUserRole on /MyDatacenter/datastore/MyDatastore2 for group MyExistingGroup
+`, userPrivs[0])))
+}
+
+func TestBuildPlanGetGroupRolesOnObjectError(t *testing.T) {
+	tt := newPlanTest(t)
+	tt.expectExistingSetup()
+	tt.govc.EXPECT().GetRolePrivileges(tt.ctx, gomock.Any()).Return(nil, nil).Times(3)
+	tt.govc.EXPECT().GetGroupRolesOnObject(tt.ctx, tt.c.Spec.GroupName, "/", tt.c.Spec.VSphereDomain).Return(nil, fmt.Errorf("govc error"))
+
+	_, err := setupuser.BuildPlan(tt.ctx, tt.c, tt.govc)
+	tt.Expect(err).To(MatchError("govc error"))
+}
+
+func TestBuildPlanUserExistsError(t *testing.T) {
+	tt := newPlanTest(t)
+	tt.govc.EXPECT().UserExists(tt.ctx, tt.c.Spec.Username).Return(false, fmt.Errorf("govc error"))
+
+	_, err := setupuser.BuildPlan(tt.ctx, tt.c, tt.govc)
+	tt.Expect(err).To(MatchError("govc error"))
+}
@@ -24,7 +24,10 @@ type GovcClient interface {
 	AddUserToGroup(ctx context.Context, name string, username string) error
 	RoleExists(ctx context.Context, name string) (bool, error)
 	CreateRole(ctx context.Context, name string, privileges []string) error
+	GetRolePrivileges(ctx context.Context, name string) ([]string, error)
+	AddRolePrivileges(ctx context.Context, name string, privileges []string) error
 	SetGroupRoleOnObject(ctx context.Context, principal string, role string, object string, domain string) error
+	GetGroupRolesOnObject(ctx context.Context, principal string, object string, domain string) ([]string, error)
 }
 
 // SetupGOVCEnv creates appropriate govc environment variables to build govc client.
@@ -45,6 +48,8 @@ func SetupGOVCEnv(ctx context.Context, vsuc *VSphereSetupUserConfig) error {
 }
 
 // Run sets up a vSphere user with appropriate group, role, and permissions to create EKS-A kubernetes clusters.
+// It can be run against an existing setup: the privileges missing from existing roles are added and the
+// permissions are set again on every object.
 func Run(ctx context.Context, vsuc *VSphereSetupUserConfig, govc GovcClient) error {
 	err := createGroup(ctx, vsuc, govc)
 	if err != nil {
@@ -106,13 +111,38 @@ func createRoles(ctx context.Context, vsuc *VSphereSetupUserConfig, govc GovcCli
 			}
 			logger.V(0).Info(fmt.Sprintf("Created %s role", r.name))
 		} else {
-			logger.V(0).Info(fmt.Sprintf("Skipping creating %s role because it already exists", r.name))
+			err = addMissingPrivileges(ctx, r, govc)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
+func addMissingPrivileges(ctx context.Context, r vsphereRole, govc GovcClient) error {
+	privs, err := govc.GetRolePrivileges(ctx, r.name)
+	if err != nil {
+		return err
+	}
+
+	missing := difference(r.privs, privs)
+	if len(missing) == 0 {
+		logger.V(0).Info(fmt.Sprintf("Skipping updating %s role because it already has the required privileges", r.name))
+		return nil
+	}
+
+	err = govc.AddRolePrivileges(ctx, r.name, missing)
+	if err != nil {
+		logger.V(0).Info(fmt.Sprintf("Failed to add %v to %s role", missing, r.name))
+		return err
+	}
+	logger.V(0).Info(fmt.Sprintf("Added %d missing privileges to %s role", len(missing), r.name))
+
+	return nil
+}
+
 func associateRolesToObjects(ctx context.Context, vsuc *VSphereSetupUserConfig, govc GovcClient) error {
 	err := setGroupRoleOnObjects(ctx, vsuc, govc, vsuc.Spec.GlobalRole, []string{vSphereRootPath})
 	if err != nil {
@@ -201,6 +231,23 @@ func getRoles(vsuc *VSphereSetupUserConfig) ([]vsphereRole, error) {
 	}, nil
 }
 
+// difference returns the elements of a that are not in b.
+func difference(a, b []string) []string {
+	in := make(map[string]struct{}, len(b))
+	for _, e := range b {
+		in[e] = struct{}{}
+	}
+
+	var diff []string
+	for _, e := range a {
+		if _, ok := in[e]; !ok {
+			diff = append(diff, e)
+		}
+	}
+
+	return diff
+}
+
 func getUserRoleObjects(vsuc *VSphereSetupUserConfig) []string {
 	objects := append(vsuc.Spec.Objects.Networks, vsuc.Spec.Objects.Datastores...)
 	objects = append(objects, vsuc.Spec.Objects.ResourcePools...)
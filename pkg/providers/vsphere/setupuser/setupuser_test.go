@@ -2,6 +2,7 @@ package setupuser_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 
+	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/setupuser"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/setupuser/mocks"
 )
@@ -182,10 +184,13 @@ func TestSetupUserRun(t *testing.T) {
 				gc.EXPECT().AddUserToGroup(ctx, c.Spec.GroupName, c.Spec.Username).Return(nil)
 
 				gc.EXPECT().RoleExists(ctx, c.Spec.GlobalRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.GlobalRole).Return(requiredPrivileges(c, c.Spec.GlobalRole), nil)
 
 				gc.EXPECT().RoleExists(ctx, c.Spec.UserRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.UserRole).Return(requiredPrivileges(c, c.Spec.UserRole), nil)
 
 				gc.EXPECT().RoleExists(ctx, c.Spec.AdminRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.AdminRole).Return(requiredPrivileges(c, c.Spec.AdminRole), nil)
 
 				gc.EXPECT().SetGroupRoleOnObject(ctx, c.Spec.GroupName, c.Spec.GlobalRole, "/", c.Spec.VSphereDomain)
 
@@ -217,6 +222,7 @@ func TestSetupUserRun(t *testing.T) {
 				gc.EXPECT().CreateGroup(ctx, c.Spec.GroupName).Return(nil)
 				gc.EXPECT().AddUserToGroup(ctx, c.Spec.GroupName, c.Spec.Username).Return(nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.GlobalRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.GlobalRole).Return(requiredPrivileges(c, c.Spec.GlobalRole), nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.UserRole).Return(false, fmt.Errorf("govc error"))
 			},
 		},
@@ -229,7 +235,9 @@ func TestSetupUserRun(t *testing.T) {
 				gc.EXPECT().CreateGroup(ctx, c.Spec.GroupName).Return(nil)
 				gc.EXPECT().AddUserToGroup(ctx, c.Spec.GroupName, c.Spec.Username).Return(nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.GlobalRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.GlobalRole).Return(requiredPrivileges(c, c.Spec.GlobalRole), nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.UserRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.UserRole).Return(requiredPrivileges(c, c.Spec.UserRole), nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.AdminRole).Return(false, fmt.Errorf("govc error"))
 			},
 		},
@@ -254,6 +262,7 @@ func TestSetupUserRun(t *testing.T) {
 				gc.EXPECT().CreateGroup(ctx, c.Spec.GroupName).Return(nil)
 				gc.EXPECT().AddUserToGroup(ctx, c.Spec.GroupName, c.Spec.Username).Return(nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.GlobalRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.GlobalRole).Return(requiredPrivileges(c, c.Spec.GlobalRole), nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.UserRole).Return(false, nil)
 				gc.EXPECT().CreateRole(ctx, c.Spec.UserRole, gomock.Any()).Return(fmt.Errorf("govc error"))
 			},
@@ -267,7 +276,9 @@ func TestSetupUserRun(t *testing.T) {
 				gc.EXPECT().CreateGroup(ctx, c.Spec.GroupName).Return(nil)
 				gc.EXPECT().AddUserToGroup(ctx, c.Spec.GroupName, c.Spec.Username).Return(nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.GlobalRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.GlobalRole).Return(requiredPrivileges(c, c.Spec.GlobalRole), nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.UserRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.UserRole).Return(requiredPrivileges(c, c.Spec.UserRole), nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.AdminRole).Return(false, nil)
 				gc.EXPECT().CreateRole(ctx, c.Spec.AdminRole, gomock.Any()).Return(fmt.Errorf("govc error"))
 			},
@@ -281,8 +292,11 @@ func TestSetupUserRun(t *testing.T) {
 				gc.EXPECT().CreateGroup(ctx, c.Spec.GroupName).Return(nil)
 				gc.EXPECT().AddUserToGroup(ctx, c.Spec.GroupName, c.Spec.Username).Return(nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.GlobalRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.GlobalRole).Return(requiredPrivileges(c, c.Spec.GlobalRole), nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.UserRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.UserRole).Return(requiredPrivileges(c, c.Spec.UserRole), nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.AdminRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.AdminRole).Return(requiredPrivileges(c, c.Spec.AdminRole), nil)
 
 				gc.EXPECT().SetGroupRoleOnObject(ctx, c.Spec.GroupName, c.Spec.GlobalRole, "/", c.Spec.VSphereDomain).Return(fmt.Errorf("govc error"))
 			},
@@ -296,8 +310,11 @@ func TestSetupUserRun(t *testing.T) {
 				gc.EXPECT().CreateGroup(ctx, c.Spec.GroupName).Return(nil)
 				gc.EXPECT().AddUserToGroup(ctx, c.Spec.GroupName, c.Spec.Username).Return(nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.GlobalRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.GlobalRole).Return(requiredPrivileges(c, c.Spec.GlobalRole), nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.UserRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.UserRole).Return(requiredPrivileges(c, c.Spec.UserRole), nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.AdminRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.AdminRole).Return(requiredPrivileges(c, c.Spec.AdminRole), nil)
 
 				gc.EXPECT().SetGroupRoleOnObject(ctx, c.Spec.GroupName, c.Spec.GlobalRole, "/", c.Spec.VSphereDomain).Return(nil)
 
@@ -313,8 +330,11 @@ func TestSetupUserRun(t *testing.T) {
 				gc.EXPECT().CreateGroup(ctx, c.Spec.GroupName).Return(nil)
 				gc.EXPECT().AddUserToGroup(ctx, c.Spec.GroupName, c.Spec.Username).Return(nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.GlobalRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.GlobalRole).Return(requiredPrivileges(c, c.Spec.GlobalRole), nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.UserRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.UserRole).Return(requiredPrivileges(c, c.Spec.UserRole), nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.AdminRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.AdminRole).Return(requiredPrivileges(c, c.Spec.AdminRole), nil)
 
 				gc.EXPECT().SetGroupRoleOnObject(ctx, c.Spec.GroupName, c.Spec.GlobalRole, "/", c.Spec.VSphereDomain).Return(nil)
 
@@ -333,8 +353,11 @@ func TestSetupUserRun(t *testing.T) {
 				gc.EXPECT().CreateGroup(ctx, c.Spec.GroupName).Return(nil)
 				gc.EXPECT().AddUserToGroup(ctx, c.Spec.GroupName, c.Spec.Username).Return(nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.GlobalRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.GlobalRole).Return(requiredPrivileges(c, c.Spec.GlobalRole), nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.UserRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.UserRole).Return(requiredPrivileges(c, c.Spec.UserRole), nil)
 				gc.EXPECT().RoleExists(ctx, c.Spec.AdminRole).Return(true, nil)
+				gc.EXPECT().GetRolePrivileges(ctx, c.Spec.AdminRole).Return(requiredPrivileges(c, c.Spec.AdminRole), nil)
 
 				gc.EXPECT().SetGroupRoleOnObject(ctx, c.Spec.GroupName, c.Spec.GlobalRole, "/", c.Spec.VSphereDomain).Return(nil)
 
@@ -373,6 +396,49 @@ func TestSetupUserRun(t *testing.T) {
 	}
 }
 
+func TestSetupUserRunAddsMissingPrivileges(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	gc := mocks.NewMockGovcClient(gomock.NewController(t))
+	c, err := setupuser.GenerateConfig(ctx, "./testdata/configs/valid.yaml")
+	g.Expect(err).To(Succeed())
+
+	userPrivs := requiredPrivileges(c, c.Spec.UserRole)
+
+	gc.EXPECT().GroupExists(ctx, c.Spec.GroupName).Return(true, nil)
+	gc.EXPECT().AddUserToGroup(ctx, c.Spec.GroupName, c.Spec.Username).Return(nil)
+
+	gc.EXPECT().RoleExists(ctx, c.Spec.GlobalRole).Return(true, nil)
+	gc.EXPECT().GetRolePrivileges(ctx, c.Spec.GlobalRole).Return(requiredPrivileges(c, c.Spec.GlobalRole), nil)
+
+	gc.EXPECT().RoleExists(ctx, c.Spec.UserRole).Return(true, nil)
+	gc.EXPECT().GetRolePrivileges(ctx, c.Spec.UserRole).Return(append(userPrivs[1:], "Extra.Privilege"), nil)
+	gc.EXPECT().AddRolePrivileges(ctx, c.Spec.UserRole, userPrivs[:1]).Return(nil)
+
+	gc.EXPECT().RoleExists(ctx, c.Spec.AdminRole).Return(true, nil)
+	gc.EXPECT().GetRolePrivileges(ctx, c.Spec.AdminRole).Return(requiredPrivileges(c, c.Spec.AdminRole), nil)
+
+	gc.EXPECT().SetGroupRoleOnObject(ctx, c.Spec.GroupName, gomock.Any(), gomock.Any(), c.Spec.VSphereDomain).Times(6)
+
+	g.Expect(setupuser.Run(ctx, c, gc)).To(Succeed())
+}
+
+func TestSetupUserRunAddMissingPrivilegesError(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	gc := mocks.NewMockGovcClient(gomock.NewController(t))
+	c, err := setupuser.GenerateConfig(ctx, "./testdata/configs/valid.yaml")
+	g.Expect(err).To(Succeed())
+
+	gc.EXPECT().GroupExists(ctx, c.Spec.GroupName).Return(true, nil)
+	gc.EXPECT().AddUserToGroup(ctx, c.Spec.GroupName, c.Spec.Username).Return(nil)
+	gc.EXPECT().RoleExists(ctx, c.Spec.GlobalRole).Return(true, nil)
+	gc.EXPECT().GetRolePrivileges(ctx, c.Spec.GlobalRole).Return(nil, nil)
+	gc.EXPECT().AddRolePrivileges(ctx, c.Spec.GlobalRole, requiredPrivileges(c, c.Spec.GlobalRole)).Return(fmt.Errorf("govc error"))
+
+	g.Expect(setupuser.Run(ctx, c, gc)).To(MatchError("govc error"))
+}
+
 func TestSetupGOVCEnv(t *testing.T) {
 	ctx := context.Background()
 
@@ -441,3 +507,18 @@ func TestSetupGOVCEnv(t *testing.T) {
 		)
 	}
 }
+
+func requiredPrivileges(c *setupuser.VSphereSetupUserConfig, role string) []string {
+	files := map[string]string{
+		c.Spec.GlobalRole: config.VSphereGlobalPrivsFile,
+		c.Spec.UserRole:   config.VSphereUserPrivsFile,
+		c.Spec.AdminRole:  config.VSphereAdminPrivsFile,
+	}
+
+	var privs []string
+	if err := json.Unmarshal([]byte(files[role]), &privs); err != nil {
+		panic(err)
+	}
+
+	return privs
+}
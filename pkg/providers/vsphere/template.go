@@ -445,6 +445,20 @@ func buildTemplateMapCP(
 		}
 	}
 
+	controlPlaneIPPoolDevices, err := ipPoolDevices(clusterSpec, controlPlaneMachineSpec, machineNetworks(datacenterSpec, controlPlaneMachineSpec, false, ""), "")
+	if err != nil {
+		return nil, fmt.Errorf("control plane: %v", err)
+	}
+	values["controlPlaneIPPoolDevices"] = controlPlaneIPPoolDevices
+
+	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		etcdIPPoolDevices, err := ipPoolDevices(clusterSpec, etcdMachineSpec, machineNetworks(datacenterSpec, etcdMachineSpec, false, ""), "")
+		if err != nil {
+			return nil, fmt.Errorf("etcd: %v", err)
+		}
+		values["etcdIPPoolDevices"] = etcdIPPoolDevices
+	}
+
 	return values, nil
 }
 
@@ -613,6 +627,16 @@ func buildTemplateMapMD(
 		values["nodeLabelArgs"] = nodeLabelArgs
 	}
 
+	failureDomain := ""
+	if len(workerNodeGroupConfiguration.FailureDomains) > 0 {
+		failureDomain = workerNodeGroupConfiguration.FailureDomains[0]
+	}
+	workerIPPoolDevices, err := ipPoolDevices(clusterSpec, workerNodeGroupMachineSpec, machineNetworks(datacenterSpec, workerNodeGroupMachineSpec, true, failureDomain), failureDomain)
+	if err != nil {
+		return nil, fmt.Errorf("worker node group %s: %v", workerNodeGroupConfiguration.Name, err)
+	}
+	values["workerIPPoolDevices"] = workerIPPoolDevices
+
 	return values, nil
 }
 
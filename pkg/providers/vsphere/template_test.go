@@ -2,10 +2,12 @@ package vsphere_test
 
 import (
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/aws/eks-anywhere/internal/test"
@@ -270,3 +272,74 @@ func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneWithDefaultAuditPolic
 
 	g.Expect(collapseWhitespace(string(data))).To(ContainSubstring(collapseWhitespace(defaultAuditPolicy)))
 }
+
+func vsphereIPPoolForTemplate(name, network string) *v1alpha1.VSphereIPPool {
+	return &v1alpha1.VSphereIPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.VSphereIPPoolSpec{
+			Network:     network,
+			Ranges:      []v1alpha1.VSphereIPRange{{Start: "10.0.0.10", End: "10.0.0.30"}},
+			Prefix:      24,
+			Gateway:     "10.0.0.1",
+			Nameservers: []string{"10.0.0.2", "10.0.0.3"},
+		},
+	}
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecIPPools(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.VSphereIPPools = map[string]*v1alpha1.VSphereIPPool{
+		"pool-1": vsphereIPPoolForTemplate("pool-1", "/SDDC-Datacenter/network/sddc-cgw-network-1"),
+		"pool-2": vsphereIPPoolForTemplate("pool-2", "/SDDC-Datacenter/network/sddc-cgw-network-2"),
+	}
+	for _, mc := range spec.VSphereMachineConfigs {
+		mc.Spec.IPPoolRefs = []v1alpha1.Ref{
+			{Kind: v1alpha1.VSphereIPPoolKind, Name: "pool-1"},
+			{Kind: v1alpha1.VSphereIPPoolKind, Name: "pool-2"},
+		}
+	}
+	workerMachineConfig := spec.VSphereMachineConfigs[spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name]
+	workerMachineConfig.Spec.Networks = []string{"/SDDC-Datacenter/network/sddc-cgw-network-1", "/SDDC-Datacenter/network/sddc-cgw-network-2"}
+
+	device := func(network, pool string) string {
+		return collapseWhitespace(`- dhcp4: false
+  networkName: ` + network + `
+  addressesFromPools:
+  - apiGroup: anywhere.eks.amazonaws.com
+    kind: VSphereIPPool
+    name: ` + pool + `
+  nameservers:
+  - 10.0.0.2
+  - 10.0.0.3`)
+	}
+
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+	cpData, err := builder.GenerateCAPISpecControlPlane(spec, func(values map[string]interface{}) {
+		values["controlPlaneTemplateName"] = clusterapi.ControlPlaneMachineTemplateName(spec.Cluster)
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	cp := collapseWhitespace(string(cpData))
+	g.Expect(strings.Count(cp, device("/SDDC-Datacenter/network/sddc-cgw-network-1", "pool-1"))).To(Equal(2))
+	g.Expect(cp).ToNot(ContainSubstring("dhcp4: true"))
+
+	wData, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	w := collapseWhitespace(string(wData))
+	g.Expect(w).To(ContainSubstring(device("/SDDC-Datacenter/network/sddc-cgw-network-1", "pool-1") + " " + device("/SDDC-Datacenter/network/sddc-cgw-network-2", "pool-2")))
+	g.Expect(w).ToNot(ContainSubstring("dhcp4: true"))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecIPPoolsMissingPool(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.VSphereIPPools = map[string]*v1alpha1.VSphereIPPool{
+		"pool-2": vsphereIPPoolForTemplate("pool-2", "/SDDC-Datacenter/network/sddc-cgw-network-2"),
+	}
+	workerMachineConfig := spec.VSphereMachineConfigs[spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name]
+	workerMachineConfig.Spec.IPPoolRefs = []v1alpha1.Ref{{Kind: v1alpha1.VSphereIPPoolKind, Name: "pool-2"}}
+
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+	_, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).To(MatchError("worker node group md-0: no VSphereIPPool referenced for network /SDDC-Datacenter/network/sddc-cgw-network-1"))
+}
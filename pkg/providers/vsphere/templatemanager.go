@@ -0,0 +1,208 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/internal/templates"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// TemplateManagerGovcClient is the vSphere client the TemplateManager uses to import, list and delete templates.
+type TemplateManagerGovcClient interface {
+	ProviderGovcClient
+	ListTemplates(ctx context.Context, folder string) ([]string, error)
+	DeleteTemplate(ctx context.Context, resourcePool, templatePath string) error
+}
+
+// TemplatePlacement is where the templates are created in vCenter.
+type TemplatePlacement struct {
+	Datacenter   string
+	Datastore    string
+	Network      string
+	ResourcePool string
+}
+
+// TemplatePlacementFromSpec returns the placement of the templates of a cluster, using
+// the datastore and resource pool of its control plane machine config.
+func TemplatePlacementFromSpec(spec *cluster.Spec) TemplatePlacement {
+	cp := controlPlaneMachineConfig(spec)
+	return TemplatePlacement{
+		Datacenter:   spec.VSphereDatacenter.Spec.Datacenter,
+		Datastore:    cp.Spec.Datastore,
+		Network:      spec.VSphereDatacenter.Spec.Network,
+		ResourcePool: cp.Spec.ResourcePool,
+	}
+}
+
+// ImportTemplatesOptions selects the templates imported from a Bundles release.
+type ImportTemplatesOptions struct {
+	KubernetesVersions []anywherev1.KubernetesVersion
+	OSFamilies         []anywherev1.OSFamily
+
+	// OVADir is a directory with the OVAs downloaded beforehand, named as in their bundle URI.
+	// When set, the OVAs are imported from it instead of being downloaded from the bundle.
+	OVADir string
+}
+
+// TemplateManager imports the OVAs of a Bundles release as tagged templates in the default
+// templates folder and finds and removes the templates in that folder no cluster uses anymore.
+type TemplateManager struct {
+	govc      TemplateManagerGovcClient
+	placement TemplatePlacement
+}
+
+// NewTemplateManager builds a TemplateManager.
+func NewTemplateManager(govc TemplateManagerGovcClient, placement TemplatePlacement) *TemplateManager {
+	return &TemplateManager{
+		govc:      govc,
+		placement: placement,
+	}
+}
+
+// ImportTemplates imports a template for each of the selected Kubernetes versions and OS families
+// that doesn't exist yet and tags it with the tags required by the cluster validations.
+// It returns the paths of all the selected templates.
+func (m *TemplateManager) ImportTemplates(ctx context.Context, bundles *releasev1.Bundles, opts ImportTemplatesOptions) ([]string, error) {
+	factory := templates.NewFactory(m.govc, m.placement.Datacenter, m.placement.Datastore, m.placement.Network, m.placement.ResourcePool, defaultTemplateLibrary)
+
+	paths := make([]string, 0, len(opts.KubernetesVersions)*len(opts.OSFamilies))
+	for _, kubeVersion := range opts.KubernetesVersions {
+		vb, err := cluster.GetVersionsBundle(kubeVersion, bundles)
+		if err != nil {
+			return nil, err
+		}
+		versionsBundle := &cluster.VersionsBundle{VersionsBundle: vb}
+
+		for _, osFamily := range opts.OSFamilies {
+			ova, err := ovaForOSFamily(versionsBundle, osFamily)
+			if err != nil {
+				return nil, err
+			}
+
+			ovaURL, err := ovaLocation(ova, opts.OVADir)
+			if err != nil {
+				return nil, err
+			}
+
+			machineConfig := &anywherev1.VSphereMachineConfig{
+				Spec: anywherev1.VSphereMachineConfigSpec{
+					OSFamily: osFamily,
+					Template: defaultTemplatePath(m.placement.Datacenter, osFamily, versionsBundle, ova),
+				},
+			}
+
+			logger.Info("Importing template", "kubernetesVersion", kubeVersion, "osFamily", osFamily, "template", machineConfig.Spec.Template)
+			tags := requiredTemplateTagsByCategory(machineConfig, versionsBundle)
+			if err := factory.CreateIfMissing(ctx, m.placement.Datacenter, machineConfig, ovaURL, tags); err != nil {
+				return nil, fmt.Errorf("importing template for kubernetes version %s and os family %s: %v", kubeVersion, osFamily, err)
+			}
+
+			paths = append(paths, machineConfig.Spec.Template)
+		}
+	}
+
+	return paths, nil
+}
+
+func ovaLocation(ova releasev1.Archive, ovaDir string) (string, error) {
+	if ovaDir == "" {
+		return ova.URI, nil
+	}
+
+	// govc runs in the tools container, where only absolute paths of mounted directories resolve.
+	ovaPath, err := filepath.Abs(filepath.Join(ovaDir, path.Base(ova.URI)))
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(ovaPath); err != nil {
+		return "", fmt.Errorf("reading ova from directory %s: %v", ovaDir, err)
+	}
+
+	return ovaPath, nil
+}
+
+// UnusedTemplates returns the paths of the templates in the default templates folder that are not in inUse.
+// Templates are compared by name, since machine configs can reference them by name or by path.
+func (m *TemplateManager) UnusedTemplates(ctx context.Context, inUse []string) ([]string, error) {
+	folder := path.Join("/", m.placement.Datacenter, defaultTemplatesFolder)
+	existing, err := m.govc.ListTemplates(ctx, folder)
+	if err != nil {
+		return nil, err
+	}
+
+	inUseNames := make(map[string]struct{}, len(inUse))
+	for _, t := range inUse {
+		inUseNames[path.Base(t)] = struct{}{}
+	}
+
+	unused := make([]string, 0, len(existing))
+	for _, t := range existing {
+		if _, ok := inUseNames[path.Base(t)]; !ok {
+			unused = append(unused, t)
+		}
+	}
+	sort.Strings(unused)
+
+	return unused, nil
+}
+
+// DeleteTemplates deletes the given templates.
+func (m *TemplateManager) DeleteTemplates(ctx context.Context, templates []string) error {
+	for _, t := range templates {
+		logger.Info("Deleting template", "template", t)
+		if err := m.govc.DeleteTemplate(ctx, m.placement.ResourcePool, t); err != nil {
+			return fmt.Errorf("deleting template %s: %v", t, err)
+		}
+	}
+
+	return nil
+}
+
+// TemplatesInUse returns the templates referenced in all namespaces by the VSphereMachineConfigs and by
+// the CAPV VSphereMachineTemplates and VSphereMachines. The CAPV objects still reference the previous
+// templates while machines are rolled out and the templates linked clones were created from.
+func TemplatesInUse(ctx context.Context, c client.Reader) ([]string, error) {
+	machineConfigs := &anywherev1.VSphereMachineConfigList{}
+	if err := c.List(ctx, machineConfigs); err != nil {
+		return nil, fmt.Errorf("listing vsphere machine configs: %v", err)
+	}
+
+	machineTemplates := &vspherev1.VSphereMachineTemplateList{}
+	if err := c.List(ctx, machineTemplates); err != nil {
+		return nil, fmt.Errorf("listing vsphere machine templates: %v", err)
+	}
+
+	machines := &vspherev1.VSphereMachineList{}
+	if err := c.List(ctx, machines); err != nil {
+		return nil, fmt.Errorf("listing vsphere machines: %v", err)
+	}
+
+	inUse := make([]string, 0, len(machineConfigs.Items)+len(machineTemplates.Items)+len(machines.Items))
+	add := func(template string) {
+		if template != "" {
+			inUse = append(inUse, template)
+		}
+	}
+	for _, mc := range machineConfigs.Items {
+		add(mc.Spec.Template)
+	}
+	for _, mt := range machineTemplates.Items {
+		add(mt.Spec.Template.Spec.Template)
+	}
+	for _, m := range machines.Items {
+		add(m.Spec.Template)
+	}
+
+	return inUse, nil
+}
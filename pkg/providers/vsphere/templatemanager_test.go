@@ -0,0 +1,281 @@
+package vsphere_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	templateManagerTestTemplate = "/SDDC-Datacenter/vm/Templates/bottlerocket-1-31-kubernetes-1-31-eks-1-amd64-abcdef1"
+	templateManagerTestOVA      = "https://anywhere-assets.eks.amazonaws.com/bottlerocket-vmware-k8s-1.31-x86_64.ova"
+)
+
+type templateManagerTest struct {
+	*WithT
+	ctx     context.Context
+	govc    *mocks.MockTemplateManagerGovcClient
+	manager *vsphere.TemplateManager
+	bundles *releasev1.Bundles
+}
+
+func newTemplateManagerTest(t *testing.T) *templateManagerTest {
+	ctrl := gomock.NewController(t)
+	govc := mocks.NewMockTemplateManagerGovcClient(ctrl)
+	return &templateManagerTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		govc:  govc,
+		manager: vsphere.NewTemplateManager(govc, vsphere.TemplatePlacement{
+			Datacenter:   "SDDC-Datacenter",
+			Datastore:    "WorkloadDatastore",
+			Network:      "VM Network",
+			ResourcePool: "*/Resources",
+		}),
+		bundles: &releasev1.Bundles{
+			Spec: releasev1.BundlesSpec{
+				VersionsBundles: []releasev1.VersionsBundle{
+					{
+						KubeVersion: "1.31",
+						EksD: releasev1.EksDRelease{
+							Name:        "kubernetes-1-31-eks-1",
+							KubeVersion: "1-31",
+							Ova: releasev1.OSImageBundle{
+								Bottlerocket: releasev1.Archive{
+									URI:    templateManagerTestOVA,
+									SHA256: "abcdef123456",
+									Arch:   []string{"amd64"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestTemplateManagerImportTemplates(t *testing.T) {
+	tt := newTemplateManagerTest(t)
+	templateName := filepath.Base(templateManagerTestTemplate)
+
+	tt.govc.EXPECT().SearchTemplate(tt.ctx, "SDDC-Datacenter", templateManagerTestTemplate).Return("", nil)
+	tt.govc.EXPECT().LibraryElementExists(tt.ctx, "eks-a-templates").Return(true, nil)
+	tt.govc.EXPECT().GetLibraryElementContentVersion(tt.ctx, "eks-a-templates/"+templateName).Return("-1", nil)
+	tt.govc.EXPECT().ImportTemplate(tt.ctx, "eks-a-templates", templateManagerTestOVA, templateName).Return(nil)
+	tt.govc.EXPECT().DeployTemplateFromLibrary(tt.ctx, filepath.Dir(templateManagerTestTemplate), templateName, "eks-a-templates", "SDDC-Datacenter", "WorkloadDatastore", "VM Network", "*/Resources", true).Return(nil)
+	tt.govc.EXPECT().ListCategories(tt.ctx).Return([]string{"eksdRelease", "os"}, nil)
+	tt.govc.EXPECT().ListTags(tt.ctx).Return([]executables.Tag{{Name: "os:bottlerocket"}}, nil)
+	tt.govc.EXPECT().CreateTag(tt.ctx, "eksdRelease:kubernetes-1-31-eks-1", "eksdRelease").Return(nil)
+	tt.govc.EXPECT().AddTag(tt.ctx, templateManagerTestTemplate, "eksdRelease:kubernetes-1-31-eks-1").Return(nil)
+	tt.govc.EXPECT().AddTag(tt.ctx, templateManagerTestTemplate, "os:bottlerocket").Return(nil)
+
+	templates, err := tt.manager.ImportTemplates(tt.ctx, tt.bundles, vsphere.ImportTemplatesOptions{
+		KubernetesVersions: []anywherev1.KubernetesVersion{anywherev1.Kube131},
+		OSFamilies:         []anywherev1.OSFamily{anywherev1.Bottlerocket},
+	})
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(templates).To(ConsistOf(templateManagerTestTemplate))
+}
+
+func TestTemplateManagerImportTemplatesAlreadyExists(t *testing.T) {
+	tt := newTemplateManagerTest(t)
+
+	tt.govc.EXPECT().SearchTemplate(tt.ctx, "SDDC-Datacenter", templateManagerTestTemplate).Return(templateManagerTestTemplate, nil)
+
+	templates, err := tt.manager.ImportTemplates(tt.ctx, tt.bundles, vsphere.ImportTemplatesOptions{
+		KubernetesVersions: []anywherev1.KubernetesVersion{anywherev1.Kube131},
+		OSFamilies:         []anywherev1.OSFamily{anywherev1.Bottlerocket},
+	})
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(templates).To(ConsistOf(templateManagerTestTemplate))
+}
+
+func TestTemplateManagerImportTemplatesFromDir(t *testing.T) {
+	tt := newTemplateManagerTest(t)
+	dir := t.TempDir()
+	ovaPath := filepath.Join(dir, filepath.Base(templateManagerTestOVA))
+	tt.Expect(os.WriteFile(ovaPath, []byte("ova"), 0o600)).To(Succeed())
+	templateName := filepath.Base(templateManagerTestTemplate)
+
+	tt.govc.EXPECT().SearchTemplate(tt.ctx, "SDDC-Datacenter", templateManagerTestTemplate).Return("", nil)
+	tt.govc.EXPECT().LibraryElementExists(tt.ctx, "eks-a-templates").Return(true, nil)
+	tt.govc.EXPECT().GetLibraryElementContentVersion(tt.ctx, "eks-a-templates/"+templateName).Return("-1", nil)
+	tt.govc.EXPECT().ImportTemplate(tt.ctx, "eks-a-templates", ovaPath, templateName).Return(errors.New("import failed"))
+
+	_, err := tt.manager.ImportTemplates(tt.ctx, tt.bundles, vsphere.ImportTemplatesOptions{
+		KubernetesVersions: []anywherev1.KubernetesVersion{anywherev1.Kube131},
+		OSFamilies:         []anywherev1.OSFamily{anywherev1.Bottlerocket},
+		OVADir:             dir,
+	})
+	tt.Expect(err).To(MatchError(ContainSubstring("import failed")))
+}
+
+func TestTemplateManagerImportTemplatesFromRelativeDir(t *testing.T) {
+	tt := newTemplateManagerTest(t)
+	dir := t.TempDir()
+	t.Chdir(dir)
+	tt.Expect(os.Mkdir("ovas", 0o700)).To(Succeed())
+	ovaPath := filepath.Join(dir, "ovas", filepath.Base(templateManagerTestOVA))
+	tt.Expect(os.WriteFile(ovaPath, []byte("ova"), 0o600)).To(Succeed())
+	templateName := filepath.Base(templateManagerTestTemplate)
+
+	tt.govc.EXPECT().SearchTemplate(tt.ctx, "SDDC-Datacenter", templateManagerTestTemplate).Return("", nil)
+	tt.govc.EXPECT().LibraryElementExists(tt.ctx, "eks-a-templates").Return(true, nil)
+	tt.govc.EXPECT().GetLibraryElementContentVersion(tt.ctx, "eks-a-templates/"+templateName).Return("-1", nil)
+	tt.govc.EXPECT().ImportTemplate(tt.ctx, "eks-a-templates", ovaPath, templateName).Return(errors.New("import failed"))
+
+	_, err := tt.manager.ImportTemplates(tt.ctx, tt.bundles, vsphere.ImportTemplatesOptions{
+		KubernetesVersions: []anywherev1.KubernetesVersion{anywherev1.Kube131},
+		OSFamilies:         []anywherev1.OSFamily{anywherev1.Bottlerocket},
+		OVADir:             "ovas",
+	})
+	tt.Expect(err).To(MatchError(ContainSubstring("import failed")))
+}
+
+func TestTemplateManagerImportTemplatesMissingOVAInDir(t *testing.T) {
+	tt := newTemplateManagerTest(t)
+
+	_, err := tt.manager.ImportTemplates(tt.ctx, tt.bundles, vsphere.ImportTemplatesOptions{
+		KubernetesVersions: []anywherev1.KubernetesVersion{anywherev1.Kube131},
+		OSFamilies:         []anywherev1.OSFamily{anywherev1.Bottlerocket},
+		OVADir:             t.TempDir(),
+	})
+	tt.Expect(err).To(MatchError(ContainSubstring("reading ova from directory")))
+}
+
+func TestTemplateManagerImportTemplatesUnsupportedOSFamily(t *testing.T) {
+	tt := newTemplateManagerTest(t)
+
+	_, err := tt.manager.ImportTemplates(tt.ctx, tt.bundles, vsphere.ImportTemplatesOptions{
+		KubernetesVersions: []anywherev1.KubernetesVersion{anywherev1.Kube131},
+		OSFamilies:         []anywherev1.OSFamily{anywherev1.Ubuntu},
+	})
+	tt.Expect(err).To(MatchError(ContainSubstring("can not import ova for osFamily: ubuntu")))
+}
+
+func TestTemplateManagerImportTemplatesUnsupportedKubernetesVersion(t *testing.T) {
+	tt := newTemplateManagerTest(t)
+
+	_, err := tt.manager.ImportTemplates(tt.ctx, tt.bundles, vsphere.ImportTemplatesOptions{
+		KubernetesVersions: []anywherev1.KubernetesVersion{anywherev1.Kube130},
+		OSFamilies:         []anywherev1.OSFamily{anywherev1.Bottlerocket},
+	})
+	tt.Expect(err).To(MatchError(ContainSubstring("kubernetes version 1.30 is not supported")))
+}
+
+func TestTemplateManagerUnusedTemplates(t *testing.T) {
+	tt := newTemplateManagerTest(t)
+
+	tt.govc.EXPECT().ListTemplates(tt.ctx, "/SDDC-Datacenter/vm/Templates").Return([]string{
+		"/SDDC-Datacenter/vm/Templates/old-2",
+		"/SDDC-Datacenter/vm/Templates/in-use",
+		"/SDDC-Datacenter/vm/Templates/old-1",
+	}, nil)
+
+	unused, err := tt.manager.UnusedTemplates(tt.ctx, []string{"/SDDC-Datacenter/vm/Templates/in-use"})
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(unused).To(Equal([]string{"/SDDC-Datacenter/vm/Templates/old-1", "/SDDC-Datacenter/vm/Templates/old-2"}))
+}
+
+func TestTemplateManagerUnusedTemplatesListError(t *testing.T) {
+	tt := newTemplateManagerTest(t)
+
+	tt.govc.EXPECT().ListTemplates(tt.ctx, "/SDDC-Datacenter/vm/Templates").Return(nil, errors.New("error"))
+
+	_, err := tt.manager.UnusedTemplates(tt.ctx, nil)
+	tt.Expect(err).To(MatchError("error"))
+}
+
+func TestTemplateManagerDeleteTemplates(t *testing.T) {
+	tt := newTemplateManagerTest(t)
+
+	tt.govc.EXPECT().DeleteTemplate(tt.ctx, "*/Resources", "/SDDC-Datacenter/vm/Templates/old-1").Return(nil)
+	tt.govc.EXPECT().DeleteTemplate(tt.ctx, "*/Resources", "/SDDC-Datacenter/vm/Templates/old-2").Return(nil)
+
+	tt.Expect(tt.manager.DeleteTemplates(tt.ctx, []string{"/SDDC-Datacenter/vm/Templates/old-1", "/SDDC-Datacenter/vm/Templates/old-2"})).To(Succeed())
+}
+
+func TestTemplateManagerDeleteTemplatesError(t *testing.T) {
+	tt := newTemplateManagerTest(t)
+
+	tt.govc.EXPECT().DeleteTemplate(tt.ctx, "*/Resources", "/SDDC-Datacenter/vm/Templates/old").Return(errors.New("error"))
+
+	err := tt.manager.DeleteTemplates(tt.ctx, []string{"/SDDC-Datacenter/vm/Templates/old"})
+	tt.Expect(err).To(MatchError("deleting template /SDDC-Datacenter/vm/Templates/old: error"))
+}
+
+func TestTemplatesInUse(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(anywherev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(vspherev1.AddToScheme(scheme)).To(Succeed())
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&anywherev1.VSphereMachineConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "cp", Namespace: "default"},
+			Spec:       anywherev1.VSphereMachineConfigSpec{Template: "/SDDC-Datacenter/vm/Templates/cp"},
+		},
+		&anywherev1.VSphereMachineConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "md-0", Namespace: "workload"},
+			Spec:       anywherev1.VSphereMachineConfigSpec{Template: "md-0"},
+		},
+		&vspherev1.VSphereMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "cp-1", Namespace: "eksa-system"},
+			Spec: vspherev1.VSphereMachineTemplateSpec{
+				Template: vspherev1.VSphereMachineTemplateResource{
+					Spec: vspherev1.VSphereMachineSpec{
+						VirtualMachineCloneSpec: vspherev1.VirtualMachineCloneSpec{Template: "/SDDC-Datacenter/vm/Templates/previous"},
+					},
+				},
+			},
+		},
+		&vspherev1.VSphereMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "cp-1-abcde", Namespace: "eksa-system"},
+			Spec: vspherev1.VSphereMachineSpec{
+				VirtualMachineCloneSpec: vspherev1.VirtualMachineCloneSpec{Template: "/SDDC-Datacenter/vm/Templates/linked-clone-parent"},
+			},
+		},
+	).Build()
+
+	templates, err := vsphere.TemplatesInUse(context.Background(), c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(templates).To(ConsistOf(
+		"/SDDC-Datacenter/vm/Templates/cp",
+		"md-0",
+		"/SDDC-Datacenter/vm/Templates/previous",
+		"/SDDC-Datacenter/vm/Templates/linked-clone-parent",
+	))
+}
+
+func TestTemplatesInUseError(t *testing.T) {
+	g := NewWithT(t)
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+
+	_, err := vsphere.TemplatesInUse(context.Background(), c)
+	g.Expect(err).To(MatchError(ContainSubstring("listing vsphere machine configs")))
+}
+
+func TestTemplatesInUseMachinesError(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(anywherev1.AddToScheme(scheme)).To(Succeed())
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	_, err := vsphere.TemplatesInUse(context.Background(), c)
+	g.Expect(err).To(MatchError(ContainSubstring("listing vsphere machine templates")))
+}
@@ -9,6 +9,8 @@ import (
 	"net"
 	"path/filepath"
 
+	"golang.org/x/sync/errgroup"
+
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/collection"
 	"github.com/aws/eks-anywhere/pkg/config"
@@ -79,15 +81,15 @@ func (v *Validator) ValidateVCenterConfig(ctx context.Context, datacenterConfig
 		return err
 	}
 
-	if err := v.validateDatacenter(ctx, datacenterConfig.Spec.Datacenter); err != nil {
-		return err
-	}
-
-	if err := v.validateNetwork(ctx, datacenterConfig.Spec.Network); err != nil {
-		return err
-	}
+	var g errgroup.Group
+	g.Go(func() error {
+		return v.validateDatacenter(ctx, datacenterConfig.Spec.Datacenter)
+	})
+	g.Go(func() error {
+		return v.validateNetwork(ctx, datacenterConfig.Spec.Network)
+	})
 
-	return nil
+	return g.Wait()
 }
 
 // ValidateFailureDomains validates the provided list of failure domains.
@@ -142,17 +144,21 @@ func (v *Validator) validateWorkerNodeGroupDomains(vsphereClusterSpec *Spec, pro
 }
 
 func (v *Validator) validateFailureDomainResources(ctx context.Context, vsphereClusterSpec *Spec, failureDomains []anywherev1.FailureDomain) error {
-	for index, fd := range failureDomains {
-		message := fmt.Sprintf("Start failure domain validation for '%s' ", failureDomains[index].Name)
-		logger.Info(message)
-		if err := v.validateNetwork(ctx, fd.Network); err != nil {
-			return err
-		}
+	var g errgroup.Group
+	for _, fd := range failureDomains {
+		g.Go(func() error {
+			logger.Info(fmt.Sprintf("Start failure domain validation for '%s' ", fd.Name))
+			if err := v.validateNetwork(ctx, fd.Network); err != nil {
+				return err
+			}
 
-		if err := v.govc.ValidateFailureDomainConfig(ctx, vsphereClusterSpec.VSphereDatacenter, &fd); err != nil {
-			return err
-		}
+			return v.govc.ValidateFailureDomainConfig(ctx, vsphereClusterSpec.VSphereDatacenter, &fd)
+		})
 	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
 	logger.Info("Finished failure domain validations")
 	return nil
 }
@@ -214,30 +220,57 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		return err
 	}
 
+	// Each machine config is only modified by its own validation, which resolves its paths, so
+	// they can run in parallel. The validations below depend on the resolved paths.
+	var setup errgroup.Group
 	for _, config := range vsphereClusterSpec.VSphereMachineConfigs {
-		var b bool                                                                                             // Temporary until we remove the need to pass a bool pointer
-		err := v.govc.ValidateVCenterSetupMachineConfig(ctx, vsphereClusterSpec.VSphereDatacenter, config, &b) // TODO: remove side effects from this implementation or directly move it to set defaults (pointer to bool is not needed)
-		if err != nil {
-			return fmt.Errorf("validating vCenter setup for VSphereMachineConfig %v: %v", config.Name, err)
-		}
+		setup.Go(func() error {
+			var b bool                                                                                             // Temporary until we remove the need to pass a bool pointer
+			err := v.govc.ValidateVCenterSetupMachineConfig(ctx, vsphereClusterSpec.VSphereDatacenter, config, &b) // TODO: remove side effects from this implementation or directly move it to set defaults (pointer to bool is not needed)
+			if err != nil {
+				return fmt.Errorf("validating vCenter setup for VSphereMachineConfig %v: %v", config.Name, err)
+			}
+			return nil
+		})
 	}
-
-	if err := v.validateTemplates(ctx, vsphereClusterSpec); err != nil {
+	if err := setup.Wait(); err != nil {
 		return err
 	}
 
-	if err := v.validateMachineConfigTagsExist(ctx, vsphereClusterSpec.machineConfigs()); err != nil {
-		return err
-	}
-
-	logger.MarkPass("Control plane and Workload templates validated")
-
+	var g errgroup.Group
+	g.Go(func() error {
+		if err := v.validateTemplates(ctx, vsphereClusterSpec); err != nil {
+			return err
+		}
+		logger.MarkPass("Control plane and Workload templates validated")
+		return nil
+	})
+	g.Go(func() error {
+		return v.validateMachineConfigTagsExist(ctx, vsphereClusterSpec.machineConfigs())
+	})
 	for _, mc := range vsphereClusterSpec.VSphereMachineConfigs {
-		if mc.OSFamily() == anywherev1.Bottlerocket {
+		if mc.OSFamily() != anywherev1.Bottlerocket {
+			continue
+		}
+		g.Go(func() error {
 			if err := v.validateBRHardDiskSize(ctx, vsphereClusterSpec, mc); err != nil {
 				return fmt.Errorf("failed validating BR Hard Disk size: %v", err)
 			}
+			return nil
+		})
+	}
+	g.Go(func() error {
+		if err := v.validateAntiAffinityHosts(ctx, vsphereClusterSpec); err != nil {
+			return fmt.Errorf("validating anti-affinity: %v", err)
 		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	if err := v.validateIPPools(vsphereClusterSpec); err != nil {
+		return fmt.Errorf("validating ip pools: %v", err)
 	}
 
 	return nil
@@ -274,20 +307,21 @@ func (v *Validator) validateTemplates(ctx context.Context, spec *Spec) error {
 		)
 	}
 
+	var g errgroup.Group
 	for template, requiredTags := range tagsForTemplates {
-		datacenter := spec.VSphereDatacenter.Spec.Datacenter
+		g.Go(func() error {
+			datacenter := spec.VSphereDatacenter.Spec.Datacenter
 
-		templatePath, err := v.getTemplatePath(ctx, datacenter, template)
-		if err != nil {
-			return err
-		}
+			templatePath, err := v.getTemplatePath(ctx, datacenter, template)
+			if err != nil {
+				return err
+			}
 
-		if err := v.validateTemplateTags(ctx, templatePath, requiredTags); err != nil {
-			return err
-		}
+			return v.validateTemplateTags(ctx, templatePath, requiredTags)
+		})
 	}
 
-	return nil
+	return g.Wait()
 }
 
 func (v *Validator) getTemplatePath(ctx context.Context, datacenter, templatePath string) (string, error) {
@@ -12,11 +12,14 @@ import (
 	"github.com/Masterminds/sprig"
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	controlplanev1beta2 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1beta1 "sigs.k8s.io/cluster-api/api/ipam/v1beta1"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
@@ -70,6 +73,7 @@ var defaultFailureDomainConfig string
 var (
 	eksaVSphereDatacenterResourceType = fmt.Sprintf("vspheredatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
 	eksaVSphereMachineResourceType    = fmt.Sprintf("vspheremachineconfigs.%s", v1alpha1.GroupVersion.Group)
+	ipAddressResourceType             = fmt.Sprintf("ipaddresses.%s", ipamv1beta1.GroupVersion.Group)
 )
 
 var requiredEnvs = []string{vSphereUsernameKey, vSpherePasswordKey, expClusterResourceSetKey}
@@ -128,6 +132,13 @@ type ProviderGovcClient interface {
 	SetGroupRoleOnObject(ctx context.Context, principal, role, object, domain string) error
 	GetHardDiskSize(ctx context.Context, vm, datacenter string) (map[string]float64, error)
 	GetResourcePoolInfo(ctx context.Context, datacenter, resourcepool string, args ...string) (map[string]int, error)
+	GetResourcePoolCPUThreads(ctx context.Context, datacenter, resourcePool string) (int, error)
+	GetResourcePoolHostCount(ctx context.Context, datacenter, resourcePool string) (int, error)
+	ApplyAntiAffinityRule(ctx context.Context, datacenter, resourcePool string, rule executables.AntiAffinityRule) error
+	ListDRSRules(ctx context.Context, datacenter, resourcePool string) ([]string, error)
+	RemoveDRSRule(ctx context.Context, datacenter, resourcePool, name string) error
+	ListDRSGroups(ctx context.Context, datacenter, resourcePool string) ([]string, error)
+	RemoveDRSGroup(ctx context.Context, datacenter, resourcePool, name string) error
 }
 
 type ProviderKubectlClient interface {
@@ -149,6 +160,7 @@ type ProviderKubectlClient interface {
 	DeleteEksaDatacenterConfig(ctx context.Context, vsphereDatacenterResourceType, vsphereDatacenterConfigName, kubeconfigFile, namespace string) error
 	DeleteEksaMachineConfig(ctx context.Context, vsphereMachineResourceType, vsphereMachineConfigName, kubeconfigFile, namespace string) error
 	ApplyTolerationsFromTaintsToDaemonSet(ctx context.Context, oldTaints, newTaints []corev1.Taint, dsName, kubeconfigFile string) error
+	Get(ctx context.Context, resourceType, kubeconfig string, obj runtime.Object, opts ...kubernetes.KubectlGetOption) error
 }
 
 // IPValidator is an interface that defines methods to validate the control plane IP.
@@ -317,6 +329,11 @@ func (p *vsphereProvider) SetupAndValidateCreateCluster(ctx context.Context, clu
 	if err := p.validateMemoryUsage(ctx, vSphereClusterSpec, nil); err != nil {
 		return fmt.Errorf("validating vsphere machine configs resource pool memory usage: %v", err)
 	}
+	if !p.skippedValidations[validations.VSphereCapacity] {
+		if err := p.validateCapacity(ctx, vSphereClusterSpec, nil); err != nil {
+			return fmt.Errorf("validating vsphere capacity: %v, use --skip-validations=%s to skip this validation", err, validations.VSphereCapacity)
+		}
+	}
 	if err := p.generateSSHKeysIfNotSet(clusterSpec.VSphereMachineConfigs); err != nil {
 		return fmt.Errorf("failed setup and validations: %v", err)
 	}
@@ -339,6 +356,9 @@ func (p *vsphereProvider) SetupAndValidateCreateCluster(ctx context.Context, clu
 		if len(existingDatacenter) > 0 {
 			return fmt.Errorf("VSphereDatacenter %s already exists", clusterSpec.VSphereDatacenter.Name)
 		}
+		if err := p.validateIPPoolAllocations(ctx, vSphereClusterSpec, clusterSpec.ManagementCluster.KubeconfigFile); err != nil {
+			return err
+		}
 		for _, identityProviderRef := range clusterSpec.Cluster.Spec.IdentityProviderRefs {
 			if identityProviderRef.Kind == v1alpha1.OIDCConfigKind {
 				clusterSpec.OIDCConfig.SetManagedBy(p.clusterConfig.ManagedBy())
@@ -407,6 +427,12 @@ func (p *vsphereProvider) SetupAndValidateUpgradeCluster(ctx context.Context, cl
 		return fmt.Errorf("validating vsphere machine configs resource pool memory usage: %v", err)
 	}
 
+	if !p.skippedValidations[validations.VSphereCapacity] {
+		if err := p.validateCapacity(ctx, vSphereClusterSpec, cluster); err != nil {
+			return fmt.Errorf("validating vsphere capacity: %v, use --skip-validations=%s to skip this validation", err, validations.VSphereCapacity)
+		}
+	}
+
 	if !p.skippedValidations[validations.VSphereUserPriv] {
 		if err := p.validator.validateVsphereUserPrivs(ctx, vSphereClusterSpec); err != nil {
 			return fmt.Errorf("validating vsphere user privileges: %w, please refer to %s for required permissions or use -v 3 for full missing permissions", err, vSpherePermissionDoc)
@@ -417,7 +443,23 @@ func (p *vsphereProvider) SetupAndValidateUpgradeCluster(ctx context.Context, cl
 	if err != nil {
 		return fmt.Errorf("failed validate machineconfig uniqueness: %v", err)
 	}
-	return nil
+
+	return p.validateIPPoolAllocations(ctx, vSphereClusterSpec, cluster.KubeconfigFile)
+}
+
+// validateIPPoolAllocations checks the VSphereIPPools of the cluster have enough addresses left by the
+// other clusters of the management cluster.
+func (p *vsphereProvider) validateIPPoolAllocations(ctx context.Context, spec *Spec, kubeconfigFile string) error {
+	if len(spec.VSphereIPPools) == 0 {
+		return nil
+	}
+
+	addresses := &ipamv1beta1.IPAddressList{}
+	if err := p.providerKubectlClient.Get(ctx, ipAddressResourceType, kubeconfigFile, addresses, &kubernetes.KubectlGetOptions{Namespace: constants.EksaSystemNamespace}); err != nil {
+		return fmt.Errorf("listing ipaddresses: %v", err)
+	}
+
+	return p.validator.ValidateIPPoolAllocations(spec, addresses.Items)
 }
 
 // SetupAndValidateUpgradeManagementComponents performs necessary setup for upgrade management components operation.
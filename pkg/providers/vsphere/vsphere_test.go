@@ -33,6 +33,7 @@ import (
 	govmomi_mocks "github.com/aws/eks-anywhere/pkg/govmomi/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -181,6 +182,34 @@ func (pc *DummyProviderGovcClient) GetResourcePoolInfo(ctx context.Context, data
 	return map[string]int{"Memory_Available": -1}, nil
 }
 
+func (pc *DummyProviderGovcClient) GetResourcePoolCPUThreads(ctx context.Context, datacenter, resourcePool string) (int, error) {
+	return 0, nil
+}
+
+func (pc *DummyProviderGovcClient) GetResourcePoolHostCount(ctx context.Context, datacenter, resourcePool string) (int, error) {
+	return 0, nil
+}
+
+func (pc *DummyProviderGovcClient) ApplyAntiAffinityRule(ctx context.Context, datacenter, resourcePool string, rule executables.AntiAffinityRule) error {
+	return nil
+}
+
+func (pc *DummyProviderGovcClient) ListDRSRules(ctx context.Context, datacenter, resourcePool string) ([]string, error) {
+	return nil, nil
+}
+
+func (pc *DummyProviderGovcClient) RemoveDRSRule(ctx context.Context, datacenter, resourcePool, name string) error {
+	return nil
+}
+
+func (pc *DummyProviderGovcClient) ListDRSGroups(ctx context.Context, datacenter, resourcePool string) ([]string, error) {
+	return nil, nil
+}
+
+func (pc *DummyProviderGovcClient) RemoveDRSGroup(ctx context.Context, datacenter, resourcePool, name string) error {
+	return nil
+}
+
 func (pc *DummyProviderGovcClient) GetTags(ctx context.Context, path string) (tags []string, err error) {
 	return []string{eksd119ReleaseTag, eksd121ReleaseTag, eksd129ReleaseTag, pc.osTag}, nil
 }
@@ -562,7 +591,8 @@ func newProvider(t *testing.T, datacenterConfig *v1alpha1.VSphereDatacenterConfi
 		test.FakeNow,
 		false,
 		v,
-		map[string]bool{},
+		// The capacity validation is covered by its own tests, see capacity_test.go.
+		map[string]bool{validations.VSphereCapacity: true},
 	)
 }
 
@@ -1975,6 +2005,8 @@ func TestSetupAndValidateCreateClusterTemplateMissingTags(t *testing.T) {
 
 	tt.govc.EXPECT().SearchTemplate(tt.ctx, tt.datacenterConfig.Spec.Datacenter, controlPlaneMachineConfig.Spec.Template).Return(controlPlaneMachineConfig.Spec.Template, nil)
 	tt.govc.EXPECT().GetTags(tt.ctx, controlPlaneMachineConfig.Spec.Template).Return(nil, nil)
+	// The machine config tags are validated in parallel with the templates.
+	tt.govc.EXPECT().ListTags(tt.ctx)
 
 	err := tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)
 
@@ -1997,6 +2029,8 @@ func TestSetupAndValidateCreateClusterErrorGettingTags(t *testing.T) {
 	}
 	tt.govc.EXPECT().SearchTemplate(tt.ctx, tt.datacenterConfig.Spec.Datacenter, controlPlaneMachineConfig.Spec.Template).Return(controlPlaneMachineConfig.Spec.Template, nil)
 	tt.govc.EXPECT().GetTags(tt.ctx, controlPlaneMachineConfig.Spec.Template).Return(nil, errors.New(errorMessage))
+	// The machine config tags are validated in parallel with the templates.
+	tt.govc.EXPECT().ListTags(tt.ctx)
 
 	err := tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)
 
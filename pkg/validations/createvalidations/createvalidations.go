@@ -7,6 +7,8 @@ import (
 // SkippableValidations represents all the validations we offer for users to skip.
 var SkippableValidations = []string{
 	validations.VSphereUserPriv,
+	validations.TinkerbellBMCHealth,
+	validations.VSphereCapacity,
 }
 
 func New(opts *validations.Opts) *CreateValidations {
@@ -10,6 +10,13 @@ const (
 	PDB             = "pod-disruption"
 	VSphereUserPriv = "vsphere-user-privilege"
 	EksaVersionSkew = "eksa-version-skew"
+
+	// TinkerbellBMCHealth is the name of the Tinkerbell hardware BMC health checks run before creating a cluster.
+	TinkerbellBMCHealth = "tinkerbell-bmc-health"
+
+	// VSphereCapacity is the name of the vSphere capacity checks comparing the requested machines with the free
+	// resources of the target datastores and resource pools.
+	VSphereCapacity = "vsphere-capacity"
 )
 
 // ValidSkippableValidationsMap returns a map for all valid skippable validations as keys, defaulting values to false.
@@ -32,6 +32,7 @@ func TestValidateSkippableValidation(t *testing.T) {
 				validations.PDB:             true,
 				validations.VSphereUserPriv: false,
 				validations.EksaVersionSkew: false,
+				validations.VSphereCapacity: false,
 			},
 			wantErr:              nil,
 			skippedValidations:   []string{validations.PDB},
@@ -40,7 +41,9 @@ func TestValidateSkippableValidation(t *testing.T) {
 		{
 			name: "valid create validation param",
 			want: map[string]bool{
-				validations.VSphereUserPriv: true,
+				validations.VSphereUserPriv:     true,
+				validations.TinkerbellBMCHealth: false,
+				validations.VSphereCapacity:     false,
 			},
 			wantErr:              nil,
 			skippedValidations:   []string{validations.VSphereUserPriv},
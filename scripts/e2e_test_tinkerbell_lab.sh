@@ -0,0 +1,58 @@
+#!/usr/bin/env bash
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Runs Tinkerbell e2e tests against a virtual lab created on this host and deletes the lab afterwards.
+# The lab sets the hardware and network env vars of the e2e framework. The other Tinkerbell env vars it
+# requires, like T_TINKERBELL_IMAGE_UBUNTU_2204_1_33, T_TINKERBELL_SSH_AUTHORIZED_KEY and
+# T_TINKERBELL_HOOK_ISO_URL, must be set.
+
+set -x
+set -o errexit
+set -o nounset
+set -o pipefail
+
+REPO_ROOT=$(git rev-parse --show-toplevel)
+BIN_FOLDER=$REPO_ROOT/bin
+TEST_REGEX="${1:-TestTinkerbellKubernetes133Ubuntu2204SimpleFlow}"
+
+LAB_NAME="${TINKERBELL_LAB_NAME:-eksa-e2e-lab}"
+# The lab network is a /24 with the host on .1, the cluster endpoints on .2 to .10 and the VMs from .11.
+LAB_NETWORK="${TINKERBELL_LAB_NETWORK:-10.80.0}"
+LAB_CONTROL_PLANE_COUNT="${TINKERBELL_LAB_CONTROL_PLANE_COUNT:-1}"
+LAB_WORKER_COUNT="${TINKERBELL_LAB_WORKER_COUNT:-1}"
+LAB_BMC_PASSWORD="${TINKERBELL_LAB_BMC_PASSWORD:-$(openssl rand -hex 16)}"
+LAB_HARDWARE_CSV="$BIN_FOLDER/$LAB_NAME-hardware.csv"
+
+delete_lab() {
+    $BIN_FOLDER/eks-a-tool tinkerbell lab delete --name $LAB_NAME
+}
+
+trap delete_lab EXIT
+
+$BIN_FOLDER/eks-a-tool tinkerbell lab create \
+    --name $LAB_NAME \
+    --cidr $LAB_NETWORK.0/24 \
+    --control-plane-count $LAB_CONTROL_PLANE_COUNT \
+    --worker-count $LAB_WORKER_COUNT \
+    --bmc-password $LAB_BMC_PASSWORD \
+    --hardware-csv $LAB_HARDWARE_CSV
+
+export T_TINKERBELL_INVENTORY_CSV=$LAB_HARDWARE_CSV
+export T_TINKERBELL_CP_NETWORK_CIDR=$LAB_NETWORK.0/24
+export T_TINKERBELL_BOOTSTRAP_IP=$LAB_NETWORK.1
+# The control plane endpoint and the Tinkerbell IP of each test are taken from the addresses left for them.
+export T_CLUSTER_IP_POOL=$(seq -s, -f "$LAB_NETWORK.%g" 2 10)
+
+$BIN_FOLDER/e2e.test -test.v -test.timeout 4h -test.run "$TEST_REGEX"